@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/services"
+)
+
+// command is an administrative subcommand, run against the configured database instead of
+// starting the server
+type command struct {
+	// name is the words that select the command, such as "config export"
+	name  string
+	usage string
+	run   func(env *commandEnv, args []string) error
+}
+
+// commands are the subcommands radarr accepts before its flags
+var commands = []command{
+	{name: "apikey reset", usage: "Generate a new API key and save it to the config file", run: runAPIKeyReset},
+	{name: "user set-password", usage: "Set the login password, read from stdin", run: runUserSetPassword},
+	{name: "db migrate", usage: "Apply pending database migrations", run: runDBMigrate},
+	{name: "db verify", usage: "Check the schema and the data's integrity", run: runDBVerify},
+	{name: "task run", usage: "Run a task and wait for it to finish", run: runTask},
+	{name: "config validate", usage: "Check the config file loads", run: runConfigValidate},
+	{name: "config export", usage: "Export settings as a YAML manifest", run: runConfigExport},
+	{name: "config apply", usage: "Apply a YAML manifest of settings", run: runConfigApply},
+}
+
+// commandEnv holds the flags every subcommand accepts and opens the services they work with
+type commandEnv struct {
+	flags      *flag.FlagSet
+	configPath string
+	dataDir    string
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+
+	cfg       *config.Config
+	logger    *logger.Logger
+	db        *database.Database
+	container *services.Container
+}
+
+// isCommand reports whether the arguments start with a subcommand rather than a flag
+func isCommand(args []string) bool {
+	return len(args) > 0 && !strings.HasPrefix(args[0], "-")
+}
+
+// runCommand runs the subcommand the arguments name and returns the process exit code
+func runCommand(args []string) int {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != cmd.name {
+			continue
+		}
+
+		env := &commandEnv{
+			flags:  flag.NewFlagSet("radarr "+cmd.name, flag.ContinueOnError),
+			stdin:  os.Stdin,
+			stdout: os.Stdout,
+			stderr: os.Stderr,
+		}
+		env.flags.StringVar(&env.configPath, "config", "config.yaml", "path to configuration file")
+		env.flags.StringVar(&env.dataDir, "data", "./data", "path to data directory")
+		err := cmd.run(env, args[len(words):])
+		env.close()
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "radarr %s: %v\n", cmd.name, err)
+			return 1
+		}
+		return 0
+	}
+
+	status := 2
+	if args[0] == "help" {
+		status = 0
+	} else {
+		fmt.Fprintf(os.Stderr, "Unknown command %q.\n", strings.Join(args, " "))
+	}
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-18s %s\n", cmd.name, cmd.usage)
+	}
+	return status
+}
+
+// load reads the configuration and creates the logger. Logs go to stderr so they don't mix with
+// a command's output.
+func (e *commandEnv) load() error {
+	cfg, err := config.Load(e.configPath, e.dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.Log.Output = "stderr"
+	cfg.Log.File.Enabled = false
+	if cfg.Log.Level == "" || cfg.Log.Level == "info" || cfg.Log.Level == "debug" {
+		cfg.Log.Level = "warn"
+	}
+	e.cfg = cfg
+	e.logger = logger.New(cfg.Log)
+	return nil
+}
+
+// openDatabase loads the configuration and connects to the database without migrating it
+func (e *commandEnv) openDatabase() error {
+	if err := e.load(); err != nil {
+		return err
+	}
+	db, err := database.New(&e.cfg.Database, e.logger.Component(dbLogComponent))
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	e.db = db
+	return nil
+}
+
+// open connects to the migrated database and creates the services
+func (e *commandEnv) open() error {
+	if err := e.openDatabase(); err != nil {
+		return err
+	}
+	if err := database.Migrate(e.db, e.logger.Component(dbLogComponent)); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	e.container = services.NewContainer(e.db, e.cfg, e.logger)
+	return nil
+}
+
+// close stops the services' background work and closes the database
+func (e *commandEnv) close() {
+	if e.container != nil {
+		e.container.TaskService.Shutdown()
+		e.container.LeaderElector.Stop()
+	}
+	if e.db != nil {
+		if err := e.db.Close(); err != nil {
+			e.logger.Error("Failed to close database", "error", err)
+		}
+	}
+	if e.logger != nil {
+		e.logger.Close()
+	}
+}
+
+// runConfigExport writes the settings manifest to stdout or a file
+func runConfigExport(env *commandEnv, args []string) error {
+	output := env.flags.String("output", "", "file to write the manifest to (default stdout)")
+	includeSecrets := env.flags.Bool("include-secrets", false, "include passwords and API keys")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if err := env.open(); err != nil {
+		return err
+	}
+
+	manifest, err := env.container.ConfigManifestService.Export(*includeSecrets)
+	if err != nil {
+		return err
+	}
+	data, err := services.EncodeConfigManifest(manifest)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = env.stdout.Write(data)
+		return err
+	}
+	// Manifests with secrets must not be readable by other users
+	return os.WriteFile(*output, data, 0o600)
+}
+
+// runConfigApply applies a settings manifest read from a file, or from stdin for "-", and prints
+// the changes as JSON
+func runConfigApply(env *commandEnv, args []string) error {
+	file := env.flags.String("file", "", "manifest to apply, - for stdin")
+	dryRun := env.flags.Bool("dry-run", false, "report the changes without making them")
+	prune := env.flags.Bool("prune", false, "delete settings of the manifest's sections that it doesn't list")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" && env.flags.NArg() == 1 {
+		*file = env.flags.Arg(0)
+	}
+	if *file == "" {
+		return errors.New("a manifest file is required: -file path")
+	}
+
+	var data []byte
+	var err error
+	if *file == "-" {
+		data, err = io.ReadAll(env.stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	manifest, err := services.DecodeConfigManifest(data)
+	if err != nil {
+		return err
+	}
+
+	if err := env.open(); err != nil {
+		return err
+	}
+	result, err := env.container.ConfigManifestService.Apply(manifest,
+		services.ConfigManifestApplyOptions{DryRun: *dryRun, Prune: *prune})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(env.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/services"
+)
+
+// runAPIKeyReset replaces the API key, or the admin API key, in the config file with a new one
+// and prints it. Running instances keep the old key until they restart.
+func runAPIKeyReset(env *commandEnv, args []string) error {
+	admin := env.flags.Bool("admin", false, "reset the admin API key instead")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if err := env.load(); err != nil {
+		return err
+	}
+
+	key, err := config.GenerateAPIKey()
+	if err != nil {
+		return err
+	}
+	setting := "auth.api_key"
+	if *admin {
+		setting = "auth.admin_api_key"
+	}
+	if err := config.SetFileValue(env.configPath, setting, key); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(env.stdout, key)
+	fmt.Fprintf(env.stderr, "Saved the new key as %s in %s; restart Radarr to use it\n", setting, env.configPath)
+	return nil
+}
+
+// runUserSetPassword sets the login credentials. The password is read from the first line of
+// stdin rather than a flag, so it doesn't end up in shell history or the process list.
+func runUserSetPassword(env *commandEnv, args []string) error {
+	username := env.flags.String("username", "", "username to log in with (default the current username)")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+
+	if file, ok := env.stdin.(*os.File); ok {
+		if info, err := file.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			fmt.Fprint(env.stderr, "New password: ")
+		}
+	}
+	password, err := bufio.NewReader(env.stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return errors.New("failed to read the password from stdin")
+	}
+	password = strings.TrimRight(password, "\r\n")
+
+	if err := env.open(); err != nil {
+		return err
+	}
+	if *username == "" {
+		host, err := env.container.ConfigService.GetHostConfig()
+		if err != nil {
+			return err
+		}
+		if host.Username == "" {
+			return errors.New("no username is set yet: -username name")
+		}
+		*username = host.Username
+	}
+	if err := env.container.ConfigService.SetCredentials(*username, password); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Password set for %s\n", *username)
+	return nil
+}
+
+// runDBMigrate applies pending migrations and prints the schema version
+func runDBMigrate(env *commandEnv, args []string) error {
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if err := env.openDatabase(); err != nil {
+		return err
+	}
+	if err := database.Migrate(env.db, env.logger.Component(dbLogComponent)); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+
+	version, _, err := env.db.MigrationVersion(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Database schema is at migration %d\n", version)
+	return nil
+}
+
+// runDBVerify checks the schema isn't left dirty by a failed migration and runs the data
+// integrity checks, failing when either finds a problem. It doesn't migrate the database.
+func runDBVerify(env *commandEnv, args []string) error {
+	repair := env.flags.Bool("repair", false, "fix the problems that can be fixed")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if err := env.openDatabase(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	version, dirty, err := env.db.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed part way and left the schema dirty; repair it by hand first", version)
+	}
+	fmt.Fprintf(env.stdout, "Schema: migration %d\n", version)
+
+	findings, err := services.NewIntegrityService(env.db, false, env.logger).Run(ctx, *repair)
+	if err != nil {
+		return err
+	}
+	unrepaired := 0
+	for _, finding := range findings {
+		fmt.Fprintf(env.stdout, "%s: %s", finding.Check, finding.Message)
+		if finding.Repaired > 0 {
+			fmt.Fprintf(env.stdout, " (%d repaired)", finding.Repaired)
+		}
+		fmt.Fprintln(env.stdout)
+		if finding.Repaired < finding.Count {
+			unrepaired++
+		}
+	}
+	if unrepaired > 0 {
+		return fmt.Errorf("%d integrity checks found problems", unrepaired)
+	}
+	fmt.Fprintln(env.stdout, "Data integrity checks passed")
+	return nil
+}
+
+// runTask queues a task in this process and waits for it to finish. Interrupting the command
+// cancels the task.
+func runTask(env *commandEnv, args []string) error {
+	body := env.flags.String("body", "", `task parameters as JSON, such as {"movieId": 1}`)
+	timeout := env.flags.Duration("timeout", 0, "give up waiting after this long (default no limit)")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if env.flags.NArg() != 1 {
+		return errors.New("a task name is required: task run [flags] <name>")
+	}
+	name := env.flags.Arg(0)
+
+	parameters := models.JSONField{}
+	if *body != "" {
+		if err := json.Unmarshal([]byte(*body), &parameters); err != nil {
+			return fmt.Errorf("invalid -body: %w", err)
+		}
+	}
+
+	if err := env.open(); err != nil {
+		return err
+	}
+	tasks := env.container.TaskService
+	known := false
+	for _, handler := range tasks.HandlerNames() {
+		known = known || handler == name
+	}
+	if !known {
+		return fmt.Errorf("unknown task %q; tasks: %s", name, strings.Join(tasks.HandlerNames(), ", "))
+	}
+	if env.container.ReadOnly.Enabled() {
+		return errors.New("tasks are paused while the instance is in read-only mode")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	queued, err := tasks.QueueTask(name, name, parameters, "normal")
+	if err != nil {
+		return err
+	}
+	task, err := tasks.WaitForTask(ctx, queued.ID)
+	if err != nil {
+		if cancelErr := tasks.CancelTask(queued.ID); cancelErr != nil {
+			env.logger.Error("Failed to cancel task", "taskId", queued.ID, "error", cancelErr)
+		}
+		return fmt.Errorf("stopped waiting for task %d: %w", queued.ID, err)
+	}
+
+	fmt.Fprintf(env.stdout, "Task %d %s in %s\n", task.ID, task.Status, time.Since(started).Round(time.Millisecond))
+	if task.Status != "completed" {
+		return fmt.Errorf("task %s: %s", task.Status, task.ErrorMessage)
+	}
+	return nil
+}
+
+// runConfigValidate loads the config file, which validates it, and optionally connects to the
+// database it configures
+func runConfigValidate(env *commandEnv, args []string) error {
+	checkDatabase := env.flags.Bool("check-database", false, "also connect to the configured database")
+	if err := env.flags.Parse(args); err != nil {
+		return err
+	}
+	if _, err := os.Stat(env.configPath); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if *checkDatabase {
+		if err := env.openDatabase(); err != nil {
+			return err
+		}
+	} else if err := env.load(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "%s is valid\n", env.configPath)
+	return nil
+}
@@ -2,9 +2,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/api"
 	"github.com/radarr/radarr-go/internal/config"
@@ -13,6 +20,15 @@ import (
 	"github.com/radarr/radarr-go/internal/services"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish on shutdown or restart
+const shutdownTimeout = 30 * time.Second
+
+// Log components whose level can be changed at runtime; main's logger variable shadows the package
+const (
+	apiLogComponent = logger.ComponentAPI
+	dbLogComponent  = logger.ComponentDB
+)
+
 // Build information - set by ldflags during build
 var (
 	version = "dev"
@@ -21,6 +37,11 @@ var (
 )
 
 func main() {
+	// Administrative subcommands, such as "radarr config export", run instead of the server
+	if isCommand(os.Args[1:]) {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	var configPath = flag.String("config", "config.yaml", "path to configuration file")
 	var dataDir = flag.String("data", "./data", "path to data directory")
 	var showVersion = flag.Bool("version", false, "show version information and exit")
@@ -48,9 +69,10 @@ func main() {
 
 	// Initialize logger
 	logger := logger.New(cfg.Log)
+	defer logger.Close()
 
 	// Initialize database
-	db, err := database.New(&cfg.Database, logger)
+	db, err := database.New(&cfg.Database, logger.Component(dbLogComponent))
 	if err != nil {
 		logger.Fatal("Failed to initialize database", "error", err)
 	}
@@ -61,15 +83,24 @@ func main() {
 	}()
 
 	// Run migrations
-	if err := database.Migrate(db, logger); err != nil {
+	if err := database.Migrate(db, logger.Component(dbLogComponent)); err != nil {
 		logger.Fatal("Failed to run database migrations", "error", err)
 	}
 
 	// Initialize services
 	serviceContainer := services.NewContainer(db, cfg, logger)
+	serviceContainer.Readiness.Complete(services.ReadinessMigrations)
+
+	// Sample performance metrics for the health dashboard
+	serviceContainer.PerformanceMonitor.Start(context.Background())
+
+	// Deliver notification events recorded with grabs and imports, including any left over from
+	// before a crash
+	serviceContainer.OutboxDispatcher.Start(context.Background())
 
 	// Initialize and start API server
-	server := api.NewServer(cfg, serviceContainer, logger)
+	server := api.NewServer(cfg, serviceContainer, logger.Component(apiLogComponent))
+	server.SetBuildInfo(api.BuildInfo{Version: version, Commit: commit, Date: date})
 
 	// Log build information
 	logger.Info("Starting Radarr Go",
@@ -78,7 +109,62 @@ func main() {
 		"built", date,
 		"port", cfg.Server.Port)
 
-	if err := server.Start(); err != nil {
-		logger.Fatal("Failed to start server", "error", err)
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- server.Start()
+	}()
+
+	// Catch records orphaned by manual edits before they cause failures later; /ready reports
+	// false until this and the scheduler warm-up finish
+	go func() {
+		serviceContainer.WarmUp(context.Background())
+		logger.Info("Radarr Go is ready")
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	action := api.LifecycleShutdown
+	select {
+	case err := <-serverErrors:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Failed to start server", "error", err)
+		}
+		return
+	case sig := <-signals:
+		logger.Info("Received signal, shutting down", "signal", sig.String())
+	case action = <-server.LifecycleRequests():
+		logger.Info("Received lifecycle request", "action", action)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// Stop routing new traffic here, then let in-flight requests, imports and tasks finish. Tasks
+	// still running at the deadline are checkpointed and resume on the next start.
+	serviceContainer.Readiness.BeginShutdown()
+	if err := server.Stop(ctx); err != nil {
+		logger.Error("Failed to stop server gracefully", "error", err)
+	}
+	if err := serviceContainer.ImportService.Drain(ctx); err != nil {
+		logger.Error("Failed to finish imports before shutdown", "error", err)
+	}
+	serviceContainer.TaskService.Drain(ctx)
+	serviceContainer.PerformanceMonitor.Stop()
+	serviceContainer.OutboxDispatcher.Stop()
+	serviceContainer.TaskService.Shutdown()
+	serviceContainer.LeaderElector.Stop()
+
+	if action == api.LifecycleRestart {
+		// Exec does not run deferred calls, so release the database first
+		if err := db.Close(); err != nil {
+			logger.Error("Failed to close database", "error", err)
+		}
+
+		logger.Info("Restarting Radarr Go")
+		_ = logger.Sync() //nolint:errcheck // Flush before exec replaces the process
+		if err := restartProcess(); err != nil {
+			logger.Fatal("Failed to restart", "error", err)
+		}
 	}
 }
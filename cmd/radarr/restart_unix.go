@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// restartProcess replaces the running process with a fresh copy of the same executable,
+// keeping the original arguments and environment
+func restartProcess() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	//#nosec G204 // Re-executes the current binary with its own arguments
+	return syscall.Exec(executable, os.Args, os.Environ())
+}
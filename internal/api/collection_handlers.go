@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -279,6 +280,104 @@ func (s *Server) handleClearParseCache(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Parse cache cleared successfully"})
 }
 
+// Scene mapping handlers
+
+// handleGetSceneMappings lists manual and remote scene mappings
+func (s *Server) handleGetSceneMappings(c *gin.Context) {
+	mappings, err := s.services.SceneMappingService.GetAll()
+	if err != nil {
+		s.logger.Error("Failed to get scene mappings", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve scene mappings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings)
+}
+
+// handleGetSceneMapping gets a scene mapping by ID
+func (s *Server) handleGetSceneMapping(c *gin.Context) {
+	s.handleGetByID(c, "scene mapping", func(id int) (any, error) {
+		return s.services.SceneMappingService.GetByID(id)
+	})
+}
+
+// handleCreateSceneMapping creates a manual scene mapping
+func (s *Server) handleCreateSceneMapping(c *gin.Context) {
+	var mapping models.SceneMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scene mapping data"})
+		return
+	}
+
+	if err := s.services.SceneMappingService.Create(&mapping); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to create scene mapping", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create scene mapping"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapping)
+}
+
+// handleUpdateSceneMapping updates a scene mapping, making it a manual mapping
+func (s *Server) handleUpdateSceneMapping(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var mapping models.SceneMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scene mapping data"})
+		return
+	}
+
+	mapping.ID = id
+	if err := s.services.SceneMappingService.Update(&mapping); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "scene mapping not found"})
+			return
+		}
+		s.logger.Error("Failed to update scene mapping", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update scene mapping"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+// handleDeleteSceneMapping deletes a scene mapping
+func (s *Server) handleDeleteSceneMapping(c *gin.Context) {
+	s.handleDeleteByID(c, "scene mapping", s.services.SceneMappingService.Delete)
+}
+
+// handleRefreshSceneMappings queues a refresh of the remote scene mappings
+func (s *Server) handleRefreshSceneMappings(c *gin.Context) {
+	task, err := s.services.TaskService.QueueTask(
+		"Refresh Scene Mappings",
+		"RefreshSceneMappings",
+		models.JSONField{},
+		"normal",
+	)
+	if err != nil {
+		s.logger.Error("Failed to queue scene mapping refresh task", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue scene mapping refresh"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
 // Rename handlers
 
 // parseMovieIDsFromQuery parses movie IDs from the movieIds query parameter
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/services"
+)
+
+// configManifestContentType is the media type of configuration manifests
+const configManifestContentType = "application/yaml"
+
+// handleExportConfigManifest exports the instance's settings as a YAML manifest. Secrets are
+// redacted unless includeSecrets=true.
+func (s *Server) handleExportConfigManifest(c *gin.Context) {
+	manifest, err := s.services.ConfigManifestService.Export(c.Query("includeSecrets") == "true")
+	if err != nil {
+		s.logger.Error("Failed to export configuration manifest", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export configuration manifest"})
+		return
+	}
+	data, err := services.EncodeConfigManifest(manifest)
+	if err != nil {
+		s.logger.Error("Failed to encode configuration manifest", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export configuration manifest"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=radarr-config-%d.yaml", time.Now().Unix()))
+	c.Data(http.StatusOK, configManifestContentType, data)
+}
+
+// handleApplyConfigManifest applies a YAML or JSON manifest from the request body. dryRun=true
+// reports the changes without making them; prune=true deletes the settings of the manifest's
+// sections that it doesn't list.
+func (s *Server) handleApplyConfigManifest(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+
+	var validationErr models.ValidationError
+	manifest, err := services.DecodeConfigManifest(body)
+	if err != nil {
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := s.services.ConfigManifestService.Apply(manifest, services.ConfigManifestApplyOptions{
+		DryRun: c.Query("dryRun") == "true",
+		Prune:  c.Query("prune") == "true",
+	})
+	if err != nil {
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to apply configuration manifest", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply configuration manifest: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	runtimepprof "runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestAPIKey returns the API key sent in the X-API-Key header or the apikey query parameter
+func requestAPIKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	return c.Query("apikey")
+}
+
+// keyMatches compares API keys in constant time; an empty expected key never matches
+func keyMatches(provided, expected string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1
+}
+
+// adminKeyMiddleware restricts a route group to requests carrying the admin API key. The routes
+// are refused outright while no admin key is configured.
+func adminKeyMiddleware(adminKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminKey == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Diagnostics are disabled; set auth.admin_api_key to enable them"})
+			c.Abort()
+			return
+		}
+
+		if !keyMatches(requestAPIKey(c), adminKey) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin API key required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// longResponseMiddleware lifts the server write timeout for diagnostics that can take longer than
+// ordinary responses, such as the bundle and full goroutine dumps of a busy instance. CPU profiles
+// and traces extend the deadline themselves by the duration asked for.
+func (s *Server) longResponseMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := liftWriteDeadline(c.Writer); err != nil {
+			s.logger.Warn("Serving diagnostics under the server write timeout", "path", c.FullPath(), "error", err)
+		}
+		c.Next()
+	}
+}
+
+// setupProfilingRoutes exposes net/http/pprof under /debug/pprof for admin keys
+func (s *Server) setupProfilingRoutes() {
+	profiling := s.engine.Group("/debug/pprof", adminKeyMiddleware(s.config.Auth.AdminAPIKey),
+		s.longResponseMiddleware())
+	profiling.GET("/", gin.WrapF(pprof.Index))
+	profiling.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	profiling.GET("/profile", gin.WrapF(pprof.Profile))
+	profiling.GET("/symbol", gin.WrapF(pprof.Symbol))
+	profiling.POST("/symbol", gin.WrapF(pprof.Symbol))
+	profiling.GET("/trace", gin.WrapF(pprof.Trace))
+	profiling.GET("/:profile", func(c *gin.Context) {
+		pprof.Handler(c.Param("profile")).ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// handleDiagnosticsBundle returns a zip with a goroutine dump, heap profile, recent logs, build
+// information and the configuration with secrets redacted, for attaching to bug reports
+func (s *Server) handleDiagnosticsBundle(c *gin.Context) {
+	generated := time.Now().UTC()
+
+	bundle, err := s.buildDiagnosticsBundle(c, generated)
+	if err != nil {
+		s.logger.Errorw("Failed to build diagnostics bundle", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build diagnostics bundle"})
+		return
+	}
+
+	filename := fmt.Sprintf("radarr-diagnostics-%s.zip", generated.Format("20060102-150405"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/zip", bundle)
+}
+
+func (s *Server) buildDiagnosticsBundle(c *gin.Context, generated time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{"system.json", func(w io.Writer) error { return s.writeSystemDiagnostics(c, w, generated) }},
+		{"buildinfo.txt", writeBuildInfo},
+		{"goroutines.txt", func(w io.Writer) error { return runtimepprof.Lookup("goroutine").WriteTo(w, 2) }},
+		{"heap.pprof", func(w io.Writer) error {
+			runtime.GC()
+			return runtimepprof.Lookup("heap").WriteTo(w, 0)
+		}},
+		{"logs.jsonl", s.writeRecentLogs},
+		{"config.json", s.writeRedactedConfig},
+	}
+
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: generated})
+		if err != nil {
+			return nil, err
+		}
+		if err := file.write(w); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *Server) writeSystemDiagnostics(c *gin.Context, w io.Writer, generated time.Time) error {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	system := gin.H{
+		"generatedAt":  generated,
+		"goVersion":    runtime.Version(),
+		"os":           runtime.GOOS,
+		"arch":         runtime.GOARCH,
+		"numCpu":       runtime.NumCPU(),
+		"goroutines":   runtime.NumGoroutine(),
+		"heapAllocMb":  float64(mem.HeapAlloc) / 1024 / 1024,
+		"sysMb":        float64(mem.Sys) / 1024 / 1024,
+		"gcCycles":     mem.NumGC,
+		"databaseType": s.config.Database.Type,
+	}
+	if s.services != nil && s.services.HealthService != nil {
+		if resources, err := s.services.HealthService.GetSystemResources(c.Request.Context()); err == nil {
+			system["resources"] = resources
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(system)
+}
+
+func writeBuildInfo(w io.Writer) error {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		_, err := io.WriteString(w, "build information unavailable\n")
+		return err
+	}
+	_, err := io.WriteString(w, info.String())
+	return err
+}
+
+func (s *Server) writeRecentLogs(w io.Writer) error {
+	entries := s.logger.RecentEntries()
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(w, strings.Join(entries, "\n")+"\n")
+	return err
+}
+
+func (s *Server) writeRedactedConfig(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s.config.Redacted())
+}
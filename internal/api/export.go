@@ -0,0 +1,232 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// exportFlushRows is how many exported records are buffered before they're flushed to the client
+const exportFlushRows = 500
+
+// Export formats, chosen with the format query parameter
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportWriter streams an export as CSV rows under a header row, or as NDJSON with one JSON
+// object per line. Response headers are sent with the first record, so an export that fails
+// before writing anything can still answer with an error status.
+type exportWriter struct {
+	c       *gin.Context
+	name    string
+	format  string
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+	rows    int
+}
+
+// newExportWriter reads the format query parameter and returns a writer for the export called
+// name, or responds with 400 and returns nil for an unknown format
+func newExportWriter(c *gin.Context, name string, columns []string) *exportWriter {
+	format := strings.ToLower(c.DefaultQuery("format", exportFormatCSV))
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or ndjson"})
+		return nil
+	}
+	return &exportWriter{c: c, name: name, format: format, columns: columns}
+}
+
+// start sends the response headers, and the CSV header row
+func (w *exportWriter) start() error {
+	w.started = true
+
+	contentType := "text/csv; charset=utf-8"
+	if w.format == exportFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	w.c.Header("Content-Type", contentType)
+	w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`,
+		w.name, time.Now().UTC().Format("20060102-150405"), w.format))
+	w.c.Status(http.StatusOK)
+
+	// A large export can outlast the server write timeout, which is meant for ordinary responses
+	if err := liftWriteDeadline(w.c.Writer); err != nil {
+		return err
+	}
+
+	if w.format == exportFormatNDJSON {
+		w.json = json.NewEncoder(w.c.Writer)
+		return nil
+	}
+	w.csv = csv.NewWriter(w.c.Writer)
+	return w.csv.Write(w.columns)
+}
+
+// write adds record to the export, as row when exporting CSV
+func (w *exportWriter) write(record any, row []string) error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if w.format == exportFormatNDJSON {
+		err = w.json.Encode(record)
+	} else {
+		err = w.csv.Write(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%exportFlushRows == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush sends the buffered records to the client
+func (w *exportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// finishExport completes an export that ended with err. Once records have been sent the status
+// can no longer change, so a failure part way through only cuts the export short.
+func (s *Server) finishExport(w *exportWriter, err error) {
+	if err == nil && !w.started {
+		err = w.start()
+	}
+	if err == nil {
+		err = w.flush()
+	}
+	if err == nil {
+		return
+	}
+
+	if !w.started {
+		s.logger.Error("Failed to export", "export", w.name, "error", err)
+		w.c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to export %s", w.name)})
+		return
+	}
+	if w.c.Request.Context().Err() != nil {
+		s.logger.Debug("Export canceled by the client", "export", w.name, "rows", w.rows)
+		return
+	}
+	s.logger.Error("Export failed part way through", "export", w.name, "rows", w.rows, "error", err)
+}
+
+// csvText guards free text, such as release titles from indexers, against being run as a formula
+// when the export is opened in a spreadsheet
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvTime formats an optional time as RFC 3339, or empty when unset
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// historyExportColumns are the CSV columns of a history export
+var historyExportColumns = []string{
+	"id", "date", "eventType", "movieId", "movieTitle", "sourceTitle", "quality", "downloadId",
+	"successful", "message",
+}
+
+// historyExportRow returns the CSV row of a history record
+func historyExportRow(h *models.History) []string {
+	movieID, movieTitle := "", ""
+	if h.MovieID != nil {
+		movieID = strconv.Itoa(*h.MovieID)
+	}
+	if h.Movie != nil {
+		movieTitle = h.Movie.Title
+	}
+	return []string{
+		strconv.Itoa(h.ID), csvTime(&h.Date), string(h.EventType), movieID, csvText(movieTitle),
+		csvText(h.SourceTitle), h.Quality.Name, csvText(h.DownloadID), strconv.FormatBool(h.Successful),
+		csvText(h.Message),
+	}
+}
+
+// handleExportHistory handles GET /api/v3/history/export
+func (s *Server) handleExportHistory(c *gin.Context) {
+	w := newExportWriter(c, "history", historyExportColumns)
+	if w == nil {
+		return
+	}
+
+	err := s.services.HistoryService.ExportHistory(c.Request.Context(), parseHistoryRequest(c),
+		func(h *models.History) error {
+			return w.write(h, historyExportRow(h))
+		})
+	s.finishExport(w, err)
+}
+
+// wantedExportColumns are the CSV columns of a wanted movies export
+var wantedExportColumns = []string{
+	"id", "movieId", "movieTitle", "year", "versionId", "status", "reason", "priority", "currentQuality",
+	"targetQuality", "isAvailable", "searchAttempts", "lastSearchTime", "nextSearchTime", "snoozedUntil",
+	"excludedFromAutoSearch", "added",
+}
+
+// wantedExportRow returns the CSV row of a wanted movie
+func wantedExportRow(w *models.WantedMovie) []string {
+	title, year, currentQuality, targetQuality := "", "", "", ""
+	if w.Movie != nil {
+		title = w.Movie.Title
+		year = strconv.Itoa(w.Movie.Year)
+	}
+	if w.CurrentQuality != nil {
+		currentQuality = w.CurrentQuality.Title
+	}
+	if w.TargetQuality != nil {
+		targetQuality = w.TargetQuality.Title
+	}
+	return []string{
+		strconv.Itoa(w.ID), strconv.Itoa(w.MovieID), csvText(title), year, strconv.Itoa(w.VersionID),
+		string(w.Status), csvText(w.Reason), strconv.Itoa(int(w.Priority)), currentQuality, targetQuality,
+		strconv.FormatBool(w.IsAvailable), strconv.Itoa(w.SearchAttempts), csvTime(w.LastSearchTime),
+		csvTime(w.NextSearchTime), csvTime(w.SnoozedUntil), strconv.FormatBool(w.ExcludedFromAutoSearch),
+		csvTime(&w.CreatedAt),
+	}
+}
+
+// handleExportWanted handles GET /api/v3/wanted/export
+func (s *Server) handleExportWanted(c *gin.Context) {
+	w := newExportWriter(c, "wanted", wantedExportColumns)
+	if w == nil {
+		return
+	}
+
+	err := s.services.WantedMoviesService.ExportWanted(c.Request.Context(), s.parseWantedMovieFilter(c),
+		func(wanted *models.WantedMovie) error {
+			return w.write(wanted, wantedExportRow(wanted))
+		})
+	s.finishExport(w, err)
+}
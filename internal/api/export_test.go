@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExportTestContext(query string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v3/history/export"+query, nil)
+	return c, recorder
+}
+
+func exportHistory(t *testing.T, query string, records []models.History, failAfter error) *httptest.ResponseRecorder {
+	log := logger.New(config.LogConfig{Level: "error"})
+	t.Cleanup(log.Close)
+	server := &Server{logger: log}
+
+	c, recorder := newExportTestContext(query)
+	w := newExportWriter(c, "history", historyExportColumns)
+	if w == nil {
+		return recorder
+	}
+	var err error
+	for i := range records {
+		if err = w.write(&records[i], historyExportRow(&records[i])); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = failAfter
+	}
+	server.finishExport(w, err)
+	return recorder
+}
+
+func TestExportHistory_CSV(t *testing.T) {
+	movieID := 7
+	records := []models.History{{
+		ID:          1,
+		MovieID:     &movieID,
+		Movie:       &models.Movie{Title: "Dune"},
+		EventType:   models.HistoryEventTypeGrabbed,
+		Date:        time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Quality:     models.QualityDefinition{Name: "Bluray-2160p"},
+		SourceTitle: "=HYPERLINK(\"http://example.com\")",
+		Successful:  true,
+	}}
+
+	recorder := exportHistory(t, "", records, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, recorder.Header().Get("Content-Disposition"), `attachment; filename="history-`)
+
+	rows, err := csv.NewReader(strings.NewReader(recorder.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, historyExportColumns, rows[0])
+	assert.Equal(t, []string{
+		"1", "2026-10-01T12:00:00Z", "grabbed", "7", "Dune", "'=HYPERLINK(\"http://example.com\")",
+		"Bluray-2160p", "", "true", "",
+	}, rows[1])
+}
+
+func TestExportHistory_NDJSON(t *testing.T) {
+	records := []models.History{
+		{ID: 1, EventType: models.HistoryEventTypeGrabbed},
+		{ID: 2, EventType: models.HistoryEventTypeDownloadFailed},
+	}
+
+	recorder := exportHistory(t, "?format=ndjson", records, nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	var record models.History
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, models.HistoryEventTypeDownloadFailed, record.EventType)
+}
+
+func TestExportHistory_Errors(t *testing.T) {
+	recorder := exportHistory(t, "?format=xml", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// A failure before the first record still gets an error status
+	recorder = exportHistory(t, "", nil, errors.New("database unavailable"))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	// An export with no records is just the header row
+	recorder = exportHistory(t, "", nil, nil)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, strings.Join(historyExportColumns, ",")+"\n", recorder.Body.String())
+}
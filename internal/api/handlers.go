@@ -4,9 +4,15 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"path"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -27,36 +33,90 @@ const (
 
 // System handlers
 func (s *Server) handleSystemStatus(c *gin.Context) {
+	osName, osVersion := osInfo()
+	databaseVersion, migrationVersion := s.databaseStatus(c.Request.Context())
+	hostConfig := s.hostConfig()
+	docker := isDocker()
+	updateMechanism := "builtIn"
+	if docker {
+		updateMechanism = "docker"
+	}
+
 	status := gin.H{
-		"version":                "1.0.0-go",
-		"buildTime":              time.Now().Format(time.RFC3339),
+		"version":                s.buildInfo.Version,
+		"commit":                 s.buildInfo.Commit,
+		"buildTime":              s.buildInfo.buildTime(),
+		"startTime":              s.startTime.UTC().Format(time.RFC3339),
+		"instanceName":           hostConfig.Instance(),
 		"isDebug":                s.config.Log.Level == DebugLevel,
 		"isProduction":           s.config.Log.Level != DebugLevel,
 		"isAdmin":                true,
 		"isUserInteractive":      false,
 		"startupPath":            s.config.Storage.DataDirectory,
 		"appData":                s.config.Storage.DataDirectory,
-		"osName":                 "linux",
-		"osVersion":              "unknown",
+		"osName":                 osName,
+		"osVersion":              osVersion,
 		"isMonoRuntime":          false,
 		"isMono":                 false,
-		"isLinux":                true,
-		"isOsx":                  false,
-		"isWindows":              false,
+		"isLinux":                runtime.GOOS == "linux",
+		"isOsx":                  runtime.GOOS == "darwin",
+		"isWindows":              runtime.GOOS == "windows",
+		"isDocker":               docker,
 		"mode":                   "console",
-		"branch":                 "develop",
+		"branch":                 hostConfig.UpdateBranch,
 		"authentication":         s.config.Auth.Method,
-		"sqliteVersion":          "3.x",
-		"migrationVersion":       1,
+		"migrationVersion":       migrationVersion,
 		"urlBase":                s.config.Server.URLBase,
-		"runtimeVersion":         "go1.24",
+		"runtimeName":            "go",
+		"runtimeVersion":         runtime.Version(),
 		"databaseType":           s.config.Database.Type,
-		"databaseVersion":        "unknown",
-		"packageVersion":         "1.0.0-go",
+		"databaseVersion":        databaseVersion,
+		"packageVersion":         s.buildInfo.Version,
 		"packageAuthor":          "Radarr Go Team",
-		"packageUpdateMechanism": "docker",
+		"packageUpdateMechanism": updateMechanism,
+	}
+	if s.services != nil {
+		status["workers"] = s.services.WorkerLimits
+	}
+	if s.services != nil && s.services.LeaderElector != nil {
+		status["cluster"] = s.services.LeaderElector.Status()
+	}
+	if s.services != nil && s.services.ReadOnly != nil {
+		status["readOnly"] = s.services.ReadOnly.Status()
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// handleReady reports 200 once migrations, service initialization, startup checks and scheduler
+// warm-up have completed, and 503 with the pending stages until then
+func (s *Server) handleReady(c *gin.Context) {
+	if s.services == nil || s.services.Readiness == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"ready": false})
+		return
 	}
 
+	status := s.services.Readiness.Status()
+	if !status.Ready {
+		c.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// handleLive reports 200 while the database answers pings and the task scheduler and workers keep
+// their heartbeats, and 503 with the failing checks otherwise
+func (s *Server) handleLive(c *gin.Context) {
+	if s.services == nil {
+		c.JSON(http.StatusOK, gin.H{"alive": true})
+		return
+	}
+
+	status := s.services.Liveness(c.Request.Context())
+	if !status.Alive {
+		c.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
 	c.JSON(http.StatusOK, status)
 }
 
@@ -88,6 +148,17 @@ func (s *Server) handleGetByID(c *gin.Context, resourceName string, getFunc func
 	c.JSON(http.StatusOK, resource)
 }
 
+// respondConflict answers 409 when err reports an update made to a stale copy of a resource,
+// returning whether it did. The body carries when the stored resource was last updated.
+func respondConflict(c *gin.Context, err error) bool {
+	var conflict models.ConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{"error": conflict.Error(), "updatedAt": conflict.UpdatedAt})
+	return true
+}
+
 // Helper function to handle delete operations
 func (s *Server) handleDeleteByID(c *gin.Context, resourceName string, deleteFunc func(int) error) {
 	id, err := s.parseIDParam(c)
@@ -107,6 +178,11 @@ func (s *Server) handleDeleteByID(c *gin.Context, resourceName string, deleteFun
 
 // Movie handlers
 func (s *Server) handleGetMovies(c *gin.Context) {
+	if filterIDStr := c.Query("filterId"); filterIDStr != "" {
+		s.handleGetFilteredMovies(c, filterIDStr)
+		return
+	}
+
 	movies, err := s.services.MovieService.GetAll()
 	if err != nil {
 		s.logger.Error("Failed to get movies", "error", err)
@@ -117,6 +193,34 @@ func (s *Server) handleGetMovies(c *gin.Context) {
 	c.JSON(http.StatusOK, movies)
 }
 
+// handleGetFilteredMovies returns the movies matching a saved custom filter
+func (s *Server) handleGetFilteredMovies(c *gin.Context, filterIDStr string) {
+	filterID, err := strconv.Atoi(filterIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filterId"})
+		return
+	}
+
+	filter, err := s.services.CustomFilterService.GetByID(filterID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "custom filter not found"})
+		return
+	}
+	if filter.Type != models.CustomFilterTypeMovieIndex {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Custom filter does not apply to movies"})
+		return
+	}
+
+	movies, err := s.services.MovieService.GetByFilter(filter)
+	if err != nil {
+		s.logger.Error("Failed to get filtered movies", "filterId", filterID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
 func (s *Server) handleGetMovie(c *gin.Context) {
 	s.handleGetByID(c, "movie", func(id int) (any, error) {
 		return s.services.MovieService.GetByID(id)
@@ -133,6 +237,11 @@ func (s *Server) handleCreateMovie(c *gin.Context) {
 	movie.Added = time.Now()
 
 	if err := s.services.MovieService.Create(&movie); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
 		s.logger.Error("Failed to create movie", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create movie"})
 		return
@@ -141,6 +250,60 @@ func (s *Server) handleCreateMovie(c *gin.Context) {
 	c.JSON(http.StatusCreated, movie)
 }
 
+// handleImportMovies adds many movies in one request, reporting the outcome of each
+func (s *Server) handleImportMovies(c *gin.Context) {
+	var movies []models.Movie
+	if err := c.ShouldBindJSON(&movies); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie data"})
+		return
+	}
+	if len(movies) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one movie is required"})
+		return
+	}
+
+	added := time.Now()
+	for i := range movies {
+		movies[i].Added = added
+	}
+
+	results, err := s.services.MovieService.ImportMovies(movies)
+	if err != nil {
+		s.logger.Error("Failed to import movies", "count", len(movies), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import movies"})
+		return
+	}
+
+	var createdIDs []int
+	for i := range results {
+		if results[i].Movie != nil {
+			createdIDs = append(createdIDs, results[i].Movie.ID)
+		}
+	}
+	created := len(createdIDs)
+	if created > 0 {
+		s.queueMediaCoverPrefetch(createdIDs)
+	}
+	c.JSON(http.StatusOK, gin.H{"created": created, "failed": len(results) - created, "results": results})
+}
+
+// queueMediaCoverPrefetch queues the download of the covers of newly imported movies, so the first
+// load of the library finds them cached instead of fetching them all from the image host at once.
+// The movies are imported either way, so a failure is only logged.
+func (s *Server) queueMediaCoverPrefetch(movieIDs []int) {
+	if !s.services.MediaCoverService.CachesCovers() {
+		return
+	}
+	if _, err := s.services.TaskService.QueueTask(
+		fmt.Sprintf("Prefetch Media Covers - %d movies", len(movieIDs)),
+		"PrefetchMediaCovers",
+		models.JSONField{"movieIds": movieIDs},
+		"low",
+	); err != nil {
+		s.logger.Error("Failed to queue media cover prefetch", "movies", len(movieIDs), "error", err)
+	}
+}
+
 func (s *Server) handleUpdateMovie(c *gin.Context) {
 	id, err := s.parseIDParam(c)
 	if err != nil {
@@ -156,7 +319,15 @@ func (s *Server) handleUpdateMovie(c *gin.Context) {
 
 	movie.ID = id
 
-	if err := s.services.MovieService.Update(&movie); err != nil {
+	if err := s.services.MovieService.UpdateIfUnchanged(&movie, movie.UpdatedAt); err != nil {
+		if respondConflict(c, err) {
+			return
+		}
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
 		s.logger.Error("Failed to update movie", "id", id, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update movie"})
 		return
@@ -165,6 +336,107 @@ func (s *Server) handleUpdateMovie(c *gin.Context) {
 	c.JSON(http.StatusOK, movie)
 }
 
+func (s *Server) handleMovieEditor(c *gin.Context) {
+	var editor models.MovieEditor
+	if err := c.ShouldBindJSON(&editor); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch editor.ApplyTags {
+	case "", models.MovieEditorTagsAdd, models.MovieEditorTagsRemove, models.MovieEditorTagsReplace:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "applyTags must be add, remove, or replace"})
+		return
+	}
+
+	tracker := s.services.HistoryService.TrackActivity(models.ActivityTypeBulkEdit,
+		fmt.Sprintf("Edit %d movies", len(editor.MovieIDs)), len(editor.MovieIDs))
+
+	movies, err := s.services.MovieService.BulkEdit(&editor, tracker)
+	tracker.Finish(nil)
+	if err != nil && len(movies) == 0 {
+		s.logger.Error("Failed to apply movie editor changes", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
+// handleSetMovieMonitored sets whether a movie is monitored without saving the rest of the movie
+func (s *Server) handleSetMovieMonitored(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req models.MovieMonitorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "monitored is required"})
+		return
+	}
+
+	movie, err := s.services.MovieService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+		return
+	}
+
+	if err := s.services.MovieService.SetMonitored(movie, *req.Monitored); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update movie monitoring"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+// handleSetMoviesMonitored sets whether the movies chosen by id, saved filter or filter predicates
+// are monitored
+func (s *Server) handleSetMoviesMonitored(c *gin.Context) {
+	var req models.MovieBulkMonitorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "monitored is required"})
+		return
+	}
+
+	var filter *models.CustomFilter
+	switch {
+	case req.FilterID != 0:
+		saved, err := s.services.CustomFilterService.GetByID(req.FilterID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "custom filter not found"})
+			return
+		}
+		if saved.Type != models.CustomFilterTypeMovieIndex {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Custom filter does not apply to movies"})
+			return
+		}
+		filter = saved
+	case len(req.Filters) > 0:
+		filter = &models.CustomFilter{Label: "Bulk monitor", Filters: req.Filters}
+		if err := filter.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := s.services.MovieService.SetMonitoredBulk(req.MovieIDs, filter, *req.Monitored)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to set monitoring of movies", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update movie monitoring"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func (s *Server) handleDeleteMovie(c *gin.Context) {
 	s.handleDeleteByID(c, "movie", s.services.MovieService.Delete)
 }
@@ -181,6 +453,27 @@ func (s *Server) handleGetMovieFiles(c *gin.Context) {
 	c.JSON(http.StatusOK, movieFiles)
 }
 
+func (s *Server) handleGetMovieFileReleases(c *gin.Context) {
+	movieID := 0
+	if value := c.Query("movieId"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movieId"})
+			return
+		}
+		movieID = id
+	}
+
+	releases, err := s.services.MovieFileService.GetReleases(movieID)
+	if err != nil {
+		s.logger.Error("Failed to get movie file releases", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve movie file releases"})
+		return
+	}
+
+	c.JSON(http.StatusOK, releases)
+}
+
 func (s *Server) handleGetMovieFile(c *gin.Context) {
 	s.handleGetByID(c, "movie file", func(id int) (any, error) {
 		return s.services.MovieFileService.GetByID(id)
@@ -191,6 +484,194 @@ func (s *Server) handleDeleteMovieFile(c *gin.Context) {
 	s.handleDeleteByID(c, "movie file", s.services.MovieFileService.Delete)
 }
 
+// handleGetMediaCover serves a movie's poster or fanart, such as /mediacover/42/poster.jpg
+func (s *Server) handleGetMediaCover(c *gin.Context) {
+	movieID, err := strconv.Atoi(c.Param("movieId"))
+	if err != nil || movieID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID"})
+		return
+	}
+
+	fileName := c.Param("filename")
+	cover, err := s.services.MediaCoverService.GetCover(c.Request.Context(), movieID, fileName)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		s.logger.Error("Failed to get media cover", "movieId", movieID, "file", fileName, "error", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to get media cover"})
+		return
+	}
+	defer func() { _ = cover.Close() }() //nolint:errcheck // read-only cover
+
+	contentType := mime.TypeByExtension(path.Ext(fileName))
+	if contentType == "" {
+		contentType = "image/jpeg"
+	}
+	c.DataFromReader(http.StatusOK, -1, contentType, cover, map[string]string{
+		"Cache-Control": "public, max-age=86400",
+	})
+}
+
+// handleStreamMovieFile serves a movie file for previewing in the browser, honoring Range requests
+func (s *Server) handleStreamMovieFile(c *gin.Context) {
+	if !s.previewEnabled(c) {
+		return
+	}
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, info, err := s.services.MovieFileService.OpenForPreview(id)
+	if err != nil {
+		s.movieFilePreviewError(c, id, err)
+		return
+	}
+	defer func() { _ = file.Close() }() //nolint:errcheck // read-only file
+
+	s.serveStream(c, info.Name(), info.ModTime(), file)
+}
+
+// serveStream serves content with Range support, without the server write timeout, which a movie
+// file or a long Range read takes far longer than to send
+func (s *Server) serveStream(c *gin.Context, name string, modTime time.Time, content io.ReadSeeker) {
+	if err := liftWriteDeadline(c.Writer); err != nil {
+		s.logger.Warn("Streaming under the server write timeout", "name", name, "error", err)
+	}
+	http.ServeContent(c.Writer, c.Request, name, modTime, content)
+}
+
+// handleMovieFileThumbnail returns a JPEG frame from a movie file, taken at the offset in seconds
+// given by the at query parameter
+func (s *Server) handleMovieFileThumbnail(c *gin.Context) {
+	if !s.previewEnabled(c) {
+		return
+	}
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	at, err := strconv.ParseFloat(c.DefaultQuery("at", "60"), 64)
+	if err != nil || at < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at must be a non-negative number of seconds"})
+		return
+	}
+
+	image, err := s.services.MovieFileService.Thumbnail(c.Request.Context(), id,
+		time.Duration(at*float64(time.Second)), s.config.Preview.FFmpegPath)
+	if err != nil {
+		s.movieFilePreviewError(c, id, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "image/jpeg", image)
+}
+
+// previewEnabled refuses movie file previews with 403 unless preview.enabled is set
+func (s *Server) previewEnabled(c *gin.Context) bool {
+	if s.config == nil || !s.config.Preview.Enabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Movie file previews are disabled; set preview.enabled to enable them"})
+		return false
+	}
+	return true
+}
+
+// movieFilePreviewError responds to a failed movie file stream or thumbnail
+func (s *Server) movieFilePreviewError(c *gin.Context, id int, err error) {
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	s.logger.Error("Failed to preview movie file", "id", id, "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview movie file"})
+}
+
+// Movie version handlers
+func (s *Server) handleGetMovieVersions(c *gin.Context) {
+	movieID, err := strconv.Atoi(c.Query("movieId"))
+	if err != nil || movieID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "movieId query parameter is required"})
+		return
+	}
+
+	versions, err := s.services.MovieVersionService.GetByMovieID(movieID)
+	if err != nil {
+		s.logger.Error("Failed to get movie versions", "movieId", movieID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve movie versions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+func (s *Server) handleGetMovieVersion(c *gin.Context) {
+	s.handleGetByID(c, "movie version", func(id int) (any, error) {
+		return s.services.MovieVersionService.GetByID(id)
+	})
+}
+
+func (s *Server) handleCreateMovieVersion(c *gin.Context) {
+	var version models.MovieVersion
+	if err := c.ShouldBindJSON(&version); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie version data"})
+		return
+	}
+
+	if err := s.services.MovieVersionService.Create(&version); err != nil {
+		s.movieVersionError(c, err, "create")
+		return
+	}
+
+	c.JSON(http.StatusCreated, version)
+}
+
+func (s *Server) handleUpdateMovieVersion(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var version models.MovieVersion
+	if err := c.ShouldBindJSON(&version); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie version data"})
+		return
+	}
+
+	version.ID = id
+	if err := s.services.MovieVersionService.Update(&version); err != nil {
+		s.movieVersionError(c, err, "update")
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+func (s *Server) handleDeleteMovieVersion(c *gin.Context) {
+	s.handleDeleteByID(c, "movie version", s.services.MovieVersionService.Delete)
+}
+
+// movieVersionError responds to a failed movie version create or update
+func (s *Server) movieVersionError(c *gin.Context, err error, action string) {
+	var validationErr models.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	s.logger.Error("Failed to "+action+" movie version", "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to " + action + " movie version"})
+}
+
 // Placeholder handlers for other endpoints
 func (s *Server) handleGetQualityProfiles(c *gin.Context) {
 	profiles, err := s.services.QualityService.GetQualityProfiles()
@@ -296,9 +777,124 @@ func (s *Server) handleGetDownloadClientStats(c *gin.Context) {
 		return
 	}
 
+	bandwidth, err := s.services.DownloadService.GetBandwidth(c.Request.Context())
+	if err != nil {
+		s.logger.Error("Failed to get download client bandwidth", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get download client statistics"})
+		return
+	}
+	stats["downloadSpeed"] = bandwidth.DownloadSpeed
+	stats["uploadSpeed"] = bandwidth.UploadSpeed
+	stats["clients"] = bandwidth.Clients
+	stats["checkedAt"] = bandwidth.CheckedAt
+
 	c.JSON(http.StatusOK, stats)
 }
 
+// Remote path mapping handlers
+func (s *Server) handleGetRemotePathMappings(c *gin.Context) {
+	mappings, err := s.services.RemotePathMappingService.GetAll()
+	if err != nil {
+		s.logger.Error("Failed to get remote path mappings", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve remote path mappings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings)
+}
+
+func (s *Server) handleGetRemotePathMapping(c *gin.Context) {
+	s.handleGetByID(c, "remote path mapping", func(id int) (any, error) {
+		return s.services.RemotePathMappingService.GetByID(id)
+	})
+}
+
+func (s *Server) handleCreateRemotePathMapping(c *gin.Context) {
+	var mapping models.RemotePathMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid remote path mapping data"})
+		return
+	}
+
+	if err := s.services.RemotePathMappingService.Create(&mapping); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to create remote path mapping", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create remote path mapping"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapping)
+}
+
+func (s *Server) handleUpdateRemotePathMapping(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var mapping models.RemotePathMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid remote path mapping data"})
+		return
+	}
+
+	mapping.ID = id
+	if err := s.services.RemotePathMappingService.Update(&mapping); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "remote path mapping not found"})
+			return
+		}
+		s.logger.Error("Failed to update remote path mapping", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update remote path mapping"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+func (s *Server) handleDeleteRemotePathMapping(c *gin.Context) {
+	s.handleDeleteByID(c, "remote path mapping", s.services.RemotePathMappingService.Delete)
+}
+
+// Seeding handlers
+func (s *Server) handleGetSeedingTorrents(c *gin.Context) {
+	torrents, err := s.services.SeedingService.GetAll(models.SeedingStatus(c.Query("status")))
+	if err != nil {
+		s.logger.Error("Failed to get seeding torrents", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve seeding torrents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, torrents)
+}
+
+// handleCleanupSeededTorrents queues a check of the seeding torrents
+func (s *Server) handleCleanupSeededTorrents(c *gin.Context) {
+	task, err := s.services.TaskService.QueueTask(
+		"Seeding Cleanup",
+		"CleanupSeededTorrents",
+		models.JSONField{},
+		"normal",
+	)
+	if err != nil {
+		s.logger.Error("Failed to queue seeding cleanup task", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue seeding cleanup"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
 func (s *Server) handleGetDownloadHistory(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "100")
 	limit, err := strconv.Atoi(limitStr)
@@ -411,6 +1007,28 @@ func (s *Server) handleSyncImportList(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// handlePreviewImportList reports what syncing an import list would change without syncing it
+func (s *Server) handlePreviewImportList(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preview, err := s.services.ImportListService.PreviewImportListSync(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import list not found"})
+			return
+		}
+		s.logger.Error("Failed to preview import list", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview import list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
 func (s *Server) handleSyncAllImportLists(c *gin.Context) {
 	results, err := s.services.ImportListService.SyncAllImportLists()
 	if err != nil {
@@ -447,31 +1065,230 @@ func (s *Server) handleGetImportListMovies(c *gin.Context) {
 		limit = 100
 	}
 
-	movies, err := s.services.ImportListService.GetImportListMovies(listID, limit)
+	status := models.ImportListApprovalStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval status"})
+		return
+	}
+
+	movies, err := s.services.ImportListService.GetImportListMoviesByStatus(listID, status, limit)
+	if err != nil {
+		s.logger.Error("Failed to get import list movies", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve import list movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
+func (s *Server) handleApproveImportListMovie(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movie, err := s.services.ImportListService.ApproveImportListMovie(id)
+	if err != nil {
+		s.importListMovieError(c, "approve", id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+func (s *Server) handleRejectImportListMovie(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var request struct {
+		Exclude bool `json:"exclude"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	movie, err := s.services.ImportListService.RejectImportListMovie(id, request.Exclude)
+	if err != nil {
+		s.importListMovieError(c, "reject", id, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+// importListMovieError responds to a failed approval or rejection of a discovered movie
+func (s *Server) importListMovieError(c *gin.Context, action string, id int, err error) {
+	if strings.Contains(err.Error(), "import list movie not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	s.logger.Error("Failed to "+action+" import list movie", "id", id, "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to " + action + " import list movie"})
+}
+
+func (s *Server) handleGetMovieTombstones(c *gin.Context) {
+	tombstones, err := s.services.ImportListService.GetTombstones()
+	if err != nil {
+		s.logger.Error("Failed to get movie tombstones", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve movie tombstones"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tombstones)
+}
+
+func (s *Server) handleDeleteMovieTombstone(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.services.ImportListService.DeleteTombstone(id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie tombstone not found"})
+			return
+		}
+		s.logger.Error("Failed to delete movie tombstone", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete movie tombstone"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Movie tombstone deleted successfully"})
+}
+
+func (s *Server) handleClearMovieTombstones(c *gin.Context) {
+	cleared, err := s.services.ImportListService.ClearTombstones()
+	if err != nil {
+		s.logger.Error("Failed to clear movie tombstones", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear movie tombstones"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Movie tombstones cleared successfully", "cleared": cleared})
+}
+
+func (s *Server) handleSearchMovies(c *gin.Context) {
+	query := c.Query("term")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search term is required"})
+		return
+	}
+
+	movies, err := s.services.MovieService.Search(query)
+	if err != nil {
+		s.logger.Error("Failed to search movies", "query", query, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
+// handleLibrarySearch runs a full-text search over library titles, overviews, cast and crew
+func (s *Server) handleLibrarySearch(c *gin.Context) {
+	term := c.Query("term")
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := s.services.MovieService.FullTextSearch(term, limit)
+	if err != nil {
+		s.logger.Error("Failed to search library", "term", term, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search library"})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Custom filter handlers
+func (s *Server) handleGetCustomFilters(c *gin.Context) {
+	filters, err := s.services.CustomFilterService.GetAll()
+	if err != nil {
+		s.logger.Error("Failed to get custom filters", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve custom filters"})
+		return
+	}
+
+	c.JSON(http.StatusOK, filters)
+}
+
+func (s *Server) handleGetCustomFilter(c *gin.Context) {
+	s.handleGetByID(c, "custom filter", func(id int) (any, error) {
+		return s.services.CustomFilterService.GetByID(id)
+	})
+}
+
+func (s *Server) handleCreateCustomFilter(c *gin.Context) {
+	var filter models.CustomFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom filter data"})
+		return
+	}
+
+	if err := s.services.CustomFilterService.Create(&filter); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to create custom filter", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create custom filter"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, filter)
+}
+
+func (s *Server) handleUpdateCustomFilter(c *gin.Context) {
+	id, err := s.parseIDParam(c)
 	if err != nil {
-		s.logger.Error("Failed to get import list movies", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve import list movies"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, movies)
-}
-
-func (s *Server) handleSearchMovies(c *gin.Context) {
-	query := c.Query("term")
-	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Search term is required"})
+	var filter models.CustomFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom filter data"})
 		return
 	}
 
-	movies, err := s.services.MovieService.Search(query)
-	if err != nil {
-		s.logger.Error("Failed to search movies", "query", query, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search movies"})
+	filter.ID = id
+	if err := s.services.CustomFilterService.Update(&filter); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "custom filter not found"})
+			return
+		}
+		s.logger.Error("Failed to update custom filter", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update custom filter"})
 		return
 	}
 
-	c.JSON(http.StatusOK, movies)
+	c.JSON(http.StatusOK, filter)
+}
+
+func (s *Server) handleDeleteCustomFilter(c *gin.Context) {
+	s.handleDeleteByID(c, "custom filter", s.services.CustomFilterService.Delete)
 }
 
 // Quality Profile handlers
@@ -511,19 +1328,149 @@ func (s *Server) handleUpdateQualityProfile(c *gin.Context) {
 	}
 
 	profile.ID = id
-	if err := s.services.QualityService.UpdateQualityProfile(&profile); err != nil {
+	stored, err := s.services.QualityService.GetQualityProfileByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Quality profile not found"})
+		return
+	}
+
+	if err := s.services.QualityService.UpdateQualityProfileIfUnchanged(&profile, profile.UpdatedAt); err != nil {
+		if respondConflict(c, err) {
+			return
+		}
 		s.logger.Error("Failed to update quality profile", "id", id, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update quality profile"})
 		return
 	}
 
+	if stored.UpgradeRulesChanged(&profile) {
+		s.queueQualityProfileWantedRefresh(id, c.DefaultQuery("searchUpgrades", falseBoolString) == trueBoolString)
+	}
+
 	c.JSON(http.StatusOK, profile)
 }
 
+// queueQualityProfileWantedRefresh queues the re-evaluation of the wanted movies of a quality
+// profile whose cutoff, allowed qualities or scores were edited, searching the movies it puts below
+// cutoff when searchUpgrades is set. The profile is saved either way, so a failure is only logged.
+func (s *Server) queueQualityProfileWantedRefresh(profileID int, searchUpgrades bool) {
+	if _, err := s.services.TaskService.QueueTask(
+		fmt.Sprintf("Refresh Quality Profile Wanted - ID %d", profileID),
+		"RefreshQualityProfileWanted",
+		models.JSONField{"qualityProfileId": profileID, "searchUpgrades": searchUpgrades},
+		"normal",
+	); err != nil {
+		s.logger.Error("Failed to queue wanted refresh for quality profile", "id", profileID, "error", err)
+	}
+}
+
 func (s *Server) handleDeleteQualityProfile(c *gin.Context) {
 	s.handleDeleteByID(c, "quality profile", s.services.QualityService.DeleteQualityProfile)
 }
 
+// handleCloneQualityProfile copies a quality profile, including its custom format scores, under the
+// name in the optional body
+func (s *Server) handleCloneQualityProfile(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var request models.QualityProfileCloneRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid clone request"})
+			return
+		}
+	}
+
+	profile, err := s.services.QualityService.CloneQualityProfile(id, request.Name)
+	if err != nil {
+		s.respondQualityProfileError(c, "clone", err)
+		return
+	}
+	c.JSON(http.StatusCreated, profile)
+}
+
+// handleReorderQualityProfileItems reorders a quality profile's top-level items
+func (s *Server) handleReorderQualityProfileItems(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var request models.QualityProfileReorderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reorder request"})
+		return
+	}
+
+	profile, err := s.services.QualityService.ReorderQualityProfileItems(id, request.Order)
+	if err != nil {
+		s.respondQualityProfileError(c, "reorder", err)
+		return
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+func (s *Server) respondQualityProfileError(c *gin.Context, action string, err error) {
+	var validationErr models.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "quality profile not found"})
+		return
+	}
+	s.logger.Error("Failed to "+action+" quality profile", "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to " + action + " quality profile"})
+}
+
+func (s *Server) handleExportQualityProfile(c *gin.Context) {
+	s.handleGetByID(c, "quality profile", func(id int) (any, error) {
+		return s.services.QualityService.ExportQualityProfile(id)
+	})
+}
+
+// handleImportQualityProfile accepts an exported {qualityProfile, customFormats} bundle or a bare
+// TRaSH-Guides quality profile
+func (s *Server) handleImportQualityProfile(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quality profile data"})
+		return
+	}
+
+	var bundle models.TrashProfileBundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quality profile data"})
+		return
+	}
+	if bundle.QualityProfile.Name == "" {
+		if err := json.Unmarshal(body, &bundle.QualityProfile); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quality profile data"})
+			return
+		}
+	}
+
+	result, err := s.services.QualityService.ImportQualityProfile(&bundle)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to import quality profile", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import quality profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // Quality Definition handlers
 func (s *Server) handleGetQualityDefinitions(c *gin.Context) {
 	definitions, err := s.services.QualityService.GetQualityDefinitions()
@@ -556,12 +1503,42 @@ func (s *Server) handleUpdateQualityDefinition(c *gin.Context) {
 
 	definition.ID = id
 	if err := s.services.QualityService.UpdateQualityDefinition(&definition); err != nil {
-		s.logger.Error("Failed to update quality definition", "id", id, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update quality definition"})
+		s.respondQualityDefinitionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, definition)
+}
+
+// handleUpdateQualityDefinitions updates the size limits of several definitions at once, as the
+// upstream quality definitions settings page does
+func (s *Server) handleUpdateQualityDefinitions(c *gin.Context) {
+	var definitions []*models.QualityLevel
+	if err := c.ShouldBindJSON(&definitions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quality definition data"})
+		return
+	}
+
+	if err := s.services.QualityService.UpdateQualityDefinitions(definitions); err != nil {
+		s.respondQualityDefinitionError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, definition)
+	c.JSON(http.StatusAccepted, definitions)
+}
+
+func (s *Server) respondQualityDefinitionError(c *gin.Context, err error) {
+	var validationErr models.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "quality definition not found"})
+		return
+	}
+	s.logger.Error("Failed to update quality definitions", "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update quality definition"})
 }
 
 // Custom Format handlers
@@ -575,6 +1552,76 @@ func (s *Server) handleGetCustomFormats(c *gin.Context) {
 	c.JSON(http.StatusOK, formats)
 }
 
+// handleGetCustomFormatSchema lists the supported specification types with their fields, as
+// upstream Radarr does for Recyclarr and Notifiarr
+func (s *Server) handleGetCustomFormatSchema(c *gin.Context) {
+	c.JSON(http.StatusOK, models.CustomFormatSpecSchemas())
+}
+
+// handleTestCustomFormats evaluates the release title in the title query parameter against every
+// custom format, showing which specifications matched
+func (s *Server) handleTestCustomFormats(c *gin.Context) {
+	title := c.Query("title")
+	if title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title query parameter is required"})
+		return
+	}
+
+	result, err := s.services.ParseService.TestCustomFormats(c.Request.Context(), title)
+	if err != nil {
+		s.logger.Error("Failed to test custom formats", "title", title, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to test custom formats"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleExportCustomFormats(c *gin.Context) {
+	formats, err := s.services.QualityService.ExportCustomFormats()
+	if err != nil {
+		s.logger.Error("Failed to export custom formats", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export custom formats"})
+		return
+	}
+	c.JSON(http.StatusOK, formats)
+}
+
+// handleImportCustomFormats accepts a single TRaSH-Guides custom format or an array of them
+func (s *Server) handleImportCustomFormats(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom format data"})
+		return
+	}
+
+	var formats []models.TrashCustomFormat
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var format models.TrashCustomFormat
+		err = json.Unmarshal(trimmed, &format)
+		formats = append(formats, format)
+	} else {
+		err = json.Unmarshal(trimmed, &formats)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom format data"})
+		return
+	}
+
+	result, err := s.services.QualityService.ImportCustomFormats(formats)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to import custom formats", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import custom formats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func (s *Server) handleGetCustomFormat(c *gin.Context) {
 	s.handleGetByID(c, "custom format", func(id int) (any, error) {
 		return s.services.QualityService.GetCustomFormatByID(id)
@@ -589,6 +1636,11 @@ func (s *Server) handleCreateCustomFormat(c *gin.Context) {
 	}
 
 	if err := s.services.QualityService.CreateCustomFormat(&format); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
 		s.logger.Error("Failed to create custom format", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create custom format"})
 		return
@@ -612,6 +1664,11 @@ func (s *Server) handleUpdateCustomFormat(c *gin.Context) {
 
 	format.ID = id
 	if err := s.services.QualityService.UpdateCustomFormat(&format); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
 		s.logger.Error("Failed to update custom format", "id", id, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update custom format"})
 		return
@@ -661,7 +1718,10 @@ func (s *Server) handleUpdateIndexer(c *gin.Context) {
 	}
 
 	indexer.ID = id
-	if err := s.services.IndexerService.UpdateIndexer(&indexer); err != nil {
+	if err := s.services.IndexerService.UpdateIndexerIfUnchanged(&indexer, indexer.UpdatedAt); err != nil {
+		if respondConflict(c, err) {
+			return
+		}
 		s.logger.Error("Failed to update indexer", "id", id, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update indexer"})
 		return
@@ -680,6 +1740,10 @@ func (s *Server) handleTestIndexer(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid indexer data"})
 		return
 	}
+	// The test outcome is recorded on the saved indexer in the path, 0 for unsaved ones
+	if id, err := strconv.Atoi(c.Param("id")); err == nil {
+		indexer.ID = id
+	}
 
 	result, err := s.services.IndexerService.TestIndexer(&indexer)
 	if err != nil {
@@ -753,6 +1817,53 @@ func (s *Server) handleMovieDiscoverTrending(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Results)
 }
 
+func (s *Server) handleMovieDiscover(c *gin.Context) {
+	request := &models.MovieDiscoverRequest{
+		Page:                  1,
+		WatchRegion:           c.Query("region"),
+		WithWatchProviders:    queryIDs(c, "withProviders"),
+		WithoutWatchProviders: queryIDs(c, "withoutProviders"),
+	}
+	if pageStr := c.Query("page"); pageStr != "" {
+		if parsed, err := strconv.Atoi(pageStr); err == nil && parsed > 0 {
+			request.Page = parsed
+		}
+	}
+	if monetization := c.Query("monetization"); monetization != "" {
+		request.MonetizationTypes = strings.Split(monetization, ",")
+	}
+
+	response, err := s.services.MetadataService.DiscoverMovies(request)
+	if err != nil {
+		s.logger.Error("Failed to discover movies", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to discover movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Results)
+}
+
+func (s *Server) handleGetWatchProviders(c *gin.Context) {
+	providers, err := s.services.MetadataService.GetAvailableWatchProviders(c.Query("region"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get watch providers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, providers)
+}
+
+// queryIDs parses a comma separated list of IDs from a query parameter, ignoring invalid entries
+func queryIDs(c *gin.Context, name string) []int {
+	var ids []int
+	for _, idStr := range strings.Split(c.Query(name), ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(idStr)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (s *Server) handleMovieByTMDBID(c *gin.Context) {
 	tmdbIDStr := c.Param("tmdbId")
 	tmdbID, err := strconv.Atoi(tmdbIDStr)
@@ -771,6 +1882,16 @@ func (s *Server) handleMovieByTMDBID(c *gin.Context) {
 	c.JSON(http.StatusOK, movie)
 }
 
+func (s *Server) handleMovieBatchLookup(c *gin.Context) {
+	var batch models.MovieLookupBatch
+	if err := c.ShouldBindJSON(&batch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, s.services.MetadataService.LookupMoviesByTMDBIDs(batch.TmdbIDs))
+}
+
 func (s *Server) handleRefreshMovieMetadata(c *gin.Context) {
 	id, err := s.parseIDParam(c)
 	if err != nil {
@@ -791,6 +1912,12 @@ func (s *Server) handleRefreshMovieMetadata(c *gin.Context) {
 func (s *Server) handleGetQueue(c *gin.Context) {
 	params := s.parseQueueQueryParams(c)
 
+	// Passing cursor (empty for the first page) opts into cursor pagination
+	if token, ok := c.GetQuery("cursor"); ok {
+		s.handleGetQueueByCursor(c, params, token)
+		return
+	}
+
 	queue, err := s.services.QueueService.GetQueue(
 		params.MovieIDs, params.Protocol, params.Languages,
 		params.Quality, params.Status, params.IncludeUnknownMovieItems)
@@ -884,6 +2011,39 @@ func (s *Server) buildQueueResponse(queue []models.QueueItem, params queueQueryP
 	}
 }
 
+func (s *Server) handleGetQueueByCursor(c *gin.Context, params queueQueryParams, token string) {
+	cursor, err := models.ParsePageCursor(token)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+		return
+	}
+
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+
+	queue, next, err := s.services.QueueService.GetQueueByCursor(
+		params.MovieIDs, params.Protocol, params.Status, params.IncludeUnknownMovieItems, cursor, params.PageSize)
+	if err != nil {
+		s.logger.Error("Failed to get queue", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve queue"})
+		return
+	}
+
+	if !params.IncludeMovie {
+		s.removeMovieDetails(queue)
+	}
+
+	response := gin.H{
+		"pageSize": params.PageSize,
+		"records":  queue,
+	}
+	if next != nil {
+		response["nextCursor"] = next.Encode()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 func (s *Server) handleGetQueueItem(c *gin.Context) {
 	s.handleGetByID(c, "queue item", func(id int) (any, error) {
 		return s.services.QueueService.GetQueueByID(id)
@@ -946,12 +2106,80 @@ func (s *Server) handleGetQueueStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
-// History handlers
-
-func (s *Server) handleGetHistory(c *gin.Context) {
+func (s *Server) handleGetUnmappedQueueItems(c *gin.Context) {
+	items, err := s.services.QueueResolutionService.GetUnmappedItems(c.Request.Context())
+	if err != nil {
+		s.logger.Error("Failed to get unmapped queue items", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve unmapped queue items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (s *Server) handleResolveQueueItem(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var resolution models.QueueItemResolution
+	if err := c.ShouldBindJSON(&resolution); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid queue item resolution"})
+		return
+	}
+
+	queueItem, err := s.services.QueueResolutionService.Resolve(id, &resolution)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.Contains(err.Error(), "queue item not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "queue item not found"})
+			return
+		}
+		s.logger.Error("Failed to resolve queue item", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve queue item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, queueItem)
+}
+
+// History handlers
+
+func (s *Server) handleGetHistory(c *gin.Context) {
+	req := parseHistoryRequest(c)
+
+	// Passing cursor (empty for the first page) opts into cursor pagination
+	var response *models.HistoryResponse
+	var err error
+	if token, ok := c.GetQuery("cursor"); ok {
+		cursor, parseErr := models.ParsePageCursor(token)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		response, err = s.services.HistoryService.GetHistoryByCursor(req, cursor)
+	} else {
+		response, err = s.services.HistoryService.GetHistory(req)
+	}
+	if err != nil {
+		s.logger.Error("Failed to get history", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseHistoryRequest reads the paging, sorting and filter query parameters of a history request
+func parseHistoryRequest(c *gin.Context) models.HistoryRequest {
 	var req models.HistoryRequest
 
-	// Parse query parameters
 	if page := c.Query("page"); page != "" {
 		if p, err := strconv.Atoi(page); err == nil && p > 0 {
 			req.Page = p
@@ -998,17 +2226,33 @@ func (s *Server) handleGetHistory(c *gin.Context) {
 		}
 	}
 
-	response, err := s.services.HistoryService.GetHistory(req)
+	return req
+}
+
+func (s *Server) handleGetHistoryByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		s.logger.Error("Failed to get history", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve history"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid history ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	history, err := s.services.HistoryService.GetHistoryByID(id)
+	if err != nil {
+		if err.Error() == "history record not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "History record not found"})
+			return
+		}
+		s.logger.Error("Failed to get history record", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve history record"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
 }
 
-func (s *Server) handleGetHistoryByID(c *gin.Context) {
+// handleRedownloadHistory grabs the release of a past grab again, or searches for the movie with
+// that release first when it can't be retrieved any more
+func (s *Server) handleRedownloadHistory(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid history ID"})
@@ -1026,7 +2270,19 @@ func (s *Server) handleGetHistoryByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, history)
+	response, err := s.services.SearchService.Redownload(history)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to redownload history record", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to redownload release"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 func (s *Server) handleDeleteHistoryRecord(c *gin.Context) {
@@ -1264,6 +2520,88 @@ func (s *Server) handleUpdateMediaManagementConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, config)
 }
 
+// UI configuration handlers
+func (s *Server) handleGetUIConfig(c *gin.Context) {
+	config, err := s.services.ConfigService.GetUIConfig()
+	if err != nil {
+		s.logger.Error("Failed to get UI config", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve UI configuration"})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+func (s *Server) handleUpdateUIConfig(c *gin.Context) {
+	var config models.UIConfig
+	if err := c.ShouldBindJSON(&config); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UI configuration data"})
+		return
+	}
+
+	if errors := config.ValidateConfiguration(); len(errors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UI configuration", "details": errors})
+		return
+	}
+
+	err := s.services.ConfigService.UpdateUIConfig(&config)
+	if err != nil {
+		s.logger.Error("Failed to update UI config", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update UI configuration"})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// TMDB API key handlers
+func (s *Server) handleGetTMDBConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, s.services.MetadataService.GetAPIKeyStatus())
+}
+
+func (s *Server) handleUpdateTMDBConfig(c *gin.Context) {
+	var request models.TMDBKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid TMDB configuration data"})
+		return
+	}
+
+	if err := s.services.MetadataService.SetAPIKey(c.Request.Context(), request.APIKey); err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to store TMDB API key", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store TMDB API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, s.services.MetadataService.GetAPIKeyStatus())
+}
+
+func (s *Server) handleDeleteTMDBConfig(c *gin.Context) {
+	if err := s.services.MetadataService.ClearAPIKey(); err != nil {
+		s.logger.Error("Failed to remove TMDB API key", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove TMDB API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, s.services.MetadataService.GetAPIKeyStatus())
+}
+
+func (s *Server) handleTestTMDBConfig(c *gin.Context) {
+	var request models.TMDBKeyRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid TMDB configuration data"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, s.services.MetadataService.ValidateAPIKey(c.Request.Context(), request.APIKey))
+}
+
 // Root Folder handlers
 func (s *Server) handleGetRootFolders(c *gin.Context) {
 	rootFolders, err := s.services.ConfigService.GetRootFolders()
@@ -1441,6 +2779,39 @@ func (s *Server) handleCreateConfigurationBackup(c *gin.Context) {
 	c.JSON(http.StatusCreated, backup)
 }
 
+// handleListConfigurationBackups lists the stored configuration backup archives, newest first
+func (s *Server) handleListConfigurationBackups(c *gin.Context) {
+	archives, err := s.services.ConfigService.ListConfigurationBackupArchives(c.Request.Context())
+	if err != nil {
+		s.logger.Error("Failed to list configuration backups", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list configuration backups"})
+		return
+	}
+
+	c.JSON(http.StatusOK, archives)
+}
+
+// handleDownloadConfigurationBackup downloads a stored configuration backup archive, which
+// /config/restore accepts as its backup
+func (s *Server) handleDownloadConfigurationBackup(c *gin.Context) {
+	name := c.Param("name")
+	archive, err := s.services.ConfigService.GetConfigurationBackupArchive(c.Request.Context(), name)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		s.logger.Error("Failed to download configuration backup", "name", name, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to download configuration backup"})
+		return
+	}
+	defer func() { _ = archive.Close() }() //nolint:errcheck // read-only archive
+
+	c.DataFromReader(http.StatusOK, -1, "application/json", archive, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", name),
+	})
+}
+
 // handleRestoreConfigurationBackup restores configuration from a backup
 func (s *Server) handleRestoreConfigurationBackup(c *gin.Context) {
 	var request struct {
@@ -1649,6 +3020,12 @@ func (s *Server) handleGetReleases(c *gin.Context) {
 		}
 	}
 
+	// Passing cursor (empty for the first page) opts into cursor pagination
+	if token, ok := c.GetQuery("cursor"); ok {
+		s.handleGetReleasesByCursor(c, &filter, limit, token)
+		return
+	}
+
 	offset := 0
 	if offsetStr := c.Query("offset"); offsetStr != "" {
 		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
@@ -1671,6 +3048,30 @@ func (s *Server) handleGetReleases(c *gin.Context) {
 	})
 }
 
+func (s *Server) handleGetReleasesByCursor(c *gin.Context, filter *models.ReleaseFilter, limit int, token string) {
+	cursor, err := models.ParsePageCursor(token)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+		return
+	}
+
+	releases, next, err := s.services.SearchService.GetReleasesByCursor(filter, limit, cursor)
+	if err != nil {
+		s.logger.Error("Failed to get releases", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve releases"})
+		return
+	}
+
+	response := gin.H{
+		"releases": releases,
+		"limit":    limit,
+	}
+	if next != nil {
+		response["nextCursor"] = next.Encode()
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 func (s *Server) handleGetRelease(c *gin.Context) {
 	s.handleGetByID(c, "release", func(id int) (any, error) {
 		return s.services.SearchService.GetReleaseByID(id)
@@ -1694,6 +3095,12 @@ func (s *Server) handleGetReleaseStats(c *gin.Context) {
 
 // Search handlers
 func (s *Server) handleSearchReleases(c *gin.Context) {
+	// A free-text term searches the library rather than indexers
+	if c.Query("term") != "" {
+		s.handleLibrarySearch(c)
+		return
+	}
+
 	var request models.SearchRequest
 
 	// Parse search parameters
@@ -1826,6 +3233,15 @@ func (s *Server) handleGrabRelease(c *gin.Context) {
 
 	response, err := s.services.SearchService.GrabRelease(&request)
 	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "release not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Release not found"})
+			return
+		}
 		s.logger.Error("Failed to grab release", "guid", request.GUID, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to grab release"})
 		return
@@ -2062,6 +3478,31 @@ func (s *Server) handleDeleteScheduledTask(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Scheduled task deleted"})
 }
 
+// handleGetTaskHistory returns the run history of a command, or of the scheduled task with the
+// given name or ID
+func (s *Server) handleGetTaskHistory(c *gin.Context) {
+	days, limit := 0, 0
+	if d := c.Query("days"); d != "" {
+		if parsedDays, err := strconv.Atoi(d); err == nil && parsedDays > 0 {
+			days = parsedDays
+		}
+	}
+	if l := c.Query("limit"); l != "" {
+		if parsedLimit, err := strconv.Atoi(l); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	history, err := s.services.TaskService.GetTaskHistory(c.Param("id"), days, limit)
+	if err != nil {
+		s.logger.Error("Failed to get task history", "task", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve task history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
 // Command handlers for specific task operations
 
 // handleRefreshMovie queues a refresh task for a specific movie
@@ -2142,6 +3583,82 @@ func (s *Server) handleRunCleanup(c *gin.Context) {
 	c.JSON(http.StatusCreated, task)
 }
 
+// handleRestart handles POST /api/v3/system/restart
+func (s *Server) handleRestart(c *gin.Context) {
+	s.requestLifecycleAction(c, LifecycleRestart, "Restarting")
+}
+
+// handleShutdown handles POST /api/v3/system/shutdown
+func (s *Server) handleShutdown(c *gin.Context) {
+	s.requestLifecycleAction(c, LifecycleShutdown, "Shutting down")
+}
+
+// requestLifecycleAction hands a restart or shutdown to the process owner. The response is sent
+// before the server stops, since shutdown waits for in-flight requests to finish.
+func (s *Server) requestLifecycleAction(c *gin.Context, action LifecycleAction, message string) {
+	select {
+	case s.lifecycle <- action:
+		s.logger.Info("Lifecycle action requested", "action", action, "client", c.ClientIP())
+		c.JSON(http.StatusAccepted, gin.H{"message": message})
+	default:
+		c.JSON(http.StatusConflict, gin.H{"error": "A restart or shutdown is already in progress"})
+	}
+}
+
+// logLevelRequest changes the global log level and per-component overrides. A component set
+// to an empty level follows the global level again.
+type logLevelRequest struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+// handleGetLogLevels handles GET /api/v3/log/level
+func (s *Server) handleGetLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, s.logger.Levels())
+}
+
+// handleUpdateLogLevels handles PUT /api/v3/log/level
+func (s *Server) handleUpdateLogLevels(c *gin.Context) {
+	var req logLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid log level request"})
+		return
+	}
+
+	if err := s.logger.ApplyLevels(req.Level, req.Components); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := s.logger.Levels()
+	s.logger.Info("Log levels updated", "level", status.Level, "overrides", status.Overrides)
+	c.JSON(http.StatusOK, status)
+}
+
+// readOnlyModeRequest turns read-only maintenance mode on or off
+type readOnlyModeRequest struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+}
+
+// handleGetReadOnlyMode handles GET /api/v3/system/readonly
+func (s *Server) handleGetReadOnlyMode(c *gin.Context) {
+	c.JSON(http.StatusOK, s.services.ReadOnly.Status())
+}
+
+// handleUpdateReadOnlyMode handles PUT /api/v3/system/readonly
+func (s *Server) handleUpdateReadOnlyMode(c *gin.Context) {
+	var req readOnlyModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid read-only mode request"})
+		return
+	}
+
+	s.services.ReadOnly.Set(req.Enabled, req.Reason)
+	s.logger.Info("Read-only mode updated", "enabled", req.Enabled, "reason", req.Reason)
+	c.JSON(http.StatusOK, s.services.ReadOnly.Status())
+}
+
 // File Organization and Import Handlers
 
 // handleGetFileOrganizations returns file organization records
@@ -2188,6 +3705,103 @@ func (s *Server) handleRetryFailedOrganizations(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Retry initiated for failed file organizations"})
 }
 
+// handleGetPendingOrganizations handles GET /api/v3/fileorganization/pending
+func (s *Server) handleGetPendingOrganizations(c *gin.Context) {
+	organizations, err := s.services.FileOrganizationService.GetPendingOrganizations()
+	if err != nil {
+		s.logger.Error("Failed to get pending file organizations", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get pending file organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// organizationPriorityRequest sets the priority of a pending file organization
+type organizationPriorityRequest struct {
+	Priority int `json:"priority"`
+}
+
+// handleSetOrganizationPriority handles PUT /api/v3/fileorganization/:id/priority
+func (s *Server) handleSetOrganizationPriority(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req organizationPriorityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid priority request"})
+		return
+	}
+
+	organization, err := s.services.FileOrganizationService.SetPriority(id, req.Priority)
+	if err != nil {
+		var validationErr models.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "File organization not found"})
+		default:
+			s.logger.Error("Failed to set file organization priority", "id", id, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set file organization priority"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// importPauseRequest pauses or resumes imports
+type importPauseRequest struct {
+	Paused bool   `json:"paused"`
+	Reason string `json:"reason"`
+}
+
+// handleGetImportPause handles GET /api/v3/import/pause
+func (s *Server) handleGetImportPause(c *gin.Context) {
+	status, err := s.services.FileOperationService.GetImportQueueStatus()
+	if err != nil {
+		s.logger.Error("Failed to get import queue status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get import queue status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// handleUpdateImportPause handles PUT /api/v3/import/pause. Resuming queues the import of the
+// files queued while paused.
+func (s *Server) handleUpdateImportPause(c *gin.Context) {
+	var req importPauseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import pause request"})
+		return
+	}
+
+	wasPaused := s.services.ImportPause.Paused()
+	if err := s.services.ImportPause.Set(req.Paused, req.Reason); err != nil {
+		s.logger.Error("Failed to update import pause", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update import pause"})
+		return
+	}
+
+	if wasPaused && !req.Paused {
+		if _, err := s.services.TaskService.QueueTask(
+			"Process Queued Imports",
+			"ProcessQueuedImports",
+			models.JSONField{},
+			"high",
+		); err != nil {
+			s.logger.Error("Failed to queue processing of queued imports", "error", err)
+		}
+	}
+
+	s.handleGetImportPause(c)
+}
+
 // handleScanDirectory scans a directory for importable files
 func (s *Server) handleScanDirectory(c *gin.Context) {
 	var request struct {
@@ -2214,6 +3828,7 @@ func (s *Server) handleProcessImport(c *gin.Context) {
 	var request struct {
 		Path       string                    `json:"path" binding:"required"`
 		ImportMode models.ImportDecisionType `json:"importMode"`
+		DownloadID string                    `json:"downloadId"`
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -2223,6 +3838,7 @@ func (s *Server) handleProcessImport(c *gin.Context) {
 
 	options := &services.ImportOptions{
 		ImportMode: request.ImportMode,
+		DownloadID: request.DownloadID,
 	}
 
 	if options.ImportMode == "" {
@@ -2306,6 +3922,44 @@ func (s *Server) handlePreviewNaming(c *gin.Context) {
 	c.JSON(http.StatusOK, preview)
 }
 
+// handlePreviewNamingSample renders the naming formats with the sample movie, quality and media info
+// in the body. Naming settings in the body override the configured ones for this preview only.
+func (s *Server) handlePreviewNamingSample(c *gin.Context) {
+	config, err := s.services.NamingService.GetNamingConfig()
+	if err != nil {
+		s.logger.Error("Failed to get naming config", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get naming config"})
+		return
+	}
+
+	request := models.NamingPreviewRequest{Naming: config}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid naming preview request", "details": err.Error()})
+		return
+	}
+	if request.Naming == nil {
+		request.Naming = config
+	}
+	if errs := request.Naming.ValidateConfiguration(); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid naming configuration", "errors": errs})
+		return
+	}
+
+	preview, err := s.services.NamingService.PreviewSample(&request)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		s.logger.Error("Failed to generate naming preview", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate naming preview"})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
 // handleGetFileOperations returns file operations
 func (s *Server) handleGetFileOperations(c *gin.Context) {
 	status := models.FileOperationStatus(c.Query("status"))
@@ -2518,7 +4172,15 @@ func (s *Server) handleGetNotificationHistory(c *gin.Context) {
 		}
 	}
 
-	history, err := s.services.NotificationService.GetNotificationHistory(limit, offset)
+	status := models.NotificationDeliveryStatus(c.Query("status"))
+	switch status {
+	case "", models.NotificationDeliveryDelivered, models.NotificationDeliveryFailed, models.NotificationDeliveryQueued:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	history, err := s.services.NotificationService.GetNotificationHistoryByStatus(status, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -2527,6 +4189,33 @@ func (s *Server) handleGetNotificationHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, history)
 }
 
+// handleRetryNotificationDelivery resends a failed notification delivery
+func (s *Server) handleRetryNotificationDelivery(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := s.services.NotificationService.RetryNotificationDelivery(id)
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification delivery not found"})
+			return
+		}
+		s.logger.Error("Failed to retry notification delivery", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry notification delivery"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
 // Calendar API handlers
 
 // handleGetCalendar retrieves calendar events based on query parameters
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/services"
+)
+
+const (
+	// idempotencyKeyHeader carries the client's key for a request that must not be applied twice
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks a response replayed from an earlier request with the same key
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength is the longest key that is stored
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyMiddleware makes a route safe to retry. The first request with an Idempotency-Key is
+// handled and its response stored; later requests with the key get that response replayed rather
+// than being handled again. Requests without the header are handled as usual.
+func idempotencyMiddleware(services *services.Container, logger *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || services == nil || services.IdempotencyService == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large"})
+			} else {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(sum[:])
+		method, path := c.Request.Method, c.Request.URL.Path
+
+		record, reserved, err := services.IdempotencyService.Reserve(
+			c.Request.Context(), key, method, path, requestHash)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		if !reserved {
+			switch {
+			case !record.Matches(method, path, requestHash):
+				c.JSON(http.StatusUnprocessableEntity, gin.H{
+					"error": "Idempotency-Key was already used for a different request",
+				})
+			case !record.Completed:
+				c.JSON(http.StatusConflict, gin.H{
+					"error": "A request with this Idempotency-Key is still being processed",
+				})
+			default:
+				c.Header(idempotentReplayedHeader, "true")
+				c.Data(record.StatusCode, "application/json; charset=utf-8", []byte(record.Response))
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The outcome is stored even if the client has gone away, since that is when it retries
+		ctx := context.WithoutCancel(c.Request.Context())
+		if status := recorder.Status(); status >= http.StatusInternalServerError {
+			err = services.IdempotencyService.Release(ctx, record.ID)
+		} else {
+			err = services.IdempotencyService.Complete(ctx, record.ID, status, recorder.body.String())
+		}
+		if err != nil && logger != nil {
+			logger.Error("Failed to record idempotent request outcome", "path", path, "error", err)
+		}
+	}
+}
+
+// idempotent returns the idempotency middleware for routes that create movies, grabs or tasks
+func (s *Server) idempotent() gin.HandlerFunc {
+	return idempotencyMiddleware(s.services, s.logger)
+}
+
+// responseRecorder copies the response body as it is written, for storing with its idempotency key
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,158 @@
+//nolint:revive // "api" is a standard package name for API layers
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// === LIBRARY MAINTENANCE HANDLERS ===
+
+// handleGetLibraryMaintenance handles GET /api/v3/library/maintenance
+func (s *Server) handleGetLibraryMaintenance(c *gin.Context) {
+	report := s.services.LibraryMaintenanceService.GetLastReport()
+	if report == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No library maintenance report available, run the LibraryMaintenance task first",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// handleRunLibraryMaintenance handles POST /api/v3/library/maintenance
+func (s *Server) handleRunLibraryMaintenance(c *gin.Context) {
+	task, err := s.services.TaskService.QueueTask(
+		"Library Maintenance",
+		"LibraryMaintenance",
+		models.JSONField{},
+		"low",
+	)
+	if err != nil {
+		s.logger.Error("Failed to queue library maintenance task", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue library maintenance"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// handleFixLibraryMaintenance handles POST /api/v3/library/maintenance/fix
+func (s *Server) handleFixLibraryMaintenance(c *gin.Context) {
+	var request struct {
+		Category models.LibraryIssueCategory `json:"category" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !request.Category.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid library issue category"})
+		return
+	}
+
+	if !request.Category.IsFixable() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Library issue category is report only"})
+		return
+	}
+
+	if s.services.LibraryMaintenanceService.GetLastReport() == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No library maintenance report available"})
+		return
+	}
+
+	result, err := s.services.LibraryMaintenanceService.FixCategory(request.Category)
+	if err != nil {
+		s.logger.Error("Failed to apply library maintenance fix", "category", request.Category, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply library maintenance fix"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// === DUPLICATE FILE HANDLERS ===
+
+// handleGetDuplicates handles GET /api/v3/library/duplicates
+func (s *Server) handleGetDuplicates(c *gin.Context) {
+	groups, err := s.services.DuplicateService.GetDuplicates()
+	if err != nil {
+		s.logger.Error("Failed to get duplicate movie files", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get duplicate movie files"})
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+// handleFingerprintDuplicates handles POST /api/v3/library/duplicates
+func (s *Server) handleFingerprintDuplicates(c *gin.Context) {
+	if !s.services.DuplicateService.Enabled() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Duplicate fingerprinting is disabled; set duplicates.enabled to enable it",
+		})
+		return
+	}
+
+	task, err := s.services.TaskService.QueueTask(
+		"Fingerprint Duplicates",
+		"FingerprintDuplicates",
+		models.JSONField{},
+		"low",
+	)
+	if err != nil {
+		s.logger.Error("Failed to queue duplicate fingerprinting task", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue duplicate fingerprinting"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// libraryStatsDefaultDays is how far back the library statistics series goes without a from date
+const libraryStatsDefaultDays = 90
+
+// handleGetLibraryStats handles GET /api/v3/library/stats
+func (s *Server) handleGetLibraryStats(c *gin.Context) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -libraryStatsDefaultDays)
+
+	for param, value := range map[string]*time.Time{"from": &from, "to": &to} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := parseStatsDate(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be a date (YYYY-MM-DD) or RFC 3339 time"})
+			return
+		}
+		*value = parsed
+	}
+	if from.After(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	snapshots, err := s.services.LibraryStatsService.GetSnapshots(from, to)
+	if err != nil {
+		s.logger.Error("Failed to get library statistics", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get library statistics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshots)
+}
+
+// parseStatsDate parses a date or an RFC 3339 time
+func parseStatsDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
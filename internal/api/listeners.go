@@ -0,0 +1,108 @@
+//nolint:revive // "api" is a standard package name for API layers
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/radarr/radarr-go/internal/config"
+)
+
+// serverListener is one address the HTTP server accepts connections on
+type serverListener struct {
+	net.Listener
+	// secure reports whether TLS is served on the listener when SSL is enabled
+	secure bool
+}
+
+// listenAddresses returns the TCP addresses to bind. BindAddresses takes precedence over Host,
+// and entries without a port use the configured port.
+func listenAddresses(cfg config.ServerConfig) []string {
+	port := strconv.Itoa(cfg.Port)
+
+	if len(cfg.BindAddresses) == 0 {
+		return []string{net.JoinHostPort(cfg.Host, port)}
+	}
+
+	addresses := make([]string, 0, len(cfg.BindAddresses))
+	for _, address := range cfg.BindAddresses {
+		if _, _, err := net.SplitHostPort(address); err == nil {
+			addresses = append(addresses, address)
+			continue
+		}
+		// Bracketed IPv6 hosts without a port are accepted as written
+		host := address
+		if len(host) > 1 && host[0] == '[' && host[len(host)-1] == ']' {
+			host = host[1 : len(host)-1]
+		}
+		addresses = append(addresses, net.JoinHostPort(host, port))
+	}
+	return addresses
+}
+
+// openListeners binds every configured TCP address and the Unix socket, closing whatever was
+// already opened if any of them fails
+func openListeners(cfg config.ServerConfig) ([]serverListener, error) {
+	listeners := make([]serverListener, 0, len(cfg.BindAddresses)+1)
+	closeAll := func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}
+
+	for _, address := range listenAddresses(cfg) {
+		l, err := net.Listen("tcp", address)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+		}
+		listeners = append(listeners, serverListener{Listener: l, secure: true})
+	}
+
+	if cfg.UnixSocket != "" {
+		l, err := listenUnix(cfg)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		listeners = append(listeners, serverListener{Listener: l})
+	}
+
+	return listeners, nil
+}
+
+// listenUnix creates the Unix domain socket with the configured permissions. A socket file left
+// behind by an unclean exit is removed first; any other file at the path is left alone.
+func listenUnix(cfg config.ServerConfig) (net.Listener, error) {
+	mode, err := cfg.SocketMode()
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Lstat(cfg.UnixSocket); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("unix socket path %s exists and is not a socket", cfg.UnixSocket)
+		}
+		if err := os.Remove(cfg.UnixSocket); err != nil {
+			return nil, fmt.Errorf("failed to remove stale unix socket %s: %w", cfg.UnixSocket, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to inspect unix socket path %s: %w", cfg.UnixSocket, err)
+	}
+
+	l, err := net.Listen("unix", cfg.UnixSocket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", cfg.UnixSocket, err)
+	}
+
+	if err := os.Chmod(cfg.UnixSocket, mode); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("failed to set unix socket permissions: %w", err)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,68 @@
+//nolint:revive // "api" is a standard package name for API layers
+package api
+
+import (
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenAddresses(t *testing.T) {
+	cfg := config.ServerConfig{Host: "0.0.0.0", Port: 7878}
+	assert.Equal(t, []string{"0.0.0.0:7878"}, listenAddresses(cfg))
+
+	cfg.BindAddresses = []string{"127.0.0.1", "192.168.1.10:8080", "::1", "[fe80::1]"}
+	assert.Equal(t, []string{"127.0.0.1:7878", "192.168.1.10:8080", "[::1]:7878", "[fe80::1]:7878"},
+		listenAddresses(cfg))
+}
+
+func TestOpenListeners_UnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket permissions are not supported on windows")
+	}
+
+	socket := filepath.Join(t.TempDir(), "radarr.sock")
+
+	// A socket left behind by a previous run is replaced
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	cfg := config.ServerConfig{Host: "127.0.0.1", Port: 0, UnixSocket: socket, UnixSocketPermissions: "0600"}
+	listeners, err := openListeners(cfg)
+	require.NoError(t, err)
+	require.Len(t, listeners, 2)
+	assert.True(t, listeners[0].secure)
+	assert.False(t, listeners[1].secure)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0600), info.Mode().Perm())
+
+	for _, l := range listeners {
+		require.NoError(t, l.Close())
+	}
+	_, err = os.Stat(socket)
+	assert.ErrorIs(t, err, fs.ErrNotExist, "closing the listener removes the socket")
+}
+
+func TestOpenListeners_UnixSocketPathInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "radarr.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0600))
+
+	_, err := openListeners(config.ServerConfig{Host: "127.0.0.1", Port: 0, UnixSocket: path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a socket")
+
+	_, err = openListeners(config.ServerConfig{Host: "127.0.0.1", Port: 0, UnixSocket: path + "2",
+		UnixSocketPermissions: "rw-rw----"})
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,15 +14,7 @@ import (
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/services"
-)
-
-const (
-	// HTTPReadTimeout defines the maximum duration for reading the entire request
-	HTTPReadTimeout = 15 * time.Second
-	// HTTPWriteTimeout defines the maximum duration before timing out writes
-	HTTPWriteTimeout = 15 * time.Second
-	// HTTPIdleTimeout defines the maximum amount of time to wait for the next request
-	HTTPIdleTimeout = 60 * time.Second
+	"github.com/radarr/radarr-go/web"
 )
 
 // Server represents the HTTP server for the Radarr API
@@ -30,8 +24,31 @@ type Server struct {
 	logger   *logger.Logger
 	engine   *gin.Engine
 	server   *http.Server
+
+	// lifecycle carries restart and shutdown requests to the process owner
+	lifecycle chan LifecycleAction
+
+	// stopWatchers ends background work tied to the listener, such as certificate reloading
+	stopWatchers context.CancelFunc
+
+	// buildInfo and startTime are reported by /system/status
+	buildInfo BuildInfo
+	startTime time.Time
+
+	// ui is the embedded web UI, nil when the build has none
+	ui *webUI
 }
 
+// LifecycleAction is a process-level action requested through the API
+type LifecycleAction string
+
+const (
+	// LifecycleShutdown stops the process
+	LifecycleShutdown LifecycleAction = "shutdown"
+	// LifecycleRestart stops the server and starts the process again with the same arguments
+	LifecycleRestart LifecycleAction = "restart"
+)
+
 // NewServer creates a new HTTP server instance with the provided configuration and services
 func NewServer(cfg *config.Config, services *services.Container, logger *logger.Logger) *Server {
 	if cfg.Log.Level != "debug" {
@@ -41,18 +58,31 @@ func NewServer(cfg *config.Config, services *services.Container, logger *logger.
 	engine := gin.New()
 	engine.Use(gin.Recovery())
 	engine.Use(loggingMiddleware(logger))
+	engine.Use(latencyMiddleware(services))
 	engine.Use(corsMiddleware())
 
+	// Request bodies over the configured size are refused; config.Load rejects invalid limits
+	if limit, err := cfg.Server.BodyLimit(); err == nil && limit > 0 {
+		engine.Use(bodyLimitMiddleware(limit))
+	}
+
 	// API key middleware for protected routes
 	if cfg.Auth.APIKey != "" {
-		engine.Use(apiKeyMiddleware(cfg.Auth.APIKey))
+		engine.Use(apiKeyMiddleware(cfg.Auth.APIKey, cfg.Auth.AdminAPIKey))
 	}
 
+	// After authentication, so only clients with a key learn about maintenance and its reason
+	engine.Use(readOnlyMiddleware(services))
+
 	server := &Server{
 		config:   cfg,
 		services: services,
 		logger:   logger,
 		engine:   engine,
+
+		lifecycle: make(chan LifecycleAction, 1),
+		buildInfo: embeddedBuildInfo(),
+		startTime: time.Now(),
 	}
 
 	server.setupRoutes()
@@ -65,19 +95,45 @@ func (s *Server) setupRoutes() {
 		c.JSON(http.StatusOK, gin.H{"message": "pong"})
 	})
 
+	// Readiness probe, only successful once startup has finished
+	s.engine.GET("/ready", s.handleReady)
+
+	// Liveness probe, failing when the database or task workers are stuck
+	s.engine.GET("/health/live", s.handleLive)
+
 	// API v3 routes (matching Radarr's API structure)
 	v3 := s.engine.Group("/api/v3")
 	s.setupAPIRoutes(v3)
 
-	// Serve static files (if any)
-	s.engine.Static("/static", "./web/static")
-	s.setupTemplateRoutes()
+	// Go runtime profiling, restricted to the admin API key
+	s.setupProfilingRoutes()
+
+	// Web UI, embedded from the frontend build
+	ui, err := newWebUI(web.UI(), s.config.Server.BasePath())
+	if err != nil {
+		s.logger.Warn("Failed to load the web UI", "error", err)
+	}
+	if ui == nil {
+		s.setupTemplateRoutes()
+		return
+	}
+	s.ui = ui
+	s.engine.GET("/initialize.json", s.handleInitialize)
+	s.engine.NoRoute(s.handleUI)
 }
 
 func (s *Server) setupAPIRoutes(v3 *gin.RouterGroup) {
 	// System info
 	v3.GET("/system/status", s.handleSystemStatus)
 
+	// Read-only maintenance mode
+	v3.GET("/system/readonly", s.handleGetReadOnlyMode)
+	v3.PUT("/system/readonly", s.handleUpdateReadOnlyMode)
+
+	// Runtime log levels
+	v3.GET("/log/level", s.handleGetLogLevels)
+	v3.PUT("/log/level", s.handleUpdateLogLevels)
+
 	// Movies
 	s.setupMovieRoutes(v3)
 
@@ -136,27 +192,59 @@ func (s *Server) setupAPIRoutes(v3 *gin.RouterGroup) {
 
 	// Rename functionality
 	s.setupRenameRoutes(v3)
+
+	// Library maintenance
+	s.setupLibraryRoutes(v3)
 }
 
 func (s *Server) setupMovieRoutes(v3 *gin.RouterGroup) {
 	movieRoutes := v3.Group("/movie")
 	movieRoutes.GET("", s.handleGetMovies)
 	movieRoutes.GET("/:id", s.handleGetMovie)
-	movieRoutes.POST("", s.handleCreateMovie)
+	movieRoutes.POST("", s.idempotent(), s.handleCreateMovie)
+	movieRoutes.POST("/import", s.handleImportMovies)
 	movieRoutes.PUT("/:id", s.handleUpdateMovie)
+	movieRoutes.PUT("/editor", s.handleMovieEditor)
+	movieRoutes.PUT("/monitor", s.handleSetMoviesMonitored)
+	movieRoutes.PUT("/:id/monitor", s.handleSetMovieMonitored)
 	movieRoutes.DELETE("/:id", s.handleDeleteMovie)
 
 	// Movie discovery and metadata endpoints
 	movieRoutes.GET("/lookup", s.handleMovieLookup)
 	movieRoutes.GET("/lookup/tmdb", s.handleMovieByTMDBID)
+	movieRoutes.POST("/lookup/batch", s.handleMovieBatchLookup)
 	movieRoutes.GET("/popular", s.handleMovieDiscoverPopular)
 	movieRoutes.GET("/trending", s.handleMovieDiscoverTrending)
+	movieRoutes.GET("/discover", s.handleMovieDiscover)
+	movieRoutes.GET("/watchproviders", s.handleGetWatchProviders)
 	movieRoutes.PUT("/:id/refresh", s.handleRefreshMovieMetadata)
 
+	// Posters and fanart, cached in the configured storage backend
+	v3.GET("/mediacover/:movieId/:filename", s.handleGetMediaCover)
+
 	movieFileRoutes := v3.Group("/moviefile")
 	movieFileRoutes.GET("", s.handleGetMovieFiles)
+	movieFileRoutes.GET("/release", s.handleGetMovieFileReleases)
 	movieFileRoutes.GET("/:id", s.handleGetMovieFile)
 	movieFileRoutes.DELETE("/:id", s.handleDeleteMovieFile)
+	movieFileRoutes.GET("/:id/stream", s.handleStreamMovieFile)
+	movieFileRoutes.GET("/:id/thumbnail", s.handleMovieFileThumbnail)
+
+	// Additional quality versions of a movie, each with its own profile and folder
+	movieVersionRoutes := v3.Group("/movieversion")
+	movieVersionRoutes.GET("", s.handleGetMovieVersions)
+	movieVersionRoutes.GET("/:id", s.handleGetMovieVersion)
+	movieVersionRoutes.POST("", s.handleCreateMovieVersion)
+	movieVersionRoutes.PUT("/:id", s.handleUpdateMovieVersion)
+	movieVersionRoutes.DELETE("/:id", s.handleDeleteMovieVersion)
+
+	// Saved movie index filters, applied with GET /movie?filterId=
+	customFilterRoutes := v3.Group("/customfilter")
+	customFilterRoutes.GET("", s.handleGetCustomFilters)
+	customFilterRoutes.GET("/:id", s.handleGetCustomFilter)
+	customFilterRoutes.POST("", s.handleCreateCustomFilter)
+	customFilterRoutes.PUT("/:id", s.handleUpdateCustomFilter)
+	customFilterRoutes.DELETE("/:id", s.handleDeleteCustomFilter)
 }
 
 func (s *Server) setupQualityRoutes(v3 *gin.RouterGroup) {
@@ -166,14 +254,24 @@ func (s *Server) setupQualityRoutes(v3 *gin.RouterGroup) {
 	qualityProfileRoutes.POST("", s.handleCreateQualityProfile)
 	qualityProfileRoutes.PUT("/:id", s.handleUpdateQualityProfile)
 	qualityProfileRoutes.DELETE("/:id", s.handleDeleteQualityProfile)
+	qualityProfileRoutes.POST("/:id/clone", s.handleCloneQualityProfile)
+	qualityProfileRoutes.PUT("/:id/order", s.handleReorderQualityProfileItems)
+	// TRaSH-Guides JSON, as synced by Recyclarr
+	qualityProfileRoutes.GET("/:id/export", s.handleExportQualityProfile)
+	qualityProfileRoutes.POST("/import", s.handleImportQualityProfile)
 
 	qualityDefinitionRoutes := v3.Group("/qualitydefinition")
 	qualityDefinitionRoutes.GET("", s.handleGetQualityDefinitions)
 	qualityDefinitionRoutes.GET("/:id", s.handleGetQualityDefinition)
+	qualityDefinitionRoutes.PUT("/update", s.handleUpdateQualityDefinitions)
 	qualityDefinitionRoutes.PUT("/:id", s.handleUpdateQualityDefinition)
 
 	customFormatRoutes := v3.Group("/customformat")
 	customFormatRoutes.GET("", s.handleGetCustomFormats)
+	customFormatRoutes.GET("/schema", s.handleGetCustomFormatSchema)
+	customFormatRoutes.GET("/test", s.handleTestCustomFormats)
+	customFormatRoutes.GET("/export", s.handleExportCustomFormats)
+	customFormatRoutes.POST("/import", s.handleImportCustomFormats)
 	customFormatRoutes.GET("/:id", s.handleGetCustomFormat)
 	customFormatRoutes.POST("", s.handleCreateCustomFormat)
 	customFormatRoutes.PUT("/:id", s.handleUpdateCustomFormat)
@@ -202,6 +300,19 @@ func (s *Server) setupDownloadClientRoutes(v3 *gin.RouterGroup) {
 
 	// Download history
 	v3.GET("/downloadhistory", s.handleGetDownloadHistory)
+
+	// Translate download client paths into paths Radarr can reach
+	remotePathMappingRoutes := v3.Group("/remotepathmapping")
+	remotePathMappingRoutes.GET("", s.handleGetRemotePathMappings)
+	remotePathMappingRoutes.GET("/:id", s.handleGetRemotePathMapping)
+	remotePathMappingRoutes.POST("", s.handleCreateRemotePathMapping)
+	remotePathMappingRoutes.PUT("/:id", s.handleUpdateRemotePathMapping)
+	remotePathMappingRoutes.DELETE("/:id", s.handleDeleteRemotePathMapping)
+
+	// Imported torrents seeding until their seed goals are met
+	seedingRoutes := v3.Group("/seeding")
+	seedingRoutes.GET("", s.handleGetSeedingTorrents)
+	seedingRoutes.POST("/cleanup", s.handleCleanupSeededTorrents)
 }
 
 func (s *Server) setupImportListRoutes(v3 *gin.RouterGroup) {
@@ -213,11 +324,19 @@ func (s *Server) setupImportListRoutes(v3 *gin.RouterGroup) {
 	importListRoutes.DELETE("/:id", s.handleDeleteImportList)
 	importListRoutes.POST("/test", s.handleTestImportList)
 	importListRoutes.POST("/:id/sync", s.handleSyncImportList)
+	importListRoutes.POST("/:id/preview", s.handlePreviewImportList)
 	importListRoutes.POST("/sync", s.handleSyncAllImportLists)
 	importListRoutes.GET("/stats", s.handleGetImportListStats)
 
 	// Import list movies
 	v3.GET("/importlistmovies", s.handleGetImportListMovies)
+	v3.POST("/importlistmovies/:id/approve", s.handleApproveImportListMovie)
+	v3.POST("/importlistmovies/:id/reject", s.handleRejectImportListMovie)
+
+	// Movie tombstones
+	v3.GET("/movietombstone", s.handleGetMovieTombstones)
+	v3.DELETE("/movietombstone/:id", s.handleDeleteMovieTombstone)
+	v3.DELETE("/movietombstone", s.handleClearMovieTombstones)
 }
 
 func (s *Server) setupQueueRoutes(v3 *gin.RouterGroup) {
@@ -227,6 +346,8 @@ func (s *Server) setupQueueRoutes(v3 *gin.RouterGroup) {
 	queueRoutes.DELETE("/:id", s.handleRemoveQueueItem)
 	queueRoutes.DELETE("/bulk", s.handleRemoveQueueItemsBulk)
 	queueRoutes.GET("/stats", s.handleGetQueueStats)
+	queueRoutes.GET("/unmapped", s.handleGetUnmappedQueueItems)
+	queueRoutes.POST("/:id/resolve", s.handleResolveQueueItem)
 }
 
 func (s *Server) setupNotificationRoutes(v3 *gin.RouterGroup) {
@@ -240,6 +361,7 @@ func (s *Server) setupNotificationRoutes(v3 *gin.RouterGroup) {
 	notificationRoutes.GET("/schema", s.handleGetNotificationProviders)
 	notificationRoutes.GET("/schema/:type", s.handleGetNotificationProviderFields)
 	notificationRoutes.GET("/history", s.handleGetNotificationHistory)
+	notificationRoutes.POST("/history/:id/retry", s.handleRetryNotificationDelivery)
 }
 
 func (s *Server) setupTemplateRoutes() {
@@ -252,7 +374,7 @@ func (s *Server) setupTemplateRoutes() {
 		s.engine.NoRoute(func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{
 				"message":       "Radarr Go API Server",
-				"version":       "1.0.0-go",
+				"version":       s.buildInfo.Version,
 				"documentation": "Access /api/v3/system/status for system information",
 			})
 		})
@@ -261,31 +383,85 @@ func (s *Server) setupTemplateRoutes() {
 
 // Start begins listening for HTTP requests on the configured address
 func (s *Server) Start() error {
-	addr := fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.Port)
+	timeouts, err := s.config.Server.Timeouts()
+	if err != nil {
+		return err
+	}
+	headerLimit, err := s.config.Server.HeaderLimit()
+	if err != nil {
+		return err
+	}
 
 	s.server = &http.Server{
-		Addr:         addr,
-		Handler:      s.engine,
-		ReadTimeout:  HTTPReadTimeout,
-		WriteTimeout: HTTPWriteTimeout,
-		IdleTimeout:  HTTPIdleTimeout,
+		Handler:           s.Handler(),
+		ReadTimeout:       timeouts.Read,
+		ReadHeaderTimeout: timeouts.ReadHeader,
+		WriteTimeout:      timeouts.Write,
+		IdleTimeout:       timeouts.Idle,
+		MaxHeaderBytes:    headerLimit,
 	}
 
-	s.logger.Info("Starting HTTP server", "address", addr)
-
 	if s.config.Server.EnableSSL {
-		return s.server.ListenAndServeTLS(s.config.Server.SSLCertPath, s.config.Server.SSLKeyPath)
+		reloader, err := newCertificateReloader(s.config.Server, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to load SSL certificate: %w", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopWatchers = cancel
+		go reloader.watch(ctx)
+
+		s.server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+	}
+
+	listeners, err := openListeners(s.config.Server)
+	if err != nil {
+		return err
+	}
+
+	// Every listener shares the server, so Shutdown stops them all and the first to return
+	// (http.ErrServerClosed on a clean stop) is reported
+	errs := make(chan error, len(listeners))
+	for _, l := range listeners {
+		useTLS := s.config.Server.EnableSSL && l.secure
+		s.logger.Info("Starting HTTP server", "network", l.Addr().Network(), "address", l.Addr().String(),
+			"tls", useTLS)
+
+		go func(l serverListener) {
+			if useTLS {
+				errs <- s.server.ServeTLS(l, "", "")
+				return
+			}
+			errs <- s.server.Serve(l)
+		}(l)
 	}
 
-	return s.server.ListenAndServe()
+	return <-errs
 }
 
 // Stop gracefully shuts down the HTTP server
 func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+
+	if s.stopWatchers != nil {
+		s.stopWatchers()
+	}
+
 	s.logger.Info("Shutting down HTTP server")
 	return s.server.Shutdown(ctx)
 }
 
+// LifecycleRequests delivers restart and shutdown requests made through the API. The owner of
+// the process is expected to stop the server and carry them out.
+func (s *Server) LifecycleRequests() <-chan LifecycleAction {
+	return s.lifecycle
+}
+
 // Middleware functions
 func loggingMiddleware(logger *logger.Logger) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
@@ -301,12 +477,53 @@ func loggingMiddleware(logger *logger.Logger) gin.HandlerFunc {
 	})
 }
 
+// latencyMiddleware feeds API request durations to the performance monitor's percentiles
+func latencyMiddleware(services *services.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if services == nil || services.PerformanceMonitor == nil {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		services.PerformanceMonitor.ObserveRequest(time.Since(start))
+	}
+}
+
+// bodyLimitMiddleware rejects requests whose body exceeds limit bytes. Declared lengths are
+// checked up front; chunked bodies fail when a handler reads past the limit.
+func bodyLimitMiddleware(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": fmt.Sprintf("Request body exceeds the %d MB limit", limit>>20),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// liftWriteDeadline removes the server write timeout from a response that can rightly take
+// longer, such as a file stream. Writers without deadlines, as in tests, are left alone.
+func liftWriteDeadline(w http.ResponseWriter) error {
+	err := http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("failed to lift the write deadline: %w", err)
+	}
+	return nil
+}
+
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-API-Key")
-		c.Header("Access-Control-Expose-Headers", "Content-Length")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-API-Key, Idempotency-Key")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, Idempotent-Replayed")
 		c.Header("Access-Control-Allow-Credentials", "true")
 
 		if c.Request.Method == "OPTIONS" {
@@ -318,20 +535,62 @@ func corsMiddleware() gin.HandlerFunc {
 	}
 }
 
-func apiKeyMiddleware(apiKey string) gin.HandlerFunc {
+// readOnlyAllowedPaths may still be changed in read-only mode, so it can be turned off and the
+// process restarted or stopped
+var readOnlyAllowedPaths = map[string]bool{
+	"/api/v3/system/readonly": true,
+	"/api/v3/system/restart":  true,
+	"/api/v3/system/shutdown": true,
+	"/api/v3/log/level":       true,
+}
+
+// readOnlyMiddleware refuses requests that change data with 503 while read-only mode is on
+func readOnlyMiddleware(services *services.Container) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Skip API key check for health endpoint
-		if c.Request.URL.Path == "/ping" {
+		if services == nil || !services.ReadOnly.Enabled() {
 			c.Next()
 			return
 		}
 
-		providedKey := c.GetHeader("X-API-Key")
-		if providedKey == "" {
-			providedKey = c.Query("apikey")
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if readOnlyAllowedPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		status := services.ReadOnly.Status()
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":    "Radarr is in read-only maintenance mode",
+			"readOnly": status,
+		})
+		c.Abort()
+	}
+}
+
+// probePaths are answered without an API key so orchestrators can call them
+var probePaths = map[string]bool{
+	"/ping":        true,
+	"/ready":       true,
+	"/health/live": true,
+}
+
+// apiKeyMiddleware requires the API key, or the admin API key, on every route except the probes and
+// the web UI
+func apiKeyMiddleware(apiKey, adminKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Skip API key check for the health, readiness and liveness probes, and for the pages and
+		// files browsers load before the UI can send the key
+		if probePaths[c.Request.URL.Path] || uiRequest(c.Request) {
+			c.Next()
+			return
 		}
 
-		if providedKey != apiKey {
+		providedKey := requestAPIKey(c)
+		if !keyMatches(providedKey, apiKey) && !keyMatches(providedKey, adminKey) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
 			c.Abort()
 			return
@@ -346,7 +605,9 @@ func (s *Server) setupHistoryRoutes(v3 *gin.RouterGroup) {
 	historyRoutes.GET("", s.handleGetHistory)
 	historyRoutes.GET("/:id", s.handleGetHistoryByID)
 	historyRoutes.DELETE("/:id", s.handleDeleteHistoryRecord)
+	historyRoutes.POST("/:id/redownload", s.handleRedownloadHistory)
 	historyRoutes.GET("/stats", s.handleGetHistoryStats)
+	historyRoutes.GET("/export", s.handleExportHistory)
 }
 
 func (s *Server) setupActivityRoutes(v3 *gin.RouterGroup) {
@@ -371,6 +632,17 @@ func (s *Server) setupConfigRoutes(v3 *gin.RouterGroup) {
 	v3.GET("/config/mediamanagement", s.handleGetMediaManagementConfig)
 	v3.PUT("/config/mediamanagement", s.handleUpdateMediaManagementConfig)
 
+	// UI configuration
+	v3.GET("/config/ui", s.handleGetUIConfig)
+	v3.PUT("/config/ui", s.handleUpdateUIConfig)
+	v3.PUT("/config/ui/:id", s.handleUpdateUIConfig) // The frontend saves config resources by id
+
+	// TMDB API key, stored encrypted and overriding the config file
+	v3.GET("/config/tmdb", s.handleGetTMDBConfig)
+	v3.PUT("/config/tmdb", s.handleUpdateTMDBConfig)
+	v3.DELETE("/config/tmdb", s.handleDeleteTMDBConfig)
+	v3.POST("/config/tmdb/test", s.handleTestTMDBConfig)
+
 	// Root folders
 	rootFolderRoutes := v3.Group("/rootfolder")
 	rootFolderRoutes.GET("", s.handleGetRootFolders)
@@ -392,22 +664,29 @@ func (s *Server) setupConfigRoutes(v3 *gin.RouterGroup) {
 
 	// Configuration backup and restore
 	v3.POST("/config/backup", s.handleCreateConfigurationBackup)
+	v3.GET("/config/backup", s.handleListConfigurationBackups)
+	v3.GET("/config/backup/:name", s.handleDownloadConfigurationBackup)
 	v3.POST("/config/restore", s.handleRestoreConfigurationBackup)
 	v3.POST("/config/reset", s.handleFactoryResetConfiguration)
 
 	// Configuration export/import
 	v3.GET("/config/export", s.handleExportConfiguration)
 	v3.POST("/config/import", s.handleImportConfiguration)
+
+	// Declarative configuration manifests
+	v3.GET("/config/manifest", s.handleExportConfigManifest)
+	v3.POST("/config/manifest", s.handleApplyConfigManifest)
 }
 
 func (s *Server) setupSearchRoutes(v3 *gin.RouterGroup) {
 	// Release routes
 	releaseRoutes := v3.Group("/release")
 	releaseRoutes.GET("", s.handleGetReleases)
+	releaseRoutes.POST("", s.idempotent(), s.handleGrabRelease) // upstream's grab route, used by third-party tools
 	releaseRoutes.GET("/:id", s.handleGetRelease)
 	releaseRoutes.DELETE("/:id", s.handleDeleteRelease)
 	releaseRoutes.GET("/stats", s.handleGetReleaseStats)
-	releaseRoutes.POST("/grab", s.handleGrabRelease)
+	releaseRoutes.POST("/grab", s.idempotent(), s.handleGrabRelease)
 
 	// Search routes
 	searchRoutes := v3.Group("/search")
@@ -421,7 +700,7 @@ func (s *Server) setupTaskRoutes(v3 *gin.RouterGroup) {
 	commandRoutes := v3.Group("/command")
 	commandRoutes.GET("", s.handleGetTasks)
 	commandRoutes.GET("/:id", s.handleGetTask)
-	commandRoutes.POST("", s.handleQueueTask)
+	commandRoutes.POST("", s.idempotent(), s.handleQueueTask)
 	commandRoutes.DELETE("/:id", s.handleCancelTask)
 
 	// System task routes
@@ -430,6 +709,7 @@ func (s *Server) setupTaskRoutes(v3 *gin.RouterGroup) {
 	systemRoutes.POST("", s.handleCreateScheduledTask)
 	systemRoutes.PUT("/:id", s.handleUpdateScheduledTask)
 	systemRoutes.DELETE("/:id", s.handleDeleteScheduledTask)
+	systemRoutes.GET("/:id/history", s.handleGetTaskHistory) // :id is a command or scheduled task name, or an ID
 	systemRoutes.GET("/status", s.handleGetQueueStatus)
 
 	// Command-specific task routes
@@ -442,6 +722,10 @@ func (s *Server) setupTaskRoutes(v3 *gin.RouterGroup) {
 	systemCommands := v3.Group("/system")
 	systemCommands.POST("/health", s.handleRunHealthCheck)
 	systemCommands.POST("/cleanup", s.handleRunCleanup)
+	systemCommands.POST("/restart", s.handleRestart)
+	systemCommands.POST("/shutdown", s.handleShutdown)
+	systemCommands.GET("/diagnostics", adminKeyMiddleware(s.config.Auth.AdminAPIKey), s.longResponseMiddleware(),
+		s.handleDiagnosticsBundle)
 }
 
 func (s *Server) setupFileOrganizationRoutes(v3 *gin.RouterGroup) {
@@ -451,15 +735,20 @@ func (s *Server) setupFileOrganizationRoutes(v3 *gin.RouterGroup) {
 	orgRoutes.GET("/:id", s.handleGetFileOrganizationByID)
 	orgRoutes.POST("/retry", s.handleRetryFailedOrganizations)
 	orgRoutes.POST("/scan", s.handleScanDirectory)
+	orgRoutes.GET("/pending", s.handleGetPendingOrganizations)
+	orgRoutes.PUT("/:id/priority", s.handleSetOrganizationPriority)
 
 	// Import routes
 	importRoutes := v3.Group("/import")
 	importRoutes.POST("/process", s.handleProcessImport)
 	importRoutes.GET("/manual", s.handleGetManualImports)
 	importRoutes.POST("/manual", s.handleProcessManualImport)
+	importRoutes.GET("/pause", s.handleGetImportPause)
+	importRoutes.PUT("/pause", s.handleUpdateImportPause)
 
 	// Additional naming routes (basic naming routes are in setupConfigRoutes)
 	v3.GET("/config/naming/preview/:movieId", s.handlePreviewNaming)
+	v3.POST("/config/naming/preview", s.handlePreviewNamingSample)
 
 	// File operation tracking routes
 	operationRoutes := v3.Group("/fileoperation")
@@ -529,12 +818,16 @@ func (s *Server) setupWantedRoutes(v3 *gin.RouterGroup) {
 	wantedRoutes.GET("/cutoff", s.handleGetCutoffUnmetMovies)       // Get cutoff unmet movies
 	wantedRoutes.GET("", s.handleGetAllWantedMovies)                // Get all wanted movies with filters
 	wantedRoutes.GET("/stats", s.handleGetWantedStats)              // Get wanted movies statistics
+	wantedRoutes.GET("/export", s.handleExportWanted)               // Export wanted movies as CSV or NDJSON
 	wantedRoutes.GET("/:id", s.handleGetWantedMovie)                // Get specific wanted movie
 	wantedRoutes.POST("/search", s.handleTriggerWantedSearch)       // Trigger searches for wanted movies
 	wantedRoutes.POST("/bulk", s.handleWantedBulkOperation)         // Bulk operations on wanted movies
 	wantedRoutes.POST("/refresh", s.handleRefreshWantedMovies)      // Refresh wanted movies analysis
 	wantedRoutes.PUT("/:id/priority", s.handleUpdateWantedPriority) // Update wanted movie priority
 	wantedRoutes.DELETE("/:id", s.handleRemoveWantedMovie)          // Remove from wanted list
+	wantedRoutes.PUT("/:id/snooze", s.handleSnoozeWantedMovie)      // Snooze automatic searches
+	wantedRoutes.DELETE("/:id/snooze", s.handleUnsnoozeWantedMovie) // Clear a snooze
+	wantedRoutes.PUT("/:id/exclusion", s.handleWantedExclusion)     // Exclude from automatic searches
 }
 
 // setupCollectionRoutes configures movie collection management routes
@@ -556,6 +849,14 @@ func (s *Server) setupParseRoutes(v3 *gin.RouterGroup) {
 	parseRoutes.GET("", s.handleParseReleaseTitle)        // Parse single release title
 	parseRoutes.POST("", s.handleParseMultipleTitles)     // Parse multiple release titles
 	parseRoutes.DELETE("/cache", s.handleClearParseCache) // Clear parse cache
+
+	sceneMappingRoutes := v3.Group("/scenemapping")
+	sceneMappingRoutes.GET("", s.handleGetSceneMappings)              // List scene mappings
+	sceneMappingRoutes.POST("/refresh", s.handleRefreshSceneMappings) // Refresh from the remote list
+	sceneMappingRoutes.GET("/:id", s.handleGetSceneMapping)           // Get scene mapping
+	sceneMappingRoutes.POST("", s.handleCreateSceneMapping)           // Create manual scene mapping
+	sceneMappingRoutes.PUT("/:id", s.handleUpdateSceneMapping)        // Update scene mapping
+	sceneMappingRoutes.DELETE("/:id", s.handleDeleteSceneMapping)     // Delete scene mapping
 }
 
 // setupRenameRoutes configures file and folder renaming routes
@@ -566,3 +867,14 @@ func (s *Server) setupRenameRoutes(v3 *gin.RouterGroup) {
 	renameRoutes.GET("/preview/folder", s.handlePreviewMovieFolderRename) // Preview folder renames
 	renameRoutes.POST("/folder", s.handleRenameMovieFolders)              // Execute folder renames
 }
+
+// setupLibraryRoutes configures library maintenance routes
+func (s *Server) setupLibraryRoutes(v3 *gin.RouterGroup) {
+	libraryRoutes := v3.Group("/library")
+	libraryRoutes.GET("/maintenance", s.handleGetLibraryMaintenance)      // Get latest maintenance report
+	libraryRoutes.POST("/maintenance", s.handleRunLibraryMaintenance)     // Queue maintenance scan
+	libraryRoutes.POST("/maintenance/fix", s.handleFixLibraryMaintenance) // Apply fix to a category
+	libraryRoutes.GET("/duplicates", s.handleGetDuplicates)               // Movies with more than one file
+	libraryRoutes.POST("/duplicates", s.handleFingerprintDuplicates)      // Queue duplicate fingerprinting
+	libraryRoutes.GET("/stats", s.handleGetLibraryStats)                  // Daily library statistics
+}
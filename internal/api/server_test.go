@@ -2,16 +2,21 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/services"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPingHandler(t *testing.T) {
@@ -45,6 +50,36 @@ func TestPingHandler(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "pong")
 }
 
+func TestReadyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Log:  config.LogConfig{Level: "error"},
+		Auth: config.AuthConfig{APIKey: "secret"},
+	}
+	readiness := services.NewReadiness()
+	server := NewServer(cfg, &services.Container{Readiness: readiness}, logger.New(cfg.Log))
+
+	ready := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", "/ready", http.NoBody)
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	readiness.Complete(services.ReadinessMigrations)
+	readiness.Complete(services.ReadinessServices)
+	w := ready()
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"pending":["startupChecks","scheduler"]`)
+
+	readiness.Complete(services.ReadinessStartupChecks)
+	readiness.Complete(services.ReadinessScheduler)
+	w = ready()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ready":true`)
+}
+
 func TestSystemStatusHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -89,3 +124,265 @@ func TestSystemStatusHandler(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "1.0.0-go")
 	assert.Contains(t, w.Body.String(), "sqlite")
 }
+
+func TestServer_SetBuildInfo(t *testing.T) {
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}}
+	server := NewServer(cfg, &services.Container{}, logger.New(cfg.Log))
+
+	server.SetBuildInfo(BuildInfo{Version: "5.2.0", Commit: "unknown", Date: "2026-03-01_12:30:00"})
+	assert.Equal(t, "5.2.0", server.buildInfo.Version)
+	assert.Equal(t, "2026-03-01T12:30:00Z", server.buildInfo.buildTime())
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", "/api/v3/system/status", http.NoBody)
+	w := httptest.NewRecorder()
+	server.engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"version":"5.2.0"`)
+	assert.Contains(t, w.Body.String(), `"instanceName":"Radarr"`)
+	assert.Contains(t, w.Body.String(), `"branch":"master"`)
+	assert.Contains(t, w.Body.String(), `"buildTime":"2026-03-01T12:30:00Z"`)
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}}
+	container := &services.Container{ReadOnly: services.NewReadOnlyMode(true, "Database backup")}
+	server := NewServer(cfg, container, logger.New(cfg.Log))
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/api/v3/movie", `{}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Database backup")
+
+	w = serve(http.MethodGet, "/api/v3/system/status", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"readOnly":{"enabled":true`)
+
+	w = serve(http.MethodPut, "/api/v3/system/readonly", `{"enabled":false}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, container.ReadOnly.Enabled())
+}
+
+func TestReadOnlyMiddleware_AfterAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}, Auth: config.AuthConfig{APIKey: "secret"}}
+	container := &services.Container{ReadOnly: services.NewReadOnlyMode(true, "Database backup")}
+	server := NewServer(cfg, container, logger.New(cfg.Log))
+
+	serve := func(key string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v3/movie",
+			bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("X-Api-Key", key)
+		}
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	// Clients without a key are refused before learning about maintenance
+	w := serve("")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NotContains(t, w.Body.String(), "Database backup")
+
+	w = serve("secret")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Database backup")
+}
+
+func TestRestartHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "error",
+		},
+	}
+	logger := logger.New(cfg.Log)
+	server := NewServer(cfg, &services.Container{}, logger)
+
+	post := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), "POST", path, http.NoBody)
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	// The first request is handed to the process owner
+	assert.Equal(t, http.StatusAccepted, post("/api/v3/system/restart").Code)
+
+	// A second request while one is pending is rejected
+	assert.Equal(t, http.StatusConflict, post("/api/v3/system/shutdown").Code)
+
+	select {
+	case action := <-server.LifecycleRequests():
+		assert.Equal(t, LifecycleRestart, action)
+	default:
+		t.Fatal("expected a pending restart request")
+	}
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Log:    config.LogConfig{Level: "error"},
+		Server: config.ServerConfig{MaxBodySizeMB: 1},
+	}
+	server := NewServer(cfg, &services.Container{}, logger.New(cfg.Log))
+
+	put := func(body []byte, contentLength int64) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), "PUT", "/api/v3/log/level", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = contentLength
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	small := []byte(`{"level": "error"}`)
+	assert.Equal(t, http.StatusOK, put(small, int64(len(small))).Code)
+
+	large := append([]byte(`{"level": "`), bytes.Repeat([]byte("x"), 2<<20)...)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, put(large, int64(len(large))).Code)
+
+	// Bodies without a declared length are cut off while the handler reads them
+	assert.Equal(t, http.StatusBadRequest, put(large, -1).Code)
+}
+
+func TestServerConfig_Timeouts(t *testing.T) {
+	timeouts, err := config.ServerConfig{}.Timeouts()
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultReadTimeout, timeouts.Read)
+	assert.Equal(t, config.DefaultIdleTimeout, timeouts.Idle)
+
+	timeouts, err = config.ServerConfig{ReadTimeout: "5m", WriteTimeout: "0"}.Timeouts()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, timeouts.Read)
+	assert.Zero(t, timeouts.Write, "zero disables the timeout")
+
+	_, err = config.ServerConfig{IdleTimeout: "-1s"}.Timeouts()
+	assert.Error(t, err)
+	_, err = config.ServerConfig{ReadTimeout: "5s", ReadHeaderTimeout: "10s"}.Timeouts()
+	assert.Error(t, err, "the header timeout cannot exceed the read timeout")
+
+	assert.Error(t, config.ServerConfig{MaxHeaderBytes: 100}.Validate())
+	assert.Error(t, config.ServerConfig{MaxBodySizeMB: -1}.Validate())
+	assert.NoError(t, config.ServerConfig{}.Validate())
+}
+
+func TestMovieFilePreview_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}}
+	server := NewServer(cfg, &services.Container{}, logger.New(cfg.Log))
+
+	for _, path := range []string{"/api/v3/moviefile/1/stream", "/api/v3/moviefile/1/thumbnail"} {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", path, http.NoBody)
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+		assert.Contains(t, w.Body.String(), "preview.enabled")
+	}
+}
+
+func TestServeStream_WriteTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}}
+	server := NewServer(cfg, &services.Container{}, logger.New(cfg.Log))
+	// Larger than the socket buffers, so the server is still writing when the timeout passes
+	content := bytes.Repeat([]byte("radarr"), 8<<20)
+
+	engine := gin.New()
+	engine.GET("/stream", func(c *gin.Context) {
+		server.serveStream(c, "movie.mkv", time.Now(), bytes.NewReader(content))
+	})
+	ts := httptest.NewUnstartedServer(engine)
+	ts.Config.WriteTimeout = 100 * time.Millisecond
+	ts.Start()
+	defer ts.Close()
+
+	get := func(rangeHeader string) (*http.Response, []byte) {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL+"/stream", http.NoBody)
+		if rangeHeader != "" {
+			req.Header.Set("Range", rangeHeader)
+		}
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		// A player reading slowly takes longer than the write timeout
+		time.Sleep(300 * time.Millisecond)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, body
+	}
+
+	resp, body := get("")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, body, len(content))
+
+	resp, body = get("bytes=6-")
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Len(t, body, len(content)-6)
+}
+
+func TestMovieMonitor_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}}
+	log := logger.New(cfg.Log)
+	server := NewServer(cfg, &services.Container{MovieService: services.NewMovieService(nil, log)}, log)
+
+	for _, tc := range []struct {
+		path, body, error string
+	}{
+		{"/api/v3/movie/1/monitor", `{}`, "monitored is required"},
+		{"/api/v3/movie/monitor", `{"movieIds":[1]}`, "monitored is required"},
+		{"/api/v3/movie/monitor", `{"monitored":true}`, "movieIds, filterId or filters is required"},
+		{"/api/v3/movie/monitor", `{"monitored":true,"filters":[{"key":"unknown","value":[1],"type":"equal"}]}`, ""},
+	} {
+		req, _ := http.NewRequestWithContext(context.Background(), "PUT", tc.path, bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.body)
+		assert.Contains(t, w.Body.String(), tc.error, tc.body)
+	}
+}
+
+func TestImportListMovieError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{Log: config.LogConfig{Level: "error"}}
+	server := NewServer(cfg, &services.Container{}, logger.New(cfg.Log))
+
+	respond := func(err error) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		server.importListMovieError(c, "approve", 7, err)
+		return w
+	}
+
+	w := respond(errors.New("import list movie not found: 7"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// A movie TMDB doesn't know is a failure to approve, not a missing review item
+	w = respond(errors.New("failed to fetch metadata for approved movie: movie not found"))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to approve import list movie")
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// defaultVersion is reported when the binary was built without a version
+const defaultVersion = "1.0.0-go"
+
+// buildDateLayout is the layout of the build date the Makefile and release workflows link in
+const buildDateLayout = "2006-01-02_15:04:05"
+
+// osReleasePath describes the Linux distribution
+const osReleasePath = "/etc/os-release"
+
+// BuildInfo identifies the running binary, from the version, commit and date linked in at build
+// time
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// SetBuildInfo records the build information reported by /system/status. Empty and "unknown"
+// fields keep the values Go embedded from version control.
+func (s *Server) SetBuildInfo(info BuildInfo) {
+	if known(info.Version) {
+		s.buildInfo.Version = info.Version
+	}
+	if known(info.Commit) {
+		s.buildInfo.Commit = info.Commit
+	}
+	if known(info.Date) {
+		s.buildInfo.Date = info.Date
+	}
+}
+
+// known reports whether a linked-in build value was set
+func known(value string) bool {
+	return value != "" && value != "unknown"
+}
+
+// embeddedBuildInfo returns the commit and commit time Go embeds when building from a checkout
+func embeddedBuildInfo() BuildInfo {
+	info := BuildInfo{Version: defaultVersion}
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Commit = setting.Value
+		case "vcs.time":
+			info.Date = setting.Value
+		}
+	}
+	return info
+}
+
+// buildTime formats the build date as RFC 3339, passing through dates in other layouts
+func (b BuildInfo) buildTime() string {
+	if date, err := time.Parse(buildDateLayout, b.Date); err == nil {
+		return date.UTC().Format(time.RFC3339)
+	}
+	return b.Date
+}
+
+// osInfo returns the operating system name and version, taking the distribution from
+// /etc/os-release on Linux
+func osInfo() (string, string) {
+	if runtime.GOOS != "linux" {
+		return runtime.GOOS, "unknown"
+	}
+
+	file, err := os.Open(osReleasePath)
+	if err != nil {
+		return runtime.GOOS, "unknown"
+	}
+	defer func() { _ = file.Close() }()
+
+	name, version := runtime.GOOS, "unknown"
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			name = value
+		case "VERSION_ID":
+			version = value
+		}
+	}
+	return name, version
+}
+
+// isDocker reports whether the process runs in a Docker container
+func isDocker() bool {
+	_, err := os.Stat("/.dockerenv")
+	return err == nil
+}
+
+// databaseStatus returns the database server version and applied migration version, or
+// "unknown" and 0 when the database cannot be queried
+func (s *Server) databaseStatus(ctx context.Context) (string, uint) {
+	if s.services == nil || s.services.DB == nil {
+		return "unknown", 0
+	}
+
+	serverVersion, err := s.services.DB.ServerVersion(ctx)
+	if err != nil {
+		s.logger.Debug("Failed to get database server version", "error", err)
+		serverVersion = "unknown"
+	}
+
+	migrationVersion, _, err := s.services.DB.MigrationVersion(ctx)
+	if err != nil {
+		s.logger.Debug("Failed to get migration version", "error", err)
+	}
+	return serverVersion, migrationVersion
+}
+
+// hostConfig returns the stored host configuration, or the defaults when it cannot be loaded
+func (s *Server) hostConfig() *models.HostConfig {
+	if s.services != nil && s.services.ConfigService != nil {
+		if config, err := s.services.ConfigService.GetHostConfig(); err == nil {
+			return config
+		}
+	}
+	return models.GetDefaultHostConfig()
+}
@@ -0,0 +1,121 @@
+//nolint:revive // "api" is a standard package name for API layers
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/certificates"
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+)
+
+// certificateReloadInterval is how often the certificate files are checked for changes
+const certificateReloadInterval = 30 * time.Second
+
+// certificateReloader serves the configured certificate to the TLS listener and swaps in a new
+// one when the certificate or key file changes, so renewed certificates apply without a restart
+type certificateReloader struct {
+	certPath string
+	keyPath  string
+	password string
+	logger   *logger.Logger
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// newCertificateReloader loads the certificate from the server configuration
+func newCertificateReloader(cfg config.ServerConfig, logger *logger.Logger) (*certificateReloader, error) {
+	r := &certificateReloader{
+		certPath: cfg.SSLCertPath,
+		keyPath:  cfg.SSLKeyPath,
+		password: cfg.SSLCertPassword,
+		logger:   logger,
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *certificateReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// watch reloads the certificate whenever its files change until ctx is cancelled
+func (r *certificateReloader) watch(ctx context.Context) {
+	ticker := time.NewTicker(certificateReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			// A failed reload keeps serving the previous certificate, since renewals often
+			// write the certificate and key in separate steps
+			if err := r.reload(); err != nil {
+				r.logger.Error("Failed to reload SSL certificate", "path", r.certPath, "error", err)
+			}
+		}
+	}
+}
+
+// reload loads the certificate from disk and makes it the one served
+func (r *certificateReloader) reload() error {
+	modTime := r.latestModTime()
+
+	cert, err := certificates.Load(r.certPath, r.keyPath, r.password)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.cert = cert
+	r.modTime = modTime
+	r.mu.Unlock()
+
+	if cert.Leaf != nil {
+		r.logger.Info("Loaded SSL certificate", "subject", cert.Leaf.Subject.String(),
+			"expires", cert.Leaf.NotAfter)
+		if certificates.ExpiresSoon(cert) {
+			r.logger.Warn("SSL certificate expires soon", "expires", cert.Leaf.NotAfter)
+		}
+	}
+	return nil
+}
+
+// changed reports whether either certificate file was modified since the last load
+func (r *certificateReloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.latestModTime().After(r.modTime)
+}
+
+// latestModTime returns the most recent modification time of the certificate files
+func (r *certificateReloader) latestModTime() time.Time {
+	var latest time.Time
+	for _, path := range []string{r.certPath, r.keyPath} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -2,6 +2,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -311,6 +312,81 @@ func (s *Server) handleRemoveWantedMovie(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Wanted movie removed successfully"})
 }
 
+// handleSnoozeWantedMovie handles PUT /api/v3/wanted/:id/snooze
+func (s *Server) handleSnoozeWantedMovie(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var snooze struct {
+		Until time.Time `json:"until" binding:"required"`
+	}
+	if err = c.ShouldBindJSON(&snooze); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request body: %s", err.Error())})
+		return
+	}
+
+	s.respondAutoSearchOptOut(c, id, s.services.WantedMoviesService.SnoozeWantedMovie(id, snooze.Until))
+}
+
+// handleUnsnoozeWantedMovie handles DELETE /api/v3/wanted/:id/snooze
+func (s *Server) handleUnsnoozeWantedMovie(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.respondAutoSearchOptOut(c, id, s.services.WantedMoviesService.UnsnoozeWantedMovie(id))
+}
+
+// handleWantedExclusion handles PUT /api/v3/wanted/:id/exclusion
+func (s *Server) handleWantedExclusion(c *gin.Context) {
+	id, err := s.parseIDParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var exclusion struct {
+		Excluded *bool `json:"excluded" binding:"required"`
+	}
+	if err = c.ShouldBindJSON(&exclusion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request body: %s", err.Error())})
+		return
+	}
+
+	s.respondAutoSearchOptOut(c, id, s.services.WantedMoviesService.SetAutoSearchExcluded(id, *exclusion.Excluded))
+}
+
+// respondAutoSearchOptOut responds to a snooze or exclusion change with the updated wanted movie
+func (s *Server) respondAutoSearchOptOut(c *gin.Context, id int, err error) {
+	if err != nil {
+		var validationErr models.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Message, "field": validationErr.Field})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Wanted movie not found"})
+			return
+		}
+		s.logger.Error("Failed to update wanted movie automatic search", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update wanted movie"})
+		return
+	}
+
+	updated, err := s.services.WantedMoviesService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Wanted movie updated successfully"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
 // parseWantedMovieFilter parses query parameters into a WantedMovieFilter
 func (s *Server) parseWantedMovieFilter(c *gin.Context) *models.WantedMovieFilter {
 	filter := &models.WantedMovieFilter{
@@ -426,6 +502,12 @@ func (s *Server) parseTimeParams(c *gin.Context, filter *models.WantedMovieFilte
 		}
 	}
 
+	// notSearchedInDays finds stuck titles, including those never searched
+	if days, err := strconv.Atoi(c.Query("notSearchedInDays")); err == nil && days > 0 {
+		lastSearchBefore := time.Now().AddDate(0, 0, -days)
+		filter.LastSearchBefore = &lastSearchBefore
+	}
+
 	if lastSearchAfterStr := c.Query("lastSearchAfter"); lastSearchAfterStr != "" {
 		if lastSearchAfter, err := time.Parse(time.RFC3339, lastSearchAfterStr); err == nil {
 			filter.LastSearchAfter = &lastSearchAfter
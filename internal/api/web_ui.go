@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// uiIndex is the page client-side routes are answered with
+	uiIndex = "index.html"
+	// uiAssetsDir holds the files the frontend build names after their content, which never change
+	uiAssetsDir = "assets/"
+	// uiHashParam carries the content hash added to other files' URLs, so they can be cached too
+	uiHashParam = "h"
+	// uiHashLength is how many hex digits of a file's hash go in its URLs
+	uiHashLength = 8
+
+	immutableCacheControl  = "public, max-age=31536000, immutable"
+	revalidateCacheControl = "no-cache"
+)
+
+// uiReference matches the root-relative src and href attributes in index.html
+var uiReference = regexp.MustCompile(`(src|href)="/([^"?#]*)"`)
+
+// webUI serves a frontend build under the URL base. Content-hashed files are cached for good;
+// index.html and anything else is revalidated against its ETag.
+type webUI struct {
+	files fs.FS
+	// index is index.html with its links moved under the URL base and cache busted
+	index []byte
+	// hashes are each file's content hash, by path
+	hashes map[string]string
+}
+
+// newWebUI reads a frontend build, returning nil when it has no index.html
+func newWebUI(files fs.FS, basePath string) (*webUI, error) {
+	ui := &webUI{files: files, hashes: map[string]string{}}
+	err := fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		ui.hashes[name] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read web UI: %w", err)
+	}
+	if _, ok := ui.hashes[uiIndex]; !ok {
+		return nil, nil
+	}
+
+	index, err := fs.ReadFile(files, uiIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read web UI: %w", err)
+	}
+	ui.index = uiReference.ReplaceAllFunc(index, func(match []byte) []byte {
+		parts := uiReference.FindSubmatch(match)
+		name := string(parts[2])
+		ref := basePath + "/" + name
+		if hash, ok := ui.hashes[name]; ok && !strings.HasPrefix(name, uiAssetsDir) {
+			ref += "?" + uiHashParam + "=" + hash[:uiHashLength]
+		}
+		return []byte(fmt.Sprintf(`%s="%s"`, parts[1], ref))
+	})
+	sum := sha256.Sum256(ui.index)
+	ui.hashes[uiIndex] = hex.EncodeToString(sum[:])
+	return ui, nil
+}
+
+// serveFile answers a request for one of the build's files, reporting false when there is none
+func (ui *webUI) serveFile(c *gin.Context) bool {
+	name := strings.TrimPrefix(path.Clean("/"+c.Request.URL.Path), "/")
+	if name == "" || name == uiIndex {
+		ui.serveIndex(c)
+		return true
+	}
+	hash, ok := ui.hashes[name]
+	if !ok {
+		return false
+	}
+	data, err := fs.ReadFile(ui.files, name)
+	if err != nil {
+		return false
+	}
+
+	cacheControl := revalidateCacheControl
+	if strings.HasPrefix(name, uiAssetsDir) || c.Query(uiHashParam) == hash[:uiHashLength] {
+		cacheControl = immutableCacheControl
+	}
+	ui.serveContent(c, name, data, hash, cacheControl)
+	return true
+}
+
+// serveIndex answers with index.html, which is never cached so new builds are picked up
+func (ui *webUI) serveIndex(c *gin.Context) {
+	ui.serveContent(c, uiIndex, ui.index, ui.hashes[uiIndex], revalidateCacheControl)
+}
+
+func (ui *webUI) serveContent(c *gin.Context, name string, data []byte, hash, cacheControl string) {
+	c.Header("Cache-Control", cacheControl)
+	c.Header("ETag", `"`+hash[:16]+`"`)
+	// ServeContent answers If-None-Match from the ETag; embedded files have no modification time
+	http.ServeContent(c.Writer, c.Request, name, time.Time{}, bytes.NewReader(data))
+}
+
+// uiRequest reports whether a request may be for the web UI, which browsers load without an API key
+func uiRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	p := r.URL.Path
+	return p != "/api" && !strings.HasPrefix(p, "/api/") && !strings.HasPrefix(p, "/debug/")
+}
+
+// handleUI serves the web UI's files, answering the client-side routes of its history API with
+// index.html. Anything else, including unknown API routes, is not found.
+func (s *Server) handleUI(c *gin.Context) {
+	if uiRequest(c.Request) {
+		if s.ui.serveFile(c) {
+			return
+		}
+		// Paths with an extension are missing files rather than routes
+		if path.Ext(c.Request.URL.Path) == "" {
+			s.ui.serveIndex(c)
+			return
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+}
+
+// handleInitialize returns the settings the web UI bootstraps from, as Radarr's initialize.json
+// does. The API key only goes to requests that already carry a valid key: there is no login
+// session to vouch for the caller, and the key is what guards the API.
+func (s *Server) handleInitialize(c *gin.Context) {
+	hostConfig := s.hostConfig()
+	basePath := s.config.Server.BasePath()
+
+	apiKey := ""
+	if provided := requestAPIKey(c); keyMatches(provided, s.config.Auth.APIKey) ||
+		keyMatches(provided, s.config.Auth.AdminAPIKey) {
+		apiKey = s.config.Auth.APIKey
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"apiRoot":      basePath + "/api/v3",
+		"apiKey":       apiKey,
+		"release":      s.buildInfo.Version,
+		"version":      s.buildInfo.Version,
+		"instanceName": hostConfig.InstanceName,
+		"branch":       hostConfig.UpdateBranch,
+		"analytics":    hostConfig.AnalyticsEnabled,
+		"urlBase":      basePath,
+		"isProduction": gin.Mode() == gin.ReleaseMode,
+	})
+}
+
+// Handler returns the server's HTTP handler, which serves every route under the URL base as well as
+// from the root, so API clients that predate the URL base keep working
+func (s *Server) Handler() http.Handler {
+	basePath := s.config.Server.BasePath()
+	if basePath == "" {
+		return s.engine
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		if p == basePath || strings.HasPrefix(p, basePath+"/") {
+			r = r.Clone(r.Context())
+			r.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(p, basePath), "/")
+			r.URL.RawPath = ""
+		}
+		s.engine.ServeHTTP(w, r)
+	})
+}
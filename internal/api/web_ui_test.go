@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/services"
+	"github.com/radarr/radarr-go/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebUI(t *testing.T) {
+	files := fstest.MapFS{
+		"index.html": {Data: []byte(`<link rel="icon" href="/favicon.svg"><script src="/assets/app-1a2b.js"></script>` +
+			`<a href="https://example.com/">x</a>`)},
+		"favicon.svg":        {Data: []byte("<svg/>")},
+		"assets/app-1a2b.js": {Data: []byte("console.log(1)")},
+	}
+
+	ui, err := newWebUI(files, "/radarr")
+	require.NoError(t, err)
+	require.NotNil(t, ui)
+	index := string(ui.index)
+	assert.Contains(t, index, `src="/radarr/assets/app-1a2b.js"`)
+	assert.Contains(t, index, `href="/radarr/favicon.svg?h=`+ui.hashes["favicon.svg"][:uiHashLength]+`"`)
+	assert.Contains(t, index, `href="https://example.com/"`)
+
+	// Builds without an index have no UI
+	ui, err = newWebUI(fstest.MapFS{"favicon.svg": {Data: []byte("<svg/>")}}, "")
+	require.NoError(t, err)
+	assert.Nil(t, ui)
+}
+
+func TestServer_WebUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Log:    config.LogConfig{Level: "error"},
+		Server: config.ServerConfig{URLBase: "radarr/"},
+		Auth:   config.AuthConfig{APIKey: "secret"},
+	}
+	server := NewServer(cfg, &services.Container{}, logger.New(cfg.Log))
+	require.NotNil(t, server.ui, "the embedded build has an index.html")
+	assets, err := fs.Glob(web.UI(), "assets/*.js")
+	require.NoError(t, err)
+	require.NotEmpty(t, assets)
+
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", path, http.NoBody)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, req)
+		return w
+	}
+
+	// Pages and client-side routes need no API key and are revalidated
+	for _, path := range []string{"/radarr", "/radarr/", "/radarr/movies/1", "/"} {
+		w := get(path, nil)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html", path)
+		assert.Equal(t, revalidateCacheControl, w.Header().Get("Cache-Control"), path)
+		assert.Contains(t, w.Body.String(), `="/radarr/assets/`, path)
+	}
+	w := get("/radarr/", http.Header{"If-None-Match": {get("/radarr/", nil).Header().Get("ETag")}})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	// Content-hashed assets are cached for good
+	w = get("/radarr/"+assets[0], nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, immutableCacheControl, w.Header().Get("Cache-Control"))
+
+	// Missing files and API routes aren't answered with the page
+	assert.Equal(t, http.StatusNotFound, get("/radarr/assets/missing.js", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, get("/radarr/api/v3/missing", nil).Code)
+	assert.Equal(t, http.StatusNotFound, get("/radarr/api/v3/missing", http.Header{"X-Api-Key": {"secret"}}).Code)
+
+	// initialize.json never hands the key to callers that don't already have it
+	for _, method := range []string{"", "none", "forms"} {
+		cfg.Auth.Method = method
+		w = get("/radarr/initialize.json", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var initialize map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &initialize))
+		assert.Equal(t, "/radarr/api/v3", initialize["apiRoot"])
+		assert.Equal(t, "/radarr", initialize["urlBase"])
+		assert.Empty(t, initialize["apiKey"], method)
+	}
+	w = get("/radarr/initialize.json", http.Header{"X-Api-Key": {"wrong"}})
+	var initialize map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &initialize))
+	assert.Empty(t, initialize["apiKey"])
+
+	w = get("/radarr/initialize.json", http.Header{"X-Api-Key": {"secret"}})
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &initialize))
+	assert.Equal(t, "secret", initialize["apiKey"])
+}
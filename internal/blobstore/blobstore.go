@@ -0,0 +1,48 @@
+// Package blobstore keeps files such as backup archives and cached media covers on local disk or
+// in an S3-compatible bucket, so instances running on ephemeral containers can keep them elsewhere.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a key has no blob
+var ErrNotFound = errors.New("blob not found")
+
+// Object describes a stored blob
+type Object struct {
+	Key     string    `json:"key"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// Store keeps blobs under slash-separated keys such as "42/poster.jpg"
+type Store interface {
+	// Put stores body under key, replacing any blob there. size is the body length, or -1 when
+	// it isn't known.
+	Put(ctx context.Context, key string, body io.Reader, size int64) error
+	// Get opens the blob under key, returning ErrNotFound when there is none. The caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob under key; deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	// List returns the blobs whose keys start with prefix, ordered by key
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// validateKey rejects keys that could escape the store's root
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Package certificates loads and inspects the TLS certificates served by the API, accepting
+// either a PEM certificate and key pair or a password-protected PKCS#12 (.pfx) bundle.
+package certificates
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pkcs12" //nolint:staticcheck // Handles the legacy .pfx files Radarr users already have
+)
+
+// ExpiryWarningWindow is how far ahead of expiry a certificate is reported as expiring soon
+const ExpiryWarningWindow = 14 * 24 * time.Hour
+
+// IsPKCS12 reports whether the certificate path points at a PKCS#12 bundle, which carries its
+// own private key
+func IsPKCS12(certPath string) bool {
+	switch strings.ToLower(filepath.Ext(certPath)) {
+	case ".pfx", ".p12":
+		return true
+	default:
+		return false
+	}
+}
+
+// Load reads a certificate and its private key. PKCS#12 bundles are decrypted with password
+// and keyPath is ignored; otherwise certPath and keyPath must be PEM files.
+func Load(certPath, keyPath, password string) (*tls.Certificate, error) {
+	if certPath == "" {
+		return nil, errors.New("certificate path is not set")
+	}
+
+	var cert tls.Certificate
+	var err error
+
+	if IsPKCS12(certPath) {
+		cert, err = loadPKCS12(certPath, password)
+	} else {
+		if keyPath == "" {
+			return nil, errors.New("key path is required for PEM certificates")
+		}
+		cert, err = tls.LoadX509KeyPair(certPath, keyPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate %s: %w", certPath, err)
+	}
+
+	if cert.Leaf == nil && len(cert.Certificate) > 0 {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %s: %w", certPath, err)
+		}
+	}
+
+	return &cert, nil
+}
+
+// loadPKCS12 decodes every certificate and key in a PKCS#12 bundle, keeping the chain
+func loadPKCS12(path, password string) (tls.Certificate, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- Path comes from administrator configuration
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to decode PKCS#12 bundle: %w", err)
+	}
+
+	var certPEM, keyPEM []byte
+	for _, block := range blocks {
+		encoded := pem.EncodeToMemory(block)
+		if block.Type == "CERTIFICATE" {
+			certPEM = append(certPEM, encoded...)
+		} else {
+			keyPEM = append(keyPEM, encoded...)
+		}
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// Validate loads the certificate and returns an error when it cannot be used or has expired
+func Validate(certPath, keyPath, password string) error {
+	cert, err := Load(certPath, keyPath, password)
+	if err != nil {
+		return err
+	}
+
+	if cert.Leaf != nil && time.Now().After(cert.Leaf.NotAfter) {
+		return fmt.Errorf("certificate %s expired on %s", certPath, cert.Leaf.NotAfter.Format(time.DateOnly))
+	}
+
+	return nil
+}
+
+// ExpiresSoon reports whether the certificate expires within ExpiryWarningWindow
+func ExpiresSoon(cert *tls.Certificate) bool {
+	return cert.Leaf != nil && time.Until(cert.Leaf.NotAfter) < ExpiryWarningWindow
+}
@@ -0,0 +1,82 @@
+package certificates
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSigned writes a self-signed PEM certificate and key valid until notAfter
+func writeSelfSigned(t *testing.T, dir string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "radarr.local"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"radarr.local"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, "radarr.crt")
+	keyPath := filepath.Join(dir, "radarr.key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath
+}
+
+func TestLoad_PEM(t *testing.T) {
+	certPath, keyPath := writeSelfSigned(t, t.TempDir(), time.Now().Add(90*24*time.Hour))
+
+	cert, err := Load(certPath, keyPath, "")
+	require.NoError(t, err)
+	require.NotNil(t, cert.Leaf)
+	assert.Equal(t, "radarr.local", cert.Leaf.Subject.CommonName)
+	assert.False(t, ExpiresSoon(cert))
+
+	_, err = Load(certPath, "", "")
+	assert.Error(t, err, "PEM certificates need a key")
+}
+
+func TestLoad_PKCS12(t *testing.T) {
+	assert.True(t, IsPKCS12("/config/radarr.PFX"))
+	assert.True(t, IsPKCS12("radarr.p12"))
+	assert.False(t, IsPKCS12("radarr.pem"))
+
+	path := filepath.Join(t.TempDir(), "radarr.pfx")
+	require.NoError(t, os.WriteFile(path, []byte("not a pkcs12 bundle"), 0600))
+
+	_, err := Load(path, "", "secret")
+	assert.Error(t, err)
+}
+
+func TestValidate_Expiry(t *testing.T) {
+	dir := t.TempDir()
+
+	certPath, keyPath := writeSelfSigned(t, dir, time.Now().Add(7*24*time.Hour))
+	require.NoError(t, Validate(certPath, keyPath, ""))
+	cert, err := Load(certPath, keyPath, "")
+	require.NoError(t, err)
+	assert.True(t, ExpiresSoon(cert))
+
+	certPath, keyPath = writeSelfSigned(t, dir, time.Now().Add(-time.Hour))
+	err = Validate(certPath, keyPath, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expired")
+}
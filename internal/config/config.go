@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/radarr/radarr-go/internal/certificates"
 	"github.com/spf13/viper"
 )
 
@@ -16,17 +20,51 @@ const (
 	DefaultMaxConnections = 10
 	// DefaultDirectoryPerm is the default permission for created directories
 	DefaultDirectoryPerm = 0755
+	// DefaultLogFileMaxSizeMB is the size at which the log file is rotated
+	DefaultLogFileMaxSizeMB = 10
+	// DefaultLogFileMaxAgeDays is how long rotated log files are kept
+	DefaultLogFileMaxAgeDays = 30
+	// DefaultLogFileMaxBackups is how many rotated log files are kept
+	DefaultLogFileMaxBackups = 5
+	// DefaultMaxHeaderBytes is the largest request header block accepted by the HTTP server
+	DefaultMaxHeaderBytes = 1 << 20
+	// DefaultMaxBodySizeMB is the largest request body accepted by the HTTP server
+	DefaultMaxBodySizeMB = 32
+
+	// minMaxHeaderBytes keeps max_header_bytes large enough for ordinary browser requests
+	minMaxHeaderBytes = 4 << 10
+)
+
+// HTTP server timeouts used when the configuration leaves them unset
+const (
+	DefaultReadTimeout       = 15 * time.Second
+	DefaultReadHeaderTimeout = 10 * time.Second
+	DefaultWriteTimeout      = 15 * time.Second
+	DefaultIdleTimeout       = 60 * time.Second
 )
 
 // Config represents the main configuration structure for Radarr
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Log      LogConfig      `mapstructure:"log"`
-	Auth     AuthConfig     `mapstructure:"auth"`
-	Storage  StorageConfig  `mapstructure:"storage"`
-	TMDB     TMDBConfig     `mapstructure:"tmdb"`
-	Health   HealthConfig   `mapstructure:"health"`
+	Server        ServerConfig       `mapstructure:"server"`
+	Database      DatabaseConfig     `mapstructure:"database"`
+	Log           LogConfig          `mapstructure:"log"`
+	Auth          AuthConfig         `mapstructure:"auth"`
+	Storage       StorageConfig      `mapstructure:"storage"`
+	TMDB          TMDBConfig         `mapstructure:"tmdb"`
+	Health        HealthConfig       `mapstructure:"health"`
+	Proxy         ProxyConfig        `mapstructure:"proxy"`
+	TLS           TLSConfig          `mapstructure:"tls"`
+	Resilience    ResilienceConfig   `mapstructure:"resilience"`
+	Retention     RetentionConfig    `mapstructure:"retention"`
+	Notifications NotificationConfig `mapstructure:"notifications"`
+	Workers       WorkerConfig       `mapstructure:"workers"`
+	Cluster       ClusterConfig      `mapstructure:"cluster"`
+	Maintenance   MaintenanceConfig  `mapstructure:"maintenance"`
+	SceneMappings SceneMappingConfig `mapstructure:"scene_mappings"`
+	Search        SearchConfig       `mapstructure:"search"`
+	ImportLists   ImportListConfig   `mapstructure:"import_lists"`
+	Preview       PreviewConfig      `mapstructure:"preview"`
+	Duplicates    DuplicateConfig    `mapstructure:"duplicates"`
 }
 
 // ServerConfig contains HTTP server configuration settings
@@ -37,6 +75,127 @@ type ServerConfig struct {
 	EnableSSL   bool   `mapstructure:"enable_ssl"`
 	SSLCertPath string `mapstructure:"ssl_cert_path"`
 	SSLKeyPath  string `mapstructure:"ssl_key_path"`
+	// SSLCertPassword decrypts a PKCS#12 (.pfx/.p12) certificate, which needs no separate key path
+	SSLCertPassword string `mapstructure:"ssl_cert_password"`
+	// BindAddresses replaces Host when set; entries without a port listen on Port
+	BindAddresses []string `mapstructure:"bind_addresses"`
+	// UnixSocket is an optional socket path served alongside the TCP listeners, always without TLS
+	UnixSocket            string `mapstructure:"unix_socket"`
+	UnixSocketPermissions string `mapstructure:"unix_socket_permissions"`
+	// Timeouts are durations such as "30s"; "0" disables a timeout and empty uses the default
+	ReadTimeout       string `mapstructure:"read_timeout"`
+	ReadHeaderTimeout string `mapstructure:"read_header_timeout"`
+	WriteTimeout      string `mapstructure:"write_timeout"`
+	IdleTimeout       string `mapstructure:"idle_timeout"`
+	MaxHeaderBytes    int    `mapstructure:"max_header_bytes"`
+	// MaxBodySizeMB limits request bodies; 0 removes the limit
+	MaxBodySizeMB int `mapstructure:"max_body_size_mb"`
+}
+
+// ServerTimeouts are the parsed HTTP server timeouts; zero means no timeout
+type ServerTimeouts struct {
+	Read       time.Duration
+	ReadHeader time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
+// SocketMode parses UnixSocketPermissions as an octal file mode, defaulting to 0660
+func (c ServerConfig) SocketMode() (os.FileMode, error) {
+	if c.UnixSocketPermissions == "" {
+		return 0660, nil
+	}
+
+	mode, err := strconv.ParseUint(c.UnixSocketPermissions, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid unix socket permissions %q: expected an octal mode such as 0660",
+			c.UnixSocketPermissions)
+	}
+	return os.FileMode(mode), nil
+}
+
+// Timeouts parses the HTTP server timeouts, using the defaults for unset values
+func (c ServerConfig) Timeouts() (ServerTimeouts, error) {
+	timeouts := ServerTimeouts{}
+	for _, t := range []struct {
+		name     string
+		value    string
+		fallback time.Duration
+		target   *time.Duration
+	}{
+		{"read_timeout", c.ReadTimeout, DefaultReadTimeout, &timeouts.Read},
+		{"read_header_timeout", c.ReadHeaderTimeout, DefaultReadHeaderTimeout, &timeouts.ReadHeader},
+		{"write_timeout", c.WriteTimeout, DefaultWriteTimeout, &timeouts.Write},
+		{"idle_timeout", c.IdleTimeout, DefaultIdleTimeout, &timeouts.Idle},
+	} {
+		if t.value == "" {
+			*t.target = t.fallback
+			continue
+		}
+
+		d, err := time.ParseDuration(t.value)
+		if err != nil || d < 0 {
+			return ServerTimeouts{}, fmt.Errorf("invalid server.%s %q: expected a non-negative duration such as 30s",
+				t.name, t.value)
+		}
+		*t.target = d
+	}
+
+	// A header timeout longer than the whole read timeout would never fire
+	if timeouts.Read > 0 && timeouts.ReadHeader > timeouts.Read {
+		return ServerTimeouts{}, fmt.Errorf("server.read_header_timeout %s exceeds server.read_timeout %s",
+			timeouts.ReadHeader, timeouts.Read)
+	}
+	return timeouts, nil
+}
+
+// HeaderLimit returns the maximum request header size in bytes, defaulting to 1 MB
+func (c ServerConfig) HeaderLimit() (int, error) {
+	if c.MaxHeaderBytes == 0 {
+		return DefaultMaxHeaderBytes, nil
+	}
+	if c.MaxHeaderBytes < minMaxHeaderBytes {
+		return 0, fmt.Errorf("invalid server.max_header_bytes %d: must be at least %d", c.MaxHeaderBytes,
+			minMaxHeaderBytes)
+	}
+	return c.MaxHeaderBytes, nil
+}
+
+// BodyLimit returns the maximum request body size in bytes, or 0 when bodies are unlimited
+func (c ServerConfig) BodyLimit() (int64, error) {
+	if c.MaxBodySizeMB < 0 {
+		return 0, fmt.Errorf("invalid server.max_body_size_mb %d: must not be negative", c.MaxBodySizeMB)
+	}
+	return int64(c.MaxBodySizeMB) << 20, nil
+}
+
+// BasePath returns URLBase as the path prefix routes are served under, with a leading slash and
+// no trailing one, or "" when Radarr is served from the root
+func (c ServerConfig) BasePath() string {
+	base := strings.Trim(strings.TrimSpace(c.URLBase), "/")
+	if base == "" {
+		return ""
+	}
+	return "/" + base
+}
+
+// Validate checks the HTTP server limits and timeouts
+func (c ServerConfig) Validate() error {
+	if _, err := c.Timeouts(); err != nil {
+		return err
+	}
+	if _, err := c.HeaderLimit(); err != nil {
+		return err
+	}
+	if _, err := c.BodyLimit(); err != nil {
+		return err
+	}
+	if c.UnixSocket != "" {
+		if _, err := c.SocketMode(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DatabaseConfig contains database connection and configuration settings
@@ -49,13 +208,40 @@ type DatabaseConfig struct {
 	Username       string `mapstructure:"username"`
 	Password       string `mapstructure:"password"`
 	MaxConnections int    `mapstructure:"max_connections"`
+	// AutoRepair fixes records found by the startup integrity checks instead of only reporting them
+	AutoRepair bool `mapstructure:"auto_repair"`
+	// SlowQueryThreshold is the duration above which queries are logged and counted as slow; "0" disables
+	SlowQueryThreshold string `mapstructure:"slow_query_threshold"`
 }
 
-// LogConfig contains logging configuration settings
+// LogConfig contains logging configuration settings. Level, Format and Output describe the
+// console sink ("stdout", "stderr", "none" or a file path); the file and syslog sinks are
+// written alongside it.
 type LogConfig struct {
-	Level  string `mapstructure:"level"`
-	Format string `mapstructure:"format"`
-	Output string `mapstructure:"output"`
+	Level  string          `mapstructure:"level"`
+	Format string          `mapstructure:"format"`
+	Output string          `mapstructure:"output"`
+	File   LogFileConfig   `mapstructure:"file"`
+	Syslog LogSyslogConfig `mapstructure:"syslog"`
+}
+
+// LogFileConfig contains settings for the rotating log file kept under the data directory
+type LogFileConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Path       string `mapstructure:"path"`
+	Format     string `mapstructure:"format"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb"`
+	MaxAgeDays int    `mapstructure:"max_age_days"`
+	MaxBackups int    `mapstructure:"max_backups"`
+}
+
+// LogSyslogConfig contains settings for sending logs to syslog. An empty network and address
+// use the local daemon, which forwards to journald on systemd hosts.
+type LogSyslogConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Network string `mapstructure:"network"`
+	Address string `mapstructure:"address"`
+	Tag     string `mapstructure:"tag"`
 }
 
 // AuthConfig contains authentication and authorization settings
@@ -64,6 +250,9 @@ type AuthConfig struct {
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
 	APIKey   string `mapstructure:"api_key"`
+	// AdminAPIKey unlocks the profiling and diagnostics endpoints, which stay disabled while it is
+	// empty. It is also accepted wherever APIKey is.
+	AdminAPIKey string `mapstructure:"admin_api_key"`
 }
 
 // StorageConfig contains file and directory path settings
@@ -71,11 +260,56 @@ type StorageConfig struct {
 	DataDirectory  string `mapstructure:"data_directory"`
 	MovieDirectory string `mapstructure:"movie_directory"`
 	BackupDir      string `mapstructure:"backup_directory"`
+
+	// Backend is where backup archives and cached media covers are kept: "local" for the backup
+	// and data directories, or "s3" for an S3-compatible bucket
+	Backend string          `mapstructure:"backend"`
+	S3      S3StorageConfig `mapstructure:"s3"`
+}
+
+// S3StorageConfig contains the S3-compatible bucket used by the s3 storage backend
+type S3StorageConfig struct {
+	// Endpoint is the service URL, such as http://minio:9000; empty for AWS in Region
+	Endpoint        string `mapstructure:"endpoint"`
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	Prefix          string `mapstructure:"prefix"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	// PathStyle addresses the bucket in the URL path rather than the host name, as MinIO expects
+	PathStyle bool `mapstructure:"path_style"`
+}
+
+// Validate checks the storage backend settings
+func (c StorageConfig) Validate() error {
+	switch c.Backend {
+	case "", "local":
+		return nil
+	case "s3":
+		if c.S3.Bucket == "" {
+			return fmt.Errorf("invalid storage.s3.bucket: required for the s3 backend")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid storage.backend %q: must be local or s3", c.Backend)
+	}
 }
 
 // TMDBConfig contains TheMovieDB API configuration
 type TMDBConfig struct {
 	APIKey string `mapstructure:"api_key"`
+	// RequestsPerSecond caps the requests made to TMDB by all tasks together; 0 disables the limit
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	// Burst is how many requests may be made at once before the limit applies
+	Burst int `mapstructure:"burst"`
+	// CertificationCountry is the ISO 3166-1 country whose certification movies get
+	CertificationCountry string `mapstructure:"certification_country"`
+	// ReleaseDateCountry is the ISO 3166-1 country whose release dates drive the calendar and
+	// availability
+	ReleaseDateCountry string `mapstructure:"release_date_country"`
+	// WatchRegion is the ISO 3166-1 country whose watch providers are stored with movies and used
+	// by import list provider filters
+	WatchRegion string `mapstructure:"watch_region"`
 }
 
 // HealthConfig contains health monitoring configuration settings
@@ -89,6 +323,162 @@ type HealthConfig struct {
 	MetricsRetentionDays       int    `mapstructure:"metrics_retention_days"`
 	NotifyCriticalIssues       bool   `mapstructure:"notify_critical_issues"`
 	NotifyWarningIssues        bool   `mapstructure:"notify_warning_issues"`
+	// MetricsInterval is how often performance metrics are sampled and stored
+	MetricsInterval string `mapstructure:"metrics_interval"`
+}
+
+// ProxyConfig contains outbound proxy settings used for indexer, TMDB, download client,
+// and notification traffic
+type ProxyConfig struct {
+	Enabled              bool   `mapstructure:"enabled"`
+	Type                 string `mapstructure:"type"`
+	Hostname             string `mapstructure:"hostname"`
+	Port                 int    `mapstructure:"port"`
+	Username             string `mapstructure:"username"`
+	Password             string `mapstructure:"password"`
+	BypassFilter         string `mapstructure:"bypass_filter"`
+	BypassLocalAddresses bool   `mapstructure:"bypass_local_addresses"`
+}
+
+// TLSConfig contains certificate settings for outbound connections to external services
+type TLSConfig struct {
+	CABundlePath            string `mapstructure:"ca_bundle_path"`
+	InsecureSkipVerifyHosts string `mapstructure:"insecure_skip_verify_hosts"`
+}
+
+// ResilienceConfig contains retry and circuit breaker settings for outbound requests
+type ResilienceConfig struct {
+	MaxRetries              int    `mapstructure:"max_retries"`
+	RetryBaseDelay          string `mapstructure:"retry_base_delay"`
+	RetryMaxDelay           string `mapstructure:"retry_max_delay"`
+	AttemptTimeout          string `mapstructure:"attempt_timeout"`
+	BreakerFailureThreshold int    `mapstructure:"breaker_failure_threshold"`
+	BreakerResetTimeout     string `mapstructure:"breaker_reset_timeout"`
+}
+
+// RetentionConfig contains how long search results, history and retired rows are kept in the database
+type RetentionConfig struct {
+	ReleaseMaxAgeDays          int      `mapstructure:"release_max_age_days"`
+	ReleaseMaxPerMovie         int      `mapstructure:"release_max_per_movie"`
+	ReleasePartitioning        bool     `mapstructure:"release_partitioning"`
+	HistoryMaxAgeDays          int      `mapstructure:"history_max_age_days"`
+	HistoryMaxRows             int      `mapstructure:"history_max_rows"`
+	HistoryProtectedEventTypes []string `mapstructure:"history_protected_event_types"`
+	// RetiredMaxAgeDays is how long rows kept after they stopped being used, such as torrents
+	// removed once seeded, stay in the database before the cleanup task deletes them
+	RetiredMaxAgeDays int `mapstructure:"retired_max_age_days"`
+}
+
+// NotificationConfig contains how failed notification deliveries are retried before they are
+// kept as failed deliveries for manual retry, and when deliveries are held back for quiet hours
+// or combined into digests
+type NotificationConfig struct {
+	MaxRetries     int    `mapstructure:"max_retries"`
+	RetryBaseDelay string `mapstructure:"retry_base_delay"`
+	RetryMaxDelay  string `mapstructure:"retry_max_delay"`
+
+	// QuietHoursStart and QuietHoursEnd are local "HH:MM" times; the window may span midnight
+	QuietHoursStart string `mapstructure:"quiet_hours_start"`
+	QuietHoursEnd   string `mapstructure:"quiet_hours_end"`
+	// QuietHoursMode is "queue" to deliver held notifications when quiet hours end, or "suppress"
+	QuietHoursMode string `mapstructure:"quiet_hours_mode"`
+	// DigestInterval combines import notifications into one summary per interval; empty disables
+	DigestInterval string `mapstructure:"digest_interval"`
+}
+
+// WorkerConfig overrides the size of the worker pools; 0 sizes a pool from the CPU count and
+// available memory
+type WorkerConfig struct {
+	SearchWorkers int `mapstructure:"search_workers"`
+	ImportWorkers int `mapstructure:"import_workers"`
+	TaskWorkers   int `mapstructure:"task_workers"`
+}
+
+// ClusterConfig contains settings for running several instances against one database
+type ClusterConfig struct {
+	// LeaderElection lets only the instance holding a database lock run scheduled tasks
+	LeaderElection bool `mapstructure:"leader_election"`
+	// ElectionInterval is how often an instance tries to take the lock or checks it still holds it
+	ElectionInterval string `mapstructure:"election_interval"`
+	// InstanceName identifies this instance in status reports; empty uses the hostname
+	InstanceName string `mapstructure:"instance_name"`
+}
+
+// Instance returns the configured instance name, or the hostname when none is set
+func (c ClusterConfig) Instance() string {
+	if c.InstanceName != "" {
+		return c.InstanceName
+	}
+	hostname, _ := os.Hostname() //nolint:errcheck // An empty name only affects status reports
+	return hostname
+}
+
+// MaintenanceConfig contains the read-only mode an instance starts in
+type MaintenanceConfig struct {
+	// ReadOnly refuses changes through the API and pauses background tasks until turned off
+	ReadOnly bool `mapstructure:"read_only"`
+	// Reason is shown to clients while read-only mode is on
+	Reason string `mapstructure:"reason"`
+}
+
+// SceneMappingConfig contains where scene mappings, release titles that differ from the TMDB
+// title, are refreshed from
+type SceneMappingConfig struct {
+	// URL serves a JSON array of {"title", "tmdbId", "year"} mappings; empty disables the refresh
+	URL string `mapstructure:"url"`
+}
+
+// SearchConfig contains how often the missing and cutoff unmet searches retry a wanted movie: the
+// wait starts at MinInterval and doubles with each search that leaves the movie wanted, up to
+// MaxInterval
+type SearchConfig struct {
+	MinInterval string               `mapstructure:"min_interval"`
+	MaxInterval string               `mapstructure:"max_interval"`
+	Priority    SearchPriorityConfig `mapstructure:"priority"`
+}
+
+// SearchPriorityConfig weighs what raises the priority wanted movies are searched in. Missing
+// movies start at Missing and the rest at CutoffUnmet; each factor that applies then raises the
+// priority to at least its level, from 1 (very low) to 5 (very high), and a level of 0 disables it.
+type SearchPriorityConfig struct {
+	Missing     int `mapstructure:"missing"`
+	CutoffUnmet int `mapstructure:"cutoff_unmet"`
+	// CurrentYear and PreviousYear weigh recency, applying to movies from this year and last year
+	CurrentYear  int `mapstructure:"current_year"`
+	PreviousYear int `mapstructure:"previous_year"`
+	// PopularityWeighting turns the popularity factors on or off: movies with a TMDB popularity
+	// above VeryPopularThreshold get VeryPopular, and above PopularThreshold get Popular
+	PopularityWeighting  bool    `mapstructure:"popularity_weighting"`
+	VeryPopular          int     `mapstructure:"very_popular"`
+	VeryPopularThreshold float64 `mapstructure:"very_popular_threshold"`
+	Popular              int     `mapstructure:"popular"`
+	PopularThreshold     float64 `mapstructure:"popular_threshold"`
+	// Available applies to movies that reached their minimum availability
+	Available int `mapstructure:"available"`
+}
+
+// ImportListConfig contains how import list syncs treat movies deleted from the library
+type ImportListConfig struct {
+	// TombstonePeriod keeps list syncs from adding back a movie deleted without an exclusion for
+	// this long, as a duration such as "720h"; "0" disables tombstones
+	TombstonePeriod string `mapstructure:"tombstone_period"`
+}
+
+// PreviewConfig contains the movie file preview endpoints, which serve library files over the API
+type PreviewConfig struct {
+	// Enabled turns on streaming movie files and generating thumbnails from them
+	Enabled bool `mapstructure:"enabled"`
+	// FFmpegPath is the ffmpeg binary thumbnails are generated with
+	FFmpegPath string `mapstructure:"ffmpeg_path"`
+}
+
+// DuplicateConfig contains the fingerprinting of library files that may be duplicates of each other
+type DuplicateConfig struct {
+	// Enabled turns on fingerprinting the files a movie has more than one of, which reads frames
+	// from each file with ffmpeg
+	Enabled     bool   `mapstructure:"enabled"`
+	FFmpegPath  string `mapstructure:"ffmpeg_path"`
+	FFprobePath string `mapstructure:"ffprobe_path"`
 }
 
 // Load reads and parses the configuration from file and environment variables
@@ -133,6 +523,21 @@ func Load(configPath, dataDir string) (*Config, error) {
 		return nil, fmt.Errorf("error creating directories: %w", err)
 	}
 
+	// Catch unusable certificates here rather than when the listener starts
+	if config.Server.EnableSSL {
+		if err := certificates.Validate(config.Server.SSLCertPath, config.Server.SSLKeyPath,
+			config.Server.SSLCertPassword); err != nil {
+			return nil, fmt.Errorf("invalid SSL certificate: %w", err)
+		}
+	}
+
+	if err := config.Server.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.Storage.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
@@ -141,6 +546,15 @@ func setDefaults(vip *viper.Viper, dataDir string) {
 	vip.SetDefault("server.host", "0.0.0.0")
 	vip.SetDefault("server.url_base", "")
 	vip.SetDefault("server.enable_ssl", false)
+	vip.SetDefault("server.bind_addresses", []string{})
+	vip.SetDefault("server.unix_socket", "")
+	vip.SetDefault("server.unix_socket_permissions", "0660")
+	vip.SetDefault("server.read_timeout", DefaultReadTimeout.String())
+	vip.SetDefault("server.read_header_timeout", DefaultReadHeaderTimeout.String())
+	vip.SetDefault("server.write_timeout", DefaultWriteTimeout.String())
+	vip.SetDefault("server.idle_timeout", DefaultIdleTimeout.String())
+	vip.SetDefault("server.max_header_bytes", DefaultMaxHeaderBytes)
+	vip.SetDefault("server.max_body_size_mb", DefaultMaxBodySizeMB)
 
 	vip.SetDefault("database.type", "postgres")
 	vip.SetDefault("database.host", "localhost")
@@ -149,17 +563,40 @@ func setDefaults(vip *viper.Viper, dataDir string) {
 	vip.SetDefault("database.username", "radarr")
 	vip.SetDefault("database.password", "password")
 	vip.SetDefault("database.max_connections", DefaultMaxConnections)
+	vip.SetDefault("database.auto_repair", false)
+	vip.SetDefault("database.slow_query_threshold", "500ms")
 
 	vip.SetDefault("log.level", "info")
 	vip.SetDefault("log.format", "json")
 	vip.SetDefault("log.output", "stdout")
+	vip.SetDefault("log.file.enabled", false)
+	vip.SetDefault("log.file.path", filepath.Join(dataDir, "logs", "radarr.log"))
+	vip.SetDefault("log.file.format", "json")
+	vip.SetDefault("log.file.max_size_mb", DefaultLogFileMaxSizeMB)
+	vip.SetDefault("log.file.max_age_days", DefaultLogFileMaxAgeDays)
+	vip.SetDefault("log.file.max_backups", DefaultLogFileMaxBackups)
+	vip.SetDefault("log.syslog.enabled", false)
+	vip.SetDefault("log.syslog.network", "")
+	vip.SetDefault("log.syslog.address", "")
+	vip.SetDefault("log.syslog.tag", "radarr")
 
 	vip.SetDefault("auth.method", "none")
 	vip.SetDefault("auth.api_key", "")
+	vip.SetDefault("auth.admin_api_key", "")
 
 	vip.SetDefault("storage.data_directory", dataDir)
 	vip.SetDefault("storage.movie_directory", filepath.Join(dataDir, "movies"))
 	vip.SetDefault("storage.backup_directory", filepath.Join(dataDir, "backups"))
+	vip.SetDefault("storage.backend", "local")
+	vip.SetDefault("storage.s3.region", "us-east-1")
+	vip.SetDefault("storage.s3.path_style", false)
+
+	// TMDB rate limit defaults, well below TMDB's limit of about 40 requests per second
+	vip.SetDefault("tmdb.requests_per_second", 20)
+	vip.SetDefault("tmdb.burst", 20)
+	vip.SetDefault("tmdb.certification_country", "US")
+	vip.SetDefault("tmdb.release_date_country", "US")
+	vip.SetDefault("tmdb.watch_region", "US")
 
 	// Health monitoring defaults
 	vip.SetDefault("health.enabled", true)
@@ -171,6 +608,86 @@ func setDefaults(vip *viper.Viper, dataDir string) {
 	vip.SetDefault("health.metrics_retention_days", 30)
 	vip.SetDefault("health.notify_critical_issues", true)
 	vip.SetDefault("health.notify_warning_issues", false)
+	vip.SetDefault("health.metrics_interval", "5m")
+
+	// Outbound proxy defaults
+	vip.SetDefault("proxy.enabled", false)
+	vip.SetDefault("proxy.type", "http")
+	vip.SetDefault("proxy.bypass_filter", "")
+	vip.SetDefault("proxy.bypass_local_addresses", true)
+
+	// Outbound TLS defaults
+	vip.SetDefault("tls.ca_bundle_path", "")
+	vip.SetDefault("tls.insecure_skip_verify_hosts", "")
+
+	// External service resilience defaults
+	vip.SetDefault("resilience.max_retries", 2)
+	vip.SetDefault("resilience.retry_base_delay", "500ms")
+	vip.SetDefault("resilience.retry_max_delay", "10s")
+	vip.SetDefault("resilience.attempt_timeout", "")
+	vip.SetDefault("resilience.breaker_failure_threshold", 5)
+	vip.SetDefault("resilience.breaker_reset_timeout", "60s")
+
+	// Database retention defaults
+	vip.SetDefault("retention.release_max_age_days", 30)
+	vip.SetDefault("retention.release_max_per_movie", 250)
+	vip.SetDefault("retention.release_partitioning", false)
+	vip.SetDefault("retention.history_max_age_days", 365)
+	vip.SetDefault("retention.history_max_rows", 0)
+	vip.SetDefault("retention.history_protected_event_types", []string{})
+	vip.SetDefault("retention.retired_max_age_days", 30)
+
+	// Notification delivery retry defaults
+	vip.SetDefault("notifications.max_retries", 3)
+	vip.SetDefault("notifications.retry_base_delay", "2s")
+	vip.SetDefault("notifications.retry_max_delay", "1m")
+	vip.SetDefault("notifications.quiet_hours_start", "")
+	vip.SetDefault("notifications.quiet_hours_end", "")
+	vip.SetDefault("notifications.quiet_hours_mode", "queue")
+	vip.SetDefault("notifications.digest_interval", "")
+
+	// Worker pool sizes, 0 for automatic sizing
+	vip.SetDefault("workers.search_workers", 0)
+	vip.SetDefault("workers.import_workers", 0)
+	vip.SetDefault("workers.task_workers", 0)
+
+	// Multi-instance coordination defaults
+	vip.SetDefault("cluster.leader_election", false)
+	vip.SetDefault("cluster.election_interval", "15s")
+	vip.SetDefault("cluster.instance_name", "")
+
+	// Maintenance defaults
+	vip.SetDefault("maintenance.read_only", false)
+	vip.SetDefault("maintenance.reason", "")
+
+	// Scene mapping defaults
+	vip.SetDefault("scene_mappings.url", "")
+
+	// Wanted movie search backoff defaults
+	vip.SetDefault("search.min_interval", "6h")
+	vip.SetDefault("search.max_interval", "168h")
+	vip.SetDefault("search.priority.missing", 4)
+	vip.SetDefault("search.priority.cutoff_unmet", 3)
+	vip.SetDefault("search.priority.current_year", 4)
+	vip.SetDefault("search.priority.previous_year", 3)
+	vip.SetDefault("search.priority.popularity_weighting", true)
+	vip.SetDefault("search.priority.very_popular", 4)
+	vip.SetDefault("search.priority.very_popular_threshold", 50)
+	vip.SetDefault("search.priority.popular", 3)
+	vip.SetDefault("search.priority.popular_threshold", 20)
+	vip.SetDefault("search.priority.available", 0)
+
+	// Import list defaults
+	vip.SetDefault("import_lists.tombstone_period", "720h")
+
+	// Movie file preview defaults
+	vip.SetDefault("preview.enabled", false)
+	vip.SetDefault("preview.ffmpeg_path", "ffmpeg")
+
+	// Duplicate file fingerprinting defaults
+	vip.SetDefault("duplicates.enabled", false)
+	vip.SetDefault("duplicates.ffmpeg_path", "ffmpeg")
+	vip.SetDefault("duplicates.ffprobe_path", "ffprobe")
 }
 
 func ensureDirectories(config *Config) error {
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// apiKeyBytes is the length of generated API keys before hex encoding, giving the 32 characters
+// Radarr's keys have
+const apiKeyBytes = 16
+
+// GenerateAPIKey returns a new random API key
+func GenerateAPIKey() (string, error) {
+	key := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// SetFileValue sets a setting in a YAML configuration file by its dotted key, such as
+// auth.api_key, keeping the file's other settings and its comments. A missing file is created.
+func SetFileValue(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	mode := fs.FileMode(0o600)
+	if info, statErr := os.Stat(path); statErr == nil {
+		mode = info.Mode().Perm()
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	if document.Kind == 0 {
+		document.Kind = yaml.DocumentNode
+		document.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+
+	node := document.Content[0]
+	segments := strings.Split(key, ".")
+	for i, segment := range segments {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a section", key, strings.Join(segments[:i], "."))
+		}
+		var child *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == segment {
+				child = node.Content[j+1]
+				break
+			}
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: segment}, child)
+		}
+		node = child
+	}
+
+	// Settings already in the file are replaced in place, leaving the rest of the text as it is;
+	// others are added by encoding the file again, which normalizes its layout
+	updated, ok := replaceScalar(data, node, value)
+	if !ok {
+		// Quoted, like the settings in the sample configuration, so keys of digits stay strings
+		*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle,
+			LineComment: node.LineComment}
+
+		var buf bytes.Buffer
+		if bytes.HasPrefix(data, []byte("---")) {
+			buf.WriteString("---\n")
+		}
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&document); err != nil {
+			return fmt.Errorf("failed to encode config file: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("failed to encode config file: %w", err)
+		}
+		updated = buf.Bytes()
+	}
+
+	// Write a sibling file and rename it over the original, so the file is never left half written
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() //nolint:errcheck // gone after a successful rename
+	if _, err := tmp.Write(updated); err != nil {
+		_ = tmp.Close() //nolint:errcheck // the write error is reported
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// replaceScalar replaces the text of a single-line scalar value in a YAML document with value,
+// double quoted. It reports false for values it can't place, such as missing or multi-line ones.
+func replaceScalar(data []byte, node *yaml.Node, value string) ([]byte, bool) {
+	if node.Kind != yaml.ScalarNode || node.Line == 0 || strings.Contains(node.Value, "\n") {
+		return nil, false
+	}
+	// Empty plain values, as in "api_key:", have no text to replace
+	if node.Style == 0 && node.Value == "" {
+		return nil, false
+	}
+	lines := strings.Split(string(data), "\n")
+	if node.Line > len(lines) {
+		return nil, false
+	}
+	line := lines[node.Line-1]
+	start := node.Column - 1
+	if start < 0 || start >= len(line) {
+		return nil, false
+	}
+
+	end := -1
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				end = i + 1
+				break
+			}
+		}
+	case yaml.SingleQuotedStyle:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			end = i + 1
+			break
+		}
+	case 0:
+		end = len(line)
+		if comment := strings.Index(line[start:], " #"); comment >= 0 {
+			end = start + comment
+		}
+		end = start + len(strings.TrimRight(line[start:end], " \t\r"))
+	}
+	if end < 0 {
+		return nil, false
+	}
+
+	lines[node.Line-1] = line[:start] + strconv.Quote(value) + line[end:]
+	return []byte(strings.Join(lines, "\n")), true
+}
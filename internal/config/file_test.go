@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+)
+
+// writeConfigFile writes a config file with the given content and mode in a temporary directory
+func writeConfigFile(t *testing.T, content string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), mode))
+	require.NoError(t, os.Chmod(path, mode))
+	return path
+}
+
+// readConfigFile parses a config file back into its sections
+func readConfigFile(t *testing.T, path string) map[string]map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var sections map[string]map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &sections))
+	return sections
+}
+
+func TestSetFileValue_ReplacesInPlace(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "plain",
+			content: "# Radarr\nauth:\n  api_key: oldkey\n  method: none\n",
+			want:    "# Radarr\nauth:\n  api_key: \"newkey\"\n  method: none\n",
+		},
+		{
+			name:    "plain with trailing comment",
+			content: "auth:\n  api_key: oldkey   # keep me\n  method: none\n",
+			want:    "auth:\n  api_key: \"newkey\"   # keep me\n  method: none\n",
+		},
+		{
+			name:    "plain with hash inside the value",
+			content: "auth:\n  api_key: old#key # keep me\n",
+			want:    "auth:\n  api_key: \"newkey\" # keep me\n",
+		},
+		{
+			name:    "double quoted",
+			content: "auth:\n  api_key: \"old \\\" key\" # keep me\n  method: none\n",
+			want:    "auth:\n  api_key: \"newkey\" # keep me\n  method: none\n",
+		},
+		{
+			name:    "single quoted",
+			content: "auth:\n  api_key: 'it''s old' # keep me\n  method: none\n",
+			want:    "auth:\n  api_key: \"newkey\" # keep me\n  method: none\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.content, 0o600)
+			require.NoError(t, SetFileValue(path, "auth.api_key", "newkey"))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestSetFileValue_EmptyValue(t *testing.T) {
+	path := writeConfigFile(t, "auth:\n  api_key: # set on first start\n  method: none\n", 0o600)
+	require.NoError(t, SetFileValue(path, "auth.api_key", "newkey"))
+
+	sections := readConfigFile(t, path)
+	assert.Equal(t, "newkey", sections["auth"]["api_key"])
+	assert.Equal(t, "none", sections["auth"]["method"])
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# set on first start")
+}
+
+func TestSetFileValue_MissingSection(t *testing.T) {
+	path := writeConfigFile(t, "server:\n  port: 7878\n", 0o600)
+	require.NoError(t, SetFileValue(path, "auth.api_key", "0123"))
+
+	sections := readConfigFile(t, path)
+	assert.Equal(t, 7878, sections["server"]["port"])
+	// Quoted, so a key of digits stays a string
+	assert.Equal(t, "0123", sections["auth"]["api_key"])
+
+	path = writeConfigFile(t, "auth: none\n", 0o600)
+	assert.Error(t, SetFileValue(path, "auth.api_key", "newkey"))
+}
+
+func TestSetFileValue_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, SetFileValue(path, "auth.api_key", "newkey"))
+
+	sections := readConfigFile(t, path)
+	assert.Equal(t, "newkey", sections["auth"]["api_key"])
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestSetFileValue_PreservesMode(t *testing.T) {
+	for _, mode := range []os.FileMode{0o640, 0o644} {
+		path := writeConfigFile(t, "auth:\n  api_key: oldkey\n", mode)
+		require.NoError(t, SetFileValue(path, "auth.api_key", "newkey"))
+		require.NoError(t, SetFileValue(path, "auth.method", "forms"))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, mode, info.Mode().Perm())
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no temporary files are left behind")
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces secrets in exported configuration
+const RedactedValue = "********"
+
+// secretSuffixes mark the string fields whose values are replaced by Redacted. S3 credentials
+// pair a public access key ID with a secret access key, so only the latter is a secret.
+var secretSuffixes = []string{"Password", "APIKey", "Secret", "Token", "SecretAccessKey", "SecretKey"}
+
+// Redacted returns the configuration keyed by its configuration file names, with passwords, API
+// keys and the password of connection URLs replaced so the result can be shared in bug reports
+func (c *Config) Redacted() map[string]interface{} {
+	redacted, _ := redactValue(reflect.ValueOf(*c), "").(map[string]interface{}) //nolint:errcheck // Config is a struct
+	return redacted
+}
+
+func redactValue(v reflect.Value, name string) interface{} {
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key := field.Tag.Get("mapstructure")
+			if key == "" || key == "-" {
+				key = field.Name
+			}
+			fields[key] = redactValue(v.Field(i), field.Name)
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = redactValue(v.Index(i), name)
+		}
+		return items
+	case reflect.String:
+		return redactString(name, v.String())
+	default:
+		return v.Interface()
+	}
+}
+
+// redactString hides secret values; empty values stay empty so unset secrets remain visible
+func redactString(name, value string) string {
+	if value == "" {
+		return value
+	}
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return RedactedValue
+		}
+	}
+	if strings.HasSuffix(name, "URL") {
+		return redactURL(value)
+	}
+	return value
+}
+
+// redactURL hides the password in a URL's user info. Connection strings that do not parse as a
+// URL, such as key=value DSNs, are hidden entirely.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return RedactedValue
+	}
+	return u.Redacted()
+}
+
+// IsSecretName reports whether a setting name, in any case, names a secret such as a password or
+// an API key
+func IsSecretName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(lower, strings.ToLower(suffix)) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
@@ -28,7 +29,6 @@ import (
 	gormMariaDB "gorm.io/driver/mysql"
 	gormPostgres "gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	gormLogger "gorm.io/gorm/logger"
 )
 
 // Database provides access to the Radarr database
@@ -39,21 +39,54 @@ type Database struct {
 	DB       *sql.DB
 	PgxPool  *pgxpool.Pool
 	DbType   string
+
+	// SlowQueries records GORM queries slower than database.slow_query_threshold
+	SlowQueries *SlowQueryLog
 }
 
 // New creates a new database connection
-func New(cfg *config.DatabaseConfig, _ *logger.Logger) (*Database, error) {
+func New(cfg *config.DatabaseConfig, logger *logger.Logger) (*Database, error) {
+	threshold, err := slowQueryThreshold(cfg.SlowQueryThreshold)
+	if err != nil {
+		return nil, err
+	}
+	slowQueries := NewSlowQueryLog(threshold)
+	gormConfig := &gorm.Config{
+		Logger:      newQueryLogger(logger, slowQueries),
+		PrepareStmt: true, // Enable prepared statement caching for better performance
+	}
+
+	var db *Database
 	switch cfg.Type {
 	case postgresType, "postgresql":
-		return newPostgresDatabase(cfg)
+		db, err = newPostgresDatabase(cfg, gormConfig)
 	case "mariadb", mysqlType, "":
-		return newMySQLDatabase(cfg)
+		db, err = newMySQLDatabase(cfg, gormConfig)
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s (supported: postgres, mariadb)", cfg.Type)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	db.SlowQueries = slowQueries
+	return db, nil
+}
+
+// slowQueryThreshold parses the configured threshold, defaulting when it is not set
+func slowQueryThreshold(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultSlowQueryThreshold, nil
+	}
+
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("invalid slow query threshold %q: expected a duration such as 500ms", value)
+	}
+	return threshold, nil
 }
 
-func newPostgresDatabase(cfg *config.DatabaseConfig) (*Database, error) {
+func newPostgresDatabase(cfg *config.DatabaseConfig, gormConfig *gorm.Config) (*Database, error) {
 	connectionString := buildPostgresConnectionString(cfg)
 
 	// Open direct SQL connection for migration
@@ -68,10 +101,7 @@ func newPostgresDatabase(cfg *config.DatabaseConfig) (*Database, error) {
 		return nil, fmt.Errorf("failed to create pgx pool: %w", err)
 	}
 
-	gormDB, err := gorm.Open(gormPostgres.Open(connectionString), &gorm.Config{
-		Logger:      gormLogger.Default.LogMode(gormLogger.Warn),
-		PrepareStmt: true, // Enable prepared statement caching for better performance
-	})
+	gormDB, err := gorm.Open(gormPostgres.Open(connectionString), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open gorm postgres connection: %w", err)
 	}
@@ -88,7 +118,7 @@ func newPostgresDatabase(cfg *config.DatabaseConfig) (*Database, error) {
 	}, nil
 }
 
-func newMySQLDatabase(cfg *config.DatabaseConfig) (*Database, error) {
+func newMySQLDatabase(cfg *config.DatabaseConfig, gormConfig *gorm.Config) (*Database, error) {
 	connectionString := buildMariaDBConnectionString(cfg)
 
 	// Open direct SQL connection for sqlc
@@ -97,10 +127,7 @@ func newMySQLDatabase(cfg *config.DatabaseConfig) (*Database, error) {
 		return nil, fmt.Errorf("failed to open mysql connection: %w", err)
 	}
 
-	gormDB, err := gorm.Open(gormMariaDB.Open(connectionString), &gorm.Config{
-		Logger:      gormLogger.Default.LogMode(gormLogger.Warn),
-		PrepareStmt: true, // Enable prepared statement caching for better performance
-	})
+	gormDB, err := gorm.Open(gormMariaDB.Open(connectionString), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open gorm mariadb connection: %w", err)
 	}
@@ -145,6 +172,11 @@ func (d *Database) Close() error {
 	return nil
 }
 
+// IsPostgres reports whether the database is PostgreSQL
+func (d *Database) IsPostgres() bool {
+	return d.DbType == postgresType
+}
+
 const (
 	defaultDatabaseName = "radarr"
 	defaultUsername     = "radarr"
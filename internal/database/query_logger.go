@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/logger"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+const (
+	// DefaultSlowQueryThreshold is used when database.slow_query_threshold is not set
+	DefaultSlowQueryThreshold = 500 * time.Millisecond
+
+	// maxTrackedSlowQueries bounds the distinct statements kept for the health dashboard
+	maxTrackedSlowQueries = 100
+)
+
+// SlowQueryStat aggregates the slow executions of one SQL statement. The statement keeps its
+// placeholders; parameter values are never recorded.
+type SlowQueryStat struct {
+	SQL      string
+	Count    int64
+	Total    time.Duration
+	Max      time.Duration
+	LastSeen time.Time
+}
+
+// SlowQueryLog records queries that ran longer than the configured threshold
+type SlowQueryLog struct {
+	threshold time.Duration
+	pending   atomic.Int64
+
+	mu      sync.Mutex
+	queries map[string]*SlowQueryStat
+}
+
+// NewSlowQueryLog creates a slow query log; a zero threshold disables recording
+func NewSlowQueryLog(threshold time.Duration) *SlowQueryLog {
+	return &SlowQueryLog{
+		threshold: threshold,
+		queries:   make(map[string]*SlowQueryStat),
+	}
+}
+
+// Threshold returns the duration above which queries are recorded
+func (l *SlowQueryLog) Threshold() time.Duration {
+	return l.threshold
+}
+
+// IsSlow reports whether a query that took elapsed counts as slow
+func (l *SlowQueryLog) IsSlow(elapsed time.Duration) bool {
+	return l.threshold > 0 && elapsed >= l.threshold
+}
+
+// Record adds a slow execution of sql. Once maxTrackedSlowQueries statements are tracked, new
+// statements are still counted but not listed.
+func (l *SlowQueryLog) Record(sql string, elapsed time.Duration) {
+	l.pending.Add(1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stat, ok := l.queries[sql]
+	if !ok {
+		if len(l.queries) >= maxTrackedSlowQueries {
+			return
+		}
+		stat = &SlowQueryStat{SQL: sql}
+		l.queries[sql] = stat
+	}
+
+	stat.Count++
+	stat.Total += elapsed
+	stat.LastSeen = time.Now()
+	if elapsed > stat.Max {
+		stat.Max = elapsed
+	}
+}
+
+// TakeCount returns the number of slow queries since the previous call and resets it
+func (l *SlowQueryLog) TakeCount() int {
+	return int(l.pending.Swap(0))
+}
+
+// Top returns up to limit statements ordered by their slowest execution
+func (l *SlowQueryLog) Top(limit int) []SlowQueryStat {
+	l.mu.Lock()
+	stats := make([]SlowQueryStat, 0, len(l.queries))
+	for _, stat := range l.queries {
+		stats = append(stats, *stat)
+	}
+	l.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Max > stats[j].Max
+	})
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	return stats
+}
+
+// queryLogger routes GORM logging to the application logger. Statements are logged with their
+// placeholders so parameter values such as API keys and passwords never reach the logs; every
+// statement is logged at debug level and statements over the threshold are recorded as slow.
+type queryLogger struct {
+	logger *logger.Logger
+	slow   *SlowQueryLog
+	level  gormLogger.LogLevel
+}
+
+// newQueryLogger creates the GORM logger for a database connection
+func newQueryLogger(logger *logger.Logger, slow *SlowQueryLog) *queryLogger {
+	return &queryLogger{logger: logger, slow: slow, level: gormLogger.Warn}
+}
+
+// LogMode implements gormLogger.Interface
+func (l *queryLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+// Info implements gormLogger.Interface
+func (l *queryLogger) Info(_ context.Context, msg string, args ...interface{}) {
+	if l.logger != nil && l.level >= gormLogger.Info {
+		l.logger.Infow(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn implements gormLogger.Interface
+func (l *queryLogger) Warn(_ context.Context, msg string, args ...interface{}) {
+	if l.logger != nil && l.level >= gormLogger.Warn {
+		l.logger.Warnw(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error implements gormLogger.Interface
+func (l *queryLogger) Error(_ context.Context, msg string, args ...interface{}) {
+	if l.logger != nil && l.level >= gormLogger.Error {
+		l.logger.Errorw(fmt.Sprintf(msg, args...))
+	}
+}
+
+// ParamsFilter implements gorm.ParamsFilter, dropping the parameters so statements are
+// rendered with placeholders instead of values
+func (l *queryLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// Trace implements gormLogger.Interface
+func (l *queryLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.slow != nil && l.slow.IsSlow(elapsed)
+	debug := l.logger != nil && l.logger.Desugar().Core().Enabled(zapcore.DebugLevel)
+	if !failed && !slow && !debug {
+		return
+	}
+
+	sql, rows := fc()
+	sql = normalizeSQL(sql)
+
+	if slow {
+		l.slow.Record(sql, elapsed)
+	}
+	if l.logger == nil {
+		return
+	}
+
+	switch {
+	case failed && l.level >= gormLogger.Error:
+		l.logger.Warnw("Database query failed", "sql", sql, "elapsed", elapsed, "error", err)
+	case slow && l.level >= gormLogger.Warn:
+		l.logger.Warnw("Slow database query", "sql", sql, "elapsed", elapsed, "rows", rows,
+			"threshold", l.slow.Threshold())
+	case debug:
+		l.logger.Debugw("Database query", "sql", sql, "elapsed", elapsed, "rows", rows)
+	}
+}
+
+// normalizeSQL collapses whitespace so the same statement is grouped regardless of formatting
+func normalizeSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
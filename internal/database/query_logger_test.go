@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSlowQueryLog_Top(t *testing.T) {
+	log := NewSlowQueryLog(100 * time.Millisecond)
+	assert.False(t, log.IsSlow(50*time.Millisecond))
+	assert.True(t, log.IsSlow(100*time.Millisecond))
+
+	log.Record("SELECT * FROM movies WHERE id = $1", 200*time.Millisecond)
+	log.Record("SELECT * FROM movies WHERE id = $1", 400*time.Millisecond)
+	log.Record("SELECT * FROM history", 300*time.Millisecond)
+
+	top := log.Top(1)
+	require.Len(t, top, 1)
+	assert.Equal(t, "SELECT * FROM movies WHERE id = $1", top[0].SQL)
+	assert.Equal(t, int64(2), top[0].Count)
+	assert.Equal(t, 400*time.Millisecond, top[0].Max)
+	assert.Equal(t, 600*time.Millisecond, top[0].Total)
+
+	assert.Equal(t, 3, log.TakeCount())
+	assert.Equal(t, 0, log.TakeCount(), "the count resets once taken")
+
+	assert.False(t, NewSlowQueryLog(0).IsSlow(time.Hour), "a zero threshold disables recording")
+}
+
+func TestQueryLogger_Trace(t *testing.T) {
+	slow := NewSlowQueryLog(100 * time.Millisecond)
+	ql := newQueryLogger(logger.New(config.LogConfig{Level: "error", Output: "none"}), slow)
+
+	sql, params := ql.ParamsFilter(context.Background(), "SELECT * FROM users WHERE api_key = ?", "secret")
+	assert.Equal(t, "SELECT * FROM users WHERE api_key = ?", sql)
+	assert.Empty(t, params, "parameters are redacted")
+
+	calls := 0
+	fc := func() (string, int64) {
+		calls++
+		return "SELECT *\n\tFROM movies   WHERE id = ?", 1
+	}
+
+	ql.Trace(context.Background(), time.Now(), fc, nil)
+	assert.Equal(t, 0, calls, "fast queries are not rendered when debug logging is off")
+
+	ql.Trace(context.Background(), time.Now().Add(-time.Second), fc, nil)
+	ql.Trace(context.Background(), time.Now().Add(-time.Second), fc, gorm.ErrRecordNotFound)
+	ql.Trace(context.Background(), time.Now(), fc, errors.New("connection reset"))
+	assert.Equal(t, 3, calls)
+
+	top := slow.Top(0)
+	require.Len(t, top, 1)
+	assert.Equal(t, "SELECT * FROM movies WHERE id = ?", top[0].SQL)
+	assert.Equal(t, int64(2), top[0].Count)
+}
+
+func TestSlowQueryThreshold(t *testing.T) {
+	threshold, err := slowQueryThreshold("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSlowQueryThreshold, threshold)
+
+	threshold, err = slowQueryThreshold("2s")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, threshold)
+
+	threshold, err = slowQueryThreshold("0")
+	require.NoError(t, err)
+	assert.Zero(t, threshold)
+
+	_, err = slowQueryThreshold("fast")
+	assert.Error(t, err)
+}
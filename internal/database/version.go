@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// migrationsTable is where golang-migrate records the applied schema version
+const migrationsTable = "schema_migrations"
+
+// ServerVersion returns the version reported by the database server, such as "16.2" for
+// PostgreSQL or "11.4.2-MariaDB" for MariaDB
+func (d *Database) ServerVersion(ctx context.Context) (string, error) {
+	if d.DB == nil {
+		return "", fmt.Errorf("database not available")
+	}
+
+	query := "SELECT VERSION()"
+	if d.IsPostgres() {
+		query = "SHOW server_version"
+	}
+
+	var version string
+	if err := d.DB.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to query database server version: %w", err)
+	}
+	return version, nil
+}
+
+// MigrationVersion returns the number of the last applied migration, and whether it failed part
+// way and left the schema dirty. It returns 0 before any migration has run.
+func (d *Database) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	if d.DB == nil {
+		return 0, false, fmt.Errorf("database not available")
+	}
+
+	var version int64
+	var dirty bool
+	err := d.DB.QueryRowContext(ctx, "SELECT version, dirty FROM "+migrationsTable+" LIMIT 1").
+		Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to query migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,253 @@
+// Package httpclient provides the shared outbound HTTP client configuration used
+// by services talking to indexers, TMDB, download clients, and notification providers.
+package httpclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+)
+
+const (
+	// ProxyTypeHTTP routes outbound traffic through an HTTP CONNECT proxy
+	ProxyTypeHTTP = "http"
+	// ProxyTypeHTTPS routes outbound traffic through an HTTP CONNECT proxy reached over TLS
+	ProxyTypeHTTPS = "https"
+	// ProxyTypeSocks5 routes outbound traffic through a SOCKS5 proxy
+	ProxyTypeSocks5 = "socks5"
+
+	defaultDialTimeout         = 30 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+	defaultMaxIdleConns        = 100
+)
+
+var (
+	mu        sync.RWMutex
+	proxyURL  *url.URL
+	bypass    []string
+	bypassLAN bool
+
+	transport         = newTransport(&tls.Config{MinVersion: tls.VersionTLS12})
+	insecureTransport = newTransport(&tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}) //nolint:gosec // Opt-in per provider
+	insecureHosts     []string
+
+	defaultRoundTripper  = &roundTripper{}
+	insecureRoundTripper = &roundTripper{skipTLSVerify: true}
+)
+
+// Option customizes a client returned by New
+type Option func(*http.Client)
+
+// WithSkipTLSVerify disables certificate verification for the client when skip is true.
+// It is intended for self-hosted providers that use self-signed certificates.
+func WithSkipTLSVerify(skip bool) Option {
+	return func(c *http.Client) {
+		if skip {
+			c.Transport = insecureRoundTripper
+		}
+	}
+}
+
+// roundTripper dispatches requests to the verifying or non-verifying transport
+type roundTripper struct {
+	skipTLSVerify bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	mu.RLock()
+	selected := transport
+	if rt.skipTLSVerify || matchesAny(req.URL.Hostname(), insecureHosts) {
+		selected = insecureTransport
+	}
+	mu.RUnlock()
+
+	return doWithRetry(selected, req)
+}
+
+// Configure applies the proxy settings to every client created by this package.
+// Clients created before Configure is called pick up the new settings on their
+// next request because they share a single transport.
+func Configure(cfg config.ProxyConfig) error {
+	var parsed *url.URL
+	if cfg.Enabled && cfg.Hostname != "" {
+		var err error
+		parsed, err = buildProxyURL(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	proxyURL = parsed
+	bypass = parseBypassFilter(cfg.BypassFilter)
+	bypassLAN = cfg.BypassLocalAddresses
+
+	// Drop pooled connections so they are re-established through the new proxy
+	transport.CloseIdleConnections()
+	insecureTransport.CloseIdleConnections()
+	return nil
+}
+
+// New returns an HTTP client with the given timeout that honors the configured proxy,
+// TLS, retry, and circuit breaker settings. The timeout bounds the request including retries.
+func New(timeout time.Duration, opts ...Option) *http.Client {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: defaultRoundTripper,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// ProxyURL returns the currently configured proxy, or nil when no proxy is in use
+func ProxyURL() *url.URL {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return proxyURL
+}
+
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxyForRequest,
+		DialContext: (&net.Dialer{
+			Timeout:   defaultDialTimeout,
+			KeepAlive: defaultKeepAlive,
+		}).DialContext,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        defaultMaxIdleConns,
+		IdleConnTimeout:     defaultIdleConnTimeout,
+		TLSHandshakeTimeout: defaultTLSHandshakeTimeout,
+	}
+}
+
+// proxyForRequest resolves the proxy for an outbound request, applying the bypass list
+func proxyForRequest(req *http.Request) (*url.URL, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if proxyURL == nil {
+		return nil, nil
+	}
+
+	if shouldBypass(req.URL.Hostname(), bypass, bypassLAN) {
+		return nil, nil
+	}
+
+	return proxyURL, nil
+}
+
+func buildProxyURL(cfg config.ProxyConfig) (*url.URL, error) {
+	proxyType := strings.ToLower(cfg.Type)
+	if proxyType == "" {
+		proxyType = ProxyTypeHTTP
+	}
+
+	switch proxyType {
+	case ProxyTypeHTTP, ProxyTypeHTTPS, ProxyTypeSocks5:
+	default:
+		return nil, fmt.Errorf("unsupported proxy type: %s (supported: %s, %s, %s)",
+			cfg.Type, ProxyTypeHTTP, ProxyTypeHTTPS, ProxyTypeSocks5)
+	}
+
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		return nil, fmt.Errorf("invalid proxy port: %d", cfg.Port)
+	}
+
+	u := &url.URL{
+		Scheme: proxyType,
+		Host:   net.JoinHostPort(cfg.Hostname, strconv.Itoa(cfg.Port)),
+	}
+	if cfg.Username != "" {
+		u.User = url.UserPassword(cfg.Username, cfg.Password)
+	}
+
+	return u, nil
+}
+
+// parseBypassFilter splits a comma or semicolon separated host list
+func parseBypassFilter(filter string) []string {
+	fields := strings.FieldsFunc(filter, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+
+	hosts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if host := strings.ToLower(strings.TrimSpace(field)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+// shouldBypass reports whether a host matches the bypass list or is a local address
+func shouldBypass(host string, filter []string, bypassLocal bool) bool {
+	host = strings.ToLower(host)
+
+	if matchesAny(host, filter) {
+		return true
+	}
+
+	if bypassLocal {
+		return isLocalAddress(host)
+	}
+
+	return false
+}
+
+func matchesAny(host string, patterns []string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range patterns {
+		if matchHost(host, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchHost(host, pattern string) bool {
+	if strings.HasPrefix(pattern, "*.") {
+		suffix := pattern[1:]
+		return strings.HasSuffix(host, suffix) || host == pattern[2:]
+	}
+
+	if _, network, err := net.ParseCIDR(pattern); err == nil {
+		ip := net.ParseIP(host)
+		return ip != nil && network.Contains(ip)
+	}
+
+	return host == pattern
+}
+
+func isLocalAddress(host string) bool {
+	if host == "localhost" || !strings.Contains(host, ".") && net.ParseIP(host) == nil {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".lan")
+	}
+
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+}
@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigure_ProxyURL(t *testing.T) {
+	t.Cleanup(func() { _ = Configure(config.ProxyConfig{}) })
+
+	err := Configure(config.ProxyConfig{
+		Enabled:  true,
+		Type:     "socks5",
+		Hostname: "proxy.example.com",
+		Port:     1080,
+		Username: "user",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+
+	proxy := ProxyURL()
+	require.NotNil(t, proxy)
+	assert.Equal(t, "socks5", proxy.Scheme)
+	assert.Equal(t, "proxy.example.com:1080", proxy.Host)
+	assert.Equal(t, "user", proxy.User.Username())
+}
+
+func TestConfigure_Disabled(t *testing.T) {
+	err := Configure(config.ProxyConfig{Enabled: false, Hostname: "proxy.example.com", Port: 8080})
+	require.NoError(t, err)
+	assert.Nil(t, ProxyURL())
+}
+
+func TestConfigure_InvalidSettings(t *testing.T) {
+	err := Configure(config.ProxyConfig{Enabled: true, Type: "socks4", Hostname: "proxy", Port: 1080})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "supported: http, https, socks5")
+
+	err = Configure(config.ProxyConfig{Enabled: true, Type: "http", Hostname: "proxy", Port: 0})
+	assert.Error(t, err)
+}
+
+func TestProxyForRequest_Bypass(t *testing.T) {
+	t.Cleanup(func() { _ = Configure(config.ProxyConfig{}) })
+
+	require.NoError(t, Configure(config.ProxyConfig{
+		Enabled:              true,
+		Type:                 "http",
+		Hostname:             "proxy.example.com",
+		Port:                 3128,
+		BypassFilter:         "*.internal.example, tracker.local; 10.10.0.0/16",
+		BypassLocalAddresses: true,
+	}))
+
+	tests := []struct {
+		url       string
+		proxied   bool
+		assertion string
+	}{
+		{"https://api.themoviedb.org/3/movie/1", true, "public host"},
+		{"https://indexer.internal.example/api", false, "wildcard match"},
+		{"http://tracker.local/rss", false, "exact match"},
+		{"http://10.10.4.2:8080/api", false, "CIDR match"},
+		{"http://192.168.1.20:8080/api", false, "private address"},
+		{"http://localhost:9091/transmission/rpc", false, "localhost"},
+		{"http://sabnzbd:8080/api", false, "single label host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.assertion, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, http.NoBody)
+			require.NoError(t, err)
+
+			proxy, err := proxyForRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.proxied, proxy != nil)
+		})
+	}
+}
+
+func TestNew_SharesTransport(t *testing.T) {
+	first := New(5 * time.Second)
+	second := New(30 * time.Second)
+
+	assert.Equal(t, 5*time.Second, first.Timeout)
+	assert.Same(t, first.Transport, second.Transport)
+}
+
+func TestWithSkipTLSVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	_, err = New(5 * time.Second).Do(req)
+	assert.Error(t, err, "self-signed certificate should be rejected by default")
+
+	resp, err := New(5*time.Second, WithSkipTLSVerify(true)).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestConfigureTLS_CABundle(t *testing.T) {
+	t.Cleanup(func() { _ = ConfigureTLS(config.TLSConfig{}) })
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, certPEM, 0o600))
+
+	require.NoError(t, ConfigureTLS(config.TLSConfig{CABundlePath: bundle}))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := New(5 * time.Second).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestConfigureTLS_InvalidBundle(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(bundle, []byte("not a certificate"), 0o600))
+
+	assert.Error(t, ConfigureTLS(config.TLSConfig{CABundlePath: bundle}))
+	assert.Error(t, ConfigureTLS(config.TLSConfig{CABundlePath: filepath.Join(t.TempDir(), "missing.pem")}))
+}
@@ -0,0 +1,457 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+)
+
+// BreakerState represents the state of a per-host circuit breaker
+type BreakerState string
+
+const (
+	// BreakerStateClosed allows requests through normally
+	BreakerStateClosed BreakerState = "closed"
+	// BreakerStateOpen rejects requests until the reset timeout elapses
+	BreakerStateOpen BreakerState = "open"
+	// BreakerStateHalfOpen allows a single trial request to probe the host
+	BreakerStateHalfOpen BreakerState = "halfOpen"
+
+	defaultMaxRetries       = 2
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+	defaultFailureThreshold = 5
+	defaultResetTimeout     = 60 * time.Second
+)
+
+// ErrCircuitOpen is returned when a request is rejected by an open circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerStatus is a point-in-time snapshot of a host's circuit breaker
+type BreakerStatus struct {
+	Host                string       `json:"host"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	LastError           string       `json:"lastError,omitempty"`
+	LastFailure         *time.Time   `json:"lastFailure,omitempty"`
+	OpenedAt            *time.Time   `json:"openedAt,omitempty"`
+}
+
+// retryPolicy holds the retry and circuit breaker settings applied to all requests
+type retryPolicy struct {
+	maxRetries       int
+	baseDelay        time.Duration
+	maxDelay         time.Duration
+	attemptTimeout   time.Duration
+	failureThreshold int
+	resetTimeout     time.Duration
+}
+
+type breaker struct {
+	state       BreakerState
+	failures    int
+	lastError   string
+	lastFailure time.Time
+	openedAt    time.Time
+	probing     bool
+}
+
+var (
+	policyMu sync.RWMutex
+	policy   = retryPolicy{
+		maxRetries:       defaultMaxRetries,
+		baseDelay:        defaultRetryBaseDelay,
+		maxDelay:         defaultRetryMaxDelay,
+		failureThreshold: defaultFailureThreshold,
+		resetTimeout:     defaultResetTimeout,
+	}
+
+	breakersMu sync.Mutex
+	breakers   = make(map[string]*breaker)
+)
+
+// ConfigureResilience applies the retry and circuit breaker policy from configuration
+func ConfigureResilience(cfg config.ResilienceConfig) error {
+	next := retryPolicy{
+		maxRetries:       cfg.MaxRetries,
+		failureThreshold: cfg.BreakerFailureThreshold,
+	}
+
+	durations := []struct {
+		value    string
+		fallback time.Duration
+		target   *time.Duration
+		name     string
+	}{
+		{cfg.RetryBaseDelay, defaultRetryBaseDelay, &next.baseDelay, "retry_base_delay"},
+		{cfg.RetryMaxDelay, defaultRetryMaxDelay, &next.maxDelay, "retry_max_delay"},
+		{cfg.AttemptTimeout, 0, &next.attemptTimeout, "attempt_timeout"},
+		{cfg.BreakerResetTimeout, defaultResetTimeout, &next.resetTimeout, "breaker_reset_timeout"},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			*d.target = d.fallback
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", d.name, d.value, err)
+		}
+		*d.target = parsed
+	}
+
+	if next.maxRetries < 0 {
+		next.maxRetries = 0
+	}
+
+	policyMu.Lock()
+	policy = next
+	policyMu.Unlock()
+
+	return nil
+}
+
+// BreakerStatuses returns a snapshot of every host's circuit breaker, sorted by host
+func BreakerStatuses() []BreakerStatus {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	statuses := make([]BreakerStatus, 0, len(breakers))
+	for host, b := range breakers {
+		status := BreakerStatus{
+			Host:                host,
+			State:               b.state,
+			ConsecutiveFailures: b.failures,
+			LastError:           b.lastError,
+		}
+		if !b.lastFailure.IsZero() {
+			lastFailure := b.lastFailure
+			status.LastFailure = &lastFailure
+		}
+		if b.state != BreakerStateClosed {
+			openedAt := b.openedAt
+			status.OpenedAt = &openedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Host < statuses[j].Host })
+	return statuses
+}
+
+// ResetBreakers clears all circuit breaker state
+func ResetBreakers() {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	breakers = make(map[string]*breaker)
+}
+
+func currentPolicy() retryPolicy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+
+	return policy
+}
+
+// doWithRetry executes a request against the transport with breaker checks and retries
+func doWithRetry(rt http.RoundTripper, req *http.Request) (*http.Response, error) {
+	p := currentPolicy()
+	host := strings.ToLower(req.URL.Host)
+
+	retries := p.maxRetries
+	if !isRetryable(req) {
+		retries = 0
+	}
+
+	var lastErr error
+	var retryAfter time.Duration
+	for attempt := 0; attempt <= retries; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			wait := sleepWithJitter
+			delay := backoff(p, attempt)
+			if retryAfter > 0 {
+				wait, delay = sleep, retryAfter
+			}
+			if err := wait(req.Context(), delay); err != nil {
+				return nil, err
+			}
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := allowRequest(host, p); err != nil {
+			return nil, err
+		}
+
+		resp, err := roundTripAttempt(rt, attemptReq, p.attemptTimeout)
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			recordSuccess(host)
+			return resp, nil
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("server returned status %d", resp.StatusCode)
+		}
+
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			// A caller giving up says nothing about the host, though its deadline passing does
+			if errors.Is(ctxErr, context.Canceled) {
+				releaseProbe(host)
+			} else {
+				recordFailure(host, lastErr, p)
+			}
+			return nil, ctxErr
+		}
+		recordFailure(host, lastErr, p)
+
+		// Certificate problems will not resolve themselves between attempts
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return nil, err
+		}
+
+		// Hosts that say when to come back are retried then, or left to the caller when that's
+		// later than retries wait
+		retryAfter = 0
+		if delay, ok := retryAfterDelay(resp, time.Now()); ok {
+			if delay > p.maxDelay {
+				return resp, nil
+			}
+			retryAfter = delay
+		}
+
+		// Hand the final response back to the caller so it can inspect the status
+		if attempt == retries && resp != nil {
+			return resp, nil
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	return nil, lastErr
+}
+
+func roundTripAttempt(rt http.RoundTripper, req *http.Request, timeout time.Duration) (*http.Response, error) {
+	if timeout <= 0 {
+		return rt.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// allowRequest checks the host's breaker, moving it to half-open once the reset timeout elapses
+func allowRequest(host string, p retryPolicy) error {
+	if p.failureThreshold <= 0 {
+		return nil
+	}
+
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	b, ok := breakers[host]
+	if !ok {
+		return nil
+	}
+
+	switch b.state {
+	case BreakerStateOpen:
+		if time.Since(b.openedAt) < p.resetTimeout {
+			return fmt.Errorf("%w for %s: %s", ErrCircuitOpen, host, b.lastError)
+		}
+		b.state = BreakerStateHalfOpen
+		b.probing = true
+		return nil
+	case BreakerStateHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w for %s: trial request in progress", ErrCircuitOpen, host)
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func recordSuccess(host string) {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	if b, ok := breakers[host]; ok {
+		b.state = BreakerStateClosed
+		b.failures = 0
+		b.probing = false
+	}
+}
+
+// releaseProbe lets another trial request through a half-open breaker whose trial was cancelled
+func releaseProbe(host string) {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	if b, ok := breakers[host]; ok {
+		b.probing = false
+	}
+}
+
+func recordFailure(host string, err error, p retryPolicy) {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	b, ok := breakers[host]
+	if !ok {
+		b = &breaker{state: BreakerStateClosed}
+		breakers[host] = b
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	b.lastFailure = time.Now()
+	b.probing = false
+
+	if p.failureThreshold <= 0 {
+		return
+	}
+
+	if b.state == BreakerStateHalfOpen || b.failures >= p.failureThreshold {
+		b.state = BreakerStateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// retryOptInKey is the context key of WithRetries
+type retryOptInKey struct{}
+
+// WithRetries returns a context whose requests are retried whatever their method, for calls that
+// are safe to repeat although they aren't GETs, such as a JSON-RPC status query. Their bodies must
+// be rewindable, as those of http.NewRequest with a bytes or strings reader are.
+func WithRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryOptInKey{}, true)
+}
+
+// isRetryable reports whether a request can safely be sent more than once. Only methods that
+// change nothing are retried by default: resending a grab or a notification would repeat it.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	optedIn, _ := req.Context().Value(retryOptInKey{}).(bool)
+	return optedIn && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryAfterDelay returns how long a 429 or 503 response asks clients to wait before retrying,
+// given in its Retry-After header as seconds or a date
+func retryAfterDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil ||
+		(resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rewindRequest clones a request for a retry attempt with a fresh copy of its body
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return clone, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	clone.Body = body
+	return clone, nil
+}
+
+// backoff returns the exponential delay for a retry attempt, capped at the maximum delay
+func backoff(p retryPolicy, attempt int) time.Duration {
+	delay := p.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	return delay
+}
+
+// sleepWithJitter waits between half and the full delay, returning early if ctx is cancelled
+func sleepWithJitter(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	half := delay / 2
+	jittered := half + time.Duration(rand.Int64N(int64(half)+1)) // #nosec G404 -- Jitter does not need crypto randomness
+	return sleep(ctx, jittered)
+}
+
+// sleep waits for delay, returning early if ctx is cancelled
+func sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases a per-attempt context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,299 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func configureTestResilience(t *testing.T, cfg config.ResilienceConfig) {
+	t.Helper()
+
+	require.NoError(t, ConfigureResilience(cfg))
+	ResetBreakers()
+	t.Cleanup(func() {
+		_ = ConfigureResilience(config.ResilienceConfig{MaxRetries: defaultMaxRetries, BreakerFailureThreshold: defaultFailureThreshold})
+		ResetBreakers()
+	})
+}
+
+func TestDoWithRetry_RetriesServerErrors(t *testing.T) {
+	configureTestResilience(t, config.ResilienceConfig{
+		MaxRetries:              3,
+		RetryBaseDelay:          "1ms",
+		RetryMaxDelay:           "5ms",
+		BreakerFailureThreshold: 10,
+	})
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := New(5 * time.Second).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDoWithRetry_NonIdempotentRequests(t *testing.T) {
+	configureTestResilience(t, config.ResilienceConfig{
+		MaxRetries:              2,
+		RetryBaseDelay:          "1ms",
+		RetryMaxDelay:           "5ms",
+		BreakerFailureThreshold: 10,
+	})
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	send := func(ctx context.Context, method string) int32 {
+		calls.Store(0)
+		req, err := http.NewRequestWithContext(ctx, method, server.URL, strings.NewReader(`{"method":"add"}`))
+		require.NoError(t, err)
+		require.NotNil(t, req.GetBody)
+		resp, err := New(5 * time.Second).Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return calls.Load()
+	}
+
+	// Requests with side effects are sent once, even with a rewindable body
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		assert.Equal(t, int32(1), send(context.Background(), method), method)
+	}
+	assert.Equal(t, int32(3), send(WithRetries(context.Background()), http.MethodPost))
+}
+
+func TestDoWithRetry_ReturnsFinalResponse(t *testing.T) {
+	configureTestResilience(t, config.ResilienceConfig{
+		MaxRetries:     1,
+		RetryBaseDelay: "1ms",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := New(5 * time.Second).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	configureTestResilience(t, config.ResilienceConfig{
+		MaxRetries:              0,
+		BreakerFailureThreshold: 2,
+		BreakerResetTimeout:     "20ms",
+	})
+
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := New(5 * time.Second)
+	get := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := get()
+		require.NoError(t, err)
+	}
+
+	_, err := get()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+
+	statuses := BreakerStatuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, BreakerStateOpen, statuses[0].State)
+	assert.Equal(t, 2, statuses[0].ConsecutiveFailures)
+
+	healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+
+	resp, err := get()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, BreakerStateClosed, BreakerStatuses()[0].State)
+}
+
+func TestConfigureResilience_InvalidDuration(t *testing.T) {
+	err := ConfigureResilience(config.ResilienceConfig{RetryBaseDelay: "soon"})
+	assert.Error(t, err)
+}
+
+func TestBackoff_Capped(t *testing.T) {
+	p := retryPolicy{baseDelay: 100 * time.Millisecond, maxDelay: 300 * time.Millisecond}
+
+	assert.Equal(t, 100*time.Millisecond, backoff(p, 1))
+	assert.Equal(t, 200*time.Millisecond, backoff(p, 2))
+	assert.Equal(t, 300*time.Millisecond, backoff(p, 3))
+	assert.Equal(t, 300*time.Millisecond, backoff(p, 40))
+}
+
+func TestDoWithRetry_RetryAfter(t *testing.T) {
+	configureTestResilience(t, config.ResilienceConfig{
+		MaxRetries:              2,
+		RetryBaseDelay:          "1ms",
+		RetryMaxDelay:           "2s",
+		BreakerFailureThreshold: 10,
+	})
+
+	var calls atomic.Int32
+	var retryAfter atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", retryAfter.Load().(string))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func() (*http.Response, time.Duration) {
+		calls.Store(0)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		start := time.Now()
+		resp, err := New(5 * time.Second).Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp, time.Since(start)
+	}
+
+	// The retry waits as long as the host asked rather than the shorter backoff
+	retryAfter.Store("1")
+	resp, elapsed := get()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.GreaterOrEqual(t, elapsed, time.Second)
+
+	// A wait longer than retries take is left to the caller
+	retryAfter.Store(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	resp, _ = get()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	response := func(status int, value string) *http.Response {
+		return &http.Response{StatusCode: status, Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	delay, ok := retryAfterDelay(response(http.StatusServiceUnavailable, "120"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = retryAfterDelay(response(http.StatusTooManyRequests, "Thu, 15 Oct 2026 12:00:30 GMT"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	delay, ok = retryAfterDelay(response(http.StatusTooManyRequests, "Thu, 15 Oct 2026 11:00:00 GMT"), now)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+
+	_, ok = retryAfterDelay(response(http.StatusInternalServerError, "120"), now)
+	assert.False(t, ok)
+	_, ok = retryAfterDelay(response(http.StatusServiceUnavailable, "soon"), now)
+	assert.False(t, ok)
+	_, ok = retryAfterDelay(nil, now)
+	assert.False(t, ok)
+}
+
+func TestCircuitBreaker_IgnoresCancellation(t *testing.T) {
+	configureTestResilience(t, config.ResilienceConfig{
+		MaxRetries:              0,
+		BreakerFailureThreshold: 1,
+		BreakerResetTimeout:     "20ms",
+	})
+
+	var mode atomic.Int32 // 0 answers, 1 hangs until released, 2 fails
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch mode.Load() {
+		case 1:
+			<-release
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := New(5 * time.Second)
+	get := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+	cancelled := func() error {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		return get(ctx)
+	}
+
+	mode.Store(1)
+	require.ErrorIs(t, cancelled(), context.Canceled)
+	assert.Empty(t, BreakerStatuses(), "a cancelled request is not a host failure")
+
+	// A cancelled trial request of a half-open breaker lets the next one through
+	mode.Store(2)
+	require.NoError(t, get(context.Background()))
+	assert.Equal(t, BreakerStateOpen, BreakerStatuses()[0].State)
+	time.Sleep(30 * time.Millisecond)
+	mode.Store(1)
+	require.ErrorIs(t, cancelled(), context.Canceled)
+	mode.Store(0)
+	require.NoError(t, get(context.Background()))
+	assert.Equal(t, BreakerStateClosed, BreakerStatuses()[0].State)
+}
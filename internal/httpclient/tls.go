@@ -0,0 +1,69 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/radarr/radarr-go/internal/config"
+)
+
+// ConfigureTLS loads the extra CA bundle and the list of hosts that skip certificate
+// verification. It replaces the shared transports, so it should be called at startup
+// before outbound requests are made.
+func ConfigureTLS(cfg config.TLSConfig) error {
+	rootCAs, err := loadRootCAs(cfg.CABundlePath)
+	if err != nil {
+		return err
+	}
+
+	secure := newTransport(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	})
+	insecure := newTransport(&tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            rootCAs,
+		InsecureSkipVerify: true, //nolint:gosec // Opt-in per host or provider
+	})
+
+	mu.Lock()
+	previous := []*http.Transport{transport, insecureTransport}
+	transport = secure
+	insecureTransport = insecure
+	insecureHosts = parseBypassFilter(cfg.InsecureSkipVerifyHosts)
+	mu.Unlock()
+
+	for _, t := range previous {
+		t.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+// loadRootCAs returns the system pool extended with the certificates in bundlePath.
+// A nil pool is returned when no bundle is configured so the system roots are used.
+func loadRootCAs(bundlePath string) (*x509.CertPool, error) {
+	if bundlePath == "" {
+		return nil, nil
+	}
+
+	pem, err := os.ReadFile(bundlePath) // #nosec G304 -- Path comes from administrator configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", bundlePath, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("CA bundle does not contain any valid PEM certificates")
+	}
+
+	return pool, nil
+}
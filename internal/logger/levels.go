@@ -0,0 +1,217 @@
+package logger
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Components whose log level can be overridden at runtime
+const (
+	ComponentAPI    = "api"
+	ComponentSearch = "search"
+	ComponentImport = "import"
+	ComponentTasks  = "tasks"
+	ComponentDB     = "db"
+)
+
+// Components lists the subsystems that accept log level overrides
+var Components = []string{ComponentAPI, ComponentSearch, ComponentImport, ComponentTasks, ComponentDB}
+
+// LevelStatus reports the global log level and the effective level of each component
+type LevelStatus struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+	Overrides  map[string]string `json:"overrides"`
+}
+
+// componentLevel is a component's level, followed only while overridden is set
+type componentLevel struct {
+	overridden atomic.Bool
+	level      zap.AtomicLevel
+}
+
+// levelRegistry holds the global level and the per-component overrides shared by a logger and
+// every component logger derived from it. The component set is fixed at construction.
+type levelRegistry struct {
+	root       zap.AtomicLevel
+	components map[string]*componentLevel
+}
+
+func newLevelRegistry(level zapcore.Level) *levelRegistry {
+	r := &levelRegistry{
+		root:       zap.NewAtomicLevelAt(level),
+		components: make(map[string]*componentLevel, len(Components)),
+	}
+	for _, name := range Components {
+		r.components[name] = &componentLevel{level: zap.NewAtomicLevel()}
+	}
+	return r
+}
+
+// enabler returns the level enabler for a component, or the global level for names that
+// cannot be overridden
+func (r *levelRegistry) enabler(name string) zapcore.LevelEnabler {
+	c := r.components[name]
+	if c == nil {
+		return r.root
+	}
+	return zap.LevelEnablerFunc(func(level zapcore.Level) bool {
+		if c.overridden.Load() {
+			return c.level.Enabled(level)
+		}
+		return r.root.Enabled(level)
+	})
+}
+
+// filteredCore applies a logger's level to cores that accept every level
+type filteredCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+// Enabled implements zapcore.LevelEnabler
+func (c *filteredCore) Enabled(level zapcore.Level) bool {
+	return c.enabler.Enabled(level)
+}
+
+// With implements zapcore.Core
+func (c *filteredCore) With(fields []zapcore.Field) zapcore.Core {
+	return &filteredCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+// Check implements zapcore.Core
+func (c *filteredCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// ParseLevel validates a level name accepted by SetLevel and SetComponentLevel
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case "debug", "info", "warn", "error":
+		return parseLevel(level), nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", level)
+	}
+}
+
+// Component returns a logger for one subsystem. Its level follows the global level until an
+// override is set with SetComponentLevel.
+func (l *Logger) Component(name string) *Logger {
+	if l == nil || l.levels == nil || l.base == nil {
+		return l
+	}
+
+	// The sinks stay owned by the root logger, so component loggers carry no closers
+	return &Logger{
+		SugaredLogger: filtered(l.base, l.levels.enabler(name)).Named(name).Sugar(),
+		base:          l.base,
+		levels:        l.levels,
+		recent:        l.recent,
+	}
+}
+
+// filtered returns base restricted to the levels accepted by enabler
+func filtered(base *zap.Logger, enabler zapcore.LevelEnabler) *zap.Logger {
+	return base.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &filteredCore{Core: core, enabler: enabler}
+	}))
+}
+
+// SetLevel changes the global log level
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	if l.levels != nil {
+		l.levels.root.SetLevel(parsed)
+	}
+	return nil
+}
+
+// SetComponentLevel overrides the level of one component. An empty level removes the override
+// so the component follows the global level again.
+func (l *Logger) SetComponentLevel(component, level string) error {
+	if l.levels == nil {
+		return nil
+	}
+
+	c := l.levels.components[component]
+	if c == nil {
+		return fmt.Errorf("unknown log component %q", component)
+	}
+
+	if level == "" {
+		c.overridden.Store(false)
+		return nil
+	}
+
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	c.level.SetLevel(parsed)
+	c.overridden.Store(true)
+	return nil
+}
+
+// ApplyLevels sets the global level, when given, and the listed component overrides after
+// validating all of them, so an invalid entry leaves every level unchanged
+func (l *Logger) ApplyLevels(level string, components map[string]string) error {
+	if level != "" {
+		if _, err := ParseLevel(level); err != nil {
+			return err
+		}
+	}
+	for component, componentLevel := range components {
+		if l.levels != nil && l.levels.components[component] == nil {
+			return fmt.Errorf("unknown log component %q", component)
+		}
+		if componentLevel != "" {
+			if _, err := ParseLevel(componentLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if level != "" {
+		if err := l.SetLevel(level); err != nil {
+			return err
+		}
+	}
+	for component, componentLevel := range components {
+		if err := l.SetComponentLevel(component, componentLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Levels reports the global level and each component's effective level and override
+func (l *Logger) Levels() LevelStatus {
+	status := LevelStatus{
+		Level:      zapcore.InfoLevel.String(),
+		Components: make(map[string]string, len(Components)),
+		Overrides:  make(map[string]string),
+	}
+	if l.levels == nil {
+		return status
+	}
+
+	status.Level = l.levels.root.Level().String()
+	for name, c := range l.levels.components {
+		if c.overridden.Load() {
+			status.Components[name] = c.level.Level().String()
+			status.Overrides[name] = c.level.Level().String()
+		} else {
+			status.Components[name] = status.Level
+		}
+	}
+	return status
+}
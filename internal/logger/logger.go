@@ -2,59 +2,149 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+const (
+	consoleFormat = "console"
+
+	// Entries repeated more than samplingInitial times a second are sampled, as zap's production
+	// configuration does, so a misbehaving loop cannot flood the sinks
+	samplingInitial    = 100
+	samplingThereafter = 100
+)
+
 // Logger provides structured logging for Radarr
 type Logger struct {
 	*zap.SugaredLogger
+
+	// base writes to every sink without a level check; levels filters it per component
+	base    *zap.Logger
+	levels  *levelRegistry
+	recent  *recentBuffer
+	closers []io.Closer
 }
 
-// New creates a new logger with the given configuration
+// New creates a new logger with the given configuration. Every enabled sink receives each
+// entry; a sink that cannot be opened is skipped and reported through the others. The sinks
+// accept every level and the logger filters entries by the global or component level.
 func New(cfg config.LogConfig) *Logger {
-	var zapConfig zap.Config
+	level := parseLevel(cfg.Level)
+
+	var cores []zapcore.Core
+	var closers []io.Closer
+	var sinkErrors []error
+
+	if cfg.Output != "none" {
+		writer, closer, err := openConsole(cfg.Output)
+		if err != nil {
+			sinkErrors = append(sinkErrors, fmt.Errorf("console output %s: %w", cfg.Output, err))
+		} else {
+			cores = append(cores, zapcore.NewCore(newEncoder(cfg.Format), writer, zapcore.DebugLevel))
+			if closer != nil {
+				closers = append(closers, closer)
+			}
+		}
+	}
+
+	if cfg.File.Enabled {
+		file, err := newRotatingFile(cfg.File)
+		if err != nil {
+			sinkErrors = append(sinkErrors, fmt.Errorf("log file %s: %w", cfg.File.Path, err))
+		} else {
+			cores = append(cores, zapcore.NewCore(newEncoder(cfg.File.Format), file, zapcore.DebugLevel))
+			closers = append(closers, file)
+		}
+	}
+
+	if cfg.Syslog.Enabled {
+		core, closer, err := newSyslogCore(cfg.Syslog, zapcore.DebugLevel)
+		if err != nil {
+			sinkErrors = append(sinkErrors, fmt.Errorf("syslog: %w", err))
+		} else {
+			cores = append(cores, core)
+			closers = append(closers, closer)
+		}
+	}
+
+	if len(cores) == 0 {
+		// Fallback to basic logger
+		return &Logger{SugaredLogger: zap.NewNop().Sugar()}
+	}
+
+	// Recent entries are also kept in memory for diagnostics bundles
+	recent := newRecentBuffer(recentLogCapacity)
+	cores = append(cores, zapcore.NewCore(newEncoder(""), recent, zapcore.DebugLevel))
 
-	switch cfg.Level {
+	core := zapcore.NewTee(cores...)
+	if level > zapcore.DebugLevel {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, samplingInitial, samplingThereafter)
+	}
+
+	base := zap.New(core,
+		zap.AddCaller(),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	)
+	levels := newLevelRegistry(level)
+
+	l := &Logger{
+		SugaredLogger: filtered(base, levels.root).Sugar(),
+		base:          base,
+		levels:        levels,
+		recent:        recent,
+		closers:       closers,
+	}
+	for _, err := range sinkErrors {
+		l.Warn("Failed to open log sink", "error", err)
+	}
+	return l
+}
+
+// parseLevel maps the configured level name to a zap level, defaulting to info
+func parseLevel(level string) zapcore.Level {
+	switch level {
 	case "debug":
-		zapConfig = zap.NewDevelopmentConfig()
-	case "info", "":
-		zapConfig = zap.NewProductionConfig()
+		return zapcore.DebugLevel
 	case "warn":
-		zapConfig = zap.NewProductionConfig()
-		zapConfig.Level = zap.NewAtomicLevelAt(zapcore.WarnLevel)
+		return zapcore.WarnLevel
 	case "error":
-		zapConfig = zap.NewProductionConfig()
-		zapConfig.Level = zap.NewAtomicLevelAt(zapcore.ErrorLevel)
+		return zapcore.ErrorLevel
 	default:
-		zapConfig = zap.NewProductionConfig()
+		return zapcore.InfoLevel
 	}
+}
 
-	// Configure output
-	if cfg.Output != "" && cfg.Output != "stdout" {
-		zapConfig.OutputPaths = []string{cfg.Output}
-		zapConfig.ErrorOutputPaths = []string{cfg.Output}
+// newEncoder returns a human readable encoder for the console format and JSON otherwise
+func newEncoder(format string) zapcore.Encoder {
+	if format == consoleFormat {
+		return zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
 	}
+	return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+}
 
-	// Configure encoding
-	const consoleFormat = "console"
-	if cfg.Format == consoleFormat {
-		zapConfig.Encoding = consoleFormat
-		zapConfig.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+// openConsole resolves the console output to a writer. Paths other than stdout and stderr are
+// opened for appending and returned with their closer.
+func openConsole(output string) (zapcore.WriteSyncer, io.Closer, error) {
+	switch output {
+	case "", "stdout":
+		return zapcore.Lock(os.Stdout), nil, nil
+	case "stderr":
+		return zapcore.Lock(os.Stderr), nil, nil
 	}
 
-	logger, err := zapConfig.Build()
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_APPEND, logFilePerm) // #nosec G304 -- Path comes from administrator configuration
 	if err != nil {
-		// Fallback to basic logger
-		logger = zap.NewNop()
-	}
-
-	return &Logger{
-		SugaredLogger: logger.Sugar(),
+		return nil, nil, err
 	}
+	return zapcore.Lock(file), file, nil
 }
 
 // Fatal logs a fatal message and exits the application
@@ -63,7 +153,12 @@ func (l *Logger) Fatal(msg string, keysAndValues ...interface{}) {
 	os.Exit(1)
 }
 
-// Close flushes any buffered log entries
+// Close flushes any buffered log entries and releases the file and syslog sinks
 func (l *Logger) Close() {
 	_ = l.Sync() //nolint:errcheck // Sync on shutdown, error is non-critical
+
+	for _, closer := range l.closers {
+		_ = closer.Close() //nolint:errcheck // Closing on shutdown, error is non-critical
+	}
+	l.closers = nil
 }
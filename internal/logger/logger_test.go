@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_FileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "radarr.log")
+
+	log := New(config.LogConfig{
+		Level:  "info",
+		Output: "none",
+		File:   config.LogFileConfig{Enabled: true, Path: path, Format: "json"},
+	})
+	log.Debug("Hidden at info level")
+	log.Infow("Movie imported", "movieId", 42)
+	log.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "Movie imported", entry["msg"])
+	assert.Equal(t, "info", entry["level"])
+	assert.EqualValues(t, 42, entry["movieId"])
+}
+
+func TestNew_NoSinks(t *testing.T) {
+	log := New(config.LogConfig{Output: "none"})
+	require.NotNil(t, log)
+	log.Info("Discarded")
+	log.Close()
+}
+
+func TestLogger_RecentEntries(t *testing.T) {
+	log := New(config.LogConfig{Level: "info", Output: filepath.Join(t.TempDir(), "console.log")})
+	defer log.Close()
+
+	log.Debug("Hidden at info level")
+	log.Component(ComponentSearch).Infow("Search started", "movieId", 7)
+
+	entries := log.RecentEntries()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0], `"msg":"Search started"`)
+
+	buffer := newRecentBuffer(3)
+	for _, entry := range []string{"a\n", "b\n", "c\n", "d\n"} {
+		_, err := buffer.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, buffer.snapshot(), "the oldest entries are dropped")
+}
+
+func TestRotatingFile_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "radarr.log")
+
+	file, err := newRotatingFile(config.LogFileConfig{Path: path, MaxSizeMB: 1, MaxBackups: 2})
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	chunk := []byte(strings.Repeat("x", 600*1024) + "\n")
+	for i := 0; i < 4; i++ {
+		_, err := file.Write(chunk)
+		require.NoError(t, err)
+		// Backup names carry millisecond timestamps, keep them distinct
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	backups := file.backups()
+	assert.Len(t, backups, 2, "older backups are pruned")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(chunk)), info.Size())
+}
+
+func TestRotatingFile_PruneByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "radarr.log")
+
+	old := filepath.Join(dir, "radarr-20200101T000000.000.log")
+	recent := filepath.Join(dir, "radarr-20200102T000000.000.log")
+	require.NoError(t, os.WriteFile(old, []byte("old\n"), 0600))
+	require.NoError(t, os.WriteFile(recent, []byte("recent\n"), 0600))
+	past := time.Now().Add(-10 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(old, past, past))
+
+	file, err := newRotatingFile(config.LogFileConfig{Path: path, MaxSizeMB: 1, MaxAgeDays: 7})
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	assert.Equal(t, []string{recent}, file.backups())
+}
+
+func TestRotatingFile_KeepsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "radarr.log")
+
+	// Files named like backups of radarr.log that aren't, old enough and many enough to be pruned
+	others := []string{"radarr-debug.log", "radarr-2020.log", "radarr-20200101T000000.log"}
+	past := time.Now().Add(-10 * 24 * time.Hour)
+	for _, name := range others {
+		other := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(other, []byte("other\n"), 0600))
+		require.NoError(t, os.Chtimes(other, past, past))
+	}
+
+	file, err := newRotatingFile(config.LogFileConfig{Path: path, MaxSizeMB: 1, MaxBackups: 1, MaxAgeDays: 7})
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	assert.Empty(t, file.backups())
+	file.prune()
+	for _, name := range others {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+}
+
+func TestLogger_ComponentLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "radarr.log")
+
+	log := New(config.LogConfig{
+		Level:  "info",
+		Output: "none",
+		File:   config.LogFileConfig{Enabled: true, Path: path},
+	})
+	search := log.Component(ComponentSearch)
+	tasks := log.Component(ComponentTasks)
+
+	require.NoError(t, log.ApplyLevels("", map[string]string{ComponentSearch: "debug"}))
+	search.Debug("Search debug")
+	tasks.Debug("Tasks debug")
+
+	status := log.Levels()
+	assert.Equal(t, "info", status.Level)
+	assert.Equal(t, "debug", status.Components[ComponentSearch])
+	assert.Equal(t, "info", status.Components[ComponentTasks])
+	assert.Equal(t, map[string]string{ComponentSearch: "debug"}, status.Overrides)
+
+	// Invalid entries leave every level unchanged
+	assert.Error(t, log.ApplyLevels("warn", map[string]string{"scheduler": "debug"}))
+	assert.Error(t, log.ApplyLevels("warn", map[string]string{ComponentTasks: "verbose"}))
+	assert.Equal(t, "info", log.Levels().Level)
+
+	// Clearing the override makes the component follow the global level again
+	require.NoError(t, log.ApplyLevels("error", map[string]string{ComponentSearch: ""}))
+	search.Info("Search info")
+	tasks.Error("Tasks error")
+	log.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	output := string(data)
+	assert.Contains(t, output, "Search debug")
+	assert.Contains(t, output, `"logger":"search"`)
+	assert.NotContains(t, output, "Tasks debug")
+	assert.NotContains(t, output, "Search info")
+	assert.Contains(t, output, "Tasks error")
+}
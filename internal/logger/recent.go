@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+)
+
+// recentLogCapacity is the number of entries kept in memory for diagnostics bundles
+const recentLogCapacity = 1000
+
+// recentBuffer is a write syncer that keeps the last entries written to it, one JSON entry per
+// write, so recent logs are available without a file sink
+type recentBuffer struct {
+	mu      sync.Mutex
+	entries []string
+	next    int
+}
+
+func newRecentBuffer(capacity int) *recentBuffer {
+	return &recentBuffer{entries: make([]string, 0, capacity)}
+}
+
+// Write implements zapcore.WriteSyncer
+func (b *recentBuffer) Write(p []byte) (int, error) {
+	entry := strings.TrimSuffix(string(p), "\n")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) < cap(b.entries) {
+		b.entries = append(b.entries, entry)
+	} else {
+		b.entries[b.next] = entry
+		b.next = (b.next + 1) % len(b.entries)
+	}
+	return len(p), nil
+}
+
+// Sync implements zapcore.WriteSyncer
+func (b *recentBuffer) Sync() error {
+	return nil
+}
+
+// snapshot returns the buffered entries, oldest first
+func (b *recentBuffer) snapshot() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := make([]string, 0, len(b.entries))
+	entries = append(entries, b.entries[b.next:]...)
+	return append(entries, b.entries[:b.next]...)
+}
+
+// RecentEntries returns up to the last thousand entries logged through this logger or any
+// logger derived from it, oldest first, as JSON lines
+func (l *Logger) RecentEntries() []string {
+	if l == nil || l.recent == nil {
+		return nil
+	}
+	return l.recent.snapshot()
+}
@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+)
+
+const (
+	logFilePerm = 0640
+	logDirPerm  = 0750
+
+	// backupTimeFormat is embedded in rotated file names and sorts chronologically
+	backupTimeFormat = "20060102T150405.000"
+
+	bytesPerMB = 1024 * 1024
+)
+
+// rotatingFile writes log entries to a file and moves it aside once it reaches the size limit.
+// Rotated files are named after the active file with the rotation time appended and are
+// removed once they exceed the age or backup count limits.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// newRotatingFile opens the configured log file, creating its directory when needed
+func newRotatingFile(cfg config.LogFileConfig) (*rotatingFile, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("log file path is not set")
+	}
+
+	r := &rotatingFile{
+		path:       cfg.Path,
+		maxSize:    int64(cfg.MaxSizeMB) * bytesPerMB,
+		maxAge:     time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+		maxBackups: cfg.MaxBackups,
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	r.prune()
+	return r, nil
+}
+
+// Write implements io.Writer, rotating first when p would take the file past its size limit
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		// A failed rotation keeps writing to the current file when it is still open
+		if err := r.rotate(); err != nil && r.file == nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Sync implements zapcore.WriteSyncer
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the active file
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the active file for appending and records its current size
+func (r *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), logDirPerm); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, logFilePerm) // #nosec G304 -- Path comes from administrator configuration
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate moves the active file aside, starts a new one and prunes old backups
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	ext := filepath.Ext(r.path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(r.path, ext), time.Now().Format(backupTimeFormat), ext)
+	if err := os.Rename(r.path, backup); err != nil {
+		// Keep appending to the current file rather than losing entries
+		if openErr := r.open(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := r.open(); err != nil {
+		return err
+	}
+	r.prune()
+	return nil
+}
+
+// backups lists the rotated files belonging to the active file, newest first. Files that only
+// look alike, such as radarr-debug.log next to radarr.log, are not backups and are left alone.
+func (r *rotatingFile) backups() []string {
+	ext := filepath.Ext(r.path)
+	prefix := strings.TrimSuffix(r.path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil
+	}
+
+	backups := matches[:0]
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups
+}
+
+// prune removes rotated files beyond the backup count or older than the age limit
+func (r *rotatingFile) prune() {
+	cutoff := time.Now().Add(-r.maxAge)
+
+	for i, backup := range r.backups() {
+		expired := r.maxBackups > 0 && i >= r.maxBackups
+		if !expired && r.maxAge > 0 {
+			if info, err := os.Stat(backup); err == nil && info.ModTime().Before(cutoff) {
+				expired = true
+			}
+		}
+		if expired {
+			_ = os.Remove(backup) //nolint:errcheck // Retried on the next rotation
+		}
+	}
+}
@@ -0,0 +1,90 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"io"
+	"log/syslog"
+	"strings"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogCore writes entries to syslog at the priority matching their level. Entries are JSON
+// without time or level fields, since syslog records both itself.
+type syslogCore struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	writer  *syslog.Writer
+}
+
+// newSyslogCore connects to the configured syslog daemon
+func newSyslogCore(cfg config.LogSyslogConfig, enabler zapcore.LevelEnabler) (zapcore.Core, io.Closer, error) {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "radarr"
+	}
+
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = ""
+	encoderConfig.LevelKey = ""
+
+	return &syslogCore{
+		LevelEnabler: enabler,
+		encoder:      zapcore.NewJSONEncoder(encoderConfig),
+		writer:       writer,
+	}, writer, nil
+}
+
+// With implements zapcore.Core
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	encoder := c.encoder.Clone()
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+	return &syslogCore{LevelEnabler: c.LevelEnabler, encoder: encoder, writer: c.writer}
+}
+
+// Check implements zapcore.Core
+func (c *syslogCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core
+func (c *syslogCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	message := strings.TrimSuffix(buf.String(), "\n")
+	switch entry.Level {
+	case zapcore.DebugLevel:
+		return c.writer.Debug(message)
+	case zapcore.InfoLevel:
+		return c.writer.Info(message)
+	case zapcore.WarnLevel:
+		return c.writer.Warning(message)
+	case zapcore.ErrorLevel:
+		return c.writer.Err(message)
+	default:
+		return c.writer.Crit(message)
+	}
+}
+
+// Sync implements zapcore.Core; syslog writes are not buffered
+func (c *syslogCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package logger
+
+import (
+	"errors"
+	"io"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"go.uber.org/zap/zapcore"
+)
+
+// newSyslogCore reports that syslog is unavailable; Windows installs should use the log file
+func newSyslogCore(_ config.LogSyslogConfig, _ zapcore.LevelEnabler) (zapcore.Core, io.Closer, error) {
+	return nil, nil, errors.New("syslog is not supported on windows")
+}
@@ -151,6 +151,7 @@ type ConfigurationBackup struct {
 	NamingConfig          *NamingConfig          `json:"namingConfig"`
 	MediaManagementConfig *MediaManagementConfig `json:"mediaManagementConfig"`
 	AppSettings           *AppSettings           `json:"appSettings"`
+	UIConfig              *UIConfig              `json:"uiConfig"`
 	QualityProfiles       []QualityProfile       `json:"qualityProfiles"`
 	CustomFormats         []CustomFormat         `json:"customFormats"`
 	RootFolders           []RootFolder           `json:"rootFolders"`
@@ -160,6 +161,8 @@ type ConfigurationBackup struct {
 	Notifications         []Notification         `json:"notifications"`
 	BackupSize            int64                  `json:"backupSize"`
 	ConfigurationCount    int                    `json:"configurationCount"`
+	// ArchiveName is the stored archive of the backup, which /config/backup/{name} downloads
+	ArchiveName string `json:"archiveName,omitempty"`
 }
 
 // ConfigurationValidationResult represents validation results for configuration
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Provider settings keys of the completed download folder, for download clients without an API
+// Radarr can track downloads through, such as watch folder based clients
+const (
+	// SettingCompletedDownloadFolder is the folder the client moves finished downloads to
+	SettingCompletedDownloadFolder = "completedDownloadFolder"
+	// SettingCompletedFolderScanInterval is the number of minutes between scans of the folder
+	SettingCompletedFolderScanInterval = "completedFolderScanInterval"
+)
+
+// DefaultCompletedFolderScanInterval is how often a completed download folder is scanned when
+// the client doesn't set an interval
+const DefaultCompletedFolderScanInterval = time.Minute
+
+// CompletedDownloadFolder returns the folder the client moves finished downloads to, or "" when
+// the client is tracked through its API
+func (dc *DownloadClient) CompletedDownloadFolder() string {
+	folder, _ := dc.Settings[SettingCompletedDownloadFolder].(string)
+	return strings.TrimSpace(folder)
+}
+
+// CompletedFolderScanInterval returns how often the client's completed download folder is scanned
+func (dc *DownloadClient) CompletedFolderScanInterval() time.Duration {
+	if minutes := settingFloat(dc.Settings, SettingCompletedFolderScanInterval); minutes > 0 {
+		return time.Duration(minutes * float64(time.Minute))
+	}
+	return DefaultCompletedFolderScanInterval
+}
+
+// CompletedFolderScanResult summarizes a scan of the download clients' completed download folders
+type CompletedFolderScanResult struct {
+	Clients  int `json:"clients"`
+	Scanned  int `json:"scanned"`
+	Matched  int `json:"matched"`
+	Imported int `json:"imported"`
+	// Unmatched are the folder entries no grab was found for, left in place
+	Unmatched []string `json:"unmatched"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// ConfigManifestVersion is the version of the declarative configuration format written by exports
+const ConfigManifestVersion = 1
+
+// ConfigManifest is the declarative form of an instance's settings, kept as YAML so it can be
+// reviewed and versioned. Settings that refer to each other do so by name rather than id, so a
+// manifest applies to any instance. Sections left out of a manifest are not managed by it.
+type ConfigManifest struct {
+	Version         int              `json:"version" yaml:"version"`
+	ExportedAt      *time.Time       `json:"exportedAt,omitempty" yaml:"exportedAt,omitempty"`
+	Naming          map[string]any   `json:"naming,omitempty" yaml:"naming,omitempty"`
+	CustomFormats   []map[string]any `json:"customFormats,omitempty" yaml:"customFormats,omitempty"`
+	QualityProfiles []map[string]any `json:"qualityProfiles,omitempty" yaml:"qualityProfiles,omitempty"`
+	DownloadClients []map[string]any `json:"downloadClients,omitempty" yaml:"downloadClients,omitempty"`
+	Indexers        []map[string]any `json:"indexers,omitempty" yaml:"indexers,omitempty"`
+	ImportLists     []map[string]any `json:"importLists,omitempty" yaml:"importLists,omitempty"`
+	Notifications   []map[string]any `json:"notifications,omitempty" yaml:"notifications,omitempty"`
+}
+
+// ConfigManifestAction is what applying a manifest did, or would do, to one setting
+type ConfigManifestAction string
+
+// Manifest apply actions
+const (
+	ConfigManifestCreated   ConfigManifestAction = "created"
+	ConfigManifestUpdated   ConfigManifestAction = "updated"
+	ConfigManifestUnchanged ConfigManifestAction = "unchanged"
+	ConfigManifestDeleted   ConfigManifestAction = "deleted"
+)
+
+// ConfigManifestChange is the action taken on one setting of a manifest section
+type ConfigManifestChange struct {
+	Section string               `json:"section"`
+	Name    string               `json:"name"`
+	Action  ConfigManifestAction `json:"action"`
+}
+
+// ConfigManifestApplyResult lists what applying a manifest changed. A dry run lists what it would
+// change without writing anything.
+type ConfigManifestApplyResult struct {
+	DryRun    bool                   `json:"dryRun"`
+	Changes   []ConfigManifestChange `json:"changes"`
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Deleted   int                    `json:"deleted"`
+	// Warnings are settings applied with a caveat, such as redacted secrets with nothing to keep
+	Warnings  []string  `json:"warnings"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// Record adds a change to the result and its counts
+func (r *ConfigManifestApplyResult) Record(section, name string, action ConfigManifestAction) {
+	r.Changes = append(r.Changes, ConfigManifestChange{Section: section, Name: name, Action: action})
+	switch action {
+	case ConfigManifestCreated:
+		r.Created++
+	case ConfigManifestUpdated:
+		r.Updated++
+	case ConfigManifestUnchanged:
+		r.Unchanged++
+	case ConfigManifestDeleted:
+		r.Deleted++
+	}
+}
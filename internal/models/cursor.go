@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PageCursor marks the last record returned by a cursor-paginated listing. Listings are ordered
+// newest first by a timestamp with the record ID as a tie-breaker, so the next page holds the
+// records that sort after this position.
+type PageCursor struct {
+	Time time.Time
+	ID   int
+}
+
+// NewPageCursor returns the cursor positioned at the given record
+func NewPageCursor(t time.Time, id int) *PageCursor {
+	return &PageCursor{Time: t, ID: id}
+}
+
+// Encode returns the opaque token handed to clients as nextCursor
+func (c *PageCursor) Encode() string {
+	raw := strconv.FormatInt(c.Time.UnixNano(), 10) + ":" + strconv.Itoa(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParsePageCursor decodes a token produced by Encode. An empty token yields a nil cursor,
+// which starts from the newest record.
+func ParsePageCursor(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor: missing record ID")
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor timestamp: %w", err)
+	}
+	recordID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor record ID: %w", err)
+	}
+
+	return &PageCursor{Time: time.Unix(0, unixNano).UTC(), ID: recordID}, nil
+}
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// CustomFilterType identifies the view a custom filter applies to
+type CustomFilterType string
+
+const (
+	// CustomFilterTypeMovieIndex filters the movie index returned by GET /movie
+	CustomFilterTypeMovieIndex CustomFilterType = "movieIndex"
+)
+
+// FilterPredicateType is the comparison a filter predicate applies
+type FilterPredicateType string
+
+const (
+	// FilterEqual matches movies equal to any of the values
+	FilterEqual FilterPredicateType = "equal"
+	// FilterNotEqual matches movies equal to none of the values
+	FilterNotEqual FilterPredicateType = "notEqual"
+	// FilterLessThan matches movies below the value
+	FilterLessThan FilterPredicateType = "lessThan"
+	// FilterLessThanOrEqual matches movies at or below the value
+	FilterLessThanOrEqual FilterPredicateType = "lessThanOrEqual"
+	// FilterGreaterThan matches movies above the value
+	FilterGreaterThan FilterPredicateType = "greaterThan"
+	// FilterGreaterThanOrEqual matches movies at or above the value
+	FilterGreaterThanOrEqual FilterPredicateType = "greaterThanOrEqual"
+	// FilterContains matches movies whose list holds any of the values
+	FilterContains FilterPredicateType = "contains"
+	// FilterNotContains matches movies whose list holds none of the values
+	FilterNotContains FilterPredicateType = "notContains"
+)
+
+// FilterValueKind is the type of value a filter key compares
+type FilterValueKind int
+
+const (
+	// FilterValueBool compares true or false
+	FilterValueBool FilterValueKind = iota
+	// FilterValueInt compares whole numbers
+	FilterValueInt
+	// FilterValueString compares text
+	FilterValueString
+)
+
+// MovieFilterField describes a movie property custom filters can test
+type MovieFilterField struct {
+	Kind  FilterValueKind
+	Types []FilterPredicateType
+}
+
+var (
+	equalityPredicates   = []FilterPredicateType{FilterEqual, FilterNotEqual}
+	comparisonPredicates = []FilterPredicateType{
+		FilterEqual, FilterNotEqual, FilterLessThan, FilterLessThanOrEqual, FilterGreaterThan, FilterGreaterThanOrEqual,
+	}
+	listPredicates  = []FilterPredicateType{FilterContains, FilterNotContains}
+	rangePredicates = []FilterPredicateType{
+		FilterLessThan, FilterLessThanOrEqual, FilterGreaterThan, FilterGreaterThanOrEqual,
+	}
+)
+
+// MovieFilterFields are the movie index keys custom filters support, keyed by their API name
+var MovieFilterFields = map[string]MovieFilterField{
+	"monitored":        {Kind: FilterValueBool, Types: equalityPredicates},
+	"hasFile":          {Kind: FilterValueBool, Types: equalityPredicates},
+	"qualityProfileId": {Kind: FilterValueInt, Types: equalityPredicates},
+	"year":             {Kind: FilterValueInt, Types: comparisonPredicates},
+	"tags":             {Kind: FilterValueInt, Types: listPredicates},
+	"genres":           {Kind: FilterValueString, Types: listPredicates},
+	// daysSinceLastSearch counts movies never searched as searched infinitely long ago
+	"daysSinceLastSearch": {Kind: FilterValueInt, Types: rangePredicates},
+}
+
+// FilterPredicate is one condition of a custom filter. A movie matches when it satisfies the
+// comparison for any of the values; a filter's predicates must all match.
+type FilterPredicate struct {
+	Key   string              `json:"key"`
+	Value []interface{}       `json:"value"`
+	Type  FilterPredicateType `json:"type"`
+}
+
+// FilterPredicates is the list of predicates stored with a custom filter
+type FilterPredicates []FilterPredicate
+
+// Value implements the driver.Valuer interface for database storage
+func (p FilterPredicates) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(p)
+	return string(data), err
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (p *FilterPredicates) Scan(value interface{}) error {
+	if value == nil {
+		*p = FilterPredicates{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into FilterPredicates", value)
+	}
+}
+
+// CustomFilter is a saved, named set of predicates, such as a "4K missing" smart list
+type CustomFilter struct {
+	ID        int              `json:"id" gorm:"primaryKey;autoIncrement"`
+	Type      CustomFilterType `json:"type" gorm:"not null;size:50"`
+	Label     string           `json:"label" gorm:"not null;size:255"`
+	Filters   FilterPredicates `json:"filters" gorm:"type:text"`
+	CreatedAt time.Time        `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time        `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for the CustomFilter model
+func (CustomFilter) TableName() string {
+	return "custom_filters"
+}
+
+// Validate checks the label, type and every predicate, defaulting the type to the movie index
+func (f *CustomFilter) Validate() error {
+	if f.Label == "" {
+		return ValidationError{Field: "label", Message: "Label is required"}
+	}
+	if f.Type == "" {
+		f.Type = CustomFilterTypeMovieIndex
+	}
+	if f.Type != CustomFilterTypeMovieIndex {
+		return ValidationError{Field: "type", Message: fmt.Sprintf("Unsupported filter type %q", f.Type)}
+	}
+
+	for i, predicate := range f.Filters {
+		if err := predicate.validate(); err != nil {
+			return ValidationError{Field: fmt.Sprintf("filters[%d]", i), Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+func (p FilterPredicate) validate() error {
+	field, ok := MovieFilterFields[p.Key]
+	if !ok {
+		return fmt.Errorf("unknown filter key %q", p.Key)
+	}
+
+	supported := false
+	for _, t := range field.Types {
+		supported = supported || t == p.Type
+	}
+	if !supported {
+		return fmt.Errorf("filter type %q is not supported for %s", p.Type, p.Key)
+	}
+
+	if len(p.Value) == 0 {
+		return fmt.Errorf("%s requires at least one value", p.Key)
+	}
+	if p.IsRange() && len(p.Value) != 1 {
+		return fmt.Errorf("%s %s takes exactly one value", p.Key, p.Type)
+	}
+
+	_, err := p.Values()
+	return err
+}
+
+// IsRange reports whether the predicate compares against a single bound
+func (p FilterPredicate) IsRange() bool {
+	switch p.Type {
+	case FilterLessThan, FilterLessThanOrEqual, FilterGreaterThan, FilterGreaterThanOrEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Values converts the JSON values to the Go type of the predicate's key: bool, int or string
+func (p FilterPredicate) Values() ([]interface{}, error) {
+	field, ok := MovieFilterFields[p.Key]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter key %q", p.Key)
+	}
+
+	values := make([]interface{}, len(p.Value))
+	for i, raw := range p.Value {
+		var ok bool
+		switch field.Kind {
+		case FilterValueBool:
+			values[i], ok = raw.(bool)
+		case FilterValueInt:
+			// JSON numbers decode as float64
+			switch number := raw.(type) {
+			case int:
+				values[i], ok = number, true
+			case float64:
+				values[i], ok = int(number), number == math.Trunc(number)
+			}
+		case FilterValueString:
+			values[i], ok = raw.(string)
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v for %s", raw, p.Key)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,288 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Special values of the language specification's language field
+const (
+	customFormatLanguageOriginal = -2
+	customFormatLanguageAny      = -1
+)
+
+// bytesPerGB converts the size specification's limits, given in GB, to bytes
+const bytesPerGB = 1024 * 1024 * 1024
+
+// customFormatSources are the source specification values, indexed as upstream Radarr's Source enum
+var customFormatSources = []string{
+	"unknown", "cam", "telesync", "telecine", "workprint", "dvd", "tv", "webdl", "webrip", "bluray",
+}
+
+// customFormatSourceAliases maps other source names found in stored qualities to the upstream name
+var customFormatSourceAliases = map[string]string{"hdtv": "tv", "web-dl": "webdl", "web": "webdl", "": "unknown"}
+
+// customFormatModifiers are the quality modifier specification values, indexed as upstream
+// Radarr's Modifier enum
+var customFormatModifiers = []string{"none", "regional", "screener", "rawhd", "brdisk", "remux"}
+
+// CustomFormatInput is what custom format specifications are evaluated against: a parsed release,
+// or the release a movie file was imported from
+type CustomFormatInput struct {
+	Title        string
+	Edition      string
+	ReleaseGroup string
+	Quality      QualityDefinition
+	// Languages are language names, such as "English", matched ignoring case
+	Languages        []string
+	OriginalLanguage Language
+	IndexerFlags     int
+	Size             int64
+	Year             int
+}
+
+// CustomFormatMatch is the evaluation of a custom format against a release, with the outcome of
+// each of its specifications
+type CustomFormatMatch struct {
+	ID             int                      `json:"id"`
+	Name           string                   `json:"name"`
+	Matched        bool                     `json:"matched"`
+	Specifications []CustomFormatSpecResult `json:"specifications"`
+}
+
+// CustomFormatSpecResult is the outcome of one specification. Matched already accounts for negate.
+type CustomFormatSpecResult struct {
+	Name           string `json:"name"`
+	Implementation string `json:"implementation"`
+	Negate         bool   `json:"negate"`
+	Required       bool   `json:"required"`
+	Matched        bool   `json:"matched"`
+}
+
+// CustomFormatTestResult is the evaluation of a sample release title against every custom format
+type CustomFormatTestResult struct {
+	Title           string              `json:"title"`
+	ParsedMovieInfo *ParsedMovieInfo    `json:"parsedMovieInfo"`
+	CustomFormats   []CustomFormatMatch `json:"customFormats"`
+}
+
+// Matches reports whether the format matches input, see Evaluate
+func (f *CustomFormat) Matches(input *CustomFormatInput) bool {
+	return f.Evaluate(input).Matched
+}
+
+// Evaluate evaluates the format against input as upstream Radarr does. Specifications are grouped
+// by implementation: a group matches when none of its required specifications fail and at least
+// one of its specifications matches, and the format matches when every group does. So
+// specifications of the same type are alternatives, different types must all hold, and required
+// narrows a type down to every specification so marked. A format without specifications matches
+// nothing.
+func (f *CustomFormat) Evaluate(input *CustomFormatInput) CustomFormatMatch {
+	match := CustomFormatMatch{
+		ID:             f.ID,
+		Name:           f.Name,
+		Specifications: make([]CustomFormatSpecResult, 0, len(f.Specifications)),
+	}
+
+	type group struct{ anyMatched, requiredFailed bool }
+	groups := make(map[string]*group)
+	for _, spec := range f.Specifications {
+		if spec == nil {
+			continue
+		}
+		matched := spec.Matches(input)
+		match.Specifications = append(match.Specifications, CustomFormatSpecResult{
+			Name:           spec.Name,
+			Implementation: spec.Implementation,
+			Negate:         spec.Negate,
+			Required:       spec.Required,
+			Matched:        matched,
+		})
+
+		g := groups[spec.Implementation]
+		if g == nil {
+			g = &group{}
+			groups[spec.Implementation] = g
+		}
+		g.anyMatched = g.anyMatched || matched
+		g.requiredFailed = g.requiredFailed || (spec.Required && !matched)
+	}
+
+	match.Matched = len(groups) > 0
+	for _, g := range groups {
+		if !g.anyMatched || g.requiredFailed {
+			match.Matched = false
+		}
+	}
+	return match
+}
+
+// Matches reports whether input satisfies the specification, inverted when the specification is
+// negated
+func (s *CustomFormatSpec) Matches(input *CustomFormatInput) bool {
+	return s.matchesWithoutNegate(input) != s.Negate
+}
+
+// matchesWithoutNegate evaluates the specification the way upstream Radarr evaluates its
+// implementation. Unknown implementations match nothing.
+func (s *CustomFormatSpec) matchesWithoutNegate(input *CustomFormatInput) bool {
+	switch s.Implementation {
+	case "EditionSpecification":
+		return s.matchesPattern(input.Edition)
+	case "ReleaseGroupSpecification":
+		return s.matchesPattern(input.ReleaseGroup)
+	case "ReleaseTitleSpecification":
+		return s.matchesPattern(input.Title)
+	case "IndexerFlagSpecification":
+		flag, ok := s.intField("value")
+		return ok && flag != 0 && input.IndexerFlags&flag == flag
+	case "LanguageSpecification":
+		return s.matchesLanguage(input)
+	case "QualityModifierSpecification":
+		return s.matchesEnum(customFormatModifiers, customFormatModifier(input.Quality.Modifier))
+	case "ResolutionSpecification":
+		resolution, ok := s.intField("value")
+		return ok && input.Quality.Resolution == resolution
+	case "SizeSpecification":
+		minSize, _ := s.floatField("min")
+		maxSize, _ := s.floatField("max")
+		size := float64(input.Size)
+		return size > minSize*bytesPerGB && size <= maxSize*bytesPerGB
+	case "SourceSpecification":
+		return s.matchesEnum(customFormatSources, customFormatSource(input.Quality.Source))
+	case "YearSpecification":
+		minYear, _ := s.intField("min")
+		maxYear, _ := s.intField("max")
+		return input.Year >= minYear && input.Year <= maxYear
+	}
+	return false
+}
+
+// matchesPattern reports whether value matches the specification's case insensitive regular
+// expression. An invalid expression matches nothing.
+func (s *CustomFormatSpec) matchesPattern(value string) bool {
+	pattern, _ := s.Fields["value"].(string)
+	if pattern == "" {
+		return false
+	}
+	re := customFormatRegex(pattern)
+	return re != nil && re.MatchString(value)
+}
+
+// matchesLanguage reports whether input has the specification's language, or with exceptLanguage
+// set, any other language. Original stands for the movie's original language.
+func (s *CustomFormatSpec) matchesLanguage(input *CustomFormatInput) bool {
+	id, ok := s.intField("value")
+	if !ok {
+		return false
+	}
+	except, _ := s.Fields["exceptLanguage"].(bool)
+
+	var name string
+	switch {
+	case id == customFormatLanguageAny:
+		return len(input.Languages) > 0 && !except
+	case id == customFormatLanguageOriginal:
+		name = input.OriginalLanguage.Name
+	case id >= 0 && id < len(languageNames):
+		name = languageNames[id]
+	}
+	if name == "" {
+		return false
+	}
+
+	for _, language := range input.Languages {
+		if strings.EqualFold(language, name) != except {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEnum reports whether the specification's value is the index of value in names
+func (s *CustomFormatSpec) matchesEnum(names []string, value string) bool {
+	index, ok := s.intField("value")
+	return ok && index >= 0 && index < len(names) && names[index] == value
+}
+
+// intField returns a whole number field, which JSON decodes as a float
+func (s *CustomFormatSpec) intField(name string) (int, bool) {
+	value, ok := s.floatField(name)
+	return int(value), ok
+}
+
+// floatField returns a number field, accepting numbers given as strings
+func (s *CustomFormatSpec) floatField(name string) (float64, bool) {
+	switch value := s.Fields[name].(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// customFormatSource returns the upstream name of a stored quality source
+func customFormatSource(source string) string {
+	source = strings.ToLower(source)
+	if alias, ok := customFormatSourceAliases[source]; ok {
+		return alias
+	}
+	return source
+}
+
+// customFormatModifier returns the upstream name of a stored quality modifier
+func customFormatModifier(modifier string) string {
+	if modifier == "" {
+		return "none"
+	}
+	return strings.ToLower(modifier)
+}
+
+// customFormatRegexes caches compiled specification expressions by pattern, nil for invalid ones
+var customFormatRegexes sync.Map
+
+// customFormatRegex returns the compiled case insensitive form of pattern, or nil when invalid.
+// Patterns use Go's RE2 syntax, so the lookarounds some TRaSH-Guides formats borrow from .NET
+// don't compile and their specifications match nothing.
+func customFormatRegex(pattern string) *regexp.Regexp {
+	if cached, ok := customFormatRegexes.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		re = nil
+	}
+	customFormatRegexes.Store(pattern, re)
+	return re
+}
+
+// CustomFormatScore returns the profile's total score for the matched formats. Formats are
+// identified by id, or by name for profile items without one.
+func (qp *QualityProfile) CustomFormatScore(formats []CustomFormat) int {
+	score := 0
+	for i := range formats {
+		for _, item := range qp.FormatItems {
+			if item == nil {
+				continue
+			}
+			if (item.Format != nil && item.Format.ID == formats[i].ID) ||
+				(item.Format == nil && item.Name == formats[i].Name) {
+				score += item.Score
+				break
+			}
+		}
+	}
+	return score
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFormatSpec_Matches(t *testing.T) {
+	input := &CustomFormatInput{
+		Title:            "The.Movie.2019.2160p.UHD.BluRay.REMUX.HDR.TrueHD.Atmos-FraMeSToR",
+		Edition:          "Director's Cut",
+		ReleaseGroup:     "FraMeSToR",
+		Quality:          QualityDefinition{Name: "Remux-2160p", Source: "bluray", Resolution: 2160, Modifier: "remux"},
+		Languages:        []string{"english", "French"},
+		OriginalLanguage: Language{ID: 2, Name: "French"},
+		IndexerFlags:     1 | 8,
+		Size:             60 << 30,
+		Year:             2019,
+	}
+
+	tests := []struct {
+		name           string
+		implementation string
+		fields         map[string]interface{}
+		want           bool
+	}{
+		{"title regex", "ReleaseTitleSpecification", map[string]interface{}{"value": `\bremux\b`}, true},
+		{"title regex no match", "ReleaseTitleSpecification", map[string]interface{}{"value": `\bx265\b`}, false},
+		{"invalid regex", "ReleaseTitleSpecification", map[string]interface{}{"value": `(?<!web)remux`}, false},
+		{"edition", "EditionSpecification", map[string]interface{}{"value": `director`}, true},
+		{"release group", "ReleaseGroupSpecification", map[string]interface{}{"value": `^(framestor)$`}, true},
+		{"source", "SourceSpecification", map[string]interface{}{"value": float64(9)}, true},
+		{"other source", "SourceSpecification", map[string]interface{}{"value": float64(7)}, false},
+		{"resolution", "ResolutionSpecification", map[string]interface{}{"value": float64(2160)}, true},
+		{"other resolution", "ResolutionSpecification", map[string]interface{}{"value": 1080}, false},
+		{"modifier", "QualityModifierSpecification", map[string]interface{}{"value": float64(5)}, true},
+		{"language", "LanguageSpecification", map[string]interface{}{"value": float64(1)}, true},
+		{"missing language", "LanguageSpecification", map[string]interface{}{"value": float64(4)}, false},
+		{"original language", "LanguageSpecification", map[string]interface{}{"value": float64(-2)}, true},
+		{"any language", "LanguageSpecification", map[string]interface{}{"value": float64(-1)}, true},
+		{
+			"except language", "LanguageSpecification",
+			map[string]interface{}{"value": float64(1), "exceptLanguage": true}, true,
+		},
+		{"indexer flag", "IndexerFlagSpecification", map[string]interface{}{"value": float64(8)}, true},
+		{"missing indexer flag", "IndexerFlagSpecification", map[string]interface{}{"value": float64(2)}, false},
+		{"size", "SizeSpecification", map[string]interface{}{"min": float64(40), "max": "100"}, true},
+		{"too small", "SizeSpecification", map[string]interface{}{"min": float64(0), "max": float64(50)}, false},
+		{"year", "YearSpecification", map[string]interface{}{"min": float64(2010), "max": float64(2019)}, true},
+		{"unknown implementation", "CodecSpecification", map[string]interface{}{"value": "x265"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &CustomFormatSpec{Implementation: tt.implementation, Fields: tt.fields}
+			assert.Equal(t, tt.want, spec.Matches(input))
+		})
+	}
+}
+
+func TestCustomFormatSpec_Matches_Language(t *testing.T) {
+	english := &CustomFormatSpec{
+		Implementation: "LanguageSpecification",
+		Fields:         map[string]interface{}{"value": float64(1), "exceptLanguage": true},
+	}
+	assert.False(t, english.Matches(&CustomFormatInput{Languages: []string{"English"}}))
+	assert.False(t, english.Matches(&CustomFormatInput{}))
+
+	original := &CustomFormatSpec{Implementation: "LanguageSpecification", Fields: map[string]interface{}{"value": -2}}
+	assert.False(t, original.Matches(&CustomFormatInput{Languages: []string{"English"}}))
+}
+
+func TestCustomFormatSpec_Matches_Source(t *testing.T) {
+	tv := &CustomFormatSpec{Implementation: "SourceSpecification", Fields: map[string]interface{}{"value": float64(6)}}
+	assert.True(t, tv.Matches(&CustomFormatInput{Quality: QualityDefinition{Source: "hdtv"}}))
+
+	unknown := &CustomFormatSpec{Implementation: "SourceSpecification", Fields: map[string]interface{}{"value": 0}}
+	assert.True(t, unknown.Matches(&CustomFormatInput{}))
+
+	none := &CustomFormatSpec{Implementation: "QualityModifierSpecification", Fields: map[string]interface{}{"value": 0}}
+	assert.True(t, none.Matches(&CustomFormatInput{}))
+}
+
+func TestCustomFormat_Matches(t *testing.T) {
+	format := &CustomFormat{Specifications: CustomFormatSpecs{
+		{Implementation: "SourceSpecification", Fields: map[string]interface{}{"value": float64(7)}},
+		{Implementation: "ResolutionSpecification", Fields: map[string]interface{}{"value": float64(1080)}},
+	}}
+
+	assert.True(t, format.Matches(&CustomFormatInput{Quality: QualityDefinition{Source: "webdl", Resolution: 1080}}))
+	assert.False(t, format.Matches(&CustomFormatInput{Quality: QualityDefinition{Source: "webdl", Resolution: 720}}))
+	assert.False(t, (&CustomFormat{}).Matches(&CustomFormatInput{}))
+}
+
+func TestQualityProfile_CustomFormatScore(t *testing.T) {
+	profile := &QualityProfile{FormatItems: CustomFormatItems{
+		{Format: &CustomFormat{ID: 1}, Score: 100},
+		{Name: "HDR", Score: 50},
+		{Format: &CustomFormat{ID: 3}, Score: -1000},
+	}}
+
+	formats := []CustomFormat{{ID: 1, Name: "Remux"}, {ID: 2, Name: "HDR"}, {ID: 4, Name: "Unscored"}}
+	assert.Equal(t, 150, profile.CustomFormatScore(formats))
+	assert.Equal(t, 0, profile.CustomFormatScore(nil))
+}
+
+func TestCustomFormat_Evaluate(t *testing.T) {
+	source := func(value int, negate, required bool) *CustomFormatSpec {
+		return &CustomFormatSpec{
+			Name: "Source", Implementation: "SourceSpecification", Negate: negate, Required: required,
+			Fields: map[string]interface{}{"value": float64(value)},
+		}
+	}
+	title := func(pattern string, negate, required bool) *CustomFormatSpec {
+		return &CustomFormatSpec{
+			Name: "Title", Implementation: "ReleaseTitleSpecification", Negate: negate, Required: required,
+			Fields: map[string]interface{}{"value": pattern},
+		}
+	}
+	webdl := &CustomFormatInput{Title: "Movie.2020.1080p.AMZN.WEB-DL-GRP", Quality: QualityDefinition{Source: "webdl"}}
+
+	tests := []struct {
+		name  string
+		specs CustomFormatSpecs
+		want  bool
+	}{
+		{"one of a type is enough", CustomFormatSpecs{source(7, false, false), source(8, false, false)}, true},
+		{"none of a type", CustomFormatSpecs{source(8, false, false), source(9, false, false)}, false},
+		{"every type must match", CustomFormatSpecs{source(7, false, false), title(`\bNF\b`, false, false)}, false},
+		{"types combined", CustomFormatSpecs{source(7, false, false), title(`\bAMZN\b`, false, false)}, true},
+		{"negated", CustomFormatSpecs{source(9, true, false)}, true},
+		{"negated match", CustomFormatSpecs{source(7, true, false)}, false},
+		{"required fails", CustomFormatSpecs{title(`\bAMZN\b`, false, false), title(`\bNF\b`, false, true)}, false},
+		{"required holds", CustomFormatSpecs{title(`\bAMZN\b`, false, true), title(`\bNF\b`, false, false)}, true},
+		{
+			"required negated", CustomFormatSpecs{
+				title(`\bWEB-DL\b`, false, true), title(`\bHDR\b`, true, true), source(7, false, false),
+			}, true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := &CustomFormat{ID: 3, Name: "Test", Specifications: tt.specs}
+			match := format.Evaluate(webdl)
+			assert.Equal(t, tt.want, match.Matched)
+			assert.Equal(t, 3, match.ID)
+			assert.Len(t, match.Specifications, len(tt.specs))
+		})
+	}
+}
+
+func TestCustomFormat_Evaluate_SpecificationResults(t *testing.T) {
+	format := &CustomFormat{Name: "Not HDTV", Specifications: CustomFormatSpecs{
+		{
+			Name: "HDTV", Implementation: "SourceSpecification", Negate: true, Required: true,
+			Fields: map[string]interface{}{"value": float64(6)},
+		},
+	}}
+
+	match := format.Evaluate(&CustomFormatInput{Quality: QualityDefinition{Source: "hdtv"}})
+	assert.False(t, match.Matched)
+	assert.Equal(t, []CustomFormatSpecResult{
+		{Name: "HDTV", Implementation: "SourceSpecification", Negate: true, Required: true, Matched: false},
+	}, match.Specifications)
+}
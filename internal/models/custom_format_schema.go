@@ -0,0 +1,261 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// customFormatInfoLink is the documentation link upstream Radarr gives every specification
+const customFormatInfoLink = "https://wiki.servarr.com/radarr/settings#custom-formats-2"
+
+// CustomFormatField is a specification field in the upstream Radarr resource format
+type CustomFormatField struct {
+	Order         int            `json:"order"`
+	Name          string         `json:"name"`
+	Label         string         `json:"label"`
+	Unit          string         `json:"unit,omitempty"`
+	HelpText      string         `json:"helpText,omitempty"`
+	Value         interface{}    `json:"value"`
+	Type          string         `json:"type"`
+	Advanced      bool           `json:"advanced"`
+	SelectOptions []SelectOption `json:"selectOptions,omitempty"`
+	Privacy       string         `json:"privacy"`
+	IsFloat       bool           `json:"isFloat"`
+}
+
+// customFormatImplementation describes a specification type and its fields
+type customFormatImplementation struct {
+	Implementation     string
+	ImplementationName string
+	Fields             []CustomFormatField
+}
+
+func regexField() []CustomFormatField {
+	return []CustomFormatField{
+		{Name: "value", Label: "Regular Expression", HelpText: "Custom Format RegEx is Case Insensitive", Type: "textbox"},
+	}
+}
+
+func selectField(label string, options []SelectOption) []CustomFormatField {
+	return []CustomFormatField{{Name: "value", Label: label, Value: 0, Type: "select", SelectOptions: options}}
+}
+
+func rangeFields(minLabel, maxLabel, unit string, isFloat bool) []CustomFormatField {
+	return []CustomFormatField{
+		{Name: "min", Label: minLabel, Unit: unit, Value: 0, Type: "number", IsFloat: isFloat},
+		{Name: "max", Label: maxLabel, Unit: unit, Value: 0, Type: "number", IsFloat: isFloat},
+	}
+}
+
+func enumOptions(names ...string) []SelectOption {
+	options := make([]SelectOption, len(names))
+	for i, name := range names {
+		options[i] = SelectOption{Value: i, Name: name, Order: i}
+	}
+	return options
+}
+
+func valueOptions(values []int, names []string) []SelectOption {
+	options := make([]SelectOption, len(values))
+	for i := range values {
+		options[i] = SelectOption{Value: values[i], Name: names[i], Order: i}
+	}
+	return options
+}
+
+func languageOptions() []SelectOption {
+	options := []SelectOption{{Value: -2, Name: "Original", Order: 0}, {Value: -1, Name: "Any", Order: 1}}
+	for i, name := range languageNames {
+		options = append(options, SelectOption{Value: i, Name: name, Order: i + 2})
+	}
+	return options
+}
+
+// customFormatImplementations lists the specification types upstream Radarr supports, in the
+// order its /customformat/schema endpoint returns them
+var customFormatImplementations = []customFormatImplementation{
+	{
+		Implementation:     "EditionSpecification",
+		ImplementationName: "Edition",
+		Fields:             regexField(),
+	},
+	{
+		Implementation:     "IndexerFlagSpecification",
+		ImplementationName: "Indexer Flag",
+		Fields: selectField("Flag", valueOptions(
+			[]int{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048},
+			[]string{
+				"G_Freeleech", "G_Halfleech", "G_DoubleUpload", "PTP_Golden", "PTP_Approved", "G_Internal",
+				"AHD_Internal", "G_Scene", "G_Freeleech75", "G_Freeleech25", "AHD_UserRelease", "Nuked",
+			},
+		)),
+	},
+	{
+		Implementation:     "LanguageSpecification",
+		ImplementationName: "Language",
+		Fields: append(selectField("Language", languageOptions()),
+			CustomFormatField{
+				Order: 1, Name: "exceptLanguage", Label: "Except Language", Value: false, Type: "checkbox",
+				HelpText: "Matches if any language other than the selected language is present",
+			}),
+	},
+	{
+		Implementation:     "QualityModifierSpecification",
+		ImplementationName: "Quality Modifier",
+		Fields:             selectField("Quality Modifier", enumOptions("NONE", "REGIONAL", "SCREENER", "RAWHD", "BRDISK", "REMUX")),
+	},
+	{
+		Implementation:     "ReleaseGroupSpecification",
+		ImplementationName: "Release Group",
+		Fields:             regexField(),
+	},
+	{
+		Implementation:     "ReleaseTitleSpecification",
+		ImplementationName: "Release Title",
+		Fields:             regexField(),
+	},
+	{
+		Implementation:     "ResolutionSpecification",
+		ImplementationName: "Resolution",
+		Fields: selectField("Resolution", valueOptions(
+			[]int{0, 360, 480, 540, 576, 720, 1080, 2160},
+			[]string{"Unknown", "R360p", "R480p", "R540p", "R576p", "R720p", "R1080p", "R2160p"},
+		)),
+	},
+	{
+		Implementation:     "SizeSpecification",
+		ImplementationName: "Size",
+		Fields:             rangeFields("Minimum Size", "Maximum Size", "GB", true),
+	},
+	{
+		Implementation:     "SourceSpecification",
+		ImplementationName: "Source",
+		Fields: selectField("Source", enumOptions(
+			"UNKNOWN", "CAM", "TELESYNC", "TELECINE", "WORKPRINT", "DVD", "TV", "WEBDL", "WEBRIP", "BLURAY",
+		)),
+	},
+	{
+		Implementation:     "YearSpecification",
+		ImplementationName: "Year",
+		Fields:             rangeFields("Minimum Year", "Maximum Year", "", false),
+	},
+}
+
+func findCustomFormatImplementation(implementation string) *customFormatImplementation {
+	for i := range customFormatImplementations {
+		if customFormatImplementations[i].Implementation == implementation {
+			return &customFormatImplementations[i]
+		}
+	}
+	return nil
+}
+
+// IsKnownCustomFormatImplementation reports whether upstream Radarr supports the specification type
+func IsKnownCustomFormatImplementation(implementation string) bool {
+	return findCustomFormatImplementation(implementation) != nil
+}
+
+// CustomFormatSpecSchemas returns an empty specification of every supported type, as served by
+// /customformat/schema
+func CustomFormatSpecSchemas() CustomFormatSpecs {
+	schemas := make(CustomFormatSpecs, 0, len(customFormatImplementations))
+	for _, implementation := range customFormatImplementations {
+		schemas = append(schemas, &CustomFormatSpec{
+			Implementation: implementation.Implementation,
+			Fields:         map[string]interface{}{},
+		})
+	}
+	return schemas
+}
+
+// customFormatSpecResource is the upstream Radarr JSON form of a specification
+type customFormatSpecResource struct {
+	Name               string              `json:"name"`
+	Implementation     string              `json:"implementation"`
+	ImplementationName string              `json:"implementationName"`
+	InfoLink           string              `json:"infoLink"`
+	Negate             bool                `json:"negate"`
+	Required           bool                `json:"required"`
+	Fields             []CustomFormatField `json:"fields"`
+}
+
+// MarshalJSON writes the specification as upstream Radarr does, with fields as an ordered array
+// carrying their labels and types, so Recyclarr and Notifiarr can compare it with TRaSH-Guides
+func (s CustomFormatSpec) MarshalJSON() ([]byte, error) {
+	resource := customFormatSpecResource{
+		Name:           s.Name,
+		Implementation: s.Implementation,
+		InfoLink:       customFormatInfoLink,
+		Negate:         s.Negate,
+		Required:       s.Required,
+		Fields:         []CustomFormatField{},
+	}
+
+	if implementation := findCustomFormatImplementation(s.Implementation); implementation != nil {
+		resource.ImplementationName = implementation.ImplementationName
+		for i, field := range implementation.Fields {
+			field.Order = i
+			field.Privacy = "normal"
+			if value, ok := s.Fields[field.Name]; ok {
+				field.Value = value
+			}
+			resource.Fields = append(resource.Fields, field)
+		}
+		return json.Marshal(resource)
+	}
+
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		resource.Fields = append(resource.Fields, CustomFormatField{
+			Order: i, Name: name, Label: name, Value: s.Fields[name], Type: "textbox", Privacy: "normal",
+		})
+	}
+	return json.Marshal(resource)
+}
+
+// UnmarshalJSON reads a specification with fields either as the upstream array of named fields
+// or as the name-to-value object used by TRaSH-Guides and the database
+func (s *CustomFormatSpec) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name           string          `json:"name"`
+		Implementation string          `json:"implementation"`
+		Negate         bool            `json:"negate"`
+		Required       bool            `json:"required"`
+		Fields         json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = CustomFormatSpec{
+		Name:           raw.Name,
+		Implementation: raw.Implementation,
+		Negate:         raw.Negate,
+		Required:       raw.Required,
+		Fields:         map[string]interface{}{},
+	}
+	if len(raw.Fields) == 0 || string(raw.Fields) == "null" {
+		return nil
+	}
+
+	if raw.Fields[0] != '[' {
+		return json.Unmarshal(raw.Fields, &s.Fields)
+	}
+
+	var fields []struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(raw.Fields, &fields); err != nil {
+		return fmt.Errorf("invalid specification fields: %w", err)
+	}
+	for _, field := range fields {
+		s.Fields[field.Name] = field.Value
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFormatSpec_MarshalJSON(t *testing.T) {
+	spec := &CustomFormatSpec{
+		Name:           "Not 2160p",
+		Implementation: "ResolutionSpecification",
+		Negate:         true,
+		Fields:         map[string]interface{}{"value": 2160},
+	}
+
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	var resource map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &resource))
+	assert.Equal(t, "Resolution", resource["implementationName"])
+	assert.Equal(t, customFormatInfoLink, resource["infoLink"])
+
+	fields, ok := resource["fields"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, fields, 1)
+	field := fields[0].(map[string]interface{})
+	assert.Equal(t, "value", field["name"])
+	assert.Equal(t, "Resolution", field["label"])
+	assert.Equal(t, "select", field["type"])
+	assert.InDelta(t, 2160, field["value"], 0)
+	assert.NotEmpty(t, field["selectOptions"])
+}
+
+func TestCustomFormatSpec_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"upstream field array", `{"name":"x265","implementation":"ReleaseTitleSpecification","required":true,
+			"fields":[{"order":0,"name":"value","label":"Regular Expression","value":"[xh][ .]?265"}]}`},
+		{"TRaSH-Guides field object", `{"name":"x265","implementation":"ReleaseTitleSpecification","required":true,
+			"fields":{"value":"[xh][ .]?265"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec CustomFormatSpec
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &spec))
+			assert.Equal(t, "ReleaseTitleSpecification", spec.Implementation)
+			assert.True(t, spec.Required)
+			assert.Equal(t, map[string]interface{}{"value": "[xh][ .]?265"}, spec.Fields)
+		})
+	}
+}
+
+func TestCustomFormatSpecs_Value(t *testing.T) {
+	specs := CustomFormatSpecs{{Name: "Size", Implementation: "SizeSpecification",
+		Fields: map[string]interface{}{"min": 1.5, "max": 10.0}}}
+
+	value, err := specs.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"Size","implementation":"SizeSpecification","negate":false,"required":false,
+		"fields":{"min":1.5,"max":10}}]`, string(value.([]byte)))
+
+	var scanned CustomFormatSpecs
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, specs, scanned)
+}
+
+func TestCustomFormatSpecSchemas(t *testing.T) {
+	schemas := CustomFormatSpecSchemas()
+	require.Len(t, schemas, len(customFormatImplementations))
+	for _, schema := range schemas {
+		assert.True(t, IsKnownCustomFormatImplementation(schema.Implementation))
+	}
+	assert.False(t, IsKnownCustomFormatImplementation("MadeUpSpecification"))
+}
@@ -20,6 +20,10 @@
 // All models implement proper GORM hooks for validation and business logic,
 // custom JSON serialization for complex types, and follow Go naming conventions.
 //
+// Deletes are hard deletes: no model embeds gorm.DeletedAt, so counts and statistics never
+// include soft-deleted rows. Rows kept after they stop being used, such as seeding torrents
+// marked removed or expired movie tombstones, are deleted by the Cleanup task.
+//
 // Example usage:
 //
 //	movie := &models.Movie{
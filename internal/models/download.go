@@ -104,6 +104,8 @@ type DownloadClient struct {
 	Tags                     IntArray               `json:"tags" gorm:"type:text"`
 	CreatedAt                time.Time              `json:"added" gorm:"autoCreateTime"`
 	UpdatedAt                time.Time              `json:"updated" gorm:"autoUpdateTime"`
+	// LastTest is the outcome of the client's last connection test
+	LastTest ProviderTestStatus `json:"lastTest" gorm:"type:text"`
 }
 
 // TableName returns the database table name for the DownloadClient model
@@ -130,6 +132,11 @@ func (dc *DownloadClient) GetBaseURL() string {
 	return fmt.Sprintf("%s://%s:%d", scheme, dc.Host, dc.Port)
 }
 
+// SkipTLSVerify returns true if certificate verification is disabled for this client
+func (dc *DownloadClient) SkipTLSVerify() bool {
+	return settingBool(dc.Settings, SettingSkipTLSVerify)
+}
+
 // QueueStatus represents the status of a download in the queue
 type QueueStatus string
 
@@ -174,7 +181,7 @@ type QueueItem struct {
 	ErrorMessage            string             `json:"errorMessage" gorm:"type:text"`
 	Added                   time.Time          `json:"added" gorm:"autoCreateTime"`
 	Updated                 time.Time          `json:"updated" gorm:"autoUpdateTime"`
-	TimeLeft                *time.Duration     `json:"timeleft,omitempty"`
+	TimeLeft                *TimeSpan          `json:"timeleft,omitempty"`
 	EstimatedCompletionTime *time.Time         `json:"estimatedCompletionTime,omitempty"`
 	Protocol                DownloadProtocol   `json:"protocol" gorm:"size:20"`
 	OutputPath              string             `json:"outputPath" gorm:"size:500"`
@@ -185,6 +192,45 @@ func (QueueItem) TableName() string {
 	return "queue_items"
 }
 
+// MarshalJSON writes the download client as its name, as Radarr does
+func (q QueueItem) MarshalJSON() ([]byte, error) {
+	type queueItem QueueItem
+	item := struct {
+		queueItem
+		DownloadClient string `json:"downloadClient,omitempty"`
+	}{queueItem: queueItem(q)}
+	if q.DownloadClient != nil {
+		item.DownloadClient = q.DownloadClient.Name
+	}
+	return json.Marshal(item)
+}
+
+// UnmarshalJSON reads the download client as Radarr writes it, by name, or as an object
+func (q *QueueItem) UnmarshalJSON(data []byte) error {
+	type queueItem QueueItem
+	var item struct {
+		queueItem
+		DownloadClient json.RawMessage `json:"downloadClient,omitempty"`
+	}
+	// Like json.Unmarshal, the fields that could be read are kept when others have the wrong type
+	err := json.Unmarshal(data, &item)
+	*q = QueueItem(item.queueItem)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	switch {
+	case len(item.DownloadClient) == 0 || string(item.DownloadClient) == "null":
+	case json.Unmarshal(item.DownloadClient, &name) == nil:
+		q.DownloadClient = &DownloadClient{Name: name}
+	default:
+		q.DownloadClient = &DownloadClient{}
+		return json.Unmarshal(item.DownloadClient, q.DownloadClient)
+	}
+	return nil
+}
+
 // DownloadedInfo contains information about downloaded files
 type DownloadedInfo struct {
 	Hash           string `json:"hash"`
@@ -245,6 +291,32 @@ func (qi *QueueItem) GetProgress() float64 {
 type DownloadClientTestResult struct {
 	IsValid bool     `json:"isValid"`
 	Errors  []string `json:"validationFailures"`
+	// Version and APIVersion are reported by clients that expose them, such as qBittorrent
+	Version    string `json:"version,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	ProviderTestReport
+}
+
+// DownloadClientTransferRate is the current transfer speed of one download client, in bytes per
+// second
+type DownloadClientTransferRate struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Implementation DownloadClientType `json:"implementation"`
+	Protocol       DownloadProtocol   `json:"protocol"`
+	DownloadSpeed  int64              `json:"downloadSpeed"`
+	UploadSpeed    int64              `json:"uploadSpeed"`
+	// Error is set when the client couldn't be polled, leaving its speeds out of the totals
+	Error string `json:"error,omitempty"`
+}
+
+// DownloadClientBandwidth is the combined transfer speed of the enabled download clients, in
+// bytes per second, with a breakdown per client
+type DownloadClientBandwidth struct {
+	DownloadSpeed int64                        `json:"downloadSpeed"`
+	UploadSpeed   int64                        `json:"uploadSpeed"`
+	Clients       []DownloadClientTransferRate `json:"clients"`
+	CheckedAt     time.Time                    `json:"checkedAt"`
 }
 
 // DownloadHistory represents a completed download from history
@@ -359,6 +431,14 @@ func (s StatusMessageArray) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// MarshalJSON writes a nil array as empty rather than null, as Radarr does
+func (s StatusMessageArray) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]StatusMessage(s))
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (s *StatusMessageArray) Scan(value interface{}) error {
 	if value == nil {
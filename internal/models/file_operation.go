@@ -157,4 +157,14 @@ type FileOperationSummary struct {
 	Completed  int `json:"completed"`
 	Failed     int `json:"failed"`
 	Canceled   int `json:"canceled"`
+	// Imports reports whether imports are paused and how many files wait to be organized
+	Imports ImportQueueStatus `json:"imports"`
+}
+
+// ImportQueueStatus reports whether imports are paused and how many file organizations are queued
+type ImportQueueStatus struct {
+	Paused  bool       `json:"paused"`
+	Reason  string     `json:"reason,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	Pending int        `json:"pending"`
 }
@@ -32,6 +32,9 @@ type FileOrganization struct {
 	LastAttemptAt     *time.Time         `json:"lastAttemptAt"`
 	CreatedAt         time.Time          `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt         time.Time          `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Priority orders pending organizations, highest first, when queued imports are processed
+	Priority int `json:"priority" gorm:"default:0"`
 }
 
 // TableName returns the database table name for FileOrganization
@@ -66,6 +69,7 @@ const (
 type ImportDecision struct {
 	LocalMovie   *Movie             `json:"localMovie"`
 	RemoteMovie  *Movie             `json:"remoteMovie"`
+	Version      *MovieVersion      `json:"version,omitempty"` // nil imports the movie's primary file
 	Decision     ImportDecisionType `json:"decision"`
 	Rejections   []ImportRejection  `json:"rejections"`
 	DownloadItem *QueueItem         `json:"downloadItem,omitempty"`
@@ -151,6 +155,9 @@ type FileImportResult struct {
 	ErrorFiles      []ImportableFile `json:"errorFiles"`
 	ErrorSize       int64            `json:"errorSize"`
 	ProcessingTime  time.Duration    `json:"processingTime"`
+	// QueuedFiles were approved while imports were paused and wait as pending file organizations
+	QueuedFiles []ImportableFile `json:"queuedFiles"`
+	QueuedSize  int64            `json:"queuedSize"`
 }
 
 // FileOrganizationResult represents the result of organizing files
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// FileFingerprint is a lightweight fingerprint of a movie file, used to tell whether files a movie
+// has more than one of hold the same content
+type FileFingerprint struct {
+	ID          int `json:"id" gorm:"primaryKey;autoIncrement"`
+	MovieFileID int `json:"movieFileId" gorm:"not null;uniqueIndex"`
+	MovieID     int `json:"movieId" gorm:"not null;index"`
+	// Size is the file size when fingerprinted; a file whose size changed is fingerprinted again
+	Size int64 `json:"size"`
+	// Duration is the running time in seconds
+	Duration float64 `json:"duration"`
+	// FrameHashes are the perceptual hashes of frames taken at fixed fractions of the running
+	// time, as comma-separated hex
+	FrameHashes string    `json:"frameHashes" gorm:"type:text"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for the FileFingerprint model
+func (FileFingerprint) TableName() string {
+	return "file_fingerprints"
+}
+
+// DuplicateGroup is a movie, or one of its versions, with more than one file
+type DuplicateGroup struct {
+	MovieID    int             `json:"movieId"`
+	MovieTitle string          `json:"movieTitle"`
+	VersionID  int             `json:"versionId"`
+	Files      []DuplicateFile `json:"files"`
+	// Comparisons compare each pair of fingerprinted files in the group
+	Comparisons []DuplicateComparison `json:"comparisons"`
+}
+
+// DuplicateFile is one of the files of a duplicate group
+type DuplicateFile struct {
+	MovieFileID       int     `json:"movieFileId"`
+	Path              string  `json:"path"`
+	Size              int64   `json:"size"`
+	Quality           string  `json:"quality"`
+	CustomFormatScore int     `json:"customFormatScore"`
+	ReleaseGroup      string  `json:"releaseGroup,omitempty"`
+	Duration          float64 `json:"duration,omitempty"`
+	Fingerprinted     bool    `json:"fingerprinted"`
+}
+
+// DuplicateComparison compares the fingerprints of two files
+type DuplicateComparison struct {
+	MovieFileID      int `json:"movieFileId"`
+	OtherMovieFileID int `json:"otherMovieFileId"`
+	// DurationDelta is the difference in running time in seconds
+	DurationDelta float64 `json:"durationDelta"`
+	// FrameDistance is the mean number of differing bits between the frame hashes, from 0 for
+	// identical frames to 64
+	FrameDistance float64 `json:"frameDistance"`
+	// SameContent is set when the files are close enough to be copies of the same cut
+	SameContent bool `json:"sameContent"`
+}
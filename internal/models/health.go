@@ -44,6 +44,7 @@ const (
 	HealthCheckTypePerformance     HealthCheckType = "performance"     // Performance check
 	HealthCheckTypeConfiguration   HealthCheckType = "configuration"   // Configuration check
 	HealthCheckTypeExternalService HealthCheckType = "externalService" // External service check
+	HealthCheckTypeDataIntegrity   HealthCheckType = "dataIntegrity"   // Data integrity check
 )
 
 // HealthIssue represents a specific health issue detected in the system
@@ -114,10 +115,24 @@ type PerformanceMetrics struct {
 	DiskTotalGB       float64   `json:"diskTotalGB" gorm:"not null"`
 	DatabaseLatencyMs float64   `json:"databaseLatencyMs" gorm:"not null"`
 	APILatencyMs      float64   `json:"apiLatencyMs" gorm:"not null"`
+	APILatencyP50Ms   float64   `json:"apiLatencyP50Ms" gorm:"column:api_latency_p50_ms;not null;default:0"`
+	APILatencyP95Ms   float64   `json:"apiLatencyP95Ms" gorm:"column:api_latency_p95_ms;not null;default:0"`
+	APILatencyP99Ms   float64   `json:"apiLatencyP99Ms" gorm:"column:api_latency_p99_ms;not null;default:0"`
+	APIRequestCount   int       `json:"apiRequestCount" gorm:"column:api_request_count;not null;default:0"`
 	ActiveConnections int       `json:"activeConnections" gorm:"not null"`
+	DBOpenConnections int       `json:"dbOpenConnections" gorm:"column:db_open_connections;not null;default:0"`
+	DBWaitCount       int       `json:"dbWaitCount" gorm:"column:db_wait_count;not null;default:0"`
+	Goroutines        int       `json:"goroutines" gorm:"not null;default:0"`
+	GCCount           int       `json:"gcCount" gorm:"column:gc_count;not null;default:0"`
+	GCPauseMs         float64   `json:"gcPauseMs" gorm:"column:gc_pause_ms;not null;default:0"`
 	QueueSize         int       `json:"queueSize" gorm:"not null"`
+	SlowQueryCount    int       `json:"slowQueryCount" gorm:"not null;default:0"`
 	Timestamp         time.Time `json:"timestamp" gorm:"not null;index"`
 	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	// DownloadSpeed and UploadSpeed are the download clients' combined transfer speeds in bytes per
+	// second
+	DownloadSpeed int64 `json:"downloadSpeed" gorm:"column:download_speed;not null;default:0"`
+	UploadSpeed   int64 `json:"uploadSpeed" gorm:"column:upload_speed;not null;default:0"`
 }
 
 // TableName returns the table name for GORM
@@ -172,6 +187,7 @@ type HealthCheckConfig struct {
 	MetricsRetentionDays       int           `json:"metricsRetentionDays" yaml:"metricsRetentionDays"`
 	NotifyCriticalIssues       bool          `json:"notifyCriticalIssues" yaml:"notifyCriticalIssues"`
 	NotifyWarningIssues        bool          `json:"notifyWarningIssues" yaml:"notifyWarningIssues"`
+	MetricsInterval            time.Duration `json:"metricsInterval" yaml:"metricsInterval"`
 }
 
 // DefaultHealthCheckConfig returns default health check configuration
@@ -186,6 +202,7 @@ func DefaultHealthCheckConfig() HealthCheckConfig {
 		MetricsRetentionDays:       30,
 		NotifyCriticalIssues:       true,
 		NotifyWarningIssues:        false,
+		MetricsInterval:            5 * time.Minute,
 	}
 }
 
@@ -217,9 +234,31 @@ type HealthDashboard struct {
 	ServiceHealth    []ServiceHealthInfo  `json:"serviceHealth"`
 	DiskSpaceInfo    []DiskSpaceInfo      `json:"diskSpaceInfo"`
 	PerformanceTrend []PerformanceMetrics `json:"performanceTrend"`
+	CircuitBreakers  []CircuitBreakerInfo `json:"circuitBreakers"`
+	SlowQueries      []SlowQueryInfo      `json:"slowQueries"`
 	LastUpdated      time.Time            `json:"lastUpdated"`
 }
 
+// CircuitBreakerInfo represents the circuit breaker state for an external service host
+type CircuitBreakerInfo struct {
+	Host                string     `json:"host"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastFailure         *time.Time `json:"lastFailure,omitempty"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+}
+
+// SlowQueryInfo summarizes a database statement that exceeded the slow query threshold.
+// The SQL keeps its placeholders; parameter values are not recorded.
+type SlowQueryInfo struct {
+	SQL       string    `json:"sql"`
+	Count     int64     `json:"count"`
+	AverageMs float64   `json:"averageMs"`
+	MaxMs     float64   `json:"maxMs"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
 // IsHealthy returns true if the health status indicates a healthy system
 func (s HealthStatus) IsHealthy() bool {
 	return s == HealthStatusHealthy
@@ -41,9 +41,22 @@ const (
 	HistoryEventTypeMovieImported          HistoryEventType = "movieImported"
 	HistoryEventTypeMovieUnmonitored       HistoryEventType = "movieUnmonitored"
 	HistoryEventTypeMovieMonitored         HistoryEventType = "movieMonitored"
-	HistoryEventTypeIgnoredDownload        HistoryEventType = "ignoredDownload"
+	HistoryEventTypeIgnoredDownload        HistoryEventType = "downloadIgnored"
 )
 
+// MarshalJSON also writes the language as languages, the list Radarr reports
+func (h History) MarshalJSON() ([]byte, error) {
+	type history History
+	languages := LanguageArray{}
+	if h.Language != (Language{}) {
+		languages = append(languages, h.Language)
+	}
+	return json.Marshal(struct {
+		history
+		Languages LanguageArray `json:"languages"`
+	}{history(h), languages})
+}
+
 // HistoryEventData contains additional data specific to the event type
 type HistoryEventData struct {
 	Indexer            string                 `json:"indexer,omitempty"`
@@ -140,6 +153,9 @@ const (
 	ActivityTypeIndexerTest     ActivityType = "indexerTest"
 	ActivityTypeQueueProcess    ActivityType = "queueProcess"
 	ActivityTypeSystemUpdate    ActivityType = "systemUpdate"
+	ActivityTypeBulkEdit        ActivityType = "bulkEdit"
+	ActivityTypeFolderMove      ActivityType = "folderMove"
+	ActivityTypeLibraryImport   ActivityType = "libraryImport"
 )
 
 // ActivityStatus represents the current status of an activity
@@ -215,6 +231,7 @@ type HistoryResponse struct {
 	SortDir      string    `json:"sortDirection"`
 	TotalRecords int64     `json:"totalRecords"`
 	Records      []History `json:"records"`
+	NextCursor   string    `json:"nextCursor,omitempty"`
 }
 
 // ActivityRequest represents a request for activity data with filtering options
@@ -4,9 +4,18 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/radarr/radarr-go/internal/certificates"
 )
 
+// DefaultInstanceName names an instance until the user sets its own name
+const DefaultInstanceName = "Radarr"
+
+// maxInstanceNameLength is the longest instance name host_config stores
+const maxInstanceNameLength = 100
+
 // HostConfig represents the host/system configuration for Radarr
 type HostConfig struct {
 	ID                     int             `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -17,6 +26,7 @@ type HostConfig struct {
 	SSLPort                int             `json:"sslPort" gorm:"default:6969"`
 	SSLCertPath            string          `json:"sslCertPath" gorm:"default:''"`
 	SSLKeyPath             string          `json:"sslKeyPath" gorm:"default:''"`
+	SSLCertPassword        string          `json:"sslCertPassword" gorm:"default:''"`
 	Username               string          `json:"username" gorm:"default:''"`
 	Password               string          `json:"password" gorm:"default:''"`
 	AuthenticationMethod   AuthMethod      `json:"authenticationMethod" gorm:"default:'none'"`
@@ -34,6 +44,7 @@ type HostConfig struct {
 	BackupInterval         int             `json:"backupInterval" gorm:"default:7"`
 	BackupRetention        int             `json:"backupRetention" gorm:"default:28"`
 	CertificateValidation  CertValidation  `json:"certificateValidation" gorm:"default:'enabled'"`
+	InstanceName           string          `json:"instanceName" gorm:"default:'Radarr';size:100"`
 	CreatedAt              time.Time       `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt              time.Time       `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -177,9 +188,18 @@ func GetDefaultHostConfig() *HostConfig {
 		BackupInterval:         7,
 		BackupRetention:        28,
 		CertificateValidation:  CertValidationEnabled,
+		InstanceName:           DefaultInstanceName,
 	}
 }
 
+// Instance returns the instance name, or DefaultInstanceName when it is not set
+func (hc *HostConfig) Instance() string {
+	if name := strings.TrimSpace(hc.InstanceName); name != "" {
+		return name
+	}
+	return DefaultInstanceName
+}
+
 // IsAuthenticationEnabled returns true if authentication is enabled
 func (hc *HostConfig) IsAuthenticationEnabled() bool {
 	return hc.AuthenticationMethod != AuthMethodNone
@@ -195,6 +215,31 @@ func (hc *HostConfig) HasProxyConfigured() bool {
 	return hc.ProxySettings.Hostname != ""
 }
 
+// RestartRequiredChanges returns the names of settings that differ from previous and only take
+// effect once the process restarts: the listen address, URL base, SSL, and authentication
+func (hc *HostConfig) RestartRequiredChanges(previous *HostConfig) []string {
+	var changes []string
+	check := func(name string, changed bool) {
+		if changed {
+			changes = append(changes, name)
+		}
+	}
+
+	check("bindAddress", hc.BindAddress != previous.BindAddress)
+	check("port", hc.Port != previous.Port)
+	check("urlBase", hc.URLBase != previous.URLBase)
+	check("enableSsl", hc.EnableSSL != previous.EnableSSL)
+	check("sslPort", hc.SSLPort != previous.SSLPort)
+	check("sslCertPath", hc.SSLCertPath != previous.SSLCertPath)
+	check("sslKeyPath", hc.SSLKeyPath != previous.SSLKeyPath)
+	check("sslCertPassword", hc.SSLCertPassword != previous.SSLCertPassword)
+	check("authenticationMethod", hc.AuthenticationMethod != previous.AuthenticationMethod)
+	check("username", hc.Username != previous.Username)
+	check("password", hc.Password != previous.Password)
+
+	return changes
+}
+
 // GetEffectivePort returns the port that should be used (SSL or regular)
 func (hc *HostConfig) GetEffectivePort() int {
 	if hc.EnableSSL {
@@ -211,6 +256,10 @@ func (hc *HostConfig) ValidateConfiguration() []string {
 		errors = append(errors, "Port must be between 1 and 65535")
 	}
 
+	if len(hc.InstanceName) > maxInstanceNameLength {
+		errors = append(errors, fmt.Sprintf("Instance name cannot be longer than %d characters", maxInstanceNameLength))
+	}
+
 	if hc.EnableSSL {
 		if hc.SSLPort < 1 || hc.SSLPort > 65535 {
 			errors = append(errors, "SSL Port must be between 1 and 65535")
@@ -218,9 +267,14 @@ func (hc *HostConfig) ValidateConfiguration() []string {
 		if hc.SSLCertPath == "" {
 			errors = append(errors, "SSL Certificate path is required when SSL is enabled")
 		}
-		if hc.SSLKeyPath == "" {
+		if hc.SSLKeyPath == "" && !certificates.IsPKCS12(hc.SSLCertPath) {
 			errors = append(errors, "SSL Key path is required when SSL is enabled")
 		}
+		if hc.SSLCertPath != "" && (hc.SSLKeyPath != "" || certificates.IsPKCS12(hc.SSLCertPath)) {
+			if err := certificates.Validate(hc.SSLCertPath, hc.SSLKeyPath, hc.SSLCertPassword); err != nil {
+				errors = append(errors, fmt.Sprintf("SSL Certificate is not usable: %v", err))
+			}
+		}
 	}
 
 	if hc.AuthenticationMethod == AuthMethodBasic || hc.AuthenticationMethod == AuthMethodForms {
@@ -232,6 +286,15 @@ func (hc *HostConfig) ValidateConfiguration() []string {
 		}
 	}
 
+	if hc.HasProxyConfigured() {
+		if hc.ProxySettings.Port < 1 || hc.ProxySettings.Port > 65535 {
+			errors = append(errors, "Proxy port must be between 1 and 65535")
+		}
+		if hc.ProxySettings.Type == ProxyTypeSocks4 {
+			errors = append(errors, "SOCKS4 proxies are not supported, use HTTP or SOCKS5")
+		}
+	}
+
 	if hc.UpdateMechanism == UpdateMechanismScript && hc.UpdateScriptPath == "" {
 		errors = append(errors, "Update script path is required when using script update mechanism")
 	}
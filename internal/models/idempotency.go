@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// IdempotencyKey is a client-supplied Idempotency-Key together with the response its first request
+// received, so a retry of the same request is answered from the snapshot instead of repeating it
+type IdempotencyKey struct {
+	ID  int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Key string `json:"key" gorm:"column:idempotency_key;not null;size:255;uniqueIndex"`
+	// Method, Path and RequestHash identify the request the key was first used for
+	Method      string `json:"method" gorm:"not null;size:10"`
+	Path        string `json:"path" gorm:"not null;size:255"`
+	RequestHash string `json:"requestHash" gorm:"not null;size:64"`
+	// Completed is false while the first request is still being handled
+	Completed  bool      `json:"completed" gorm:"not null;default:false"`
+	StatusCode int       `json:"statusCode" gorm:"not null;default:0"`
+	Response   string    `json:"-" gorm:"type:text"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	ExpiresAt  time.Time `json:"expiresAt" gorm:"not null;index"`
+}
+
+// TableName returns the database table name for the IdempotencyKey model
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// Matches reports whether a request with the given method, path and body hash is the one the key
+// was first used for
+func (k *IdempotencyKey) Matches(method, path, requestHash string) bool {
+	return k.Method == method && k.Path == path && k.RequestHash == requestHash
+}
@@ -25,9 +25,52 @@ type ImportList struct {
 	MinimumAvailability Availability          `json:"minimumAvailability" gorm:"size:20;default:'released'"`
 	Tags                IntArray              `json:"tags" gorm:"type:text"`
 	Fields              ImportListFieldsArray `json:"fields" gorm:"type:text"`
+	RequiresApproval    bool                  `json:"requiresApproval" gorm:"default:false"`
 	LastSync            *time.Time            `json:"lastSync,omitempty"`
 	CreatedAt           time.Time             `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt           time.Time             `json:"updatedAt" gorm:"autoUpdateTime"`
+	// LastFetch records the last fetch of a remote list; syncs send its validators back so an
+	// unchanged list isn't processed again
+	LastFetch ImportListFetch `json:"lastFetch" gorm:"type:text"`
+	// LastTest is the outcome of the list's last connection test
+	LastTest ProviderTestStatus `json:"lastTest" gorm:"type:text"`
+}
+
+// ImportListFetch is the last fetch of a remote import list and the HTTP caching validators the
+// server returned with it
+type ImportListFetch struct {
+	FetchedAt    *time.Time `json:"fetchedAt,omitempty"`
+	ETag         string     `json:"etag,omitempty"`
+	LastModified string     `json:"lastModified,omitempty"`
+	// Unchanged is set when the server answered 304 Not Modified and the sync skipped the list
+	Unchanged bool `json:"unchanged"`
+	Movies    int  `json:"movies"`
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (f ImportListFetch) Value() (driver.Value, error) {
+	if f.FetchedAt == nil {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (f *ImportListFetch) Scan(value interface{}) error {
+	*f = ImportListFetch{}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, f)
 }
 
 // ImportListType represents different types of import list implementations
@@ -85,6 +128,27 @@ type ImportListSettings struct {
 	CountryCode        string            `json:"countryCode,omitempty"`
 	URL                string            `json:"url,omitempty"`
 	AdditionalSettings map[string]string `json:"additionalSettings,omitempty"`
+
+	// IncludeWatchProviderIDs keeps only movies streaming on one of the providers and
+	// ExcludeWatchProviderIDs drops movies streaming on any of them, in WatchRegion or the
+	// configured watch region
+	IncludeWatchProviderIDs IntArray `json:"includeWatchProviderIds,omitempty"`
+	ExcludeWatchProviderIDs IntArray `json:"excludeWatchProviderIds,omitempty"`
+	WatchRegion             string   `json:"watchRegion,omitempty"`
+}
+
+// FiltersWatchProviders reports whether the list keeps or drops movies by watch provider
+func (s *ImportListSettings) FiltersWatchProviders() bool {
+	return len(s.IncludeWatchProviderIDs) > 0 || len(s.ExcludeWatchProviderIDs) > 0
+}
+
+// AllowsWatchProviders reports whether a movie with the given watch providers passes the list's
+// provider filters
+func (s *ImportListSettings) AllowsWatchProviders(providers *MovieWatchProviders) bool {
+	if len(s.IncludeWatchProviderIDs) > 0 && !providers.StreamsOn(s.IncludeWatchProviderIDs) {
+		return false
+	}
+	return !providers.StreamsOn(s.ExcludeWatchProviderIDs)
 }
 
 // Value implements the driver.Valuer interface for database storage
@@ -153,35 +217,57 @@ func (f *ImportListFieldsArray) Scan(value interface{}) error {
 
 // ImportListMovie represents a movie discovered from an import list
 type ImportListMovie struct {
-	ID                  int          `json:"id" gorm:"primaryKey;autoIncrement"`
-	ImportListID        int          `json:"importListId" gorm:"not null;index"`
-	ImportList          *ImportList  `json:"importList,omitempty" gorm:"foreignKey:ImportListID"`
-	TmdbID              int          `json:"tmdbId" gorm:"not null;index"`
-	ImdbID              string       `json:"imdbId" gorm:"size:20;index"`
-	Title               string       `json:"title" gorm:"not null;size:500"`
-	OriginalTitle       string       `json:"originalTitle" gorm:"size:500"`
-	Year                int          `json:"year" gorm:"index"`
-	Overview            string       `json:"overview" gorm:"type:text"`
-	Runtime             int          `json:"runtime"`
-	Images              MediaCover   `json:"images" gorm:"type:text"`
-	Genres              StringArray  `json:"genres" gorm:"type:text"`
-	Ratings             Ratings      `json:"ratings" gorm:"type:text"`
-	Certification       string       `json:"certification" gorm:"size:20"`
-	Status              MovieStatus  `json:"status" gorm:"size:20"`
-	InCinemas           *time.Time   `json:"inCinemas,omitempty"`
-	PhysicalRelease     *time.Time   `json:"physicalRelease,omitempty"`
-	DigitalRelease      *time.Time   `json:"digitalRelease,omitempty"`
-	Website             string       `json:"website" gorm:"size:500"`
-	YouTubeTrailerID    string       `json:"youTubeTrailerId" gorm:"size:50"`
-	Studio              string       `json:"studio" gorm:"size:255"`
-	MinimumAvailability Availability `json:"minimumAvailability" gorm:"size:20"`
-	IsExcluded          bool         `json:"isExcluded" gorm:"default:false;index"`
-	IsExisting          bool         `json:"isExisting" gorm:"default:false;index"`
-	IsRecommendation    bool         `json:"isRecommendation" gorm:"default:false;index"`
-	ListPosition        int          `json:"listPosition" gorm:"default:0"`
-	DiscoveredAt        time.Time    `json:"discoveredAt" gorm:"autoCreateTime"`
-	CreatedAt           time.Time    `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt           time.Time    `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID                  int                      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ImportListID        int                      `json:"importListId" gorm:"not null;index"`
+	ImportList          *ImportList              `json:"importList,omitempty" gorm:"foreignKey:ImportListID"`
+	TmdbID              int                      `json:"tmdbId" gorm:"not null;index"`
+	ImdbID              string                   `json:"imdbId" gorm:"size:20;index"`
+	Title               string                   `json:"title" gorm:"not null;size:500"`
+	OriginalTitle       string                   `json:"originalTitle" gorm:"size:500"`
+	Year                int                      `json:"year" gorm:"index"`
+	Overview            string                   `json:"overview" gorm:"type:text"`
+	Runtime             int                      `json:"runtime"`
+	Images              MediaCover               `json:"images" gorm:"type:text"`
+	Genres              StringArray              `json:"genres" gorm:"type:text"`
+	Ratings             Ratings                  `json:"ratings" gorm:"type:text"`
+	Certification       string                   `json:"certification" gorm:"size:20"`
+	Status              MovieStatus              `json:"status" gorm:"size:20"`
+	InCinemas           *time.Time               `json:"inCinemas,omitempty"`
+	PhysicalRelease     *time.Time               `json:"physicalRelease,omitempty"`
+	DigitalRelease      *time.Time               `json:"digitalRelease,omitempty"`
+	Website             string                   `json:"website" gorm:"size:500"`
+	YouTubeTrailerID    string                   `json:"youTubeTrailerId" gorm:"size:50"`
+	Studio              string                   `json:"studio" gorm:"size:255"`
+	MinimumAvailability Availability             `json:"minimumAvailability" gorm:"size:20"`
+	IsExcluded          bool                     `json:"isExcluded" gorm:"default:false;index"`
+	IsExisting          bool                     `json:"isExisting" gorm:"default:false;index"`
+	IsRecommendation    bool                     `json:"isRecommendation" gorm:"default:false;index"`
+	ListPosition        int                      `json:"listPosition" gorm:"default:0"`
+	ApprovalStatus      ImportListApprovalStatus `json:"approvalStatus" gorm:"size:20;default:'pending';index"`
+	ReviewedAt          *time.Time               `json:"reviewedAt,omitempty"`
+	DiscoveredAt        time.Time                `json:"discoveredAt" gorm:"autoCreateTime"`
+	CreatedAt           time.Time                `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time                `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// ImportListApprovalStatus represents the manual review state of a discovered movie
+type ImportListApprovalStatus string
+
+// Approval states for movies discovered by import lists that require manual approval
+const (
+	ImportListApprovalPending  ImportListApprovalStatus = "pending"
+	ImportListApprovalApproved ImportListApprovalStatus = "approved"
+	ImportListApprovalRejected ImportListApprovalStatus = "rejected"
+)
+
+// IsValid returns true if the approval status is a known value
+func (s ImportListApprovalStatus) IsValid() bool {
+	switch s {
+	case ImportListApprovalPending, ImportListApprovalApproved, ImportListApprovalRejected:
+		return true
+	default:
+		return false
+	}
 }
 
 // ImportListExclusion represents a movie that should be excluded from import lists
@@ -196,6 +282,23 @@ type ImportListExclusion struct {
 	UpdatedAt  time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
 
+// MovieTombstone remembers a movie deleted from the library without an exclusion, keeping import
+// list syncs from adding it back until it expires
+type MovieTombstone struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	TmdbID     int       `json:"tmdbId" gorm:"not null;uniqueIndex"`
+	MovieTitle string    `json:"movieTitle" gorm:"not null;size:500"`
+	MovieYear  int       `json:"movieYear"`
+	ImdbID     string    `json:"imdbId,omitempty" gorm:"size:20"`
+	DeletedAt  time.Time `json:"deletedAt" gorm:"not null"`
+	ExpiresAt  time.Time `json:"expiresAt" gorm:"not null;index"`
+}
+
+// TableName returns the database table name for the MovieTombstone model
+func (MovieTombstone) TableName() string {
+	return "movie_tombstones"
+}
+
 // ImportListSyncResult represents the result of syncing an import list
 type ImportListSyncResult struct {
 	ImportListID   int               `json:"importListId"`
@@ -205,10 +308,58 @@ type ImportListSyncResult struct {
 	MoviesUpdated  int               `json:"moviesUpdated"`
 	MoviesExcluded int               `json:"moviesExcluded"`
 	MoviesExisting int               `json:"moviesExisting"`
+	MoviesPending  int               `json:"moviesPending"`
+	MoviesCleaned  int               `json:"moviesCleaned"`
+	MoviesFiltered int               `json:"moviesFiltered"`
 	Movies         []ImportListMovie `json:"movies"`
 	SyncTime       time.Time         `json:"syncTime"`
 	Errors         []string          `json:"errors,omitempty"`
 	Success        bool              `json:"success"`
+	// Unchanged is set when the list hadn't changed since the last sync, which skipped it
+	Unchanged bool `json:"unchanged,omitempty"`
+}
+
+// ImportListSyncAction is what a sync does with a movie from an import list
+type ImportListSyncAction string
+
+// Sync actions reported by an import list preview
+const (
+	// ImportListSyncAdd adds the movie to the library
+	ImportListSyncAdd ImportListSyncAction = "add"
+	// ImportListSyncReview stores the movie as a discovered movie for review
+	ImportListSyncReview ImportListSyncAction = "review"
+	// ImportListSyncSkipExisting skips a movie already in the library
+	ImportListSyncSkipExisting ImportListSyncAction = "existing"
+	// ImportListSyncSkipExcluded skips a movie on the exclusion list
+	ImportListSyncSkipExcluded ImportListSyncAction = "excluded"
+	// ImportListSyncSkipDiscovered skips a movie an earlier sync of the list already discovered
+	ImportListSyncSkipDiscovered ImportListSyncAction = "discovered"
+	// ImportListSyncSkipTombstoned skips a movie deleted from the library while its tombstone lasts
+	ImportListSyncSkipTombstoned ImportListSyncAction = "tombstoned"
+	// ImportListSyncSkipWatchProvider skips a movie the list's watch provider filters leave out
+	ImportListSyncSkipWatchProvider ImportListSyncAction = "watchProvider"
+	// ImportListSyncClean removes a pending discovered movie that is no longer on the list
+	ImportListSyncClean ImportListSyncAction = "clean"
+)
+
+// ImportListPreviewItem is a movie and the action a sync would take for it
+type ImportListPreviewItem struct {
+	TmdbID int                  `json:"tmdbId"`
+	Title  string               `json:"title"`
+	Year   int                  `json:"year"`
+	Action ImportListSyncAction `json:"action"`
+}
+
+// ImportListPreview reports what syncing an import list would change, without changing anything
+type ImportListPreview struct {
+	ImportListID   int                     `json:"importListId"`
+	ImportListName string                  `json:"importListName"`
+	MoviesTotal    int                     `json:"moviesTotal"`
+	Added          []ImportListPreviewItem `json:"added"`
+	Review         []ImportListPreviewItem `json:"review"`
+	Skipped        []ImportListPreviewItem `json:"skipped"`
+	Cleaned        []ImportListPreviewItem `json:"cleaned"`
+	PreviewTime    time.Time               `json:"previewTime"`
 }
 
 // ImportListTestResult represents the result of testing an import list configuration
@@ -216,6 +367,7 @@ type ImportListTestResult struct {
 	IsValid bool              `json:"isValid"`
 	Errors  []string          `json:"errors"`
 	Movies  []ImportListMovie `json:"movies,omitempty"`
+	ProviderTestReport
 }
 
 // IsEnabled returns whether the import list is enabled
@@ -267,7 +419,7 @@ func (il *ImportList) GetBaseURL() string {
 
 // ShouldAutoAdd determines if movies from this list should be automatically added
 func (il *ImportList) ShouldAutoAdd() bool {
-	return il.EnableAuto && il.Enabled
+	return il.EnableAuto && il.Enabled && !il.RequiresApproval
 }
 
 // GetMinRefreshIntervalMinutes returns the minimum refresh interval in minutes
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
@@ -62,6 +63,55 @@ func (is *IndexerSettings) Scan(value interface{}) error {
 	}
 }
 
+// SettingSkipTLSVerify is the provider settings key that disables certificate verification
+// for self-hosted providers using self-signed certificates
+const SettingSkipTLSVerify = "skipTlsVerify"
+
+// SettingRetention is the provider settings key of the number of days a usenet indexer's news
+// server keeps posts
+const SettingRetention = "retention"
+
+// SettingMultiLanguages is the provider settings key of the ids of the languages an indexer's
+// MULTi releases have, as Radarr's indexer setting of the same name
+const SettingMultiLanguages = "multiLanguages"
+
+// settingBool reads a boolean flag from a provider settings map, accepting JSON booleans
+// and the string forms submitted by some clients
+func settingBool(settings map[string]interface{}, key string) bool {
+	switch v := settings[key].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	default:
+		return false
+	}
+}
+
+// settingFloat reads a number from a provider settings map, accepting JSON numbers and the
+// string forms submitted by some clients
+func settingFloat(settings map[string]interface{}, key string) float64 {
+	return settingNumber(settings[key])
+}
+
+// settingNumber reads a number from a provider setting value, as settingFloat does
+func settingNumber(setting interface{}) float64 {
+	switch v := setting.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		value, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0
+		}
+		return value
+	default:
+		return 0
+	}
+}
+
 // Indexer represents a movie indexer/search provider
 type Indexer struct {
 	ID                      int             `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -86,6 +136,10 @@ type Indexer struct {
 	EnableInteractiveSearch bool            `json:"enableInteractiveSearch" gorm:"default:true"`
 	SupportsRedirect        bool            `json:"supportsRedirect" gorm:"default:false"`
 	Tags                    IntArray        `json:"tags" gorm:"type:text"`
+	// VipExpiration is when the indexer's VIP status or API key expires, if it does
+	VipExpiration *time.Time `json:"vipExpiration,omitempty"`
+	// LastTest is the outcome of the indexer's last connection test
+	LastTest ProviderTestStatus `json:"lastTest" gorm:"type:text"`
 }
 
 // TableName returns the database table name for the Indexer model
@@ -124,10 +178,35 @@ func (i *Indexer) CanRSS() bool {
 	return i.SupportsRSS && i.IsEnabled() && i.EnableRSS
 }
 
+// SkipTLSVerify returns true if certificate verification is disabled for this indexer
+func (i *Indexer) SkipTLSVerify() bool {
+	return settingBool(i.Settings, SettingSkipTLSVerify)
+}
+
+// Retention returns the number of days the indexer's news server keeps usenet posts, 0 when
+// unlimited or unknown
+func (i *Indexer) Retention() int {
+	return int(settingFloat(i.Settings, SettingRetention))
+}
+
+// MultiLanguages returns the languages the indexer's releases tagged MULTi have, skipping unknown
+// ids. Ids may be JSON numbers or the string forms submitted by some clients.
+func (i *Indexer) MultiLanguages() []Language {
+	ids, _ := i.Settings[SettingMultiLanguages].([]interface{})
+	languages := make([]Language, 0, len(ids))
+	for _, id := range ids {
+		if language, ok := LanguageByID(int(settingNumber(id))); ok {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
 // IndexerTestResult represents the result of testing an indexer connection
 type IndexerTestResult struct {
 	IsValid bool     `json:"isValid"`
 	Errors  []string `json:"validationFailures"`
+	ProviderTestReport
 }
 
 // IndexerCapabilities represents what an indexer supports
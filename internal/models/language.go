@@ -0,0 +1,113 @@
+package models
+
+import "strings"
+
+// languageNames are Radarr's languages indexed by language id, which TRaSH-Guides custom formats
+// and the official frontend reference
+var languageNames = []string{
+	"Unknown", "English", "French", "Spanish", "German", "Italian", "Danish", "Dutch", "Japanese",
+	"Icelandic", "Chinese", "Russian", "Polish", "Vietnamese", "Swedish", "Norwegian", "Finnish",
+	"Turkish", "Portuguese", "Flemish", "Greek", "Korean", "Hungarian", "Hebrew", "Lithuanian", "Czech",
+	"Hindi", "Romanian", "Thai", "Bulgarian", "Portuguese (Brazil)", "Arabic", "Ukrainian", "Persian",
+	"Bengali", "Slovak", "Latvian", "Spanish (Latino)", "Catalan", "Croatian", "Serbian", "Bosnian",
+	"Estonian", "Tamil", "Indonesian", "Telugu", "Macedonian", "Slovenian",
+}
+
+// languageISOCodes maps the ISO 639-1 and 639-2 codes that tag audio and subtitle streams to the
+// Radarr language name; ISO 639-2 has separate bibliographic and terminology codes for some
+var languageISOCodes = map[string]string{
+	"en": "English", "eng": "English",
+	"fr": "French", "fre": "French", "fra": "French",
+	"es": "Spanish", "spa": "Spanish",
+	"de": "German", "ger": "German", "deu": "German",
+	"it": "Italian", "ita": "Italian",
+	"da": "Danish", "dan": "Danish",
+	"nl": "Dutch", "dut": "Dutch", "nld": "Dutch",
+	"ja": "Japanese", "jpn": "Japanese",
+	"is": "Icelandic", "ice": "Icelandic", "isl": "Icelandic",
+	"zh": "Chinese", "chi": "Chinese", "zho": "Chinese",
+	"ru": "Russian", "rus": "Russian",
+	"pl": "Polish", "pol": "Polish",
+	"vi": "Vietnamese", "vie": "Vietnamese",
+	"sv": "Swedish", "swe": "Swedish",
+	"no": "Norwegian", "nor": "Norwegian", "nb": "Norwegian", "nob": "Norwegian", "nn": "Norwegian", "nno": "Norwegian",
+	"fi": "Finnish", "fin": "Finnish",
+	"tr": "Turkish", "tur": "Turkish",
+	"pt": "Portuguese", "por": "Portuguese",
+	"el": "Greek", "gre": "Greek", "ell": "Greek",
+	"ko": "Korean", "kor": "Korean",
+	"hu": "Hungarian", "hun": "Hungarian",
+	"he": "Hebrew", "heb": "Hebrew",
+	"lt": "Lithuanian", "lit": "Lithuanian",
+	"cs": "Czech", "cze": "Czech", "ces": "Czech",
+	"hi": "Hindi", "hin": "Hindi",
+	"ro": "Romanian", "rum": "Romanian", "ron": "Romanian",
+	"th": "Thai", "tha": "Thai",
+	"bg": "Bulgarian", "bul": "Bulgarian",
+	"ar": "Arabic", "ara": "Arabic",
+	"uk": "Ukrainian", "ukr": "Ukrainian",
+	"fa": "Persian", "per": "Persian", "fas": "Persian",
+	"bn": "Bengali", "ben": "Bengali",
+	"sk": "Slovak", "slo": "Slovak", "slk": "Slovak",
+	"lv": "Latvian", "lav": "Latvian",
+	"ca": "Catalan", "cat": "Catalan",
+	"hr": "Croatian", "hrv": "Croatian",
+	"sr": "Serbian", "srp": "Serbian",
+	"bs": "Bosnian", "bos": "Bosnian",
+	"et": "Estonian", "est": "Estonian",
+	"ta": "Tamil", "tam": "Tamil",
+	"id": "Indonesian", "ind": "Indonesian",
+	"te": "Telugu", "tel": "Telugu",
+	"mk": "Macedonian", "mac": "Macedonian", "mkd": "Macedonian",
+	"sl": "Slovenian", "slv": "Slovenian",
+}
+
+// Radarr's pseudo-languages a quality profile can want: any language, or the movie's original one
+var (
+	LanguageAny      = Language{ID: -1, Name: "Any"}
+	LanguageOriginal = Language{ID: -2, Name: "Original"}
+)
+
+// LanguageByName returns the Radarr language with the given name, ignoring case, or false when
+// the name is not a known language
+func LanguageByName(name string) (Language, bool) {
+	for id, languageName := range languageNames {
+		if id > 0 && strings.EqualFold(languageName, name) {
+			return Language{ID: id, Name: languageName}, true
+		}
+	}
+	return Language{}, false
+}
+
+// LanguageByID returns the Radarr language with the given id, including Original, or false when
+// the id is not a known language
+func LanguageByID(id int) (Language, bool) {
+	switch {
+	case id == LanguageOriginal.ID:
+		return LanguageOriginal, true
+	case id > 0 && id < len(languageNames):
+		return Language{ID: id, Name: languageNames[id]}, true
+	}
+	return Language{}, false
+}
+
+// LanguageByISOCode returns the Radarr language of an ISO 639-1 or 639-2 code as found in stream
+// tags, such as "eng" or "de", or false for unknown and undetermined ("und") codes
+func LanguageByISOCode(code string) (Language, bool) {
+	name, ok := languageISOCodes[strings.ToLower(strings.TrimSpace(code))]
+	if !ok {
+		return Language{}, false
+	}
+	return LanguageByName(name)
+}
+
+// ISOCode returns the two-letter ISO 639-1 code of the language, such as "en", or "" when it
+// has none
+func (l Language) ISOCode() string {
+	for code, name := range languageISOCodes {
+		if len(code) == 2 && name == l.Name && code != "nb" && code != "nn" {
+			return code
+		}
+	}
+	return ""
+}
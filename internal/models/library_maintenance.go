@@ -0,0 +1,146 @@
+package models
+
+import "time"
+
+// LibraryIssueCategory identifies a class of inconsistency between the database and disk
+type LibraryIssueCategory string
+
+const (
+	// LibraryIssueUnmonitoredWithFiles indicates unmonitored movies that have files on disk
+	LibraryIssueUnmonitoredWithFiles LibraryIssueCategory = "unmonitoredWithFiles"
+	// LibraryIssueMonitoredMissing indicates monitored, available movies without a file
+	LibraryIssueMonitoredMissing LibraryIssueCategory = "monitoredMissing"
+	// LibraryIssueOrphanedFiles indicates video files in movie folders that are not tracked in the database
+	LibraryIssueOrphanedFiles LibraryIssueCategory = "orphanedFiles"
+	// LibraryIssueMissingFiles indicates movie file records whose file no longer exists on disk
+	LibraryIssueMissingFiles LibraryIssueCategory = "missingFiles"
+	// LibraryIssueMoviesWithoutFolders indicates movies whose folder does not exist
+	LibraryIssueMoviesWithoutFolders LibraryIssueCategory = "moviesWithoutFolders"
+	// LibraryIssueFoldersWithoutMovies indicates root folder subdirectories not mapped to any movie
+	LibraryIssueFoldersWithoutMovies LibraryIssueCategory = "foldersWithoutMovies"
+)
+
+// LibraryIssueCategories lists every category in report order
+var LibraryIssueCategories = []LibraryIssueCategory{
+	LibraryIssueUnmonitoredWithFiles,
+	LibraryIssueMonitoredMissing,
+	LibraryIssueOrphanedFiles,
+	LibraryIssueMissingFiles,
+	LibraryIssueMoviesWithoutFolders,
+	LibraryIssueFoldersWithoutMovies,
+}
+
+// LibraryFixAction describes the one-click fix applied to a category
+type LibraryFixAction string
+
+const (
+	// LibraryFixNone marks categories that are only reported, as they may reflect a deliberate choice
+	LibraryFixNone LibraryFixAction = "none"
+	// LibraryFixSearch queues the affected movies for a wanted search
+	LibraryFixSearch LibraryFixAction = "search"
+	// LibraryFixLinkFile attaches the orphaned file to the movie owning the folder
+	LibraryFixLinkFile LibraryFixAction = "linkFile"
+	// LibraryFixRemoveRecord removes the stale movie file record
+	LibraryFixRemoveRecord LibraryFixAction = "removeRecord"
+	// LibraryFixCreateFolder creates the missing movie folder
+	LibraryFixCreateFolder LibraryFixAction = "createFolder"
+	// LibraryFixRemoveEmptyFolder deletes unmapped folders that contain no files
+	LibraryFixRemoveEmptyFolder LibraryFixAction = "removeEmptyFolder"
+)
+
+// FixAction returns the fix applied to issues in the category
+func (c LibraryIssueCategory) FixAction() LibraryFixAction {
+	switch c {
+	case LibraryIssueUnmonitoredWithFiles:
+		return LibraryFixNone
+	case LibraryIssueMonitoredMissing:
+		return LibraryFixSearch
+	case LibraryIssueOrphanedFiles:
+		return LibraryFixLinkFile
+	case LibraryIssueMissingFiles:
+		return LibraryFixRemoveRecord
+	case LibraryIssueMoviesWithoutFolders:
+		return LibraryFixCreateFolder
+	case LibraryIssueFoldersWithoutMovies:
+		return LibraryFixRemoveEmptyFolder
+	default:
+		return ""
+	}
+}
+
+// Description returns a human-readable explanation of the category
+func (c LibraryIssueCategory) Description() string {
+	switch c {
+	case LibraryIssueUnmonitoredWithFiles:
+		return "Unmonitored movies that have a file on disk"
+	case LibraryIssueMonitoredMissing:
+		return "Monitored, available movies that have no file"
+	case LibraryIssueOrphanedFiles:
+		return "Video files in movie folders that are not in the database"
+	case LibraryIssueMissingFiles:
+		return "Movie files in the database that no longer exist on disk"
+	case LibraryIssueMoviesWithoutFolders:
+		return "Movies whose folder does not exist"
+	case LibraryIssueFoldersWithoutMovies:
+		return "Folders in root folders that do not belong to any movie"
+	default:
+		return ""
+	}
+}
+
+// IsValid reports whether the category is known
+func (c LibraryIssueCategory) IsValid() bool {
+	return c.FixAction() != ""
+}
+
+// IsFixable reports whether the category has a fix action that can be applied
+func (c LibraryIssueCategory) IsFixable() bool {
+	return c.IsValid() && c.FixAction() != LibraryFixNone
+}
+
+// LibraryIssue represents a single inconsistency found by the maintenance scan
+type LibraryIssue struct {
+	MovieID     int    `json:"movieId,omitempty"`
+	MovieTitle  string `json:"movieTitle,omitempty"`
+	MovieFileID int    `json:"movieFileId,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+// LibraryMaintenanceCategory groups the issues found for one category
+type LibraryMaintenanceCategory struct {
+	Category    LibraryIssueCategory `json:"category"`
+	Description string               `json:"description"`
+	FixAction   LibraryFixAction     `json:"fixAction"`
+	Count       int                  `json:"count"`
+	Issues      []LibraryIssue       `json:"issues"`
+}
+
+// LibraryMaintenanceReport is the result of a library maintenance scan
+type LibraryMaintenanceReport struct {
+	GeneratedAt time.Time                    `json:"generatedAt"`
+	Duration    time.Duration                `json:"duration"`
+	RootFolders []string                     `json:"rootFolders"`
+	TotalIssues int                          `json:"totalIssues"`
+	Categories  []LibraryMaintenanceCategory `json:"categories"`
+	Errors      []string                     `json:"errors,omitempty"`
+}
+
+// Category returns the report section for the given category, or nil if absent
+func (r *LibraryMaintenanceReport) Category(category LibraryIssueCategory) *LibraryMaintenanceCategory {
+	for i := range r.Categories {
+		if r.Categories[i].Category == category {
+			return &r.Categories[i]
+		}
+	}
+	return nil
+}
+
+// LibraryMaintenanceFixResult summarizes a fix action applied to a category
+type LibraryMaintenanceFixResult struct {
+	Category LibraryIssueCategory `json:"category"`
+	Action   LibraryFixAction     `json:"action"`
+	Fixed    int                  `json:"fixed"`
+	Failed   int                  `json:"failed"`
+	Errors   []string             `json:"errors,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// LibrarySnapshot records the size and state of the library on one day, so growth and upgrade
+// progress can be charted over time
+type LibrarySnapshot struct {
+	ID int `json:"id" gorm:"primaryKey;autoIncrement"`
+	// Date is the UTC day the snapshot describes; recording again the same day replaces it
+	Date           time.Time `json:"date" gorm:"type:date;not null;uniqueIndex"`
+	MovieCount     int       `json:"movieCount"`
+	MonitoredCount int       `json:"monitoredCount"`
+	// MissingCount is monitored, available movies without a file
+	MissingCount int `json:"missingCount"`
+	// CutoffUnmetCount is wanted movies whose file is below the quality profile cutoff
+	CutoffUnmetCount int   `json:"cutoffUnmetCount"`
+	FileCount        int   `json:"fileCount"`
+	SizeOnDisk       int64 `json:"sizeOnDisk"`
+	// QualityDistribution counts movie files by quality name
+	QualityDistribution JSON      `json:"qualityDistribution" gorm:"type:text"`
+	CreatedAt           time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for LibrarySnapshot
+func (LibrarySnapshot) TableName() string {
+	return "library_snapshots"
+}
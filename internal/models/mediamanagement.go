@@ -29,8 +29,14 @@ type MediaManagementConfig struct {
 	EnableMediaInfo            bool                   `json:"enableMediaInfo" gorm:"default:true"`
 	ImportMechanism            ImportMechanism        `json:"importMechanism" gorm:"default:'move'"`
 	WatchLibraryForChanges     bool                   `json:"watchLibraryForChanges" gorm:"default:true"`
-	CreatedAt                  time.Time              `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt                  time.Time              `json:"updatedAt" gorm:"autoUpdateTime"`
+	// AllowEditionChangeOnUpgrade lets upgrades replace a file with a different edition, such as a
+	// Director's Cut with the theatrical cut
+	AllowEditionChangeOnUpgrade bool `json:"allowEditionChangeOnUpgrade" gorm:"default:false"`
+	// KeepOriginalFiles leaves imported files in the download folder so they keep seeding under
+	// their release names, hardlinking them into the library when CopyUsingHardlinks is set
+	KeepOriginalFiles bool      `json:"keepOriginalFiles" gorm:"default:false"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
 
 // TableName returns the database table name for the MediaManagementConfig model
@@ -112,6 +118,10 @@ type RootFolder struct {
 	DefaultSearchForMissingMovie bool      `json:"defaultSearchForMissingMovie" gorm:"default:false"`
 	CreatedAt                    time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt                    time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Applied to movies added under the folder by a library import or an import list that don't
+	// set their own, along with DefaultQualityProfileID and DefaultTags
+	DefaultMinimumAvailability Availability `json:"defaultMinimumAvailability,omitempty" gorm:"size:20"`
 }
 
 // TableName returns the database table name for the RootFolder model
@@ -235,6 +245,12 @@ func ValidateRootFolder(rf *RootFolder) []string {
 		errors = append(errors, "Invalid default monitor option")
 	}
 
+	switch rf.DefaultMinimumAvailability {
+	case "", AvailabilityTBA, AvailabilityAnnounced, AvailabilityInCinemas, AvailabilityReleased, AvailabilityPreDB:
+	default:
+		errors = append(errors, "Invalid default minimum availability")
+	}
+
 	return errors
 }
 
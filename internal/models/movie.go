@@ -5,6 +5,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -50,18 +52,40 @@ type Movie struct {
 	Genres                StringArray  `json:"genres" db:"genres" gorm:"type:text"`
 	Tags                  IntArray     `json:"tags" db:"tags" gorm:"type:text"`
 	Added                 time.Time    `json:"added" db:"added"`
+	LastSearchTime        *time.Time   `json:"lastSearchTime,omitempty" db:"last_search_time"`
 	AddOptions            AddOptions   `json:"addOptions" db:"add_options" gorm:"type:text"`
 	Ratings               Ratings      `json:"ratings" db:"ratings" gorm:"type:text"`
 	MovieFile             *MovieFile   `json:"movieFile,omitempty" gorm:"foreignKey:MovieFileID"`
 	Collection            *Collection  `json:"collection,omitempty" db:"collection" gorm:"type:text"`
 	CollectionTmdbID      *int         `json:"collectionTmdbId,omitempty" db:"collection_tmdb_id"`
 	Popularity            float64      `json:"popularity" db:"popularity"`
+	// Cast and Crew hold the billed cast and key crew names used by full-text search
+	Cast StringArray `json:"cast,omitempty" db:"cast_members" gorm:"column:cast_members;type:text"`
+	Crew StringArray `json:"crew,omitempty" db:"crew_members" gorm:"column:crew_members;type:text"`
+	// ReleaseDates holds the certification and release dates in every country; Certification and the
+	// release dates above are taken from the configured countries
+	ReleaseDates RegionalReleases `json:"releaseDates,omitempty" db:"release_dates" gorm:"type:text"`
+	// WatchProviders is where the movie can be watched in the configured watch region
+	WatchProviders *MovieWatchProviders `json:"watchProviders,omitempty" db:"watch_providers" gorm:"type:text"`
 
 	// Timestamps
 	CreatedAt time.Time `json:"createdAt" db:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updatedAt" db:"updated_at" gorm:"autoUpdateTime"`
 }
 
+// LibrarySearchResult is a library movie matched by full-text search
+type LibrarySearchResult struct {
+	Movie      Movie             `json:"movie"`
+	Score      float64           `json:"score"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// SearchHighlight is an excerpt of a matched field with the matching words wrapped in <mark>
+type SearchHighlight struct {
+	Field   string `json:"field"`
+	Snippet string `json:"snippet"`
+}
+
 // MovieStatus represents the current status of a movie
 type MovieStatus string
 
@@ -78,6 +102,10 @@ const (
 	MovieStatusDeleted MovieStatus = "deleted"
 )
 
+// inCinemasToReleaseDays is how long after its cinema release Radarr expects a movie without other
+// release dates to come out
+const inCinemasToReleaseDays = 90
+
 // Availability represents when a movie becomes available for download
 type Availability string
 
@@ -108,6 +136,14 @@ func (s StringArray) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// MarshalJSON writes a nil array as empty rather than null, as Radarr does
+func (s StringArray) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(s))
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (s *StringArray) Scan(value interface{}) error {
 	if value == nil {
@@ -115,8 +151,14 @@ func (s *StringArray) Scan(value interface{}) error {
 		return nil
 	}
 
-	bytes, ok := value.([]byte)
-	if !ok {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		// pgx returns text columns as strings
+		bytes = []byte(v)
+	default:
 		return nil
 	}
 
@@ -131,6 +173,14 @@ func (i IntArray) Value() (driver.Value, error) {
 	return json.Marshal(i)
 }
 
+// MarshalJSON writes a nil array as empty rather than null, as Radarr does
+func (i IntArray) MarshalJSON() ([]byte, error) {
+	if i == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]int(i))
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (i *IntArray) Scan(value interface{}) error {
 	if value == nil {
@@ -154,6 +204,14 @@ func (m MediaCover) Value() (driver.Value, error) {
 	return json.Marshal(m)
 }
 
+// MarshalJSON writes a nil array as empty rather than null, as Radarr does
+func (m MediaCover) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]MediaCoverImage(m))
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (m *MediaCover) Scan(value interface{}) error {
 	if value == nil {
@@ -242,9 +300,33 @@ type Rating struct {
 
 // Collection represents a movie collection or series
 type Collection struct {
-	Name   string            `json:"name"`
-	TmdbID int               `json:"tmdbId"`
-	Images []MediaCoverImage `json:"images"`
+	Name   string     `json:"name"`
+	TmdbID int        `json:"tmdbId"`
+	Images MediaCover `json:"images"`
+}
+
+// MarshalJSON also writes the name as title, the field Radarr names it
+func (c Collection) MarshalJSON() ([]byte, error) {
+	type collection Collection
+	return json.Marshal(struct {
+		collection
+		Title string `json:"title"`
+	}{collection(c), c.Name})
+}
+
+// UnmarshalJSON reads Radarr's title when there is no name
+func (c *Collection) UnmarshalJSON(data []byte) error {
+	type collection Collection
+	var decoded struct {
+		collection
+		Title string `json:"title"`
+	}
+	err := json.Unmarshal(data, &decoded)
+	*c = Collection(decoded.collection)
+	if c.Name == "" {
+		c.Name = decoded.Title
+	}
+	return err
 }
 
 // Value implements the driver.Valuer interface for database storage
@@ -267,6 +349,113 @@ func (c *Collection) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, c)
 }
 
+// RegionalRelease is the certification and release dates of a movie in one country
+type RegionalRelease struct {
+	Country             string     `json:"country"`
+	Certification       string     `json:"certification,omitempty"`
+	InCinemas           *time.Time `json:"inCinemas,omitempty"`
+	PhysicalRelease     *time.Time `json:"physicalRelease,omitempty"`
+	PhysicalReleaseNote string     `json:"physicalReleaseNote,omitempty"`
+	DigitalRelease      *time.Time `json:"digitalRelease,omitempty"`
+}
+
+// RegionalReleases holds the certification and release dates of a movie by country
+type RegionalReleases []RegionalRelease
+
+// ForCountry returns the releases in an ISO 3166-1 country, or nil when there are none
+func (r RegionalReleases) ForCountry(country string) *RegionalRelease {
+	for i := range r {
+		if strings.EqualFold(r[i].Country, country) {
+			return &r[i]
+		}
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (r RegionalReleases) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (r *RegionalReleases) Scan(value interface{}) error {
+	if value == nil {
+		*r = RegionalReleases{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(bytes, r)
+}
+
+// WatchProvider is a streaming service, store or channel offering a movie
+type WatchProvider struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	LogoPath string `json:"logoPath,omitempty"`
+}
+
+// MovieWatchProviders holds where a movie can be watched in one country, by how it is offered
+type MovieWatchProviders struct {
+	Region   string          `json:"region"`
+	Link     string          `json:"link,omitempty"`
+	Flatrate []WatchProvider `json:"flatrate,omitempty"`
+	Free     []WatchProvider `json:"free,omitempty"`
+	Ads      []WatchProvider `json:"ads,omitempty"`
+	Rent     []WatchProvider `json:"rent,omitempty"`
+	Buy      []WatchProvider `json:"buy,omitempty"`
+}
+
+// StreamsOn reports whether any of the providers streams the movie by subscription, for free or
+// with ads; renting and buying don't count
+func (p *MovieWatchProviders) StreamsOn(providerIDs []int) bool {
+	if p == nil {
+		return false
+	}
+	for _, offers := range [][]WatchProvider{p.Flatrate, p.Free, p.Ads} {
+		for _, provider := range offers {
+			if slices.Contains(providerIDs, provider.ID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (p MovieWatchProviders) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (p *MovieWatchProviders) Scan(value interface{}) error {
+	if value == nil {
+		*p = MovieWatchProviders{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(bytes, p)
+}
+
 // BeforeCreate hook validates movie data before creation
 func (m *Movie) BeforeCreate(_ *gorm.DB) error {
 	if m.TmdbID == 0 {
@@ -295,6 +484,65 @@ func (m *Movie) BeforeUpdate(_ *gorm.DB) error {
 	return nil
 }
 
+// MovieStatistics summarizes a movie's files, as Radarr's movie resource does
+type MovieStatistics struct {
+	MovieFileCount int         `json:"movieFileCount"`
+	SizeOnDisk     int64       `json:"sizeOnDisk"`
+	ReleaseGroups  StringArray `json:"releaseGroups"`
+}
+
+// Statistics returns the movie's file statistics. Release groups are only known when the movie
+// file is loaded.
+func (m *Movie) Statistics() MovieStatistics {
+	stats := MovieStatistics{SizeOnDisk: m.SizeOnDisk, ReleaseGroups: StringArray{}}
+	if m.HasFile || m.MovieFileID != 0 {
+		stats.MovieFileCount = 1
+	}
+	if m.MovieFile != nil && m.MovieFile.ReleaseGroup != "" {
+		stats.ReleaseGroups = append(stats.ReleaseGroups, m.MovieFile.ReleaseGroup)
+	}
+	return stats
+}
+
+// ReleaseDate returns the date Radarr reports the movie released on for its minimum availability:
+// the earliest known date for announced movies, the cinema release for in-cinema availability,
+// and otherwise the earlier of the digital and physical releases, or 90 days after the cinema
+// release when neither is known
+func (m *Movie) ReleaseDate() *time.Time {
+	earliest := func(dates ...*time.Time) *time.Time {
+		var first *time.Time
+		for _, date := range dates {
+			if date != nil && (first == nil || date.Before(*first)) {
+				first = date
+			}
+		}
+		return first
+	}
+
+	switch {
+	case m.MinimumAvailability == AvailabilityTBA || m.MinimumAvailability == AvailabilityAnnounced:
+		return earliest(m.InCinemas, m.DigitalRelease, m.PhysicalRelease)
+	case m.MinimumAvailability == AvailabilityInCinemas && m.InCinemas != nil:
+		return m.InCinemas
+	case m.DigitalRelease != nil || m.PhysicalRelease != nil:
+		return earliest(m.DigitalRelease, m.PhysicalRelease)
+	case m.InCinemas != nil:
+		date := m.InCinemas.AddDate(0, 0, inCinemasToReleaseDays)
+		return &date
+	}
+	return nil
+}
+
+// MarshalJSON adds the release date and file statistics Radarr computes for its movie resource
+func (m Movie) MarshalJSON() ([]byte, error) {
+	type movie Movie
+	return json.Marshal(struct {
+		movie
+		ReleaseDate *time.Time      `json:"releaseDate,omitempty"`
+		Statistics  MovieStatistics `json:"statistics"`
+	}{movie(m), m.ReleaseDate(), m.Statistics()})
+}
+
 // AfterFind hook processes movie data after retrieval
 func (m *Movie) AfterFind(_ *gorm.DB) error {
 	// Set computed fields
@@ -322,3 +570,132 @@ func (m *Movie) computeAvailability() bool {
 		return false
 	}
 }
+
+// MovieLookupBatch requests metadata for up to 500 TMDB IDs in one call
+type MovieLookupBatch struct {
+	TmdbIDs []int `json:"tmdbIds" binding:"required,min=1,max=500"`
+}
+
+// MovieLookupResult is the outcome for one TMDB ID of a batch lookup. Movie is set on success,
+// with a non-zero ID when the movie is already in the library; Error is set otherwise.
+type MovieLookupResult struct {
+	TmdbID int    `json:"tmdbId"`
+	Movie  *Movie `json:"movie,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// MovieImportResult is the outcome for one movie of a bulk add, in request order. Movie is the
+// created movie on success; Error, and Field for validation failures, are set otherwise.
+type MovieImportResult struct {
+	Index  int    `json:"index"`
+	TmdbID int    `json:"tmdbId"`
+	Title  string `json:"title"`
+	Movie  *Movie `json:"movie,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Field  string `json:"field,omitempty"`
+}
+
+// MovieDiscoverRequest filters the popular movies returned by discovery by where they can be
+// watched
+type MovieDiscoverRequest struct {
+	Page int
+	// WatchRegion is the ISO 3166-1 country the provider filters apply to, the configured watch
+	// region when empty
+	WatchRegion string
+	// WithWatchProviders keeps movies offered by any of the providers
+	WithWatchProviders []int
+	// WithoutWatchProviders drops movies offered by any of the providers
+	WithoutWatchProviders []int
+	// MonetizationTypes limits the provider filters to offers of these kinds: flatrate, free, ads,
+	// rent or buy
+	MonetizationTypes []string
+}
+
+// MovieEditorTagMode controls how MovieEditor tags are combined with existing tags
+type MovieEditorTagMode string
+
+const (
+	// MovieEditorTagsAdd adds the tags to each movie
+	MovieEditorTagsAdd MovieEditorTagMode = "add"
+	// MovieEditorTagsRemove removes the tags from each movie
+	MovieEditorTagsRemove MovieEditorTagMode = "remove"
+	// MovieEditorTagsReplace replaces each movie's tags
+	MovieEditorTagsReplace MovieEditorTagMode = "replace"
+)
+
+// MovieEditor represents a bulk edit applied to several movies; nil fields are left unchanged
+type MovieEditor struct {
+	MovieIDs            []int              `json:"movieIds" binding:"required,min=1"`
+	Monitored           *bool              `json:"monitored,omitempty"`
+	QualityProfileID    *int               `json:"qualityProfileId,omitempty"`
+	MinimumAvailability *Availability      `json:"minimumAvailability,omitempty"`
+	Tags                []int              `json:"tags,omitempty"`
+	ApplyTags           MovieEditorTagMode `json:"applyTags,omitempty"`
+}
+
+// MovieMonitorRequest sets whether a single movie is monitored
+type MovieMonitorRequest struct {
+	Monitored *bool `json:"monitored" binding:"required"`
+}
+
+// MovieBulkMonitorRequest sets whether several movies are monitored. Movies are chosen by id, by
+// a saved movie index filter, or by filter predicates; when ids and a filter are both given, only
+// the listed movies matching the filter change.
+type MovieBulkMonitorRequest struct {
+	MovieIDs  []int            `json:"movieIds,omitempty"`
+	FilterID  int              `json:"filterId,omitempty"`
+	Filters   FilterPredicates `json:"filters,omitempty"`
+	Monitored *bool            `json:"monitored" binding:"required"`
+}
+
+// MovieBulkMonitorResult reports the movies a bulk monitor request changed; movies already in the
+// requested state aren't counted
+type MovieBulkMonitorResult struct {
+	Monitored bool  `json:"monitored"`
+	Matched   int   `json:"matched"`
+	Updated   int   `json:"updated"`
+	MovieIDs  []int `json:"movieIds"`
+}
+
+// Apply updates the movie with the editor's changes
+func (e *MovieEditor) Apply(movie *Movie) {
+	if e.Monitored != nil {
+		movie.Monitored = *e.Monitored
+	}
+	if e.QualityProfileID != nil {
+		movie.QualityProfileID = *e.QualityProfileID
+	}
+	if e.MinimumAvailability != nil {
+		movie.MinimumAvailability = *e.MinimumAvailability
+	}
+	if e.Tags != nil {
+		movie.Tags = e.applyTags(movie.Tags)
+	}
+}
+
+func (e *MovieEditor) applyTags(current IntArray) IntArray {
+	switch e.ApplyTags {
+	case MovieEditorTagsRemove:
+		remove := make(map[int]bool, len(e.Tags))
+		for _, tag := range e.Tags {
+			remove[tag] = true
+		}
+		tags := IntArray{}
+		for _, tag := range current {
+			if !remove[tag] {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	case MovieEditorTagsReplace:
+		return append(IntArray{}, e.Tags...)
+	default:
+		tags := append(IntArray{}, current...)
+		for _, tag := range e.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return tags
+	}
+}
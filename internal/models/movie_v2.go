@@ -5,6 +5,7 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -173,3 +174,16 @@ type ValidationError struct {
 func (e ValidationError) Error() string {
 	return e.Message
 }
+
+// ConflictError reports an update made to a stale copy of a resource: the stored resource was
+// updated at UpdatedAt, after the copy was read
+type ConflictError struct {
+	Resource  string
+	ID        int
+	UpdatedAt time.Time
+}
+
+func (e ConflictError) Error() string {
+	return fmt.Sprintf("%s %d was changed at %s, after the copy being saved was read; reload it and retry",
+		e.Resource, e.ID, e.UpdatedAt.UTC().Format(time.RFC3339))
+}
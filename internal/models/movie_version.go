@@ -0,0 +1,53 @@
+package models
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PrimaryVersionID is the version ID of a movie's own file, tracked on the movie rather than as
+// a MovieVersion
+const PrimaryVersionID = 0
+
+// MovieVersion is an additional copy of a movie kept alongside its primary file, such as a 2160p
+// version of a movie that is also kept in 1080p. Each version has its own quality profile and
+// folder, and is wanted, searched and named separately from the movie's primary file.
+type MovieVersion struct {
+	ID               int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	MovieID          int       `json:"movieId" gorm:"not null;index"`
+	Name             string    `json:"name" gorm:"not null;size:100"`
+	QualityProfileID int       `json:"qualityProfileId" gorm:"not null"`
+	Path             string    `json:"path" gorm:"not null;size:500"`
+	Monitored        bool      `json:"monitored" gorm:"default:true"`
+	HasFile          bool      `json:"hasFile" gorm:"default:false"`
+	MovieFileID      *int      `json:"movieFileId,omitempty"`
+	CreatedAt        time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Relationships
+	MovieFile *MovieFile `json:"movieFile,omitempty" gorm:"foreignKey:MovieFileID"`
+}
+
+// TableName returns the database table name for the MovieVersion model
+func (MovieVersion) TableName() string {
+	return "movie_versions"
+}
+
+// Validate checks that the version has a name, a quality profile and an absolute folder
+func (v *MovieVersion) Validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.MovieID <= 0 {
+		return ValidationError{Field: "movieId", Message: "Movie is required"}
+	}
+	if v.Name == "" {
+		return ValidationError{Field: "name", Message: "Name is required"}
+	}
+	if v.QualityProfileID <= 0 {
+		return ValidationError{Field: "qualityProfileId", Message: "Quality profile is required"}
+	}
+	if !filepath.IsAbs(v.Path) {
+		return ValidationError{Field: "path", Message: "Path must be an absolute path"}
+	}
+	return nil
+}
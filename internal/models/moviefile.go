@@ -10,6 +10,7 @@ import (
 type MovieFile struct {
 	ID                int        `json:"id" db:"id" gorm:"primaryKey"`
 	MovieID           int        `json:"movieId" db:"movie_id" gorm:"index"`
+	VersionID         int        `json:"versionId" db:"version_id" gorm:"default:0"`
 	RelativePath      string     `json:"relativePath" db:"relative_path"`
 	Path              string     `json:"path" db:"path"`
 	Size              int64      `json:"size" db:"size"`
@@ -30,6 +31,31 @@ type MovieFile struct {
 	UpdatedAt time.Time `json:"updatedAt" db:"updated_at" gorm:"autoUpdateTime"`
 }
 
+// MovieFileRelease is the release a library file was imported from, letting cross-seeding tools
+// match the file back to the torrent it came from
+type MovieFileRelease struct {
+	MovieFileID      int    `json:"movieFileId"`
+	MovieID          int    `json:"movieId"`
+	Path             string `json:"path"`
+	Size             int64  `json:"size"`
+	ReleaseName      string `json:"releaseName"`
+	ReleaseGroup     string `json:"releaseGroup,omitempty"`
+	OriginalFilePath string `json:"originalFilePath,omitempty"`
+}
+
+// Release returns the release the file was imported from
+func (mf *MovieFile) Release() MovieFileRelease {
+	return MovieFileRelease{
+		MovieFileID:      mf.ID,
+		MovieID:          mf.MovieID,
+		Path:             mf.Path,
+		Size:             mf.Size,
+		ReleaseName:      mf.SceneName,
+		ReleaseGroup:     mf.ReleaseGroup,
+		OriginalFilePath: mf.OriginalFilePath,
+	}
+}
+
 // Quality represents the quality information of a movie file
 type Quality struct {
 	Quality  QualityDefinition `json:"quality"`
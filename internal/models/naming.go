@@ -87,6 +87,8 @@ func GetAvailableTokens() []NamingToken {
 		{Token: "{Movie TitleFirstCharacter}", Example: "D", Description: "First character of movie title", Optional: false},
 		{Token: "{Movie Collection}", Example: "The Dark Knight Collection",
 			Description: "Movie collection name", Optional: true},
+		{Token: "{Certification}", Example: "PG-13",
+			Description: "Certification in the configured certification country", Optional: true},
 
 		// Year Tokens
 		{Token: "{Release Year}", Example: "2008", Description: "Year the movie was released", Optional: false},
@@ -112,6 +114,8 @@ func GetAvailableTokens() []NamingToken {
 
 		// Source Tokens
 		{Token: "{Edition Tags}", Example: "Director's Cut", Description: "Edition information", Optional: true},
+		{Token: "{Edition}", Example: "Director's Cut", Description: "Edition information", Optional: true},
+		{Token: "{Movie Version}", Example: "4K", Description: "Name of the movie version", Optional: true},
 		{Token: "{Custom Formats}", Example: "iNTERNAL", Description: "Custom format tags", Optional: true},
 
 		// Release Group Tokens
@@ -243,3 +247,15 @@ func (nc *NamingConfig) ValidateConfiguration() []string {
 
 	return errors
 }
+
+// NamingPreviewRequest is sample data to render naming formats with, for trying formats out before
+// anything is imported. Naming fields left out of the request keep their configured values.
+type NamingPreviewRequest struct {
+	Naming    *NamingConfig `json:"naming"`
+	Movie     *Movie        `json:"movie"`
+	Quality   *Quality      `json:"quality"`
+	MediaInfo *MediaInfo    `json:"mediaInfo"`
+	Edition   string        `json:"edition"`
+	// Version is the movie version name the {Movie Version} token renders as
+	Version string `json:"version"`
+}
@@ -356,13 +356,34 @@ type NotificationHistory struct {
 	Movie          *Movie        `json:"movie,omitempty" gorm:"foreignKey:MovieID"`
 	EventType      string        `json:"eventType" gorm:"not null;size:50"`
 	Subject        string        `json:"subject" gorm:"size:500"`
-	Body           string        `json:"message" gorm:"type:text"`
+	Body           string        `json:"message" gorm:"column:message;type:text"`
 	Successful     bool          `json:"successful" gorm:"not null"`
 	ErrorMessage   string        `json:"errorMessage,omitempty" gorm:"type:text"`
-	SentAt         time.Time     `json:"date" gorm:"not null;index"`
+	SentAt         time.Time     `json:"date" gorm:"column:date;not null;index"`
 	CreatedAt      time.Time     `json:"createdAt" gorm:"autoCreateTime"`
+
+	// Status and Attempts cover every automatic and manual attempt at the delivery
+	Status        NotificationDeliveryStatus `json:"status" gorm:"size:20;default:'delivered';index"`
+	Attempts      int                        `json:"attempts" gorm:"not null;default:1"`
+	LastAttemptAt *time.Time                 `json:"lastAttemptAt,omitempty"`
+	// Payload keeps the message of a queued or failed delivery so it can be sent later
+	Payload string `json:"-" gorm:"type:text"`
+	// DeliverAfter is when a queued delivery is due, at the end of quiet hours or a digest window
+	DeliverAfter *time.Time `json:"deliverAfter,omitempty" gorm:"index"`
 }
 
+// NotificationDeliveryStatus is the outcome of a notification delivery
+type NotificationDeliveryStatus string
+
+const (
+	// NotificationDeliveryDelivered means the provider accepted the notification
+	NotificationDeliveryDelivered NotificationDeliveryStatus = "delivered"
+	// NotificationDeliveryFailed means every retry failed; the delivery can be retried manually
+	NotificationDeliveryFailed NotificationDeliveryStatus = "failed"
+	// NotificationDeliveryQueued means the delivery is held for quiet hours or a digest
+	NotificationDeliveryQueued NotificationDeliveryStatus = "queued"
+)
+
 // TableName returns the database table name for the NotificationHistory model
 func (NotificationHistory) TableName() string {
 	return "notification_history"
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// OutboxEvent is a notification event written in the same transaction as the change it reports,
+// such as a grab or an import, so the event survives a crash right after the change commits. The
+// outbox dispatcher delivers it at least once and then deletes it.
+type OutboxEvent struct {
+	ID        int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventType string `json:"eventType" gorm:"not null;size:50"`
+	// Payload is the NotificationEvent as JSON
+	Payload  string `json:"-" gorm:"type:text;not null"`
+	Attempts int    `json:"attempts" gorm:"not null;default:0"`
+	// NextAttemptAt is when the event is next due; a dispatcher claiming the event pushes it out so
+	// an event abandoned mid-delivery is picked up again later
+	NextAttemptAt time.Time `json:"nextAttemptAt" gorm:"not null;index"`
+	LastError     string    `json:"lastError,omitempty" gorm:"type:text"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the database table name for the OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
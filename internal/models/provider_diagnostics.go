@@ -0,0 +1,142 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// ProviderTestCheck is a stage of testing an indexer, download client or import list
+type ProviderTestCheck string
+
+// Provider test stages, run in this order; a failed stage skips the ones after it
+const (
+	ProviderTestCheckConfiguration  ProviderTestCheck = "configuration"
+	ProviderTestCheckConnectivity   ProviderTestCheck = "connectivity"
+	ProviderTestCheckAuthentication ProviderTestCheck = "authentication"
+	ProviderTestCheckCapability     ProviderTestCheck = "capability"
+	ProviderTestCheckVersion        ProviderTestCheck = "version"
+)
+
+// ProviderTestFailureCategory says why a provider test stage failed
+type ProviderTestFailureCategory string
+
+// Provider test failure categories
+const (
+	ProviderTestFailureInvalidSetting     ProviderTestFailureCategory = "invalidSetting"
+	ProviderTestFailureHostNotFound       ProviderTestFailureCategory = "hostNotFound"
+	ProviderTestFailureConnectionRefused  ProviderTestFailureCategory = "connectionRefused"
+	ProviderTestFailureTimeout            ProviderTestFailureCategory = "timeout"
+	ProviderTestFailureTLS                ProviderTestFailureCategory = "tls"
+	ProviderTestFailureUnauthorized       ProviderTestFailureCategory = "unauthorized"
+	ProviderTestFailureNotFound           ProviderTestFailureCategory = "notFound"
+	ProviderTestFailureServerError        ProviderTestFailureCategory = "serverError"
+	ProviderTestFailureUnexpectedResponse ProviderTestFailureCategory = "unexpectedResponse"
+	ProviderTestFailureUnsupported        ProviderTestFailureCategory = "unsupported"
+	ProviderTestFailureUnsupportedVersion ProviderTestFailureCategory = "unsupportedVersion"
+)
+
+// ProviderTestStage is the outcome of one stage of a provider test
+type ProviderTestStage struct {
+	Check   ProviderTestCheck `json:"check"`
+	Passed  bool              `json:"passed"`
+	Message string            `json:"message,omitempty"`
+}
+
+// ProviderTestFailure is one reason a provider test failed, with a hint on how to fix it
+type ProviderTestFailure struct {
+	Check    ProviderTestCheck           `json:"check"`
+	Category ProviderTestFailureCategory `json:"category"`
+	// Field is the setting at fault for configuration failures
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// ProviderTestReport is the structured part of a provider test result, listing the stages that
+// ran and why the failed ones failed
+type ProviderTestReport struct {
+	Checks   []ProviderTestStage   `json:"checks"`
+	Failures []ProviderTestFailure `json:"failures"`
+	TestedAt time.Time             `json:"testedAt"`
+}
+
+// NewProviderTestReport starts the report of a provider test
+func NewProviderTestReport() ProviderTestReport {
+	return ProviderTestReport{
+		Checks:   []ProviderTestStage{},
+		Failures: []ProviderTestFailure{},
+		TestedAt: time.Now(),
+	}
+}
+
+// Pass records a stage that passed
+func (r *ProviderTestReport) Pass(check ProviderTestCheck, message string) {
+	r.Checks = append(r.Checks, ProviderTestStage{Check: check, Passed: true, Message: message})
+}
+
+// Fail records a failure, marking its stage failed
+func (r *ProviderTestReport) Fail(failure ProviderTestFailure) {
+	for i := range r.Checks {
+		if r.Checks[i].Check == failure.Check {
+			r.Checks[i].Passed = false
+			r.Failures = append(r.Failures, failure)
+			return
+		}
+	}
+	r.Checks = append(r.Checks, ProviderTestStage{Check: failure.Check, Message: failure.Message})
+	r.Failures = append(r.Failures, failure)
+}
+
+// Passed reports whether no stage failed
+func (r *ProviderTestReport) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Messages returns the failure messages, for clients that only read the plain error list
+func (r *ProviderTestReport) Messages() []string {
+	messages := make([]string, len(r.Failures))
+	for i := range r.Failures {
+		messages[i] = r.Failures[i].Message
+	}
+	return messages
+}
+
+// Status returns the summary of the test kept on the provider
+func (r *ProviderTestReport) Status() ProviderTestStatus {
+	testedAt := r.TestedAt
+	return ProviderTestStatus{TestedAt: &testedAt, IsValid: r.Passed(), Failures: r.Failures}
+}
+
+// ProviderTestStatus is the outcome of the last test of a saved provider
+type ProviderTestStatus struct {
+	TestedAt *time.Time            `json:"testedAt,omitempty"`
+	IsValid  bool                  `json:"isValid"`
+	Failures []ProviderTestFailure `json:"failures,omitempty"`
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (s ProviderTestStatus) Value() (driver.Value, error) {
+	if s.TestedAt == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (s *ProviderTestStatus) Scan(value interface{}) error {
+	*s = ProviderTestStatus{}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, s)
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -18,7 +20,43 @@ type QualityLevel struct {
 	Weight        int     `json:"weight" gorm:"not null;default:1"`
 	MinSize       float64 `json:"minSize" gorm:"default:0"`   // MB per minute
 	MaxSize       float64 `json:"maxSize" gorm:"default:400"` // MB per minute
-	PreferredSize float64 `json:"preferredSize,omitempty"`    // MB per minute
+	PreferredSize float64 `json:"preferredSize"`              // MB per minute
+}
+
+// defaultPreferredSize is the preferred size given to quality definitions, capped at their maximum
+const defaultPreferredSize = 95 // MB per minute
+
+// bytesPerMB converts the per-minute sizes of quality definitions to bytes
+const bytesPerMB = 1024 * 1024
+
+// SizeLimits returns the accepted size range in bytes for a movie of the given runtime in
+// minutes. A maximum of zero means there is no upper limit, as for remuxes.
+func (ql *QualityLevel) SizeLimits(runtime int) (minBytes, maxBytes int64) {
+	minBytes = int64(ql.MinSize * bytesPerMB * float64(runtime))
+	if ql.MaxSize > 0 {
+		maxBytes = int64(ql.MaxSize * bytesPerMB * float64(runtime))
+	}
+	return minBytes, maxBytes
+}
+
+// Validate checks the size limits as upstream Radarr does: sizes are not negative and the
+// preferred size lies between the minimum and a non-zero maximum
+func (ql *QualityLevel) Validate() error {
+	switch {
+	case ql.MinSize < 0:
+		return ValidationError{Field: "minSize", Message: "Minimum size cannot be negative"}
+	case ql.MaxSize < 0:
+		return ValidationError{Field: "maxSize", Message: "Maximum size cannot be negative"}
+	case ql.PreferredSize < 0:
+		return ValidationError{Field: "preferredSize", Message: "Preferred size cannot be negative"}
+	case ql.MaxSize > 0 && ql.MinSize > ql.MaxSize:
+		return ValidationError{Field: "minSize", Message: "Minimum size cannot be greater than maximum size"}
+	case ql.PreferredSize > 0 && ql.PreferredSize < ql.MinSize:
+		return ValidationError{Field: "preferredSize", Message: "Preferred size cannot be less than minimum size"}
+	case ql.MaxSize > 0 && ql.PreferredSize > ql.MaxSize:
+		return ValidationError{Field: "preferredSize", Message: "Preferred size cannot be greater than maximum size"}
+	}
+	return nil
 }
 
 // TableName returns the database table name for the QualityLevel model
@@ -28,11 +66,11 @@ func (QualityLevel) TableName() string {
 
 // QualityProfileItem represents a quality within a profile
 type QualityProfileItem struct {
-	Quality *QualityLevel         `json:"quality,omitempty"`
-	Items   []*QualityProfileItem `json:"items,omitempty"`
-	Allowed bool                  `json:"allowed"`
-	Name    string                `json:"name,omitempty"`
-	ID      int                   `json:"id,omitempty"`
+	Quality *QualityLevel       `json:"quality,omitempty"`
+	Items   QualityProfileItems `json:"items"`
+	Allowed bool                `json:"allowed"`
+	Name    string              `json:"name,omitempty"`
+	ID      int                 `json:"id,omitempty"`
 }
 
 // QualityProfileItems represents a slice of quality profile items for JSON/DB storage
@@ -46,6 +84,14 @@ func (qpi QualityProfileItems) Value() (driver.Value, error) {
 	return json.Marshal(qpi)
 }
 
+// MarshalJSON writes a nil array as empty rather than null, as Radarr does
+func (qpi QualityProfileItems) MarshalJSON() ([]byte, error) {
+	if qpi == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]*QualityProfileItem(qpi))
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (qpi *QualityProfileItems) Scan(value interface{}) error {
 	if value == nil {
@@ -83,11 +129,82 @@ func (QualityProfile) TableName() string {
 	return "quality_profiles"
 }
 
+// LanguageResource returns the profile's language as Radarr identifies it, Unknown when the name
+// isn't a Radarr language
+func (qp QualityProfile) LanguageResource() Language {
+	for _, pseudo := range []Language{LanguageAny, LanguageOriginal} {
+		if strings.EqualFold(qp.Language, pseudo.Name) {
+			return pseudo
+		}
+	}
+	if language, ok := LanguageByName(qp.Language); ok {
+		return language
+	}
+	return Language{Name: languageNames[0]}
+}
+
+// MarshalJSON writes the language as the object Radarr does, such as {"id": 1, "name": "English"}
+func (qp QualityProfile) MarshalJSON() ([]byte, error) {
+	type qualityProfile QualityProfile
+	return json.Marshal(struct {
+		qualityProfile
+		Language Language `json:"language"`
+	}{qualityProfile(qp), qp.LanguageResource()})
+}
+
+// UnmarshalJSON reads the language as Radarr writes it, or by name as earlier versions did
+func (qp *QualityProfile) UnmarshalJSON(data []byte) error {
+	type qualityProfile QualityProfile
+	var profile struct {
+		qualityProfile
+		Language json.RawMessage `json:"language"`
+	}
+	// Like json.Unmarshal, the fields that could be read are kept when others have the wrong type
+	err := json.Unmarshal(data, &profile)
+	*qp = QualityProfile(profile.qualityProfile)
+	if err != nil {
+		return err
+	}
+	if len(profile.Language) == 0 || string(profile.Language) == "null" {
+		return nil
+	}
+
+	var language Language
+	if err := json.Unmarshal(profile.Language, &qp.Language); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(profile.Language, &language); err != nil {
+		return err
+	}
+	// Stored lower case, as profiles imported from TRaSH-Guides are
+	qp.Language = strings.ToLower(language.Name)
+	return nil
+}
+
 // IsUpgradeAllowed returns true if upgrades are allowed for this profile
 func (qp *QualityProfile) IsUpgradeAllowed() bool {
 	return qp.UpgradeAllowed
 }
 
+// QualityProfileCloneRequest names the copy made by cloning a quality profile
+type QualityProfileCloneRequest struct {
+	Name string `json:"name"`
+}
+
+// QualityProfileReorderRequest lists a profile's top-level items in their new order, each by its
+// quality id, or its group id for quality groups
+type QualityProfileReorderRequest struct {
+	Order []int `json:"order"`
+}
+
+// ItemID returns the id an item is referred to by: its quality id, or for a group the group id
+func (item *QualityProfileItem) ItemID() int {
+	if item.Quality != nil {
+		return item.Quality.ID
+	}
+	return item.ID
+}
+
 // GetAllowedQualities returns all allowed qualities in this profile
 func (qp *QualityProfile) GetAllowedQualities() []*QualityLevel {
 	var allowed []*QualityLevel
@@ -110,14 +227,54 @@ func (qp *QualityProfile) GetAllowedQualities() []*QualityLevel {
 	return allowed
 }
 
+// UpgradeRulesChanged reports whether other decides differently than qp which movies are wanted:
+// a different cutoff, allowed qualities, upgrade setting, format score thresholds or format scores.
+// Renames and reordering that leave the allowed qualities alone don't count.
+func (qp *QualityProfile) UpgradeRulesChanged(other *QualityProfile) bool {
+	if qp.Cutoff != other.Cutoff || qp.UpgradeAllowed != other.UpgradeAllowed ||
+		qp.MinFormatScore != other.MinFormatScore || qp.CutoffFormatScore != other.CutoffFormatScore {
+		return true
+	}
+	return !maps.Equal(qp.allowedQualityIDs(), other.allowedQualityIDs()) ||
+		!maps.Equal(qp.formatScores(), other.formatScores())
+}
+
+// allowedQualityIDs returns the set of the profile's allowed quality ids
+func (qp *QualityProfile) allowedQualityIDs() map[int]bool {
+	ids := make(map[int]bool)
+	for _, quality := range qp.GetAllowedQualities() {
+		ids[quality.ID] = true
+	}
+	return ids
+}
+
+// formatScores returns the profile's non-zero format scores, keyed by format id or, for items
+// without one, by name
+func (qp *QualityProfile) formatScores() map[string]int {
+	scores := make(map[string]int)
+	for _, item := range qp.FormatItems {
+		if item == nil || item.Score == 0 {
+			continue
+		}
+		key := "name:" + item.Name
+		if item.Format != nil {
+			key = fmt.Sprintf("id:%d", item.Format.ID)
+		}
+		scores[key] = item.Score
+	}
+	return scores
+}
+
 // CustomFormat represents a custom quality format
 type CustomFormat struct {
 	ID                              int               `json:"id" gorm:"primaryKey;autoIncrement"`
 	Name                            string            `json:"name" gorm:"not null;unique;size:255"`
 	IncludeCustomFormatWhenRenaming bool              `json:"includeCustomFormatWhenRenaming" gorm:"default:false"`
 	Specifications                  CustomFormatSpecs `json:"specifications" gorm:"type:text"`
-	CreatedAt                       time.Time         `json:"added" gorm:"autoCreateTime"`
-	UpdatedAt                       time.Time         `json:"updated" gorm:"autoUpdateTime"`
+	// TrashID is the TRaSH-Guides id of a format imported from the guides, used to match it on re-import
+	TrashID   string    `json:"-" gorm:"size:64;index"`
+	CreatedAt time.Time `json:"added" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated" gorm:"autoUpdateTime"`
 }
 
 // TableName returns the database table name for the CustomFormat model
@@ -125,7 +282,8 @@ func (CustomFormat) TableName() string {
 	return "custom_formats"
 }
 
-// CustomFormatSpec represents a specification for a custom format
+// CustomFormatSpec represents a specification for a custom format. The API form follows
+// upstream Radarr's resource, see MarshalJSON.
 type CustomFormatSpec struct {
 	Name           string                 `json:"name"`
 	Implementation string                 `json:"implementation"`
@@ -134,6 +292,10 @@ type CustomFormatSpec struct {
 	Fields         map[string]interface{} `json:"fields"`
 }
 
+// customFormatSpecRecord is the stored form of a specification, with fields keyed by name
+// rather than the API's field array
+type customFormatSpecRecord CustomFormatSpec
+
 // CustomFormatSpecs represents a slice of custom format specifications
 type CustomFormatSpecs []*CustomFormatSpec
 
@@ -142,7 +304,11 @@ func (cfs CustomFormatSpecs) Value() (driver.Value, error) {
 	if cfs == nil {
 		return nil, nil
 	}
-	return json.Marshal(cfs)
+	records := make([]*customFormatSpecRecord, len(cfs))
+	for i, spec := range cfs {
+		records[i] = (*customFormatSpecRecord)(spec)
+	}
+	return json.Marshal(records)
 }
 
 // Scan implements the sql.Scanner interface for database retrieval
@@ -180,6 +346,14 @@ func (cfi CustomFormatItems) Value() (driver.Value, error) {
 	return json.Marshal(cfi)
 }
 
+// MarshalJSON writes a nil array as empty rather than null, as Radarr does
+func (cfi CustomFormatItems) MarshalJSON() ([]byte, error) {
+	if cfi == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]*CustomFormatItem(cfi))
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (cfi *CustomFormatItems) Scan(value interface{}) error {
 	if value == nil {
@@ -199,7 +373,7 @@ func (cfi *CustomFormatItems) Scan(value interface{}) error {
 
 // DefaultQualityDefinitions returns the standard quality definitions
 func DefaultQualityDefinitions() []*QualityLevel {
-	return []*QualityLevel{
+	definitions := []*QualityLevel{
 		{ID: 0, Title: "Unknown", Weight: 1, MinSize: 0, MaxSize: 199.9},
 		{ID: 24, Title: "WORKPRINT", Weight: 2, MinSize: 0, MaxSize: 199.9},
 		{ID: 25, Title: "CAM", Weight: 3, MinSize: 0, MaxSize: 199.9},
@@ -229,6 +403,12 @@ func DefaultQualityDefinitions() []*QualityLevel {
 		{ID: 19, Title: "Bluray-2160p", Weight: 27, MinSize: 4.3, MaxSize: 258.1},
 		{ID: 31, Title: "Remux-2160p", Weight: 28, MinSize: 0, MaxSize: 0},
 	}
+	for _, definition := range definitions {
+		if definition.MaxSize > 0 {
+			definition.PreferredSize = min(defaultPreferredSize, definition.MaxSize)
+		}
+	}
+	return definitions
 }
 
 // BeforeCreate hook validates quality profile data before creation
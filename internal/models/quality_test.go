@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualityProfile_UpgradeRulesChanged(t *testing.T) {
+	profile := func() *QualityProfile {
+		return &QualityProfile{
+			Name: "HD", Cutoff: 7, UpgradeAllowed: true,
+			Items: QualityProfileItems{
+				{Quality: &QualityLevel{ID: 4, Title: "HDTV-720p"}, Allowed: true},
+				{Quality: &QualityLevel{ID: 7, Title: "Bluray-1080p"}, Allowed: true},
+				{Quality: &QualityLevel{ID: 19, Title: "Bluray-2160p"}, Allowed: false},
+			},
+			FormatItems: CustomFormatItems{{Format: &CustomFormat{ID: 1}, Score: 10}, {Name: "x265", Score: 0}},
+		}
+	}
+	stored := profile()
+
+	renamed := profile()
+	renamed.Name = "HD-1080p"
+	renamed.Items[0], renamed.Items[1] = renamed.Items[1], renamed.Items[0]
+	renamed.FormatItems = renamed.FormatItems[:1]
+	assert.False(t, stored.UpgradeRulesChanged(renamed))
+
+	cutoff := profile()
+	cutoff.Cutoff = 19
+	assert.True(t, stored.UpgradeRulesChanged(cutoff))
+
+	allowed := profile()
+	allowed.Items[2].Allowed = true
+	assert.True(t, stored.UpgradeRulesChanged(allowed))
+
+	upgrades := profile()
+	upgrades.UpgradeAllowed = false
+	assert.True(t, stored.UpgradeRulesChanged(upgrades))
+
+	scores := profile()
+	scores.FormatItems[0].Score = 20
+	assert.True(t, stored.UpgradeRulesChanged(scores))
+
+	cutoffScore := profile()
+	cutoffScore.CutoffFormatScore = 100
+	assert.True(t, stored.UpgradeRulesChanged(cutoffScore))
+}
@@ -0,0 +1,51 @@
+package models
+
+// UnmappedQueueItem is a queued or completed download that is not matched to a library movie,
+// with the movies it may belong to
+type UnmappedQueueItem struct {
+	QueueItem
+	ParsedMovieInfo *ParsedMovieInfo      `json:"parsedMovieInfo,omitempty"`
+	Suggestions     []QueueItemSuggestion `json:"suggestions"`
+}
+
+// QueueItemSuggestion is a movie an unmapped download may belong to, either already in the
+// library or found on TMDB
+type QueueItemSuggestion struct {
+	TmdbID    int    `json:"tmdbId"`
+	MovieID   int    `json:"movieId,omitempty"`
+	Title     string `json:"title"`
+	Year      int    `json:"year,omitempty"`
+	InLibrary bool   `json:"inLibrary"`
+}
+
+// QueueItemResolution maps an unmapped download to a library movie by movieId, or to a TMDB
+// movie by tmdbId, which is added to the library with the given settings when it isn't there yet
+type QueueItemResolution struct {
+	MovieID          int    `json:"movieId"`
+	TmdbID           int    `json:"tmdbId"`
+	QualityProfileID int    `json:"qualityProfileId"`
+	RootFolderPath   string `json:"rootFolderPath"`
+	Monitored        bool   `json:"monitored"`
+}
+
+// Validate checks that the resolution names exactly one movie
+func (r *QueueItemResolution) Validate() error {
+	if r.MovieID < 0 || r.TmdbID < 0 {
+		return ValidationError{Field: "movieId", Message: "Movie ID and TMDB ID cannot be negative"}
+	}
+	if (r.MovieID == 0) == (r.TmdbID == 0) {
+		return ValidationError{Field: "movieId", Message: "Exactly one of movie ID or TMDB ID is required"}
+	}
+	return nil
+}
+
+// ValidateNewMovie checks the settings needed to add the resolved TMDB movie to the library
+func (r *QueueItemResolution) ValidateNewMovie() error {
+	if r.QualityProfileID <= 0 {
+		return ValidationError{Field: "qualityProfileId", Message: "A quality profile is required to add the movie"}
+	}
+	if r.RootFolderPath == "" {
+		return ValidationError{Field: "rootFolderPath", Message: "A root folder is required to add the movie"}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fixtures in testdata/radarr are responses of Radarr's /api/v3, with instance-specific
+// values such as paths and hashes replaced. Each is decoded into the model the matching endpoint
+// returns and encoded again, and the result must have the fixture's fields, with the same names
+// and JSON types. Fields Radarr doesn't have are allowed; clients ignore them.
+
+// radarrCompatGaps are the known differences from Radarr, by fixture or enumeration. The tests
+// fail when a new difference appears and when a listed one no longer does, so the list only
+// shrinks as gaps are closed.
+var radarrCompatGaps = map[string][]string{
+	// Alternate titles are stored as plain strings, without their source
+	"movie.json": {
+		".alternateTitles.0: can't decode object into string",
+		".alternateTitles[]: string, not object",
+	},
+	// Queue items don't track the parsed release or the download's import state
+	"queue.json": {
+		".customFormatScore: missing",
+		".customFormats: missing",
+		".downloadClientHasPostImportCategory: missing",
+		".indexer: missing",
+		".languages: missing",
+		".quality: missing",
+		".trackedDownloadState: missing",
+	},
+	// History stores the quality definition without its revision, and typed event data where
+	// Radarr keeps strings
+	"history.json": {
+		".customFormatScore: missing",
+		".customFormats: missing",
+		".data.age: can't decode string into int",
+		".data.age: missing",
+		".data.ageHours: missing",
+		".data.ageMinutes: missing",
+		".data.customFormatScore: missing",
+		".data.downloadClientName: missing",
+		".data.imdbId: missing",
+		".data.indexerFlags: missing",
+		".data.movieMatchType: missing",
+		".data.releaseSource: missing",
+		".data.size: missing",
+		".data.tmdbId: missing",
+		".quality.quality: missing",
+		".quality.revision: missing",
+		".qualityCutoffNotMet: missing",
+	},
+	// Profile items hold quality definitions rather than qualities, format items hold the whole
+	// custom format, and there is no minimum upgrade score
+	"qualityprofile.json": {
+		".formatItems.0.format: can't decode number into models.CustomFormat",
+		".formatItems[].format: object, not number",
+		".items[].quality.modifier: missing",
+		".items[].quality.name: missing",
+		".items[].quality.resolution: missing",
+		".items[].quality.source: missing",
+		".minUpgradeFormatScore: missing",
+	},
+	// Radarr v3's preDB availability was dropped by later versions
+	"minimum availability": {`"preDB" is not a Radarr value`},
+	"tracked download state": {
+		`"downloadFailed" is not a Radarr value`,
+		`"downloadFailedPending" is not a Radarr value`,
+	},
+	// Library events Radarr doesn't record in history
+	"history event type": {
+		`"movieAdded" is not a Radarr value`,
+		`"movieDeleted" is not a Radarr value`,
+		`"movieSearched" is not a Radarr value`,
+		`"movieRefreshed" is not a Radarr value`,
+		`"qualityUpgraded" is not a Radarr value`,
+		`"movieImported" is not a Radarr value`,
+		`"movieUnmonitored" is not a Radarr value`,
+		`"movieMonitored" is not a Radarr value`,
+	},
+}
+
+func TestRadarrCompat_Fields(t *testing.T) {
+	for fixture, model := range map[string]func() any{
+		"movie.json":          func() any { return &Movie{} },
+		"queue.json":          func() any { return &QueueItem{} },
+		"history.json":        func() any { return &History{} },
+		"qualityprofile.json": func() any { return &QualityProfile{} },
+	} {
+		t.Run(fixture, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "radarr", fixture))
+			require.NoError(t, err)
+			var golden any
+			require.NoError(t, json.Unmarshal(data, &golden))
+
+			var differences []string
+			value := model()
+			// Fields of the wrong type are reported by the decoder, which carries on with the rest
+			var typeErr *json.UnmarshalTypeError
+			if err := json.Unmarshal(data, value); errors.As(err, &typeErr) {
+				differences = append(differences, fmt.Sprintf(".%s: can't decode %s into %s", typeErr.Field,
+					typeErr.Value, typeErr.Type))
+			} else {
+				require.NoError(t, err)
+			}
+			encoded, err := json.Marshal(value)
+			require.NoError(t, err)
+			var actual any
+			require.NoError(t, json.Unmarshal(encoded, &actual))
+			differences = append(differences, compareShape("", golden, actual)...)
+
+			assertCompatGaps(t, fixture, differences)
+		})
+	}
+}
+
+// TestRadarrCompat_Nulls checks empty models, as for a movie with no tags, don't write null where
+// Radarr writes an empty value; Radarr leaves out null values rather than writing them
+func TestRadarrCompat_Nulls(t *testing.T) {
+	for name, value := range map[string]any{
+		"movie":          Movie{},
+		"queue":          QueueItem{},
+		"history":        History{},
+		"qualityprofile": QualityProfile{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := json.Marshal(value)
+			require.NoError(t, err)
+			var actual any
+			require.NoError(t, json.Unmarshal(encoded, &actual))
+
+			assertCompatGaps(t, name+" nulls", nullFields("", actual))
+		})
+	}
+}
+
+// TestRadarrCompat_Enums checks the values the models write for enumerations are ones Radarr
+// writes, spelled the same way
+func TestRadarrCompat_Enums(t *testing.T) {
+	for name, enum := range map[string]struct {
+		radarr []string
+		ours   []string
+	}{
+		"movie status": {
+			radarr: []string{"tba", "announced", "inCinemas", "released", "deleted"},
+			ours: []string{string(MovieStatusTBA), string(MovieStatusAnnounced), string(MovieStatusInCinemas),
+				string(MovieStatusReleased), string(MovieStatusDeleted)},
+		},
+		"minimum availability": {
+			radarr: []string{"tba", "announced", "inCinemas", "released", "deleted"},
+			ours: []string{string(AvailabilityTBA), string(AvailabilityAnnounced), string(AvailabilityInCinemas),
+				string(AvailabilityReleased), string(AvailabilityPreDB)},
+		},
+		"queue status": {
+			radarr: []string{"unknown", "queued", "paused", "downloading", "completed", "failed", "warning", "delay",
+				"downloadClientUnavailable", "fallback"},
+			ours: []string{string(QueueStatusUnknown), string(QueueStatusQueued), string(QueueStatusPaused),
+				string(QueueStatusDownloading), string(QueueStatusCompleted), string(QueueStatusFailed),
+				string(QueueStatusWarning), string(QueueStatusDelay), string(QueueStatusDownloadClientUnavailable),
+				string(QueueStatusFallback)},
+		},
+		"tracked download status": {
+			radarr: []string{"ok", "warning", "error"},
+			ours: []string{string(TrackedDownloadStatusOk), string(TrackedDownloadStatusWarning),
+				string(TrackedDownloadStatusError)},
+		},
+		"tracked download state": {
+			radarr: []string{"downloading", "importBlocked", "importPending", "importing", "imported",
+				"failedPending", "failed", "ignored"},
+			ours: []string{string(TrackedDownloadStateDownloading), string(TrackedDownloadStateDownloadFailed),
+				string(TrackedDownloadStateDownloadFailedPending), string(TrackedDownloadStateImportPending),
+				string(TrackedDownloadStateImporting), string(TrackedDownloadStateImported),
+				string(TrackedDownloadStateFailedPending), string(TrackedDownloadStateFailed),
+				string(TrackedDownloadStateIgnored)},
+		},
+		"download protocol": {
+			radarr: []string{"unknown", "usenet", "torrent"},
+			ours: []string{string(DownloadProtocolUnknown), string(DownloadProtocolUsenet),
+				string(DownloadProtocolTorrent)},
+		},
+		"history event type": {
+			radarr: []string{"unknown", "grabbed", "downloadFolderImported", "downloadFailed", "movieFileDeleted",
+				"movieFolderImported", "movieFileRenamed", "downloadIgnored"},
+			ours: []string{string(HistoryEventTypeGrabbed), string(HistoryEventTypeDownloadFolderImported),
+				string(HistoryEventTypeDownloadFailed), string(HistoryEventTypeMovieFileDeleted),
+				string(HistoryEventTypeMovieFileRenamed), string(HistoryEventTypeMovieAdded),
+				string(HistoryEventTypeMovieDeleted), string(HistoryEventTypeMovieSearched),
+				string(HistoryEventTypeMovieRefreshed), string(HistoryEventTypeQualityUpgraded),
+				string(HistoryEventTypeMovieImported), string(HistoryEventTypeMovieUnmonitored),
+				string(HistoryEventTypeMovieMonitored), string(HistoryEventTypeIgnoredDownload)},
+		},
+	} {
+		var differences []string
+		for _, value := range enum.ours {
+			if !contains(enum.radarr, value) {
+				differences = append(differences, fmt.Sprintf("%q is not a Radarr value", value))
+			}
+		}
+		assertCompatGaps(t, name, differences)
+	}
+}
+
+// assertCompatGaps fails for differences that aren't known gaps and for known gaps that are gone
+func assertCompatGaps(t *testing.T, name string, differences []string) {
+	t.Helper()
+	known := radarrCompatGaps[name]
+	for _, difference := range differences {
+		assert.Contains(t, known, difference, "%s differs from Radarr", name)
+	}
+	for _, gap := range known {
+		assert.Contains(t, differences, gap, "%s now matches Radarr; remove the gap from radarrCompatGaps", name)
+	}
+}
+
+// compareShape lists where actual lacks the fields of golden or has values of another JSON type.
+// Array elements are compared with golden's first element.
+func compareShape(path string, golden, actual any) []string {
+	if jsonType(golden) != jsonType(actual) {
+		return []string{fmt.Sprintf("%s: %s, not %s", displayPath(path), jsonType(actual), jsonType(golden))}
+	}
+
+	var differences []string
+	switch golden := golden.(type) {
+	case map[string]any:
+		actual := actual.(map[string]any)
+		keys := make([]string, 0, len(golden))
+		for key := range golden {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, ok := actual[key]
+			if !ok {
+				differences = append(differences, fmt.Sprintf("%s: missing", path+"."+key))
+				continue
+			}
+			differences = append(differences, compareShape(path+"."+key, golden[key], value)...)
+		}
+	case []any:
+		actual := actual.([]any)
+		if len(golden) > 0 && len(actual) > 0 {
+			differences = append(differences, compareShape(path+"[]", golden[0], actual[0])...)
+		}
+	}
+	return differences
+}
+
+// nullFields lists the fields holding null
+func nullFields(path string, value any) []string {
+	var fields []string
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if field == nil {
+				fields = append(fields, path+"."+key+": null")
+				continue
+			}
+			fields = append(fields, nullFields(path+"."+key, field)...)
+		}
+	case []any:
+		for _, element := range value {
+			fields = append(fields, nullFields(path+"[]", element)...)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -93,6 +93,7 @@ type Release struct {
 	Title            string          `json:"title" gorm:"not null;size:500"`
 	SortTitle        string          `json:"sortTitle" gorm:"size:500;index"`
 	Overview         string          `json:"overview" gorm:"type:text"`
+	Edition          string          `json:"edition" gorm:"size:255"`
 	Quality          Quality         `json:"quality" gorm:"type:text"`
 	QualityWeight    int             `json:"qualityWeight" gorm:"index"`
 	Age              int             `json:"age"`
@@ -127,6 +128,11 @@ type Release struct {
 	UpdatedAt        time.Time       `json:"updated" gorm:"autoUpdateTime"`
 	GrabbedAt        *time.Time      `json:"grabbedAt,omitempty"`
 	FailedAt         *time.Time      `json:"failedAt,omitempty"`
+	ImportedAt       *time.Time      `json:"importedAt,omitempty"`
+	// CustomFormats are the custom formats the release matches, and CustomFormatScore their score
+	// in the movie's quality profile, as of the release's last evaluation
+	CustomFormats     []CustomFormat `json:"customFormats,omitempty" gorm:"-"`
+	CustomFormatScore int            `json:"customFormatScore" gorm:"-"`
 }
 
 // TableName returns the database table name for the Release model
@@ -216,6 +222,9 @@ type SearchRequest struct {
 	SortOrder  string        `json:"sortOrder,omitempty"`
 	Protocol   *Protocol     `json:"protocol,omitempty"`
 	Source     ReleaseSource `json:"source"`
+	// AlternateTitles are queried by title alongside the main query and their results merged, so
+	// foreign language movies are also found under their original title
+	AlternateTitles []string `json:"alternateTitles,omitempty"`
 }
 
 // SearchResponse represents the response from a search request
@@ -230,10 +239,13 @@ type SearchResponse struct {
 
 // GrabRequest represents a request to grab a release
 type GrabRequest struct {
-	GUID             string `json:"guid" binding:"required"`
-	IndexerID        int    `json:"indexerId" binding:"required"`
-	MovieID          *int   `json:"movieId,omitempty"`
-	DownloadClientID *int   `json:"downloadClientId,omitempty"`
+	GUID      string `json:"guid" binding:"required"`
+	IndexerID int    `json:"indexerId" binding:"required"`
+	// MovieID, Quality and DownloadClientID override the movie the release was mapped to, its
+	// parsed quality and the indexer's download client
+	MovieID          *int     `json:"movieId,omitempty"`
+	Quality          *Quality `json:"quality,omitempty"`
+	DownloadClientID *int     `json:"downloadClientId,omitempty"`
 }
 
 // GrabResponse represents the response from grabbing a release
@@ -246,6 +258,18 @@ type GrabResponse struct {
 	Message          string `json:"message,omitempty"`
 }
 
+// RedownloadResponse reports how a grab from history was redone: the release was sent to a
+// download client again ("grabbed"), was no longer acceptable ("rejected"), or a fresh search ran
+// without finding it grabbable ("searched")
+type RedownloadResponse struct {
+	HistoryID int           `json:"historyId"`
+	Status    string        `json:"status"`
+	Grab      *GrabResponse `json:"grab,omitempty"`
+	// Releases are the fresh search's results, with the original release first when found
+	Releases []Release `json:"releases,omitempty"`
+	Message  string    `json:"message,omitempty"`
+}
+
 // ReleaseFilter represents filters for release queries
 type ReleaseFilter struct {
 	Status        []ReleaseStatus `json:"status,omitempty"`
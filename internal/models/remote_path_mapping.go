@@ -0,0 +1,57 @@
+package models
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RemotePathMapping translates a folder as a download client on Host reports it into the path
+// where Radarr reaches the same folder, such as a share mounted at a different location
+type RemotePathMapping struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Host       string    `json:"host" gorm:"not null;size:255;index"`
+	RemotePath string    `json:"remotePath" gorm:"not null;size:500"`
+	LocalPath  string    `json:"localPath" gorm:"not null;size:500"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for the RemotePathMapping model
+func (RemotePathMapping) TableName() string {
+	return "remote_path_mappings"
+}
+
+// Validate checks that the mapping names a host, a remote folder and an absolute local folder
+func (m *RemotePathMapping) Validate() error {
+	m.Host = strings.TrimSpace(m.Host)
+	if m.Host == "" {
+		return ValidationError{Field: "host", Message: "Host is required"}
+	}
+	if m.RemotePath == "" {
+		return ValidationError{Field: "remotePath", Message: "Remote path is required"}
+	}
+	if !filepath.IsAbs(m.LocalPath) {
+		return ValidationError{Field: "localPath", Message: "Local path must be an absolute path"}
+	}
+	return nil
+}
+
+// Translate returns the local path of remotePath reported by a client on host, and whether the
+// mapping applies. Remote paths may use either separator, since the client can run on another OS.
+func (m *RemotePathMapping) Translate(host, remotePath string) (string, bool) {
+	if !strings.EqualFold(m.Host, host) {
+		return "", false
+	}
+
+	remoteRoot := strings.TrimRight(m.RemotePath, `/\`)
+	if remotePath != remoteRoot && !strings.HasPrefix(remotePath, remoteRoot+"/") &&
+		!strings.HasPrefix(remotePath, remoteRoot+`\`) {
+		return "", false
+	}
+
+	parts := strings.FieldsFunc(remotePath[len(remoteRoot):], func(r rune) bool {
+		return r == '/' || r == '\\'
+	})
+	return filepath.Join(append([]string{m.LocalPath}, parts...)...), true
+}
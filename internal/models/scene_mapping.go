@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SceneMappingSource records where a scene mapping came from
+type SceneMappingSource string
+
+const (
+	// SceneMappingSourceManual mappings are managed through the API and kept across refreshes
+	SceneMappingSourceManual SceneMappingSource = "manual"
+	// SceneMappingSourceRemote mappings are replaced each time the configured list is refreshed
+	SceneMappingSourceRemote SceneMappingSource = "remote"
+)
+
+// SceneMapping maps a title releases are named under, which does not match the TMDB title or
+// its alternate titles, to the movie it belongs to
+type SceneMapping struct {
+	ID     int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Title  string `json:"title" gorm:"not null;size:500"`
+	TmdbID int    `json:"tmdbId" gorm:"not null;index"`
+	// Year restricts the mapping to releases of that year, 0 matches any year
+	Year       int                `json:"year"`
+	Source     SceneMappingSource `json:"source" gorm:"not null;size:20;default:'manual'"`
+	CleanTitle string             `json:"-" gorm:"not null;size:500;index"`
+	CreatedAt  time.Time          `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time          `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for the SceneMapping model
+func (SceneMapping) TableName() string {
+	return "scene_mappings"
+}
+
+// Validate checks that the mapping names a title and a TMDB movie
+func (m *SceneMapping) Validate() error {
+	m.Title = strings.TrimSpace(m.Title)
+	if m.Title == "" {
+		return ValidationError{Field: "title", Message: "Title is required"}
+	}
+	if m.TmdbID <= 0 {
+		return ValidationError{Field: "tmdbId", Message: "TMDB ID must be a positive number"}
+	}
+	if m.Year < 0 {
+		return ValidationError{Field: "year", Message: "Year cannot be negative"}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+)
+
+// Provider settings keys of the seed goals, set on download clients and on torrent indexers
+const (
+	// SettingSeedRatio is the upload ratio after which an imported torrent may be removed
+	SettingSeedRatio = "seedRatio"
+	// SettingSeedTime is the number of minutes after which an imported torrent may be removed
+	SettingSeedTime = "seedTime"
+)
+
+// SeedingStatus represents the state of an imported torrent tracked for seeding
+type SeedingStatus string
+
+const (
+	// SeedingStatusSeeding is a torrent still seeding in its download client
+	SeedingStatusSeeding SeedingStatus = "seeding"
+	// SeedingStatusRemoved is a torrent removed from its client after meeting its seed goals
+	SeedingStatusRemoved SeedingStatus = "removed"
+	// SeedingStatusMissing is a torrent no longer in its client, removed outside Radarr
+	SeedingStatusMissing SeedingStatus = "missing"
+)
+
+// SeedingTorrent is a torrent that was imported and keeps seeding in its download client until
+// its seed goals are met
+type SeedingTorrent struct {
+	ID               int           `json:"id" gorm:"primaryKey;autoIncrement"`
+	DownloadClientID int           `json:"downloadClientId" gorm:"not null;index"`
+	IndexerID        *int          `json:"indexerId,omitempty"`
+	MovieID          int           `json:"movieId" gorm:"index"`
+	DownloadID       string        `json:"downloadId" gorm:"not null;size:255"`
+	Title            string        `json:"title" gorm:"size:500"`
+	Status           SeedingStatus `json:"status" gorm:"not null;size:20;index"`
+	Ratio            float64       `json:"ratio"`
+	SeedingTime      int64         `json:"seedingTime"` // seconds
+	ImportedAt       time.Time     `json:"importedAt"`
+	LastCheckedAt    *time.Time    `json:"lastCheckedAt,omitempty"`
+	RemovedAt        *time.Time    `json:"removedAt,omitempty"`
+	CreatedAt        time.Time     `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time     `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for the SeedingTorrent model
+func (SeedingTorrent) TableName() string {
+	return "seeding_torrents"
+}
+
+// SeedGoals are the ratio and seeding time after which a torrent may be removed from its client.
+// Zero values are unset.
+type SeedGoals struct {
+	Ratio float64       `json:"ratio"`
+	Time  time.Duration `json:"time"`
+}
+
+// IsSet reports whether any goal is set. Torrents without goals seed until removed by hand.
+func (g SeedGoals) IsSet() bool {
+	return g.Ratio > 0 || g.Time > 0
+}
+
+// Override returns the goals with the goals set in other taking precedence
+func (g SeedGoals) Override(other SeedGoals) SeedGoals {
+	if other.Ratio > 0 {
+		g.Ratio = other.Ratio
+	}
+	if other.Time > 0 {
+		g.Time = other.Time
+	}
+	return g
+}
+
+// Met reports whether a torrent with the given ratio and seeding time has reached either goal
+func (g SeedGoals) Met(ratio float64, seedingTime time.Duration) bool {
+	return (g.Ratio > 0 && ratio >= g.Ratio) || (g.Time > 0 && seedingTime >= g.Time)
+}
+
+// SeedGoals returns the seed goals configured on the download client
+func (dc *DownloadClient) SeedGoals() SeedGoals {
+	return seedGoals(dc.Settings)
+}
+
+// SeedGoals returns the seed goals configured on the indexer, as its tracker requires
+func (i *Indexer) SeedGoals() SeedGoals {
+	return seedGoals(i.Settings)
+}
+
+// seedGoals reads the seed goals from a provider settings map
+func seedGoals(settings map[string]interface{}) SeedGoals {
+	return SeedGoals{
+		Ratio: settingFloat(settings, SettingSeedRatio),
+		Time:  time.Duration(settingFloat(settings, SettingSeedTime) * float64(time.Minute)),
+	}
+}
+
+// SeedingCleanupResult summarizes a check of the seeding torrents
+type SeedingCleanupResult struct {
+	Checked int `json:"checked"`
+	Removed int `json:"removed"`
+	Missing int `json:"missing"`
+	// RemovedTitles are the torrents removed, or that would be in a simulated run
+	RemovedTitles []string `json:"removedTitles"`
+}
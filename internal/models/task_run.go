@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskRun is a finished execution of a task, kept after the task leaves the queue so the history of
+// recurring jobs can be reviewed
+type TaskRun struct {
+	ID           int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	TaskID       int        `json:"taskId" gorm:"not null"`
+	Name         string     `json:"name" gorm:"not null;size:255"`
+	CommandName  string     `json:"commandName" gorm:"not null;size:255;index"`
+	Outcome      TaskStatus `json:"outcome" gorm:"not null;size:20"` // completed, failed or aborted
+	StartedAt    time.Time  `json:"startedAt" gorm:"not null"`
+	EndedAt      time.Time  `json:"endedAt" gorm:"not null"`
+	DurationMs   int64      `json:"durationMs"`
+	Metrics      JSONField  `json:"metrics,omitempty" gorm:"type:text"` // the counts the task reported
+	ErrorMessage string     `json:"errorMessage,omitempty" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the database table name for the TaskRun model
+func (TaskRun) TableName() string {
+	return "task_runs"
+}
+
+// TaskRunSummary aggregates the runs of a command over a period
+type TaskRunSummary struct {
+	TotalRuns         int        `json:"totalRuns"`
+	Completed         int        `json:"completed"`
+	Failed            int        `json:"failed"`
+	Aborted           int        `json:"aborted"`
+	SuccessRate       float64    `json:"successRate"` // percentage of runs that completed
+	AverageDurationMs int64      `json:"averageDurationMs"`
+	MaxDurationMs     int64      `json:"maxDurationMs"`
+	LastRun           *time.Time `json:"lastRun,omitempty"`
+	LastSuccess       *time.Time `json:"lastSuccess,omitempty"`
+}
+
+// TaskRunDay aggregates the runs of a command started on one day, with the numeric counts the runs
+// reported summed by name
+type TaskRunDay struct {
+	Date              string             `json:"date"` // YYYY-MM-DD
+	Runs              int                `json:"runs"`
+	Completed         int                `json:"completed"`
+	Failed            int                `json:"failed"`
+	Aborted           int                `json:"aborted"`
+	AverageDurationMs int64              `json:"averageDurationMs"`
+	Metrics           map[string]float64 `json:"metrics,omitempty"`
+}
+
+// TaskRunHistory is the run history of a command: the totals and daily aggregates over the
+// requested days, and the most recent runs
+type TaskRunHistory struct {
+	CommandName string         `json:"commandName"`
+	Days        int            `json:"days"`
+	Summary     TaskRunSummary `json:"summary"`
+	Daily       []TaskRunDay   `json:"daily"`
+	Runs        []TaskRun      `json:"runs"`
+}
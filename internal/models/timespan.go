@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSpan is a duration written to JSON as .NET writes a TimeSpan, such as "00:12:30" or
+// "1.02:03:04" for a day and more, so Radarr clients can read it
+type TimeSpan time.Duration
+
+// String formats the span as [-][d.]hh:mm:ss, dropping fractions of a second
+func (t TimeSpan) String() string {
+	d := time.Duration(t)
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	seconds := int64(d / time.Second)
+	days := seconds / 86400
+	hours := seconds / 3600 % 24
+	minutes := seconds / 60 % 60
+	seconds %= 60
+
+	if days > 0 {
+		return fmt.Sprintf("%s%d.%02d:%02d:%02d", sign, days, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+}
+
+// MarshalJSON writes the span as a .NET TimeSpan string
+func (t TimeSpan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON reads a .NET TimeSpan string, with optional days and fractional seconds, or a
+// number of nanoseconds as earlier versions wrote
+func (t *TimeSpan) UnmarshalJSON(data []byte) error {
+	var nanoseconds int64
+	if err := json.Unmarshal(data, &nanoseconds); err == nil {
+		*t = TimeSpan(nanoseconds)
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	span, err := ParseTimeSpan(value)
+	if err != nil {
+		return err
+	}
+	*t = span
+	return nil
+}
+
+// ParseTimeSpan parses a .NET TimeSpan string in the [-][d.]hh:mm:ss[.fffffff] form
+func ParseTimeSpan(value string) (TimeSpan, error) {
+	invalid := fmt.Errorf("invalid time span %q: expected [d.]hh:mm:ss", value)
+
+	text := strings.TrimSpace(value)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	parts := strings.Split(text, ":")
+	if len(parts) != 3 {
+		return 0, invalid
+	}
+	var days int64
+	if day, hour, ok := strings.Cut(parts[0], "."); ok {
+		n, err := strconv.ParseInt(day, 10, 64)
+		if err != nil {
+			return 0, invalid
+		}
+		days, parts[0] = n, hour
+	}
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil || hours > 23 || minutes > 59 || seconds >= 60 {
+		return 0, invalid
+	}
+
+	d := time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second))
+	if negative {
+		d = -d
+	}
+	return TimeSpan(d), nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeSpan_JSON(t *testing.T) {
+	tests := []struct {
+		span TimeSpan
+		want string
+	}{
+		{0, `"00:00:00"`},
+		{TimeSpan(12*time.Minute + 30*time.Second + 400*time.Millisecond), `"00:12:30"`},
+		{TimeSpan(26*time.Hour + 3*time.Minute + 4*time.Second), `"1.02:03:04"`},
+		{TimeSpan(-90 * time.Second), `"-00:01:30"`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.span)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, string(data))
+
+		var decoded TimeSpan
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, tt.span.String(), decoded.String())
+	}
+
+	// Earlier versions wrote nanoseconds
+	var decoded TimeSpan
+	require.NoError(t, json.Unmarshal([]byte("90000000000"), &decoded))
+	assert.Equal(t, TimeSpan(90*time.Second), decoded)
+}
+
+func TestParseTimeSpan(t *testing.T) {
+	span, err := ParseTimeSpan("2.00:00:01.5000000")
+	require.NoError(t, err)
+	assert.Equal(t, TimeSpan(48*time.Hour+1500*time.Millisecond), span)
+
+	for _, value := range []string{"", "12:30", "00:60:00", "24:00:00", "x.00:00:00", "00:00:ss"} {
+		_, err := ParseTimeSpan(value)
+		assert.Error(t, err, value)
+	}
+}
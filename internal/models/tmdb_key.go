@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// TMDBKeySource tells where the TMDB API key in use comes from
+type TMDBKeySource string
+
+const (
+	// TMDBKeySourceNone means no API key is configured
+	TMDBKeySourceNone TMDBKeySource = "none"
+	// TMDBKeySourceConfig means the key comes from tmdb.api_key in the config file
+	TMDBKeySourceConfig TMDBKeySource = "config"
+	// TMDBKeySourceDatabase means the key was set through the API and overrides the config file
+	TMDBKeySourceDatabase TMDBKeySource = "database"
+)
+
+// TMDBKeyStatus describes the TMDB API key in use without revealing it
+type TMDBKeyStatus struct {
+	Configured bool          `json:"configured"`
+	Source     TMDBKeySource `json:"source"`
+	// APIKey is the key in use with all but its last characters masked
+	APIKey string `json:"apiKey,omitempty"`
+	// Checked is set once TMDB answered a request made with the key, telling whether it is Valid
+	Checked   bool       `json:"checked"`
+	Valid     bool       `json:"valid"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+// TMDBKeyRequest carries an API key to store or validate
+type TMDBKeyRequest struct {
+	APIKey string `json:"apiKey"`
+}
+
+// TMDBKeyValidation is the outcome of validating an API key against TMDB
+type TMDBKeyValidation struct {
+	Valid   bool   `json:"valid"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,59 @@
+package models
+
+// TrashCustomFormat is a custom format in the JSON format published by TRaSH-Guides and synced
+// by Recyclarr
+type TrashCustomFormat struct {
+	TrashID string `json:"trash_id"`
+	// TrashScores holds the recommended score per score set, with "default" used when a
+	// profile names no set
+	TrashScores                     map[string]int           `json:"trash_scores,omitempty"`
+	TrashDescription                string                   `json:"trash_description,omitempty"`
+	Name                            string                   `json:"name"`
+	IncludeCustomFormatWhenRenaming bool                     `json:"includeCustomFormatWhenRenaming"`
+	Specifications                  []*TrashCustomFormatSpec `json:"specifications"`
+}
+
+// TrashCustomFormatSpec is a specification as written by TRaSH-Guides, with fields as a
+// name-to-value object rather than the API's field array
+type TrashCustomFormatSpec CustomFormatSpec
+
+// TrashQualityProfile is a quality profile in the TRaSH-Guides JSON format. Qualities and the
+// cutoff are referenced by name, highest quality first, and custom formats by trash_id.
+type TrashQualityProfile struct {
+	TrashID           string             `json:"trash_id,omitempty"`
+	Name              string             `json:"name"`
+	TrashDescription  string             `json:"trash_description,omitempty"`
+	TrashScoreSet     string             `json:"trash_score_set,omitempty"`
+	UpgradeAllowed    bool               `json:"upgradeAllowed"`
+	Cutoff            string             `json:"cutoff"`
+	MinFormatScore    int                `json:"minFormatScore"`
+	CutoffFormatScore int                `json:"cutoffFormatScore"`
+	Language          string             `json:"language,omitempty"`
+	Items             []TrashQualityItem `json:"items"`
+	// FormatItems maps custom format names to their trash_id
+	FormatItems map[string]string `json:"formatItems,omitempty"`
+}
+
+// TrashQualityItem is a quality, or a named group of qualities, in a TRaSH-Guides profile
+type TrashQualityItem struct {
+	Name    string   `json:"name"`
+	Allowed bool     `json:"allowed"`
+	Items   []string `json:"items,omitempty"`
+}
+
+// TrashProfileBundle is a quality profile together with the custom formats it scores, as
+// exported from and imported into radarr-go
+type TrashProfileBundle struct {
+	QualityProfile TrashQualityProfile `json:"qualityProfile"`
+	CustomFormats  []TrashCustomFormat `json:"customFormats"`
+}
+
+// TrashImportResult reports what an import changed
+type TrashImportResult struct {
+	Created        []*CustomFormat `json:"created"`
+	Updated        []*CustomFormat `json:"updated"`
+	QualityProfile *QualityProfile `json:"qualityProfile,omitempty"`
+	// SkippedFormats lists profile format items whose custom format was neither supplied nor
+	// already stored
+	SkippedFormats []string `json:"skippedFormats,omitempty"`
+}
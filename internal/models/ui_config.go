@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+)
+
+// UIConfig represents the display settings the web frontend loads on startup: calendar and date
+// formatting, color-impaired mode and the interface language
+type UIConfig struct {
+	ID                       int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	FirstDayOfWeek           int       `json:"firstDayOfWeek" gorm:"default:0"`
+	CalendarWeekColumnHeader string    `json:"calendarWeekColumnHeader" gorm:"default:'ddd M/D'"`
+	ShortDateFormat          string    `json:"shortDateFormat" gorm:"default:'MMM D YYYY'"`
+	LongDateFormat           string    `json:"longDateFormat" gorm:"default:'dddd, MMMM D YYYY'"`
+	TimeFormat               string    `json:"timeFormat" gorm:"default:'h(:mm)a'"`
+	ShowRelativeDates        bool      `json:"showRelativeDates" gorm:"default:true"`
+	EnableColorImpairedMode  bool      `json:"enableColorImpairedMode" gorm:"default:false"`
+	UILanguage               int       `json:"uiLanguage" gorm:"default:1"`
+	CreatedAt                time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt                time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the database table name for the UIConfig model
+func (UIConfig) TableName() string {
+	return "ui_config"
+}
+
+// GetDefaultUIConfig returns the default UI configuration
+func GetDefaultUIConfig() *UIConfig {
+	return &UIConfig{
+		ID:                       1,
+		FirstDayOfWeek:           0,
+		CalendarWeekColumnHeader: "ddd M/D",
+		ShortDateFormat:          "MMM D YYYY",
+		LongDateFormat:           "dddd, MMMM D YYYY",
+		TimeFormat:               "h(:mm)a",
+		ShowRelativeDates:        true,
+		EnableColorImpairedMode:  false,
+		UILanguage:               1,
+	}
+}
+
+// ValidateConfiguration validates the UI configuration
+func (uc *UIConfig) ValidateConfiguration() []string {
+	var errors []string
+
+	// The calendar starts on Sunday (0) or Monday (1)
+	if uc.FirstDayOfWeek != 0 && uc.FirstDayOfWeek != 1 {
+		errors = append(errors, "First day of week must be 0 (Sunday) or 1 (Monday)")
+	}
+
+	if uc.CalendarWeekColumnHeader == "" {
+		errors = append(errors, "Calendar week column header cannot be empty")
+	}
+	if uc.ShortDateFormat == "" {
+		errors = append(errors, "Short date format cannot be empty")
+	}
+	if uc.LongDateFormat == "" {
+		errors = append(errors, "Long date format cannot be empty")
+	}
+	if uc.TimeFormat == "" {
+		errors = append(errors, "Time format cannot be empty")
+	}
+
+	// UI language is a language id, Unknown (0) is not a UI language
+	if uc.UILanguage <= 0 || uc.UILanguage >= len(languageNames) {
+		errors = append(errors, "UI language must be a known language id")
+	}
+
+	return errors
+}
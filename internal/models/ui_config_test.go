@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIConfig_ValidateConfiguration(t *testing.T) {
+	assert.Empty(t, GetDefaultUIConfig().ValidateConfiguration())
+
+	config := GetDefaultUIConfig()
+	config.FirstDayOfWeek = 1
+	config.UILanguage = 4
+	assert.Empty(t, config.ValidateConfiguration())
+
+	config.FirstDayOfWeek = 3
+	config.LongDateFormat = ""
+	config.UILanguage = 0
+	assert.Equal(t, []string{
+		"First day of week must be 0 (Sunday) or 1 (Monday)",
+		"Long date format cannot be empty",
+		"UI language must be a known language id",
+	}, config.ValidateConfiguration())
+}
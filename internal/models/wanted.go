@@ -23,7 +23,8 @@ const (
 // WantedMovie represents a movie that needs to be downloaded or upgraded
 type WantedMovie struct {
 	ID                int            `json:"id" gorm:"primaryKey"`
-	MovieID           int            `json:"movieId" gorm:"not null;uniqueIndex"`
+	MovieID           int            `json:"movieId" gorm:"not null;uniqueIndex:uq_wanted_movies_movie_version"`
+	VersionID         int            `json:"versionId" gorm:"not null;default:0;uniqueIndex:uq_wanted_movies_movie_version"`
 	Status            WantedStatus   `json:"status" gorm:"not null"`
 	Reason            string         `json:"reason"`
 	CurrentQualityID  *int           `json:"currentQualityId,omitempty"`
@@ -38,6 +39,10 @@ type WantedMovie struct {
 	CreatedAt         time.Time      `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt         time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 
+	// Automatic search opt-outs, manual searches ignore both
+	SnoozedUntil           *time.Time `json:"snoozedUntil,omitempty"`
+	ExcludedFromAutoSearch bool       `json:"excludedFromAutoSearch" gorm:"default:false"`
+
 	// Relationships
 	Movie          *Movie        `json:"movie,omitempty" gorm:"foreignKey:MovieID"`
 	CurrentQuality *QualityLevel `json:"currentQuality,omitempty" gorm:"foreignKey:CurrentQualityID"`
@@ -214,6 +219,15 @@ func (w *WantedMovie) IsEligibleForSearch() bool {
 	return true
 }
 
+// IsAutoSearchAllowed reports whether automatic searches may pick up the movie: it is not
+// excluded from them and not snoozed past now
+func (w *WantedMovie) IsAutoSearchAllowed() bool {
+	if w.ExcludedFromAutoSearch {
+		return false
+	}
+	return w.SnoozedUntil == nil || !time.Now().Before(*w.SnoozedUntil)
+}
+
 // CalculateNextSearchTime calculates when the next search should occur based on attempts and priority
 func (w *WantedMovie) CalculateNextSearchTime() time.Time {
 	baseDelay := time.Hour * 2 // Base delay of 2 hours
@@ -0,0 +1,112 @@
+// Package secrets encrypts the credentials Radarr stores in its database with AES-256-GCM, using a
+// key kept in a file in the data directory so a database dump alone doesn't reveal them.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// KeyFileName is the name of the key file created in the data directory
+	KeyFileName = "secrets.key"
+
+	keySize       = 32
+	keyFilePerm   = 0o600
+	encodedPrefix = "v1:"
+)
+
+// ErrDecrypt is returned for values that weren't encrypted with the current key
+var ErrDecrypt = errors.New("secret cannot be decrypted with the current key")
+
+// Box encrypts and decrypts secrets with one key
+type Box struct {
+	aead cipher.AEAD
+}
+
+// Open loads the key from the file at path, creating the file with a new random key when it
+// doesn't exist
+func Open(path string) (*Box, error) {
+	key, err := os.ReadFile(path) //nolint:gosec // The path comes from the configured data directory
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		key, err = createKey(path)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to read secrets key: %w", err)
+	}
+
+	return New(key)
+}
+
+// New creates a box using key, which must be 32 bytes
+func New(key []byte) (*Box, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("secrets key must be %d bytes, got %d", keySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Encrypt returns plaintext encrypted under a random nonce, encoded for storage as text
+func (b *Box) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encodedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a value produced by Encrypt
+func (b *Box) Decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encodedPrefix)
+	if !ok {
+		return "", ErrDecrypt
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < b.aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, ciphertext := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plaintext), nil
+}
+
+// createKey writes a new random key to path, readable only by its owner
+func createKey(path string) ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate secrets key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create secrets key directory: %w", err)
+	}
+	if err := os.WriteFile(path, key, keyFilePerm); err != nil {
+		return nil, fmt.Errorf("failed to write secrets key: %w", err)
+	}
+	return key, nil
+}
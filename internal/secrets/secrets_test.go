@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBox_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), KeyFileName)
+	box, err := Open(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	encrypted, err := box.Encrypt("tmdb-key")
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "tmdb-key")
+
+	// The key file is reused on the next start
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	decrypted, err := reopened.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "tmdb-key", decrypted)
+
+	other, err := Open(filepath.Join(t.TempDir(), KeyFileName))
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = box.Decrypt("tmdb-key")
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
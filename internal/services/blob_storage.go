@@ -0,0 +1,62 @@
+package services
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/blobstore"
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/logger"
+)
+
+// blobStorageTimeout bounds a single request to the s3 storage backend, long enough for backups
+const blobStorageTimeout = 5 * time.Minute
+
+// blobKind is a kind of blob, named by its key prefix within the s3 bucket
+type blobKind string
+
+const (
+	blobKindBackups     blobKind = "backups/"
+	blobKindMediaCovers blobKind = "mediacover/"
+)
+
+// blobStore returns the store for one kind of blob: its prefix within the bucket for the s3
+// backend, or its directory on local disk otherwise. An unusable s3 configuration falls back to
+// local disk.
+func blobStore(cfg *config.Config, kind blobKind, logger *logger.Logger) blobstore.Store {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Storage.Backend == "s3" {
+		s3 := cfg.Storage.S3
+		store, err := blobstore.NewS3(blobstore.S3Config{
+			Endpoint:        s3.Endpoint,
+			Region:          s3.Region,
+			Bucket:          s3.Bucket,
+			Prefix:          s3.Prefix + string(kind),
+			AccessKeyID:     s3.AccessKeyID,
+			SecretAccessKey: s3.SecretAccessKey,
+			PathStyle:       s3.PathStyle,
+		}, httpclient.New(blobStorageTimeout))
+		if err == nil {
+			return store
+		}
+		logger.Error("Invalid s3 storage configuration, storing on local disk", "kind", kind, "error", err)
+	}
+
+	var dir string
+	switch kind {
+	case blobKindBackups:
+		dir = cfg.Storage.BackupDir
+	case blobKindMediaCovers:
+		if cfg.Storage.DataDirectory != "" {
+			dir = filepath.Join(cfg.Storage.DataDirectory, "MediaCover")
+		}
+	}
+	if dir == "" {
+		return nil
+	}
+	return blobstore.NewLocal(dir)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/blobstore"
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobStore_Backend(t *testing.T) {
+	log := logger.New(config.LogConfig{Level: "error"})
+
+	assert.Nil(t, blobStore(nil, blobKindBackups, log))
+
+	cfg := &config.Config{Storage: config.StorageConfig{BackupDir: t.TempDir()}}
+	assert.IsType(t, &blobstore.Local{}, blobStore(cfg, blobKindBackups, log))
+	assert.Nil(t, blobStore(cfg, blobKindMediaCovers, log))
+
+	cfg.Storage.Backend = "s3"
+	cfg.Storage.S3 = config.S3StorageConfig{Endpoint: "http://minio:9000", Bucket: "radarr", PathStyle: true}
+	assert.IsType(t, &blobstore.S3{}, blobStore(cfg, blobKindMediaCovers, log))
+
+	// Without a bucket the local directory is used instead
+	cfg.Storage.S3.Bucket = ""
+	assert.IsType(t, &blobstore.Local{}, blobStore(cfg, blobKindBackups, log))
+}
+
+func TestConfigService_BackupArchives(t *testing.T) {
+	service := NewConfigService(nil, logger.New(config.LogConfig{Level: "error"}))
+	service.UseBackupStore(blobstore.NewLocal(t.TempDir()))
+	ctx := context.Background()
+
+	backup := &models.ConfigurationBackup{
+		BackupName: "Before Upgrade!",
+		CreatedAt:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, service.archiveConfigurationBackup(backup))
+	assert.Equal(t, "config-backup-20261001-120000-before-upgrade.json", backup.ArchiveName)
+	assert.Positive(t, backup.BackupSize)
+
+	archives, err := service.ListConfigurationBackupArchives(ctx)
+	require.NoError(t, err)
+	require.Len(t, archives, 1)
+	assert.Equal(t, backup.ArchiveName, archives[0].Key)
+
+	archive, err := service.GetConfigurationBackupArchive(ctx, backup.ArchiveName)
+	require.NoError(t, err)
+	data, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+	assert.Contains(t, string(data), `"backupName":"Before Upgrade!"`)
+
+	_, err = service.GetConfigurationBackupArchive(ctx, "secrets.key")
+	assert.ErrorContains(t, err, "not found")
+}
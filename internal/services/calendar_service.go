@@ -101,6 +101,8 @@ func (s *CalendarService) generateCalendarEvents(request *models.CalendarRequest
 		query = query.Where("monitored = ?", true)
 	}
 
+	query = s.applyDateRangeFilter(query, request)
+
 	if err := query.Find(&movies).Error; err != nil {
 		return nil, fmt.Errorf("failed to query movies: %w", err)
 	}
@@ -117,6 +119,32 @@ func (s *CalendarService) generateCalendarEvents(request *models.CalendarRequest
 	return events, nil
 }
 
+// applyDateRangeFilter restricts the query to movies with at least one date that can produce
+// an event inside the requested range, so the range is not filtered in memory over the whole library
+func (s *CalendarService) applyDateRangeFilter(query *gorm.DB, request *models.CalendarRequest) *gorm.DB {
+	if request.Start == nil && request.End == nil {
+		return query
+	}
+
+	start := time.Time{}
+	if request.Start != nil {
+		start = *request.Start
+	}
+	end := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	// PreDB availability is derived from the physical release minus a week
+	physicalEnd := end
+	if request.End != nil {
+		end = *request.End
+		physicalEnd = end.AddDate(0, 0, 7)
+	}
+
+	return query.Where(
+		"((in_cinemas BETWEEN ? AND ?) OR (physical_release BETWEEN ? AND ?) OR "+
+			"(digital_release BETWEEN ? AND ?) OR (added BETWEEN ? AND ?))",
+		start, end, start, physicalEnd, start, end, start, end,
+	)
+}
+
 // generateEventsForMovie creates calendar events for a specific movie
 func (s *CalendarService) generateEventsForMovie(
 	movie *models.Movie, request *models.CalendarRequest, now time.Time,
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 	"gorm.io/gorm"
@@ -318,7 +319,7 @@ func (s *CollectionService) makeTMDBRequest(ctx context.Context, url string) (*h
 		return nil, fmt.Errorf("failed to create TMDB request: %w", err)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := httpclient.New(10 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from TMDB: %w", err)
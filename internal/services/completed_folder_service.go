@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// completedFolderSettleTime is how long a folder entry must go unmodified before it's imported,
+// so files the client is still moving into the folder are left for a later scan
+const completedFolderSettleTime = time.Minute
+
+// completedFolderPartialSuffixes mark files clients are still writing
+var completedFolderPartialSuffixes = []string{".part", ".partial", ".!qb", ".!ut", ".tmp", ".crdownload"}
+
+// CompletedFolderService imports finished downloads of clients without an API Radarr can track
+// them through. It scans the completed download folder set on such clients, maps each entry to a
+// grab sent to the client by its parsed name and imports it.
+type CompletedFolderService struct {
+	db                      *database.Database
+	logger                  *logger.Logger
+	downloadService         *DownloadService
+	parseService            *ParseService
+	fileOrganizationService *FileOrganizationService
+	importService           *ImportService
+	now                     func() time.Time
+
+	mu          sync.Mutex
+	lastScanned map[int]time.Time
+}
+
+// NewCompletedFolderService creates a new instance of CompletedFolderService
+func NewCompletedFolderService(
+	db *database.Database,
+	logger *logger.Logger,
+	downloadService *DownloadService,
+	parseService *ParseService,
+	fileOrganizationService *FileOrganizationService,
+	importService *ImportService,
+) *CompletedFolderService {
+	return &CompletedFolderService{
+		db:                      db,
+		logger:                  logger,
+		downloadService:         downloadService,
+		parseService:            parseService,
+		fileOrganizationService: fileOrganizationService,
+		importService:           importService,
+		now:                     time.Now,
+		lastScanned:             make(map[int]time.Time),
+	}
+}
+
+// Scan scans the completed download folders of the enabled clients whose scan interval has
+// elapsed, or of every client that has one when force is set
+func (s *CompletedFolderService) Scan(ctx context.Context, force bool) (*models.CompletedFolderScanResult, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	clients, err := s.downloadService.GetEnabledDownloadClients()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CompletedFolderScanResult{Unmatched: []string{}}
+	for i := range clients {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if clients[i].CompletedDownloadFolder() == "" || !s.due(&clients[i], force) {
+			continue
+		}
+		result.Clients++
+		if err := s.scanClient(ctx, &clients[i], result); err != nil {
+			s.logger.Warn("Failed to scan completed download folder", "client", clients[i].Name,
+				"folder", clients[i].CompletedDownloadFolder(), "error", err)
+		}
+	}
+
+	if result.Matched > 0 {
+		s.logger.Info("Completed download folders scanned", "clients", result.Clients, "scanned", result.Scanned,
+			"matched", result.Matched, "imported", result.Imported)
+	}
+	return result, nil
+}
+
+// due reports whether the client's folder is due a scan, and if so records the scan
+func (s *CompletedFolderService) due(client *models.DownloadClient, force bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	last, scanned := s.lastScanned[client.ID]
+	if scanned && !force && now.Sub(last) < client.CompletedFolderScanInterval() {
+		return false
+	}
+	s.lastScanned[client.ID] = now
+	return true
+}
+
+// scanClient imports the settled entries of one client's completed download folder that map to
+// a grab sent to the client and not imported yet
+func (s *CompletedFolderService) scanClient(
+	ctx context.Context, client *models.DownloadClient, result *models.CompletedFolderScanResult,
+) error {
+	folder := client.CompletedDownloadFolder()
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return fmt.Errorf("failed to read folder: %w", err)
+	}
+
+	var grabs []models.Release
+	if err := s.db.GORM.Where("download_client_id = ? AND status = ?", client.ID, models.ReleaseStatusGrabbed).
+		Preload("Movie").Order("grabbed_at DESC").Find(&grabs).Error; err != nil {
+		return fmt.Errorf("failed to get grabbed releases: %w", err)
+	}
+
+	for _, entry := range entries {
+		name, ok := s.completedEntryName(entry)
+		if !ok {
+			continue
+		}
+		result.Scanned++
+
+		grab := s.matchGrab(name, grabs)
+		if grab == nil {
+			result.Unmatched = append(result.Unmatched, entry.Name())
+			continue
+		}
+		if grab.ImportedAt != nil {
+			// Imported by an earlier scan and left in place by a copy or hardlink import
+			continue
+		}
+		result.Matched++
+
+		if s.importEntry(ctx, client, filepath.Join(folder, entry.Name()), grab) {
+			result.Imported++
+		}
+	}
+	return nil
+}
+
+// completedEntryName returns the release name of a folder entry: a folder's name, or a video
+// file's name without its extension. Hidden entries, entries other than video files and folders,
+// and entries the client may still be writing are skipped.
+func (s *CompletedFolderService) completedEntryName(entry os.DirEntry) (string, bool) {
+	name := entry.Name()
+	if strings.HasPrefix(name, ".") {
+		return "", false
+	}
+	lower := strings.ToLower(name)
+	for _, suffix := range completedFolderPartialSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return "", false
+		}
+	}
+
+	info, err := entry.Info()
+	if err != nil || s.now().Sub(info.ModTime()) < completedFolderSettleTime {
+		return "", false
+	}
+	if entry.IsDir() {
+		return name, true
+	}
+
+	ext := filepath.Ext(name)
+	if !s.fileOrganizationService.isVideoFile(strings.ToLower(ext)) {
+		return "", false
+	}
+	return strings.TrimSuffix(name, ext), true
+}
+
+// matchGrab returns the grab a folder entry is the download of: the grab of the same release
+// name, imported or not, or else the most recent grab not imported yet whose parsed movie title,
+// year and resolution match the entry's
+func (s *CompletedFolderService) matchGrab(name string, grabs []models.Release) *models.Release {
+	normalized := normalizeSearchTitle(name)
+	for i := range grabs {
+		if normalizeSearchTitle(grabs[i].Title) == normalized {
+			return &grabs[i]
+		}
+	}
+
+	parsed := s.parseService.parseTitle(name)
+	if normalizeSearchTitle(parsed.PrimaryMovieTitle) == "" {
+		return nil
+	}
+	for i := range grabs {
+		if grabs[i].ImportedAt != nil {
+			continue
+		}
+		if grabMatchesParsed(&grabs[i], s.parseService.parseTitle(grabs[i].Title), parsed) {
+			return &grabs[i]
+		}
+	}
+	return nil
+}
+
+// grabMatchesParsed reports whether the grab is for the movie parsed from a folder entry, judged
+// by the grab's own parsed title or the movie it was grabbed for. A resolution parsed from both
+// must agree, so an earlier download of the movie left in the folder isn't taken for an upgrade.
+func grabMatchesParsed(grab *models.Release, grabParsed, parsed *models.ParsedMovieInfo) bool {
+	resolution, grabResolution := parsed.Quality.Quality.Resolution, grabParsed.Quality.Quality.Resolution
+	if resolution != 0 && grabResolution != 0 && resolution != grabResolution {
+		return false
+	}
+
+	title := normalizeSearchTitle(parsed.PrimaryMovieTitle)
+	if normalizeSearchTitle(grabParsed.PrimaryMovieTitle) == title &&
+		(parsed.Year == 0 || grabParsed.Year == parsed.Year) {
+		return true
+	}
+	return grab.Movie != nil && normalizeSearchTitle(grab.Movie.Title) == title &&
+		(parsed.Year == 0 || grab.Movie.Year == parsed.Year)
+}
+
+// importEntry imports a folder entry and marks its grab imported when any of its files were, so
+// the entry isn't imported again by later scans
+func (s *CompletedFolderService) importEntry(
+	ctx context.Context, client *models.DownloadClient, path string, grab *models.Release,
+) bool {
+	importResult, err := s.importService.ProcessImport(ctx, path, &ImportOptions{
+		ImportMode: models.ImportDecisionApproved,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to import completed download", "client", client.Name, "path", path, "error", err)
+		return false
+	}
+	if len(importResult.ImportedFiles) == 0 {
+		s.logger.Debug("Completed download had no files to import", "client", client.Name, "path", path)
+		return false
+	}
+
+	now := s.now()
+	grab.ImportedAt = &now
+	if err := s.db.GORM.Model(&models.Release{}).Where("id = ?", grab.ID).
+		Update("imported_at", now).Error; err != nil {
+		s.logger.Error("Failed to mark grab imported", "release", grab.Title, "error", err)
+	}
+	s.logger.Info("Imported completed download", "client", client.Name, "path", path, "release", grab.Title,
+		"files", len(importResult.ImportedFiles))
+	return true
+}
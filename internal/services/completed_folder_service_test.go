@@ -0,0 +1,109 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCompletedFolderService() *CompletedFolderService {
+	log := logger.New(config.LogConfig{Level: "error"})
+	return NewCompletedFolderService(nil, log, nil, NewParseService(nil, log, nil, nil),
+		NewFileOrganizationService(nil, log, nil, nil), nil)
+}
+
+func TestCompletedFolderService_MatchGrab(t *testing.T) {
+	service := newTestCompletedFolderService()
+	imported := time.Now()
+	grabs := []models.Release{
+		{ID: 1, Title: "Dune.Part.Two.2024.2160p.WEB-DL.DDP5.1.Atmos-FLUX"},
+		{ID: 2, Title: "Arrival.2016.1080p.BluRay.x264-SPARKS", ImportedAt: &imported},
+		{ID: 3, Title: "Heat 1995 1080p BluRay", Movie: &models.Movie{Title: "Heat", Year: 1995}},
+		{ID: 4, Title: "Some.Renamed.Upload.1080p", Movie: &models.Movie{Title: "Oppenheimer", Year: 2023}},
+	}
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"Dune.Part.Two.2024.2160p.WEB-DL.DDP5.1.Atmos-FLUX", 1},
+		{"dune part two 2024 2160p web-dl ddp5 1 atmos-flux", 1},
+		{"Dune.Part.Two.2024.2160p.WEB-DL-OTHERGROUP", 1},
+		{"Dune.Part.Two.2024.1080p.WEB-DL-OTHERGROUP", 0},
+		{"Arrival.2016.1080p.BluRay.x264-SPARKS", 2},
+		{"Arrival.2016.1080p.BluRay.x264-OTHER", 0},
+		{"Heat.1995.1080p.BluRay.REMUX-GRP", 3},
+		{"Oppenheimer.2023.1080p.WEBRip-GRP", 4},
+		{"Oppenheimer.2022.1080p.WEBRip-GRP", 0},
+		{"Unrelated.Movie.2020.720p.HDTV-GRP", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grab := service.matchGrab(tt.name, grabs)
+			if tt.want == 0 {
+				assert.Nil(t, grab)
+				return
+			}
+			require.NotNil(t, grab)
+			assert.Equal(t, tt.want, grab.ID)
+		})
+	}
+}
+
+func TestCompletedFolderService_CompletedEntryName(t *testing.T) {
+	service := newTestCompletedFolderService()
+	dir := t.TempDir()
+	settled := time.Now().Add(-time.Hour)
+
+	create := func(name string, isDir bool, modTime time.Time) {
+		path := filepath.Join(dir, name)
+		if isDir {
+			require.NoError(t, os.Mkdir(path, 0o755))
+		} else {
+			require.NoError(t, os.WriteFile(path, []byte("x"), 0o600))
+		}
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	create("Movie.2020.1080p.WEB-DL-GRP", true, settled)
+	create("Movie.2021.720p.BluRay-GRP.mkv", false, settled)
+	create("Movie.2022.720p.BluRay-GRP.mkv.part", false, settled)
+	create("Movie.2023.1080p.WEB-DL-GRP.nfo", false, settled)
+	create(".hidden", true, settled)
+	create("Movie.2024.2160p.WEB-DL-GRP.mkv", false, time.Now())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		if name, ok := service.completedEntryName(entry); ok {
+			names = append(names, name)
+		}
+	}
+	assert.Equal(t, []string{"Movie.2020.1080p.WEB-DL-GRP", "Movie.2021.720p.BluRay-GRP"}, names)
+}
+
+func TestCompletedFolderService_Due(t *testing.T) {
+	service := newTestCompletedFolderService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	client := &models.DownloadClient{ID: 1, Settings: models.DownloadClientSettings{
+		models.SettingCompletedFolderScanInterval: float64(5),
+	}}
+
+	assert.True(t, service.due(client, false))
+	assert.False(t, service.due(client, false))
+	assert.True(t, service.due(client, true))
+
+	now = now.Add(4 * time.Minute)
+	assert.False(t, service.due(client, false))
+	now = now.Add(time.Minute)
+	assert.True(t, service.due(client, false))
+}
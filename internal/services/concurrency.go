@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// concurrencyNow stamps the updated time of resources saved by saveUnlessStale. It's truncated to
+// the second, which every database stores exactly, so the time returned to a client matches the
+// stored one and can be sent back with its next change.
+func concurrencyNow() time.Time {
+	return time.Now().Truncate(time.Second)
+}
+
+// saveUnlessStale saves value, the resource with id, unless it changed since read, the updated
+// time of the copy the client changed. The stored updated time is checked under a row lock, so a
+// concurrent save can't slip in between, and a mismatch returns a models.ConflictError instead of
+// overwriting the other change. A zero read skips the check, for clients that don't send the
+// updated time back. The saved updated time is read back into value, as database triggers, such
+// as PostgreSQL's on movies, stamp their own.
+func saveUnlessStale(db *gorm.DB, value interface{}, id int, read time.Time, resource string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Session(&gorm.Session{NowFunc: concurrencyNow})
+		if !read.IsZero() {
+			var stored []time.Time
+			err := tx.Model(value).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).
+				Pluck("updated_at", &stored).Error
+			if err != nil {
+				return fmt.Errorf("failed to check %s for changes: %w", resource, err)
+			}
+			if len(stored) > 0 && !stored[0].Equal(read) {
+				return models.ConflictError{Resource: resource, ID: id, UpdatedAt: stored[0]}
+			}
+		}
+
+		if err := tx.Save(value).Error; err != nil {
+			return err
+		}
+		if err := tx.Select("updated_at").Where("id = ?", id).Take(value).Error; err != nil {
+			return fmt.Errorf("failed to read back %s updated time: %w", resource, err)
+		}
+		return nil
+	})
+}
+
+// isConflict reports whether err is a models.ConflictError
+func isConflict(err error) bool {
+	var conflict models.ConflictError
+	return errors.As(err, &conflict)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieService_UpdateIfUnchanged(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewMovieService(db, logger)
+	movie := &models.Movie{Title: "Concurrent Movie", TmdbID: 9501, TitleSlug: "concurrent-movie-9501"}
+	require.NoError(t, service.Create(movie))
+	require.NoError(t, service.Update(movie))
+
+	first, err := service.GetByID(movie.ID)
+	require.NoError(t, err)
+	second, err := service.GetByID(movie.ID)
+	require.NoError(t, err)
+	assert.True(t, first.UpdatedAt.Equal(movie.UpdatedAt), "the saved updated time is the stored one")
+
+	time.Sleep(time.Second)
+	first.Monitored = !first.Monitored
+	require.NoError(t, service.UpdateIfUnchanged(first, first.UpdatedAt))
+
+	second.Title = "Stale Title"
+	err = service.UpdateIfUnchanged(second, second.UpdatedAt)
+	var conflict models.ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.True(t, conflict.UpdatedAt.Equal(first.UpdatedAt))
+
+	stored, err := service.GetByID(movie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Concurrent Movie", stored.Title)
+
+	first.Title = "Fresh Title"
+	require.NoError(t, service.UpdateIfUnchanged(first, first.UpdatedAt))
+	second.Title = "Unchecked Title"
+	require.NoError(t, service.UpdateIfUnchanged(second, time.Time{}))
+}
+
+func TestMovieService_UpdateIfUnchanged_SavedTime(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewMovieService(db, logger)
+	created := &models.Movie{Title: "Twice Saved Movie", TmdbID: 9502, TitleSlug: "twice-saved-movie-9502"}
+	require.NoError(t, service.Create(created))
+
+	// A client saving twice sends back the updated time of the first save's response. On
+	// PostgreSQL a trigger stamps that time rather than the service.
+	movie, err := service.GetByID(created.ID)
+	require.NoError(t, err)
+	movie.Monitored = !movie.Monitored
+	require.NoError(t, service.UpdateIfUnchanged(movie, movie.UpdatedAt))
+	movie.Title = "Saved Again"
+	require.NoError(t, service.UpdateIfUnchanged(movie, movie.UpdatedAt))
+
+	stored, err := service.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Saved Again", stored.Title)
+	assert.True(t, stored.UpdatedAt.Equal(movie.UpdatedAt), "the returned updated time is the stored one")
+}
+
+func TestConflictError(t *testing.T) {
+	err := models.ConflictError{Resource: "indexer", ID: 3, UpdatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "indexer 3 was changed at 2024-05-01T12:00:00Z, after the copy being saved was read; "+
+		"reload it and retry", err.Error())
+	assert.True(t, isConflict(err))
+	assert.False(t, isConflict(errors.New("indexer 3 not found")))
+}
@@ -0,0 +1,682 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// ConfigManifestService exports an instance's settings as a declarative manifest and applies
+// manifests idempotently, so instances can be managed from settings kept in version control.
+// Applying a manifest twice changes nothing the second time.
+type ConfigManifestService struct {
+	logger        *logger.Logger
+	quality       *QualityService
+	indexers      *IndexerService
+	downloads     *DownloadService
+	importLists   *ImportListService
+	notifications *NotificationService
+	naming        *NamingService
+}
+
+// NewConfigManifestService creates a new configuration manifest service
+func NewConfigManifestService(logger *logger.Logger, quality *QualityService, indexers *IndexerService,
+	downloads *DownloadService, importLists *ImportListService, notifications *NotificationService,
+	naming *NamingService) *ConfigManifestService {
+	return &ConfigManifestService{
+		logger:        logger,
+		quality:       quality,
+		indexers:      indexers,
+		downloads:     downloads,
+		importLists:   importLists,
+		notifications: notifications,
+		naming:        naming,
+	}
+}
+
+// ConfigManifestApplyOptions control how a manifest is applied
+type ConfigManifestApplyOptions struct {
+	// DryRun reports the changes without making them
+	DryRun bool
+	// Prune deletes the settings of the manifest's sections that it doesn't list. Sections the
+	// manifest leaves out are never pruned.
+	Prune bool
+}
+
+// namingVolatileKeys are the naming settings keys that aren't settings
+var namingVolatileKeys = []string{"id", "createdAt", "updatedAt"}
+
+// configManifestSection is one list of named settings in a manifest
+type configManifestSection interface {
+	key() string
+	export(manifest *models.ConfigManifest, includeSecrets bool) error
+	validate(manifest *models.ConfigManifest, names map[string]map[string]bool) error
+	names() ([]string, error)
+	apply(manifest *models.ConfigManifest, opts ConfigManifestApplyOptions,
+		result *models.ConfigManifestApplyResult) error
+}
+
+// manifestReference is a setting an entry refers to by name
+type manifestReference struct {
+	field   string
+	section string
+	name    string
+}
+
+// manifestSection maps one kind of setting to and from its manifest entries
+type manifestSection[T any] struct {
+	section  string
+	volatile []string
+	entries  func(*models.ConfigManifest) *[]map[string]any
+	list     func() ([]*T, error)
+	create   func(*T) error
+	update   func(*T) error
+	remove   func(id int) error
+	id       func(*T) int
+	name     func(*T) string
+	// toManifest and fromManifest swap the ids of the other settings an entry refers to for their
+	// names and back, so manifests apply to instances where the ids differ
+	toManifest   func(entry map[string]any) error
+	fromManifest func(entry map[string]any) error
+	// references lists the settings a manifest entry refers to by name
+	references func(entry map[string]any) []manifestReference
+}
+
+func (m *manifestSection[T]) key() string {
+	return m.section
+}
+
+// entry converts a stored setting to its manifest entry
+func (m *manifestSection[T]) entry(setting *T) (map[string]any, error) {
+	entry, err := manifestEntry(setting, m.volatile)
+	if err != nil {
+		return nil, err
+	}
+	if m.toManifest != nil {
+		if err := m.toManifest(entry); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+func (m *manifestSection[T]) export(manifest *models.ConfigManifest, includeSecrets bool) error {
+	settings, err := m.list()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(settings, func(i, j int) bool { return m.name(settings[i]) < m.name(settings[j]) })
+
+	entries := make([]map[string]any, 0, len(settings))
+	for _, setting := range settings {
+		entry, err := m.entry(setting)
+		if err != nil {
+			return fmt.Errorf("failed to export %s %q: %w", m.section, m.name(setting), err)
+		}
+		if !includeSecrets {
+			redactManifestSecrets(entry)
+		}
+		entries = append(entries, entry)
+	}
+	*m.entries(manifest) = entries
+	return nil
+}
+
+func (m *manifestSection[T]) names() ([]string, error) {
+	settings, err := m.list()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		names = append(names, m.name(setting))
+	}
+	return names, nil
+}
+
+// validate checks every entry has a unique name and refers only to settings that exist or that
+// the manifest creates
+func (m *manifestSection[T]) validate(manifest *models.ConfigManifest, names map[string]map[string]bool) error {
+	seen := make(map[string]bool)
+	for i, entry := range *m.entries(manifest) {
+		field := fmt.Sprintf("%s[%d]", m.section, i)
+		name, _ := entry["name"].(string)
+		if name == "" {
+			return models.ValidationError{Field: field + ".name", Message: fmt.Sprintf("%s needs a name", field)}
+		}
+		if seen[name] {
+			return models.ValidationError{Field: field + ".name",
+				Message: fmt.Sprintf("%s lists %q more than once", m.section, name)}
+		}
+		seen[name] = true
+
+		if m.references == nil {
+			continue
+		}
+		for _, ref := range m.references(entry) {
+			if !names[ref.section][ref.name] {
+				return models.ValidationError{Field: field + "." + ref.field, Message: fmt.Sprintf(
+					"%s %q refers to %s %q, which neither exists nor is in the manifest", m.section, name,
+					ref.section, ref.name)}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *manifestSection[T]) apply(manifest *models.ConfigManifest, opts ConfigManifestApplyOptions,
+	result *models.ConfigManifestApplyResult) error {
+	entries := *m.entries(manifest)
+	if entries == nil {
+		return nil
+	}
+	settings, err := m.list()
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]*T, len(settings))
+	for _, setting := range settings {
+		stored[m.name(setting)] = setting
+	}
+
+	listed := make(map[string]bool, len(entries))
+	for i, desired := range entries {
+		name, _ := desired["name"].(string)
+		listed[name] = true
+
+		entry, err := normalizeManifestEntry(desired)
+		if err != nil {
+			return err
+		}
+		setting := stored[name]
+		var current map[string]any
+		if setting != nil {
+			if current, err = m.entry(setting); err != nil {
+				return err
+			}
+		}
+		for _, path := range restoreManifestSecrets(entry, current, fmt.Sprintf("%s[%d]", m.section, i)) {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s is redacted and %s %q has no stored value to keep; it was left empty",
+					path, m.section, name))
+		}
+
+		switch {
+		case setting == nil:
+			if !opts.DryRun {
+				if err := m.write(nil, entry); err != nil {
+					return fmt.Errorf("failed to create %s %q: %w", m.section, name, err)
+				}
+			}
+			result.Record(m.section, name, models.ConfigManifestCreated)
+		default:
+			merged := mergeManifestEntry(current, entry)
+			if reflect.DeepEqual(current, merged) {
+				result.Record(m.section, name, models.ConfigManifestUnchanged)
+				continue
+			}
+			if !opts.DryRun {
+				if err := m.write(setting, merged); err != nil {
+					return fmt.Errorf("failed to update %s %q: %w", m.section, name, err)
+				}
+			}
+			result.Record(m.section, name, models.ConfigManifestUpdated)
+		}
+	}
+
+	if !opts.Prune {
+		return nil
+	}
+	for _, setting := range settings {
+		name := m.name(setting)
+		if listed[name] {
+			continue
+		}
+		if !opts.DryRun {
+			if err := m.remove(m.id(setting)); err != nil {
+				return fmt.Errorf("failed to delete %s %q: %w", m.section, name, err)
+			}
+		}
+		result.Record(m.section, name, models.ConfigManifestDeleted)
+	}
+	return nil
+}
+
+// write creates a setting from its manifest entry, or updates a stored one to match it. New
+// settings are saved a second time so false and zero values the entry sets aren't left replaced
+// by the column defaults applied on insert.
+func (m *manifestSection[T]) write(setting *T, entry map[string]any) error {
+	modelEntry := make(map[string]any, len(entry))
+	for key, value := range entry {
+		modelEntry[key] = value
+	}
+	if m.fromManifest != nil {
+		if err := m.fromManifest(modelEntry); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(modelEntry)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest entry: %w", err)
+	}
+
+	if setting == nil {
+		setting = new(T)
+		if err := json.Unmarshal(data, setting); err != nil {
+			return models.ValidationError{Field: m.section, Message: fmt.Sprintf("Invalid settings: %v", err)}
+		}
+		if err := m.create(setting); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, setting); err != nil {
+		return models.ValidationError{Field: m.section, Message: fmt.Sprintf("Invalid settings: %v", err)}
+	}
+	return m.update(setting)
+}
+
+// Export builds the manifest of every managed setting. Secrets are redacted unless includeSecrets
+// is set; applying a manifest keeps the stored value of each redacted secret.
+func (s *ConfigManifestService) Export(includeSecrets bool) (*models.ConfigManifest, error) {
+	now := time.Now().UTC()
+	manifest := &models.ConfigManifest{Version: models.ConfigManifestVersion, ExportedAt: &now}
+
+	naming, err := s.namingEntry()
+	if err != nil {
+		return nil, err
+	}
+	manifest.Naming = naming
+
+	for _, section := range s.sections() {
+		if err := section.export(manifest, includeSecrets); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Apply makes the instance's settings match a manifest. Settings are matched by name: listed ones
+// are created or updated, and keep the stored values of the keys their entry leaves out. Sections
+// the manifest leaves out are left alone. The manifest is validated before anything is written,
+// but a failure while writing leaves the settings applied so far; applying again finishes the job.
+func (s *ConfigManifestService) Apply(manifest *models.ConfigManifest,
+	opts ConfigManifestApplyOptions) (*models.ConfigManifestApplyResult, error) {
+	result := &models.ConfigManifestApplyResult{
+		DryRun:   opts.DryRun,
+		Changes:  []models.ConfigManifestChange{},
+		Warnings: []string{},
+	}
+	sections := s.sections()
+
+	// Names that exist once the manifest is applied, for checking the references between settings
+	names := make(map[string]map[string]bool, len(sections))
+	for _, section := range sections {
+		entries := manifestEntries(manifest, section.key())
+		names[section.key()] = make(map[string]bool)
+		if !opts.Prune || entries == nil {
+			stored, err := section.names()
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range stored {
+				names[section.key()][name] = true
+			}
+		}
+		for _, entry := range entries {
+			if name, ok := entry["name"].(string); ok {
+				names[section.key()][name] = true
+			}
+		}
+	}
+	for _, section := range sections {
+		if err := section.validate(manifest, names); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.applyNaming(manifest, opts, result); err != nil {
+		return nil, err
+	}
+	for _, section := range sections {
+		if err := section.apply(manifest, opts, result); err != nil {
+			return nil, err
+		}
+	}
+
+	result.AppliedAt = time.Now().UTC()
+	if !opts.DryRun {
+		s.logger.Info("Applied configuration manifest", "created", result.Created, "updated", result.Updated,
+			"unchanged", result.Unchanged, "deleted", result.Deleted)
+	}
+	return result, nil
+}
+
+// manifestEntries is the entries of a manifest section by its key
+func manifestEntries(manifest *models.ConfigManifest, section string) []map[string]any {
+	switch section {
+	case "customFormats":
+		return manifest.CustomFormats
+	case "qualityProfiles":
+		return manifest.QualityProfiles
+	case "downloadClients":
+		return manifest.DownloadClients
+	case "indexers":
+		return manifest.Indexers
+	case "importLists":
+		return manifest.ImportLists
+	case "notifications":
+		return manifest.Notifications
+	}
+	return nil
+}
+
+// namingEntry is the naming settings' manifest entry
+func (s *ConfigManifestService) namingEntry() (map[string]any, error) {
+	config, err := s.naming.GetNamingConfig()
+	if err != nil {
+		return nil, err
+	}
+	return manifestEntry(config, namingVolatileKeys)
+}
+
+// applyNaming updates the naming settings when the manifest has them
+func (s *ConfigManifestService) applyNaming(manifest *models.ConfigManifest, opts ConfigManifestApplyOptions,
+	result *models.ConfigManifestApplyResult) error {
+	if manifest.Naming == nil {
+		return nil
+	}
+	current, err := s.namingEntry()
+	if err != nil {
+		return err
+	}
+	entry, err := normalizeManifestEntry(manifest.Naming)
+	if err != nil {
+		return err
+	}
+	merged := mergeManifestEntry(current, entry)
+	if reflect.DeepEqual(current, merged) {
+		result.Record("naming", "naming", models.ConfigManifestUnchanged)
+		return nil
+	}
+
+	if !opts.DryRun {
+		config, err := s.naming.GetNamingConfig()
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(merged)
+		if err != nil {
+			return fmt.Errorf("failed to encode naming settings: %w", err)
+		}
+		if err := json.Unmarshal(data, config); err != nil {
+			return models.ValidationError{Field: "naming", Message: fmt.Sprintf("Invalid naming settings: %v", err)}
+		}
+		if err := s.naming.UpdateNamingConfig(config); err != nil {
+			return err
+		}
+	}
+	result.Record("naming", "naming", models.ConfigManifestUpdated)
+	return nil
+}
+
+// sections lists the manifest's sections in the order they are applied, so settings are created
+// before the settings that refer to them
+func (s *ConfigManifestService) sections() []configManifestSection {
+	return []configManifestSection{
+		&manifestSection[models.CustomFormat]{
+			section:  "customFormats",
+			volatile: []string{"id", "added", "updated"},
+			entries:  func(m *models.ConfigManifest) *[]map[string]any { return &m.CustomFormats },
+			list:     s.quality.GetCustomFormats,
+			create:   s.quality.CreateCustomFormat,
+			update:   s.quality.UpdateCustomFormat,
+			remove:   s.quality.DeleteCustomFormat,
+			id:       func(f *models.CustomFormat) int { return f.ID },
+			name:     func(f *models.CustomFormat) string { return f.Name },
+		},
+		&manifestSection[models.QualityProfile]{
+			section:      "qualityProfiles",
+			volatile:     []string{"id", "added", "updated"},
+			entries:      func(m *models.ConfigManifest) *[]map[string]any { return &m.QualityProfiles },
+			list:         s.quality.GetQualityProfiles,
+			create:       s.quality.CreateQualityProfile,
+			update:       s.quality.UpdateQualityProfile,
+			remove:       s.quality.DeleteQualityProfile,
+			id:           func(p *models.QualityProfile) int { return p.ID },
+			name:         func(p *models.QualityProfile) string { return p.Name },
+			toManifest:   profileFormatsToManifest,
+			fromManifest: s.profileFormatsFromManifest,
+			references:   profileFormatReferences,
+		},
+		&manifestSection[models.DownloadClient]{
+			section:  "downloadClients",
+			volatile: []string{"id", "added", "updated", "lastTest"},
+			entries:  func(m *models.ConfigManifest) *[]map[string]any { return &m.DownloadClients },
+			list:     pointersTo(s.downloads.GetDownloadClients),
+			create:   s.downloads.CreateDownloadClient,
+			update:   s.downloads.UpdateDownloadClient,
+			remove:   s.downloads.DeleteDownloadClient,
+			id:       func(c *models.DownloadClient) int { return c.ID },
+			name:     func(c *models.DownloadClient) string { return c.Name },
+		},
+		&manifestSection[models.Indexer]{
+			section:  "indexers",
+			volatile: []string{"id", "added", "updated", "lastTest", "lastRssSync", "vipExpiration"},
+			entries:  func(m *models.ConfigManifest) *[]map[string]any { return &m.Indexers },
+			list:     s.indexers.GetIndexers,
+			create:   s.indexers.CreateIndexer,
+			update:   s.indexers.UpdateIndexer,
+			remove:   s.indexers.DeleteIndexer,
+			id:       func(i *models.Indexer) int { return i.ID },
+			name:     func(i *models.Indexer) string { return i.Name },
+			toManifest: func(entry map[string]any) error {
+				return s.referenceToManifest(entry, "downloadClientId", "downloadClient", s.downloadClientNames)
+			},
+			fromManifest: func(entry map[string]any) error {
+				return s.referenceFromManifest(entry, "downloadClient", "downloadClientId", s.downloadClientNames)
+			},
+			references: func(entry map[string]any) []manifestReference {
+				return namedReference(entry, "downloadClient", "downloadClients")
+			},
+		},
+		&manifestSection[models.ImportList]{
+			section:  "importLists",
+			volatile: []string{"id", "createdAt", "updatedAt", "lastTest", "lastFetch", "lastSync"},
+			entries:  func(m *models.ConfigManifest) *[]map[string]any { return &m.ImportLists },
+			list:     pointersTo(s.importLists.GetImportLists),
+			create:   s.importLists.CreateImportList,
+			update:   s.importLists.UpdateImportList,
+			remove:   s.importLists.DeleteImportList,
+			id:       func(l *models.ImportList) int { return l.ID },
+			name:     func(l *models.ImportList) string { return l.Name },
+			toManifest: func(entry map[string]any) error {
+				return s.referenceToManifest(entry, "qualityProfileId", "qualityProfile", s.qualityProfileNames)
+			},
+			fromManifest: func(entry map[string]any) error {
+				return s.referenceFromManifest(entry, "qualityProfile", "qualityProfileId", s.qualityProfileNames)
+			},
+			references: func(entry map[string]any) []manifestReference {
+				return namedReference(entry, "qualityProfile", "qualityProfiles")
+			},
+		},
+		&manifestSection[models.Notification]{
+			section:  "notifications",
+			volatile: notificationVolatileKeys,
+			entries:  func(m *models.ConfigManifest) *[]map[string]any { return &m.Notifications },
+			list:     pointersTo(s.notifications.GetNotifications),
+			create:   s.notifications.CreateNotification,
+			update:   s.notifications.UpdateNotification,
+			remove:   s.notifications.DeleteNotification,
+			id:       func(n *models.Notification) int { return n.ID },
+			name:     func(n *models.Notification) string { return n.Name },
+		},
+	}
+}
+
+// notificationVolatileKeys are a notification's keys that aren't settings: the provider's
+// capabilities are set from its implementation
+var notificationVolatileKeys = []string{
+	"id", "createdAt", "updatedAt", "supportsOnGrab", "supportsOnDownload", "supportsOnUpgrade", "supportsOnRename",
+	"supportsOnMovieAdded", "supportsOnMovieDelete", "supportsOnMovieFileDelete", "supportsOnHealthIssue",
+	"supportsOnApplicationUpdate", "supportsOnManualInteractionRequired",
+}
+
+// pointersTo adapts a getter of settings by value to return pointers to them
+func pointersTo[T any](get func() ([]T, error)) func() ([]*T, error) {
+	return func() ([]*T, error) {
+		settings, err := get()
+		if err != nil {
+			return nil, err
+		}
+		pointers := make([]*T, len(settings))
+		for i := range settings {
+			pointers[i] = &settings[i]
+		}
+		return pointers, nil
+	}
+}
+
+// downloadClientNames maps download client ids to names
+func (s *ConfigManifestService) downloadClientNames() (map[int]string, error) {
+	clients, err := s.downloads.GetDownloadClients()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(clients))
+	for _, client := range clients {
+		names[client.ID] = client.Name
+	}
+	return names, nil
+}
+
+// qualityProfileNames maps quality profile ids to names
+func (s *ConfigManifestService) qualityProfileNames() (map[int]string, error) {
+	profiles, err := s.quality.GetQualityProfiles()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(profiles))
+	for _, profile := range profiles {
+		names[profile.ID] = profile.Name
+	}
+	return names, nil
+}
+
+// referenceToManifest replaces the id of a setting an entry refers to with the setting's name
+func (s *ConfigManifestService) referenceToManifest(entry map[string]any, idKey, nameKey string,
+	names func() (map[int]string, error)) error {
+	id, ok := entry[idKey].(int64)
+	delete(entry, idKey)
+	if !ok || id == 0 {
+		return nil
+	}
+	byID, err := names()
+	if err != nil {
+		return err
+	}
+	if name, ok := byID[int(id)]; ok {
+		entry[nameKey] = name
+	}
+	return nil
+}
+
+// referenceFromManifest replaces the name of a setting an entry refers to with the setting's id
+func (s *ConfigManifestService) referenceFromManifest(entry map[string]any, nameKey, idKey string,
+	names func() (map[int]string, error)) error {
+	name, _ := entry[nameKey].(string)
+	delete(entry, nameKey)
+	if name == "" {
+		entry[idKey] = nil
+		return nil
+	}
+	byID, err := names()
+	if err != nil {
+		return err
+	}
+	for id, candidate := range byID {
+		if candidate == name {
+			entry[idKey] = id
+			return nil
+		}
+	}
+	return models.ValidationError{Field: nameKey, Message: fmt.Sprintf("%q doesn't exist", name)}
+}
+
+// namedReference is the reference an entry makes by name in the given key, if any
+func namedReference(entry map[string]any, key, section string) []manifestReference {
+	name, _ := entry[key].(string)
+	if name == "" {
+		return nil
+	}
+	return []manifestReference{{field: key, section: section, name: name}}
+}
+
+// profileFormatsToManifest replaces the custom formats embedded in a profile's format scores with
+// the formats' names
+func profileFormatsToManifest(entry map[string]any) error {
+	items, _ := entry["formatItems"].([]any)
+	formats := make([]any, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := m["name"].(string)
+		if format, ok := m["format"].(map[string]any); ok {
+			if formatName, ok := format["name"].(string); ok {
+				name = formatName
+			}
+		}
+		formats = append(formats, map[string]any{"format": name, "score": m["score"]})
+	}
+	entry["formatItems"] = formats
+	return nil
+}
+
+// profileFormatsFromManifest resolves the custom formats a profile's format scores name
+func (s *ConfigManifestService) profileFormatsFromManifest(entry map[string]any) error {
+	items, _ := entry["formatItems"].([]any)
+	if len(items) == 0 {
+		return nil
+	}
+	formats, err := s.quality.GetCustomFormats()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]*models.CustomFormat, len(formats))
+	for _, format := range formats {
+		byName[format.Name] = format
+	}
+
+	resolved := make(models.CustomFormatItems, 0, len(items))
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		name, _ := m["format"].(string)
+		format, ok := byName[name]
+		if !ok {
+			return models.ValidationError{Field: "formatItems",
+				Message: fmt.Sprintf("Custom format %q doesn't exist", name)}
+		}
+		score, _ := m["score"].(int64)
+		resolved = append(resolved, &models.CustomFormatItem{Format: format, Name: name, Score: int(score)})
+	}
+	entry["formatItems"] = resolved
+	return nil
+}
+
+// profileFormatReferences lists the custom formats a profile's format scores name
+func profileFormatReferences(entry map[string]any) []manifestReference {
+	items, _ := entry["formatItems"].([]any)
+	refs := make([]manifestReference, 0, len(items))
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		name, _ := m["format"].(string)
+		refs = append(refs, manifestReference{field: "formatItems", section: "customFormats", name: name})
+	}
+	return refs
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeConfigManifest_RoundTrip(t *testing.T) {
+	manifest := &models.ConfigManifest{
+		Version: models.ConfigManifestVersion,
+		Naming:  map[string]any{"renameMovies": true, "standardMovieFormat": "{Movie Title} ({Release Year})"},
+		Indexers: []map[string]any{{
+			"priority":       int64(25),
+			"name":           "NZBgeek",
+			"implementation": "newznab",
+			"categories":     "2000,2040",
+			"tags":           []any{},
+			"downloadClient": "SABnzbd",
+		}},
+	}
+
+	data, err := EncodeConfigManifest(manifest)
+	require.NoError(t, err)
+	yaml := string(data)
+	assert.True(t, strings.HasPrefix(yaml, "version: 1\nnaming:\n"), yaml)
+	assert.Less(t, strings.Index(yaml, "name: NZBgeek"), strings.Index(yaml, "implementation: newznab"))
+	assert.Less(t, strings.Index(yaml, "implementation: newznab"), strings.Index(yaml, "categories:"))
+	assert.NotContains(t, yaml, "qualityProfiles", "sections not exported are left out")
+
+	decoded, err := DecodeConfigManifest(data)
+	require.NoError(t, err)
+	require.Len(t, decoded.Indexers, 1)
+	entry, err := normalizeManifestEntry(decoded.Indexers[0])
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Indexers[0], entry)
+}
+
+func TestDecodeConfigManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		field    string
+	}{
+		{"empty", "", "manifest"},
+		{"misspelled section", "version: 1\nindexer:\n  - name: NZBgeek\n", "manifest"},
+		{"newer version", "version: 2\n", "version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeConfigManifest([]byte(tt.manifest))
+			var validationErr models.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	manifest, err := DecodeConfigManifest([]byte(`{"indexers": [{"name": "NZBgeek", "priority": 10}]}`))
+	require.NoError(t, err, "JSON manifests are YAML too")
+	assert.Equal(t, "NZBgeek", manifest.Indexers[0]["name"])
+}
+
+func TestManifestSecrets(t *testing.T) {
+	stored := map[string]any{
+		"name":     "Pushover",
+		"apiKey":   "stored-key",
+		"password": "",
+		"fields": []any{
+			map[string]any{"name": "appToken", "value": "stored-user"},
+			map[string]any{"name": "priority", "value": int64(1)},
+		},
+	}
+	entry := map[string]any{
+		"name":     "Pushover",
+		"apiKey":   "stored-key",
+		"password": "",
+		"fields": []any{
+			map[string]any{"name": "priority", "value": int64(2)},
+			map[string]any{"name": "appToken", "value": "stored-user"},
+			map[string]any{"name": "token", "value": "new-token"},
+		},
+	}
+
+	redactManifestSecrets(entry)
+	assert.Equal(t, config.RedactedValue, entry["apiKey"])
+	assert.Equal(t, "", entry["password"], "unset secrets stay visibly unset")
+	fields := entry["fields"].([]any)
+	assert.Equal(t, int64(2), fields[0].(map[string]any)["value"])
+	assert.Equal(t, config.RedactedValue, fields[1].(map[string]any)["value"])
+	assert.Equal(t, config.RedactedValue, fields[2].(map[string]any)["value"])
+
+	missing := restoreManifestSecrets(entry, stored, "notifications[0]")
+	assert.Equal(t, []string{"notifications[0].fields[2].value"}, missing)
+	assert.Equal(t, "stored-key", entry["apiKey"])
+	assert.Equal(t, "stored-user", fields[1].(map[string]any)["value"], "fields are matched by name")
+	assert.Equal(t, "", fields[2].(map[string]any)["value"])
+}
+
+func TestMergeManifestEntry(t *testing.T) {
+	stored := map[string]any{
+		"name":     "Trakt",
+		"enabled":  true,
+		"tags":     []any{int64(1), int64(2)},
+		"settings": map[string]any{"listId": "watchlist", "limit": int64(100)},
+	}
+	desired := map[string]any{
+		"name":     "Trakt",
+		"tags":     []any{int64(3)},
+		"settings": map[string]any{"limit": int64(50)},
+	}
+
+	merged := mergeManifestEntry(stored, desired)
+	assert.Equal(t, map[string]any{
+		"name":     "Trakt",
+		"enabled":  true,
+		"tags":     []any{int64(3)},
+		"settings": map[string]any{"listId": "watchlist", "limit": int64(50)},
+	}, merged)
+	assert.Equal(t, int64(100), stored["settings"].(map[string]any)["limit"], "the stored entry is left alone")
+}
+
+func TestConfigManifestService_Apply(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	quality := NewQualityService(db, logger)
+	downloads := NewDownloadService(db, logger)
+	service := NewConfigManifestService(logger, quality, NewIndexerService(db, logger), downloads,
+		NewImportListService(db, logger, nil, nil), NewNotificationService(db, config.NotificationConfig{}, logger),
+		NewNamingService(db, logger))
+
+	manifest, err := DecodeConfigManifest([]byte(`
+version: 1
+downloadClients:
+  - name: SABnzbd
+    implementation: sabnzbd
+    protocol: usenet
+    host: sabnzbd
+    port: 8080
+    apiKey: secret
+    enable: false
+indexers:
+  - name: NZBgeek
+    implementation: newznab
+    baseUrl: https://api.nzbgeek.info/api
+    apiKey: indexer-secret
+    categories: "2000,2040"
+    enableRss: false
+    downloadClient: SABnzbd
+`))
+	require.NoError(t, err)
+
+	result, err := service.Apply(manifest, ConfigManifestApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	clients, err := downloads.GetDownloadClients()
+	require.NoError(t, err)
+	assert.Empty(t, clients, "dry runs write nothing")
+
+	result, err = service.Apply(manifest, ConfigManifestApplyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	clients, err = downloads.GetDownloadClients()
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	assert.False(t, clients[0].Enable, "false values aren't replaced by column defaults")
+	assert.Equal(t, "secret", clients[0].APIKey)
+
+	result, err = service.Apply(manifest, ConfigManifestApplyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Unchanged, "applying again changes nothing")
+	assert.Zero(t, result.Created+result.Updated)
+
+	exported, err := service.Export(false)
+	require.NoError(t, err)
+	require.Len(t, exported.Indexers, 1)
+	assert.Equal(t, "SABnzbd", exported.Indexers[0]["downloadClient"])
+	assert.Equal(t, config.RedactedValue, exported.Indexers[0]["apiKey"])
+
+	exported.Indexers[0]["priority"] = int64(10)
+	exported.DownloadClients = []map[string]any{}
+	_, err = service.Apply(exported, ConfigManifestApplyOptions{Prune: true})
+	var validationErr models.ValidationError
+	require.ErrorAs(t, err, &validationErr, "the indexer refers to the client being pruned")
+	assert.Equal(t, "indexers[0].downloadClient", validationErr.Field)
+
+	exported.Indexers[0]["downloadClient"] = nil
+	result, err = service.Apply(exported, ConfigManifestApplyOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Empty(t, result.Warnings)
+
+	indexers, err := service.indexers.GetIndexers()
+	require.NoError(t, err)
+	require.Len(t, indexers, 1)
+	assert.Equal(t, 10, indexers[0].Priority)
+	assert.Equal(t, "indexer-secret", indexers[0].APIKey, "redacted secrets keep their stored value")
+}
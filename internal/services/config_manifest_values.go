@@ -0,0 +1,319 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// manifestLeadingKeys are written first in manifest entries, so each entry starts with what
+// identifies it; the remaining keys follow alphabetically
+var manifestLeadingKeys = []string{"name", "implementation"}
+
+// manifestEntry converts a setting to its manifest entry: its API JSON form without the given
+// volatile keys, such as ids and timestamps
+func manifestEntry(setting any, volatile []string) (map[string]any, error) {
+	data, err := json.Marshal(setting)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setting: %w", err)
+	}
+	entry, err := decodeManifestJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range volatile {
+		delete(entry, key)
+	}
+	return entry, nil
+}
+
+// normalizeManifestEntry puts an entry read from a manifest in the form exported entries have, so
+// the two compare equal when they hold the same settings
+func normalizeManifestEntry(entry map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest entry: %w", err)
+	}
+	return decodeManifestJSON(data)
+}
+
+// decodeManifestJSON decodes a JSON object keeping integers as int64 rather than float64
+func decodeManifestJSON(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var entry map[string]any
+	if err := decoder.Decode(&entry); err != nil {
+		return nil, fmt.Errorf("failed to decode setting: %w", err)
+	}
+	if entry == nil {
+		entry = map[string]any{}
+	}
+	normalizeManifestNumbers(entry)
+	return entry, nil
+}
+
+// normalizeManifestNumbers replaces the JSON numbers in a decoded value with int64 or float64
+func normalizeManifestNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeManifestNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeManifestNumbers(item)
+		}
+	}
+	return value
+}
+
+// redactManifestSecrets replaces the values of secret settings, both keys named like secrets and
+// the values of named fields, as in provider field lists, whose name is a secret's
+func redactManifestSecrets(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		fieldName, _ := v["name"].(string)
+		for key, item := range v {
+			secret := config.IsSecretName(key) || (key == "value" && config.IsSecretName(fieldName))
+			if s, ok := item.(string); ok && secret && s != "" {
+				v[key] = config.RedactedValue
+				continue
+			}
+			redactManifestSecrets(item)
+		}
+	case []any:
+		for _, item := range v {
+			redactManifestSecrets(item)
+		}
+	}
+}
+
+// restoreManifestSecrets puts the stored values back in place of the secrets a manifest left
+// redacted. Redacted secrets with no stored value are cleared and their paths returned.
+func restoreManifestSecrets(desired, stored any, path string) []string {
+	var missing []string
+	switch d := desired.(type) {
+	case map[string]any:
+		s, _ := stored.(map[string]any)
+		for key, item := range d {
+			if item == config.RedactedValue {
+				if value, ok := s[key]; ok && value != "" {
+					d[key] = value
+				} else {
+					d[key] = ""
+					missing = append(missing, path+"."+key)
+				}
+				continue
+			}
+			missing = append(missing, restoreManifestSecrets(item, s[key], path+"."+key)...)
+		}
+	case []any:
+		s, _ := stored.([]any)
+		for i, item := range d {
+			// Named fields are matched by name, so reordering a field list keeps its secrets
+			var match any
+			if name := manifestItemName(item); name != "" {
+				for _, candidate := range s {
+					if manifestItemName(candidate) == name {
+						match = candidate
+						break
+					}
+				}
+			} else if i < len(s) {
+				match = s[i]
+			}
+			missing = append(missing, restoreManifestSecrets(item, match, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return missing
+}
+
+// manifestItemName is the name of a list item that is an object with a name
+func manifestItemName(item any) string {
+	if m, ok := item.(map[string]any); ok {
+		name, _ := m["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// mergeManifestEntry overlays a manifest entry on a stored setting's entry: settings the manifest
+// leaves out keep their stored values, nested objects are merged key by key and lists replaced
+func mergeManifestEntry(stored, desired map[string]any) map[string]any {
+	merged := make(map[string]any, len(stored)+len(desired))
+	for key, value := range stored {
+		merged[key] = value
+	}
+	for key, value := range desired {
+		if desiredMap, ok := value.(map[string]any); ok {
+			if storedMap, ok := merged[key].(map[string]any); ok {
+				merged[key] = mergeManifestEntry(storedMap, desiredMap)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// EncodeConfigManifest writes a manifest as YAML, with sections in the order they are applied
+func EncodeConfigManifest(manifest *models.ConfigManifest) ([]byte, error) {
+	document := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(key string, value any) error {
+		keyNode := &yaml.Node{}
+		if err := keyNode.Encode(key); err != nil {
+			return err
+		}
+		valueNode, err := manifestNode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		document.Content = append(document.Content, keyNode, valueNode)
+		return nil
+	}
+
+	if err := add("version", manifest.Version); err != nil {
+		return nil, err
+	}
+	if manifest.ExportedAt != nil {
+		if err := add("exportedAt", manifest.ExportedAt.UTC()); err != nil {
+			return nil, err
+		}
+	}
+	if manifest.Naming != nil {
+		if err := add("naming", manifest.Naming); err != nil {
+			return nil, err
+		}
+	}
+	sections := []struct {
+		key     string
+		entries []map[string]any
+	}{
+		{"customFormats", manifest.CustomFormats},
+		{"qualityProfiles", manifest.QualityProfiles},
+		{"downloadClients", manifest.DownloadClients},
+		{"indexers", manifest.Indexers},
+		{"importLists", manifest.ImportLists},
+		{"notifications", manifest.Notifications},
+	}
+	for _, section := range sections {
+		if section.entries == nil {
+			continue
+		}
+		if err := add(section.key, section.entries); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// manifestNode builds the YAML node of a manifest value, ordering object keys
+func manifestNode(value any) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range manifestKeys(v) {
+			keyNode := &yaml.Node{}
+			if err := keyNode.Encode(key); err != nil {
+				return nil, err
+			}
+			valueNode, err := manifestNode(v[key])
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, keyNode, valueNode)
+		}
+		return node, nil
+	case []map[string]any:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			itemNode, err := manifestNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, itemNode)
+		}
+		return node, nil
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			itemNode, err := manifestNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, itemNode)
+		}
+		return node, nil
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+}
+
+// manifestKeys orders the keys of a manifest object: leading keys first, then alphabetically
+func manifestKeys(entry map[string]any) []string {
+	keys := make([]string, 0, len(entry))
+	for _, key := range manifestLeadingKeys {
+		if _, ok := entry[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	rest := make([]string, 0, len(entry))
+	for key := range entry {
+		leading := false
+		for _, lead := range manifestLeadingKeys {
+			leading = leading || key == lead
+		}
+		if !leading {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// DecodeConfigManifest reads a YAML manifest. JSON manifests are read too, JSON being YAML.
+// Unknown sections are rejected so a misspelled section isn't silently left unmanaged.
+func DecodeConfigManifest(data []byte) (*models.ConfigManifest, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var manifest models.ConfigManifest
+	if err := decoder.Decode(&manifest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, models.ValidationError{Field: "manifest", Message: "The manifest is empty"}
+		}
+		return nil, models.ValidationError{Field: "manifest", Message: fmt.Sprintf("Invalid manifest: %v", err)}
+	}
+	if manifest.Version > models.ConfigManifestVersion {
+		return nil, models.ValidationError{Field: "version", Message: fmt.Sprintf(
+			"Manifest version %d is newer than the supported version %d", manifest.Version,
+			models.ConfigManifestVersion)}
+	}
+	return &manifest, nil
+}
@@ -1,12 +1,23 @@
 package services
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/radarr/radarr-go/internal/blobstore"
+	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 	"gorm.io/gorm"
@@ -17,6 +28,13 @@ type ConfigService struct {
 	db       *database.Database
 	logger   *logger.Logger
 	services *Container
+
+	// backupStore keeps the archives of configuration backups, when set
+	backupStore blobstore.Store
+
+	// pendingRestart lists saved host settings that need a restart to take effect
+	mu             sync.RWMutex
+	pendingRestart []string
 }
 
 // NewConfigService creates a new instance of ConfigService
@@ -33,6 +51,12 @@ func (s *ConfigService) SetServiceContainer(services *Container) {
 	s.services = services
 }
 
+// UseBackupStore makes configuration backups keep an archive in store, which can be listed and
+// downloaded later
+func (s *ConfigService) UseBackupStore(store blobstore.Store) {
+	s.backupStore = store
+}
+
 // Host Configuration Management
 
 // GetHostConfig retrieves the host configuration
@@ -54,11 +78,140 @@ func (s *ConfigService) GetHostConfig() (*models.HostConfig, error) {
 	return &config, nil
 }
 
-// UpdateHostConfig updates the host configuration
+// instanceName returns the instance name from the host configuration, which tells notifications
+// and calendars from several instances apart, or the default name when it cannot be loaded
+func instanceName(db *database.Database) string {
+	if db == nil {
+		return models.DefaultInstanceName
+	}
+
+	var config models.HostConfig
+	if err := db.GORM.Select("instance_name").First(&config).Error; err != nil {
+		return models.DefaultInstanceName
+	}
+	return config.Instance()
+}
+
+// UpdateHostConfig updates the host configuration. Changes to settings that are only read at
+// startup are recorded so a pending restart can be reported.
 func (s *ConfigService) UpdateHostConfig(config *models.HostConfig) error {
-	return s.updateConfig(config, "host config", func() []string {
+	previous, previousErr := s.GetHostConfig()
+
+	if err := s.updateConfig(config, "host config", func() []string {
 		return config.ValidateConfiguration()
-	})
+	}); err != nil {
+		return err
+	}
+
+	s.applyProxySettings(config)
+
+	if previousErr == nil {
+		s.markRestartRequired(config.RestartRequiredChanges(previous))
+	}
+	return nil
+}
+
+// SetCredentials sets the username and password of the host's authentication. Only those two
+// settings are written, so credentials can be reset on hosts whose other settings don't validate.
+func (s *ConfigService) SetCredentials(username, password string) error {
+	if username == "" {
+		return models.ValidationError{Field: "username", Message: "Username is required"}
+	}
+	if password == "" {
+		return models.ValidationError{Field: "password", Message: "Password is required"}
+	}
+
+	config, err := s.GetHostConfig()
+	if err != nil {
+		return err
+	}
+	config.Username, config.Password = username, password
+	if config.ID == 0 {
+		err = s.db.GORM.Create(config).Error
+	} else {
+		err = s.db.GORM.Model(&models.HostConfig{}).Where("id = ?", config.ID).
+			Updates(map[string]interface{}{"username": username, "password": password}).Error
+	}
+	if err != nil {
+		s.logger.Error("Failed to set credentials", "error", err)
+		return fmt.Errorf("failed to set credentials: %w", err)
+	}
+
+	s.logger.Info("Updated authentication credentials", "username", username)
+	return nil
+}
+
+// PendingRestart returns the host settings saved since startup that need a restart to take effect
+func (s *ConfigService) PendingRestart() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string(nil), s.pendingRestart...)
+}
+
+// markRestartRequired adds settings to the pending restart list
+func (s *ConfigService) markRestartRequired(settings []string) {
+	if len(settings) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, setting := range settings {
+		if !slices.Contains(s.pendingRestart, setting) {
+			s.pendingRestart = append(s.pendingRestart, setting)
+		}
+	}
+	s.logger.Warn("Host configuration changes require a restart", "settings", settings)
+}
+
+// ApplyProxySettings loads the stored host configuration and applies its proxy settings
+// to all outbound HTTP clients
+func (s *ConfigService) ApplyProxySettings() {
+	if s.db == nil {
+		return
+	}
+
+	hostConfig, err := s.GetHostConfig()
+	if err != nil {
+		s.logger.Warn("Failed to load host config for proxy settings", "error", err)
+		return
+	}
+
+	s.applyProxySettings(hostConfig)
+}
+
+// applyProxySettings configures the shared HTTP transport from host config, falling back
+// to the config file proxy when the host config has no proxy
+func (s *ConfigService) applyProxySettings(hostConfig *models.HostConfig) {
+	var proxyConfig config.ProxyConfig
+	if s.services != nil && s.services.Config != nil {
+		proxyConfig = s.services.Config.Proxy
+	}
+
+	if hostConfig.HasProxyConfigured() {
+		proxy := hostConfig.ProxySettings
+		proxyConfig = config.ProxyConfig{
+			Enabled:              true,
+			Type:                 string(proxy.Type),
+			Hostname:             proxy.Hostname,
+			Port:                 proxy.Port,
+			Username:             proxy.Username,
+			Password:             proxy.Password,
+			BypassFilter:         proxy.BypassFilter,
+			BypassLocalAddresses: proxy.BypassLocal,
+		}
+	}
+
+	if err := httpclient.Configure(proxyConfig); err != nil {
+		s.logger.Error("Failed to apply proxy settings", "error", err)
+		return
+	}
+
+	if proxyURL := httpclient.ProxyURL(); proxyURL != nil {
+		s.logger.Info("Outbound proxy configured", "type", proxyURL.Scheme, "host", proxyURL.Host)
+	}
 }
 
 // Naming Configuration Management
@@ -122,6 +275,47 @@ func (s *ConfigService) UpdateMediaManagementConfig(config *models.MediaManageme
 	})
 }
 
+// UI Configuration
+
+// GetUIConfig retrieves the UI configuration
+func (s *ConfigService) GetUIConfig() (*models.UIConfig, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var config models.UIConfig
+	if err := s.db.GORM.First(&config).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			// Return default configuration
+			return models.GetDefaultUIConfig(), nil
+		}
+		s.logger.Error("Failed to fetch UI config", "error", err)
+		return nil, fmt.Errorf("failed to fetch UI config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// UpdateUIConfig updates the UI configuration, which is always the single row with id 1
+func (s *ConfigService) UpdateUIConfig(config *models.UIConfig) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	if errors := config.ValidateConfiguration(); len(errors) > 0 {
+		return fmt.Errorf("validation failed: %v", errors)
+	}
+
+	config.ID = 1
+	if err := s.db.GORM.Save(config).Error; err != nil {
+		s.logger.Error("Failed to update UI config", "error", err)
+		return fmt.Errorf("failed to update UI config: %w", err)
+	}
+
+	s.logger.Info("Updated UI configuration")
+	return nil
+}
+
 // Root Folder Management
 
 // GetRootFolders retrieves all root folders
@@ -462,11 +656,13 @@ func (s *ConfigService) CreateConfigurationBackup(name, description string) (*mo
 	namingConfig, _ := s.GetNamingConfig()         //nolint:errcheck // Backup uses available config
 	mediaConfig, _ := s.GetMediaManagementConfig() //nolint:errcheck // Backup uses available config
 	appSettings, _ := s.GetAppSettings()           //nolint:errcheck // Backup uses available config
+	uiConfig, _ := s.GetUIConfig()                 //nolint:errcheck // Backup uses available config
 
 	backup.HostConfig = hostConfig
 	backup.NamingConfig = namingConfig
 	backup.MediaManagementConfig = mediaConfig
 	backup.AppSettings = appSettings
+	backup.UIConfig = uiConfig
 
 	// Get additional configurations if available
 	if s.services != nil {
@@ -486,10 +682,88 @@ func (s *ConfigService) CreateConfigurationBackup(name, description string) (*mo
 	// Calculate backup statistics
 	backup.ConfigurationCount = s.countConfigurations(backup)
 
+	if err := s.archiveConfigurationBackup(backup); err != nil {
+		return nil, err
+	}
+
 	s.logger.Info("Created configuration backup", "name", name)
 	return backup, nil
 }
 
+// archiveConfigurationBackup writes a backup to the backup store, recording its archive name and
+// size on the backup
+func (s *ConfigService) archiveConfigurationBackup(backup *models.ConfigurationBackup) error {
+	if s.backupStore == nil {
+		return nil
+	}
+
+	backup.ArchiveName = fmt.Sprintf("config-backup-%s-%s.json",
+		backup.CreatedAt.UTC().Format("20060102-150405"), archiveNameSlug(backup.BackupName))
+	data, err := json.Marshal(backup)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration backup: %w", err)
+	}
+	backup.BackupSize = int64(len(data))
+
+	if err := s.backupStore.Put(context.Background(), backup.ArchiveName, bytes.NewReader(data),
+		int64(len(data))); err != nil {
+		s.logger.Error("Failed to store configuration backup", "archive", backup.ArchiveName, "error", err)
+		return fmt.Errorf("failed to store configuration backup: %w", err)
+	}
+	return nil
+}
+
+// ListConfigurationBackupArchives returns the stored configuration backup archives, newest first
+func (s *ConfigService) ListConfigurationBackupArchives(ctx context.Context) ([]blobstore.Object, error) {
+	if s.backupStore == nil {
+		return []blobstore.Object{}, nil
+	}
+
+	archives, err := s.backupStore.List(ctx, configBackupArchivePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configuration backups: %w", err)
+	}
+	slices.Reverse(archives)
+	return archives, nil
+}
+
+// GetConfigurationBackupArchive opens a stored configuration backup archive. The caller closes it.
+func (s *ConfigService) GetConfigurationBackupArchive(ctx context.Context, name string) (io.ReadCloser, error) {
+	if s.backupStore == nil || !strings.HasPrefix(name, configBackupArchivePrefix) || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("configuration backup not found")
+	}
+
+	archive, err := s.backupStore.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, fmt.Errorf("configuration backup not found")
+		}
+		return nil, fmt.Errorf("failed to open configuration backup: %w", err)
+	}
+	return archive, nil
+}
+
+// configBackupArchivePrefix starts the names of configuration backup archives
+const configBackupArchivePrefix = "config-backup-"
+
+// archiveNameSlug reduces a backup name to the letters, digits and dashes used in archive names
+func archiveNameSlug(name string) string {
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, name), "-")
+	if slug == "" {
+		return "backup"
+	}
+	return slug
+}
+
 // RestoreConfigurationBackup restores configuration from a backup
 func (s *ConfigService) RestoreConfigurationBackup(
 	backup *models.ConfigurationBackup, components []string,
@@ -561,6 +835,15 @@ func (s *ConfigService) restoreConfigurationComponent(
 				result.ImportedComponents = append(result.ImportedComponents, "app")
 			}
 		}
+	case "ui":
+		if backup.UIConfig != nil {
+			if err := s.UpdateUIConfig(backup.UIConfig); err != nil {
+				result.ValidationErrors["ui"] = []string{err.Error()}
+				result.Success = false
+			} else {
+				result.ImportedComponents = append(result.ImportedComponents, "ui")
+			}
+		}
 	default:
 		result.SkippedComponents = append(result.SkippedComponents, component)
 	}
@@ -594,6 +877,10 @@ func (s *ConfigService) FactoryResetConfiguration(components []string) error {
 			if err := s.UpdateAppSettings(defaultSettings); err != nil {
 				return fmt.Errorf("failed to reset app settings: %w", err)
 			}
+		case "ui":
+			if err := s.UpdateUIConfig(models.GetDefaultUIConfig()); err != nil {
+				return fmt.Errorf("failed to reset UI config: %w", err)
+			}
 		}
 	}
 
@@ -618,6 +905,7 @@ func (s *ConfigService) ValidateAllConfigurations() (*models.ConfigurationValida
 	s.validateNamingConfig(result)
 	s.validateMediaManagementConfig(result)
 	s.validateAppSettings(result)
+	s.validateUIConfig(result)
 	s.validateRootFolders(result)
 
 	return result, nil
@@ -675,6 +963,19 @@ func (s *ConfigService) validateAppSettings(result *models.ConfigurationValidati
 	}
 }
 
+// validateUIConfig validates UI configuration
+func (s *ConfigService) validateUIConfig(result *models.ConfigurationValidationResult) {
+	if uiConfig, err := s.GetUIConfig(); err == nil {
+		if errors := uiConfig.ValidateConfiguration(); len(errors) > 0 {
+			result.ValidationErrors["ui"] = errors
+			result.ComponentStatus["ui"] = models.ConfigurationStatusError
+			result.IsValid = false
+		} else {
+			result.ComponentStatus["ui"] = models.ConfigurationStatusOK
+		}
+	}
+}
+
 // validateRootFolders validates root folders
 func (s *ConfigService) validateRootFolders(result *models.ConfigurationValidationResult) {
 	if rootFolders, err := s.GetRootFolders(); err == nil {
@@ -742,6 +1043,9 @@ func (s *ConfigService) countConfigurations(backup *models.ConfigurationBackup)
 	if backup.AppSettings != nil {
 		count++
 	}
+	if backup.UIConfig != nil {
+		count++
+	}
 	count += len(backup.QualityProfiles)
 	count += len(backup.RootFolders)
 	return count
@@ -2,9 +2,16 @@
 package services
 
 import (
+	"context"
+	"path/filepath"
+
+	"github.com/radarr/radarr-go/internal/blobstore"
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/secrets"
 )
 
 // Container holds all services and their dependencies for dependency injection
@@ -13,33 +20,67 @@ type Container struct {
 	Config *config.Config
 	Logger *logger.Logger
 
+	// WorkerLimits are the effective worker pool sizes for this host
+	WorkerLimits WorkerLimits
+
+	// Readiness tracks the startup stages reported by the readiness probe
+	Readiness *Readiness
+
+	// LeaderElector decides which instance sharing the database runs scheduled tasks
+	LeaderElector *LeaderElector
+
+	// ReadOnly is the maintenance toggle that refuses API changes and pauses background tasks
+	ReadOnly *ReadOnlyMode
+
+	// OutboxDispatcher delivers the notification events grabs and imports write to the outbox
+	OutboxDispatcher *OutboxDispatcher
+
+	// IdempotencyService replays responses to retried requests that carry an Idempotency-Key
+	IdempotencyService *IdempotencyService
+
+	// BackupStore keeps configuration backup archives on disk or in an S3-compatible bucket
+	BackupStore blobstore.Store
+
 	// Services
-	MovieService        *MovieService
-	MovieFileService    *MovieFileService
-	QualityService      *QualityService
-	IndexerService      *IndexerService
-	DownloadService     *DownloadService
-	NotificationService *NotificationService
-	MetadataService     *MetadataService
-	QueueService        *QueueService
-	ImportListService   *ImportListService
-	HistoryService      *HistoryService
-	ConfigService       *ConfigService
-	SearchService       *SearchService
-	TaskService         *TaskService
-	WantedMoviesService *WantedMoviesService
+	MovieService             *MovieService
+	MovieFileService         *MovieFileService
+	MovieVersionService      *MovieVersionService
+	QualityService           *QualityService
+	IndexerService           *IndexerService
+	DownloadService          *DownloadService
+	SeedingService           *SeedingService
+	NotificationService      *NotificationService
+	MetadataService          *MetadataService
+	QueueService             *QueueService
+	ImportListService        *ImportListService
+	HistoryService           *HistoryService
+	ConfigService            *ConfigService
+	ConfigManifestService    *ConfigManifestService
+	SearchService            *SearchService
+	RetentionService         *RetentionService
+	TaskService              *TaskService
+	WantedMoviesService      *WantedMoviesService
+	CustomFilterService      *CustomFilterService
+	RemotePathMappingService *RemotePathMappingService
+	SceneMappingService      *SceneMappingService
+	MediaCoverService        *MediaCoverService
 
 	// File management services
-	NamingService           *NamingService
-	MediaInfoService        *MediaInfoService
-	FileOrganizationService *FileOrganizationService
-	ImportService           *ImportService
-	FileOperationService    *FileOperationService
+	NamingService             *NamingService
+	MediaInfoService          *MediaInfoService
+	FileOrganizationService   *FileOrganizationService
+	ImportService             *ImportService
+	ImportPause               *ImportPause
+	FileOperationService      *FileOperationService
+	LibraryMaintenanceService *LibraryMaintenanceService
+	DuplicateService          *DuplicateService
+	LibraryStatsService       *LibraryStatsService
 
 	// Health monitoring services
 	HealthService      *HealthService
 	HealthIssueService *HealthIssueService
 	PerformanceMonitor *PerformanceMonitor
+	IntegrityService   *IntegrityService
 
 	// Calendar services
 	CalendarService *CalendarService
@@ -49,16 +90,37 @@ type Container struct {
 	CollectionService *CollectionService
 	ParseService      *ParseService
 	RenameService     *RenameService
+
+	// QueueResolutionService maps downloads that matched no library movie
+	QueueResolutionService *QueueResolutionService
+
+	// CompletedFolderService imports downloads from the completed folders of watch folder clients
+	CompletedFolderService *CompletedFolderService
 }
 
+// Log components of the services whose level can be changed at runtime. Declared at package
+// level because the initializers' logger parameters shadow the package name.
+const (
+	searchLogComponent = logger.ComponentSearch
+	importLogComponent = logger.ComponentImport
+	tasksLogComponent  = logger.ComponentTasks
+)
+
 // NewContainer creates a new service container with all dependencies initialized
 func NewContainer(db *database.Database, cfg *config.Config, logger *logger.Logger) *Container {
 	container := &Container{
 		DB:     db,
 		Config: cfg,
 		Logger: logger,
+
+		Readiness: NewReadiness(),
 	}
 
+	// Outbound HTTP settings must be in place before services create their clients
+	container.configureOutboundHTTP(cfg, logger)
+
+	container.WorkerLimits = ResolveWorkerLimits(workerConfig(cfg), logger)
+
 	// Initialize services in logical groups
 	container.initializeCoreServices(db, cfg, logger)
 	container.initializeFileServices(db, logger)
@@ -69,46 +131,232 @@ func NewContainer(db *database.Database, cfg *config.Config, logger *logger.Logg
 	// Set service container reference for ConfigService
 	container.ConfigService.SetServiceContainer(container)
 
+	// Host configuration stored in the database takes precedence over the config file proxy
+	container.ConfigService.ApplyProxySettings()
+
 	// Register all task handlers
 	container.registerTaskHandlers()
 
+	container.Readiness.Complete(ReadinessServices)
 	return container
 }
 
+// configureOutboundHTTP applies the config file TLS, proxy, and resilience settings to the
+// shared HTTP transport
+func (c *Container) configureOutboundHTTP(cfg *config.Config, logger *logger.Logger) {
+	if cfg == nil {
+		return
+	}
+
+	if err := httpclient.ConfigureTLS(cfg.TLS); err != nil {
+		logger.Error("Invalid TLS configuration, using system certificate authorities", "error", err)
+	}
+
+	if err := httpclient.Configure(cfg.Proxy); err != nil {
+		logger.Error("Invalid proxy configuration, outbound requests will not use a proxy", "error", err)
+	}
+
+	if err := httpclient.ConfigureResilience(cfg.Resilience); err != nil {
+		logger.Error("Invalid resilience configuration, using default retry policy", "error", err)
+	}
+}
+
 // initializeCoreServices initializes the core business logic services
 func (c *Container) initializeCoreServices(db *database.Database, cfg *config.Config, logger *logger.Logger) {
 	c.MovieService = NewMovieService(db, logger)
+	tombstonePeriod, err := parseTombstonePeriod(importListConfig(cfg))
+	if err != nil {
+		logger.Error("Invalid import list configuration, using the default tombstone period", "error", err)
+	}
+	c.MovieService.UseTombstones(tombstonePeriod)
 	c.MovieFileService = NewMovieFileService(db, logger)
 	c.QualityService = NewQualityService(db, logger)
-	c.IndexerService = NewIndexerService(db, logger)
+	c.MovieVersionService = NewMovieVersionService(db, logger, c.QualityService)
+	c.IndexerService = NewIndexerService(db, logger.Component(searchLogComponent))
 	c.DownloadService = NewDownloadService(db, logger)
-	c.NotificationService = NewNotificationService(db, logger)
+	c.SeedingService = NewSeedingService(db, logger, c.DownloadService)
+	c.NotificationService = NewNotificationService(db, notificationConfig(cfg), logger)
+	c.OutboxDispatcher = NewOutboxDispatcher(db, logger)
+	c.OutboxDispatcher.AddSink("notifications", c.NotificationService.SendNotification)
+	c.IdempotencyService = NewIdempotencyService(db, logger)
 	c.MetadataService = NewMetadataService(db, cfg, logger)
+	c.MetadataService.UseStoredAPIKey(secretsBox(cfg, logger))
 	c.QueueService = NewQueueService(db, logger)
 	c.ImportListService = NewImportListService(db, logger, c.MetadataService, c.MovieService)
 	c.HistoryService = NewHistoryService(db, logger)
 	c.ConfigService = NewConfigService(db, logger)
-	c.SearchService = NewSearchService(db, logger, c.IndexerService, c.QualityService,
-		c.MovieService, c.DownloadService, c.NotificationService)
-	c.WantedMoviesService = NewWantedMoviesService(db, logger, c.MovieService, c.QualityService)
+	c.BackupStore = blobStore(cfg, blobKindBackups, logger)
+	c.ConfigService.UseBackupStore(c.BackupStore)
+	c.MediaCoverService = NewMediaCoverService(db, logger, blobStore(cfg, blobKindMediaCovers, logger))
+	c.RetentionService = NewRetentionService(db, retentionConfig(cfg), logger)
+	c.SearchService = NewSearchService(db, logger.Component(searchLogComponent), c.IndexerService, c.QualityService,
+		c.MovieService, c.DownloadService, c.NotificationService, c.RetentionService,
+		c.WorkerLimits.SearchWorkers)
+	wantedPriority, err := newWantedPriority(searchConfig(cfg).Priority)
+	if err != nil {
+		logger.Error("Invalid search priority configuration, using default priorities", "error", err)
+	}
+	c.WantedMoviesService = NewWantedMoviesService(db, logger, c.MovieService, c.QualityService, wantedPriority)
+	c.CustomFilterService = NewCustomFilterService(db, logger)
+	c.RemotePathMappingService = NewRemotePathMappingService(db, logger)
+	c.SceneMappingService = NewSceneMappingService(db, sceneMappingConfig(cfg), logger)
+}
+
+// retentionConfig returns the configured retention policy, or no limits without a config
+func retentionConfig(cfg *config.Config) config.RetentionConfig {
+	if cfg == nil {
+		return config.RetentionConfig{}
+	}
+	return cfg.Retention
+}
+
+// notificationConfig returns the configured notification retry settings, or the defaults without a config
+func notificationConfig(cfg *config.Config) config.NotificationConfig {
+	if cfg == nil {
+		return config.NotificationConfig{}
+	}
+	return cfg.Notifications
+}
+
+// workerConfig returns the configured worker pool overrides, or automatic sizing without a config
+func workerConfig(cfg *config.Config) config.WorkerConfig {
+	if cfg == nil {
+		return config.WorkerConfig{}
+	}
+	return cfg.Workers
+}
+
+// clusterConfig returns the configured multi-instance settings, or a single instance without a config
+func clusterConfig(cfg *config.Config) config.ClusterConfig {
+	if cfg == nil {
+		return config.ClusterConfig{}
+	}
+	return cfg.Cluster
+}
+
+// maintenanceConfig returns the configured starting maintenance mode, or normal operation without a config
+func maintenanceConfig(cfg *config.Config) config.MaintenanceConfig {
+	if cfg == nil {
+		return config.MaintenanceConfig{}
+	}
+	return cfg.Maintenance
+}
+
+// sceneMappingConfig returns the configured scene mapping source, or no remote list without a config
+func sceneMappingConfig(cfg *config.Config) config.SceneMappingConfig {
+	if cfg == nil {
+		return config.SceneMappingConfig{}
+	}
+	return cfg.SceneMappings
+}
+
+// importListConfig returns the configured import list settings, or the defaults without a config
+func importListConfig(cfg *config.Config) config.ImportListConfig {
+	if cfg == nil {
+		return config.ImportListConfig{}
+	}
+	return cfg.ImportLists
+}
+
+// duplicateConfig returns the configured duplicate fingerprinting, disabled without a config
+func duplicateConfig(cfg *config.Config) config.DuplicateConfig {
+	if cfg == nil {
+		return config.DuplicateConfig{}
+	}
+	return cfg.Duplicates
+}
+
+// searchConfig returns the configured wanted search intervals and priorities, or the defaults
+// without a config
+func searchConfig(cfg *config.Config) config.SearchConfig {
+	if cfg == nil {
+		return config.SearchConfig{Priority: defaultWantedPriority}
+	}
+	return cfg.Search
+}
+
+// secretsBox opens the key encrypting credentials stored in the database, kept in the data
+// directory. Without it credentials can only come from the config file.
+func secretsBox(cfg *config.Config, logger *logger.Logger) *secrets.Box {
+	if cfg == nil || cfg.Storage.DataDirectory == "" {
+		return nil
+	}
+
+	box, err := secrets.Open(filepath.Join(cfg.Storage.DataDirectory, secrets.KeyFileName))
+	if err != nil {
+		logger.Error("Failed to open secrets key, credentials cannot be stored in the database", "error", err)
+		return nil
+	}
+	return box
 }
 
 // initializeFileServices initializes file management and organization services
 func (c *Container) initializeFileServices(db *database.Database, logger *logger.Logger) {
 	c.NamingService = NewNamingService(db, logger)
+	c.ConfigManifestService = NewConfigManifestService(logger, c.QualityService, c.IndexerService, c.DownloadService,
+		c.ImportListService, c.NotificationService, c.NamingService)
 	c.MediaInfoService = NewMediaInfoService(db, logger)
-	c.FileOperationService = NewFileOperationService(db, logger)
+	c.FileOperationService = NewFileOperationService(db, logger.Component(importLogComponent))
 	c.FileOrganizationService = NewFileOrganizationService(db, logger, c.NamingService, c.MediaInfoService)
-	c.ImportService = NewImportService(db, logger, c.MovieService, c.MovieFileService,
-		c.FileOrganizationService, c.MediaInfoService, c.NamingService)
+	c.ImportService = NewImportService(db, logger.Component(importLogComponent), c.MovieService, c.MovieFileService,
+		c.MovieVersionService, c.FileOrganizationService, c.MediaInfoService, c.NamingService, c.HistoryService,
+		c.ConfigService, c.SeedingService, c.WorkerLimits.ImportWorkers)
+	c.ImportPause = NewImportPause(db, logger.Component(importLogComponent))
+	c.ImportService.UseImportPause(c.ImportPause)
+	c.QueueService.UseImportService(c.ImportService)
+	c.FileOperationService.UseImportPause(c.ImportPause)
+	c.DuplicateService = NewDuplicateService(db, duplicateConfig(c.Config), logger.Component(importLogComponent))
+	c.LibraryMaintenanceService = NewLibraryMaintenanceService(db, logger, c.MovieService,
+		c.MediaInfoService, c.WantedMoviesService)
+	c.LibraryStatsService = NewLibraryStatsService(db, logger)
 }
 
 // initializeMonitoringServices initializes health monitoring and performance services
 func (c *Container) initializeMonitoringServices(db *database.Database, cfg *config.Config, logger *logger.Logger) {
-	c.TaskService = NewTaskService(db, logger)
-	c.PerformanceMonitor = NewPerformanceMonitor(db, logger)
+	c.LeaderElector = NewLeaderElector(db, clusterConfig(cfg), logger)
+	c.TaskService = NewTaskService(db, c.WorkerLimits.TaskWorkers, logger.Component(tasksLogComponent))
+	c.TaskService.SetLeaderCheck(c.LeaderElector.IsLeader)
+	c.LeaderElector.OnPromote(c.TaskService.TakeOver)
+	c.ReadOnly = NewReadOnlyMode(maintenanceConfig(cfg).ReadOnly, maintenanceConfig(cfg).Reason)
+	c.TaskService.SetPaused(c.ReadOnly.Enabled())
+	c.ReadOnly.OnChange(c.TaskService.SetPaused)
 	c.HealthIssueService = NewHealthIssueService(db, logger)
 	c.HealthService = NewHealthService(db, cfg, logger)
+	c.PerformanceMonitor = NewPerformanceMonitor(db, c.HealthService.MetricsInterval(), logger)
+	c.PerformanceMonitor.SetBandwidthSource(c.DownloadService)
+	c.HealthService.SetPerformanceMonitor(c.PerformanceMonitor)
+	c.IntegrityService = NewIntegrityService(db, cfg != nil && cfg.Database.AutoRepair, logger)
+	c.HealthService.RegisterChecker(NewDataIntegrityHealthChecker(c.IntegrityService))
+	c.HealthService.RegisterChecker(NewPendingRestartHealthChecker(c.ConfigService))
+	c.HealthService.RegisterChecker(NewTMDBHealthChecker(c.MetadataService))
+}
+
+// RunStartupChecks runs the data integrity checks, repairing findings when database.auto_repair
+// is enabled, and records anything left over as health issues
+func (c *Container) RunStartupChecks(ctx context.Context) {
+	if c.DB == nil {
+		return
+	}
+
+	if _, err := c.IntegrityService.RunStartupChecks(ctx); err != nil {
+		c.Logger.Error("Startup data integrity checks failed", "error", err)
+		return
+	}
+
+	c.HealthService.RunAllChecks(ctx, []string{string(models.HealthCheckTypeDataIntegrity)})
+}
+
+// WarmUp runs the startup checks, joins the leader election and primes the task scheduler,
+// marking each stage complete in Readiness so the instance only reports ready once all have finished
+func (c *Container) WarmUp(ctx context.Context) {
+	c.RunStartupChecks(ctx)
+	c.Readiness.Complete(ReadinessStartupChecks)
+
+	c.LeaderElector.Start(ctx)
+	if c.DB != nil {
+		c.TaskService.WarmUp()
+	}
+	c.Readiness.Complete(ReadinessScheduler)
 }
 
 // initializeCalendarServices initializes calendar and scheduling services
@@ -120,8 +368,13 @@ func (c *Container) initializeCalendarServices(db *database.Database, logger *lo
 // initializeCollectionServices initializes collection management and parsing services
 func (c *Container) initializeCollectionServices(db *database.Database, logger *logger.Logger) {
 	c.CollectionService = NewCollectionService(db, logger)
-	c.ParseService = NewParseService(db, logger)
-	c.RenameService = NewRenameService(db, logger, c.NamingService)
+	c.ParseService = NewParseService(db, logger, c.MovieService, c.SceneMappingService)
+	c.ParseService.UseQualityService(c.QualityService)
+	c.QueueResolutionService = NewQueueResolutionService(db, logger, c.QueueService, c.ParseService,
+		c.MovieService, c.MetadataService)
+	c.CompletedFolderService = NewCompletedFolderService(db, logger.Component(importLogComponent),
+		c.DownloadService, c.ParseService, c.FileOrganizationService, c.ImportService)
+	c.RenameService = NewRenameService(db, logger, c.NamingService, c.HistoryService)
 }
 
 // registerTaskHandlers registers all task handlers with the task service
@@ -132,6 +385,23 @@ func (c *Container) registerTaskHandlers() {
 	c.TaskService.RegisterHandler(NewSyncImportListHandler(c.ImportListService))
 	c.TaskService.RegisterHandler(NewRefreshWantedMoviesHandler(c.WantedMoviesService))
 	c.TaskService.RegisterHandler(NewAutoWantedSearchHandler(c.WantedMoviesService, c.SearchService))
+	backoff, err := newSearchBackoff(searchConfig(c.Config))
+	if err != nil {
+		c.Logger.Error("Invalid search interval configuration, using defaults", "error", err)
+	}
+	c.TaskService.RegisterHandler(NewMissingMoviesSearchHandler(c.WantedMoviesService, c.SearchService, backoff))
+	c.TaskService.RegisterHandler(NewCutoffUnmetMoviesSearchHandler(c.WantedMoviesService, c.SearchService, backoff))
+	c.TaskService.RegisterHandler(NewRefreshQualityProfileWantedHandler(c.WantedMoviesService, c.SearchService))
+	c.TaskService.RegisterHandler(NewLibraryMaintenanceHandler(c.LibraryMaintenanceService))
+	c.TaskService.RegisterHandler(NewFingerprintDuplicatesHandler(c.DuplicateService))
+	c.TaskService.RegisterHandler(NewProcessQueuedImportsHandler(c.ImportService))
+	c.TaskService.RegisterHandler(NewRecordLibraryStatsHandler(c.LibraryStatsService))
+	c.TaskService.RegisterHandler(NewFlushNotificationsHandler(c.NotificationService))
+	c.TaskService.RegisterHandler(NewRefreshSceneMappingsHandler(c.SceneMappingService))
+	c.TaskService.RegisterHandler(NewCleanupSeededTorrentsHandler(c.SeedingService))
+	c.TaskService.RegisterHandler(NewScanCompletedDownloadFoldersHandler(c.CompletedFolderService))
+	c.TaskService.RegisterHandler(NewCheckCompletedDownloadsHandler(c.QueueService))
+	c.TaskService.RegisterHandler(NewPrefetchMediaCoversHandler(c.MediaCoverService))
 
 	// Register health monitoring task handlers
 	c.TaskService.RegisterHandler(NewHealthCheckTaskHandler(c.HealthService))
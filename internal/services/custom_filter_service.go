@@ -0,0 +1,245 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// movieFilterColumns maps custom filter keys to their movies table columns
+var movieFilterColumns = map[string]string{
+	"monitored":        "movies.monitored",
+	"hasFile":          "movies.has_file",
+	"qualityProfileId": "movies.quality_profile_id",
+	"year":             "movies.year",
+	"tags":             "movies.tags",
+	"genres":           "movies.genres",
+}
+
+// rangeOperators maps range predicates to their SQL comparison
+var rangeOperators = map[models.FilterPredicateType]string{
+	models.FilterLessThan:           "<",
+	models.FilterLessThanOrEqual:    "<=",
+	models.FilterGreaterThan:        ">",
+	models.FilterGreaterThanOrEqual: ">=",
+}
+
+// reversedRangeOperators maps range predicates on an age to the SQL comparison of its timestamp
+var reversedRangeOperators = map[models.FilterPredicateType]string{
+	models.FilterLessThan:           ">",
+	models.FilterLessThanOrEqual:    ">=",
+	models.FilterGreaterThan:        "<",
+	models.FilterGreaterThanOrEqual: "<=",
+}
+
+// CustomFilterService provides operations for managing saved custom filters.
+type CustomFilterService struct {
+	db     *database.Database
+	logger *logger.Logger
+}
+
+// NewCustomFilterService creates a new instance of CustomFilterService with the provided database and logger.
+func NewCustomFilterService(db *database.Database, logger *logger.Logger) *CustomFilterService {
+	return &CustomFilterService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAll retrieves all custom filters ordered by label.
+func (s *CustomFilterService) GetAll() ([]models.CustomFilter, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var filters []models.CustomFilter
+	if err := s.db.GORM.Order("label").Find(&filters).Error; err != nil {
+		s.logger.Error("Failed to fetch custom filters", "error", err)
+		return nil, fmt.Errorf("failed to fetch custom filters: %w", err)
+	}
+
+	return filters, nil
+}
+
+// GetByID retrieves a custom filter by its ID.
+func (s *CustomFilterService) GetByID(id int) (*models.CustomFilter, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var filter models.CustomFilter
+	if err := s.db.GORM.Where("id = ?", id).First(&filter).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch custom filter with id %d: %w", id, err)
+	}
+
+	return &filter, nil
+}
+
+// Create validates and saves a new custom filter.
+func (s *CustomFilterService) Create(filter *models.CustomFilter) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.db.GORM.Create(filter).Error; err != nil {
+		s.logger.Error("Failed to create custom filter", "label", filter.Label, "error", err)
+		return fmt.Errorf("failed to create custom filter: %w", err)
+	}
+
+	s.logger.Info("Created custom filter", "id", filter.ID, "label", filter.Label)
+	return nil
+}
+
+// Update validates and saves changes to an existing custom filter.
+func (s *CustomFilterService) Update(filter *models.CustomFilter) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.GetByID(filter.ID)
+	if err != nil {
+		return err
+	}
+	filter.CreatedAt = existing.CreatedAt
+
+	if err := s.db.GORM.Save(filter).Error; err != nil {
+		s.logger.Error("Failed to update custom filter", "id", filter.ID, "error", err)
+		return fmt.Errorf("failed to update custom filter: %w", err)
+	}
+
+	s.logger.Info("Updated custom filter", "id", filter.ID, "label", filter.Label)
+	return nil
+}
+
+// Delete removes a custom filter.
+func (s *CustomFilterService) Delete(id int) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	result := s.db.GORM.Delete(&models.CustomFilter{}, id)
+	if result.Error != nil {
+		s.logger.Error("Failed to delete custom filter", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete custom filter: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("custom filter with id %d not found", id)
+	}
+
+	s.logger.Info("Deleted custom filter", "id", id)
+	return nil
+}
+
+// applyMovieFilter adds a WHERE condition on the movies table for each of the filter's predicates
+func applyMovieFilter(query *gorm.DB, filter *models.CustomFilter) (*gorm.DB, error) {
+	for _, predicate := range filter.Filters {
+		condition, args, err := moviePredicateSQL(predicate)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, args...)
+	}
+	return query, nil
+}
+
+// moviePredicateSQL translates a predicate into a SQL condition and its arguments
+func moviePredicateSQL(predicate models.FilterPredicate) (string, []interface{}, error) {
+	if predicate.Key == "daysSinceLastSearch" {
+		return lastSearchPredicateSQL(predicate)
+	}
+
+	column, ok := movieFilterColumns[predicate.Key]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown filter key %q", predicate.Key)
+	}
+	values, err := predicate.Values()
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch predicate.Type {
+	case models.FilterEqual:
+		return column + " IN ?", []interface{}{values}, nil
+	case models.FilterNotEqual:
+		return column + " NOT IN ?", []interface{}{values}, nil
+	case models.FilterContains, models.FilterNotContains:
+		condition, args := jsonListContainsSQL(column, values)
+		if predicate.Type == models.FilterNotContains {
+			condition = "NOT " + condition
+		}
+		return condition, args, nil
+	default:
+		if operator, ok := rangeOperators[predicate.Type]; ok && len(values) == 1 {
+			return fmt.Sprintf("%s %s ?", column, operator), values, nil
+		}
+		return "", nil, fmt.Errorf("filter type %q is not supported for %s", predicate.Type, predicate.Key)
+	}
+}
+
+// lastSearchPredicateSQL compares the days since a movie was last searched against a cutoff time.
+// More days means an earlier search, so the comparison is reversed, and movies never searched
+// match "more than" comparisons so "not searched in 30 days" finds them too.
+func lastSearchPredicateSQL(predicate models.FilterPredicate) (string, []interface{}, error) {
+	values, err := predicate.Values()
+	if err != nil {
+		return "", nil, err
+	}
+	operator, ok := reversedRangeOperators[predicate.Type]
+	if !ok || len(values) != 1 {
+		return "", nil, fmt.Errorf("filter type %q is not supported for %s", predicate.Type, predicate.Key)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -values[0].(int))
+	condition := fmt.Sprintf("movies.last_search_time %s ?", operator)
+	if predicate.Type == models.FilterGreaterThan || predicate.Type == models.FilterGreaterThanOrEqual {
+		condition = fmt.Sprintf("(movies.last_search_time IS NULL OR %s)", condition)
+	}
+	return condition, []interface{}{cutoff}, nil
+}
+
+// jsonListContainsSQL matches list columns stored as compact JSON arrays, such as [1,2,3] or
+// ["Action","Drama"], containing any of the values. Plain LIKE patterns keep the condition
+// portable between PostgreSQL and MySQL.
+func jsonListContainsSQL(column string, values []interface{}) (string, []interface{}) {
+	column = fmt.Sprintf("COALESCE(%s, '[]')", column)
+
+	var conditions []string
+	var args []interface{}
+	for _, value := range values {
+		element := fmt.Sprint(value)
+		if text, ok := value.(string); ok {
+			element = jsonStringLiteral(text)
+		}
+		element = escapeLike(element)
+		for _, pattern := range []string{"[" + element + "]", "[" + element + ",%", "%," + element + ",%", "%," + element + "]"} {
+			conditions = append(conditions, column+" LIKE ?")
+			args = append(args, pattern)
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// jsonStringLiteral quotes a string the way StringArray stores it
+func jsonStringLiteral(s string) string {
+	data, _ := json.Marshal(s) //nolint:errcheck // Marshaling a string cannot fail
+	return string(data)
+}
+
+// escapeLike escapes the LIKE wildcards in a literal, using backslash, the default escape
+// character of both PostgreSQL and MySQL
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoviePredicateSQL(t *testing.T) {
+	condition, args, err := moviePredicateSQL(models.FilterPredicate{
+		Key: "monitored", Type: models.FilterEqual, Value: []interface{}{true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "movies.monitored IN ?", condition)
+	assert.Equal(t, []interface{}{[]interface{}{true}}, args)
+
+	condition, args, err = moviePredicateSQL(models.FilterPredicate{
+		Key: "year", Type: models.FilterGreaterThanOrEqual, Value: []interface{}{float64(2000)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "movies.year >= ?", condition)
+	assert.Equal(t, []interface{}{2000}, args)
+
+	condition, args, err = moviePredicateSQL(models.FilterPredicate{
+		Key: "tags", Type: models.FilterNotContains, Value: []interface{}{float64(3)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "NOT (COALESCE(movies.tags, '[]') LIKE ? OR COALESCE(movies.tags, '[]') LIKE ? OR "+
+		"COALESCE(movies.tags, '[]') LIKE ? OR COALESCE(movies.tags, '[]') LIKE ?)", condition)
+	assert.Equal(t, []interface{}{"[3]", "[3,%", "%,3,%", "%,3]"}, args)
+
+	_, args, err = moviePredicateSQL(models.FilterPredicate{
+		Key: "genres", Type: models.FilterContains, Value: []interface{}{"Sci_Fi"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `["Sci\_Fi"]`, args[0])
+
+	_, _, err = moviePredicateSQL(models.FilterPredicate{
+		Key: "year", Type: models.FilterEqual, Value: []interface{}{"1999"},
+	})
+	assert.Error(t, err)
+
+	// Movies not searched in 30 days include those never searched
+	condition, args, err = moviePredicateSQL(models.FilterPredicate{
+		Key: "daysSinceLastSearch", Type: models.FilterGreaterThan, Value: []interface{}{float64(30)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "(movies.last_search_time IS NULL OR movies.last_search_time < ?)", condition)
+	require.Len(t, args, 1)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), args[0].(time.Time), time.Minute)
+
+	condition, _, err = moviePredicateSQL(models.FilterPredicate{
+		Key: "daysSinceLastSearch", Type: models.FilterLessThanOrEqual, Value: []interface{}{float64(7)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "movies.last_search_time >= ?", condition)
+}
+
+func TestCustomFilter_Validate(t *testing.T) {
+	filter := &models.CustomFilter{
+		Label: "Recent unmonitored",
+		Filters: models.FilterPredicates{
+			{Key: "monitored", Type: models.FilterEqual, Value: []interface{}{false}},
+			{Key: "year", Type: models.FilterGreaterThan, Value: []interface{}{float64(2020)}},
+		},
+	}
+	require.NoError(t, filter.Validate())
+	assert.Equal(t, models.CustomFilterTypeMovieIndex, filter.Type)
+
+	tests := []struct {
+		name      string
+		predicate models.FilterPredicate
+	}{
+		{"unknown key", models.FilterPredicate{Key: "runtime", Type: models.FilterEqual, Value: []interface{}{float64(90)}}},
+		{"unsupported type", models.FilterPredicate{Key: "tags", Type: models.FilterEqual, Value: []interface{}{float64(1)}}},
+		{"no values", models.FilterPredicate{Key: "hasFile", Type: models.FilterEqual}},
+		{"several bounds", models.FilterPredicate{Key: "year", Type: models.FilterLessThan, Value: []interface{}{float64(1990), float64(2000)}}},
+		{"fractional number", models.FilterPredicate{Key: "qualityProfileId", Type: models.FilterEqual, Value: []interface{}{1.5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &models.CustomFilter{Label: "Invalid", Filters: models.FilterPredicates{tt.predicate}}
+			var validationErr models.ValidationError
+			require.ErrorAs(t, filter.Validate(), &validationErr)
+			assert.Equal(t, "filters[0]", validationErr.Field)
+		})
+	}
+
+	var validationErr models.ValidationError
+	require.ErrorAs(t, (&models.CustomFilter{}).Validate(), &validationErr)
+	assert.Equal(t, "label", validationErr.Field)
+}
+
+func TestCustomFilterService_NoDatabase(t *testing.T) {
+	service := NewCustomFilterService(nil, nil)
+	_, err := service.GetAll()
+	assert.EqualError(t, err, "database not available")
+	assert.EqualError(t, service.Create(&models.CustomFilter{Label: "All"}), "database not available")
+}
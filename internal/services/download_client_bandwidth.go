@@ -0,0 +1,209 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// bandwidthPollTimeout bounds a poll of the download clients' transfer rates; clients that
+	// don't answer in time are reported with an error
+	bandwidthPollTimeout = 10 * time.Second
+	// bandwidthCacheTTL is how long polled transfer rates are reused, so the stats endpoint and the
+	// metrics sampler polling together don't hit every client twice
+	bandwidthCacheTTL = 5 * time.Second
+)
+
+// transferRateClient reads the current transfer speeds of a download client
+type transferRateClient interface {
+	// TransferRates returns the download and upload speeds in bytes per second
+	TransferRates(ctx context.Context) (download, upload int64, err error)
+}
+
+// newTransferRateClient returns the transfer rate client for a download client, or an error for
+// clients whose speeds can't be read
+func newTransferRateClient(client *models.DownloadClient) (transferRateClient, error) {
+	httpClient := httpclient.New(bandwidthPollTimeout, httpclient.WithSkipTLSVerify(client.SkipTLSVerify()))
+	switch client.Type {
+	case models.DownloadClientTypeQBittorrent:
+		return newQBittorrentClient(client)
+	case models.DownloadClientTypeTransmission:
+		return &transmissionSeedingClient{client: client, httpClient: httpClient}, nil
+	case models.DownloadClientTypeSABnzbd:
+		return &sabnzbdRateClient{client: client, httpClient: httpClient}, nil
+	case models.DownloadClientTypeNZBGet:
+		return &nzbgetRateClient{client: client, httpClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("bandwidth statistics are not supported for %s", client.Type)
+	}
+}
+
+// GetBandwidth polls the enabled download clients for their current transfer speeds and returns
+// their totals with a breakdown per client. Clients that can't be polled are listed with their
+// error and left out of the totals. Results are reused for a few seconds.
+func (s *DownloadService) GetBandwidth(ctx context.Context) (*models.DownloadClientBandwidth, error) {
+	s.bandwidthMu.Lock()
+	defer s.bandwidthMu.Unlock()
+
+	if s.bandwidth != nil && time.Since(s.bandwidth.CheckedAt) < bandwidthCacheTTL {
+		return s.bandwidth, nil
+	}
+
+	clients, err := s.GetEnabledDownloadClients()
+	if err != nil {
+		return nil, err
+	}
+
+	s.bandwidth = pollBandwidth(ctx, clients)
+	return s.bandwidth, nil
+}
+
+// pollBandwidth reads the transfer speeds of the clients concurrently and adds them up
+func pollBandwidth(ctx context.Context, clients []models.DownloadClient) *models.DownloadClientBandwidth {
+	ctx, cancel := context.WithTimeout(ctx, bandwidthPollTimeout)
+	defer cancel()
+
+	rates := make([]models.DownloadClientTransferRate, len(clients))
+	var wg sync.WaitGroup
+	for i := range clients {
+		client := &clients[i]
+		rates[i] = models.DownloadClientTransferRate{
+			ID: client.ID, Name: client.Name, Implementation: client.Type, Protocol: client.Protocol,
+		}
+		wg.Add(1)
+		go func(rate *models.DownloadClientTransferRate) {
+			defer wg.Done()
+			rateClient, err := newTransferRateClient(client)
+			if err == nil {
+				rate.DownloadSpeed, rate.UploadSpeed, err = rateClient.TransferRates(ctx)
+			}
+			if err != nil {
+				rate.Error = err.Error()
+			}
+		}(&rates[i])
+	}
+	wg.Wait()
+
+	bandwidth := &models.DownloadClientBandwidth{Clients: rates, CheckedAt: time.Now()}
+	for _, rate := range rates {
+		if rate.Error == "" {
+			bandwidth.DownloadSpeed += rate.DownloadSpeed
+			bandwidth.UploadSpeed += rate.UploadSpeed
+		}
+	}
+	return bandwidth
+}
+
+// sabnzbdRateClient reads the download speed from SABnzbd's queue. Usenet clients don't upload.
+type sabnzbdRateClient struct {
+	client     *models.DownloadClient
+	httpClient *http.Client
+}
+
+// TransferRates returns SABnzbd's current download speed
+func (c *sabnzbdRateClient) TransferRates(ctx context.Context) (download, upload int64, err error) {
+	query := url.Values{"mode": {"queue"}, "output": {"json"}, "limit": {"0"}, "apikey": {c.client.APIKey}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.client.GetBaseURL()+"/api?"+query.Encode(),
+		http.NoBody)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	body, err := doSeedingRequest(c.httpClient, req, "SABnzbd")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var response struct {
+		Error string `json:"error"`
+		Queue struct {
+			// KBPerSec is the speed in KiB per second, as a string such as "1024.50"
+			KBPerSec string `json:"kbpersec"`
+		} `json:"queue"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, 0, fmt.Errorf("invalid SABnzbd queue: %w", err)
+	}
+	if strings.HasPrefix(response.Error, "API Key") {
+		// "API Key Required" or "API Key Incorrect"
+		return 0, 0, &providerAuthError{message: "SABnzbd rejected the API key: " + response.Error}
+	}
+	if response.Error != "" {
+		return 0, 0, fmt.Errorf("SABnzbd queue failed: %s", response.Error)
+	}
+	if response.Queue.KBPerSec == "" {
+		return 0, 0, nil
+	}
+	kibPerSec, err := strconv.ParseFloat(response.Queue.KBPerSec, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid SABnzbd speed %q: %w", response.Queue.KBPerSec, err)
+	}
+	return int64(kibPerSec * 1024), 0, nil
+}
+
+// nzbgetRateClient reads the download speed from NZBGet's status. Usenet clients don't upload.
+type nzbgetRateClient struct {
+	client     *models.DownloadClient
+	httpClient *http.Client
+}
+
+// TransferRates returns NZBGet's current download speed
+func (c *nzbgetRateClient) TransferRates(ctx context.Context) (download, upload int64, err error) {
+	var status struct {
+		DownloadRate int64 `json:"DownloadRate"`
+	}
+	if err := c.call(httpclient.WithRetries(ctx), "status", &status); err != nil {
+		return 0, 0, err
+	}
+	return status.DownloadRate, 0, nil
+}
+
+// call invokes a JSON-RPC method without parameters
+func (c *nzbgetRateClient) call(ctx context.Context, method string, result any) error {
+	payload, err := json.Marshal(map[string]any{"method": method, "params": []any{}})
+	if err != nil {
+		return fmt.Errorf("failed to encode NZBGet request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.client.GetBaseURL()+"/jsonrpc",
+		bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.client.Username != "" {
+		req.SetBasicAuth(c.client.Username, c.client.Password)
+	}
+
+	body, err := doSeedingRequest(c.httpClient, req, "NZBGet")
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("invalid NZBGet %s response: %w", method, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("NZBGet %s failed: %s", method, response.Error.Message)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("invalid NZBGet %s response: %w", method, err)
+	}
+	return nil
+}
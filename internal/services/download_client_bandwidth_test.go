@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollBandwidth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/auth/login":
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/transfer/info":
+			_, _ = w.Write([]byte(`{"dl_info_speed": 1000, "up_info_speed": 200}`))
+		case "/transmission/rpc":
+			if r.Header.Get(transmissionSessionHeader) == "" {
+				w.Header().Set(transmissionSessionHeader, "token")
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			_, _ = w.Write([]byte(`{"result": "success", "arguments": {"downloadSpeed": 3000, "uploadSpeed": 400}}`))
+		case "/api":
+			assert.Equal(t, "queue", r.URL.Query().Get("mode"))
+			_, _ = w.Write([]byte(`{"queue": {"kbpersec": "2.5"}}`))
+		case "/jsonrpc":
+			var request struct {
+				Method string `json:"method"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "status", request.Method)
+			_, _ = w.Write([]byte(`{"result": {"DownloadRate": 5000}}`))
+		}
+	}))
+	defer server.Close()
+
+	var clients []models.DownloadClient
+	for i, clientType := range []models.DownloadClientType{
+		models.DownloadClientTypeQBittorrent, models.DownloadClientTypeTransmission,
+		models.DownloadClientTypeSABnzbd, models.DownloadClientTypeNZBGet, models.DownloadClientTypeDeluge,
+	} {
+		client := testDownloadClient(t, server, clientType)
+		client.ID = i + 1
+		clients = append(clients, *client)
+	}
+
+	bandwidth := pollBandwidth(context.Background(), clients)
+	require.Len(t, bandwidth.Clients, 5)
+	assert.Equal(t, int64(1000+3000+2560+5000), bandwidth.DownloadSpeed)
+	assert.Equal(t, int64(200+400), bandwidth.UploadSpeed)
+
+	assert.Equal(t, int64(2560), bandwidth.Clients[2].DownloadSpeed, "SABnzbd reports KiB per second")
+	assert.Equal(t, 5, bandwidth.Clients[4].ID)
+	assert.Contains(t, bandwidth.Clients[4].Error, "not supported for deluge")
+	for _, rate := range bandwidth.Clients[:4] {
+		assert.Empty(t, rate.Error, rate.Implementation)
+	}
+}
+
+func TestPollBandwidth_UnreachableClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := testDownloadClient(t, server, models.DownloadClientTypeNZBGet)
+	bandwidth := pollBandwidth(context.Background(), []models.DownloadClient{*client})
+	require.Len(t, bandwidth.Clients, 1)
+	assert.Contains(t, bandwidth.Clients[0].Error, "status 503")
+	assert.Zero(t, bandwidth.DownloadSpeed)
+}
+
+func TestPollBandwidth_RetriesStatusQueries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"result": {"DownloadRate": 5000}}`))
+	}))
+	defer server.Close()
+
+	// NZBGet's status is a POST, but reading it changes nothing
+	client := testDownloadClient(t, server, models.DownloadClientTypeNZBGet)
+	bandwidth := pollBandwidth(context.Background(), []models.DownloadClient{*client})
+	require.Len(t, bandwidth.Clients, 1)
+	assert.Empty(t, bandwidth.Clients[0].Error)
+	assert.Equal(t, int64(5000), bandwidth.DownloadSpeed)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// downloadClientTestTimeout bounds all requests of one download client test
+	downloadClientTestTimeout = 10 * time.Second
+	// minTransmissionRPCVersion is the RPC version of Transmission 2.40, the oldest release with the
+	// torrent fields Radarr reads
+	minTransmissionRPCVersion = 14
+	// minNZBGetVersion is the oldest NZBGet major version whose JSON-RPC API Radarr speaks
+	minNZBGetVersion = 12
+)
+
+// diagnoseDownloadClient tests a download client with valid settings stage by stage: reaching it,
+// getting past its authentication, the capabilities Radarr needs and its version. Clients Radarr
+// has no API client for only get the connectivity check.
+func (s *DownloadService) diagnoseDownloadClient(client *models.DownloadClient,
+	result *models.DownloadClientTestResult) {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadClientTestTimeout)
+	defer cancel()
+
+	report := &result.ProviderTestReport
+	switch client.Type {
+	case models.DownloadClientTypeQBittorrent:
+		s.diagnoseQBittorrent(ctx, client, result)
+	case models.DownloadClientTypeTransmission:
+		diagnoseTransmission(ctx, client, result)
+	case models.DownloadClientTypeSABnzbd:
+		diagnoseSABnzbd(ctx, client, result)
+	case models.DownloadClientTypeNZBGet:
+		diagnoseNZBGet(ctx, client, result)
+	default:
+		if err := s.testClientConnection(client); err != nil {
+			report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, string(client.Type), err))
+			return
+		}
+		report.Pass(models.ProviderTestCheckConnectivity, "")
+	}
+}
+
+// diagnoseQBittorrent logs in to qBittorrent, recording its version in the result, and creates the
+// client's category when qBittorrent doesn't have it yet
+func (s *DownloadService) diagnoseQBittorrent(ctx context.Context, client *models.DownloadClient,
+	result *models.DownloadClientTestResult) {
+	report := &result.ProviderTestReport
+	qbittorrent, err := newQBittorrentClient(client)
+	if err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, "qBittorrent", err))
+		return
+	}
+
+	version, err := qbittorrent.Version(ctx)
+	if err != nil {
+		failure := diagnoseProviderError(models.ProviderTestCheckConnectivity, "qBittorrent", err)
+		if failure.Category == models.ProviderTestFailureNotFound {
+			// Releases before 4.1 serve the v1 Web API only
+			failure.Check = models.ProviderTestCheckVersion
+			failure.Category = models.ProviderTestFailureUnsupportedVersion
+			failure.Hint = "Radarr needs qBittorrent 4.1 or later; for newer releases check the URL base"
+		}
+		report.Fail(failure)
+		return
+	}
+	report.Pass(models.ProviderTestCheckConnectivity, "")
+	report.Pass(models.ProviderTestCheckAuthentication, "")
+	result.Version, result.APIVersion = version.App, version.WebAPI
+	report.Pass(models.ProviderTestCheckVersion, fmt.Sprintf("qBittorrent %s, Web API %s", version.App, version.WebAPI))
+
+	if client.Category == "" {
+		report.Pass(models.ProviderTestCheckCapability, "")
+		return
+	}
+	created, err := qbittorrent.EnsureCategory(ctx, client.Category)
+	if err != nil {
+		failure := diagnoseProviderError(models.ProviderTestCheckCapability, "qBittorrent", err)
+		failure.Check, failure.Field = models.ProviderTestCheckCapability, "category"
+		if failure.Category == models.ProviderTestFailureUnexpectedResponse {
+			failure.Hint = "Check the category name is one qBittorrent accepts"
+		}
+		report.Fail(failure)
+		return
+	}
+	if created {
+		s.logger.Info("Created qBittorrent category", "client", client.Name, "category", client.Category)
+		report.Pass(models.ProviderTestCheckCapability, fmt.Sprintf("Created category %s", client.Category))
+		return
+	}
+	report.Pass(models.ProviderTestCheckCapability, fmt.Sprintf("Category %s exists", client.Category))
+}
+
+// diagnoseTransmission reads Transmission's session, which needs a working RPC login, and checks
+// its RPC version
+func diagnoseTransmission(ctx context.Context, client *models.DownloadClient,
+	result *models.DownloadClientTestResult) {
+	report := &result.ProviderTestReport
+	transmission := &transmissionSeedingClient{
+		client:     client,
+		httpClient: httpclient.New(downloadClientTestTimeout, httpclient.WithSkipTLSVerify(client.SkipTLSVerify())),
+	}
+
+	var session struct {
+		Version    string `json:"version"`
+		RPCVersion int    `json:"rpc-version"`
+	}
+	err := transmission.call(ctx, "session-get", map[string]any{"fields": []string{"version", "rpc-version"}},
+		&session)
+	if err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, "Transmission", err))
+		return
+	}
+	report.Pass(models.ProviderTestCheckConnectivity, "")
+	report.Pass(models.ProviderTestCheckAuthentication, "")
+
+	result.Version, result.APIVersion = session.Version, strconv.Itoa(session.RPCVersion)
+	if session.RPCVersion < minTransmissionRPCVersion {
+		report.Fail(models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckVersion,
+			Category: models.ProviderTestFailureUnsupportedVersion,
+			Message:  fmt.Sprintf("Transmission %s (RPC %d) is not supported", session.Version, session.RPCVersion),
+			Hint:     "Radarr needs Transmission 2.40 or later",
+		})
+		return
+	}
+	report.Pass(models.ProviderTestCheckVersion,
+		fmt.Sprintf("Transmission %s, RPC %d", session.Version, session.RPCVersion))
+}
+
+// diagnoseSABnzbd reads SABnzbd's version, which needs no API key, then its queue, which does
+func diagnoseSABnzbd(ctx context.Context, client *models.DownloadClient,
+	result *models.DownloadClientTestResult) {
+	report := &result.ProviderTestReport
+	httpClient := httpclient.New(downloadClientTestTimeout, httpclient.WithSkipTLSVerify(client.SkipTLSVerify()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		client.GetBaseURL()+"/api?mode=version&output=json", http.NoBody)
+	if err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, "SABnzbd", err))
+		return
+	}
+	body, err := doSeedingRequest(httpClient, req, "SABnzbd")
+	if err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, "SABnzbd", err))
+		return
+	}
+	var version struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(body, &version); err != nil || version.Version == "" {
+		report.Fail(models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckConnectivity,
+			Category: models.ProviderTestFailureUnexpectedResponse,
+			Message:  "The server didn't answer like SABnzbd",
+			Hint:     "Check the host, port and URL base point to SABnzbd",
+		})
+		return
+	}
+	report.Pass(models.ProviderTestCheckConnectivity, "")
+
+	rates := &sabnzbdRateClient{client: client, httpClient: httpClient}
+	if _, _, err := rates.TransferRates(ctx); err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckAuthentication, "SABnzbd", err))
+		return
+	}
+	report.Pass(models.ProviderTestCheckAuthentication, "")
+	result.Version = version.Version
+	report.Pass(models.ProviderTestCheckVersion, "SABnzbd "+version.Version)
+}
+
+// diagnoseNZBGet calls NZBGet's version method, which needs a working login, and checks the
+// version
+func diagnoseNZBGet(ctx context.Context, client *models.DownloadClient, result *models.DownloadClientTestResult) {
+	report := &result.ProviderTestReport
+	nzbget := &nzbgetRateClient{
+		client:     client,
+		httpClient: httpclient.New(downloadClientTestTimeout, httpclient.WithSkipTLSVerify(client.SkipTLSVerify())),
+	}
+
+	var version string
+	if err := nzbget.call(ctx, "version", &version); err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, "NZBGet", err))
+		return
+	}
+	report.Pass(models.ProviderTestCheckConnectivity, "")
+	report.Pass(models.ProviderTestCheckAuthentication, "")
+
+	result.Version = version
+	// Versions that don't parse, such as development builds, are let through
+	major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if major > 0 && major < minNZBGetVersion {
+		report.Fail(models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckVersion,
+			Category: models.ProviderTestFailureUnsupportedVersion,
+			Message:  fmt.Sprintf("NZBGet %s is not supported", version),
+			Hint:     fmt.Sprintf("Radarr needs NZBGet %d.0 or later", minNZBGetVersion),
+		})
+		return
+	}
+	report.Pass(models.ProviderTestCheckVersion, "NZBGet "+version)
+}
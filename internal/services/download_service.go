@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 )
@@ -15,6 +18,9 @@ import (
 type DownloadService struct {
 	db     *database.Database
 	logger *logger.Logger
+
+	bandwidthMu sync.Mutex
+	bandwidth   *models.DownloadClientBandwidth
 }
 
 // NewDownloadService creates a new instance of DownloadService with the provided database and logger.
@@ -139,36 +145,30 @@ func (s *DownloadService) GetEnabledDownloadClients() ([]models.DownloadClient,
 
 // TestDownloadClient tests the connection to a download client.
 func (s *DownloadService) TestDownloadClient(client *models.DownloadClient) (*models.DownloadClientTestResult, error) {
-	// Basic validation
-	errors := []string{}
+	result := &models.DownloadClientTestResult{ProviderTestReport: models.NewProviderTestReport()}
+	report := &result.ProviderTestReport
 
 	if client.Name == "" {
-		errors = append(errors, "Name is required")
+		report.Fail(configurationFailure("name", "Name is required"))
 	}
 
 	if client.Host == "" {
-		errors = append(errors, "Host is required")
+		report.Fail(configurationFailure("host", "Host is required"))
 	}
 
 	if client.Port <= 0 || client.Port > 65535 {
-		errors = append(errors, "Port must be between 1 and 65535")
-	}
-
-	result := &models.DownloadClientTestResult{
-		IsValid: len(errors) == 0,
-		Errors:  errors,
+		report.Fail(configurationFailure("port", "Port must be between 1 and 65535"))
 	}
 
-	if !result.IsValid {
-		return result, nil
+	if report.Passed() {
+		report.Pass(models.ProviderTestCheckConfiguration, "")
+		s.diagnoseDownloadClient(client, result)
 	}
+	result.IsValid, result.Errors = report.Passed(), report.Messages()
 
-	// Test actual connection
-	if err := s.testClientConnection(client); err != nil {
-		result.IsValid = false
-		result.Errors = append(result.Errors, err.Error())
+	if err := recordProviderTest(s.db, &models.DownloadClient{}, client.ID, report); err != nil {
+		s.logger.Warn("Failed to record download client test", "name", client.Name, "error", err)
 	}
-
 	s.logger.Info("Tested download client connection", "name", client.Name, "valid", result.IsValid)
 	return result, nil
 }
@@ -273,6 +273,10 @@ func (s *DownloadService) validateDownloadClient(client *models.DownloadClient)
 		return fmt.Errorf("protocol %s is not supported by client type %s", client.Protocol, client.Type)
 	}
 
+	if folder := client.CompletedDownloadFolder(); folder != "" && !filepath.IsAbs(folder) {
+		return fmt.Errorf("completed download folder must be an absolute path")
+	}
+
 	return nil
 }
 
@@ -294,15 +298,11 @@ func (s *DownloadService) isValidProtocolForClientType(
 // testClientConnection tests the actual connection to a download client
 func (s *DownloadService) testClientConnection(client *models.DownloadClient) error {
 	// Create HTTP client with timeout
-	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	httpClient := httpclient.New(10*time.Second, httpclient.WithSkipTLSVerify(client.SkipTLSVerify()))
 
 	// Build test URL based on client type
 	testURL := client.GetBaseURL()
 	switch client.Type {
-	case models.DownloadClientTypeQBittorrent:
-		testURL += "/api/v2/app/version"
 	case models.DownloadClientTypeTransmission:
 		testURL += "/transmission/rpc"
 	case models.DownloadClientTypeSABnzbd:
@@ -339,7 +339,7 @@ func (s *DownloadService) testClientConnection(client *models.DownloadClient) er
 
 	// Check if we get a reasonable response
 	if resp.StatusCode >= 500 {
-		return fmt.Errorf("server error: %d", resp.StatusCode)
+		return &providerStatusError{Provider: string(client.Type), StatusCode: resp.StatusCode}
 	}
 
 	return nil
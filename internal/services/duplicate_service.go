@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// fingerprintTimeout bounds the ffprobe and ffmpeg runs for one file
+	fingerprintTimeout = 2 * time.Minute
+	// sameContentMaxDurationDelta is how many seconds apart copies of the same cut may run, allowing
+	// for different intros and container overhead
+	sameContentMaxDurationDelta = 5.0
+	// sameContentMaxFrameDistance is the largest mean frame hash distance of copies of the same cut
+	sameContentMaxFrameDistance = 10.0
+)
+
+// DuplicateService finds movies with more than one file for the same version and fingerprints
+// those files, so the copies can be compared and the one to keep picked
+type DuplicateService struct {
+	db     *database.Database
+	logger *logger.Logger
+	config config.DuplicateConfig
+}
+
+// NewDuplicateService creates a new duplicate service
+func NewDuplicateService(db *database.Database, cfg config.DuplicateConfig, logger *logger.Logger) *DuplicateService {
+	if cfg.FFmpegPath == "" {
+		cfg.FFmpegPath = "ffmpeg"
+	}
+	if cfg.FFprobePath == "" {
+		cfg.FFprobePath = "ffprobe"
+	}
+	return &DuplicateService{
+		db:     db,
+		logger: logger,
+		config: cfg,
+	}
+}
+
+// Enabled reports whether fingerprinting is turned on
+func (s *DuplicateService) Enabled() bool {
+	return s.config.Enabled
+}
+
+// GetDuplicates returns the movie versions with more than one file, comparing the files that have
+// been fingerprinted
+func (s *DuplicateService) GetDuplicates() ([]models.DuplicateGroup, error) {
+	groups, err := s.candidateGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	var fileIDs []int
+	for i := range groups {
+		for _, file := range groups[i].Files {
+			fileIDs = append(fileIDs, file.MovieFileID)
+		}
+	}
+
+	fingerprints := make(map[int]models.FileFingerprint)
+	if len(fileIDs) > 0 {
+		var stored []models.FileFingerprint
+		if err := s.db.GORM.Where("movie_file_id IN ?", fileIDs).Find(&stored).Error; err != nil {
+			return nil, fmt.Errorf("failed to get file fingerprints: %w", err)
+		}
+		for _, fingerprint := range stored {
+			fingerprints[fingerprint.MovieFileID] = fingerprint
+		}
+	}
+
+	for i := range groups {
+		compareGroup(&groups[i], fingerprints)
+	}
+	return groups, nil
+}
+
+// FingerprintCandidates fingerprints the files of every duplicate group that have no fingerprint,
+// or whose size changed since, reporting progress as files are done. It returns how many files
+// were fingerprinted.
+func (s *DuplicateService) FingerprintCandidates(ctx context.Context, progress func(done, total int)) (int, error) {
+	if !s.config.Enabled {
+		return 0, fmt.Errorf("duplicate fingerprinting is disabled; set duplicates.enabled to enable it")
+	}
+
+	groups, err := s.candidateGroups()
+	if err != nil {
+		return 0, err
+	}
+
+	stale, err := s.staleFiles(groups)
+	if err != nil {
+		return 0, err
+	}
+
+	fingerprinted := 0
+	for i, file := range stale {
+		if ctx.Err() != nil {
+			return fingerprinted, ctx.Err()
+		}
+		progress(i, len(stale))
+
+		if err := s.fingerprint(ctx, &file); err != nil {
+			s.logger.Warn("Failed to fingerprint movie file", "movieFileId", file.ID, "path", file.Path, "error", err)
+			continue
+		}
+		fingerprinted++
+	}
+	progress(len(stale), len(stale))
+
+	// Fingerprints of files no longer in the library are of no further use
+	if err := s.db.GORM.Where("movie_file_id NOT IN (?)", s.db.GORM.Model(&models.MovieFile{}).Select("id")).
+		Delete(&models.FileFingerprint{}).Error; err != nil {
+		s.logger.Warn("Failed to remove fingerprints of deleted files", "error", err)
+	}
+
+	s.logger.Info("Fingerprinted duplicate movie files", "fingerprinted", fingerprinted, "candidates", len(stale))
+	return fingerprinted, nil
+}
+
+// candidateGroups loads the movie versions with more than one file
+func (s *DuplicateService) candidateGroups() ([]models.DuplicateGroup, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	type groupKey struct {
+		MovieID   int
+		VersionID int
+	}
+	var keys []groupKey
+	if err := s.db.GORM.Model(&models.MovieFile{}).Select("movie_id, version_id").
+		Group("movie_id, version_id").Having("COUNT(*) > 1").Order("movie_id, version_id").
+		Scan(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to find duplicate movie files: %w", err)
+	}
+	if len(keys) == 0 {
+		return []models.DuplicateGroup{}, nil
+	}
+
+	movieIDs := make([]int, len(keys))
+	for i, key := range keys {
+		movieIDs[i] = key.MovieID
+	}
+
+	var files []models.MovieFile
+	if err := s.db.GORM.Where("movie_id IN ?", movieIDs).Order("id").Find(&files).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate movie files: %w", err)
+	}
+	var movies []models.Movie
+	if err := s.db.GORM.Select("id", "title").Where("id IN ?", movieIDs).Find(&movies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get movies with duplicate files: %w", err)
+	}
+	titles := make(map[int]string, len(movies))
+	for _, movie := range movies {
+		titles[movie.ID] = movie.Title
+	}
+
+	groups := make([]models.DuplicateGroup, len(keys))
+	index := make(map[groupKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+		groups[i] = models.DuplicateGroup{
+			MovieID:     key.MovieID,
+			MovieTitle:  titles[key.MovieID],
+			VersionID:   key.VersionID,
+			Comparisons: []models.DuplicateComparison{},
+		}
+	}
+	for _, file := range files {
+		i, ok := index[groupKey{file.MovieID, file.VersionID}]
+		if !ok {
+			continue
+		}
+		groups[i].Files = append(groups[i].Files, models.DuplicateFile{
+			MovieFileID:       file.ID,
+			Path:              file.Path,
+			Size:              file.Size,
+			Quality:           file.Quality.Quality.Name,
+			CustomFormatScore: file.CustomFormatScore,
+			ReleaseGroup:      file.ReleaseGroup,
+		})
+	}
+	return groups, nil
+}
+
+// staleFiles returns the files of groups without a fingerprint for their current size
+func (s *DuplicateService) staleFiles(groups []models.DuplicateGroup) ([]models.MovieFile, error) {
+	var fileIDs []int
+	for i := range groups {
+		for _, file := range groups[i].Files {
+			fileIDs = append(fileIDs, file.MovieFileID)
+		}
+	}
+	if len(fileIDs) == 0 {
+		return nil, nil
+	}
+
+	var files []models.MovieFile
+	if err := s.db.GORM.Where("id IN ?", fileIDs).
+		Where("NOT EXISTS (?)", s.db.GORM.Model(&models.FileFingerprint{}).Select("1").
+			Where("file_fingerprints.movie_file_id = movie_files.id AND file_fingerprints.size = movie_files.size")).
+		Order("id").Find(&files).Error; err != nil {
+		return nil, fmt.Errorf("failed to find files to fingerprint: %w", err)
+	}
+	return files, nil
+}
+
+// fingerprint measures a file's running time and hashes frames across it, storing the result
+func (s *DuplicateService) fingerprint(ctx context.Context, file *models.MovieFile) error {
+	ctx, cancel := context.WithTimeout(ctx, fingerprintTimeout)
+	defer cancel()
+
+	duration, err := probeDuration(ctx, s.config.FFprobePath, file.Path)
+	if err != nil {
+		return err
+	}
+
+	hashes := make([]uint64, 0, len(fingerprintFramePositions))
+	for _, position := range fingerprintFramePositions {
+		hash, err := hashFrame(ctx, s.config.FFmpegPath, file.Path, duration*position)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	fingerprint := &models.FileFingerprint{
+		MovieFileID: file.ID,
+		MovieID:     file.MovieID,
+		Size:        file.Size,
+		Duration:    duration,
+		FrameHashes: formatFrameHashes(hashes),
+	}
+	if err := s.db.GORM.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "movie_file_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"movie_id", "size", "duration", "frame_hashes", "updated_at"}),
+	}).Create(fingerprint).Error; err != nil {
+		return fmt.Errorf("failed to save file fingerprint: %w", err)
+	}
+	return nil
+}
+
+// compareGroup fills in the durations of a group's fingerprinted files and compares each pair
+func compareGroup(group *models.DuplicateGroup, fingerprints map[int]models.FileFingerprint) {
+	for i := range group.Files {
+		if fingerprint, ok := fingerprints[group.Files[i].MovieFileID]; ok && fingerprint.Size == group.Files[i].Size {
+			group.Files[i].Duration = fingerprint.Duration
+			group.Files[i].Fingerprinted = true
+		}
+	}
+
+	for i := range group.Files {
+		for j := i + 1; j < len(group.Files); j++ {
+			a, b := group.Files[i], group.Files[j]
+			if !a.Fingerprinted || !b.Fingerprinted {
+				continue
+			}
+
+			delta := math.Abs(a.Duration - b.Duration)
+			distance := frameDistance(parseFrameHashes(fingerprints[a.MovieFileID].FrameHashes),
+				parseFrameHashes(fingerprints[b.MovieFileID].FrameHashes))
+			group.Comparisons = append(group.Comparisons, models.DuplicateComparison{
+				MovieFileID:      a.MovieFileID,
+				OtherMovieFileID: b.MovieFileID,
+				DurationDelta:    math.Round(delta*10) / 10,
+				FrameDistance:    distance,
+				SameContent: delta <= sameContentMaxDurationDelta &&
+					distance >= 0 && distance <= sameContentMaxFrameDistance,
+			})
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareGroup(t *testing.T) {
+	group := &models.DuplicateGroup{
+		Files: []models.DuplicateFile{
+			{MovieFileID: 1, Size: 100},
+			{MovieFileID: 2, Size: 200},
+			{MovieFileID: 3, Size: 300},
+			{MovieFileID: 4, Size: 400},
+		},
+	}
+	fingerprints := map[int]models.FileFingerprint{
+		1: {MovieFileID: 1, Size: 100, Duration: 7200, FrameHashes: formatFrameHashes([]uint64{0xff, 0xf0f0})},
+		2: {MovieFileID: 2, Size: 200, Duration: 7203, FrameHashes: formatFrameHashes([]uint64{0xfe, 0xf0f0})},
+		3: {MovieFileID: 3, Size: 300, Duration: 8100, FrameHashes: formatFrameHashes([]uint64{0xff, 0xf0f0})},
+		// Fingerprinted before the file was replaced
+		4: {MovieFileID: 4, Size: 1, Duration: 7200},
+	}
+
+	compareGroup(group, fingerprints)
+
+	assert.True(t, group.Files[0].Fingerprinted)
+	assert.False(t, group.Files[3].Fingerprinted)
+	require.Len(t, group.Comparisons, 3)
+
+	assert.Equal(t, 1, group.Comparisons[0].MovieFileID)
+	assert.Equal(t, 2, group.Comparisons[0].OtherMovieFileID)
+	assert.InDelta(t, 0.5, group.Comparisons[0].FrameDistance, 0.001)
+	assert.True(t, group.Comparisons[0].SameContent)
+
+	// An extended cut runs much longer
+	assert.Equal(t, 3, group.Comparisons[1].OtherMovieFileID)
+	assert.False(t, group.Comparisons[1].SameContent)
+}
+
+func TestDuplicateService_GetDuplicates(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	movie := &models.Movie{Title: "Duplicate Movie", TmdbID: 9401, TitleSlug: "duplicate-movie-9401"}
+	require.NoError(t, db.GORM.Create(movie).Error)
+	for _, path := range []string{"/movies/a.mkv", "/movies/b.mkv"} {
+		require.NoError(t, db.GORM.Create(&models.MovieFile{MovieID: movie.ID, Path: path, Size: 10}).Error)
+	}
+	require.NoError(t, db.GORM.Create(&models.MovieFile{MovieID: movie.ID, VersionID: 7, Path: "/movies/c.mkv"}).Error)
+
+	service := NewDuplicateService(db, duplicateConfig(nil), logger)
+	groups, err := service.GetDuplicates()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "Duplicate Movie", groups[0].MovieTitle)
+	assert.Len(t, groups[0].Files, 2)
+
+	_, err = service.FingerprintCandidates(t.Context(), func(int, int) {})
+	assert.ErrorContains(t, err, "disabled")
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// editionPatterns recognises edition tags in release names and filenames, with the name each is
+// stored and rendered as
+var editionPatterns = []struct {
+	pattern *regexp.Regexp
+	name    string
+}{
+	{regexp.MustCompile(`(?i)\bdirector'?s?[ ._-]?cut\b`), "Director's Cut"},
+	{regexp.MustCompile(`(?i)\bextended(?:[ ._-](?:cut|edition))?\b`), "Extended"},
+	{regexp.MustCompile(`(?i)\bultimate[ ._-](?:cut|edition)\b`), "Ultimate Edition"},
+	{regexp.MustCompile(`(?i)\bfinal[ ._-]cut\b`), "Final Cut"},
+	{regexp.MustCompile(`(?i)\btheatrical(?:[ ._-](?:cut|edition))?\b`), "Theatrical"},
+	{regexp.MustCompile(`(?i)\bunrated\b`), "Unrated"},
+	{regexp.MustCompile(`(?i)\buncut\b`), "Uncut"},
+	{regexp.MustCompile(`(?i)\bimax\b`), "IMAX"},
+	{regexp.MustCompile(`(?i)\bremastered\b`), "Remastered"},
+	{regexp.MustCompile(`(?i)\bcriterion(?:[ ._-]collection)?\b`), "Criterion"},
+	{regexp.MustCompile(`(?i)\bspecial[ ._-]edition\b`), "Special Edition"},
+	{regexp.MustCompile(`(?i)\bcollector'?s[ ._-]edition\b`), "Collector's Edition"},
+	{regexp.MustCompile(`(?i)\b\d{1,3}(?:th|st|nd|rd)[ ._-]anniversary(?:[ ._-]edition)?\b`), "Anniversary Edition"},
+}
+
+// parseEdition returns the edition tags in a release name or filename, in the order they appear,
+// such as "Extended Remastered", or "" for the standard edition
+func parseEdition(title string) string {
+	type match struct {
+		index int
+		name  string
+	}
+
+	var matches []match
+	for _, edition := range editionPatterns {
+		if location := edition.pattern.FindStringIndex(title); location != nil {
+			matches = append(matches, match{index: location[0], name: edition.name})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].index < matches[j].index })
+
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.name
+	}
+	return strings.Join(names, " ")
+}
+
+// editionChangeRejection returns why a file or release of one edition may not replace an
+// existing file of another, or "" when the replacement keeps the edition or is allowed. Only
+// files with an edition are protected, so a standard edition can still be upgraded to any cut.
+func editionChangeRejection(existingEdition, newEdition string, allowChange bool) string {
+	if allowChange || existingEdition == "" || strings.EqualFold(existingEdition, newEdition) {
+		return ""
+	}
+
+	replacement := "the standard edition"
+	if newEdition != "" {
+		replacement = newEdition
+	}
+	return fmt.Sprintf("Existing file is the %s edition, replacing it with %s is not allowed", existingEdition, replacement)
+}
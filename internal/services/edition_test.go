@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEdition(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Blade.Runner.1982.Final.Cut.1080p.BluRay.x264-GROUP", "Final Cut"},
+		{"Kingdom.of.Heaven.2005.Directors.Cut.2160p.UHD.BluRay-GROUP", "Director's Cut"},
+		{"Aliens 1986 Extended Remastered 1080p BluRay", "Extended Remastered"},
+		{"Dune.2021.IMAX.2160p.WEB-DL.DDP5.1.Atmos-GROUP", "IMAX"},
+		{"Jaws.1975.45th.Anniversary.Edition.1080p.BluRay", "Anniversary Edition"},
+		{"The.Matrix.1999.1080p.BluRay.x264-GROUP", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseEdition(tt.title), tt.title)
+	}
+}
+
+func TestEditionChangeRejection(t *testing.T) {
+	assert.Empty(t, editionChangeRejection("", "Director's Cut", false))
+	assert.Empty(t, editionChangeRejection("Director's Cut", "director's cut", false))
+	assert.Empty(t, editionChangeRejection("Director's Cut", "", true))
+
+	assert.Equal(t, "Existing file is the Director's Cut edition, replacing it with the standard edition is not allowed",
+		editionChangeRejection("Director's Cut", "", false))
+	assert.Equal(t, "Existing file is the Extended edition, replacing it with Theatrical is not allowed",
+		editionChangeRejection("Extended", "Theatrical", false))
+}
+
+func TestNamingService_BuildFileName_Edition(t *testing.T) {
+	service := &NamingService{}
+	movie := &models.Movie{Title: "Blade Runner", Year: 1982}
+	config := &models.NamingConfig{StandardMovieFormat: "{Movie Title} ({Release Year}) {Edition Tags}"}
+
+	fileName, err := service.BuildFileName(movie, nil, nil, "Final Cut", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "Blade Runner (1982) Final Cut.mkv", fileName)
+
+	fileName, err = service.BuildFileName(movie, nil, nil, "", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "Blade Runner (1982).mkv", fileName)
+}
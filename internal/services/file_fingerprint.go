@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// frameHashSize is the side of the grayscale thumbnail a frame is reduced to before hashing
+	frameHashSize = 32
+	// frameHashLowFrequencies is the side of the block of lowest DCT frequencies the hash keeps
+	frameHashLowFrequencies = 8
+)
+
+// fingerprintFramePositions are the fractions of the running time frames are hashed at; the ends
+// are skipped since studio logos and credits differ between releases
+var fingerprintFramePositions = []float64{0.15, 0.3, 0.5, 0.7, 0.85}
+
+// probeDuration reads a file's running time in seconds with ffprobe
+func probeDuration(ctx context.Context, ffprobePath, path string) (float64, error) {
+	output, err := exec.CommandContext(ctx, ffprobePath, //nolint:gosec // G204: path comes from the library
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path).Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %w", err)
+	}
+
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("ffprobe reported no duration for %s", path)
+	}
+	return duration, nil
+}
+
+// hashFrame reads the frame at offset seconds as a small grayscale image with ffmpeg and returns
+// its perceptual hash
+func hashFrame(ctx context.Context, ffmpegPath, path string, offset float64) (uint64, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffmpegPath, //nolint:gosec // G204: path comes from the library
+		"-v", "error",
+		"-ss", strconv.FormatFloat(offset, 'f', 3, 64),
+		"-i", path,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf("scale=%d:%d,format=gray", frameHashSize, frameHashSize),
+		"-f", "rawvideo",
+		"-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("ffmpeg failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if stdout.Len() != frameHashSize*frameHashSize {
+		return 0, fmt.Errorf("ffmpeg returned %d bytes for a frame of %s", stdout.Len(), path)
+	}
+	return perceptualHash(stdout.Bytes()), nil
+}
+
+// perceptualHash computes the DCT hash of a frameHashSize square grayscale image: each bit says
+// whether one of the lowest frequencies is above their median, which survives re-encoding,
+// scaling and small color changes
+func perceptualHash(pixels []byte) uint64 {
+	const n = frameHashSize
+
+	// Separable 2D DCT-II: rows first, then columns
+	cosines := make([][]float64, n)
+	for u := range cosines {
+		cosines[u] = make([]float64, n)
+		for x := range cosines[u] {
+			cosines[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * n))
+		}
+	}
+
+	rows := make([][]float64, n)
+	for y := range rows {
+		rows[y] = make([]float64, frameHashLowFrequencies)
+		for u := range frameHashLowFrequencies {
+			var sum float64
+			for x := range n {
+				sum += float64(pixels[y*n+x]) * cosines[u][x]
+			}
+			rows[y][u] = sum
+		}
+	}
+
+	coefficients := make([]float64, 0, frameHashLowFrequencies*frameHashLowFrequencies)
+	for v := range frameHashLowFrequencies {
+		for u := range frameHashLowFrequencies {
+			var sum float64
+			for y := range n {
+				sum += rows[y][u] * cosines[v][y]
+			}
+			coefficients = append(coefficients, sum)
+		}
+	}
+
+	// The DC term is the overall brightness, which would dominate the median
+	sorted := append([]float64(nil), coefficients[1:]...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+	for i, coefficient := range coefficients {
+		if coefficient > median {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+// formatFrameHashes joins frame hashes for storage
+func formatFrameHashes(hashes []uint64) string {
+	parts := make([]string, len(hashes))
+	for i, hash := range hashes {
+		parts[i] = strconv.FormatUint(hash, 16)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseFrameHashes splits stored frame hashes, skipping malformed entries
+func parseFrameHashes(value string) []uint64 {
+	var hashes []uint64
+	for _, part := range strings.Split(value, ",") {
+		if hash, err := strconv.ParseUint(part, 16, 64); err == nil {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// frameDistance returns the mean number of differing bits between frame hashes taken at the same
+// positions, or -1 when the files have no positions in common
+func frameDistance(a, b []uint64) float64 {
+	count := min(len(a), len(b))
+	if count == 0 {
+		return -1
+	}
+
+	total := 0
+	for i := range count {
+		total += bits.OnesCount64(a[i] ^ b[i])
+	}
+	return float64(total) / float64(count)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// texturedFrame returns a deterministic patterned frame, mirrored along the diagonal when transposed
+func texturedFrame(transposed bool, offset int) []byte {
+	pixels := make([]byte, frameHashSize*frameHashSize)
+	for y := range frameHashSize {
+		for x := range frameHashSize {
+			a, b := x, y
+			if transposed {
+				a, b = y, x
+			}
+			pixels[y*frameHashSize+x] = byte((a*37+b*91+a*b*13)%200 + offset)
+		}
+	}
+	return pixels
+}
+
+func TestPerceptualHash(t *testing.T) {
+	original := perceptualHash(texturedFrame(false, 0))
+
+	// A brighter copy of the same frame hashes the same or nearly so
+	brighter := perceptualHash(texturedFrame(false, 20))
+	assert.LessOrEqual(t, frameDistance([]uint64{original}, []uint64{brighter}), 4.0)
+
+	// A different frame doesn't
+	different := perceptualHash(texturedFrame(true, 0))
+	assert.Greater(t, frameDistance([]uint64{original}, []uint64{different}), sameContentMaxFrameDistance)
+}
+
+func TestFrameHashes_RoundTrip(t *testing.T) {
+	hashes := []uint64{0, 0xdeadbeef, ^uint64(0)}
+	assert.Equal(t, hashes, parseFrameHashes(formatFrameHashes(hashes)))
+	assert.Empty(t, parseFrameHashes(""))
+
+	assert.Equal(t, float64(-1), frameDistance(nil, hashes))
+	assert.Equal(t, float64(0), frameDistance(hashes, hashes))
+}
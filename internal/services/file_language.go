@@ -0,0 +1,310 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// mediaLanguageSeparator separates the stream language codes stored in MediaInfo, as in "eng/ger"
+const mediaLanguageSeparator = "/"
+
+// releaseLanguageRegex matches language names in release names and filenames
+var releaseLanguageRegex = regexp.MustCompile(`(?i)\b(english|french|spanish|german|italian|danish|dutch|` +
+	`japanese|chinese|russian|polish|swedish|norwegian|finnish|turkish|portuguese|greek|korean|hungarian|` +
+	`hebrew|czech|hindi|romanian|thai|arabic|ukrainian)\b`)
+
+// releaseFrenchRegex matches the French scene's tags for French audio, such as VFF for a France
+// French dub
+var releaseFrenchRegex = regexp.MustCompile(`(?i)\b(?:truefrench|vff|vfq)\b`)
+
+// Release name tags other than language names: MULTi and DUAL releases have several audio
+// tracks, VOSTFR releases the original audio with French subtitles, and NORDiC releases subtitles
+// in the Nordic languages
+var (
+	releaseMultiRegex  = regexp.MustCompile(`(?i)\bmulti\b`)
+	releaseDualRegex   = regexp.MustCompile(`(?i)\bdual(?:[ ._-]?audio)?\b`)
+	releaseVostfrRegex = regexp.MustCompile(`(?i)\b(?:vostfr|subfrench)\b`)
+	releaseNordicRegex = regexp.MustCompile(`(?i)\bnordic\b`)
+)
+
+// nordicLanguages are the subtitle languages of NORDiC releases
+var nordicLanguages = []string{"Danish", "Finnish", "Norwegian", "Swedish"}
+
+// streamLanguages lists the language tags of a file's audio and subtitle streams, in stream order
+type streamLanguages struct {
+	audio        []string
+	subtitles    []string
+	audioStreams int
+}
+
+// apply stores the stream languages on mediaInfo
+func (l streamLanguages) apply(mediaInfo *models.MediaInfo) {
+	mediaInfo.AudioStreamCount = l.audioStreams
+	mediaInfo.AudioLanguages = strings.Join(l.audio, mediaLanguageSeparator)
+	mediaInfo.Subtitles = strings.Join(l.subtitles, mediaLanguageSeparator)
+}
+
+// add records the language tag of one stream, ignoring untagged streams and repeated languages
+func (l *streamLanguages) add(streamType, language string) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	switch streamType {
+	case "audio":
+		l.audioStreams++
+		if language != "" && !slices.Contains(l.audio, language) {
+			l.audio = append(l.audio, language)
+		}
+	case "subtitle", "text":
+		if language != "" && !slices.Contains(l.subtitles, language) {
+			l.subtitles = append(l.subtitles, language)
+		}
+	}
+}
+
+// ffprobeStreamLanguages reads the stream language tags from ffprobe's -show_streams JSON output
+func ffprobeStreamLanguages(output string) (streamLanguages, error) {
+	var probe struct {
+		Streams []struct {
+			CodecType string            `json:"codec_type"`
+			Tags      map[string]string `json:"tags"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal([]byte(output), &probe); err != nil {
+		return streamLanguages{}, fmt.Errorf("invalid ffprobe output: %w", err)
+	}
+
+	var languages streamLanguages
+	for _, stream := range probe.Streams {
+		languages.add(stream.CodecType, stream.Tags["language"])
+	}
+	return languages, nil
+}
+
+// mediaInfoStreamLanguages reads the track languages from mediainfo's --Output=JSON output
+func mediaInfoStreamLanguages(output string) (streamLanguages, error) {
+	var probe struct {
+		Media struct {
+			Track []struct {
+				Type     string `json:"@type"`
+				Language string `json:"Language"`
+			} `json:"track"`
+		} `json:"media"`
+	}
+	if err := json.Unmarshal([]byte(output), &probe); err != nil {
+		return streamLanguages{}, fmt.Errorf("invalid mediainfo output: %w", err)
+	}
+
+	var languages streamLanguages
+	for _, track := range probe.Media.Track {
+		languages.add(strings.ToLower(track.Type), track.Language)
+	}
+	return languages, nil
+}
+
+// mediaInfoLanguages returns the known languages of a file's audio streams
+func mediaInfoLanguages(mediaInfo *models.MediaInfo) []models.Language {
+	if mediaInfo == nil {
+		return nil
+	}
+	return isoCodeLanguages(mediaInfo.AudioLanguages)
+}
+
+// isoCodeLanguages converts separated stream language codes to languages, skipping unknown codes
+func isoCodeLanguages(codes string) []models.Language {
+	var languages []models.Language
+	for _, code := range strings.Split(codes, mediaLanguageSeparator) {
+		if language, ok := streamLanguage(code); ok && !containsLanguage(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// streamLanguage returns the language of a stream language tag, which is normally an ISO code
+// but may be a language name in media info stored by older versions
+func streamLanguage(tag string) (models.Language, bool) {
+	if language, ok := models.LanguageByISOCode(tag); ok {
+		return language, true
+	}
+	return models.LanguageByName(strings.TrimSpace(tag))
+}
+
+// parseLanguages returns the languages named in a release name or filename, or English when
+// none are named, as most releases without a language tag are
+func parseLanguages(title string) []models.Language {
+	languages := namedLanguages(title)
+	if len(languages) == 0 {
+		english, _ := models.LanguageByName("English")
+		languages = append(languages, english)
+	}
+	return languages
+}
+
+// namedLanguages returns the languages a release name or filename names
+func namedLanguages(title string) []models.Language {
+	var languages []models.Language
+	for _, match := range releaseLanguageRegex.FindAllString(title, -1) {
+		if language, ok := models.LanguageByName(match); ok && !containsLanguage(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	if french, _ := models.LanguageByName("French"); releaseFrenchRegex.MatchString(title) &&
+		!containsLanguage(languages, french) {
+		languages = append(languages, french)
+	}
+	return languages
+}
+
+// releaseLanguages are the audio and subtitle languages of a release
+type releaseLanguages struct {
+	audio     []models.Language
+	subtitles []models.Language
+}
+
+// parseReleaseLanguages returns the languages a release name tags. MULTi releases have the
+// indexer's multi languages, and like DUAL releases, the original audio besides the languages
+// named. Releases tagging no audio language are English, as parseLanguages assumes. Original
+// stands for the movie's original language until resolveOriginalLanguage replaces it.
+func parseReleaseLanguages(title string, multiLanguages []models.Language) releaseLanguages {
+	parsed := releaseLanguages{audio: namedLanguages(title)}
+	add := func(languages []models.Language, language models.Language) []models.Language {
+		if containsLanguage(languages, language) {
+			return languages
+		}
+		return append(languages, language)
+	}
+
+	multi := releaseMultiRegex.MatchString(title)
+	if multi {
+		for _, language := range multiLanguages {
+			parsed.audio = add(parsed.audio, language)
+		}
+	}
+	if multi || releaseDualRegex.MatchString(title) {
+		parsed.audio = add(parsed.audio, models.LanguageOriginal)
+	}
+	if releaseVostfrRegex.MatchString(title) {
+		french, _ := models.LanguageByName("French")
+		parsed.audio = add(parsed.audio, models.LanguageOriginal)
+		parsed.subtitles = add(parsed.subtitles, french)
+	}
+	if releaseNordicRegex.MatchString(title) {
+		for _, name := range nordicLanguages {
+			language, _ := models.LanguageByName(name)
+			parsed.subtitles = add(parsed.subtitles, language)
+		}
+	}
+
+	if len(parsed.audio) == 0 {
+		english, _ := models.LanguageByName("English")
+		parsed.audio = append(parsed.audio, english)
+	}
+	return parsed
+}
+
+// resolveOriginalLanguage replaces Original in language names with the movie's original language.
+// Original is kept while the movie, or its original language, is unknown.
+func resolveOriginalLanguage(names []string, movie *models.Movie) []string {
+	if movie == nil || movie.OriginalLanguage.Name == "" {
+		return names
+	}
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.EqualFold(name, models.LanguageOriginal.Name) {
+			name = movie.OriginalLanguage.Name
+		}
+		if !slices.ContainsFunc(resolved, func(r string) bool { return strings.EqualFold(r, name) }) {
+			resolved = append(resolved, name)
+		}
+	}
+	return resolved
+}
+
+// releaseLanguageRejection returns why a release is rejected when it lacks the audio language the
+// quality profile wants, or "" when it has it. Profiles wanting any language don't reject, nor do
+// releases whose original audio is of a movie with an unknown language.
+func releaseLanguageRejection(wanted string, languages []string, movie *models.Movie) string {
+	if strings.EqualFold(wanted, models.LanguageOriginal.Name) && movie != nil {
+		wanted = movie.OriginalLanguage.Name
+	}
+	language, ok := models.LanguageByName(wanted)
+	if !ok || len(languages) == 0 {
+		return ""
+	}
+	for _, name := range languages {
+		if strings.EqualFold(name, language.Name) || strings.EqualFold(name, models.LanguageOriginal.Name) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("Language %s is not wanted in profile", strings.Join(languages, ", "))
+}
+
+// languageNames returns the names of languages
+func languageNames(languages []models.Language) []string {
+	names := make([]string, 0, len(languages))
+	for _, language := range languages {
+		names = append(names, language.Name)
+	}
+	return names
+}
+
+// fileLanguages returns a file's languages from its audio streams when media info was probed
+// and the streams are tagged, and otherwise the languages parsed from its name
+func fileLanguages(mediaInfo *models.MediaInfo, parsed []models.Language) []models.Language {
+	if languages := mediaInfoLanguages(mediaInfo); len(languages) > 0 {
+		return languages
+	}
+	return parsed
+}
+
+// languageUpgrade compares the languages of an existing file and its replacement against the
+// quality profile's language. It reports whether the replacement adds the wanted language, and
+// the rejection reason when the replacement would lose it. Profiles wanting "any" language, or
+// the movie's "original" language, don't take part.
+func languageUpgrade(wanted string, existing, candidate []models.Language) (bool, string) {
+	language, ok := models.LanguageByName(wanted)
+	if !ok || len(existing) == 0 || len(candidate) == 0 {
+		return false, ""
+	}
+
+	existingHas := containsLanguage(existing, language)
+	candidateHas := containsLanguage(candidate, language)
+	switch {
+	case existingHas && !candidateHas:
+		return false, fmt.Sprintf("Existing file has %s audio, the new file does not", language.Name)
+	case !existingHas && candidateHas:
+		return true, ""
+	}
+	return false, ""
+}
+
+// formatLanguageToken formats separated stream language codes for the {MediaInfo AudioLanguages}
+// and {MediaInfo SubtitleLanguages} naming tokens, as in "[EN+DE]", keeping codes it cannot map
+func formatLanguageToken(codes string) string {
+	var parts []string
+	for _, code := range strings.Split(codes, mediaLanguageSeparator) {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		if language, ok := streamLanguage(code); ok && language.ISOCode() != "" {
+			code = language.ISOCode()
+		}
+		if part := strings.ToUpper(code); !slices.Contains(parts, part) {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, "+") + "]"
+}
+
+// containsLanguage reports whether languages includes language
+func containsLanguage(languages []models.Language, language models.Language) bool {
+	return slices.ContainsFunc(languages, func(l models.Language) bool { return l.ID == language.ID })
+}
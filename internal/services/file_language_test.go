@@ -0,0 +1,133 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFFProbeStreamLanguages(t *testing.T) {
+	output := `{"streams": [
+		{"codec_type": "video", "tags": {"language": "und"}},
+		{"codec_type": "audio", "tags": {"language": "eng"}},
+		{"codec_type": "audio", "tags": {"language": "ger"}},
+		{"codec_type": "audio"},
+		{"codec_type": "audio", "tags": {"language": "ENG"}},
+		{"codec_type": "subtitle", "tags": {"language": "spa"}}
+	]}`
+
+	languages, err := ffprobeStreamLanguages(output)
+	require.NoError(t, err)
+
+	var mediaInfo models.MediaInfo
+	languages.apply(&mediaInfo)
+	assert.Equal(t, 4, mediaInfo.AudioStreamCount)
+	assert.Equal(t, "eng/ger", mediaInfo.AudioLanguages)
+	assert.Equal(t, "spa", mediaInfo.Subtitles)
+
+	_, err = ffprobeStreamLanguages("not json")
+	assert.Error(t, err)
+}
+
+func TestFileLanguages(t *testing.T) {
+	english, _ := models.LanguageByName("English")
+	german, _ := models.LanguageByName("German")
+	french, _ := models.LanguageByName("French")
+	parsed := []models.Language{french}
+
+	assert.Equal(t, []models.Language{german, english},
+		fileLanguages(&models.MediaInfo{AudioLanguages: "ger/und/eng"}, parsed))
+	assert.Equal(t, parsed, fileLanguages(&models.MediaInfo{AudioLanguages: "und"}, parsed))
+	assert.Equal(t, parsed, fileLanguages(nil, parsed))
+
+	assert.Equal(t, []models.Language{french}, parseLanguages("Amelie.2001.FRENCH.1080p.BluRay.x264"))
+	assert.Equal(t, []models.Language{english}, parseLanguages("The.Matrix.1999.1080p.BluRay.x264"))
+}
+
+func TestLanguageUpgrade(t *testing.T) {
+	english, _ := models.LanguageByName("English")
+	german, _ := models.LanguageByName("German")
+
+	upgrade, reason := languageUpgrade("English", []models.Language{german}, []models.Language{german, english})
+	assert.True(t, upgrade)
+	assert.Empty(t, reason)
+
+	upgrade, reason = languageUpgrade("English", []models.Language{english}, []models.Language{german})
+	assert.False(t, upgrade)
+	assert.Equal(t, "Existing file has English audio, the new file does not", reason)
+
+	upgrade, reason = languageUpgrade("any", []models.Language{english}, []models.Language{german})
+	assert.False(t, upgrade)
+	assert.Empty(t, reason)
+}
+
+func TestFormatLanguageToken(t *testing.T) {
+	assert.Equal(t, "[EN+DE]", formatLanguageToken("eng/ger"))
+	assert.Equal(t, "[EN+XX]", formatLanguageToken("English/en/xx"))
+	assert.Empty(t, formatLanguageToken(""))
+}
+
+func TestParseReleaseLanguages(t *testing.T) {
+	language := func(name string) models.Language {
+		l, ok := models.LanguageByName(name)
+		require.True(t, ok, name)
+		return l
+	}
+	english, french, german := language("English"), language("French"), language("German")
+	original := models.LanguageOriginal
+	nordic := []models.Language{language("Danish"), language("Finnish"), language("Norwegian"), language("Swedish")}
+
+	tests := []struct {
+		title          string
+		multiLanguages []models.Language
+		audio          []models.Language
+		subtitles      []models.Language
+	}{
+		{"The.Matrix.1999.1080p.BluRay.x264-GROUP", nil, []models.Language{english}, nil},
+		{"Amelie.2001.FRENCH.1080p.BluRay.x264", nil, []models.Language{french}, nil},
+		{"The.Matrix.1999.MULTi.1080p.BluRay.x264-GROUP", nil, []models.Language{original}, nil},
+		{"The.Matrix.1999.MULTi.VFF.1080p.BluRay.x264-GROUP", nil, []models.Language{french, original}, nil},
+		{
+			"The.Matrix.1999.MULTi.1080p.BluRay.x264-GROUP", []models.Language{french, english},
+			[]models.Language{french, english, original}, nil,
+		},
+		{"The.Matrix.1999.German.DUAL.1080p.BluRay.x264", nil, []models.Language{german, original}, nil},
+		{"The.Matrix.1999.VOSTFR.1080p.WEB-DL.x264", nil, []models.Language{original}, []models.Language{french}},
+		{"The.Matrix.1999.NORDiC.1080p.BluRay.x264", nil, []models.Language{english}, nordic},
+		// MULTi applies only to releases tagged with it
+		{"The.Matrix.1999.1080p.BluRay.x264-GROUP", []models.Language{french}, []models.Language{english}, nil},
+	}
+	for _, tt := range tests {
+		parsed := parseReleaseLanguages(tt.title, tt.multiLanguages)
+		assert.Equal(t, tt.audio, parsed.audio, tt.title)
+		assert.Equal(t, tt.subtitles, parsed.subtitles, tt.title)
+	}
+}
+
+func TestResolveOriginalLanguage(t *testing.T) {
+	french, _ := models.LanguageByName("French")
+	movie := &models.Movie{OriginalLanguage: french}
+
+	assert.Equal(t, []string{"French", "English"}, resolveOriginalLanguage([]string{"Original", "English"}, movie))
+	assert.Equal(t, []string{"French"}, resolveOriginalLanguage([]string{"French", "Original"}, movie))
+	assert.Equal(t, []string{"Original"}, resolveOriginalLanguage([]string{"Original"}, &models.Movie{}))
+	assert.Equal(t, []string{"Original"}, resolveOriginalLanguage([]string{"Original"}, nil))
+}
+
+func TestReleaseLanguageRejection(t *testing.T) {
+	french, _ := models.LanguageByName("French")
+	movie := &models.Movie{OriginalLanguage: french}
+
+	assert.Empty(t, releaseLanguageRejection("english", []string{"French", "English"}, movie))
+	assert.Equal(t, "Language French, German is not wanted in profile",
+		releaseLanguageRejection("english", []string{"French", "German"}, movie))
+	assert.Empty(t, releaseLanguageRejection("original", []string{"French"}, movie))
+	assert.Equal(t, "Language English is not wanted in profile",
+		releaseLanguageRejection("original", []string{"English"}, movie))
+
+	// Profiles wanting any language, and unresolved original audio, don't reject
+	assert.Empty(t, releaseLanguageRejection("any", []string{"German"}, movie))
+	assert.Empty(t, releaseLanguageRejection("english", []string{"Original"}, nil))
+}
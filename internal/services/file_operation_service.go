@@ -13,6 +13,7 @@ import (
 type FileOperationService struct {
 	db     *database.Database
 	logger *logger.Logger
+	pause  *ImportPause
 }
 
 // NewFileOperationService creates a new instance of FileOperationService
@@ -23,6 +24,11 @@ func NewFileOperationService(db *database.Database, logger *logger.Logger) *File
 	}
 }
 
+// UseImportPause reports pause in the operation summary
+func (s *FileOperationService) UseImportPause(pause *ImportPause) {
+	s.pause = pause
+}
+
 // CreateOperation creates a new file operation record
 func (s *FileOperationService) CreateOperation(
 	operationType models.FileOperationType,
@@ -218,9 +224,29 @@ func (s *FileOperationService) GetOperationSummary() (*models.FileOperationSumma
 		*sc.Count = int(count)
 	}
 
+	imports, err := s.GetImportQueueStatus()
+	if err != nil {
+		return nil, err
+	}
+	summary.Imports = *imports
+
 	return summary, nil
 }
 
+// GetImportQueueStatus reports whether imports are paused and how many files are queued for
+// when they resume
+func (s *FileOperationService) GetImportQueueStatus() (*models.ImportQueueStatus, error) {
+	var pending int64
+	if err := s.db.GORM.Model(&models.FileOrganization{}).
+		Where("status = ?", models.OrganizationStatusPending).Count(&pending).Error; err != nil {
+		return nil, fmt.Errorf("failed to count pending file organizations: %w", err)
+	}
+
+	status := s.pause.Status()
+	status.Pending = int(pending)
+	return &status, nil
+}
+
 // CleanupOldOperations removes old completed/failed operations
 func (s *FileOperationService) CleanupOldOperations(olderThanDays int) error {
 	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -13,6 +14,7 @@ import (
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
 )
 
 // FileOrganizationService provides operations for organizing and managing movie files
@@ -38,10 +40,12 @@ func NewFileOrganizationService(
 	}
 }
 
-// OrganizeFile organizes a single file according to naming configuration
+// OrganizeFile organizes a single file according to naming configuration, as the file of
+// version, or of the movie itself when version is nil
 func (s *FileOrganizationService) OrganizeFile(
 	ctx context.Context, sourcePath string,
 	movie *models.Movie,
+	version *models.MovieVersion,
 	namingConfig *models.NamingConfig,
 	operation models.FileOperation,
 ) (*models.FileOrganizationResult, error) {
@@ -53,7 +57,7 @@ func (s *FileOrganizationService) OrganizeFile(
 		return s.buildFailureResult(sourcePath, err.Error()), err
 	}
 
-	mediaInfo, destinationPath, err := s.prepareOrganization(ctx, fileOrg, movie, namingConfig, sourcePath)
+	mediaInfo, destinationPath, err := s.prepareOrganization(ctx, fileOrg, movie, version, namingConfig, sourcePath)
 	if err != nil {
 		s.handleOrganizationFailure(fileOrg, err.Error())
 		return s.buildFailureResult(sourcePath, err.Error()), err
@@ -65,7 +69,7 @@ func (s *FileOrganizationService) OrganizeFile(
 		return s.buildFailureResult(sourcePath, err.Error()), err
 	}
 
-	s.finalizeOrganization(fileOrg, movieFile, movie, mediaInfo, destinationPath)
+	s.finalizeOrganization(fileOrg, movieFile, movie, version, mediaInfo, destinationPath)
 
 	processingTime := time.Since(start)
 	s.logOrganizationCompletion(sourcePath, destinationPath, processingTime)
@@ -89,6 +93,8 @@ func (s *FileOrganizationService) initializeFileOrganization(
 		Operation:        operation,
 		OriginalFileName: filepath.Base(sourcePath),
 		Size:             fileInfo.Size(),
+		Edition:          parseEdition(filepath.Base(sourcePath)),
+		Languages:        parseLanguages(filepath.Base(sourcePath)),
 	}
 
 	// Parse quality from filename
@@ -103,7 +109,7 @@ func (s *FileOrganizationService) initializeFileOrganization(
 // prepareOrganization handles preparation steps for file organization
 func (s *FileOrganizationService) prepareOrganization(
 	ctx context.Context, fileOrg *models.FileOrganization, movie *models.Movie,
-	namingConfig *models.NamingConfig, sourcePath string,
+	version *models.MovieVersion, namingConfig *models.NamingConfig, sourcePath string,
 ) (*models.MediaInfo, string, error) {
 	// Extract media info if enabled
 	var mediaInfo *models.MediaInfo
@@ -112,7 +118,9 @@ func (s *FileOrganizationService) prepareOrganization(
 	}
 
 	// Generate destination path
-	destinationPath, err := s.namingService.BuildMovieFilePath(movie, fileOrg.Quality, mediaInfo, namingConfig)
+	destinationPath, err := s.namingService.BuildVersionFilePath(
+		movie, version, fileOrg.Quality, mediaInfo, fileOrg.Edition, namingConfig,
+	)
 	if err != nil {
 		s.logger.Error("Failed to build destination path", "error", err)
 		return nil, "", fmt.Errorf("failed to build destination path: %w", err)
@@ -184,11 +192,16 @@ func (s *FileOrganizationService) executeFileOperation(
 // finalizeOrganization completes the organization process
 func (s *FileOrganizationService) finalizeOrganization(
 	fileOrg *models.FileOrganization, movieFile *models.MovieFile,
-	movie *models.Movie, mediaInfo *models.MediaInfo, destinationPath string,
+	movie *models.Movie, version *models.MovieVersion, mediaInfo *models.MediaInfo, destinationPath string,
 ) {
 	// Update movie file record
 	if movieFile != nil {
 		movieFile.MovieID = movie.ID
+		if version != nil {
+			movieFile.VersionID = version.ID
+		}
+		movieFile.Edition = fileOrg.Edition
+		movieFile.Languages = fileLanguages(mediaInfo, fileOrg.Languages)
 		if fileOrg.Quality != nil {
 			movieFile.Quality = *fileOrg.Quality
 		}
@@ -429,14 +442,16 @@ func (s *FileOrganizationService) createMovieFileRecord(sourcePath, destPath str
 	return movieFile
 }
 
-// hardlinkFile creates a hard link from source to destination
+// hardlinkFile creates a hard link from source to destination, copying the file instead when
+// it can't be linked, such as across filesystems
 func (s *FileOrganizationService) hardlinkFile(
 	sourcePath, destPath string,
-	_ *models.NamingConfig,
+	namingConfig *models.NamingConfig,
 ) (*models.MovieFile, error) {
 	// Create hard link
 	if err := os.Link(sourcePath, destPath); err != nil {
-		return nil, fmt.Errorf("failed to create hard link: %w", err)
+		s.logger.Debug("Failed to create hard link, copying instead", "source", sourcePath, "error", err)
+		return s.copyFile(sourcePath, destPath, namingConfig)
 	}
 
 	// Create movie file record
@@ -568,6 +583,75 @@ func (s *FileOrganizationService) RetryFailedOrganizations() error {
 	return nil
 }
 
+// QueueFile records file as a pending organization for movie, to be organized once imports resume.
+// A file already queued keeps its existing record and priority.
+func (s *FileOrganizationService) QueueFile(
+	file models.ImportableFile, movie *models.Movie, operation models.FileOperation,
+) (*models.FileOrganization, error) {
+	var existing models.FileOrganization
+	err := s.db.GORM.Where("source_path = ? AND status = ?", file.Path, models.OrganizationStatusPending).
+		First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check queued file organizations: %w", err)
+	}
+
+	fileOrg, err := s.initializeFileOrganization(file.Path, movie, operation)
+	if err != nil {
+		return nil, err
+	}
+	if file.Quality != nil {
+		fileOrg.Quality = file.Quality
+	}
+	fileOrg.ReleaseGroup = file.ReleaseGroup
+	fileOrg.Status = models.OrganizationStatusPending
+	fileOrg.StatusMessage = "Queued while imports are paused"
+
+	if err := s.saveFileOrganization(fileOrg); err != nil {
+		return nil, fmt.Errorf("failed to queue file organization: %w", err)
+	}
+
+	s.logger.Info("Queued file organization", "id", fileOrg.ID, "path", file.Path, "movie", movie.Title)
+	return fileOrg, nil
+}
+
+// GetPendingOrganizations returns the pending file organizations in the order they are processed:
+// highest priority first, then oldest first
+func (s *FileOrganizationService) GetPendingOrganizations() ([]models.FileOrganization, error) {
+	var organizations []models.FileOrganization
+
+	if err := s.db.GORM.Where("status = ?", models.OrganizationStatusPending).
+		Order("priority desc, created_at asc, id asc").Find(&organizations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending file organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+// SetPriority sets the priority of a pending file organization
+func (s *FileOrganizationService) SetPriority(id, priority int) (*models.FileOrganization, error) {
+	organization, err := s.GetFileOrganizationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if organization.Status != models.OrganizationStatusPending {
+		return nil, models.ValidationError{
+			Field:   "status",
+			Message: fmt.Sprintf("only pending file organizations can be prioritized, this one is %s", organization.Status),
+		}
+	}
+
+	if err := s.db.GORM.Model(organization).Update("priority", priority).Error; err != nil {
+		return nil, fmt.Errorf("failed to update file organization priority: %w", err)
+	}
+	organization.Priority = priority
+
+	return organization, nil
+}
+
 // CleanupOldOrganizations removes old completed file organization records
 func (s *FileOrganizationService) CleanupOldOrganizations(olderThanDays int) error {
 	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
@@ -3,12 +3,18 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/radarr/radarr-go/internal/certificates"
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
@@ -346,8 +352,9 @@ func (d *DiskSpaceHealthChecker) getDiskSpaceInfo(path string) *models.DiskSpace
 
 // SystemResourcesHealthChecker checks system resource usage
 type SystemResourcesHealthChecker struct {
-	logger *logger.Logger
-	config models.HealthCheckConfig
+	logger        *logger.Logger
+	config        models.HealthCheckConfig
+	systemChecker SystemResourceCheckerInterface
 }
 
 // Name returns the human-readable name of this health checker
@@ -386,6 +393,12 @@ func (s *SystemResourcesHealthChecker) Check(_ context.Context) models.HealthChe
 
 	// Evaluate system resource issues
 	issues := s.evaluateSystemIssues(memoryStats)
+
+	// Host statistics that the platform cannot provide are left out
+	hostStats := s.collectHostStats()
+	s.populateHostDetails(&result, hostStats)
+	issues = append(issues, s.evaluateHostIssues(hostStats)...)
+
 	s.finalizeSystemResult(&result, issues)
 
 	return result
@@ -466,6 +479,112 @@ func (s *SystemResourcesHealthChecker) evaluateSystemIssues(stats memoryStats) [
 	return issues
 }
 
+type hostStats struct {
+	cpuPercent     float64
+	hasCPU         bool
+	memoryUsedMB   float64
+	memoryTotalMB  float64
+	memoryPercent  float64
+	hasMemory      bool
+	loadAverage    float64
+	hasLoadAverage bool
+	uptimeSeconds  int64
+	hasUptime      bool
+	cpuCount       int
+}
+
+func (s *SystemResourcesHealthChecker) collectHostStats() hostStats {
+	stats := hostStats{cpuCount: runtime.NumCPU()}
+	if s.systemChecker == nil {
+		return stats
+	}
+
+	if cpu, err := s.systemChecker.GetCPUUsage(); err == nil {
+		stats.cpuPercent, stats.hasCPU = cpu, true
+	}
+	if used, total, err := s.systemChecker.GetMemoryUsage(); err == nil && total > 0 {
+		stats.memoryUsedMB = float64(used) / 1024 / 1024
+		stats.memoryTotalMB = float64(total) / 1024 / 1024
+		stats.memoryPercent = float64(used) / float64(total) * 100
+		stats.hasMemory = true
+	}
+	if load, err := s.systemChecker.GetLoadAverage(); err == nil {
+		stats.loadAverage, stats.hasLoadAverage = load, true
+	}
+	if uptime, err := s.systemChecker.GetSystemUptime(); err == nil {
+		stats.uptimeSeconds, stats.hasUptime = uptime, true
+	}
+	return stats
+}
+
+func (s *SystemResourcesHealthChecker) populateHostDetails(result *models.HealthCheckExecution, stats hostStats) {
+	result.Details["cpu_count"] = stats.cpuCount
+	if stats.hasCPU {
+		result.Details["cpu_usage_percent"] = stats.cpuPercent
+	}
+	if stats.hasMemory {
+		result.Details["system_memory_used_mb"] = stats.memoryUsedMB
+		result.Details["system_memory_total_mb"] = stats.memoryTotalMB
+		result.Details["system_memory_usage_percent"] = stats.memoryPercent
+	}
+	if stats.hasLoadAverage {
+		result.Details["load_average"] = stats.loadAverage
+	}
+	if stats.hasUptime {
+		result.Details["uptime_seconds"] = stats.uptimeSeconds
+	}
+}
+
+func (s *SystemResourcesHealthChecker) evaluateHostIssues(stats hostStats) []models.HealthIssue {
+	var issues []models.HealthIssue
+
+	// A single CPU sample can spike, so high usage is only ever a warning
+	if stats.hasCPU && stats.cpuPercent > 90 {
+		issues = append(issues, models.HealthIssue{
+			Type:     s.Type(),
+			Source:   s.Name(),
+			Severity: models.HealthSeverityWarning,
+			Message:  fmt.Sprintf("High CPU usage: %.1f%%", stats.cpuPercent),
+		})
+	}
+
+	if stats.hasMemory {
+		if stats.memoryPercent > 95 {
+			issues = append(issues, models.HealthIssue{
+				Type:     s.Type(),
+				Source:   s.Name(),
+				Severity: models.HealthSeverityCritical,
+				Message: fmt.Sprintf(
+					"Critical system memory usage: %.1f%% (%.0f MB / %.0f MB)",
+					stats.memoryPercent, stats.memoryUsedMB, stats.memoryTotalMB,
+				),
+			})
+		} else if stats.memoryPercent > 90 {
+			issues = append(issues, models.HealthIssue{
+				Type:     s.Type(),
+				Source:   s.Name(),
+				Severity: models.HealthSeverityWarning,
+				Message: fmt.Sprintf(
+					"High system memory usage: %.1f%% (%.0f MB / %.0f MB)",
+					stats.memoryPercent, stats.memoryUsedMB, stats.memoryTotalMB,
+				),
+			})
+		}
+	}
+
+	// A load average above twice the core count means work is queuing for the CPU
+	if stats.hasLoadAverage && stats.cpuCount > 0 && stats.loadAverage > float64(2*stats.cpuCount) {
+		issues = append(issues, models.HealthIssue{
+			Type:     s.Type(),
+			Source:   s.Name(),
+			Severity: models.HealthSeverityWarning,
+			Message:  fmt.Sprintf("High load average: %.2f on %d CPUs", stats.loadAverage, stats.cpuCount),
+		})
+	}
+
+	return issues
+}
+
 func (s *SystemResourcesHealthChecker) finalizeSystemResult(
 	result *models.HealthCheckExecution, issues []models.HealthIssue,
 ) {
@@ -488,7 +607,17 @@ func (s *SystemResourcesHealthChecker) finalizeSystemResult(
 	}
 }
 
-// RootFolderHealthChecker checks accessibility of configured root folders
+// Servarr wiki sections linked from root folder and recycle bin issues
+const (
+	rootFolderWikiURL    = "https://wiki.servarr.com/radarr/system#missing-root-folder"
+	readOnlyMountWikiURL = "https://wiki.servarr.com/radarr/system#read-only-mount"
+	permissionsWikiURL   = "https://wiki.servarr.com/radarr/system#permissions"
+	staleMountWikiURL    = "https://wiki.servarr.com/radarr/system#network-mount-unavailable"
+	recycleBinWikiURL    = "https://wiki.servarr.com/radarr/system#recycle-bin"
+)
+
+// RootFolderHealthChecker checks that the configured root folders and the recycle bin exist, are
+// mounted read-write, and can be used by Radarr
 type RootFolderHealthChecker struct {
 	db     *database.Database
 	logger *logger.Logger
@@ -526,10 +655,12 @@ func (r *RootFolderHealthChecker) Check(_ context.Context) models.HealthCheckExe
 	}
 
 	var issues []models.HealthIssue
-	// TODO: Get root folders from config service
-	// For now, check the data directory
-	paths := []string{r.config.Storage.DataDirectory}
+	paths := r.rootFolderPaths()
 	checkedPaths := r.checkAllPaths(paths, &issues)
+	if recycleBin := r.recycleBinPath(); recycleBin != "" {
+		issues = append(issues, r.checkRecycleBin(recycleBin, paths)...)
+		result.Details["recycle_bin"] = recycleBin
+	}
 
 	result.Details["checked_paths"] = checkedPaths
 	r.finalizeRootFolderResult(&result, issues)
@@ -537,6 +668,33 @@ func (r *RootFolderHealthChecker) Check(_ context.Context) models.HealthCheckExe
 	return result
 }
 
+// rootFolderPaths returns the configured root folders, or the data directory when none are
+// configured or the database is unavailable
+func (r *RootFolderHealthChecker) rootFolderPaths() []string {
+	var paths []string
+	if r.db != nil {
+		if err := r.db.GORM.Model(&models.RootFolder{}).Order("path").Pluck("path", &paths).Error; err != nil {
+			r.logger.Warn("Failed to load root folders for health check", "error", err)
+		}
+	}
+	if len(paths) == 0 && r.config != nil {
+		paths = []string{r.config.Storage.DataDirectory}
+	}
+	return paths
+}
+
+// recycleBinPath returns the recycle bin from the media management settings, or "" when unset
+func (r *RootFolderHealthChecker) recycleBinPath() string {
+	if r.db == nil {
+		return ""
+	}
+	var mediaConfig models.MediaManagementConfig
+	if err := r.db.GORM.First(&mediaConfig).Error; err != nil {
+		return ""
+	}
+	return mediaConfig.RecycleBin
+}
+
 func (r *RootFolderHealthChecker) checkAllPaths(paths []string, issues *[]models.HealthIssue) []string {
 	var checkedPaths []string
 	for _, path := range paths {
@@ -547,50 +705,144 @@ func (r *RootFolderHealthChecker) checkAllPaths(paths []string, issues *[]models
 }
 
 func (r *RootFolderHealthChecker) checkSinglePath(path string, issues *[]models.HealthIssue) {
-	// Check if path exists and is accessible
+	if issue := r.checkFolder("Root folder", path); issue != nil {
+		*issues = append(*issues, *issue)
+		return
+	}
+	r.checkMountedContent(path, issues)
+}
+
+// checkFolder checks that a folder exists, is on a writable mount, and can be listed and written
+// by Radarr, returning the first problem found
+func (r *RootFolderHealthChecker) checkFolder(kind, path string) *models.HealthIssue {
 	info, err := os.Stat(path)
 	if err != nil {
-		*issues = append(*issues, models.HealthIssue{
-			Type:     r.Type(),
-			Source:   r.Name(),
-			Severity: models.HealthSeverityCritical,
-			Message:  fmt.Sprintf("Root folder not accessible: %s - %v", path, err),
-		})
-		return
+		return r.folderErrorIssue(kind, path, err)
 	}
 
-	// Check if it's a directory
 	if !info.IsDir() {
-		*issues = append(*issues, models.HealthIssue{
-			Type:     r.Type(),
-			Source:   r.Name(),
-			Severity: models.HealthSeverityError,
-			Message:  fmt.Sprintf("Root folder path is not a directory: %s", path),
-		})
-		return
+		return r.folderIssue(models.HealthSeverityError, path, rootFolderWikiURL,
+			fmt.Sprintf("%s path is not a directory: %s", kind, path))
+	}
+
+	if readOnly, err := isReadOnlyMount(path); err == nil && readOnly {
+		return r.folderErrorIssue(kind, path, syscall.EROFS)
 	}
 
-	// Check write permissions
-	r.checkWritePermissions(path, issues)
+	if err := checkFolderAccess(path); err != nil {
+		return r.folderErrorIssue(kind, path, err)
+	}
+	return nil
 }
 
-func (r *RootFolderHealthChecker) checkWritePermissions(path string, issues *[]models.HealthIssue) {
+// checkFolderAccess lists the folder and writes a test file to it, which needs read, write and
+// execute permission
+func checkFolderAccess(path string) error {
+	dir, err := os.Open(path) //#nosec G304 -- path is a configured folder
+	if err != nil {
+		return err
+	}
+	_, readErr := dir.Readdirnames(1)
+	closeErr := dir.Close()
+	if readErr != nil && !errors.Is(readErr, io.EOF) {
+		return readErr
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
 	testFile := filepath.Join(path, ".radarr-health-check")
 	if err := os.WriteFile(testFile, []byte("test"), 0400); err != nil {
-		*issues = append(*issues, models.HealthIssue{
-			Type:     r.Type(),
-			Source:   r.Name(),
-			Severity: models.HealthSeverityError,
-			Message:  fmt.Sprintf("Root folder not writable: %s - %v", path, err),
-		})
+		return err
+	}
+	// Not critical if cleanup fails; a leftover test file is harmless
+	_ = os.Remove(testFile)
+	return nil
+}
+
+// folderErrorIssue turns a file system error into an issue that says what to fix
+func (r *RootFolderHealthChecker) folderErrorIssue(kind, path string, err error) *models.HealthIssue {
+	switch {
+	case errors.Is(err, syscall.ESTALE), errors.Is(err, syscall.ENOTCONN):
+		return r.folderIssue(models.HealthSeverityCritical, path, staleMountWikiURL, fmt.Sprintf(
+			"%s is on a network mount that is no longer available: %s - %v. Remount the share", kind, path, err))
+	case errors.Is(err, fs.ErrNotExist):
+		return r.folderIssue(models.HealthSeverityCritical, path, rootFolderWikiURL, fmt.Sprintf(
+			"%s does not exist: %s. Create it, or mount the network share it is on", kind, path))
+	case errors.Is(err, syscall.EROFS):
+		return r.folderIssue(models.HealthSeverityError, path, readOnlyMountWikiURL, fmt.Sprintf(
+			"%s is on a read-only mount: %s. Remount it read-write so Radarr can import and delete files",
+			kind, path))
+	case errors.Is(err, fs.ErrPermission):
+		return r.folderIssue(models.HealthSeverityError, path, permissionsWikiURL, fmt.Sprintf(
+			"%s is missing read, write or execute permission for the user running Radarr: %s - %v", kind, path, err))
+	default:
+		return r.folderIssue(models.HealthSeverityCritical, path, rootFolderWikiURL, fmt.Sprintf(
+			"%s not accessible: %s - %v", kind, path, err))
+	}
+}
+
+func (r *RootFolderHealthChecker) folderIssue(
+	severity models.HealthSeverity, path, wikiURL, message string,
+) *models.HealthIssue {
+	return &models.HealthIssue{
+		Type:     r.Type(),
+		Source:   r.Name(),
+		Severity: severity,
+		Message:  message,
+		WikiURL:  &wikiURL,
+		Data:     map[string]interface{}{"path": path},
+	}
+}
+
+// checkMountedContent warns when a root folder is empty although the library has movies in it,
+// which usually means the network share behind it was unmounted and the mount point is left
+func (r *RootFolderHealthChecker) checkMountedContent(path string, issues *[]models.HealthIssue) {
+	if r.db == nil {
 		return
 	}
 
-	// Clean up test file
-	if err := os.Remove(testFile); err != nil {
-		// Not critical if cleanup fails, just log it
-		r.logger.Debug("Failed to clean up test file", "file", testFile, "error", err)
+	entries, err := os.ReadDir(path)
+	if err != nil || len(entries) > 0 {
+		return
+	}
+
+	prefix := strings.TrimRight(path, `/\`) + string(filepath.Separator)
+	var movieCount int64
+	err = r.db.GORM.Model(&models.Movie{}).Where("path LIKE ?", escapeLike(prefix)+"%").Count(&movieCount).Error
+	if err != nil || movieCount == 0 {
+		return
+	}
+
+	*issues = append(*issues, *r.folderIssue(models.HealthSeverityWarning, path, staleMountWikiURL, fmt.Sprintf(
+		"Root folder is empty but holds %d movies in the library: %s. The network share it points to may "+
+			"have been unmounted", movieCount, path)))
+}
+
+// checkRecycleBin validates the recycle bin folder, which must be absolute, usable, and outside
+// every root folder so deleted files are not picked up again by library scans
+func (r *RootFolderHealthChecker) checkRecycleBin(recycleBin string, rootFolders []string) []models.HealthIssue {
+	if !filepath.IsAbs(recycleBin) {
+		return []models.HealthIssue{*r.folderIssue(models.HealthSeverityError, recycleBin, recycleBinWikiURL,
+			fmt.Sprintf("Recycle bin must be an absolute path: %s", recycleBin))}
+	}
+
+	var issues []models.HealthIssue
+	for _, root := range rootFolders {
+		if rel, err := filepath.Rel(root, recycleBin); err == nil && !strings.HasPrefix(rel, "..") {
+			issues = append(issues, *r.folderIssue(models.HealthSeverityWarning, recycleBin, recycleBinWikiURL,
+				fmt.Sprintf("Recycle bin %s is inside root folder %s. Deleted movies would be found again by "+
+					"library scans", recycleBin, root)))
+			break
+		}
+	}
+
+	if issue := r.checkFolder("Recycle bin", recycleBin); issue != nil {
+		wikiURL := recycleBinWikiURL
+		issue.WikiURL = &wikiURL
+		issues = append(issues, *issue)
 	}
+	return issues
 }
 
 func (r *RootFolderHealthChecker) finalizeRootFolderResult(
@@ -609,8 +861,577 @@ func (r *RootFolderHealthChecker) finalizeRootFolderResult(
 			result.Status = models.HealthStatusCritical
 			return
 		}
-		if issue.Severity == models.HealthSeverityError && result.Status == models.HealthStatusHealthy {
+		if issue.Severity == models.HealthSeverityError {
 			result.Status = models.HealthStatusError
 		}
+		if issue.Severity == models.HealthSeverityWarning && result.Status == models.HealthStatusHealthy {
+			result.Status = models.HealthStatusWarning
+		}
+	}
+}
+
+// DataIntegrityHealthChecker reports orphaned and dangling records found by the integrity checks
+type DataIntegrityHealthChecker struct {
+	integrity *IntegrityService
+}
+
+// NewDataIntegrityHealthChecker creates a health checker backed by the given integrity service
+func NewDataIntegrityHealthChecker(integrity *IntegrityService) *DataIntegrityHealthChecker {
+	return &DataIntegrityHealthChecker{integrity: integrity}
+}
+
+// Name returns the human-readable name of this health checker
+func (d *DataIntegrityHealthChecker) Name() string {
+	return "Data Integrity"
+}
+
+// Type returns the health check type identifier
+func (d *DataIntegrityHealthChecker) Type() models.HealthCheckType {
+	return models.HealthCheckTypeDataIntegrity
+}
+
+// IsEnabled returns whether this health checker is enabled
+func (d *DataIntegrityHealthChecker) IsEnabled() bool {
+	return true // Always enabled
+}
+
+// GetInterval returns the check interval for this health checker
+func (d *DataIntegrityHealthChecker) GetInterval() time.Duration {
+	return 6 * time.Hour
+}
+
+// Check runs the integrity checks without repairing and reports each finding as a warning
+func (d *DataIntegrityHealthChecker) Check(ctx context.Context) models.HealthCheckExecution {
+	result := models.HealthCheckExecution{
+		Type:      d.Type(),
+		Source:    d.Name(),
+		Status:    models.HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Details:   make(map[string]interface{}),
+	}
+
+	findings, err := d.integrity.Run(ctx, false)
+	if err != nil {
+		result.Status = models.HealthStatusError
+		result.Message = "Data integrity checks failed"
+		result.Error = err
+		return result
 	}
+
+	for _, finding := range findings {
+		result.Issues = append(result.Issues, models.HealthIssue{
+			Type:     d.Type(),
+			Source:   d.Name(),
+			Severity: models.HealthSeverityWarning,
+			Message:  finding.Message,
+		})
+		result.Details[finding.Check] = finding.Count
+	}
+
+	if len(result.Issues) > 0 {
+		result.Status = models.HealthStatusWarning
+		result.Message = fmt.Sprintf("%d data integrity problems found", len(result.Issues))
+	} else {
+		result.Message = "No data integrity problems found"
+	}
+
+	return result
+}
+
+// PendingRestartHealthChecker warns when saved host settings are waiting for a restart
+type PendingRestartHealthChecker struct {
+	configService *ConfigService
+}
+
+// NewPendingRestartHealthChecker creates a health checker backed by the given config service
+func NewPendingRestartHealthChecker(configService *ConfigService) *PendingRestartHealthChecker {
+	return &PendingRestartHealthChecker{configService: configService}
+}
+
+// Name returns the human-readable name of this health checker
+func (p *PendingRestartHealthChecker) Name() string {
+	return "Pending Restart"
+}
+
+// Type returns the health check type identifier
+func (p *PendingRestartHealthChecker) Type() models.HealthCheckType {
+	return models.HealthCheckTypeConfiguration
+}
+
+// IsEnabled returns whether this health checker is enabled
+func (p *PendingRestartHealthChecker) IsEnabled() bool {
+	return true // Always enabled
+}
+
+// GetInterval returns the check interval for this health checker
+func (p *PendingRestartHealthChecker) GetInterval() time.Duration {
+	return 5 * time.Minute
+}
+
+// Check reports a warning listing the settings that take effect after a restart
+func (p *PendingRestartHealthChecker) Check(_ context.Context) models.HealthCheckExecution {
+	result := models.HealthCheckExecution{
+		Type:      p.Type(),
+		Source:    p.Name(),
+		Status:    models.HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Details:   make(map[string]interface{}),
+		Message:   "No restart required",
+	}
+
+	settings := p.configService.PendingRestart()
+	if len(settings) == 0 {
+		return result
+	}
+
+	result.Status = models.HealthStatusWarning
+	result.Message = "Restart required to apply configuration changes"
+	result.Details["settings"] = settings
+	result.Issues = []models.HealthIssue{{
+		Type:     p.Type(),
+		Source:   p.Name(),
+		Severity: models.HealthSeverityWarning,
+		Message: fmt.Sprintf("Restart required to apply changes to: %s",
+			strings.Join(settings, ", ")),
+	}}
+
+	return result
+}
+
+// SSLCertificateHealthChecker checks that the served SSL certificate loads and is not close to expiry
+type SSLCertificateHealthChecker struct {
+	config *config.Config
+}
+
+// Name returns the human-readable name of this health checker
+func (c *SSLCertificateHealthChecker) Name() string {
+	return "SSL Certificate"
+}
+
+// Type returns the health check type identifier
+func (c *SSLCertificateHealthChecker) Type() models.HealthCheckType {
+	return models.HealthCheckTypeConfiguration
+}
+
+// IsEnabled returns whether this health checker is enabled
+func (c *SSLCertificateHealthChecker) IsEnabled() bool {
+	return c.config != nil && c.config.Server.EnableSSL
+}
+
+// GetInterval returns the check interval for this health checker
+func (c *SSLCertificateHealthChecker) GetInterval() time.Duration {
+	return 12 * time.Hour
+}
+
+// Check loads the configured certificate and reports when it is unusable, expired, or expiring
+// within certificates.ExpiryWarningWindow
+func (c *SSLCertificateHealthChecker) Check(_ context.Context) models.HealthCheckExecution {
+	result := models.HealthCheckExecution{
+		Type:      c.Type(),
+		Source:    c.Name(),
+		Status:    models.HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Details:   make(map[string]interface{}),
+	}
+
+	server := c.config.Server
+	cert, err := certificates.Load(server.SSLCertPath, server.SSLKeyPath, server.SSLCertPassword)
+	if err != nil {
+		return c.issueResult(result, models.HealthSeverityCritical,
+			fmt.Sprintf("SSL certificate could not be loaded: %v", err))
+	}
+	if cert.Leaf == nil {
+		result.Message = "SSL certificate loaded"
+		return result
+	}
+
+	expires := cert.Leaf.NotAfter
+	result.Details["subject"] = cert.Leaf.Subject.String()
+	result.Details["expires"] = expires
+
+	switch {
+	case time.Now().After(expires):
+		return c.issueResult(result, models.HealthSeverityCritical,
+			fmt.Sprintf("SSL certificate expired on %s", expires.Format(time.DateOnly)))
+	case certificates.ExpiresSoon(cert):
+		return c.issueResult(result, models.HealthSeverityWarning,
+			fmt.Sprintf("SSL certificate expires on %s", expires.Format(time.DateOnly)))
+	}
+
+	result.Message = fmt.Sprintf("SSL certificate valid until %s", expires.Format(time.DateOnly))
+	return result
+}
+
+func (c *SSLCertificateHealthChecker) issueResult(
+	result models.HealthCheckExecution, severity models.HealthSeverity, message string,
+) models.HealthCheckExecution {
+	result.Status = models.HealthStatusWarning
+	if severity == models.HealthSeverityCritical {
+		result.Status = models.HealthStatusCritical
+	}
+	result.Message = message
+	result.Issues = []models.HealthIssue{{
+		Type:     c.Type(),
+		Source:   c.Name(),
+		Severity: severity,
+		Message:  message,
+	}}
+	return result
+}
+
+const (
+	// remotePathMappingWikiURL explains mapping download client paths to paths Radarr can reach
+	remotePathMappingWikiURL = "https://wiki.servarr.com/radarr/settings#remote-path-mappings"
+	// downloadPathSampleSize bounds the completed downloads checked per download client
+	downloadPathSampleSize = 20
+)
+
+// DownloadClientPathHealthChecker checks that the folders download clients report completed
+// downloads in can be reached by Radarr, directly or through a remote path mapping. A mismatch
+// leaves finished downloads that never import.
+type DownloadClientPathHealthChecker struct {
+	db     *database.Database
+	logger *logger.Logger
+}
+
+// Name returns the human-readable name of this health checker
+func (d *DownloadClientPathHealthChecker) Name() string {
+	return "Download Client Path Visibility"
+}
+
+// Type returns the health check type identifier
+func (d *DownloadClientPathHealthChecker) Type() models.HealthCheckType {
+	return models.HealthCheckTypeDownloadClient
+}
+
+// IsEnabled returns whether this health checker is enabled
+func (d *DownloadClientPathHealthChecker) IsEnabled() bool {
+	return d.db != nil
+}
+
+// GetInterval returns the check interval for this health checker
+func (d *DownloadClientPathHealthChecker) GetInterval() time.Duration {
+	return 30 * time.Minute
+}
+
+// Check resolves the paths of recent completed downloads for every enabled client
+func (d *DownloadClientPathHealthChecker) Check(_ context.Context) models.HealthCheckExecution {
+	result := models.HealthCheckExecution{
+		Type:      d.Type(),
+		Source:    d.Name(),
+		Status:    models.HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Details:   make(map[string]interface{}),
+	}
+
+	var clients []models.DownloadClient
+	if err := d.db.GORM.Where("enable = ?", true).Order("name").Find(&clients).Error; err != nil {
+		return d.errorResult(result, fmt.Errorf("failed to load download clients: %w", err))
+	}
+	var mappings []models.RemotePathMapping
+	if err := d.db.GORM.Find(&mappings).Error; err != nil {
+		return d.errorResult(result, fmt.Errorf("failed to load remote path mappings: %w", err))
+	}
+
+	checkedPaths := 0
+	for i := range clients {
+		var items []models.QueueItem
+		err := d.db.GORM.Where("download_client_id = ? AND status = ?", clients[i].ID, models.QueueStatusCompleted).
+			Order("updated DESC").Limit(downloadPathSampleSize).Find(&items).Error
+		if err != nil {
+			return d.errorResult(result, fmt.Errorf("failed to load completed downloads: %w", err))
+		}
+
+		paths := completedDownloadPaths(items)
+		checkedPaths += len(paths)
+		if issue := d.checkClientPaths(&clients[i], paths, mappings); issue != nil {
+			result.Issues = append(result.Issues, *issue)
+		}
+	}
+
+	result.Details["checked_clients"] = len(clients)
+	result.Details["checked_paths"] = checkedPaths
+	if len(result.Issues) == 0 {
+		result.Message = "Completed downloads are reachable for all download clients"
+		return result
+	}
+
+	result.Status = models.HealthStatusError
+	result.Message = fmt.Sprintf("%d download client(s) report paths Radarr cannot reach", len(result.Issues))
+	return result
+}
+
+// completedDownloadPaths returns the distinct folders the client reported for the downloads
+func completedDownloadPaths(items []models.QueueItem) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, item := range items {
+		path := item.OutputPath
+		if path == "" {
+			path = item.DownloadedInfo.DownloadedPath
+		}
+		if path != "" && !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// checkClientPaths returns an issue describing the first path of the client Radarr cannot reach
+func (d *DownloadClientPathHealthChecker) checkClientPaths(
+	client *models.DownloadClient, paths []string, mappings []models.RemotePathMapping,
+) *models.HealthIssue {
+	unreachable := 0
+	var remotePath, localPath string
+	var mapping *models.RemotePathMapping
+	for _, path := range paths {
+		local, applied := translateRemotePath(mappings, client.Host, path)
+		if _, err := os.Stat(local); err == nil {
+			continue
+		}
+		if unreachable == 0 {
+			remotePath, localPath, mapping = path, local, applied
+		}
+		unreachable++
+	}
+	if unreachable == 0 {
+		return nil
+	}
+
+	var message string
+	switch {
+	case mapping != nil:
+		message = fmt.Sprintf("Download client %s reports completed downloads in %s, which the remote path mapping "+
+			"for %s translates to %s, but Radarr cannot find that path. Check the mapping's local path",
+			client.Name, remotePath, mapping.Host, localPath)
+	case looksLikeWindowsPath(remotePath) && runtime.GOOS != "windows":
+		message = fmt.Sprintf("Download client %s reports completed downloads in the Windows path %s. Add a "+
+			"remote path mapping for host %s to the folder where Radarr reaches those downloads",
+			client.Name, remotePath, client.Host)
+	default:
+		message = fmt.Sprintf("Download client %s reports completed downloads in %s, which Radarr cannot find. "+
+			"If the client runs on another host or in a container, add a remote path mapping for host %s",
+			client.Name, remotePath, client.Host)
+	}
+	if unreachable > 1 {
+		message += fmt.Sprintf(" (%d of %d recent download paths are unreachable)", unreachable, len(paths))
+	}
+
+	wikiURL := remotePathMappingWikiURL
+	return &models.HealthIssue{
+		Type:     d.Type(),
+		Source:   d.Name(),
+		Severity: models.HealthSeverityError,
+		Message:  message,
+		WikiURL:  &wikiURL,
+		Data: map[string]interface{}{
+			"downloadClientId": client.ID,
+			"remotePath":       remotePath,
+			"localPath":        localPath,
+		},
+	}
+}
+
+// looksLikeWindowsPath reports drive letter and UNC paths such as C:\Downloads or \\nas\downloads
+func looksLikeWindowsPath(path string) bool {
+	if strings.HasPrefix(path, `\\`) {
+		return true
+	}
+	return len(path) >= 3 && path[1] == ':' && (path[2] == '\\' || path[2] == '/') &&
+		(path[0] >= 'A' && path[0] <= 'Z' || path[0] >= 'a' && path[0] <= 'z')
+}
+
+func (d *DownloadClientPathHealthChecker) errorResult(
+	result models.HealthCheckExecution, err error,
+) models.HealthCheckExecution {
+	d.logger.Error("Download client path check failed", "error", err)
+	result.Status = models.HealthStatusUnknown
+	result.Message = err.Error()
+	return result
+}
+
+const (
+	// indexerVIPWarningWindow is how long before an indexer's VIP expiration it is reported
+	indexerVIPWarningWindow  = 7 * 24 * time.Hour
+	indexerVIPWikiURL        = "https://wiki.servarr.com/radarr/system#indexer-vip-expiring"
+	indexerVIPExpiredWikiURL = "https://wiki.servarr.com/radarr/system#indexer-vip-expired"
+)
+
+// IndexerVIPHealthChecker reports enabled indexers whose VIP status or API key has expired or
+// expires within a week
+type IndexerVIPHealthChecker struct {
+	db     *database.Database
+	logger *logger.Logger
+}
+
+// Name returns the human-readable name of this health checker
+func (i *IndexerVIPHealthChecker) Name() string {
+	return "Indexer VIP Expiration"
+}
+
+// Type returns the health check type identifier
+func (i *IndexerVIPHealthChecker) Type() models.HealthCheckType {
+	return models.HealthCheckTypeIndexer
+}
+
+// IsEnabled returns whether this health checker is enabled
+func (i *IndexerVIPHealthChecker) IsEnabled() bool {
+	return i.db != nil
+}
+
+// GetInterval returns the check interval for this health checker
+func (i *IndexerVIPHealthChecker) GetInterval() time.Duration {
+	return 6 * time.Hour
+}
+
+// Check looks up the enabled indexers with a VIP expiration date
+func (i *IndexerVIPHealthChecker) Check(_ context.Context) models.HealthCheckExecution {
+	result := models.HealthCheckExecution{
+		Type:      i.Type(),
+		Source:    i.Name(),
+		Status:    models.HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Details:   make(map[string]interface{}),
+	}
+
+	var indexers []models.Indexer
+	err := i.db.GORM.Where("status = ? AND vip_expiration IS NOT NULL", models.IndexerStatusEnabled).
+		Order("vip_expiration").Find(&indexers).Error
+	if err != nil {
+		i.logger.Error("Failed to load indexers for VIP check", "error", err)
+		result.Status = models.HealthStatusUnknown
+		result.Message = fmt.Sprintf("Failed to load indexers: %v", err)
+		return result
+	}
+
+	result.Issues = i.vipIssues(indexers, time.Now())
+	result.Details["tracked_indexers"] = len(indexers)
+
+	if len(result.Issues) == 0 {
+		result.Message = "No indexer VIP status is expiring"
+		return result
+	}
+
+	result.Message = fmt.Sprintf("%d indexer(s) have VIP status expiring or expired", len(result.Issues))
+	result.Status = models.HealthStatusWarning
+	for _, issue := range result.Issues {
+		if issue.Severity == models.HealthSeverityError {
+			result.Status = models.HealthStatusError
+		}
+	}
+	return result
+}
+
+// vipIssues returns an error for each expired indexer and a warning for each expiring within a
+// week. Messages name the date rather than the days left so the issue stays the same until fixed.
+func (i *IndexerVIPHealthChecker) vipIssues(indexers []models.Indexer, now time.Time) []models.HealthIssue {
+	var issues []models.HealthIssue
+	for _, indexer := range indexers {
+		if indexer.VipExpiration == nil {
+			continue
+		}
+		expires := *indexer.VipExpiration
+
+		var severity models.HealthSeverity
+		var message, wikiURL string
+		switch {
+		case !expires.After(now):
+			severity, wikiURL = models.HealthSeverityError, indexerVIPExpiredWikiURL
+			message = fmt.Sprintf("Indexer %s VIP status expired on %s", indexer.Name, expires.Format(time.DateOnly))
+		case expires.Sub(now) <= indexerVIPWarningWindow:
+			severity, wikiURL = models.HealthSeverityWarning, indexerVIPWikiURL
+			message = fmt.Sprintf("Indexer %s VIP status expires on %s", indexer.Name, expires.Format(time.DateOnly))
+		default:
+			continue
+		}
+
+		issues = append(issues, models.HealthIssue{
+			Type:     i.Type(),
+			Source:   i.Name(),
+			Severity: severity,
+			Message:  message,
+			WikiURL:  &wikiURL,
+			Data: map[string]interface{}{
+				"indexerId":     indexer.ID,
+				"vipExpiration": expires,
+			},
+		})
+	}
+	return issues
+}
+
+const tmdbAPIKeyWikiURL = "https://wiki.servarr.com/radarr/system#tmdb-api-key"
+
+// TMDBHealthChecker reports a missing or rejected TMDB API key, which stops all metadata lookups
+type TMDBHealthChecker struct {
+	metadataService *MetadataService
+}
+
+// NewTMDBHealthChecker creates a health checker for the API key of the given metadata service
+func NewTMDBHealthChecker(metadataService *MetadataService) *TMDBHealthChecker {
+	return &TMDBHealthChecker{metadataService: metadataService}
+}
+
+// Name returns the human-readable name of this health checker
+func (t *TMDBHealthChecker) Name() string {
+	return "TMDB API Key"
+}
+
+// Type returns the health check type identifier
+func (t *TMDBHealthChecker) Type() models.HealthCheckType {
+	return models.HealthCheckTypeConfiguration
+}
+
+// IsEnabled returns whether this health checker is enabled
+func (t *TMDBHealthChecker) IsEnabled() bool {
+	return true // Always enabled
+}
+
+// GetInterval returns the check interval for this health checker
+func (t *TMDBHealthChecker) GetInterval() time.Duration {
+	return 30 * time.Minute
+}
+
+// Check validates a key that hasn't been checked yet or was rejected, so a key fixed on TMDB's side
+// recovers, and reports an error while the key is missing or rejected
+func (t *TMDBHealthChecker) Check(ctx context.Context) models.HealthCheckExecution {
+	result := models.HealthCheckExecution{
+		Type:      t.Type(),
+		Source:    t.Name(),
+		Status:    models.HealthStatusHealthy,
+		Timestamp: time.Now(),
+		Details:   make(map[string]interface{}),
+	}
+
+	status := t.metadataService.GetAPIKeyStatus()
+	if status.Configured && (!status.Checked || !status.Valid) {
+		t.metadataService.ValidateAPIKey(ctx, "")
+		status = t.metadataService.GetAPIKeyStatus()
+	}
+	result.Details["source"] = status.Source
+	result.Details["checked"] = status.Checked
+
+	var message string
+	switch {
+	case !status.Configured:
+		message = "No TMDB API key is configured, movie metadata cannot be looked up"
+	case status.Checked && !status.Valid:
+		message = "TMDB rejected the API key, movie metadata cannot be looked up"
+	default:
+		result.Message = "TMDB API key is configured"
+		return result
+	}
+
+	wikiURL := tmdbAPIKeyWikiURL
+	result.Status = models.HealthStatusError
+	result.Message = message
+	result.Issues = []models.HealthIssue{{
+		Type:     t.Type(),
+		Source:   t.Name(),
+		Severity: models.HealthSeverityError,
+		Message:  message,
+		WikiURL:  &wikiURL,
+	}}
+	return result
 }
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRootFolderChecker(dataDirectory string) *RootFolderHealthChecker {
+	return &RootFolderHealthChecker{
+		logger: logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stdout"}),
+		config: &config.Config{Storage: config.StorageConfig{DataDirectory: dataDirectory}},
+	}
+}
+
+func TestRootFolderHealthChecker_Check(t *testing.T) {
+	dir := t.TempDir()
+	result := newTestRootFolderChecker(dir).Check(context.Background())
+	assert.Equal(t, models.HealthStatusHealthy, result.Status)
+	assert.Empty(t, result.Issues)
+	assert.Equal(t, []string{dir}, result.Details["checked_paths"])
+
+	missing := filepath.Join(dir, "missing")
+	result = newTestRootFolderChecker(missing).Check(context.Background())
+	assert.Equal(t, models.HealthStatusCritical, result.Status)
+	require.Len(t, result.Issues, 1)
+	assert.Contains(t, result.Issues[0].Message, "does not exist")
+	require.NotNil(t, result.Issues[0].WikiURL)
+	assert.Equal(t, rootFolderWikiURL, *result.Issues[0].WikiURL)
+}
+
+func TestRootFolderHealthChecker_folderErrorIssue(t *testing.T) {
+	checker := newTestRootFolderChecker("")
+
+	tests := []struct {
+		name     string
+		err      error
+		severity models.HealthSeverity
+		wikiURL  string
+	}{
+		{"stale handle", &os.PathError{Op: "stat", Path: "/mnt/movies", Err: syscall.ESTALE},
+			models.HealthSeverityCritical, staleMountWikiURL},
+		{"disconnected mount", fmt.Errorf("wrapped: %w", syscall.ENOTCONN),
+			models.HealthSeverityCritical, staleMountWikiURL},
+		{"read-only", syscall.EROFS, models.HealthSeverityError, readOnlyMountWikiURL},
+		{"permission denied", &os.PathError{Op: "open", Path: "/mnt/movies", Err: syscall.EACCES},
+			models.HealthSeverityError, permissionsWikiURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := checker.folderErrorIssue("Root folder", "/mnt/movies", tt.err)
+			assert.Equal(t, tt.severity, issue.Severity)
+			require.NotNil(t, issue.WikiURL)
+			assert.Equal(t, tt.wikiURL, *issue.WikiURL)
+			assert.Equal(t, "/mnt/movies", issue.Data["path"])
+		})
+	}
+}
+
+func TestRootFolderHealthChecker_checkRecycleBin(t *testing.T) {
+	checker := newTestRootFolderChecker("")
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	assert.Empty(t, checker.checkRecycleBin(outside, []string{root}))
+
+	issues := checker.checkRecycleBin("recycle", []string{root})
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "absolute path")
+
+	inside := filepath.Join(root, ".recycle")
+	require.NoError(t, os.Mkdir(inside, 0o755))
+	issues = checker.checkRecycleBin(inside, []string{root})
+	require.Len(t, issues, 1)
+	assert.Equal(t, models.HealthSeverityWarning, issues[0].Severity)
+	assert.Contains(t, issues[0].Message, "inside root folder")
+
+	issues = checker.checkRecycleBin(filepath.Join(outside, "missing"), []string{root})
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "Recycle bin does not exist")
+	assert.Equal(t, recycleBinWikiURL, *issues[0].WikiURL)
+}
+
+func TestDownloadClientPathHealthChecker_checkClientPaths(t *testing.T) {
+	checker := &DownloadClientPathHealthChecker{}
+	local := t.TempDir()
+	client := &models.DownloadClient{ID: 4, Name: "qBittorrent", Host: "seedbox"}
+	mappings := []models.RemotePathMapping{{Host: "seedbox", RemotePath: "/data/complete", LocalPath: local}}
+
+	assert.Nil(t, checker.checkClientPaths(client, []string{local}, nil))
+	assert.Nil(t, checker.checkClientPaths(client, []string{"/data/complete"}, mappings))
+
+	issue := checker.checkClientPaths(client, []string{"/data/other", "/data/complete"}, mappings)
+	require.NotNil(t, issue)
+	assert.Equal(t, models.HealthSeverityError, issue.Severity)
+	assert.Contains(t, issue.Message, "add a remote path mapping for host seedbox")
+	assert.Equal(t, "/data/other", issue.Data["remotePath"])
+	assert.Equal(t, remotePathMappingWikiURL, *issue.WikiURL)
+
+	issue = checker.checkClientPaths(client, []string{"/data/complete/Movie (2020)"}, mappings)
+	require.NotNil(t, issue)
+	assert.Contains(t, issue.Message, "Check the mapping's local path")
+	assert.Equal(t, filepath.Join(local, "Movie (2020)"), issue.Data["localPath"])
+}
+
+func TestCompletedDownloadPaths(t *testing.T) {
+	paths := completedDownloadPaths([]models.QueueItem{
+		{OutputPath: "/downloads/a"},
+		{DownloadedInfo: models.DownloadedInfo{DownloadedPath: "/downloads/b"}},
+		{OutputPath: "/downloads/a"},
+		{},
+	})
+	assert.Equal(t, []string{"/downloads/a", "/downloads/b"}, paths)
+
+	assert.True(t, looksLikeWindowsPath(`C:\Downloads`))
+	assert.True(t, looksLikeWindowsPath(`\\nas\downloads`))
+	assert.False(t, looksLikeWindowsPath("/downloads"))
+}
+
+func TestIndexerVIPHealthChecker_vipIssues(t *testing.T) {
+	checker := &IndexerVIPHealthChecker{}
+	now := time.Date(2026, time.May, 10, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		expires := now.AddDate(0, 0, days)
+		return &expires
+	}
+
+	issues := checker.vipIssues([]models.Indexer{
+		{ID: 1, Name: "Expired", VipExpiration: at(-1)},
+		{ID: 2, Name: "Expiring", VipExpiration: at(3)},
+		{ID: 3, Name: "Renewed", VipExpiration: at(30)},
+		{ID: 4, Name: "Untracked"},
+	}, now)
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, models.HealthSeverityError, issues[0].Severity)
+	assert.Equal(t, "Indexer Expired VIP status expired on 2026-05-09", issues[0].Message)
+	assert.Equal(t, indexerVIPExpiredWikiURL, *issues[0].WikiURL)
+	assert.Equal(t, models.HealthSeverityWarning, issues[1].Severity)
+	assert.Equal(t, "Indexer Expiring VIP status expires on 2026-05-13", issues[1].Message)
+	assert.Equal(t, 2, issues[1].Data["indexerId"])
+}
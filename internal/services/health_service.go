@@ -11,10 +11,14 @@ import (
 
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 )
 
+// dashboardSlowQueryLimit is how many of the slowest database statements the dashboard lists
+const dashboardSlowQueryLimit = 10
+
 // HealthService implements comprehensive health monitoring for the Radarr system
 type HealthService struct {
 	db     *database.Database
@@ -70,15 +74,21 @@ func NewHealthService(db *database.Database, cfg *config.Config, logger *logger.
 		healthConfig.MetricsRetentionDays = cfg.Health.MetricsRetentionDays
 		healthConfig.NotifyCriticalIssues = cfg.Health.NotifyCriticalIssues
 		healthConfig.NotifyWarningIssues = cfg.Health.NotifyWarningIssues
+
+		// Parse performance metrics interval
+		if metricsInterval, err := time.ParseDuration(cfg.Health.MetricsInterval); err == nil {
+			healthConfig.MetricsInterval = metricsInterval
+		}
 	}
 
 	hs := &HealthService{
-		db:           db,
-		config:       cfg,
-		logger:       logger,
-		checkers:     make(map[string]HealthChecker),
-		checkerTypes: make(map[string][]string),
-		healthConfig: healthConfig,
+		db:            db,
+		config:        cfg,
+		logger:        logger,
+		checkers:      make(map[string]HealthChecker),
+		checkerTypes:  make(map[string][]string),
+		healthConfig:  healthConfig,
+		systemChecker: NewSystemResourceChecker(),
 	}
 
 	// Initialize built-in health checkers
@@ -106,8 +116,9 @@ func (hs *HealthService) initializeBuiltinCheckers() {
 
 	// System resources checker
 	hs.RegisterChecker(&SystemResourcesHealthChecker{
-		logger: hs.logger,
-		config: hs.healthConfig,
+		logger:        hs.logger,
+		config:        hs.healthConfig,
+		systemChecker: hs.systemChecker,
 	})
 
 	// Root folder accessibility checker
@@ -116,6 +127,23 @@ func (hs *HealthService) initializeBuiltinCheckers() {
 		logger: hs.logger,
 		config: hs.config,
 	})
+
+	// Download client completed path checker
+	hs.RegisterChecker(&DownloadClientPathHealthChecker{
+		db:     hs.db,
+		logger: hs.logger,
+	})
+
+	// Indexer VIP expiration checker
+	hs.RegisterChecker(&IndexerVIPHealthChecker{
+		db:     hs.db,
+		logger: hs.logger,
+	})
+
+	// SSL certificate expiry checker
+	hs.RegisterChecker(&SSLCertificateHealthChecker{
+		config: hs.config,
+	})
 }
 
 // RegisterChecker implements HealthServiceInterface
@@ -553,6 +581,9 @@ func (hs *HealthService) GetSystemResources(_ context.Context) (*models.SystemRe
 		}
 
 		// Get disk usage for data directory
+		if hs.config == nil {
+			return resources, nil
+		}
 		if used, total, err := hs.systemChecker.GetDiskUsage(hs.config.Storage.DataDirectory); err == nil {
 			resources.DiskAvailable = total - used
 			resources.DiskTotal = total
@@ -569,7 +600,7 @@ func (hs *HealthService) GetSystemResources(_ context.Context) (*models.SystemRe
 func (hs *HealthService) CheckDiskSpace(_ context.Context) ([]models.DiskSpaceInfo, error) {
 	var diskSpaceInfo []models.DiskSpaceInfo
 
-	if hs.systemChecker == nil {
+	if hs.systemChecker == nil || hs.config == nil {
 		return diskSpaceInfo, fmt.Errorf("system checker not available")
 	}
 
@@ -646,6 +677,16 @@ func (hs *HealthService) monitoringLoop() {
 	}
 }
 
+// SetPerformanceMonitor sets the monitor that collects and stores performance metrics
+func (hs *HealthService) SetPerformanceMonitor(monitor PerformanceMonitorInterface) {
+	hs.performanceMonitor = monitor
+}
+
+// MetricsInterval returns how often performance metrics are sampled
+func (hs *HealthService) MetricsInterval() time.Duration {
+	return hs.healthConfig.MetricsInterval
+}
+
 // RecordPerformanceMetrics implements HealthServiceInterface
 func (hs *HealthService) RecordPerformanceMetrics(ctx context.Context) error {
 	if hs.performanceMonitor == nil {
@@ -743,12 +784,51 @@ func (hs *HealthService) GetHealthDashboard(ctx context.Context) (*models.Health
 		ServiceHealth:    serviceHealth,
 		DiskSpaceInfo:    diskSpaceInfo,
 		PerformanceTrend: performanceTrend,
+		CircuitBreakers:  hs.getCircuitBreakers(),
+		SlowQueries:      hs.getSlowQueries(),
 		LastUpdated:      time.Now(),
 	}
 
 	return dashboard, nil
 }
 
+// getCircuitBreakers returns the circuit breaker state of every external host contacted
+func (hs *HealthService) getCircuitBreakers() []models.CircuitBreakerInfo {
+	statuses := httpclient.BreakerStatuses()
+	breakers := make([]models.CircuitBreakerInfo, 0, len(statuses))
+	for _, status := range statuses {
+		breakers = append(breakers, models.CircuitBreakerInfo{
+			Host:                status.Host,
+			State:               string(status.State),
+			ConsecutiveFailures: status.ConsecutiveFailures,
+			LastError:           status.LastError,
+			LastFailure:         status.LastFailure,
+			OpenedAt:            status.OpenedAt,
+		})
+	}
+	return breakers
+}
+
+// getSlowQueries returns the slowest database statements recorded since startup
+func (hs *HealthService) getSlowQueries() []models.SlowQueryInfo {
+	if hs.db == nil || hs.db.SlowQueries == nil {
+		return []models.SlowQueryInfo{}
+	}
+
+	stats := hs.db.SlowQueries.Top(dashboardSlowQueryLimit)
+	queries := make([]models.SlowQueryInfo, 0, len(stats))
+	for _, stat := range stats {
+		queries = append(queries, models.SlowQueryInfo{
+			SQL:       stat.SQL,
+			Count:     stat.Count,
+			AverageMs: float64(stat.Total.Microseconds()) / float64(stat.Count) / 1000,
+			MaxMs:     float64(stat.Max.Microseconds()) / 1000,
+			LastSeen:  stat.LastSeen,
+		})
+	}
+	return queries
+}
+
 // Helper functions
 
 // convertV2ToV1Issue converts a HealthIssueV2 to HealthIssue for API compatibility
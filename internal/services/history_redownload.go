@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// Redownload redoes a grab from history. The exact release is grabbed again when it's still stored
+// and its indexer still exists, after being evaluated against the movie's current quality profile.
+// Otherwise the movie is searched afresh, the original release is put first among the results,
+// and it's grabbed when the search finds it grabbable. The grab goes to the download client of the
+// original grab while it exists and takes the release's protocol.
+func (s *SearchService) Redownload(history *models.History) (*models.RedownloadResponse, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if history.EventType != models.HistoryEventTypeGrabbed {
+		return nil, models.ValidationError{Field: "eventType", Message: "Only grab events can be redownloaded"}
+	}
+	if history.MovieID == nil {
+		return nil, models.ValidationError{Field: "movieId", Message: "The grab isn't for a movie"}
+	}
+	if _, err := s.movieService.GetByID(*history.MovieID); err != nil {
+		return nil, models.ValidationError{Field: "movieId", Message: "The movie does not exist"}
+	}
+
+	response := &models.RedownloadResponse{HistoryID: history.ID}
+	release, err := s.findHistoryRelease(history)
+	if err != nil {
+		return nil, err
+	}
+	if release != nil {
+		if release.Status == models.ReleaseStatusGrabbed || release.Status == models.ReleaseStatusFailed {
+			err := s.db.GORM.Model(release).Update("status", models.ReleaseStatusAvailable).Error
+			if err != nil {
+				return nil, fmt.Errorf("failed to reset release for redownload: %w", err)
+			}
+		}
+		return s.grabForRedownload(response, history, release)
+	}
+
+	search, err := s.SearchMovieReleases(*history.MovieID, true)
+	if err != nil {
+		return nil, err
+	}
+	response.Releases = prioritizeHistoryRelease(search.Releases, history)
+	if len(response.Releases) > 0 && isHistoryRelease(&response.Releases[0], history) &&
+		response.Releases[0].IsGrabbable() {
+		return s.grabForRedownload(response, history, &response.Releases[0])
+	}
+
+	response.Status = "searched"
+	response.Message = "The original release is no longer available, choose one of the search results to grab"
+	return response, nil
+}
+
+// grabForRedownload grabs the release of a history grab for the movie it was grabbed for
+func (s *SearchService) grabForRedownload(response *models.RedownloadResponse, history *models.History,
+	release *models.Release) (*models.RedownloadResponse, error) {
+	grab, err := s.GrabRelease(&models.GrabRequest{
+		GUID:             release.GUID,
+		IndexerID:        release.IndexerID,
+		MovieID:          history.MovieID,
+		DownloadClientID: s.historyDownloadClientID(history, release.Protocol),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Grab, response.Status, response.Message = grab, grab.Status, grab.Message
+	s.logger.Info("Redownloaded release from history", "historyId", history.ID, "release", release.Title,
+		"status", grab.Status)
+	return response, nil
+}
+
+// findHistoryRelease returns the stored release a history grab was for, or nil when it was purged,
+// has no download URL, or its indexer was removed
+func (s *SearchService) findHistoryRelease(history *models.History) (*models.Release, error) {
+	query := s.db.GORM.Preload("Indexer").Preload("Movie")
+	if history.Data.GUID != "" {
+		query = query.Where("guid = ?", history.Data.GUID)
+	} else {
+		query = query.Where("title = ? AND movie_id = ?", history.SourceTitle, *history.MovieID).Order("id DESC")
+	}
+
+	var release models.Release
+	if err := query.First(&release).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find release for redownload: %w", err)
+	}
+	if release.Indexer == nil || release.DownloadURL == "" {
+		return nil, nil
+	}
+	return &release, nil
+}
+
+// historyDownloadClientID returns the download client of a history grab when it still exists and
+// downloads the protocol, leaving the choice to the release's indexer otherwise
+func (s *SearchService) historyDownloadClientID(history *models.History, protocol models.Protocol) *int {
+	if history.Data.DownloadClient == "" || s.downloadService == nil {
+		return nil
+	}
+	clients, err := s.downloadService.GetDownloadClients()
+	if err != nil {
+		s.logger.Warn("Failed to load download clients for redownload", "error", err)
+		return nil
+	}
+	for i := range clients {
+		if clients[i].Name == history.Data.DownloadClient && string(clients[i].Protocol) == string(protocol) {
+			return &clients[i].ID
+		}
+	}
+	return nil
+}
+
+// prioritizeHistoryRelease moves the release a history grab was for to the front of the search
+// results, keeping the order of the others
+func prioritizeHistoryRelease(releases []models.Release, history *models.History) []models.Release {
+	for i := range releases {
+		if isHistoryRelease(&releases[i], history) {
+			prioritized := append([]models.Release{releases[i]}, releases[:i]...)
+			return append(prioritized, releases[i+1:]...)
+		}
+	}
+	return releases
+}
+
+// isHistoryRelease reports whether a release is the one a history grab was for: the same GUID, or
+// the same title when the grab didn't record one or the indexer changed it
+func isHistoryRelease(release *models.Release, history *models.History) bool {
+	if history.Data.GUID != "" && release.GUID == history.Data.GUID {
+		return true
+	}
+	return history.SourceTitle != "" &&
+		normalizeSearchTitle(release.Title) == normalizeSearchTitle(history.SourceTitle)
+}
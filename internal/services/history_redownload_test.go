@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrioritizeHistoryRelease(t *testing.T) {
+	releases := []models.Release{
+		{GUID: "a", Title: "Movie.2020.2160p.WEB-DL-GRP"},
+		{GUID: "b", Title: "Movie.2020.1080p.BluRay-GRP"},
+		{GUID: "c", Title: "Movie.2020.720p.HDTV-GRP"},
+	}
+
+	byGUID := &models.History{SourceTitle: "Renamed", Data: models.HistoryEventData{GUID: "c"}}
+	prioritized := prioritizeHistoryRelease(releases, byGUID)
+	assert.Equal(t, []string{"c", "a", "b"}, releaseGUIDs(prioritized))
+
+	byTitle := &models.History{SourceTitle: "Movie 2020 1080p BluRay-GRP"}
+	prioritized = prioritizeHistoryRelease(releases, byTitle)
+	assert.Equal(t, []string{"b", "a", "c"}, releaseGUIDs(prioritized))
+	assert.Equal(t, []string{"a", "b", "c"}, releaseGUIDs(releases), "the results aren't reordered in place")
+
+	missing := &models.History{SourceTitle: "Other.Movie.1999", Data: models.HistoryEventData{GUID: "z"}}
+	assert.Equal(t, []string{"a", "b", "c"}, releaseGUIDs(prioritizeHistoryRelease(releases, missing)))
+}
+
+func releaseGUIDs(releases []models.Release) []string {
+	guids := make([]string, len(releases))
+	for i := range releases {
+		guids[i] = releases[i].GUID
+	}
+	return guids
+}
+
+func TestSearchService_Redownload(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	downloadService := NewDownloadService(db, logger)
+	service := NewSearchService(db, logger, nil, nil, NewMovieService(db, logger), downloadService, nil, nil, 1)
+
+	indexer := &models.Indexer{Name: "Test Indexer", Type: models.IndexerTypeNewznab, BaseURL: "http://localhost:5076"}
+	require.NoError(t, db.GORM.Create(indexer).Error)
+	movie := &models.Movie{TmdbID: 604, Title: "The Matrix Reloaded", TitleSlug: "the-matrix-reloaded-604", Year: 2003}
+	require.NoError(t, db.GORM.Create(movie).Error)
+	sabnzbd := &models.DownloadClient{Name: "SABnzbd", Type: models.DownloadClientTypeSABnzbd,
+		Protocol: models.DownloadProtocolUsenet, Host: "localhost", Port: 8080, Enable: true}
+	require.NoError(t, db.GORM.Create(sabnzbd).Error)
+	grabbedAt := time.Now().Add(-time.Hour)
+	release := &models.Release{GUID: "redownload", Title: "Matrix.Reloaded.2003.1080p.BluRay-GROUP",
+		IndexerID: indexer.ID, MovieID: &movie.ID, Protocol: models.ProtocolUsenet,
+		DownloadURL: "http://localhost/nzb/2", PublishDate: time.Now(), Source: models.ReleaseSourceSearch,
+		Status: models.ReleaseStatusGrabbed, GrabbedAt: &grabbedAt}
+	require.NoError(t, db.GORM.Create(release).Error)
+
+	var validationErr models.ValidationError
+	_, err := service.Redownload(&models.History{EventType: models.HistoryEventTypeMovieImported, MovieID: &movie.ID})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "eventType", validationErr.Field)
+
+	history := &models.History{
+		ID: 7, EventType: models.HistoryEventTypeGrabbed, MovieID: &movie.ID, SourceTitle: release.Title,
+		Data: models.HistoryEventData{GUID: release.GUID, DownloadClient: sabnzbd.Name},
+	}
+	response, err := service.Redownload(history)
+	require.NoError(t, err)
+	assert.Equal(t, "grabbed", response.Status)
+	assert.Equal(t, 7, response.HistoryID)
+	require.NotNil(t, response.Grab)
+	assert.Equal(t, sabnzbd.ID, *response.Grab.DownloadClientID, "the original download client is used")
+
+	var stored models.Release
+	require.NoError(t, db.GORM.First(&stored, release.ID).Error)
+	assert.Equal(t, models.ReleaseStatusGrabbed, stored.Status)
+	assert.True(t, stored.GrabbedAt.After(grabbedAt))
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
@@ -50,6 +52,81 @@ func (s *HistoryService) GetHistory(req models.HistoryRequest) (*models.HistoryR
 	return response, nil
 }
 
+// GetHistoryByCursor retrieves history records newest first, starting after the given cursor.
+// Unlike GetHistory it does not count matching records or use offsets, so it stays fast on
+// large history tables. A nil cursor starts from the newest record.
+func (s *HistoryService) GetHistoryByCursor(req models.HistoryRequest,
+	cursor *models.PageCursor) (*models.HistoryResponse, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	s.setHistoryRequestDefaults(&req)
+	query := applyPageCursor(s.buildHistoryQuery(req), "date", "id", cursor, req.PageSize)
+
+	var records []models.History
+	if err := query.Find(&records).Error; err != nil {
+		s.logger.Error("Failed to fetch history records", "error", err)
+		return nil, fmt.Errorf("failed to fetch history records: %w", err)
+	}
+
+	records, next := trimCursorPage(records, req.PageSize, func(h *models.History) (time.Time, int) {
+		return h.Date, h.ID
+	})
+
+	response := &models.HistoryResponse{
+		PageSize: req.PageSize,
+		SortKey:  "date",
+		SortDir:  "desc",
+		Records:  records,
+	}
+	if next != nil {
+		response.NextCursor = next.Encode()
+	}
+
+	s.logger.Debug("Retrieved history records by cursor", "count", len(records))
+	return response, nil
+}
+
+// historyExportBatchSize is the number of history records read per query while exporting
+const historyExportBatchSize = 500
+
+// ExportHistory passes every history record matching the filters of req to each, newest first.
+// Records are read in batches, so exporting the whole table doesn't hold it in memory. Paging and
+// sorting in req are ignored.
+func (s *HistoryService) ExportHistory(
+	ctx context.Context, req models.HistoryRequest, each func(*models.History) error,
+) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var cursor *models.PageCursor
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var records []models.History
+		query := applyPageCursor(s.buildHistoryQuery(req), "date", "id", cursor, historyExportBatchSize)
+		if err := query.Find(&records).Error; err != nil {
+			return fmt.Errorf("failed to fetch history records: %w", err)
+		}
+
+		records, cursor = trimCursorPage(records, historyExportBatchSize, func(h *models.History) (time.Time, int) {
+			return h.Date, h.ID
+		})
+		for i := range records {
+			if err := each(&records[i]); err != nil {
+				return err
+			}
+		}
+		if cursor == nil {
+			return nil
+		}
+	}
+}
+
 // setHistoryRequestDefaults sets default values for the history request
 func (s *HistoryService) setHistoryRequestDefaults(req *models.HistoryRequest) {
 	if req.Page <= 0 {
@@ -486,6 +563,104 @@ func (s *HistoryService) CompleteActivity(activityID int, successful bool,
 	return s.UpdateActivity(activity)
 }
 
+// activityProgressInterval limits how often tracked progress is written to the database
+const activityProgressInterval = time.Second
+
+// ActivityTracker reports the progress of a long-running bulk operation as an Activity.
+// A nil tracker, or one whose activity could not be created, is a no-op so callers never fail
+// because of tracking.
+type ActivityTracker struct {
+	service  *HistoryService
+	activity *models.Activity
+
+	mu        sync.Mutex
+	processed int
+	lastSave  time.Time
+}
+
+// TrackActivity starts a running activity for an operation over total items
+func (s *HistoryService) TrackActivity(activityType models.ActivityType, title string, total int) *ActivityTracker {
+	tracker := &ActivityTracker{service: s}
+	if s == nil {
+		return tracker
+	}
+
+	activity := &models.Activity{
+		Type:      activityType,
+		Title:     title,
+		Status:    models.ActivityStatusRunning,
+		StartTime: time.Now(),
+		Data:      models.ActivityData{TotalItems: total},
+	}
+	if err := s.CreateActivity(activity); err != nil {
+		s.logger.Warn("Failed to track activity", "type", activityType, "title", title, "error", err)
+		return tracker
+	}
+
+	tracker.activity = activity
+	tracker.lastSave = time.Now()
+	return tracker
+}
+
+// Activity returns the tracked activity, or nil if tracking is unavailable
+func (t *ActivityTracker) Activity() *models.Activity {
+	if t == nil {
+		return nil
+	}
+	return t.activity
+}
+
+// Advance records one processed item, noting an error when it failed
+func (t *ActivityTracker) Advance(itemErr error) {
+	if t == nil || t.activity == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.processed++
+	if itemErr != nil {
+		t.activity.Data.FailedItems++
+		t.activity.Data.Errors = append(t.activity.Data.Errors, itemErr.Error())
+	} else {
+		t.activity.Data.SuccessfulItems++
+	}
+	t.activity.UpdateProgress(t.processed, t.activity.Data.TotalItems)
+
+	if time.Since(t.lastSave) >= activityProgressInterval {
+		t.lastSave = time.Now()
+		if err := t.service.UpdateActivity(t.activity); err != nil {
+			t.service.logger.Warn("Failed to update activity progress", "id", t.activity.ID, "error", err)
+		}
+	}
+}
+
+// Finish completes the activity, marking it failed when any item failed or err is set
+func (t *ActivityTracker) Finish(err error) {
+	if t == nil || t.activity == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case err != nil:
+		t.activity.Fail(err.Error())
+	case t.activity.Data.FailedItems > 0:
+		t.activity.Complete(false)
+		t.activity.Message = fmt.Sprintf("%d of %d items failed",
+			t.activity.Data.FailedItems, t.activity.Data.TotalItems)
+	default:
+		t.activity.Complete(true)
+	}
+
+	if updateErr := t.service.UpdateActivity(t.activity); updateErr != nil {
+		t.service.logger.Warn("Failed to complete activity", "id", t.activity.ID, "error", updateErr)
+	}
+}
+
 // GetHistoryStats returns statistics about history records
 func (s *HistoryService) GetHistoryStats() (map[string]interface{}, error) {
 	if s.db == nil {
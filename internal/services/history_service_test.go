@@ -1,6 +1,7 @@
 package services
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHistoryService_GetHistory(t *testing.T) {
@@ -24,6 +26,51 @@ func TestHistoryService_GetHistory(t *testing.T) {
 	assert.Contains(t, err.Error(), "database not available")
 }
 
+func TestHistoryService_GetHistoryByCursor(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewHistoryService(nil, logger)
+
+	// Test with nil database
+	_, err := service.GetHistoryByCursor(models.HistoryRequest{PageSize: 10}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+}
+
+func TestHistoryService_GetHistoryByCursorPages(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewHistoryService(db, logger)
+
+	// Records share timestamps in pairs so paging has to break ties by ID
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, db.GORM.Create(&models.History{
+			EventType:   models.HistoryEventTypeGrabbed,
+			Date:        base.Add(time.Duration(i/2) * time.Minute),
+			SourceTitle: fmt.Sprintf("record-%d", i),
+			Successful:  true,
+		}).Error)
+	}
+
+	var titles []string
+	var cursor *models.PageCursor
+	for pages := 0; pages < 5; pages++ {
+		response, err := service.GetHistoryByCursor(models.HistoryRequest{PageSize: 2}, cursor)
+		require.NoError(t, err)
+		for _, record := range response.Records {
+			titles = append(titles, record.SourceTitle)
+		}
+		if response.NextCursor == "" {
+			break
+		}
+		cursor, err = models.ParsePageCursor(response.NextCursor)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"record-4", "record-3", "record-2", "record-1", "record-0"}, titles)
+}
+
 func TestHistoryService_GetHistoryByID(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
 	service := NewHistoryService(nil, logger)
@@ -129,6 +176,25 @@ func TestHistoryService_UpdateActivity(t *testing.T) {
 	assert.Contains(t, err.Error(), "database not available")
 }
 
+func TestHistoryService_TrackActivity(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewHistoryService(nil, logger)
+
+	// Tracking degrades to a no-op when the activity cannot be stored
+	tracker := service.TrackActivity(models.ActivityTypeBulkEdit, "Edit 2 movies", 2)
+	assert.NotNil(t, tracker)
+	assert.Nil(t, tracker.Activity())
+	tracker.Advance(nil)
+	tracker.Advance(assert.AnError)
+	tracker.Finish(nil)
+
+	// A nil tracker is also safe to use
+	var nilTracker *ActivityTracker
+	nilTracker.Advance(nil)
+	nilTracker.Finish(assert.AnError)
+	assert.Nil(t, nilTracker.Activity())
+}
+
 func TestHistoryService_DeleteActivity(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
 	service := NewHistoryService(nil, logger)
@@ -323,7 +389,7 @@ func TestHistoryEventType_Constants(t *testing.T) {
 	assert.Equal(t, "movieImported", string(models.HistoryEventTypeMovieImported))
 	assert.Equal(t, "movieUnmonitored", string(models.HistoryEventTypeMovieUnmonitored))
 	assert.Equal(t, "movieMonitored", string(models.HistoryEventTypeMovieMonitored))
-	assert.Equal(t, "ignoredDownload", string(models.HistoryEventTypeIgnoredDownload))
+	assert.Equal(t, "downloadIgnored", string(models.HistoryEventTypeIgnoredDownload))
 }
 
 func TestActivityType_Constants(t *testing.T) {
@@ -277,11 +277,12 @@ func (s *ICalService) ValidateICalFeedConfig(config *models.CalendarFeedConfig)
 	return nil
 }
 
-// GetDefaultFeedConfig returns a default iCal feed configuration
+// GetDefaultFeedConfig returns a default iCal feed configuration, titled with the instance name
 func (s *ICalService) GetDefaultFeedConfig() *models.CalendarFeedConfig {
+	name := instanceName(s.db)
 	return &models.CalendarFeedConfig{
-		Title:       "Radarr Movie Calendar",
-		Description: "Movie release dates from Radarr",
+		Title:       name + " Movie Calendar",
+		Description: "Movie release dates from " + name,
 		TimeZone:    "UTC",
 		EventTypes: []models.CalendarEventType{
 			models.CalendarEventCinemaRelease,
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// idempotencyKeyTTL is how long a key's response snapshot is replayed to retries
+	idempotencyKeyTTL = 24 * time.Hour
+	// idempotencyPendingTimeout is how long a key stays reserved for a first request that never
+	// completed, such as one cut off by a restart, before the key may be used again
+	idempotencyPendingTimeout = 5 * time.Minute
+)
+
+// IdempotencyService stores Idempotency-Key reservations and the responses they received
+type IdempotencyService struct {
+	db     *database.Database
+	logger *logger.Logger
+}
+
+// NewIdempotencyService creates a new idempotency service
+func NewIdempotencyService(db *database.Database, logger *logger.Logger) *IdempotencyService {
+	return &IdempotencyService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Reserve claims key for a request. It returns the new reservation and true when the request
+// should be handled, or the key's existing record and false when the key is already in use.
+func (s *IdempotencyService) Reserve(
+	ctx context.Context, key, method, path, requestHash string,
+) (*models.IdempotencyKey, bool, error) {
+	if s.db == nil {
+		return nil, false, fmt.Errorf("database not available")
+	}
+
+	now := time.Now()
+	db := s.db.GORM.WithContext(ctx)
+
+	// An expired key, or one whose first request was abandoned, is free to be used afresh
+	if err := db.Where("idempotency_key = ? AND (expires_at <= ? OR (completed = ? AND created_at <= ?))",
+		key, now, false, now.Add(-idempotencyPendingTimeout)).
+		Delete(&models.IdempotencyKey{}).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to release stale idempotency key: %w", err)
+	}
+
+	record := &models.IdempotencyKey{
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(idempotencyKeyTTL),
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	var existing models.IdempotencyKey
+	if err := db.Where("idempotency_key = ?", key).First(&existing).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, fmt.Errorf("idempotency key was released concurrently, retry the request")
+		}
+		return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &existing, false, nil
+}
+
+// Complete stores the response a reserved key's request received, for replaying to retries
+func (s *IdempotencyService) Complete(ctx context.Context, id, statusCode int, response string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	if err := s.db.GORM.WithContext(ctx).Model(&models.IdempotencyKey{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"completed":   true,
+			"status_code": statusCode,
+			"response":    response,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release drops a reservation whose request failed, so a retry is handled again
+func (s *IdempotencyService) Release(ctx context.Context, id int) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	if err := s.db.GORM.WithContext(ctx).Delete(&models.IdempotencyKey{}, id).Error; err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PruneExpired deletes keys whose snapshots expired and returns the number deleted
+func (s *IdempotencyService) PruneExpired(ctx context.Context) (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	removed, err := prune(ctx, s.db.GORM, func(tx *gorm.DB) (int64, error) {
+		result := tx.Where("expires_at <= ?", time.Now()).Delete(&models.IdempotencyKey{})
+		return result.RowsAffected, result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	s.logger.Info("Pruned expired idempotency keys", "removed", removed, "simulated", Simulating(ctx))
+	return removed, nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyService_Reserve(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewIdempotencyService(db, logger)
+	ctx := context.Background()
+
+	record, reserved, err := service.Reserve(ctx, "key-1", "POST", "/api/v3/movie", "hash")
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	// A retry while the first request is in flight sees the pending reservation
+	pending, reserved, err := service.Reserve(ctx, "key-1", "POST", "/api/v3/movie", "hash")
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.False(t, pending.Completed)
+
+	// Once completed, retries get the stored response
+	require.NoError(t, service.Complete(ctx, record.ID, 201, `{"id":1}`))
+	completed, reserved, err := service.Reserve(ctx, "key-1", "POST", "/api/v3/movie", "hash")
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.True(t, completed.Completed)
+	assert.Equal(t, 201, completed.StatusCode)
+	assert.Equal(t, `{"id":1}`, completed.Response)
+	assert.False(t, completed.Matches("POST", "/api/v3/movie", "other-hash"))
+
+	// A released key is handled again
+	require.NoError(t, service.Release(ctx, record.ID))
+	_, reserved, err = service.Reserve(ctx, "key-1", "POST", "/api/v3/movie", "hash")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	// Abandoned reservations can be taken over
+	require.NoError(t, db.GORM.Model(&models.IdempotencyKey{}).Where("idempotency_key = ?", "key-1").
+		Update("created_at", time.Now().Add(-2*idempotencyPendingTimeout)).Error)
+	_, reserved, err = service.Reserve(ctx, "key-1", "POST", "/api/v3/movie", "hash")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestIdempotencyService_PruneExpired(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewIdempotencyService(db, logger)
+	ctx := context.Background()
+
+	_, _, err := service.Reserve(ctx, "expired", "POST", "/api/v3/command", "hash")
+	require.NoError(t, err)
+	_, _, err = service.Reserve(ctx, "live", "POST", "/api/v3/command", "hash")
+	require.NoError(t, err)
+	require.NoError(t, db.GORM.Model(&models.IdempotencyKey{}).Where("idempotency_key = ?", "expired").
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	removed, err := service.PruneExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	var remaining int64
+	require.NoError(t, db.GORM.Model(&models.IdempotencyKey{}).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+}
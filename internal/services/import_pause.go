@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importPauseConfigKey is the app_config entry the import pause is stored under
+const importPauseConfigKey = "import.paused"
+
+// ImportPause holds imports back, for example during disk maintenance. Files approved for import
+// while paused are queued as pending file organizations instead of being moved. The state is
+// stored in the database so a restart doesn't resume imports on its own.
+type ImportPause struct {
+	db     *database.Database
+	logger *logger.Logger
+
+	mu     sync.RWMutex
+	paused bool
+	reason string
+	since  time.Time
+}
+
+// NewImportPause creates the import pause in its stored state, unpaused when none is stored
+func NewImportPause(db *database.Database, logger *logger.Logger) *ImportPause {
+	pause := &ImportPause{db: db, logger: logger}
+	if err := pause.load(); err != nil {
+		logger.Error("Failed to load the stored import pause, imports are not paused", "error", err)
+	}
+	return pause
+}
+
+// Paused reports whether imports are paused
+func (p *ImportPause) Paused() bool {
+	if p == nil {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.paused
+}
+
+// Status returns the pause state, without the count of queued files
+func (p *ImportPause) Status() models.ImportQueueStatus {
+	if p == nil {
+		return models.ImportQueueStatus{}
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status := models.ImportQueueStatus{Paused: p.paused, Reason: p.reason}
+	if p.paused {
+		since := p.since
+		status.Since = &since
+	}
+	return status
+}
+
+// Set pauses or resumes imports, storing the state before it takes effect
+func (p *ImportPause) Set(paused bool, reason string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	since := p.since
+	if paused && !p.paused {
+		since = time.Now()
+	}
+	if !paused {
+		reason = ""
+	}
+
+	if p.db != nil {
+		entry := &models.AppConfig{
+			Key:         importPauseConfigKey,
+			Value:       models.JSON{"paused": paused, "reason": reason, "since": since.Format(time.RFC3339Nano)},
+			Description: "Whether imports are paused",
+		}
+		if err := p.db.GORM.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "description", "updated_at"}),
+		}).Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to store import pause: %w", err)
+		}
+	}
+
+	if paused != p.paused {
+		p.logger.Info("Import pause updated", "paused", paused, "reason", reason)
+	}
+	p.paused = paused
+	p.reason = reason
+	p.since = since
+	return nil
+}
+
+// load restores the stored pause state
+func (p *ImportPause) load() error {
+	if p.db == nil {
+		return nil
+	}
+
+	var entry models.AppConfig
+	if err := p.db.GORM.Where(&models.AppConfig{Key: importPauseConfigKey}).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	p.paused, _ = entry.Value["paused"].(bool)
+	if !p.paused {
+		return nil
+	}
+	p.reason, _ = entry.Value["reason"].(string)
+	if since, ok := entry.Value["since"].(string); ok {
+		p.since, _ = time.Parse(time.RFC3339Nano, since) //nolint:errcheck // An unreadable time leaves since unset
+	}
+	p.logger.Warn("Imports are paused", "reason", p.reason, "since", p.since)
+	return nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPause_Nil(t *testing.T) {
+	var pause *ImportPause
+	assert.False(t, pause.Paused())
+	assert.Equal(t, models.ImportQueueStatus{}, pause.Status())
+}
+
+func TestImportPause_QueuesByPriority(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	pause := NewImportPause(db, logger)
+	require.False(t, pause.Paused())
+	require.NoError(t, pause.Set(true, "disk maintenance"))
+
+	// The pause survives a restart
+	restored := NewImportPause(db, logger)
+	assert.True(t, restored.Paused())
+	assert.Equal(t, "disk maintenance", restored.Status().Reason)
+	assert.NotNil(t, restored.Status().Since)
+
+	movie := &models.Movie{Title: "Queued Movie", TmdbID: 9501, TitleSlug: "queued-movie-9501"}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	organizations := NewFileOrganizationService(db, logger, nil, nil)
+	dir := t.TempDir()
+	var queued []*models.FileOrganization
+	for _, name := range []string{"first.mkv", "second.mkv"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("movie"), 0o600))
+		org, err := organizations.QueueFile(models.ImportableFile{Path: path}, movie, models.FileOperationMove)
+		require.NoError(t, err)
+		queued = append(queued, org)
+	}
+
+	// Queueing a file again keeps its record
+	again, err := organizations.QueueFile(models.ImportableFile{Path: queued[0].SourcePath}, movie, models.FileOperationMove)
+	require.NoError(t, err)
+	assert.Equal(t, queued[0].ID, again.ID)
+
+	_, err = organizations.SetPriority(queued[1].ID, 10)
+	require.NoError(t, err)
+	pending, err := organizations.GetPendingOrganizations()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, queued[1].ID, pending[0].ID)
+
+	operations := NewFileOperationService(db, logger)
+	operations.UseImportPause(restored)
+	summary, err := operations.GetOperationSummary()
+	require.NoError(t, err)
+	assert.True(t, summary.Imports.Paused)
+	assert.Equal(t, 2, summary.Imports.Pending)
+
+	require.NoError(t, restored.Set(false, ""))
+	assert.False(t, NewImportPause(db, logger).Paused())
+}
@@ -6,11 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
 )
 
 // ImportService provides file import and processing functionality
@@ -19,29 +21,51 @@ type ImportService struct {
 	logger                  *logger.Logger
 	movieService            *MovieService
 	movieFileService        *MovieFileService
+	movieVersionService     *MovieVersionService
 	fileOrganizationService *FileOrganizationService
 	mediaInfoService        *MediaInfoService
 	namingService           *NamingService
+	historyService          *HistoryService
+	configService           *ConfigService
+	seedingService          *SeedingService
+	importWorkers           int
+	pause                   *ImportPause
+
+	// drainMu guards draining so no import starts once Drain has begun waiting on inFlight
+	drainMu  sync.Mutex
+	draining bool
+	inFlight sync.WaitGroup
 }
 
-// NewImportService creates a new instance of ImportService
+// NewImportService creates a new instance of ImportService that imports up to importWorkers
+// files at once
 func NewImportService(
 	db *database.Database,
 	logger *logger.Logger,
 	movieService *MovieService,
 	movieFileService *MovieFileService,
+	movieVersionService *MovieVersionService,
 	fileOrganizationService *FileOrganizationService,
 	mediaInfoService *MediaInfoService,
 	namingService *NamingService,
+	historyService *HistoryService,
+	configService *ConfigService,
+	seedingService *SeedingService,
+	importWorkers int,
 ) *ImportService {
 	return &ImportService{
 		db:                      db,
 		logger:                  logger,
 		movieService:            movieService,
 		movieFileService:        movieFileService,
+		movieVersionService:     movieVersionService,
 		fileOrganizationService: fileOrganizationService,
 		mediaInfoService:        mediaInfoService,
 		namingService:           namingService,
+		historyService:          historyService,
+		configService:           configService,
+		seedingService:          seedingService,
+		importWorkers:           importWorkers,
 	}
 }
 
@@ -49,6 +73,11 @@ func NewImportService(
 func (s *ImportService) ProcessImport(
 	ctx context.Context, path string, options *ImportOptions,
 ) (*models.FileImportResult, error) {
+	if !s.beginImport() {
+		return nil, fmt.Errorf("import service is shutting down")
+	}
+	defer s.inFlight.Done()
+
 	start := time.Now()
 	s.logger.Info("Starting import process", "path", path)
 
@@ -75,24 +104,77 @@ func (s *ImportService) ProcessImport(
 	s.logger.Info("Found importable files", "count", len(importableFiles), "path", path)
 
 	// Make import decisions for each file
-	importDecisions := s.makeImportDecisions(importableFiles, options)
+	importDecisions := s.makeImportDecisions(ctx, importableFiles, options)
 
 	result := &models.FileImportResult{
 		ImportDecisions: importDecisions,
 		ProcessingTime:  time.Since(start),
 	}
 
-	// Process approved imports
-	for _, decision := range importDecisions {
+	tracker := s.historyService.TrackActivity(models.ActivityTypeLibraryImport,
+		fmt.Sprintf("Import %d files from %s", len(importDecisions), path), len(importDecisions))
+	defer tracker.Finish(nil)
+
+	// Process approved imports on up to importWorkers workers, each filling its own result so the
+	// combined result keeps the decision order. A file that has started importing finishes even if
+	// the request is cancelled, so no file is left half moved; files not yet started when the
+	// request is cancelled or the service drains stay in place and are reported as skipped.
+	fileCtx := context.WithoutCancel(ctx)
+	approved := make([]*models.FileImportResult, len(importDecisions))
+	sem := make(chan struct{}, max(s.importWorkers, 1))
+	var wg sync.WaitGroup
+
+	for i, decision := range importDecisions {
 		switch decision.Decision {
 		case models.ImportDecisionApproved:
-			s.processApprovedImport(ctx, &decision, result)
+			if s.pause.Paused() {
+				s.queueImport(&decision, result)
+				break
+			}
+			sem <- struct{}{}
+			if ctx.Err() != nil || s.isDraining() {
+				<-sem
+				result.SkippedFiles = append(result.SkippedFiles, decision.Item)
+				result.SkippedSize += decision.Item.Size
+				break
+			}
+			approved[i] = &models.FileImportResult{}
+			wg.Add(1)
+			go func(decision *models.ImportDecision, fileResult *models.FileImportResult) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				s.processApprovedImport(fileCtx, decision, fileResult)
+				if len(fileResult.ErrorFiles) > 0 {
+					tracker.Advance(fmt.Errorf("failed to import %s", decision.Item.Path))
+					return
+				}
+				tracker.Advance(nil)
+			}(&decision, approved[i])
+			continue
 		case models.ImportDecisionRejected:
 			s.processRejectedImport(&decision, result)
 		case models.ImportDecisionUnknown:
 			// Unknown decisions are treated as skipped
 			result.SkippedFiles = append(result.SkippedFiles, decision.Item)
 		}
+		tracker.Advance(nil)
+	}
+	wg.Wait()
+
+	for _, fileResult := range approved {
+		if fileResult == nil {
+			continue
+		}
+		result.ImportedFiles = append(result.ImportedFiles, fileResult.ImportedFiles...)
+		result.ImportedSize += fileResult.ImportedSize
+		result.ErrorFiles = append(result.ErrorFiles, fileResult.ErrorFiles...)
+		result.ErrorSize += fileResult.ErrorSize
+	}
+
+	if len(result.ImportedFiles) > 0 {
+		s.trackSeeding(options.DownloadID)
 	}
 
 	s.logger.Info("Import process completed",
@@ -107,12 +189,12 @@ func (s *ImportService) ProcessImport(
 
 // makeImportDecisions analyzes files and makes decisions about whether to import them
 func (s *ImportService) makeImportDecisions(
-	files []models.ImportableFile, options *ImportOptions,
+	ctx context.Context, files []models.ImportableFile, options *ImportOptions,
 ) []models.ImportDecision {
 	var decisions []models.ImportDecision
 
 	for _, file := range files {
-		decision := s.makeImportDecision(file, options)
+		decision := s.makeImportDecision(ctx, file, options)
 		decisions = append(decisions, decision)
 	}
 
@@ -120,9 +202,15 @@ func (s *ImportService) makeImportDecisions(
 }
 
 // makeImportDecision makes a decision about whether to import a specific file
-func (s *ImportService) makeImportDecision(file models.ImportableFile, _ *ImportOptions) models.ImportDecision {
+func (s *ImportService) makeImportDecision(
+	ctx context.Context, file models.ImportableFile, _ *ImportOptions,
+) models.ImportDecision {
 	s.logger.Debug("Making import decision", "file", file.Path)
 
+	if file.Edition == "" {
+		file.Edition = parseEdition(file.Name)
+	}
+	s.detectLanguages(ctx, &file)
 	decision := models.ImportDecision{
 		Item: file,
 	}
@@ -147,6 +235,7 @@ func (s *ImportService) makeImportDecision(file models.ImportableFile, _ *Import
 
 	decision.LocalMovie = movie
 	decision.RemoteMovie = movie
+	decision.Version = s.matchVersion(file, movie)
 
 	// Check for existing files and quality upgrades
 	if rejection := s.validateExistingFileUpgrade(file, movie, &decision); rejection != nil {
@@ -156,7 +245,7 @@ func (s *ImportService) makeImportDecision(file models.ImportableFile, _ *Import
 	}
 
 	// Check if file already exists at destination
-	if exists, err := s.checkExistingFile(file, movie); err == nil && exists {
+	if exists, err := s.checkExistingFile(file, movie, decision.Version); err == nil && exists {
 		decision.Decision = models.ImportDecisionRejected
 		decision.Rejections = append(decision.Rejections, models.ImportRejection{
 			Reason: models.ImportRejectionExistingFile,
@@ -172,6 +261,7 @@ func (s *ImportService) makeImportDecision(file models.ImportableFile, _ *Import
 		"file", file.Name,
 		"decision", decision.Decision,
 		"movie", movie.Title,
+		"version", versionName(decision.Version),
 		"isUpgrade", decision.IsUpgrade)
 
 	return decision
@@ -202,24 +292,149 @@ func (s *ImportService) validateBasicFileRequirements(file models.ImportableFile
 func (s *ImportService) validateExistingFileUpgrade(
 	file models.ImportableFile, movie *models.Movie, decision *models.ImportDecision,
 ) *models.ImportRejection {
-	existingFiles, err := s.movieFileService.GetByMovieID(movie.ID)
-	if err != nil || len(existingFiles) == 0 {
+	movieFiles, err := s.movieFileService.GetByMovieID(movie.ID)
+	if err != nil {
 		return nil
 	}
 
-	// Check quality comparison
+	// Only the file of the same version is replaced, other versions are kept alongside it
+	existingFiles := versionFiles(movieFiles, decision.Version)
+	if len(existingFiles) == 0 {
+		return nil
+	}
+
+	// Don't replace a file that has the profile's language with one that lacks it
+	qualityProfileID := movie.QualityProfileID
+	if decision.Version != nil {
+		qualityProfileID = decision.Version.QualityProfileID
+	}
+	languageUpgrade, languageReason := languageUpgrade(
+		s.profileLanguage(qualityProfileID), existingFiles[0].Languages, file.Languages,
+	)
+	if languageReason != "" {
+		return &models.ImportRejection{
+			Reason: models.ImportRejectionReason(languageReason),
+			Type:   models.ImportRejectionTypePermanent,
+		}
+	}
+
+	// Check quality comparison, a file adding the profile's language is an upgrade regardless
 	isUpgrade, upgradeReason := s.checkQualityUpgrade(file, existingFiles[0])
-	if !isUpgrade {
+	if !isUpgrade && !languageUpgrade {
 		return &models.ImportRejection{
 			Reason: models.ImportRejectionReason(upgradeReason),
 			Type:   models.ImportRejectionTypeTemporary,
 		}
 	}
 
+	// Don't replace a Director's Cut with a theatrical release unless edition changes are allowed
+	if reason := editionChangeRejection(existingFiles[0].Edition, file.Edition, s.allowEditionChange()); reason != "" {
+		return &models.ImportRejection{
+			Reason: models.ImportRejectionReason(reason),
+			Type:   models.ImportRejectionTypePermanent,
+		}
+	}
+
 	decision.IsUpgrade = true
 	return nil
 }
 
+// allowEditionChange reports whether upgrades may replace a file with a different edition
+func (s *ImportService) allowEditionChange() bool {
+	return s.mediaManagementConfig().AllowEditionChangeOnUpgrade
+}
+
+// mediaManagementConfig returns the media management settings, or the defaults when they
+// cannot be loaded
+func (s *ImportService) mediaManagementConfig() *models.MediaManagementConfig {
+	if s.configService == nil {
+		return models.GetDefaultMediaManagementConfig()
+	}
+	config, err := s.configService.GetMediaManagementConfig()
+	if err != nil {
+		s.logger.Warn("Failed to load media management config, using defaults", "error", err)
+		return models.GetDefaultMediaManagementConfig()
+	}
+	return config
+}
+
+// detectLanguages sets a file's languages from its audio streams when media info probing is
+// enabled, falling back to the languages parsed from its name
+func (s *ImportService) detectLanguages(ctx context.Context, file *models.ImportableFile) {
+	if len(file.Languages) == 0 {
+		file.Languages = parseLanguages(file.Name)
+	}
+	if file.MediaInfo == nil && s.mediaInfoService != nil && s.mediaManagementConfig().EnableMediaInfo {
+		mediaInfo, err := s.mediaInfoService.ExtractMediaInfo(ctx, file.Path)
+		if err != nil {
+			s.logger.Debug("Failed to probe file languages", "file", file.Path, "error", err)
+			return
+		}
+		file.MediaInfo = mediaInfo
+	}
+	file.Languages = fileLanguages(file.MediaInfo, file.Languages)
+}
+
+// profileLanguage returns the language wanted by a quality profile, or "" when the profile
+// cannot be loaded
+func (s *ImportService) profileLanguage(qualityProfileID int) string {
+	if s.db == nil || qualityProfileID == 0 {
+		return ""
+	}
+	var profile models.QualityProfile
+	if err := s.db.GORM.Select("language").First(&profile, qualityProfileID).Error; err != nil {
+		s.logger.Debug("Failed to load quality profile language", "profileId", qualityProfileID, "error", err)
+		return ""
+	}
+	return profile.Language
+}
+
+// matchVersion returns the version of movie a file belongs to going by its quality, or nil when
+// it is the movie's primary file
+func (s *ImportService) matchVersion(file models.ImportableFile, movie *models.Movie) *models.MovieVersion {
+	if s.movieVersionService == nil {
+		return nil
+	}
+
+	quality := file.Quality
+	if quality == nil {
+		quality = s.fileOrganizationService.parseQualityFromFilename(file.Name)
+	}
+
+	version, err := s.movieVersionService.MatchVersion(movie, quality.Quality.ID)
+	if err != nil {
+		s.logger.Warn("Failed to match movie version, importing as the primary file",
+			"file", file.Name, "movie", movie.Title, "error", err)
+		return nil
+	}
+	return version
+}
+
+// versionFiles returns the files that belong to version, or to the movie's primary file when
+// version is nil
+func versionFiles(files []models.MovieFile, version *models.MovieVersion) []models.MovieFile {
+	versionID := models.PrimaryVersionID
+	if version != nil {
+		versionID = version.ID
+	}
+
+	var matching []models.MovieFile
+	for _, file := range files {
+		if file.VersionID == versionID {
+			matching = append(matching, file)
+		}
+	}
+	return matching
+}
+
+// versionName returns the name of version for logging, or "" for the movie's primary file
+func versionName(version *models.MovieVersion) string {
+	if version == nil {
+		return ""
+	}
+	return version.Name
+}
+
 // identifyMovieFromFilename attempts to identify a movie from its filename
 func (s *ImportService) identifyMovieFromFilename(file models.ImportableFile) (*models.Movie, error) {
 	// This is a simplified implementation
@@ -231,8 +446,8 @@ func (s *ImportService) identifyMovieFromFilename(file models.ImportableFile) (*
 	// Extract year from filename
 	year := s.extractYearFromFilename(fileName)
 
-	// Search for movies by title
-	movies, err := s.movieService.Search(cleanName)
+	// Look up candidate movies by title in the movie index
+	movies, err := s.movieService.FindByTitle(cleanName, year)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search movies: %w", err)
 	}
@@ -333,11 +548,16 @@ func (s *ImportService) checkQualityUpgrade(
 		return true, ""
 	}
 
-	return false, "Existing file has better or equal quality"
+	return false, qualityNotUpgradeReason
 }
 
+// qualityNotUpgradeReason rejects files that aren't an upgrade over the movie's existing file
+const qualityNotUpgradeReason = "Existing file has better or equal quality"
+
 // checkExistingFile checks if a file already exists at the intended destination
-func (s *ImportService) checkExistingFile(_ models.ImportableFile, movie *models.Movie) (bool, error) {
+func (s *ImportService) checkExistingFile(
+	file models.ImportableFile, movie *models.Movie, version *models.MovieVersion,
+) (bool, error) {
 	// Get naming configuration
 	namingConfig, err := s.namingService.GetNamingConfig()
 	if err != nil {
@@ -345,7 +565,7 @@ func (s *ImportService) checkExistingFile(_ models.ImportableFile, movie *models
 	}
 
 	// Build expected destination path
-	expectedPath, err := s.namingService.BuildMovieFilePath(movie, nil, nil, namingConfig)
+	expectedPath, err := s.namingService.BuildVersionFilePath(movie, version, nil, nil, file.Edition, namingConfig)
 	if err != nil {
 		return false, err
 	}
@@ -392,6 +612,11 @@ func (s *ImportService) prepareImportResources(
 		return nil, nil
 	}
 
+	// Reuse the media info probed for the import decision
+	if decision.Item.MediaInfo != nil {
+		return namingConfig, decision.Item.MediaInfo
+	}
+
 	// Extract media info
 	mediaInfo, err := s.mediaInfoService.ExtractMediaInfo(ctx, decision.Item.Path)
 	if err != nil {
@@ -410,8 +635,9 @@ func (s *ImportService) organizeImportFile(
 	orgResult, err := s.fileOrganizationService.OrganizeFile(
 		ctx, decision.Item.Path,
 		decision.LocalMovie,
+		decision.Version,
 		namingConfig,
-		models.FileOperationMove,
+		s.importOperation(),
 	)
 
 	if err != nil {
@@ -429,29 +655,88 @@ func (s *ImportService) organizeImportFile(
 	return orgResult
 }
 
+// importOperation returns how imported files are placed in the library: moved, or hardlinked or
+// copied when the originals are kept in the download folder
+func (s *ImportService) importOperation() models.FileOperation {
+	config := s.mediaManagementConfig()
+	switch {
+	case !config.KeepOriginalFiles:
+		return models.FileOperationMove
+	case config.CopyUsingHardlinks:
+		return models.FileOperationHardlink
+	default:
+		return models.FileOperationCopy
+	}
+}
+
+// releaseName returns the name of the release a file was imported from, as trackers know it:
+// the scene name when known, else the download's title, else the file name without extension
+func releaseName(file models.ImportableFile) string {
+	switch {
+	case file.SceneName != "":
+		return file.SceneName
+	case file.DownloadItem != nil && file.DownloadItem.Title != "":
+		return file.DownloadItem.Title
+	default:
+		return strings.TrimSuffix(file.Name, filepath.Ext(file.Name))
+	}
+}
+
 // createMovieFileRecord creates and saves a movie file record
 func (s *ImportService) createMovieFileRecord(
 	decision *models.ImportDecision, orgResult *models.FileOrganizationResult,
 	mediaInfo *models.MediaInfo,
 ) *models.MovieFile {
+	rootPath, versionID := decision.LocalMovie.Path, models.PrimaryVersionID
+	if decision.Version != nil {
+		rootPath, versionID = decision.Version.Path, decision.Version.ID
+	}
+
 	movieFile := &models.MovieFile{
 		MovieID:          decision.LocalMovie.ID,
+		VersionID:        versionID,
 		Path:             orgResult.OrganizedPath,
-		RelativePath:     strings.TrimPrefix(orgResult.OrganizedPath, decision.LocalMovie.Path),
+		RelativePath:     strings.TrimPrefix(orgResult.OrganizedPath, rootPath),
 		Size:             decision.Item.Size,
 		DateAdded:        time.Now(),
 		OriginalFilePath: decision.Item.Path,
+		SceneName:        releaseName(decision.Item),
+		ReleaseGroup:     decision.Item.ReleaseGroup,
+		Edition:          decision.Item.Edition,
+		Languages:        fileLanguages(mediaInfo, decision.Item.Languages),
 	}
 
 	if mediaInfo != nil {
 		movieFile.MediaInfo = *mediaInfo
 	}
 
-	if err := s.movieFileService.Create(movieFile); err != nil {
+	// The import notification is recorded with the file so it isn't lost if we stop right after
+	event := &models.NotificationEvent{
+		EventType:      NotificationEventDownload,
+		Movie:          decision.LocalMovie,
+		MovieFile:      movieFile,
+		SourceTitle:    movieFile.SceneName,
+		QualityUpgrade: decision.IsUpgrade,
+	}
+	if decision.IsUpgrade {
+		event.EventType = NotificationEventUpgrade
+	}
+	if decision.Item.DownloadItem != nil {
+		event.DownloadID = decision.Item.DownloadItem.DownloadID
+	}
+
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(movieFile).Error; err != nil {
+			return err
+		}
+		return enqueueOutboxEvent(tx, event)
+	})
+	if err != nil {
 		s.logger.Error("Failed to create movie file record", "error", err)
 		return nil
 	}
 
+	s.logger.Info("Created movie file", "id", movieFile.ID, "path", movieFile.Path)
 	return movieFile
 }
 
@@ -460,12 +745,19 @@ func (s *ImportService) finalizeImport(
 	decision *models.ImportDecision, result *models.FileImportResult,
 	movieFile *models.MovieFile, orgResult *models.FileOrganizationResult,
 ) {
-	// Update movie to mark it as having a file
-	decision.LocalMovie.HasFile = true
-	decision.LocalMovie.MovieFileID = movieFile.ID
-	if err := s.movieService.Update(decision.LocalMovie); err != nil {
-		s.logger.Error("Failed to update movie", "error", err)
-		// Continue - file is imported, just movie record update failed
+	// Mark the version, or the movie itself, as having a file
+	if decision.Version != nil {
+		if err := s.movieVersionService.SetFile(decision.Version, movieFile); err != nil {
+			s.logger.Error("Failed to update movie version", "versionId", decision.Version.ID, "error", err)
+			// Continue - file is imported, just version record update failed
+		}
+	} else {
+		decision.LocalMovie.HasFile = true
+		decision.LocalMovie.MovieFileID = movieFile.ID
+		if err := s.movieService.Update(decision.LocalMovie); err != nil {
+			s.logger.Error("Failed to update movie", "error", err)
+			// Continue - file is imported, just movie record update failed
+		}
 	}
 
 	// Add to results
@@ -475,7 +767,8 @@ func (s *ImportService) finalizeImport(
 	s.logger.Info("Successfully imported file",
 		"originalPath", decision.Item.Path,
 		"organizedPath", orgResult.OrganizedPath,
-		"movie", decision.LocalMovie.Title)
+		"movie", decision.LocalMovie.Title,
+		"version", versionName(decision.Version))
 }
 
 // processRejectedImport processes a rejected import decision
@@ -546,17 +839,27 @@ func (s *ImportService) ProcessManualImport(ctx context.Context, manualImport *m
 	if manualImport.Movie == nil {
 		return fmt.Errorf("movie must be specified for manual import")
 	}
+	if !s.beginImport() {
+		return fmt.Errorf("import service is shutting down")
+	}
+	defer s.inFlight.Done()
 
 	// Create import decision
 	file := models.ImportableFile{
-		Path: manualImport.Path,
-		Name: manualImport.Name,
-		Size: manualImport.Size,
+		Path:         manualImport.Path,
+		Name:         manualImport.Name,
+		Size:         manualImport.Size,
+		SceneName:    manualImport.SceneName,
+		ReleaseGroup: manualImport.ReleaseGroup,
+	}
+	if manualImport.Quality.Quality.ID > 0 {
+		file.Quality = &manualImport.Quality
 	}
 
 	decision := models.ImportDecision{
 		LocalMovie:  manualImport.Movie,
 		RemoteMovie: manualImport.Movie,
+		Version:     s.matchVersion(file, manualImport.Movie),
 		Decision:    models.ImportDecisionApproved,
 		Item:        file,
 	}
@@ -564,15 +867,183 @@ func (s *ImportService) ProcessManualImport(ctx context.Context, manualImport *m
 	// Create result structure
 	result := &models.FileImportResult{}
 
+	if s.pause.Paused() {
+		s.queueImport(&decision, result)
+		if len(result.ErrorFiles) > 0 {
+			return fmt.Errorf("failed to queue %s while imports are paused", manualImport.Path)
+		}
+		return nil
+	}
+
 	// Process the import
-	s.processApprovedImport(ctx, &decision, result)
+	s.processApprovedImport(context.WithoutCancel(ctx), &decision, result)
+
+	if len(result.ImportedFiles) > 0 {
+		s.trackSeeding(manualImport.DownloadID)
+	}
 
 	return nil
 }
 
+// UseImportPause makes imports honor pause, queueing approved files while imports are paused
+func (s *ImportService) UseImportPause(pause *ImportPause) {
+	s.pause = pause
+}
+
+// queueImport queues an approved file as a pending file organization while imports are paused,
+// leaving the file in place
+func (s *ImportService) queueImport(decision *models.ImportDecision, result *models.FileImportResult) {
+	if _, err := s.fileOrganizationService.QueueFile(decision.Item, decision.LocalMovie, s.importOperation()); err != nil {
+		s.logger.Error("Failed to queue import while imports are paused", "file", decision.Item.Path, "error", err)
+		s.moveToErrored(decision, result, err.Error())
+		return
+	}
+
+	result.QueuedFiles = append(result.QueuedFiles, decision.Item)
+	result.QueuedSize += decision.Item.Size
+}
+
+// ProcessPendingOrganizations imports the files queued while imports were paused, highest
+// priority first, and returns how many were imported. It stops early, leaving the rest queued,
+// when imports are paused again, ctx is cancelled, or the service drains.
+func (s *ImportService) ProcessPendingOrganizations(
+	ctx context.Context, progress func(done, total int),
+) (int, error) {
+	if s.pause.Paused() {
+		return 0, fmt.Errorf("imports are paused")
+	}
+	if !s.beginImport() {
+		return 0, fmt.Errorf("import service is shutting down")
+	}
+	defer s.inFlight.Done()
+
+	pending, err := s.fileOrganizationService.GetPendingOrganizations()
+	if err != nil {
+		return 0, err
+	}
+
+	imported := 0
+	for i := range pending {
+		if ctx.Err() != nil || s.isDraining() || s.pause.Paused() {
+			s.logger.Info("Stopped processing queued imports", "remaining", len(pending)-i)
+			break
+		}
+		if s.processPendingOrganization(ctx, &pending[i]) {
+			imported++
+		}
+		progress(i+1, len(pending))
+	}
+
+	return imported, nil
+}
+
+// processPendingOrganization imports the file of a queued organization. The queued record is
+// replaced by the one the import itself records, or marked failed when the file can't be imported.
+func (s *ImportService) processPendingOrganization(ctx context.Context, org *models.FileOrganization) bool {
+	fail := func(reason string) bool {
+		org.MarkAsFailed(reason)
+		if err := s.fileOrganizationService.saveFileOrganization(org); err != nil {
+			s.logger.Error("Failed to save file organization record", "id", org.ID, "error", err)
+		}
+		return false
+	}
+
+	if org.MovieID == nil {
+		return fail("No movie to import the file for")
+	}
+	movie, err := s.movieService.GetByID(*org.MovieID)
+	if err != nil {
+		return fail(fmt.Sprintf("Movie %d no longer exists", *org.MovieID))
+	}
+	info, err := os.Stat(org.SourcePath)
+	if err != nil {
+		return fail("Source file no longer exists")
+	}
+
+	file := models.ImportableFile{
+		Path:         org.SourcePath,
+		Name:         filepath.Base(org.SourcePath),
+		Size:         info.Size(),
+		DateModified: info.ModTime(),
+		FolderName:   filepath.Base(filepath.Dir(org.SourcePath)),
+		Quality:      org.Quality,
+		Languages:    org.Languages,
+		ReleaseGroup: org.ReleaseGroup,
+		Edition:      org.Edition,
+	}
+	decision := models.ImportDecision{
+		LocalMovie:  movie,
+		RemoteMovie: movie,
+		Version:     s.matchVersion(file, movie),
+		Decision:    models.ImportDecisionApproved,
+		Item:        file,
+	}
+
+	if err := s.db.GORM.Delete(org).Error; err != nil {
+		s.logger.Error("Failed to remove queued file organization", "id", org.ID, "error", err)
+		return false
+	}
+
+	result := &models.FileImportResult{}
+	s.processApprovedImport(context.WithoutCancel(ctx), &decision, result)
+	return len(result.ImportedFiles) > 0
+}
+
+// trackSeeding starts tracking the torrent of an imported download so it's removed from its
+// client once it has seeded
+func (s *ImportService) trackSeeding(downloadID string) {
+	if downloadID == "" || s.seedingService == nil {
+		return
+	}
+	if err := s.seedingService.Track(downloadID); err != nil {
+		s.logger.Warn("Failed to track seeding torrent", "downloadId", downloadID, "error", err)
+	}
+}
+
+// beginImport registers an import with the drain, refusing it once the service is draining
+func (s *ImportService) beginImport() bool {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+
+	if s.draining {
+		return false
+	}
+	s.inFlight.Add(1)
+	return true
+}
+
+func (s *ImportService) isDraining() bool {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	return s.draining
+}
+
+// Drain refuses new imports and waits for running imports to finish the files they have started.
+// Files an import has not reached yet are left in place for the next import of the folder.
+func (s *ImportService) Drain(ctx context.Context) error {
+	s.drainMu.Lock()
+	s.draining = true
+	s.drainMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("imports still running at shutdown: %w", ctx.Err())
+	}
+}
+
 // ImportOptions configures import behavior
 type ImportOptions struct {
 	ImportMode           models.ImportDecisionType `json:"importMode"`
 	ReplaceExistingFiles bool                      `json:"replaceExistingFiles"`
 	SkipFreeSpaceCheck   bool                      `json:"skipFreeSpaceCheck"`
+	// DownloadID is the client's ID of the download being imported, tracked for seeding when it's a torrent
+	DownloadID string `json:"downloadId"`
 }
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseName(t *testing.T) {
+	file := models.ImportableFile{Name: "Dune.2021.2160p.UHD.BluRay.x265-GROUP.mkv"}
+	assert.Equal(t, "Dune.2021.2160p.UHD.BluRay.x265-GROUP", releaseName(file))
+
+	file.DownloadItem = &models.QueueItem{Title: "Dune 2021 2160p UHD BluRay x265-GROUP"}
+	assert.Equal(t, "Dune 2021 2160p UHD BluRay x265-GROUP", releaseName(file))
+
+	file.SceneName = "Dune.2021.2160p.BluRay.x265-GROUP"
+	assert.Equal(t, "Dune.2021.2160p.BluRay.x265-GROUP", releaseName(file))
+}
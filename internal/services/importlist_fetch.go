@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// importListFetchTimeout bounds one fetch of a remote list
+	importListFetchTimeout = 60 * time.Second
+	// maxImportListSize bounds the list documents read from remote servers
+	maxImportListSize = 50 << 20
+)
+
+// errImportListUnchanged reports a remote list the server says hasn't changed since its last fetch
+var errImportListUnchanged = errors.New("import list has not changed since the last fetch")
+
+// fetchListDocument GETs a remote list document. A conditional fetch sends the ETag and
+// Last-Modified validators of the list's last fetch back, returns errImportListUnchanged when the
+// server answers 304 Not Modified, and otherwise records the validators of the new response on
+// the list. Unconditional fetches, such as previews, leave the list's fetch record alone.
+func (s *ImportListService) fetchListDocument(ctx context.Context, list *models.ImportList, url string,
+	header http.Header, conditional bool) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list URL: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if conditional {
+		if list.LastFetch.ETag != "" {
+			req.Header.Set("If-None-Match", list.LastFetch.ETag)
+		}
+		if list.LastFetch.LastModified != "" {
+			req.Header.Set("If-Modified-Since", list.LastFetch.LastModified)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch list: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // read-only response
+
+	now := time.Now()
+	if resp.StatusCode == http.StatusNotModified && conditional {
+		list.LastFetch.FetchedAt = &now
+		list.LastFetch.Unchanged = true
+		return nil, errImportListUnchanged
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch list: %w",
+			&providerStatusError{Provider: string(list.Implementation), StatusCode: resp.StatusCode})
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImportListSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list: %w", err)
+	}
+	if len(body) > maxImportListSize {
+		return nil, fmt.Errorf("list exceeds %d MB", maxImportListSize>>20)
+	}
+
+	if conditional {
+		list.LastFetch = models.ImportListFetch{
+			FetchedAt:    &now,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+	}
+	return body, nil
+}
+
+// fetchRadarrList fetches the movies of another Radarr instance from its movie API
+func (s *ImportListService) fetchRadarrList(list *models.ImportList, conditional bool) (
+	[]models.ImportListMovie, error) {
+	if list.Settings.BaseURL == "" {
+		return nil, fmt.Errorf("base URL is required for Radarr lists")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), importListFetchTimeout)
+	defer cancel()
+
+	header := http.Header{"Accept": {"application/json"}}
+	if list.Settings.APIKey != "" {
+		header.Set("X-Api-Key", list.Settings.APIKey)
+	}
+	body, err := s.fetchListDocument(ctx, list, strings.TrimSuffix(list.Settings.BaseURL, "/")+"/api/v3/movie",
+		header, conditional)
+	if err != nil {
+		return nil, err
+	}
+
+	var remote []struct {
+		TmdbID        int               `json:"tmdbId"`
+		ImdbID        string            `json:"imdbId"`
+		Title         string            `json:"title"`
+		OriginalTitle string            `json:"originalTitle"`
+		Year          int               `json:"year"`
+		Overview      string            `json:"overview"`
+		Runtime       int               `json:"runtime"`
+		Images        models.MediaCover `json:"images"`
+		Genres        []string          `json:"genres"`
+	}
+	if err := json.Unmarshal(body, &remote); err != nil {
+		return nil, fmt.Errorf("invalid Radarr movie list: %w", err)
+	}
+
+	movies := make([]models.ImportListMovie, 0, len(remote))
+	for _, movie := range remote {
+		if movie.TmdbID == 0 {
+			continue
+		}
+		movies = append(movies, models.ImportListMovie{
+			ImportListID:  list.ID,
+			TmdbID:        movie.TmdbID,
+			ImdbID:        movie.ImdbID,
+			Title:         movie.Title,
+			OriginalTitle: movie.OriginalTitle,
+			Year:          movie.Year,
+			Overview:      movie.Overview,
+			Runtime:       movie.Runtime,
+			Images:        movie.Images,
+			Genres:        movie.Genres,
+		})
+	}
+	return movies, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportListService_FetchRadarrList_Caching(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/v3/movie", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		_, _ = w.Write([]byte(`[{"tmdbId": 603, "title": "The Matrix", "year": 1999}, {"tmdbId": 0, "title": "Unmatched"}]`))
+	}))
+	defer server.Close()
+
+	service := NewImportListService(nil, logger.New(config.LogConfig{Level: "error"}), nil, nil)
+	list := &models.ImportList{ID: 3, Implementation: models.ImportListTypeRadarrList,
+		Settings: models.ImportListSettings{BaseURL: server.URL + "/", APIKey: "secret"}}
+
+	movies, err := service.fetchMoviesFromImportList(list, true)
+	require.NoError(t, err)
+	require.Len(t, movies, 1, "movies without a TMDB ID are skipped")
+	assert.Equal(t, models.ImportListMovie{ImportListID: 3, TmdbID: 603, Title: "The Matrix", Year: 1999}, movies[0])
+	assert.Equal(t, `"v1"`, list.LastFetch.ETag)
+	assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 GMT", list.LastFetch.LastModified)
+	require.NotNil(t, list.LastFetch.FetchedAt)
+	assert.False(t, list.LastFetch.Unchanged)
+
+	_, err = service.fetchMoviesFromImportList(list, true)
+	require.ErrorIs(t, err, errImportListUnchanged)
+	assert.True(t, list.LastFetch.Unchanged)
+	assert.Equal(t, `"v1"`, list.LastFetch.ETag, "an unchanged list keeps its validators")
+
+	// Previews always fetch the whole list
+	movies, err = service.fetchMoviesFromImportList(list, false)
+	require.NoError(t, err)
+	assert.Len(t, movies, 1)
+	assert.True(t, list.LastFetch.Unchanged, "previews leave the fetch record alone")
+	assert.Equal(t, 3, requests)
+}
+
+func TestImportListFetch_Value(t *testing.T) {
+	value, err := models.ImportListFetch{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value, "lists never fetched store no fetch record")
+
+	var fetch models.ImportListFetch
+	require.NoError(t, fetch.Scan(`{"etag": "\"v2\"", "movies": 4}`))
+	assert.Equal(t, models.ImportListFetch{ETag: `"v2"`, Movies: 4}, fetch)
+}
@@ -2,18 +2,24 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
 )
 
 // Import list processing result constants
 const (
-	ImportListResultAdded = "added"
+	ImportListResultAdded    = "added"
+	ImportListResultPending  = "pending"
+	ImportListResultFiltered = "filtered"
 )
 
 // ImportListService provides operations for managing import lists and movie discovery
@@ -33,9 +39,7 @@ func NewImportListService(db *database.Database, logger *logger.Logger,
 		logger:          logger,
 		metadataService: metadataService,
 		movieService:    movieService,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient:      httpclient.New(30 * time.Second),
 	}
 }
 
@@ -151,53 +155,89 @@ func (s *ImportListService) GetEnabledImportLists() ([]models.ImportList, error)
 	return lists, nil
 }
 
-// TestImportList tests the connection and configuration of an import list
+// TestImportList tests the connection and configuration of an import list, recording the outcome
+// on the list when it's a saved one
 func (s *ImportListService) TestImportList(list *models.ImportList) (*models.ImportListTestResult, error) {
-	// Basic validation
-	errors := []string{}
+	result := &models.ImportListTestResult{ProviderTestReport: models.NewProviderTestReport()}
+	report := &result.ProviderTestReport
 
 	if list.Name == "" {
-		errors = append(errors, "Name is required")
+		report.Fail(configurationFailure("name", "Name is required"))
 	}
 
 	if list.Implementation == "" {
-		errors = append(errors, "Implementation is required")
+		report.Fail(configurationFailure("implementation", "Implementation is required"))
 	}
 
-	if list.QualityProfileID <= 0 {
-		errors = append(errors, "Quality profile is required")
+	if list.QualityProfileID < 0 {
+		report.Fail(configurationFailure("qualityProfileId", "Quality profile cannot be negative"))
 	}
 
 	if list.RootFolderPath == "" {
-		errors = append(errors, "Root folder path is required")
+		report.Fail(configurationFailure("rootFolderPath", "Root folder path is required"))
 	}
 
-	result := &models.ImportListTestResult{
-		IsValid: len(errors) == 0,
-		Errors:  errors,
+	if report.Passed() {
+		report.Pass(models.ProviderTestCheckConfiguration, "")
+		result.Movies = s.diagnoseImportList(list, report)
 	}
+	result.IsValid, result.Errors = report.Passed(), report.Messages()
 
-	if !result.IsValid {
-		return result, nil
+	if err := recordProviderTest(s.db, &models.ImportList{}, list.ID, report); err != nil {
+		s.logger.Warn("Failed to record import list test", "name", list.Name, "error", err)
 	}
+	s.logger.Info("Tested import list connection", "name", list.Name, "valid", result.IsValid)
+	return result, nil
+}
 
-	// Test actual connection based on implementation type
-	if err := s.testImportListConnection(list); err != nil {
-		result.IsValid = false
-		result.Errors = append(result.Errors, err.Error())
-	} else {
-		// Try to fetch a few sample movies to verify the connection works
-		movies, err := s.fetchSampleMovies(list, 5)
+// diagnoseImportList tests the connection to an import list with valid settings and returns a few
+// of its movies. Radarr lists are fetched, which checks the API key and that the server is Radarr;
+// other lists get a connectivity check.
+func (s *ImportListService) diagnoseImportList(list *models.ImportList,
+	report *models.ProviderTestReport) []models.ImportListMovie {
+	const sampleSize = 5
+
+	if list.Implementation == models.ImportListTypeRadarrList {
+		movies, err := s.fetchRadarrList(list, false)
 		if err != nil {
-			result.IsValid = false
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch sample movies: %s", err.Error()))
-		} else {
-			result.Movies = movies
+			failure := diagnoseProviderError(models.ProviderTestCheckConnectivity, list.Name, err)
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				failure.Check = models.ProviderTestCheckCapability
+				failure.Hint = "Check the URL points to a Radarr instance and not to a proxy or login page"
+			}
+			report.Fail(failure)
+			return nil
 		}
+		report.Pass(models.ProviderTestCheckConnectivity, "")
+		report.Pass(models.ProviderTestCheckAuthentication, "")
+		report.Pass(models.ProviderTestCheckCapability, fmt.Sprintf("%d movies listed", len(movies)))
+		return movies[:min(len(movies), sampleSize)]
 	}
 
-	s.logger.Info("Tested import list connection", "name", list.Name, "valid", result.IsValid)
-	return result, nil
+	if list.GetBaseURL() == "" {
+		report.Fail(configurationFailure("baseUrl", "Base URL is required"))
+		return nil
+	}
+	if err := s.testImportListConnection(list); err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, list.Name, err))
+		return nil
+	}
+	report.Pass(models.ProviderTestCheckConnectivity, "")
+
+	// Try to fetch a few sample movies to verify the connection works
+	movies, err := s.fetchSampleMovies(list, sampleSize)
+	if err != nil {
+		report.Fail(models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckCapability,
+			Category: models.ProviderTestFailureUnexpectedResponse,
+			Message:  fmt.Sprintf("Failed to fetch sample movies: %s", err.Error()),
+		})
+		return nil
+	}
+	report.Pass(models.ProviderTestCheckCapability, "")
+	return movies
 }
 
 // SyncImportList synchronizes movies from a specific import list
@@ -225,8 +265,12 @@ func (s *ImportListService) SyncImportList(listID int) (*models.ImportListSyncRe
 		return result, nil
 	}
 
-	// Update last sync time
+	// Update last sync time. A sync with errors drops the caching validators, so the next one
+	// processes the list again even if it's unchanged.
 	list.LastSync = &result.SyncTime
+	if len(result.Errors) > 0 {
+		list.LastFetch.ETag, list.LastFetch.LastModified = "", ""
+	}
 	if err := s.UpdateImportList(list); err != nil {
 		s.logger.Warn("Failed to update last sync time", "listId", listID, "error", err)
 	}
@@ -250,11 +294,17 @@ func (s *ImportListService) initializeSyncResult(list *models.ImportList) *model
 // processImportListSync handles the movie fetching and processing for sync
 func (s *ImportListService) processImportListSync(
 	list *models.ImportList, result *models.ImportListSyncResult) error {
-	// Fetch movies from the import list
-	movies, err := s.fetchMoviesFromImportList(list)
+	// Fetch movies from the import list, skipping the sync when the list is unchanged
+	movies, err := s.fetchMoviesFromImportList(list, true)
+	if errors.Is(err, errImportListUnchanged) {
+		result.Unchanged = true
+		s.logger.Info("Import list unchanged since the last fetch, skipping sync", "listId", list.ID)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to fetch movies: %w", err)
 	}
+	list.LastFetch.Movies = len(movies)
 
 	result.MoviesTotal = len(movies)
 	result.Movies = movies
@@ -264,9 +314,110 @@ func (s *ImportListService) processImportListSync(
 		s.processSingleMovie(movie, list, result)
 	}
 
+	stale, err := s.staleDiscoveries(list, movies)
+	if err != nil {
+		return err
+	}
+	if len(stale) > 0 {
+		ids := make([]int, len(stale))
+		for i := range stale {
+			ids[i] = stale[i].ID
+		}
+		if err := s.db.GORM.Delete(&models.ImportListMovie{}, ids).Error; err != nil {
+			return fmt.Errorf("failed to clean discovered movies: %w", err)
+		}
+		result.MoviesCleaned = len(stale)
+	}
+
 	return nil
 }
 
+// PreviewImportListSync fetches an import list and reports which movies a sync would add, queue
+// for review, skip, or clean, without changing the library. Disabled lists are previewed as if
+// enabled so a list can be checked before it is switched on.
+func (s *ImportListService) PreviewImportListSync(listID int) (*models.ImportListPreview, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	list, err := s.GetImportListByID(listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import list: %w", err)
+	}
+	list.Enabled, list.EnableAuto = true, true
+
+	movies, err := s.fetchMoviesFromImportList(list, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch movies: %w", err)
+	}
+
+	preview := &models.ImportListPreview{
+		ImportListID:   list.ID,
+		ImportListName: list.Name,
+		MoviesTotal:    len(movies),
+		Added:          []models.ImportListPreviewItem{},
+		Review:         []models.ImportListPreviewItem{},
+		Skipped:        []models.ImportListPreviewItem{},
+		Cleaned:        []models.ImportListPreviewItem{},
+		PreviewTime:    time.Now(),
+	}
+
+	for i := range movies {
+		action, err := s.classifyImportListMovie(&movies[i], list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to classify movie %s: %w", movies[i].Title, err)
+		}
+		item := previewItem(&movies[i], action)
+		switch action {
+		case models.ImportListSyncAdd:
+			preview.Added = append(preview.Added, item)
+		case models.ImportListSyncReview:
+			preview.Review = append(preview.Review, item)
+		default:
+			preview.Skipped = append(preview.Skipped, item)
+		}
+	}
+
+	stale, err := s.staleDiscoveries(list, movies)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stale {
+		preview.Cleaned = append(preview.Cleaned, previewItem(&stale[i], models.ImportListSyncClean))
+	}
+
+	s.logger.Info("Previewed import list sync", "listId", listID, "total", preview.MoviesTotal,
+		"add", len(preview.Added), "review", len(preview.Review), "skip", len(preview.Skipped),
+		"clean", len(preview.Cleaned))
+	return preview, nil
+}
+
+func previewItem(movie *models.ImportListMovie, action models.ImportListSyncAction) models.ImportListPreviewItem {
+	return models.ImportListPreviewItem{TmdbID: movie.TmdbID, Title: movie.Title, Year: movie.Year, Action: action}
+}
+
+// staleDiscoveries returns the list's pending discovered movies that are no longer on it. A list
+// that returned nothing is more likely failing than empty, so nothing is cleaned then.
+func (s *ImportListService) staleDiscoveries(
+	list *models.ImportList, movies []models.ImportListMovie) ([]models.ImportListMovie, error) {
+	if len(movies) == 0 {
+		return nil, nil
+	}
+
+	listed := make([]int, len(movies))
+	for i := range movies {
+		listed[i] = movies[i].TmdbID
+	}
+
+	var stale []models.ImportListMovie
+	err := s.db.GORM.Where("import_list_id = ? AND approval_status = ? AND tmdb_id NOT IN ?",
+		list.ID, models.ImportListApprovalPending, listed).Find(&stale).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale discovered movies: %w", err)
+	}
+	return stale, nil
+}
+
 // processSingleMovie processes a single movie and updates the result counters
 func (s *ImportListService) processSingleMovie(
 	movie models.ImportListMovie, list *models.ImportList, result *models.ImportListSyncResult) {
@@ -286,13 +437,17 @@ func (s *ImportListService) processSingleMovie(
 		result.MoviesExcluded++
 	case "existing":
 		result.MoviesExisting++
+	case ImportListResultPending:
+		result.MoviesPending++
+	case ImportListResultFiltered:
+		result.MoviesFiltered++
 	}
 }
 
 // logSyncCompletion logs the completion of import list sync
 func (s *ImportListService) logSyncCompletion(listID int, result *models.ImportListSyncResult) {
 	s.logger.Info("Completed import list sync", "listId", listID,
-		"total", result.MoviesTotal, "added", result.MoviesAdded,
+		"total", result.MoviesTotal, "added", result.MoviesAdded, "filtered", result.MoviesFiltered,
 		"updated", result.MoviesUpdated, "errors", len(result.Errors))
 }
 
@@ -320,10 +475,22 @@ func (s *ImportListService) SyncAllImportLists() ([]models.ImportListSyncResult,
 
 // GetImportListMovies retrieves movies discovered from import lists
 func (s *ImportListService) GetImportListMovies(listID *int, limit int) ([]models.ImportListMovie, error) {
+	return s.GetImportListMoviesByStatus(listID, "", limit)
+}
+
+// GetImportListMoviesByStatus retrieves discovered movies filtered by approval status.
+// An empty status returns movies in every state.
+func (s *ImportListService) GetImportListMoviesByStatus(
+	listID *int, status models.ImportListApprovalStatus, limit int,
+) ([]models.ImportListMovie, error) {
 	if s.db == nil {
 		return nil, fmt.Errorf("database not available")
 	}
 
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("invalid approval status: %s", status)
+	}
+
 	var movies []models.ImportListMovie
 	query := s.db.GORM.Preload("ImportList").Order("discovered_at DESC")
 
@@ -331,6 +498,10 @@ func (s *ImportListService) GetImportListMovies(listID *int, limit int) ([]model
 		query = query.Where("import_list_id = ?", *listID)
 	}
 
+	if status != "" {
+		query = query.Where("approval_status = ?", status)
+	}
+
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -354,8 +525,9 @@ func (s *ImportListService) validateImportList(list *models.ImportList) error {
 		return fmt.Errorf("implementation is required")
 	}
 
-	if list.QualityProfileID <= 0 {
-		return fmt.Errorf("quality profile ID is required")
+	// Without a quality profile, movies take the default of the list's root folder
+	if list.QualityProfileID < 0 {
+		return fmt.Errorf("quality profile ID cannot be negative")
 	}
 
 	if list.RootFolderPath == "" {
@@ -427,7 +599,7 @@ func (s *ImportListService) testImportListConnection(list *models.ImportList) er
 
 	// Check if we get a reasonable response
 	if resp.StatusCode >= 500 {
-		return fmt.Errorf("server error: %d", resp.StatusCode)
+		return &providerStatusError{Provider: list.Name, StatusCode: resp.StatusCode}
 	}
 
 	return nil
@@ -457,37 +629,106 @@ func (s *ImportListService) fetchSampleMovies(list *models.ImportList, _ int) ([
 	return movies, nil
 }
 
-// fetchMoviesFromImportList fetches all movies from an import list
-func (s *ImportListService) fetchMoviesFromImportList(list *models.ImportList) ([]models.ImportListMovie, error) {
+// fetchMoviesFromImportList fetches all movies from an import list. A conditional fetch returns
+// errImportListUnchanged when a remote list hasn't changed since its last fetch.
+func (s *ImportListService) fetchMoviesFromImportList(list *models.ImportList, conditional bool) (
+	[]models.ImportListMovie, error) {
+	if list.Implementation == models.ImportListTypeRadarrList {
+		return s.fetchRadarrList(list, conditional)
+	}
+
 	// This is a placeholder implementation
 	// In a real implementation, this would connect to the actual import list service
 	// (TMDB, Trakt, Plex, etc.) and fetch the actual movie list
 
 	s.logger.Debug("Fetching movies from import list", "listId", list.ID, "type", list.Implementation)
 
-	if !list.IsEnabled() {
-		return nil, fmt.Errorf("import list is not enabled")
-	}
-
 	// Return empty slice for now - this would be implemented with actual API calls
 	return []models.ImportListMovie{}, nil
 }
 
-// processImportListMovie processes a single movie from an import list
-func (s *ImportListService) processImportListMovie(
-	movie models.ImportListMovie, list *models.ImportList) (string, error) {
-	// Check if movie is already in the collection
-	// TODO: Implement GetMovieByTMDBID in MovieService
-	// For now, assume movie doesn't exist
-	_ = movie
+// classifyImportListMovie decides what a sync does with a listed movie without changing anything
+func (s *ImportListService) classifyImportListMovie(
+	movie *models.ImportListMovie, list *models.ImportList) (models.ImportListSyncAction, error) {
+	if s.movieService != nil {
+		_, err := s.movieService.GetByTmdbID(movie.TmdbID)
+		if err == nil {
+			return models.ImportListSyncSkipExisting, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("failed to check library: %w", err)
+		}
+	}
 
-	// Check if movie is excluded
 	if s.isMovieExcluded(movie.TmdbID) {
-		return "excluded", nil
+		return models.ImportListSyncSkipExcluded, nil
+	}
+
+	tombstoned, err := s.isMovieTombstoned(movie.TmdbID)
+	if err != nil {
+		return "", err
+	}
+	if tombstoned {
+		return models.ImportListSyncSkipTombstoned, nil
+	}
+
+	if list.Settings.FiltersWatchProviders() {
+		allowed, err := s.allowedByWatchProviders(movie, list)
+		if err != nil {
+			return "", err
+		}
+		if !allowed {
+			return models.ImportListSyncSkipWatchProvider, nil
+		}
 	}
 
-	// If auto-add is enabled, add the movie to the collection
 	if list.ShouldAutoAdd() {
+		return models.ImportListSyncAdd, nil
+	}
+
+	// Keep previous review decisions when the list is synced again
+	var existing models.ImportListMovie
+	err = s.db.GORM.Where("import_list_id = ? AND tmdb_id = ?", list.ID, movie.TmdbID).First(&existing).Error
+	if err == nil {
+		return models.ImportListSyncSkipDiscovered, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("failed to check discovered movie: %w", err)
+	}
+	return models.ImportListSyncReview, nil
+}
+
+// allowedByWatchProviders looks up where a listed movie streams and checks it against the list's
+// watch provider filters
+func (s *ImportListService) allowedByWatchProviders(
+	movie *models.ImportListMovie, list *models.ImportList) (bool, error) {
+	if s.metadataService == nil {
+		return false, fmt.Errorf("metadata service not available to check watch providers")
+	}
+
+	providers, err := s.metadataService.GetWatchProviders(movie.TmdbID, list.Settings.WatchRegion)
+	if err != nil {
+		return false, fmt.Errorf("failed to check watch providers: %w", err)
+	}
+	return list.Settings.AllowsWatchProviders(providers), nil
+}
+
+// processImportListMovie processes a single movie from an import list
+func (s *ImportListService) processImportListMovie(
+	movie models.ImportListMovie, list *models.ImportList) (string, error) {
+	action, err := s.classifyImportListMovie(&movie, list)
+	if err != nil {
+		return "", err
+	}
+
+	switch action {
+	case models.ImportListSyncSkipExcluded, models.ImportListSyncSkipTombstoned:
+		return "excluded", nil
+	case models.ImportListSyncSkipWatchProvider:
+		return ImportListResultFiltered, nil
+	case models.ImportListSyncSkipExisting, models.ImportListSyncSkipDiscovered:
+		return "existing", nil
+	case models.ImportListSyncAdd:
 		newMovie := &models.Movie{
 			Title:               movie.Title,
 			OriginalTitle:       movie.OriginalTitle,
@@ -515,14 +756,148 @@ func (s *ImportListService) processImportListMovie(
 	// Store as discovered movie for manual review
 	movie.ImportListID = list.ID
 	movie.DiscoveredAt = time.Now()
+	movie.ApprovalStatus = models.ImportListApprovalPending
 
 	if err := s.db.GORM.Create(&movie).Error; err != nil {
 		return "", fmt.Errorf("failed to store discovered movie: %w", err)
 	}
 
+	if list.RequiresApproval {
+		return ImportListResultPending, nil
+	}
 	return "updated", nil
 }
 
+// ApproveImportListMovie adds a discovered movie to the library using its list's settings
+func (s *ImportListService) ApproveImportListMovie(id int) (*models.ImportListMovie, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	item, err := s.getImportListMovie(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.ApprovalStatus == models.ImportListApprovalApproved {
+		return item, nil
+	}
+
+	if item.ImportList == nil {
+		return nil, fmt.Errorf("import list %d for movie not found", item.ImportListID)
+	}
+
+	if _, err := s.movieService.GetByTmdbID(item.TmdbID); err == nil {
+		s.logger.Debug("Approved movie already in library", "tmdbId", item.TmdbID)
+		item.IsExisting = true
+	} else if err := s.addApprovedMovie(item); err != nil {
+		return nil, err
+	}
+
+	if err := s.setApprovalStatus(item, models.ImportListApprovalApproved); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Approved import list movie", "id", id, "title", item.Title, "tmdbId", item.TmdbID)
+	return item, nil
+}
+
+// RejectImportListMovie rejects a discovered movie, optionally excluding it from all future syncs
+func (s *ImportListService) RejectImportListMovie(id int, exclude bool) (*models.ImportListMovie, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	item, err := s.getImportListMovie(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exclude && !s.isMovieExcluded(item.TmdbID) {
+		exclusion := &models.ImportListExclusion{
+			TmdbID:     item.TmdbID,
+			MovieTitle: item.Title,
+			MovieYear:  item.Year,
+			ImdbID:     item.ImdbID,
+			Reason:     "Rejected from import list review",
+		}
+		if err := s.db.GORM.Create(exclusion).Error; err != nil {
+			return nil, fmt.Errorf("failed to create exclusion: %w", err)
+		}
+		item.IsExcluded = true
+	}
+
+	if err := s.setApprovalStatus(item, models.ImportListApprovalRejected); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Rejected import list movie", "id", id, "title", item.Title, "excluded", exclude)
+	return item, nil
+}
+
+// getImportListMovie loads a discovered movie with its import list
+func (s *ImportListService) getImportListMovie(id int) (*models.ImportListMovie, error) {
+	var item models.ImportListMovie
+	if err := s.db.GORM.Preload("ImportList").First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("import list movie not found: %d", id)
+		}
+		return nil, fmt.Errorf("failed to fetch import list movie: %w", err)
+	}
+	return &item, nil
+}
+
+// addApprovedMovie creates a library movie from a discovered item, preferring full TMDB metadata
+func (s *ImportListService) addApprovedMovie(item *models.ImportListMovie) error {
+	list := item.ImportList
+
+	movie, err := s.metadataService.LookupMovieByTMDBID(item.TmdbID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch metadata for approved movie: %w", err)
+	}
+
+	movie.QualityProfileID = list.QualityProfileID
+	movie.RootFolderPath = list.RootFolderPath
+	movie.Monitored = list.ShouldMonitor
+	movie.MinimumAvailability = list.MinimumAvailability
+	movie.Tags = list.Tags
+	movie.AddOptions = models.AddOptions{Monitor: list.ShouldMonitor, AddMethod: "list"}
+	movie.Added = time.Now()
+
+	if err := s.movieService.ApplyRootFolderDefaults(movie); err != nil {
+		return err
+	}
+	if movie.QualityProfileID <= 0 {
+		return fmt.Errorf("import list %q has no quality profile and root folder %s has no default quality profile",
+			list.Name, list.RootFolderPath)
+	}
+
+	if err := s.movieService.Create(movie); err != nil {
+		return fmt.Errorf("failed to add approved movie: %w", err)
+	}
+	return nil
+}
+
+// setApprovalStatus records a review decision on a discovered movie
+func (s *ImportListService) setApprovalStatus(
+	item *models.ImportListMovie, status models.ImportListApprovalStatus,
+) error {
+	now := time.Now()
+	item.ApprovalStatus = status
+	item.ReviewedAt = &now
+
+	err := s.db.GORM.Model(&models.ImportListMovie{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"approval_status": status,
+		"reviewed_at":     now,
+		"is_excluded":     item.IsExcluded,
+		"is_existing":     item.IsExisting,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update import list movie: %w", err)
+	}
+	return nil
+}
+
 // isMovieExcluded checks if a movie is in the exclusion list
 func (s *ImportListService) isMovieExcluded(tmdbID int) bool {
 	if s.db == nil {
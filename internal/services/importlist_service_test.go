@@ -3,6 +3,7 @@ package services
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/logger"
@@ -128,7 +129,7 @@ func getImportListTestCases() []importListTestCase {
 			expected: false, errors: 1},
 		{name: "Missing implementation", list: createImportListWithoutField("implementation", validList),
 			expected: false, errors: 1},
-		{name: "Missing quality profile", list: createImportListWithoutField("qualityProfile", validList),
+		{name: "Negative quality profile", list: createImportListWithoutField("qualityProfile", validList),
 			expected: false, errors: 1},
 		{name: "Missing root folder", list: createImportListWithoutField("rootFolder", validList),
 			expected: false, errors: 1},
@@ -143,7 +144,7 @@ func createImportListWithoutField(field string, base *models.ImportList) *models
 	case "implementation":
 		list.Implementation = ""
 	case "qualityProfile":
-		list.QualityProfileID = 0
+		list.QualityProfileID = -1
 	case "rootFolder":
 		list.RootFolderPath = ""
 	}
@@ -197,9 +198,13 @@ func getImportListValidationTestCases() []struct {
 		{name: "Missing implementation", list: &models.ImportList{
 			Name: "Test List", QualityProfileID: 1, RootFolderPath: "/movies"},
 			wantErr: true, errMsg: "implementation is required"},
-		{name: "Missing quality profile", list: &models.ImportList{
+		{name: "Root folder default quality profile", list: &models.ImportList{
 			Name: "Test List", Implementation: models.ImportListTypeTMDBPopular, RootFolderPath: "/movies"},
-			wantErr: true, errMsg: "quality profile ID is required"},
+			wantErr: false},
+		{name: "Negative quality profile", list: &models.ImportList{
+			Name: "Test List", Implementation: models.ImportListTypeTMDBPopular, QualityProfileID: -1,
+			RootFolderPath: "/movies"},
+			wantErr: true, errMsg: "quality profile ID cannot be negative"},
 		{name: "Missing root folder", list: &models.ImportList{
 			Name: "Test List", Implementation: models.ImportListTypeTMDBPopular, QualityProfileID: 1},
 			wantErr: true, errMsg: "root folder path is required"},
@@ -274,6 +279,21 @@ func TestImportListService_SyncImportList(t *testing.T) {
 	assert.Contains(t, err.Error(), "database not available")
 }
 
+func TestImportListService_PreviewImportListSync(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewImportListService(nil, logger, nil, nil)
+
+	// Test with nil database
+	_, err := service.PreviewImportListSync(1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	// An empty fetch never cleans discovered movies
+	stale, err := service.staleDiscoveries(&models.ImportList{ID: 1}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, stale)
+}
+
 func TestImportListService_GetImportListMovies(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
 	service := NewImportListService(nil, logger, nil, nil)
@@ -284,6 +304,70 @@ func TestImportListService_GetImportListMovies(t *testing.T) {
 	assert.Contains(t, err.Error(), "database not available")
 }
 
+func TestImportListService_ReviewImportListMovie(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewImportListService(nil, logger, nil, nil)
+
+	// Test with nil database
+	_, err := service.GetImportListMoviesByStatus(nil, models.ImportListApprovalPending, 10)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.ApproveImportListMovie(1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.RejectImportListMovie(1, true)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	// Test approval status validation
+	assert.True(t, models.ImportListApprovalApproved.IsValid())
+	assert.False(t, models.ImportListApprovalStatus("unknown").IsValid())
+}
+
+func TestImportListService_Tombstones(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewImportListService(nil, logger, nil, nil)
+
+	// Test with nil database
+	_, err := service.GetTombstones()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	err = service.DeleteTombstone(1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.ClearTombstones()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	tombstoned, err := service.isMovieTombstoned(603)
+	assert.NoError(t, err)
+	assert.False(t, tombstoned)
+}
+
+func TestParseTombstonePeriod(t *testing.T) {
+	period, err := parseTombstonePeriod(config.ImportListConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTombstonePeriod, period)
+
+	period, err = parseTombstonePeriod(config.ImportListConfig{TombstonePeriod: "48h"})
+	assert.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, period)
+
+	period, err = parseTombstonePeriod(config.ImportListConfig{TombstonePeriod: "0"})
+	assert.NoError(t, err)
+	assert.Zero(t, period)
+
+	for _, invalid := range []string{"a month", "-1h"} {
+		period, err = parseTombstonePeriod(config.ImportListConfig{TombstonePeriod: invalid})
+		assert.Error(t, err, invalid)
+		assert.Equal(t, defaultTombstonePeriod, period)
+	}
+}
+
 func TestImportListService_GetImportListStats(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
 	service := NewImportListService(nil, logger, nil, nil)
@@ -357,6 +441,10 @@ func TestImportList_Methods(t *testing.T) {
 
 	list.EnableAuto = false
 	assert.False(t, list.ShouldAutoAdd())
+
+	list.EnableAuto = true
+	list.RequiresApproval = true
+	assert.False(t, list.ShouldAutoAdd())
 }
 
 func TestImportListType_Constants(t *testing.T) {
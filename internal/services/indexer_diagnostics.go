@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// indexerTestTimeout bounds the capabilities request of an indexer test
+	indexerTestTimeout = 15 * time.Second
+	// maxIndexerTestResponse bounds the capabilities and feed documents read during a test
+	maxIndexerTestResponse = 5 << 20
+)
+
+// newznabCaps is a Newznab/Torznab capabilities document, or the error document indexers answer
+// with instead
+type newznabCaps struct {
+	XMLName xml.Name
+	// Code and Description are set on <error> documents
+	Code        string `xml:"code,attr"`
+	Description string `xml:"description,attr"`
+	Server      struct {
+		Version string `xml:"version,attr"`
+		Title   string `xml:"title,attr"`
+	} `xml:"server"`
+	Searching struct {
+		MovieSearch struct {
+			Available string `xml:"available,attr"`
+		} `xml:"movie-search"`
+	} `xml:"searching"`
+	Categories []struct {
+		ID      int `xml:"id,attr"`
+		Subcats []struct {
+			ID int `xml:"id,attr"`
+		} `xml:"subcat"`
+	} `xml:"categories>category"`
+}
+
+// diagnoseIndexer tests an indexer with valid settings: Newznab and Torznab indexers are asked for
+// their capabilities, which checks the API key and that they search movies in the configured
+// categories; RSS indexers must serve a feed
+func (s *IndexerService) diagnoseIndexer(indexer *models.Indexer, report *models.ProviderTestReport) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexerTestTimeout)
+	defer cancel()
+	httpClient := httpclient.New(indexerTestTimeout, httpclient.WithSkipTLSVerify(indexer.SkipTLSVerify()))
+
+	if indexer.Type != models.IndexerTypeNewznab && indexer.Type != models.IndexerTypeTorznab {
+		body, err := fetchIndexerDocument(ctx, httpClient, indexer, indexer.BaseURL)
+		if err != nil {
+			report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, indexer.Name, err))
+			return
+		}
+		report.Pass(models.ProviderTestCheckConnectivity, "")
+
+		var feed struct{ XMLName xml.Name }
+		err = xml.Unmarshal(body, &feed)
+		if err != nil || (feed.XMLName.Local != "rss" && feed.XMLName.Local != "feed") {
+			report.Fail(models.ProviderTestFailure{
+				Check:    models.ProviderTestCheckCapability,
+				Category: models.ProviderTestFailureUnexpectedResponse,
+				Message:  "The URL doesn't serve an RSS or Atom feed",
+				Hint:     "Check the URL is the indexer's feed and not its website",
+			})
+			return
+		}
+		report.Pass(models.ProviderTestCheckCapability, "")
+		return
+	}
+
+	capsURL, err := url.Parse(indexer.BaseURL)
+	if err != nil {
+		report.Fail(configurationFailure("baseUrl", fmt.Sprintf("Base URL is invalid: %v", err)))
+		return
+	}
+	capsURL.RawQuery = url.Values{"t": {"caps"}, "apikey": {indexer.APIKey}}.Encode()
+	body, err := fetchIndexerDocument(ctx, httpClient, indexer, capsURL.String())
+	if err != nil {
+		report.Fail(diagnoseProviderError(models.ProviderTestCheckConnectivity, indexer.Name, err))
+		return
+	}
+
+	var caps newznabCaps
+	if err := xml.Unmarshal(body, &caps); err != nil {
+		report.Fail(models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckConnectivity,
+			Category: models.ProviderTestFailureUnexpectedResponse,
+			Message:  fmt.Sprintf("Invalid capabilities document: %v", err),
+			Hint:     "Check the URL points to the indexer's API, usually ending in /api",
+		})
+		return
+	}
+	report.Pass(models.ProviderTestCheckConnectivity, "")
+
+	if caps.XMLName.Local == "error" {
+		report.Fail(diagnoseNewznabError(indexer, caps.Code, caps.Description))
+		return
+	}
+	report.Pass(models.ProviderTestCheckAuthentication, "")
+
+	if failure := checkIndexerCaps(indexer, &caps); failure != nil {
+		report.Fail(*failure)
+		return
+	}
+	report.Pass(models.ProviderTestCheckCapability, "")
+
+	version := "Version not reported"
+	if caps.Server.Version != "" {
+		version = strings.TrimSpace(caps.Server.Title + " " + caps.Server.Version)
+	}
+	report.Pass(models.ProviderTestCheckVersion, version)
+}
+
+// fetchIndexerDocument GETs a document from an indexer
+func fetchIndexerDocument(ctx context.Context, httpClient *http.Client, indexer *models.Indexer,
+	documentURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", indexer.Name, err)
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // read-only response
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &providerStatusError{Provider: indexer.Name, StatusCode: resp.StatusCode}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexerTestResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", indexer.Name, err)
+	}
+	return body, nil
+}
+
+// diagnoseNewznabError categorizes a Newznab error code: 1xx codes are account and credential
+// errors, 2xx codes are requests the indexer doesn't support
+func diagnoseNewznabError(indexer *models.Indexer, code, description string) models.ProviderTestFailure {
+	failure := models.ProviderTestFailure{
+		Check:    models.ProviderTestCheckAuthentication,
+		Category: models.ProviderTestFailureUnexpectedResponse,
+		Message:  fmt.Sprintf("%s returned error %s: %s", indexer.Name, code, description),
+	}
+	switch {
+	case strings.HasPrefix(code, "1"):
+		failure.Category, failure.Field = models.ProviderTestFailureUnauthorized, "apiKey"
+		failure.Hint = "Check the API key, and that the account is active and allowed to use the API"
+	case strings.HasPrefix(code, "2"):
+		failure.Check, failure.Category = models.ProviderTestCheckCapability, models.ProviderTestFailureUnsupported
+		failure.Hint = "The indexer doesn't support capability requests, check it is a Newznab or Torznab API"
+	}
+	return failure
+}
+
+// checkIndexerCaps checks an indexer searches movies, when searches are enabled, and lists the
+// configured categories
+func checkIndexerCaps(indexer *models.Indexer, caps *newznabCaps) *models.ProviderTestFailure {
+	searches := indexer.EnableAutomaticSearch || indexer.EnableInteractiveSearch
+	if searches && caps.Searching.MovieSearch.Available != "yes" {
+		return &models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckCapability,
+			Category: models.ProviderTestFailureUnsupported,
+			Message:  fmt.Sprintf("%s doesn't support movie searches", indexer.Name),
+			Hint:     "Turn off automatic and interactive search and use the indexer for RSS only",
+		}
+	}
+
+	if indexer.Categories == "" || len(caps.Categories) == 0 {
+		return nil
+	}
+	known := make(map[int]bool)
+	for _, category := range caps.Categories {
+		known[category.ID] = true
+		for _, subcat := range category.Subcats {
+			known[subcat.ID] = true
+		}
+	}
+	var unknown []string
+	for _, category := range strings.Split(indexer.Categories, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(category))
+		if err != nil || !known[id] {
+			unknown = append(unknown, strings.TrimSpace(category))
+		}
+	}
+	if len(unknown) > 0 {
+		return &models.ProviderTestFailure{
+			Check:    models.ProviderTestCheckCapability,
+			Category: models.ProviderTestFailureUnsupported,
+			Field:    "categories",
+			Message:  fmt.Sprintf("%s doesn't have categories %s", indexer.Name, strings.Join(unknown, ", ")),
+			Hint:     "Pick categories from the indexer's capabilities",
+		}
+	}
+	return nil
+}
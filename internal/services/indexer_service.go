@@ -2,6 +2,8 @@ package services
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
@@ -59,7 +61,17 @@ func (s *IndexerService) CreateIndexer(indexer *models.Indexer) error {
 
 // UpdateIndexer updates an existing indexer configuration.
 func (s *IndexerService) UpdateIndexer(indexer *models.Indexer) error {
-	if err := s.db.GORM.Save(indexer).Error; err != nil {
+	return s.UpdateIndexerIfUnchanged(indexer, time.Time{})
+}
+
+// UpdateIndexerIfUnchanged updates an indexer configuration unless it was updated after read, the
+// updated time of the copy the changes were made to, returning a models.ConflictError if it was.
+func (s *IndexerService) UpdateIndexerIfUnchanged(indexer *models.Indexer, read time.Time) error {
+	err := saveUnlessStale(s.db.GORM, indexer, indexer.ID, read, "indexer")
+	if isConflict(err) {
+		return err
+	}
+	if err != nil {
 		s.logger.Error("Failed to update indexer", "id", indexer.ID, "error", err)
 		return fmt.Errorf("failed to update indexer: %w", err)
 	}
@@ -96,37 +108,38 @@ func (s *IndexerService) GetEnabledIndexers() ([]*models.Indexer, error) {
 	return indexers, nil
 }
 
-// TestIndexer tests the connection to an indexer.
+// TestIndexer tests the connection to an indexer, recording the outcome on the indexer when it's
+// a saved one.
 func (s *IndexerService) TestIndexer(indexer *models.Indexer) (*models.IndexerTestResult, error) {
-	// Basic validation
-	errors := []string{}
+	result := &models.IndexerTestResult{ProviderTestReport: models.NewProviderTestReport()}
+	report := &result.ProviderTestReport
 
 	if indexer.Name == "" {
-		errors = append(errors, "Name is required")
+		report.Fail(configurationFailure("name", "Name is required"))
 	}
 
 	if indexer.BaseURL == "" {
-		errors = append(errors, "Base URL is required")
+		report.Fail(configurationFailure("baseUrl", "Base URL is required"))
+	} else if baseURL, err := url.Parse(indexer.BaseURL); err != nil ||
+		(baseURL.Scheme != "http" && baseURL.Scheme != "https") {
+		report.Fail(configurationFailure("baseUrl", "Base URL must be an http or https URL"))
 	}
 
 	if indexer.Type == models.IndexerTypeTorznab || indexer.Type == models.IndexerTypeNewznab {
 		if indexer.APIKey == "" {
-			errors = append(errors, "API Key is required for Torznab/Newznab indexers")
+			report.Fail(configurationFailure("apiKey", "API Key is required for Torznab/Newznab indexers"))
 		}
 	}
 
-	result := &models.IndexerTestResult{
-		IsValid: len(errors) == 0,
-		Errors:  errors,
+	if report.Passed() {
+		report.Pass(models.ProviderTestCheckConfiguration, "")
+		s.diagnoseIndexer(indexer, report)
 	}
+	result.IsValid, result.Errors = report.Passed(), report.Messages()
 
-	if !result.IsValid {
-		return result, nil
+	if err := recordProviderTest(s.db, &models.Indexer{}, indexer.ID, report); err != nil {
+		s.logger.Warn("Failed to record indexer test", "name", indexer.Name, "error", err)
 	}
-
-	// TODO: Implement actual indexer connection testing
-	// This would involve making HTTP requests to the indexer's capabilities endpoint
-
 	s.logger.Info("Tested indexer connection", "name", indexer.Name, "valid", result.IsValid)
 	return result, nil
 }
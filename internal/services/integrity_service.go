@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// IntegrityFinding describes records that failed one data integrity check
+type IntegrityFinding struct {
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+	Count    int64  `json:"count"`
+	Repaired int64  `json:"repaired"`
+}
+
+// integrityCheck finds records left inconsistent by manual edits or interrupted operations.
+// where selects the offending rows of table; repair, when set, fixes the rows matched by the
+// query it is given and leaves the finding report-only otherwise.
+type integrityCheck struct {
+	name    string
+	message string
+	table   string
+	where   string
+	repair  func(tx *gorm.DB) *gorm.DB
+}
+
+// integrityChecks lists the consistency checks run at startup and by the health checker.
+// Each is a single indexed anti-join so the whole set stays fast on large libraries.
+var integrityChecks = []integrityCheck{
+	{
+		name:    "Wanted movies without a movie",
+		message: "%d wanted entries reference deleted movies",
+		table:   "wanted_movies",
+		where:   "movie_id NOT IN (SELECT id FROM movies)",
+		repair: func(tx *gorm.DB) *gorm.DB {
+			return tx.Delete(&models.WantedMovie{})
+		},
+	},
+	{
+		name:    "Queue items without a download client",
+		message: "%d queue items reference download clients that no longer exist",
+		table:   "queue_items",
+		where:   "download_client_id > 0 AND download_client_id NOT IN (SELECT id FROM download_clients)",
+		repair: func(tx *gorm.DB) *gorm.DB {
+			return tx.Delete(&models.QueueItem{})
+		},
+	},
+	{
+		name:    "Movies with a missing movie file",
+		message: "%d movies reference movie files that no longer exist",
+		table:   "movies",
+		where:   "movie_file_id > 0 AND movie_file_id NOT IN (SELECT id FROM movie_files)",
+		repair: func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(&models.Movie{}).Updates(map[string]interface{}{"movie_file_id": 0, "has_file": false})
+		},
+	},
+	{
+		name:    "History without a movie",
+		message: "%d history records reference deleted movies",
+		table:   "history",
+		where:   "movie_id IS NOT NULL AND movie_id NOT IN (SELECT id FROM movies)",
+		repair: func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(&models.History{}).Update("movie_id", nil)
+		},
+	},
+	{
+		// Files may still exist on disk, so these are reported for a rescan rather than deleted
+		name:    "Movie files without a movie",
+		message: "%d movie files reference deleted movies",
+		table:   "movie_files",
+		where:   "movie_id NOT IN (SELECT id FROM movies)",
+	},
+	{
+		name:    "Movies without a quality profile",
+		message: "%d movies use quality profiles that no longer exist",
+		table:   "movies",
+		where:   "quality_profile_id NOT IN (SELECT id FROM quality_profiles)",
+	},
+}
+
+// IntegrityService runs data consistency checks and optionally repairs what they find
+type IntegrityService struct {
+	db         *database.Database
+	logger     *logger.Logger
+	autoRepair bool
+
+	mu       sync.RWMutex
+	findings []IntegrityFinding
+	lastRun  time.Time
+}
+
+// NewIntegrityService creates a new data integrity service
+func NewIntegrityService(db *database.Database, autoRepair bool, logger *logger.Logger) *IntegrityService {
+	return &IntegrityService{
+		db:         db,
+		logger:     logger,
+		autoRepair: autoRepair,
+	}
+}
+
+// RunStartupChecks runs every integrity check, repairing findings when auto repair is enabled
+func (s *IntegrityService) RunStartupChecks(ctx context.Context) ([]IntegrityFinding, error) {
+	return s.Run(ctx, s.autoRepair)
+}
+
+// Run executes every integrity check and returns the checks that found problems. With repair
+// set, repairable findings are fixed and their Repaired count reflects the rows changed.
+func (s *IntegrityService) Run(ctx context.Context, repair bool) ([]IntegrityFinding, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	tx := s.db.GORM.WithContext(ctx)
+	findings := make([]IntegrityFinding, 0)
+
+	for _, check := range integrityChecks {
+		if !tx.Migrator().HasTable(check.table) {
+			continue
+		}
+
+		var count int64
+		if err := tx.Table(check.table).Where(check.where).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("integrity check %q failed: %w", check.name, err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		finding := IntegrityFinding{Check: check.name, Message: fmt.Sprintf(check.message, count), Count: count}
+
+		if repair && check.repair != nil {
+			result := check.repair(tx.Where(check.where))
+			if result.Error != nil {
+				s.logger.Error("Failed to repair integrity finding", "check", check.name, "error", result.Error)
+			} else {
+				finding.Repaired = result.RowsAffected
+			}
+		}
+
+		s.logger.Warn("Data integrity problem found", "check", check.name, "count", finding.Count,
+			"repaired", finding.Repaired)
+		findings = append(findings, finding)
+	}
+
+	s.mu.Lock()
+	s.findings = findings
+	s.lastRun = time.Now()
+	s.mu.Unlock()
+
+	if len(findings) == 0 {
+		s.logger.Info("Data integrity checks passed")
+	}
+	return findings, nil
+}
+
+// Findings returns the results of the most recent run and when it happened
+func (s *IntegrityService) Findings() ([]IntegrityFinding, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]IntegrityFinding(nil), s.findings...), s.lastRun
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrityService_NilDatabase(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewIntegrityService(nil, true, logger)
+
+	_, err := service.RunStartupChecks(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	checker := NewDataIntegrityHealthChecker(service)
+	result := checker.Check(context.Background())
+	assert.Equal(t, models.HealthStatusError, result.Status)
+	assert.Equal(t, models.HealthCheckTypeDataIntegrity, checker.Type())
+}
+
+func TestIntegrityService_RepairsDanglingMovieFile(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	movie := &models.Movie{TmdbID: 603, Title: "The Matrix", TitleSlug: "the-matrix-603", Year: 1999,
+		QualityProfileID: 1, HasFile: true, MovieFileID: 999999}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	// Report-only runs leave the record alone
+	service := NewIntegrityService(db, false, logger)
+	findings, err := service.RunStartupChecks(context.Background())
+	require.NoError(t, err)
+	finding := findIntegrityFinding(findings, "Movies with a missing movie file")
+	require.NotNil(t, finding)
+	assert.Equal(t, int64(1), finding.Count)
+	assert.Zero(t, finding.Repaired)
+
+	service = NewIntegrityService(db, true, logger)
+	findings, err = service.RunStartupChecks(context.Background())
+	require.NoError(t, err)
+	finding = findIntegrityFinding(findings, "Movies with a missing movie file")
+	require.NotNil(t, finding)
+	assert.Equal(t, int64(1), finding.Repaired)
+
+	var stored models.Movie
+	require.NoError(t, db.GORM.First(&stored, movie.ID).Error)
+	assert.False(t, stored.HasFile)
+	assert.Zero(t, stored.MovieFileID)
+}
+
+func findIntegrityFinding(findings []IntegrityFinding, check string) *IntegrityFinding {
+	for i := range findings {
+		if findings[i].Check == check {
+			return &findings[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+)
+
+const (
+	// leaderLockKey is the PostgreSQL advisory lock key held by the leader
+	leaderLockKey int64 = 0x52616461727247 // "RadarrG"
+	// leaderLockName is the MySQL/MariaDB named lock held by the leader
+	leaderLockName = "radarr-go-leader"
+
+	defaultElectionInterval = 15 * time.Second
+)
+
+// LeaderStatus reports whether this instance is the one running scheduled tasks
+type LeaderStatus struct {
+	Enabled     bool       `json:"enabled"`
+	IsLeader    bool       `json:"isLeader"`
+	Instance    string     `json:"instance"`
+	LeaderSince *time.Time `json:"leaderSince,omitempty"`
+}
+
+// LeaderElector elects one instance among those sharing a database to run the schedulers,
+// using a session-level database lock held on a dedicated connection. The lock is released when
+// the connection closes, so a crashed leader is replaced by the next election round. Without
+// leader election enabled every instance is its own leader.
+type LeaderElector struct {
+	db       *database.Database
+	logger   *logger.Logger
+	enabled  bool
+	interval time.Duration
+	instance string
+
+	mu          sync.RWMutex
+	conn        *sql.Conn
+	leader      bool
+	leaderSince time.Time
+	onPromote   []func()
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewLeaderElector creates a leader elector from the cluster configuration
+func NewLeaderElector(db *database.Database, cfg config.ClusterConfig, logger *logger.Logger) *LeaderElector {
+	interval := defaultElectionInterval
+	if cfg.ElectionInterval != "" {
+		if parsed, err := time.ParseDuration(cfg.ElectionInterval); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			logger.Warn("Invalid cluster election interval, using default",
+				"value", cfg.ElectionInterval, "default", defaultElectionInterval)
+		}
+	}
+
+	return &LeaderElector{
+		db:       db,
+		logger:   logger,
+		enabled:  cfg.LeaderElection && db != nil && db.DB != nil,
+		interval: interval,
+		instance: cfg.Instance(),
+	}
+}
+
+// Start runs the first election before returning, so callers know whether to schedule tasks,
+// then keeps campaigning in the background until ctx ends or Stop is called
+func (e *LeaderElector) Start(ctx context.Context) {
+	if !e.enabled || e.cancel != nil {
+		return
+	}
+
+	ctx, e.cancel = context.WithCancel(ctx)
+	e.done = make(chan struct{})
+	e.campaign(ctx)
+
+	go func() {
+		defer close(e.done)
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if e.campaign(ctx) {
+					e.promoted()
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the campaign and releases leadership so another instance can take over at once
+func (e *LeaderElector) Stop() {
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := e.release(ctx, e.conn); err != nil {
+			e.logger.Warn("Failed to release leader lock", "error", err)
+		}
+	}
+	e.stepDown("instance stopping")
+}
+
+// OnPromote registers a callback run when this instance takes over leadership after Start, such as
+// when the previous leader crashed. The first election in Start does not run it; callers act on
+// that result themselves once Start returns.
+func (e *LeaderElector) OnPromote(callback func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onPromote = append(e.onPromote, callback)
+}
+
+// promoted runs the OnPromote callbacks
+func (e *LeaderElector) promoted() {
+	e.mu.RLock()
+	callbacks := e.onPromote
+	e.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// IsLeader reports whether this instance should run the schedulers
+func (e *LeaderElector) IsLeader() bool {
+	if e == nil || !e.enabled {
+		return true
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Status reports the election state for this instance
+func (e *LeaderElector) Status() LeaderStatus {
+	status := LeaderStatus{Enabled: e.enabled, IsLeader: e.IsLeader(), Instance: e.instance}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.leader {
+		since := e.leaderSince
+		status.LeaderSince = &since
+	}
+	return status
+}
+
+// campaign checks that a held lock's connection is still alive, or tries to take the lock, and
+// reports whether this instance just became leader
+func (e *LeaderElector) campaign(ctx context.Context) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		if err := e.conn.PingContext(ctx); err != nil {
+			e.logger.Warn("Lost database connection holding the leader lock", "error", err)
+			e.stepDown("lock connection lost")
+		}
+		return false
+	}
+
+	conn, err := e.db.DB.Conn(ctx)
+	if err != nil {
+		e.logger.Error("Failed to open connection for leader election", "error", err)
+		return false
+	}
+
+	acquired, err := e.acquire(ctx, conn)
+	if err != nil || !acquired {
+		if err != nil {
+			e.logger.Error("Failed to try the leader lock", "error", err)
+		}
+		_ = conn.Close() //nolint:errcheck // The connection was only opened to try the lock
+		return false
+	}
+
+	e.conn = conn
+	e.leader = true
+	e.leaderSince = time.Now()
+	e.logger.Info("Became leader, running scheduled tasks on this instance", "instance", e.instance)
+	return true
+}
+
+// stepDown gives up leadership and the lock connection; the caller holds mu
+func (e *LeaderElector) stepDown(reason string) {
+	if e.conn != nil {
+		_ = e.conn.Close() //nolint:errcheck // Closing the session releases the lock either way
+		e.conn = nil
+	}
+	if e.leader {
+		e.logger.Info("No longer leader", "instance", e.instance, "reason", reason)
+	}
+	e.leader = false
+}
+
+func (e *LeaderElector) acquire(ctx context.Context, conn *sql.Conn) (bool, error) {
+	if e.db.IsPostgres() {
+		var acquired bool
+		err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", leaderLockKey).Scan(&acquired)
+		return acquired, err
+	}
+
+	var acquired sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", leaderLockName).Scan(&acquired)
+	return acquired.Valid && acquired.Int64 == 1, err
+}
+
+func (e *LeaderElector) release(ctx context.Context, conn *sql.Conn) error {
+	if e.db.IsPostgres() {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", leaderLockKey)
+		return err
+	}
+	_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", leaderLockName)
+	return err
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderElector_WithoutElection(t *testing.T) {
+	log := logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stdout"})
+
+	// Without a database to hold the lock an enabled election falls back to a single instance
+	elector := NewLeaderElector(nil, config.ClusterConfig{LeaderElection: true, InstanceName: "radarr-a"}, log)
+	assert.True(t, elector.IsLeader())
+
+	status := elector.Status()
+	assert.False(t, status.Enabled)
+	assert.True(t, status.IsLeader)
+	assert.Equal(t, "radarr-a", status.Instance)
+
+	var missing *LeaderElector
+	assert.True(t, missing.IsLeader())
+}
+
+func TestLeaderElector_OnPromote(t *testing.T) {
+	log := logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stdout"})
+	elector := NewLeaderElector(nil, config.ClusterConfig{}, log)
+
+	var calls []string
+	elector.OnPromote(func() { calls = append(calls, "first") })
+	elector.OnPromote(func() { calls = append(calls, "second") })
+	assert.Empty(t, calls)
+
+	elector.promoted()
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
@@ -0,0 +1,502 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// LibraryMaintenanceService finds and fixes inconsistencies between the library database and disk
+type LibraryMaintenanceService struct {
+	db               *database.Database
+	logger           *logger.Logger
+	movieService     *MovieService
+	mediaInfoService *MediaInfoService
+	wantedService    *WantedMoviesService
+
+	mu         sync.RWMutex
+	lastReport *models.LibraryMaintenanceReport
+}
+
+// NewLibraryMaintenanceService creates a new library maintenance service
+func NewLibraryMaintenanceService(
+	db *database.Database,
+	logger *logger.Logger,
+	movieService *MovieService,
+	mediaInfoService *MediaInfoService,
+	wantedService *WantedMoviesService,
+) *LibraryMaintenanceService {
+	return &LibraryMaintenanceService{
+		db:               db,
+		logger:           logger,
+		movieService:     movieService,
+		mediaInfoService: mediaInfoService,
+		wantedService:    wantedService,
+	}
+}
+
+// libraryMaintenanceCommand is the task command that generates the library maintenance report
+const libraryMaintenanceCommand = "LibraryMaintenance"
+
+// GetLastReport returns the most recently generated report, or nil if no scan has run
+func (s *LibraryMaintenanceService) GetLastReport() *models.LibraryMaintenanceReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.loadLastReport()
+}
+
+// loadLastReport returns the cached report, falling back to the result of the last completed
+// maintenance task so the report survives a restart. The caller must hold s.mu.
+func (s *LibraryMaintenanceService) loadLastReport() *models.LibraryMaintenanceReport {
+	if s.lastReport != nil || s.db == nil {
+		return s.lastReport
+	}
+
+	var tasks []models.TaskV2
+	err := s.db.GORM.Where("command_name = ? AND status = ?", libraryMaintenanceCommand, "completed").
+		Order("ended_at DESC").Order("id DESC").Limit(1).Find(&tasks).Error
+	if err != nil {
+		s.logger.Warn("Failed to load the last library maintenance report", "error", err)
+		return nil
+	}
+	if len(tasks) == 0 || len(tasks[0].Result) == 0 {
+		return nil
+	}
+
+	report, err := reportFromTaskResult(tasks[0].Result)
+	if err != nil {
+		s.logger.Warn("Failed to decode the last library maintenance report", "taskId", tasks[0].ID, "error", err)
+		return nil
+	}
+	s.lastReport = report
+	return report
+}
+
+// GenerateReport scans the library and stores the resulting report
+func (s *LibraryMaintenanceService) GenerateReport() (*models.LibraryMaintenanceReport, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	start := time.Now()
+
+	var movies []models.Movie
+	if err := s.db.GORM.Find(&movies).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch movies: %w", err)
+	}
+
+	var movieFiles []models.MovieFile
+	if err := s.db.GORM.Find(&movieFiles).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch movie files: %w", err)
+	}
+
+	rootFolders, err := s.getRootFolderPaths(movies)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.LibraryMaintenanceReport{
+		GeneratedAt: start,
+		RootFolders: rootFolders,
+	}
+
+	issues := make(map[models.LibraryIssueCategory][]models.LibraryIssue)
+	s.checkMonitoring(movies, issues)
+	s.checkMovieFiles(movies, movieFiles, issues)
+	s.checkMovieFolders(movies, movieFiles, issues, report)
+	s.checkRootFolders(movies, rootFolders, issues, report)
+
+	for _, category := range models.LibraryIssueCategories {
+		found := issues[category]
+		if found == nil {
+			found = []models.LibraryIssue{}
+		}
+		report.Categories = append(report.Categories, models.LibraryMaintenanceCategory{
+			Category:    category,
+			Description: category.Description(),
+			FixAction:   category.FixAction(),
+			Count:       len(found),
+			Issues:      found,
+		})
+		report.TotalIssues += len(found)
+	}
+	report.Duration = time.Since(start)
+
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+
+	s.logger.Info("Library maintenance report generated", "issues", report.TotalIssues, "duration", report.Duration)
+	return report, nil
+}
+
+// FixCategory applies the category's fix action to every issue in the last report
+func (s *LibraryMaintenanceService) FixCategory(
+	category models.LibraryIssueCategory,
+) (*models.LibraryMaintenanceFixResult, error) {
+	if !category.IsValid() {
+		return nil, fmt.Errorf("unknown library issue category: %s", category)
+	}
+
+	if !category.IsFixable() {
+		return nil, fmt.Errorf("library issue category %s is report only", category)
+	}
+
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := s.loadLastReport()
+	if report == nil {
+		return nil, fmt.Errorf("no library maintenance report available")
+	}
+
+	section := report.Category(category)
+	if section == nil {
+		return nil, fmt.Errorf("no library maintenance report available")
+	}
+
+	result := &models.LibraryMaintenanceFixResult{
+		Category: category,
+		Action:   category.FixAction(),
+	}
+
+	var remaining []models.LibraryIssue
+	if category == models.LibraryIssueMonitoredMissing {
+		remaining = s.queueWantedSearch(section.Issues, result)
+	} else {
+		remaining = []models.LibraryIssue{}
+		for _, issue := range section.Issues {
+			if err := s.fixIssue(category, issue); err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", issueLabel(issue), err))
+				remaining = append(remaining, issue)
+				continue
+			}
+			result.Fixed++
+		}
+	}
+
+	report.TotalIssues -= section.Count - len(remaining)
+	section.Issues = remaining
+	section.Count = len(remaining)
+
+	s.logger.Info("Applied library maintenance fix", "category", category, "action", result.Action,
+		"fixed", result.Fixed, "failed", result.Failed)
+	return result, nil
+}
+
+// reportTaskResult converts a report into a task result, which is persisted with the task
+func reportTaskResult(report *models.LibraryMaintenanceReport) (models.JSONField, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.JSONField
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// reportFromTaskResult decodes a report stored as a task result
+func reportFromTaskResult(result models.JSONField) (*models.LibraryMaintenanceReport, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var report models.LibraryMaintenanceReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// getRootFolderPaths returns configured root folders plus any root folder referenced by a movie
+func (s *LibraryMaintenanceService) getRootFolderPaths(movies []models.Movie) ([]string, error) {
+	var rootFolders []models.RootFolder
+	if err := s.db.GORM.Find(&rootFolders).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch root folders: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	paths := make([]string, 0, len(rootFolders))
+	add := func(path string) {
+		if path == "" {
+			return
+		}
+		path = filepath.Clean(path)
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, rf := range rootFolders {
+		add(rf.Path)
+	}
+	for _, movie := range movies {
+		add(movie.RootFolderPath)
+	}
+
+	return paths, nil
+}
+
+func (s *LibraryMaintenanceService) checkMonitoring(
+	movies []models.Movie, issues map[models.LibraryIssueCategory][]models.LibraryIssue,
+) {
+	for _, movie := range movies {
+		switch {
+		case !movie.Monitored && movie.HasFile:
+			issues[models.LibraryIssueUnmonitoredWithFiles] = append(
+				issues[models.LibraryIssueUnmonitoredWithFiles], movieIssue(&movie))
+		case movie.Monitored && !movie.HasFile && movie.IsAvailable:
+			issues[models.LibraryIssueMonitoredMissing] = append(
+				issues[models.LibraryIssueMonitoredMissing], movieIssue(&movie))
+		}
+	}
+}
+
+// checkMovieFiles finds database file records whose file is missing from disk
+func (s *LibraryMaintenanceService) checkMovieFiles(
+	movies []models.Movie, movieFiles []models.MovieFile, issues map[models.LibraryIssueCategory][]models.LibraryIssue,
+) {
+	titles := make(map[int]string, len(movies))
+	for _, movie := range movies {
+		titles[movie.ID] = movie.Title
+	}
+
+	for _, file := range movieFiles {
+		if file.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.Path); os.IsNotExist(err) {
+			issues[models.LibraryIssueMissingFiles] = append(issues[models.LibraryIssueMissingFiles], models.LibraryIssue{
+				MovieID:     file.MovieID,
+				MovieTitle:  titles[file.MovieID],
+				MovieFileID: file.ID,
+				Path:        file.Path,
+				Size:        file.Size,
+			})
+		}
+	}
+}
+
+// checkMovieFolders finds missing movie folders and untracked video files inside existing ones
+func (s *LibraryMaintenanceService) checkMovieFolders(
+	movies []models.Movie, movieFiles []models.MovieFile,
+	issues map[models.LibraryIssueCategory][]models.LibraryIssue, report *models.LibraryMaintenanceReport,
+) {
+	tracked := make(map[string]bool, len(movieFiles))
+	for _, file := range movieFiles {
+		tracked[filepath.Clean(file.Path)] = true
+	}
+
+	for i := range movies {
+		movie := &movies[i]
+		if movie.Path == "" {
+			continue
+		}
+
+		info, err := os.Stat(movie.Path)
+		if err != nil || !info.IsDir() {
+			issues[models.LibraryIssueMoviesWithoutFolders] = append(
+				issues[models.LibraryIssueMoviesWithoutFolders], movieIssue(movie))
+			continue
+		}
+
+		err = filepath.WalkDir(movie.Path, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !s.mediaInfoService.IsVideoFile(path) || tracked[filepath.Clean(path)] {
+				return nil
+			}
+
+			issue := movieIssue(movie)
+			issue.Path = path
+			if fileInfo, infoErr := d.Info(); infoErr == nil {
+				issue.Size = fileInfo.Size()
+			}
+			issues[models.LibraryIssueOrphanedFiles] = append(issues[models.LibraryIssueOrphanedFiles], issue)
+			return nil
+		})
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to scan %s: %v", movie.Path, err))
+		}
+	}
+}
+
+// checkRootFolders finds subdirectories of root folders that no movie points at
+func (s *LibraryMaintenanceService) checkRootFolders(
+	movies []models.Movie, rootFolders []string,
+	issues map[models.LibraryIssueCategory][]models.LibraryIssue, report *models.LibraryMaintenanceReport,
+) {
+	mapped := make(map[string]bool, len(movies))
+	for _, movie := range movies {
+		if movie.Path != "" {
+			mapped[filepath.Clean(movie.Path)] = true
+		}
+	}
+
+	for _, root := range rootFolders {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to read root folder %s: %v", root, err))
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(root, entry.Name())
+			if !mapped[path] {
+				issues[models.LibraryIssueFoldersWithoutMovies] = append(
+					issues[models.LibraryIssueFoldersWithoutMovies], models.LibraryIssue{Path: path})
+			}
+		}
+	}
+}
+
+// fixIssue applies the category's fix action to a single issue
+func (s *LibraryMaintenanceService) fixIssue(category models.LibraryIssueCategory, issue models.LibraryIssue) error {
+	switch category {
+	case models.LibraryIssueOrphanedFiles:
+		return s.linkOrphanedFile(issue)
+	case models.LibraryIssueMissingFiles:
+		return s.removeMissingFile(issue)
+	case models.LibraryIssueMoviesWithoutFolders:
+		return os.MkdirAll(issue.Path, 0o750)
+	case models.LibraryIssueFoldersWithoutMovies:
+		return removeEmptyFolder(issue.Path)
+	default:
+		return fmt.Errorf("no fix available for category %s", category)
+	}
+}
+
+// queueWantedSearch refreshes the wanted list and makes the movies immediately eligible for search
+func (s *LibraryMaintenanceService) queueWantedSearch(
+	issues []models.LibraryIssue, result *models.LibraryMaintenanceFixResult,
+) []models.LibraryIssue {
+	if len(issues) == 0 {
+		return []models.LibraryIssue{}
+	}
+
+	movieIDs := make([]int, 0, len(issues))
+	for _, issue := range issues {
+		movieIDs = append(movieIDs, issue.MovieID)
+	}
+
+	err := s.wantedService.RefreshWantedMovies()
+	if err == nil {
+		err = s.wantedService.BulkOperation(&models.WantedMoviesBulkOperation{
+			MovieIDs:  movieIDs,
+			Operation: models.BulkOpResetSearchAttempts,
+		})
+	}
+	if err != nil {
+		result.Failed = len(issues)
+		result.Errors = append(result.Errors, err.Error())
+		return issues
+	}
+
+	result.Fixed = len(issues)
+	return []models.LibraryIssue{}
+}
+
+// linkOrphanedFile records an untracked file against its movie when the movie has no file
+func (s *LibraryMaintenanceService) linkOrphanedFile(issue models.LibraryIssue) error {
+	movie, err := s.movieService.GetByID(issue.MovieID)
+	if err != nil {
+		return err
+	}
+	if movie.HasFile {
+		return fmt.Errorf("movie already has a file, use manual import to replace it")
+	}
+
+	info, err := os.Stat(issue.Path)
+	if err != nil {
+		return fmt.Errorf("file is no longer accessible: %w", err)
+	}
+
+	relativePath, err := filepath.Rel(movie.Path, issue.Path)
+	if err != nil {
+		relativePath = filepath.Base(issue.Path)
+	}
+
+	file := &models.MovieFile{
+		Path:         issue.Path,
+		RelativePath: relativePath,
+		Size:         info.Size(),
+		DateAdded:    time.Now(),
+	}
+	return s.movieService.UpdateWithFile(movie, file)
+}
+
+// removeMissingFile deletes a stale file record and clears the movie's file reference
+func (s *LibraryMaintenanceService) removeMissingFile(issue models.LibraryIssue) error {
+	if _, err := os.Stat(issue.Path); err == nil {
+		return fmt.Errorf("file exists on disk again")
+	}
+
+	if err := s.db.GORM.Delete(&models.MovieFile{}, issue.MovieFileID).Error; err != nil {
+		return fmt.Errorf("failed to delete movie file record: %w", err)
+	}
+
+	return s.db.GORM.Model(&models.Movie{}).
+		Where("id = ? AND movie_file_id = ?", issue.MovieID, issue.MovieFileID).
+		Updates(map[string]interface{}{"has_file": false, "movie_file_id": 0}).Error
+}
+
+// removeEmptyFolder deletes a folder only if it contains no files at any depth
+func removeEmptyFolder(path string) error {
+	hasFiles := false
+	err := filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			hasFiles = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if hasFiles {
+		return fmt.Errorf("folder is not empty, use manual import instead")
+	}
+
+	return os.RemoveAll(path)
+}
+
+func movieIssue(movie *models.Movie) models.LibraryIssue {
+	return models.LibraryIssue{
+		MovieID:    movie.ID,
+		MovieTitle: movie.Title,
+		Path:       movie.Path,
+	}
+}
+
+func issueLabel(issue models.LibraryIssue) string {
+	if issue.MovieTitle != "" {
+		return issue.MovieTitle
+	}
+	return issue.Path
+}
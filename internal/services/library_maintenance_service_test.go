@@ -0,0 +1,148 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLibraryMaintenanceService() *LibraryMaintenanceService {
+	log := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	return NewLibraryMaintenanceService(nil, log, nil, NewMediaInfoService(nil, log), nil)
+}
+
+func TestLibraryMaintenanceService_NilDatabase(t *testing.T) {
+	service := newTestLibraryMaintenanceService()
+
+	_, err := service.GenerateReport()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.FixCategory(models.LibraryIssueMissingFiles)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	assert.Nil(t, service.GetLastReport())
+}
+
+func TestLibraryMaintenanceService_ScanDisk(t *testing.T) {
+	service := newTestLibraryMaintenanceService()
+	root := t.TempDir()
+
+	trackedDir := filepath.Join(root, "Tracked (2020)")
+	require.NoError(t, os.MkdirAll(trackedDir, 0o750))
+	trackedFile := filepath.Join(trackedDir, "tracked.mkv")
+	orphanFile := filepath.Join(trackedDir, "extra.mp4")
+	for _, path := range []string{trackedFile, orphanFile, filepath.Join(trackedDir, "notes.txt")} {
+		require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "Unmapped (2019)"), 0o750))
+
+	movies := []models.Movie{
+		{ID: 1, Title: "Tracked", Path: trackedDir, Monitored: false, HasFile: true},
+		{ID: 2, Title: "Missing Folder", Path: filepath.Join(root, "Missing (2021)"), Monitored: true, IsAvailable: true},
+	}
+	movieFiles := []models.MovieFile{
+		{ID: 10, MovieID: 1, Path: trackedFile},
+		{ID: 11, MovieID: 2, Path: filepath.Join(root, "Missing (2021)", "missing.mkv")},
+	}
+
+	issues := make(map[models.LibraryIssueCategory][]models.LibraryIssue)
+	report := &models.LibraryMaintenanceReport{}
+	service.checkMonitoring(movies, issues)
+	service.checkMovieFiles(movies, movieFiles, issues)
+	service.checkMovieFolders(movies, movieFiles, issues, report)
+	service.checkRootFolders(movies, []string{root}, issues, report)
+
+	assert.Empty(t, report.Errors)
+
+	if assert.Len(t, issues[models.LibraryIssueUnmonitoredWithFiles], 1) {
+		assert.Equal(t, 1, issues[models.LibraryIssueUnmonitoredWithFiles][0].MovieID)
+	}
+	if assert.Len(t, issues[models.LibraryIssueMonitoredMissing], 1) {
+		assert.Equal(t, 2, issues[models.LibraryIssueMonitoredMissing][0].MovieID)
+	}
+	if assert.Len(t, issues[models.LibraryIssueOrphanedFiles], 1) {
+		assert.Equal(t, orphanFile, issues[models.LibraryIssueOrphanedFiles][0].Path)
+	}
+	if assert.Len(t, issues[models.LibraryIssueMissingFiles], 1) {
+		assert.Equal(t, 11, issues[models.LibraryIssueMissingFiles][0].MovieFileID)
+		assert.Equal(t, "Missing Folder", issues[models.LibraryIssueMissingFiles][0].MovieTitle)
+	}
+	if assert.Len(t, issues[models.LibraryIssueMoviesWithoutFolders], 1) {
+		assert.Equal(t, 2, issues[models.LibraryIssueMoviesWithoutFolders][0].MovieID)
+	}
+	if assert.Len(t, issues[models.LibraryIssueFoldersWithoutMovies], 1) {
+		assert.Equal(t, filepath.Join(root, "Unmapped (2019)"), issues[models.LibraryIssueFoldersWithoutMovies][0].Path)
+	}
+}
+
+func TestLibraryMaintenanceService_RemoveEmptyFolder(t *testing.T) {
+	root := t.TempDir()
+
+	empty := filepath.Join(root, "Empty", "Subs")
+	require.NoError(t, os.MkdirAll(empty, 0o750))
+	assert.NoError(t, removeEmptyFolder(filepath.Join(root, "Empty")))
+	assert.NoDirExists(t, filepath.Join(root, "Empty"))
+
+	full := filepath.Join(root, "Full")
+	require.NoError(t, os.MkdirAll(full, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(full, "movie.mkv"), []byte("data"), 0o600))
+	assert.Error(t, removeEmptyFolder(full))
+	assert.DirExists(t, full)
+}
+
+func TestLibraryIssueCategory_FixAction(t *testing.T) {
+	for _, category := range models.LibraryIssueCategories {
+		assert.True(t, category.IsValid(), category)
+		assert.NotEmpty(t, category.Description(), category)
+	}
+	assert.False(t, models.LibraryIssueCategory("unknown").IsValid())
+	assert.Equal(t, models.LibraryFixRemoveRecord, models.LibraryIssueMissingFiles.FixAction())
+
+	// Unmonitored movies may be unmonitored on purpose, so they are only reported
+	assert.Equal(t, models.LibraryFixNone, models.LibraryIssueUnmonitoredWithFiles.FixAction())
+	assert.False(t, models.LibraryIssueUnmonitoredWithFiles.IsFixable())
+	assert.True(t, models.LibraryIssueMissingFiles.IsFixable())
+	assert.False(t, models.LibraryIssueCategory("unknown").IsFixable())
+}
+
+func TestLibraryMaintenanceService_FixReportOnlyCategory(t *testing.T) {
+	service := newTestLibraryMaintenanceService()
+
+	_, err := service.FixCategory(models.LibraryIssueUnmonitoredWithFiles)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "report only")
+}
+
+func TestLibraryMaintenanceReport_TaskResult(t *testing.T) {
+	report := &models.LibraryMaintenanceReport{
+		GeneratedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Duration:    1500 * time.Millisecond,
+		RootFolders: []string{"/movies"},
+		TotalIssues: 1,
+		Categories: []models.LibraryMaintenanceCategory{{
+			Category:  models.LibraryIssueMissingFiles,
+			FixAction: models.LibraryFixRemoveRecord,
+			Count:     1,
+			Issues:    []models.LibraryIssue{{MovieID: 7, MovieFileID: 9, Path: "/movies/Film (2020)/film.mkv"}},
+		}},
+	}
+
+	result, err := reportTaskResult(report)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), result["totalIssues"])
+
+	decoded, err := reportFromTaskResult(result)
+	require.NoError(t, err)
+	assert.True(t, report.GeneratedAt.Equal(decoded.GeneratedAt))
+	decoded.GeneratedAt = report.GeneratedAt
+	assert.Equal(t, report, decoded)
+}
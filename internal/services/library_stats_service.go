@@ -0,0 +1,147 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// unknownQualityName is the quality distribution bucket of files without a parsed quality
+const unknownQualityName = "Unknown"
+
+// LibraryStatsService records daily snapshots of the library and returns them as a time series
+type LibraryStatsService struct {
+	db     *database.Database
+	logger *logger.Logger
+}
+
+// NewLibraryStatsService creates a new library statistics service
+func NewLibraryStatsService(db *database.Database, logger *logger.Logger) *LibraryStatsService {
+	return &LibraryStatsService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RecordSnapshot records the current state of the library as today's snapshot, replacing one
+// recorded earlier the same day
+func (s *LibraryStatsService) RecordSnapshot() (*models.LibrarySnapshot, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	snapshot := &models.LibrarySnapshot{Date: snapshotDate(time.Now())}
+	if err := s.countMovies(snapshot); err != nil {
+		return nil, err
+	}
+	if err := s.countFiles(snapshot); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.GORM.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"movie_count", "monitored_count", "missing_count", "cutoff_unmet_count", "file_count",
+			"size_on_disk", "quality_distribution", "updated_at",
+		}),
+	}).Create(snapshot).Error; err != nil {
+		return nil, fmt.Errorf("failed to save library snapshot: %w", err)
+	}
+
+	s.logger.Info("Recorded library snapshot", "movies", snapshot.MovieCount, "files", snapshot.FileCount,
+		"missing", snapshot.MissingCount, "sizeOnDisk", snapshot.SizeOnDisk)
+	return snapshot, nil
+}
+
+// countMovies fills in the movie, monitored, missing and cutoff unmet counts
+func (s *LibraryStatsService) countMovies(snapshot *models.LibrarySnapshot) error {
+	var counts struct {
+		Movies    int
+		Monitored int
+		Missing   int
+	}
+	if err := s.db.GORM.Model(&models.Movie{}).
+		Select("COUNT(*) AS movies, "+
+			"COALESCE(SUM(CASE WHEN monitored = ? THEN 1 ELSE 0 END), 0) AS monitored, "+
+			"COALESCE(SUM(CASE WHEN monitored = ? AND is_available = ? AND has_file = ? THEN 1 ELSE 0 END), 0) "+
+			"AS missing", true, true, true, false).
+		Scan(&counts).Error; err != nil {
+		return fmt.Errorf("failed to count movies: %w", err)
+	}
+
+	var cutoffUnmet int64
+	if err := s.db.GORM.Model(&models.WantedMovie{}).
+		Where("status = ?", models.WantedStatusCutoffUnmet).Count(&cutoffUnmet).Error; err != nil {
+		return fmt.Errorf("failed to count cutoff unmet movies: %w", err)
+	}
+
+	snapshot.MovieCount = counts.Movies
+	snapshot.MonitoredCount = counts.Monitored
+	snapshot.MissingCount = counts.Missing
+	snapshot.CutoffUnmetCount = int(cutoffUnmet)
+	return nil
+}
+
+// countFiles fills in the file count, size on disk and quality distribution. Files are grouped
+// by their stored quality, which differs between revisions of the same quality, so the groups are
+// merged by quality name.
+func (s *LibraryStatsService) countFiles(snapshot *models.LibrarySnapshot) error {
+	var groups []struct {
+		Quality string
+		Files   int
+		Size    int64
+	}
+	if err := s.db.GORM.Model(&models.MovieFile{}).
+		Select("quality, COUNT(*) AS files, COALESCE(SUM(size), 0) AS size").
+		Group("quality").
+		Scan(&groups).Error; err != nil {
+		return fmt.Errorf("failed to count movie files: %w", err)
+	}
+
+	distribution := make(map[string]int)
+	for _, group := range groups {
+		snapshot.FileCount += group.Files
+		snapshot.SizeOnDisk += group.Size
+		distribution[qualityName(group.Quality)] += group.Files
+	}
+
+	snapshot.QualityDistribution = models.JSON{}
+	for name, files := range distribution {
+		snapshot.QualityDistribution[name] = files
+	}
+	return nil
+}
+
+// qualityName returns the quality name of a stored movie file quality
+func qualityName(stored string) string {
+	var quality models.Quality
+	if err := json.Unmarshal([]byte(stored), &quality); err != nil || quality.Quality.Name == "" {
+		return unknownQualityName
+	}
+	return quality.Quality.Name
+}
+
+// GetSnapshots returns the snapshots from the day of from through the day of to, oldest first
+func (s *LibraryStatsService) GetSnapshots(from, to time.Time) ([]models.LibrarySnapshot, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	snapshots := []models.LibrarySnapshot{}
+	if err := s.db.GORM.Where("date >= ? AND date <= ?", snapshotDate(from), snapshotDate(to)).
+		Order("date").Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to get library snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// snapshotDate returns the UTC day t falls on
+func snapshotDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
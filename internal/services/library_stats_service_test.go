@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityName(t *testing.T) {
+	assert.Equal(t, "Bluray-1080p", qualityName(`{"quality":{"id":7,"name":"Bluray-1080p"},"revision":{"version":2}}`))
+	assert.Equal(t, unknownQualityName, qualityName(`{"quality":{"id":0}}`))
+	assert.Equal(t, unknownQualityName, qualityName("not json"))
+}
+
+func TestSnapshotDate(t *testing.T) {
+	late := time.Date(2026, 10, 14, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), snapshotDate(late))
+}
+
+func TestLibraryStatsService_RecordSnapshot(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	movies := []*models.Movie{
+		{Title: "Stats Movie One", TmdbID: 9601, TitleSlug: "stats-movie-one-9601", Monitored: true, IsAvailable: true},
+		{Title: "Stats Movie Two", TmdbID: 9602, TitleSlug: "stats-movie-two-9602", Monitored: true, IsAvailable: true},
+	}
+	for _, movie := range movies {
+		require.NoError(t, db.GORM.Create(movie).Error)
+	}
+	for i, name := range []string{"Bluray-1080p", "Bluray-1080p", "WEBDL-2160p"} {
+		require.NoError(t, db.GORM.Create(&models.MovieFile{
+			MovieID: movies[0].ID,
+			Path:    fmt.Sprintf("/movies/stats-%d.mkv", i),
+			Size:    100,
+			Quality: models.Quality{
+				Quality:  models.QualityDefinition{Name: name},
+				Revision: models.Revision{Version: i + 1},
+			},
+		}).Error)
+	}
+	require.NoError(t, db.GORM.Model(movies[0]).Update("has_file", true).Error)
+
+	service := NewLibraryStatsService(db, logger)
+	snapshot, err := service.RecordSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, 2, snapshot.MovieCount)
+	assert.Equal(t, 1, snapshot.MissingCount)
+	assert.Equal(t, 3, snapshot.FileCount)
+	assert.Equal(t, int64(300), snapshot.SizeOnDisk)
+	assert.Equal(t, 2, snapshot.QualityDistribution["Bluray-1080p"])
+
+	// Recording again the same day replaces the snapshot
+	_, err = service.RecordSnapshot()
+	require.NoError(t, err)
+	snapshots, err := service.GetSnapshots(time.Now().AddDate(0, 0, -1), time.Now())
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.EqualValues(t, 1, snapshots[0].QualityDistribution["WEBDL-2160p"])
+}
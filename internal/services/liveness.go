@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// livenessPingTimeout bounds the database ping made by the liveness probe
+	livenessPingTimeout = 5 * time.Second
+	// workerStaleAfter is how long an idle worker may go without a heartbeat before it is
+	// considered stuck
+	workerStaleAfter = 4 * workerHeartbeatInterval
+	// schedulerStaleAfter is how long the scheduler loop may go without a tick before it is
+	// considered stuck
+	schedulerStaleAfter = 5 * time.Minute
+)
+
+// LivenessStatus reports whether the process is still making progress. Unlike readiness it
+// only fails when something is stuck, so an orchestrator can restart the instance.
+type LivenessStatus struct {
+	Alive     bool             `json:"alive"`
+	Database  LivenessCheck    `json:"database"`
+	Scheduler LivenessCheck    `json:"scheduler"`
+	Workers   []WorkerLiveness `json:"workers"`
+}
+
+// LivenessCheck is the result of one liveness check
+type LivenessCheck struct {
+	Alive    bool       `json:"alive"`
+	Message  string     `json:"message,omitempty"`
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+}
+
+// WorkerLiveness reports the last heartbeat of a task worker and the task it is running
+type WorkerLiveness struct {
+	Pool          string     `json:"pool"`
+	Alive         bool       `json:"alive"`
+	LastHeartbeat time.Time  `json:"lastHeartbeat"`
+	TaskID        int        `json:"taskId,omitempty"`
+	BusySince     *time.Time `json:"busySince,omitempty"`
+}
+
+// Liveness pings the database and checks the task scheduler and worker heartbeats
+func (c *Container) Liveness(ctx context.Context) LivenessStatus {
+	status := LivenessStatus{
+		Database:  LivenessCheck{Alive: true},
+		Scheduler: LivenessCheck{Alive: true},
+		Workers:   []WorkerLiveness{},
+	}
+
+	if c.DB != nil && c.DB.DB != nil {
+		pingCtx, cancel := context.WithTimeout(ctx, livenessPingTimeout)
+		defer cancel()
+		if err := c.DB.DB.PingContext(pingCtx); err != nil {
+			status.Database = LivenessCheck{Message: fmt.Sprintf("Database ping failed: %v", err)}
+		}
+	}
+
+	if c.TaskService != nil {
+		status.Scheduler, status.Workers = c.TaskService.liveness(time.Now())
+	}
+
+	status.Alive = status.Database.Alive && status.Scheduler.Alive
+	for _, worker := range status.Workers {
+		status.Alive = status.Alive && worker.Alive
+	}
+	return status
+}
+
+// liveness reports whether the scheduler loop is still ticking and each worker's heartbeat.
+// Workers running a task count as alive however long the task takes, since long library
+// refreshes are normal; an idle worker that stops beating is stuck outside any task.
+func (ts *TaskService) liveness(now time.Time) (LivenessCheck, []WorkerLiveness) {
+	lastTick := time.Unix(0, ts.scheduler.lastTick.Load())
+	scheduler := LivenessCheck{Alive: now.Sub(lastTick) < schedulerStaleAfter, LastSeen: &lastTick}
+	if !scheduler.Alive {
+		scheduler.Message = fmt.Sprintf("Task scheduler has not run since %s", lastTick.Format(time.RFC3339))
+	}
+
+	names := make([]string, 0, len(ts.workers))
+	for name := range ts.workers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	workers := []WorkerLiveness{}
+	for _, name := range names {
+		for _, heartbeat := range ts.workers[name].heartbeats {
+			heartbeat.mu.Lock()
+			worker := WorkerLiveness{
+				Pool:          name,
+				LastHeartbeat: heartbeat.lastBeat,
+				TaskID:        heartbeat.taskID,
+			}
+			if heartbeat.taskID != 0 {
+				busySince := heartbeat.busySince
+				worker.BusySince = &busySince
+			}
+			heartbeat.mu.Unlock()
+
+			worker.Alive = worker.TaskID != 0 || now.Sub(worker.LastHeartbeat) < workerStaleAfter
+			workers = append(workers, worker)
+		}
+	}
+	return scheduler, workers
+}
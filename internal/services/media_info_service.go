@@ -179,6 +179,11 @@ func (s *MediaInfoService) parseFFProbeOutput(output string) (*models.MediaInfo,
 		}
 	}
 
+	// Audio and subtitle languages come from the stream language tags
+	if languages, err := ffprobeStreamLanguages(output); err == nil {
+		languages.apply(mediaInfo)
+	}
+
 	return mediaInfo, nil
 }
 
@@ -196,6 +201,10 @@ func (s *MediaInfoService) parseMediaInfoOutput(output string) (*models.MediaInf
 		mediaInfo.VideoCodec = matches[1]
 	}
 
+	if languages, err := mediaInfoStreamLanguages(output); err == nil {
+		languages.apply(mediaInfo)
+	}
+
 	return mediaInfo, nil
 }
 
@@ -321,6 +330,7 @@ func (s *MediaInfoService) UpdateMediaInfo(movieFileID int, mediaInfo *models.Me
 	}
 
 	movieFile.MediaInfo = *mediaInfo
+	movieFile.Languages = fileLanguages(mediaInfo, movieFile.Languages)
 
 	err = s.db.GORM.Save(&movieFile).Error
 	if err != nil {
@@ -348,6 +358,7 @@ func (s *MediaInfoService) RefreshMediaInfo(ctx context.Context, movieFileID int
 
 	// Update the movie file record
 	movieFile.MediaInfo = *mediaInfo
+	movieFile.Languages = fileLanguages(mediaInfo, movieFile.Languages)
 
 	err = s.db.GORM.Save(&movieFile).Error
 	if err != nil {
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// coverPrefetchInterval spaces the image downloads of a prefetch, keeping a large library
+	// import from hammering the image host
+	coverPrefetchInterval = 250 * time.Millisecond
+	// coverPrefetchBackoff is how long a prefetch waits after the image host answers 429 before
+	// trying the image again
+	coverPrefetchBackoff = 30 * time.Second
+	// coverPrefetchBatchSize is how many movies a prefetch loads at a time
+	coverPrefetchBatchSize = 500
+)
+
+// coverPrefetchTypes are the cover types a prefetch downloads, the ones the UI shows
+var coverPrefetchTypes = []string{"poster", "fanart"}
+
+// CoverPrefetchResult counts the covers a prefetch downloaded, found already cached, or failed
+// to download
+type CoverPrefetchResult struct {
+	Movies     int `json:"movies"`
+	Downloaded int `json:"downloaded"`
+	Cached     int `json:"cached"`
+	Failed     int `json:"failed"`
+}
+
+// CachesCovers reports whether covers are cached, which a prefetch needs
+func (s *MediaCoverService) CachesCovers() bool {
+	return s != nil && s.store != nil
+}
+
+// PrefetchCovers downloads the posters and fanart of the movies with the given ids, or of every
+// movie when ids is empty, into the cover cache, so the first load of a freshly imported library
+// doesn't fetch them all at once. Downloads are spaced by the prefetch interval, and a 429 from the
+// image host pauses the prefetch before the image is tried again. Covers already cached are
+// skipped, which makes a prefetch resumable: run again after an interruption, it only downloads
+// what's left. progress is called after each movie.
+func (s *MediaCoverService) PrefetchCovers(ctx context.Context, ids []int,
+	progress func(done, total int)) (*CoverPrefetchResult, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if s.store == nil {
+		return nil, fmt.Errorf("media cover cache is not configured")
+	}
+
+	total := len(ids)
+	if total == 0 {
+		var count int64
+		if err := s.db.GORM.WithContext(ctx).Model(&models.Movie{}).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to count movies: %w", err)
+		}
+		total = int(count)
+	}
+
+	result := &CoverPrefetchResult{}
+	downloaded := false
+	for lastID := 0; ; {
+		query := s.db.GORM.WithContext(ctx).Select("id", "images").Where("id > ?", lastID).Order("id").
+			Limit(coverPrefetchBatchSize)
+		if len(ids) > 0 {
+			query = query.Where("id IN ?", ids)
+		}
+		var movies []models.Movie
+		if err := query.Find(&movies).Error; err != nil {
+			return nil, fmt.Errorf("failed to load movies for cover prefetch: %w", err)
+		}
+
+		for i := range movies {
+			for _, image := range movies[i].Images {
+				if image.RemoteURL == "" || !slices.Contains(coverPrefetchTypes, image.CoverType) {
+					continue
+				}
+				fetched, err := s.prefetchCover(ctx, movies[i].ID, image, downloaded)
+				if err != nil {
+					if ctx.Err() != nil {
+						return result, ctx.Err()
+					}
+					result.Failed++
+					s.logger.Warn("Failed to prefetch media cover", "movieId", movies[i].ID,
+						"coverType", image.CoverType, "error", err)
+				} else if fetched {
+					result.Downloaded++
+				} else {
+					result.Cached++
+				}
+				downloaded = downloaded || fetched || err != nil
+			}
+			result.Movies++
+			progress(result.Movies, total)
+		}
+
+		if len(movies) < coverPrefetchBatchSize {
+			break
+		}
+		lastID = movies[len(movies)-1].ID
+	}
+
+	s.logger.Info("Prefetched media covers", "movies", result.Movies, "downloaded", result.Downloaded,
+		"cached", result.Cached, "failed", result.Failed)
+	return result, nil
+}
+
+// prefetchCover caches a movie's cover unless it already is, reporting whether it was downloaded.
+// After the first download, each waits for the prefetch interval first.
+func (s *MediaCoverService) prefetchCover(ctx context.Context, movieID int, image models.MediaCoverImage,
+	throttle bool) (bool, error) {
+	prefix, key := coverKey(movieID, image.CoverType, image.RemoteURL)
+	cached, err := s.store.List(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to check media cover cache: %w", err)
+	}
+	for _, object := range cached {
+		if object.Key == key {
+			return false, nil
+		}
+	}
+
+	if throttle {
+		if err := sleepContext(ctx, s.prefetchInterval); err != nil {
+			return false, err
+		}
+	}
+	data, err := s.download(ctx, image.RemoteURL)
+	if errors.Is(err, errMediaCoverRateLimited) {
+		s.logger.Warn("Image host is rate limiting, pausing cover prefetch", "wait", coverPrefetchBackoff)
+		if err := sleepContext(ctx, coverPrefetchBackoff); err != nil {
+			return false, err
+		}
+		data, err = s.download(ctx, image.RemoteURL)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	s.cache(ctx, prefix, key, data)
+	return true, nil
+}
+
+// sleepContext waits for d, returning early with the context's error when it's done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// PrefetchMediaCoversHandler prefetches the covers of the movies in its movieIds body, or of the
+// whole library without one
+type PrefetchMediaCoversHandler struct {
+	mediaCoverService *MediaCoverService
+}
+
+// NewPrefetchMediaCoversHandler creates a new media cover prefetch handler
+func NewPrefetchMediaCoversHandler(mediaCoverService *MediaCoverService) *PrefetchMediaCoversHandler {
+	return &PrefetchMediaCoversHandler{mediaCoverService: mediaCoverService}
+}
+
+// Execute downloads the covers that aren't cached yet
+func (h *PrefetchMediaCoversHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	var ids []int
+	if values, ok := task.Body["movieIds"].([]interface{}); ok {
+		for _, value := range values {
+			switch v := value.(type) {
+			case int:
+				ids = append(ids, v)
+			case float64:
+				ids = append(ids, int(v))
+			}
+		}
+	} else if values, ok := task.Body["movieIds"].([]int); ok {
+		ids = values
+	}
+
+	updateProgress(0, "Prefetching media covers")
+	result, err := h.mediaCoverService.PrefetchCovers(ctx, ids, func(done, total int) {
+		percent := 100
+		if total > 0 {
+			percent = min(done*100/total, 99)
+		}
+		updateProgress(percent, fmt.Sprintf("Prefetched covers of %d of %d movies", done, total))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prefetch media covers: %w", err)
+	}
+
+	task.Result = models.JSONField{
+		"movies": result.Movies, "downloaded": result.Downloaded, "cached": result.Cached, "failed": result.Failed,
+	}
+	updateProgress(100, fmt.Sprintf("Prefetched covers of %d movies: %d downloaded, %d already cached, %d failed",
+		result.Movies, result.Downloaded, result.Cached, result.Failed))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *PrefetchMediaCoversHandler) GetName() string {
+	return "PrefetchMediaCovers"
+}
+
+// GetDescription returns a human-readable description
+func (h *PrefetchMediaCoversHandler) GetDescription() string {
+	return "Downloads the posters and fanart of newly imported movies into the media cover cache"
+}
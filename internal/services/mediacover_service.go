@@ -0,0 +1,167 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/blobstore"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxMediaCoverSize bounds the images downloaded for the cover cache
+const maxMediaCoverSize = 20 << 20
+
+// errMediaCoverRateLimited reports an image host answering 429 Too Many Requests
+var errMediaCoverRateLimited = errors.New("media cover host is rate limiting requests")
+
+// MediaCoverService serves movie posters and fanart from a cache in blob storage, downloading
+// each image from its remote URL the first time it is asked for
+type MediaCoverService struct {
+	db         *database.Database
+	logger     *logger.Logger
+	store      blobstore.Store
+	httpClient *http.Client
+	// prefetchInterval spaces the downloads of a cover prefetch
+	prefetchInterval time.Duration
+}
+
+// NewMediaCoverService creates a media cover service caching in store. A nil store serves every
+// cover straight from its remote URL.
+func NewMediaCoverService(db *database.Database, logger *logger.Logger, store blobstore.Store) *MediaCoverService {
+	return &MediaCoverService{
+		db:               db,
+		logger:           logger,
+		store:            store,
+		httpClient:       httpclient.New(30 * time.Second),
+		prefetchInterval: coverPrefetchInterval,
+	}
+}
+
+// GetCover returns a movie's cover for a file name such as "poster.jpg", where the name without
+// its extension is the cover type. The caller closes the reader.
+func (s *MediaCoverService) GetCover(ctx context.Context, movieID int, fileName string) (io.ReadCloser, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	coverType := strings.TrimSuffix(fileName, path.Ext(fileName))
+	var movie models.Movie
+	if err := s.db.GORM.WithContext(ctx).Select("id", "images").First(&movie, movieID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("movie not found")
+		}
+		return nil, fmt.Errorf("failed to get movie: %w", err)
+	}
+
+	var remoteURL string
+	for _, image := range movie.Images {
+		if image.CoverType == coverType && image.RemoteURL != "" {
+			remoteURL = image.RemoteURL
+			break
+		}
+	}
+	if remoteURL == "" {
+		return nil, fmt.Errorf("%s cover not found for movie %d", coverType, movieID)
+	}
+
+	prefix, key := coverKey(movieID, coverType, remoteURL)
+	if s.store != nil {
+		cached, err := s.store.Get(ctx, key)
+		if err == nil {
+			return cached, nil
+		}
+		if !errors.Is(err, blobstore.ErrNotFound) {
+			s.logger.Warn("Failed to read cached media cover", "key", key, "error", err)
+		}
+	}
+
+	data, err := s.download(ctx, remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.store != nil {
+		s.cache(ctx, prefix, key, data)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// cache stores a downloaded cover and removes the copies of images it replaced
+func (s *MediaCoverService) cache(ctx context.Context, prefix, key string, data []byte) {
+	if err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data))); err != nil {
+		s.logger.Warn("Failed to cache media cover", "key", key, "error", err)
+		return
+	}
+
+	stale, err := s.store.List(ctx, prefix)
+	if err != nil {
+		s.logger.Warn("Failed to list cached media covers", "prefix", prefix, "error", err)
+		return
+	}
+	for _, object := range stale {
+		if object.Key == key {
+			continue
+		}
+		if err := s.store.Delete(ctx, object.Key); err != nil {
+			s.logger.Warn("Failed to remove replaced media cover", "key", object.Key, "error", err)
+		}
+	}
+}
+
+// download fetches a cover from its remote URL
+func (s *MediaCoverService) download(ctx context.Context, remoteURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, remoteURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid media cover URL: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download media cover: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // read-only response
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, errMediaCoverRateLimited
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download media cover: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMediaCoverSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download media cover: %w", err)
+	}
+	if len(data) > maxMediaCoverSize {
+		return nil, fmt.Errorf("media cover exceeds %d MB", maxMediaCoverSize>>20)
+	}
+	return data, nil
+}
+
+// coverKey returns the cache key of a movie's cover downloaded from remoteURL, and the prefix the
+// keys of the movie's earlier covers of that type share. The key changes with the remote URL, so a
+// metadata refresh that replaces the image replaces the cached copy too.
+func coverKey(movieID int, coverType, remoteURL string) (prefix, key string) {
+	prefix = fmt.Sprintf("%d/%s-", movieID, coverType)
+	remotePath, _, _ := strings.Cut(remoteURL, "?")
+	return prefix, prefix + coverURLHash(remoteURL) + path.Ext(remotePath)
+}
+
+// coverURLHash shortens a remote image URL to a stable cache key component
+func coverURLHash(remoteURL string) string {
+	sum := sha256.Sum256([]byte(remoteURL))
+	return hex.EncodeToString(sum[:6])
+}
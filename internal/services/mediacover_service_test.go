@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/blobstore"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaCoverService_GetCover(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downloads++
+		_, _ = w.Write([]byte("poster-bytes"))
+	}))
+	defer server.Close()
+
+	movie := &models.Movie{
+		Title: "Cover Movie", TmdbID: 9301, TitleSlug: "cover-movie-9301",
+		Images: models.MediaCover{{CoverType: "poster", RemoteURL: server.URL + "/poster.jpg"}},
+	}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	store := blobstore.NewLocal(t.TempDir())
+	service := NewMediaCoverService(db, logger, store)
+	ctx := context.Background()
+
+	for range 2 {
+		cover, err := service.GetCover(ctx, movie.ID, "poster.jpg")
+		require.NoError(t, err)
+		data, err := io.ReadAll(cover)
+		require.NoError(t, err)
+		require.NoError(t, cover.Close())
+		assert.Equal(t, "poster-bytes", string(data))
+	}
+	assert.Equal(t, 1, downloads, "the second request is served from the cache")
+
+	_, err := service.GetCover(ctx, movie.ID, "fanart.jpg")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestMediaCoverService_PrefetchCovers(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downloads++
+		_, _ = w.Write([]byte("cover-bytes"))
+	}))
+	defer server.Close()
+
+	movie := &models.Movie{
+		Title: "Prefetch Movie", TmdbID: 9302, TitleSlug: "prefetch-movie-9302",
+		Images: models.MediaCover{
+			{CoverType: "poster", RemoteURL: server.URL + "/poster.jpg"},
+			{CoverType: "fanart", RemoteURL: server.URL + "/fanart.jpg"},
+			{CoverType: "banner", RemoteURL: server.URL + "/banner.jpg"},
+		},
+	}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	service := NewMediaCoverService(db, logger, blobstore.NewLocal(t.TempDir()))
+	service.prefetchInterval = 0
+	ctx := context.Background()
+
+	var progress []int
+	result, err := service.PrefetchCovers(ctx, []int{movie.ID}, func(done, _ int) { progress = append(progress, done) })
+	require.NoError(t, err)
+	assert.Equal(t, CoverPrefetchResult{Movies: 1, Downloaded: 2}, *result, "banners aren't prefetched")
+	assert.Equal(t, []int{1}, progress)
+
+	result, err = service.PrefetchCovers(ctx, []int{movie.ID}, func(int, int) {})
+	require.NoError(t, err)
+	assert.Equal(t, CoverPrefetchResult{Movies: 1, Cached: 2}, *result, "a rerun skips the cached covers")
+	assert.Equal(t, 2, downloads)
+
+	cover, err := service.GetCover(ctx, movie.ID, "fanart.jpg")
+	require.NoError(t, err)
+	require.NoError(t, cover.Close())
+	assert.Equal(t, 2, downloads, "prefetched covers are served from the cache")
+}
@@ -2,22 +2,56 @@ package services
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/secrets"
 	"github.com/radarr/radarr-go/internal/tmdb"
 )
 
+const (
+	// maxStoredCastMembers bounds the billed cast kept for full-text search
+	maxStoredCastMembers = 20
+)
+
+// searchableCrewJobs are the crew credits kept for full-text search
+var searchableCrewJobs = map[string]bool{
+	"Director":                true,
+	"Screenplay":              true,
+	"Writer":                  true,
+	"Novel":                   true,
+	"Producer":                true,
+	"Director of Photography": true,
+	"Original Music Composer": true,
+}
+
+// batchLookupWorkers bounds the concurrent TMDB requests made by a batch lookup, leaving the
+// shared client's rate limiting to pace them
+const batchLookupWorkers = 4
+
 // MetadataService handles movie metadata operations
 type MetadataService struct {
 	db     *database.Database
 	tmdb   *tmdb.Client
 	logger *logger.Logger
+
+	// configKey is the config file API key, used while no key is stored in the database
+	configKey string
+	secrets   *secrets.Box
+
+	// certificationCountry and releaseDateCountry select the certification and release dates
+	// movies get from the ones TMDB lists by country
+	certificationCountry string
+	releaseDateCountry   string
+	// watchRegion is the country whose watch providers are stored with movies
+	watchRegion string
 }
 
 // NewMetadataService creates a new metadata service
@@ -25,9 +59,14 @@ func NewMetadataService(db *database.Database, cfg *config.Config, logger *logge
 	tmdbClient := tmdb.NewClient(cfg, logger)
 
 	return &MetadataService{
-		db:     db,
-		tmdb:   tmdbClient,
-		logger: logger,
+		db:        db,
+		tmdb:      tmdbClient,
+		logger:    logger,
+		configKey: cfg.TMDB.APIKey,
+
+		certificationCountry: cfg.TMDB.CertificationCountry,
+		releaseDateCountry:   cfg.TMDB.ReleaseDateCountry,
+		watchRegion:          cfg.TMDB.WatchRegion,
 	}
 }
 
@@ -82,6 +121,94 @@ func (s *MetadataService) LookupMovieByTMDBID(tmdbID int) (*models.Movie, error)
 	return movie, nil
 }
 
+// LookupMoviesByTMDBIDs resolves several TMDB IDs, returning one result per distinct ID in request
+// order. Movies already in the library are returned from the database; the rest are fetched from
+// TMDB concurrently, and a failed ID carries its error without failing the batch.
+func (s *MetadataService) LookupMoviesByTMDBIDs(tmdbIDs []int) []models.MovieLookupResult {
+	ids := uniqueInts(tmdbIDs)
+	library := s.libraryMoviesByTMDBID(ids)
+
+	results := lookupBatch(ids, func(tmdbID int) (*models.Movie, error) {
+		if movie, ok := library[tmdbID]; ok {
+			return movie, nil
+		}
+		return s.LookupMovieByTMDBID(tmdbID)
+	})
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	s.logger.Debugw("Batch movie lookup completed", "requested", len(ids), "library", len(library), "failed", failed)
+	return results
+}
+
+// libraryMoviesByTMDBID loads the library movies among tmdbIDs, or none without a database
+func (s *MetadataService) libraryMoviesByTMDBID(tmdbIDs []int) map[int]*models.Movie {
+	library := make(map[int]*models.Movie)
+	if s.db == nil || len(tmdbIDs) == 0 {
+		return library
+	}
+
+	var movies []models.Movie
+	if err := s.db.GORM.Where("tmdb_id IN ?", tmdbIDs).Find(&movies).Error; err != nil {
+		s.logger.Warnw("Failed to load library movies for batch lookup", "error", err)
+		return library
+	}
+	for i := range movies {
+		library[movies[i].TmdbID] = &movies[i]
+	}
+	return library
+}
+
+// lookupBatch runs lookup for each ID on a bounded set of workers, keeping results in ID order
+func lookupBatch(ids []int, lookup func(int) (*models.Movie, error)) []models.MovieLookupResult {
+	results := make([]models.MovieLookupResult, len(ids))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < batchLookupWorkers && w < len(ids); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := models.MovieLookupResult{TmdbID: ids[i]}
+				if ids[i] <= 0 {
+					result.Error = fmt.Sprintf("invalid TMDB ID: %d", ids[i])
+				} else if movie, err := lookup(ids[i]); err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Movie = movie
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// uniqueInts returns values without duplicates, keeping the first occurrence of each
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	unique := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
 // RefreshMovieMetadata updates movie metadata from TMDB
 func (s *MetadataService) RefreshMovieMetadata(movieID int) error {
 	if s.db == nil {
@@ -164,8 +291,108 @@ func (s *MetadataService) GetTrendingMovies(timeWindow string, page int) (*tmdb.
 	return response, nil
 }
 
+// GetWatchProviders retrieves where a movie can be watched in a country, the configured watch
+// region when region is empty. A movie not offered there has no providers.
+func (s *MetadataService) GetWatchProviders(tmdbID int, region string) (*models.MovieWatchProviders, error) {
+	if region == "" {
+		region = s.watchRegion
+	}
+
+	providers, err := s.tmdb.GetWatchProviders(tmdbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch providers: %w", err)
+	}
+
+	watchProviders := buildWatchProviders(providers, region)
+	if watchProviders == nil {
+		watchProviders = &models.MovieWatchProviders{Region: strings.ToUpper(region)}
+	}
+	return watchProviders, nil
+}
+
+// GetAvailableWatchProviders lists the providers offering movies in a country, the configured
+// watch region when region is empty
+func (s *MetadataService) GetAvailableWatchProviders(region string) ([]models.WatchProvider, error) {
+	if region == "" {
+		region = s.watchRegion
+	}
+
+	providers, err := s.tmdb.GetAvailableWatchProviders(region)
+	if err != nil {
+		s.logger.Error("Failed to get available watch providers", "region", region, "error", err)
+		return nil, fmt.Errorf("failed to get available watch providers: %w", err)
+	}
+	return convertWatchProviders(providers), nil
+}
+
+// DiscoverMovies retrieves popular movies from TMDB, optionally limited to or excluding watch
+// providers in a country
+func (s *MetadataService) DiscoverMovies(request *models.MovieDiscoverRequest) (*tmdb.SearchResponse, error) {
+	options := tmdb.DiscoverOptions{
+		Page:                  max(request.Page, 1),
+		WatchRegion:           request.WatchRegion,
+		WithWatchProviders:    request.WithWatchProviders,
+		WithoutWatchProviders: request.WithoutWatchProviders,
+		MonetizationTypes:     request.MonetizationTypes,
+	}
+	if options.WatchRegion == "" {
+		options.WatchRegion = s.watchRegion
+	}
+
+	response, err := s.tmdb.Discover(options)
+	if err != nil {
+		s.logger.Error("Failed to discover movies", "error", err)
+		return nil, fmt.Errorf("failed to discover movies: %w", err)
+	}
+
+	s.logger.Debug("Movie discovery completed", "results", len(response.Results))
+	return response, nil
+}
+
+// buildWatchProviders returns where a movie can be watched in region, or nil when TMDB lists
+// nothing there
+func buildWatchProviders(providers *tmdb.WatchProviders, region string) *models.MovieWatchProviders {
+	for country, offers := range providers.Results {
+		if !strings.EqualFold(country, region) {
+			continue
+		}
+		return &models.MovieWatchProviders{
+			Region:   strings.ToUpper(country),
+			Link:     offers.Link,
+			Flatrate: convertWatchProviders(offers.Flatrate),
+			Free:     convertWatchProviders(offers.Free),
+			Ads:      convertWatchProviders(offers.Ads),
+			Rent:     convertWatchProviders(offers.Rent),
+			Buy:      convertWatchProviders(offers.Buy),
+		}
+	}
+	return nil
+}
+
+// convertWatchProviders converts TMDB watch providers in display order
+func convertWatchProviders(providers []tmdb.WatchProvider) []models.WatchProvider {
+	if len(providers) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(providers)
+	slices.SortStableFunc(sorted, func(a, b tmdb.WatchProvider) int {
+		return a.DisplayPriority - b.DisplayPriority
+	})
+
+	converted := make([]models.WatchProvider, len(sorted))
+	for i, provider := range sorted {
+		converted[i] = models.WatchProvider{
+			ID:       provider.ProviderID,
+			Name:     provider.ProviderName,
+			LogoPath: provider.LogoPath,
+		}
+	}
+	return converted
+}
+
 // convertTMDBToMovie converts a TMDB movie to internal movie model
-func (s *MetadataService) convertTMDBToMovie(tmdbMovie *tmdb.Movie, _ *tmdb.Credits) *models.Movie {
+func (s *MetadataService) convertTMDBToMovie(tmdbMovie *tmdb.Movie, credits *tmdb.Credits) *models.Movie {
 	releaseDate := s.parseReleaseDate(tmdbMovie.ReleaseDate)
 	ratings := s.buildRatings(tmdbMovie)
 	collection := s.buildCollection(tmdbMovie.BelongsToCollection)
@@ -191,19 +418,46 @@ func (s *MetadataService) convertTMDBToMovie(tmdbMovie *tmdb.Movie, _ *tmdb.Cred
 		Collection:          collection,
 		Popularity:          tmdbMovie.Popularity,
 		Studio:              s.extractStudio(tmdbMovie.ProductionCompanies),
-		Certification:       "",
+		ReleaseDates:        s.buildRegionalReleases(tmdbMovie.ReleaseDates),
 		Monitored:           true,
 		MinimumAvailability: models.AvailabilityTBA,
 		IsAvailable:         false,
 	}
 
+	if region := movie.ReleaseDates.ForCountry(s.certificationCountry); region != nil {
+		movie.Certification = region.Certification
+	}
 	s.setReleaseDates(movie, releaseDate)
+	movie.WatchProviders = buildWatchProviders(&tmdbMovie.WatchProviders, s.watchRegion)
 	s.setMovieImages(movie, tmdbMovie)
+	movie.Cast, movie.Crew = s.buildCredits(credits)
 	movie.TitleSlug = s.generateTitleSlug(movie.Title, movie.Year)
 
 	return movie
 }
 
+// buildCredits returns the billed cast and the key crew as "Name (Job)", in TMDB order
+func (s *MetadataService) buildCredits(credits *tmdb.Credits) (cast, crew models.StringArray) {
+	cast = models.StringArray{}
+	crew = models.StringArray{}
+	if credits == nil {
+		return cast, crew
+	}
+
+	for _, member := range credits.Cast {
+		if len(cast) == maxStoredCastMembers {
+			break
+		}
+		cast = append(cast, member.Name)
+	}
+	for _, member := range credits.Crew {
+		if searchableCrewJobs[member.Job] {
+			crew = append(crew, fmt.Sprintf("%s (%s)", member.Name, member.Job))
+		}
+	}
+	return cast, crew
+}
+
 // parseReleaseDate parses TMDB release date string to time.Time
 func (s *MetadataService) parseReleaseDate(dateStr string) *time.Time {
 	if dateStr == "" {
@@ -259,13 +513,91 @@ func (s *MetadataService) buildGenresArray(genres []tmdb.Genre) models.StringArr
 	return genresArray
 }
 
-// setReleaseDates sets release dates on the movie
+// buildRegionalReleases collects the certification and earliest cinema, digital and physical
+// release dates TMDB lists for each country. The theatrical certification is preferred over those
+// of other releases.
+func (s *MetadataService) buildRegionalReleases(releaseDates tmdb.ReleaseDates) models.RegionalReleases {
+	releases := models.RegionalReleases{}
+	for _, country := range releaseDates.Results {
+		region := models.RegionalRelease{Country: strings.ToUpper(country.ISO31661)}
+		theatricalCertification := false
+
+		for _, release := range country.ReleaseDates {
+			if release.Certification != "" && !theatricalCertification {
+				region.Certification = release.Certification
+				theatricalCertification = release.Type == tmdb.ReleaseTypeTheatrical
+			}
+
+			released := s.parseReleaseDateTime(release.ReleaseDate)
+			if released == nil {
+				continue
+			}
+			switch release.Type {
+			case tmdb.ReleaseTypeTheatricalLimited, tmdb.ReleaseTypeTheatrical:
+				region.InCinemas = earliestDate(region.InCinemas, released)
+			case tmdb.ReleaseTypeDigital:
+				region.DigitalRelease = earliestDate(region.DigitalRelease, released)
+			case tmdb.ReleaseTypePhysical:
+				if earliest := earliestDate(region.PhysicalRelease, released); earliest != region.PhysicalRelease {
+					region.PhysicalRelease, region.PhysicalReleaseNote = earliest, release.Note
+				}
+			}
+		}
+		releases = append(releases, region)
+	}
+	return releases
+}
+
+// setReleaseDates takes the release dates of the configured country. A kind of release the
+// country has no date for gets the earliest date of that kind in any country, and the cinema
+// release falls back to TMDB's primary release date.
 func (s *MetadataService) setReleaseDates(movie *models.Movie, releaseDate *time.Time) {
-	if releaseDate != nil {
+	if region := movie.ReleaseDates.ForCountry(s.releaseDateCountry); region != nil {
+		movie.InCinemas = region.InCinemas
+		movie.DigitalRelease = region.DigitalRelease
+		movie.PhysicalRelease, movie.PhysicalReleaseNote = region.PhysicalRelease, region.PhysicalReleaseNote
+	}
+
+	var inCinemas, digitalRelease, physicalRelease *time.Time
+	physicalReleaseNote := ""
+	for _, region := range movie.ReleaseDates {
+		inCinemas = earliestDate(inCinemas, region.InCinemas)
+		digitalRelease = earliestDate(digitalRelease, region.DigitalRelease)
+		if earliest := earliestDate(physicalRelease, region.PhysicalRelease); earliest != physicalRelease {
+			physicalRelease, physicalReleaseNote = earliest, region.PhysicalReleaseNote
+		}
+	}
+
+	if movie.InCinemas == nil {
+		movie.InCinemas = inCinemas
+	}
+	if movie.InCinemas == nil {
 		movie.InCinemas = releaseDate
-		movie.PhysicalRelease = releaseDate
-		movie.DigitalRelease = releaseDate
 	}
+	if movie.DigitalRelease == nil {
+		movie.DigitalRelease = digitalRelease
+	}
+	if movie.PhysicalRelease == nil {
+		movie.PhysicalRelease, movie.PhysicalReleaseNote = physicalRelease, physicalReleaseNote
+	}
+}
+
+// parseReleaseDateTime parses the timestamp of a TMDB release, keeping only its date
+func (s *MetadataService) parseReleaseDateTime(value string) *time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	date := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+// earliestDate returns the earlier of two optional dates
+func earliestDate(current, candidate *time.Time) *time.Time {
+	if candidate == nil || (current != nil && !candidate.Before(*current)) {
+		return current
+	}
+	return candidate
 }
 
 // setMovieImages sets movie images from TMDB data
@@ -1,14 +1,20 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
 	"github.com/radarr/radarr-go/internal/tmdb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetadataService_SearchMovies(t *testing.T) {
@@ -58,6 +64,53 @@ func TestMetadataService_LookupMovieByTMDBID(t *testing.T) {
 	assert.Contains(t, err.Error(), "TMDB API key not configured")
 }
 
+func TestLookupBatch(t *testing.T) {
+	var active, peak atomic.Int32
+	lookup := func(tmdbID int) (*models.Movie, error) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if tmdbID == 13 {
+			return nil, errors.New("not found")
+		}
+		return &models.Movie{TmdbID: tmdbID}, nil
+	}
+
+	ids := []int{603, 13, 807, 0, 550, 680, 155, 27205}
+	results := lookupBatch(ids, lookup)
+	require.Len(t, results, len(ids))
+
+	for i, result := range results {
+		assert.Equal(t, ids[i], result.TmdbID, "results keep the request order")
+	}
+	assert.Equal(t, 603, results[0].Movie.TmdbID)
+	assert.Equal(t, "not found", results[1].Error)
+	assert.Nil(t, results[1].Movie)
+	assert.Contains(t, results[3].Error, "invalid TMDB ID")
+	assert.LessOrEqual(t, int(peak.Load()), batchLookupWorkers)
+}
+
+func TestMetadataService_LookupMoviesByTMDBIDs(t *testing.T) {
+	cfg := &config.Config{TMDB: config.TMDBConfig{APIKey: ""}}
+	service := NewMetadataService(nil, cfg, logger.New(config.LogConfig{Level: "error", Output: "none"}))
+
+	results := service.LookupMoviesByTMDBIDs([]int{603, 550, 603})
+	require.Len(t, results, 2, "duplicate IDs are looked up once")
+	assert.Equal(t, 603, results[0].TmdbID)
+	assert.Equal(t, 550, results[1].TmdbID)
+	for _, result := range results {
+		assert.Nil(t, result.Movie)
+		assert.Contains(t, result.Error, "TMDB API key not configured")
+	}
+}
+
 func TestMetadataService_convertTMDBToMovie(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
 	service := &MetadataService{logger: logger}
@@ -101,6 +154,73 @@ func TestMetadataService_convertTMDBToMovie(t *testing.T) {
 	assert.Equal(t, &expectedDate, movie.InCinemas)
 }
 
+func TestMetadataService_RegionalReleases(t *testing.T) {
+	service := &MetadataService{certificationCountry: "GB", releaseDateCountry: "US"}
+
+	tmdbMovie := &tmdb.Movie{
+		ID:          550,
+		Title:       "Fight Club",
+		ReleaseDate: "1999-09-10",
+		ReleaseDates: tmdb.ReleaseDates{Results: []tmdb.CountryReleases{
+			{ISO31661: "US", ReleaseDates: []tmdb.ReleaseDate{
+				{Type: tmdb.ReleaseTypePremiere, ReleaseDate: "1999-09-10T00:00:00.000Z"},
+				{Type: tmdb.ReleaseTypeDigital, ReleaseDate: "2000-03-01T00:00:00.000Z", Certification: "R"},
+				{Type: tmdb.ReleaseTypeTheatrical, ReleaseDate: "1999-10-15T00:00:00.000Z", Certification: "R"},
+			}},
+			{ISO31661: "gb", ReleaseDates: []tmdb.ReleaseDate{
+				{Type: tmdb.ReleaseTypeTheatrical, ReleaseDate: "1999-11-12T00:00:00.000Z", Certification: "18"},
+				{Type: tmdb.ReleaseTypePhysical, ReleaseDate: "2000-06-05T00:00:00.000Z", Note: "DVD"},
+				{Type: tmdb.ReleaseTypePhysical, ReleaseDate: "2000-04-24T00:00:00.000Z", Note: "VHS"},
+			}},
+		}},
+	}
+
+	movie := service.convertTMDBToMovie(tmdbMovie, nil)
+
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	assert.Len(t, movie.ReleaseDates, 2)
+	assert.Equal(t, "18", movie.Certification)
+	assert.Equal(t, date(1999, 10, 15), movie.InCinemas)
+	assert.Equal(t, date(2000, 3, 1), movie.DigitalRelease)
+	// The US has no physical release, so the earliest anywhere is used
+	assert.Equal(t, date(2000, 4, 24), movie.PhysicalRelease)
+	assert.Equal(t, "VHS", movie.PhysicalReleaseNote)
+
+	// A country TMDB lists nothing for leaves the certification empty and falls back for dates
+	service = &MetadataService{certificationCountry: "FR", releaseDateCountry: "FR"}
+	movie = service.convertTMDBToMovie(tmdbMovie, nil)
+	assert.Empty(t, movie.Certification)
+	assert.Equal(t, date(1999, 10, 15), movie.InCinemas)
+	assert.Equal(t, "R", movie.ReleaseDates.ForCountry("us").Certification)
+}
+
+func TestMetadataService_buildCredits(t *testing.T) {
+	service := &MetadataService{}
+
+	cast, crew := service.buildCredits(nil)
+	assert.Empty(t, cast)
+	assert.Empty(t, crew)
+
+	credits := &tmdb.Credits{
+		Crew: []tmdb.CrewMember{
+			{Name: "David Fincher", Job: "Director"},
+			{Name: "Jim Uhls", Job: "Screenplay"},
+			{Name: "Someone", Job: "Best Boy Grip"},
+		},
+	}
+	for i := 0; i < maxStoredCastMembers+5; i++ {
+		credits.Cast = append(credits.Cast, tmdb.CastMember{Name: fmt.Sprintf("Actor %d", i)})
+	}
+
+	cast, crew = service.buildCredits(credits)
+	assert.Len(t, cast, maxStoredCastMembers)
+	assert.Equal(t, "Actor 0", cast[0])
+	assert.Equal(t, models.StringArray{"David Fincher (Director)", "Jim Uhls (Screenplay)"}, crew)
+}
+
 func TestMetadataService_generateTitleSlug(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
 	service := &MetadataService{logger: logger}
@@ -143,3 +263,63 @@ func TestMetadataService_RefreshMovieMetadata(t *testing.T) {
 	err := service.RefreshMovieMetadata(1)
 	assert.Error(t, err)
 }
+
+func TestMetadataService_GetAPIKeyStatus(t *testing.T) {
+	cfg := &config.Config{TMDB: config.TMDBConfig{APIKey: "0123456789abcdef"}}
+	service := NewMetadataService(nil, cfg, logger.New(config.LogConfig{Level: "error", Output: "none"}))
+
+	status := service.GetAPIKeyStatus()
+	assert.True(t, status.Configured)
+	assert.Equal(t, models.TMDBKeySourceConfig, status.Source)
+	assert.Equal(t, "************cdef", status.APIKey)
+	assert.Nil(t, status.CheckedAt)
+
+	service.tmdb.SetAPIKey("override")
+	assert.Equal(t, models.TMDBKeySourceDatabase, service.GetAPIKeyStatus().Source)
+
+	service.tmdb.SetAPIKey("")
+	status = service.GetAPIKeyStatus()
+	assert.False(t, status.Configured)
+	assert.Equal(t, models.TMDBKeySourceNone, status.Source)
+	assert.Empty(t, status.APIKey)
+
+	// Without a database nothing can be stored
+	assert.Error(t, service.SetAPIKey(context.Background(), "key"))
+	var validationErr models.ValidationError
+	assert.ErrorAs(t, service.SetAPIKey(context.Background(), " "), &validationErr)
+}
+
+func TestBuildWatchProviders(t *testing.T) {
+	providers := &tmdb.WatchProviders{Results: map[string]tmdb.RegionWatchProviders{
+		"US": {
+			Link: "https://www.themoviedb.org/movie/550/watch?locale=US",
+			Flatrate: []tmdb.WatchProvider{
+				{ProviderID: 337, ProviderName: "Disney Plus", DisplayPriority: 2},
+				{ProviderID: 8, ProviderName: "Netflix", DisplayPriority: 1},
+			},
+			Rent: []tmdb.WatchProvider{{ProviderID: 2, ProviderName: "Apple TV"}},
+		},
+	}}
+
+	assert.Nil(t, buildWatchProviders(providers, "GB"))
+
+	watchProviders := buildWatchProviders(providers, "us")
+	require.NotNil(t, watchProviders)
+	assert.Equal(t, "US", watchProviders.Region)
+	assert.Equal(t, []models.WatchProvider{{ID: 8, Name: "Netflix"}, {ID: 337, Name: "Disney Plus"}},
+		watchProviders.Flatrate)
+
+	// Renting doesn't count as streaming
+	assert.True(t, watchProviders.StreamsOn([]int{8}))
+	assert.False(t, watchProviders.StreamsOn([]int{2}))
+
+	settings := models.ImportListSettings{ExcludeWatchProviderIDs: models.IntArray{8, 9}}
+	assert.True(t, settings.FiltersWatchProviders())
+	assert.False(t, settings.AllowsWatchProviders(watchProviders))
+	assert.True(t, settings.AllowsWatchProviders(nil))
+
+	settings = models.ImportListSettings{IncludeWatchProviderIDs: models.IntArray{2}}
+	assert.False(t, settings.AllowsWatchProviders(watchProviders))
+	settings.IncludeWatchProviderIDs = models.IntArray{337}
+	assert.True(t, settings.AllowsWatchProviders(watchProviders))
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package services
+
+// isReadOnlyMount is not supported on this platform; read-only folders are still found by the
+// write test
+func isReadOnlyMount(_ string) (bool, error) {
+	return false, nil
+}
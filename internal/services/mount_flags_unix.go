@@ -0,0 +1,23 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package services
+
+import (
+	"syscall"
+)
+
+// readOnlyMountFlag is ST_RDONLY on Linux and MNT_RDONLY on Darwin and FreeBSD
+const readOnlyMountFlag = 0x1
+
+// isReadOnlyMount reports whether the file system holding path is mounted read-only
+func isReadOnlyMount(path string) (bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false, err
+	}
+
+	// Flags is int64 on Linux, uint32 on Darwin and uint64 on FreeBSD
+	//nolint:unconvert // Conversion needed for cross-platform compatibility
+	return uint64(stat.Flags)&readOnlyMountFlag != 0, nil //#nosec G115 // Only the flag bits are tested
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// DefaultLibrarySearchLimit is the number of full-text results returned when no limit is given
+	DefaultLibrarySearchLimit = 50
+	// MaxLibrarySearchLimit bounds the full-text results returned by one search
+	MaxLibrarySearchLimit = 200
+
+	// highlightContext is the number of characters kept on each side of an overview match
+	highlightContext = 80
+	// minHighlightTermLength skips short words such as "of" when highlighting
+	minHighlightTermLength = 3
+)
+
+// fullTextMySQLColumns must list the columns of the idx_movies_full_text index exactly
+const fullTextMySQLColumns = "title, original_title, alternate_titles, overview, cast_members, crew_members"
+
+// highlightStopWords are common words the english text search configuration ignores, so they
+// are not highlighted either
+var highlightStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true, "this": true,
+	"are": true, "was": true, "his": true, "her": true, "who": true, "not": true, "but": true,
+}
+
+// fullTextHit is a movie ID with its full-text relevance
+type fullTextHit struct {
+	ID    int
+	Score float64
+}
+
+// FullTextSearch finds library movies whose titles, overview, cast, or crew match the term, using
+// the PostgreSQL search vector or the MySQL FULLTEXT index. Results are ordered by relevance and
+// carry highlighted excerpts of the matched fields.
+func (s *MovieService) FullTextSearch(term string, limit int) ([]models.LibrarySearchResult, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return []models.LibrarySearchResult{}, nil
+	}
+	if limit <= 0 {
+		limit = DefaultLibrarySearchLimit
+	}
+	limit = min(limit, MaxLibrarySearchLimit)
+
+	hits, err := s.fullTextHits(term, limit)
+	if err != nil {
+		s.logger.Error("Failed to run full-text search", "term", term, "error", err)
+		return nil, fmt.Errorf("failed to search library: %w", err)
+	}
+	if len(hits) == 0 {
+		return []models.LibrarySearchResult{}, nil
+	}
+
+	ids := make([]int, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	var movies []models.Movie
+	if err := s.db.GORM.Joins("MovieFile").Where("movies.id IN ?", ids).Find(&movies).Error; err != nil {
+		return nil, fmt.Errorf("failed to load search results: %w", err)
+	}
+	byID := make(map[int]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	terms := highlightTerms(term)
+	results := make([]models.LibrarySearchResult, 0, len(hits))
+	for _, hit := range hits {
+		movie, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, models.LibrarySearchResult{
+			Movie:      movie,
+			Score:      hit.Score,
+			Highlights: highlightMovie(&movie, terms),
+		})
+	}
+	return results, nil
+}
+
+// fullTextHits runs the database-specific full-text query, best match first
+func (s *MovieService) fullTextHits(term string, limit int) ([]fullTextHit, error) {
+	var hits []fullTextHit
+
+	if s.db.IsPostgres() {
+		err := s.db.GORM.Raw(`SELECT id, ts_rank_cd(search_vector, query) AS score
+			FROM movies, websearch_to_tsquery('english', ?) query
+			WHERE search_vector @@ query
+			ORDER BY score DESC, id
+			LIMIT ?`, term, limit).Scan(&hits).Error
+		return hits, err
+	}
+
+	match := "MATCH (" + fullTextMySQLColumns + ") AGAINST (? IN NATURAL LANGUAGE MODE)"
+	err := s.db.GORM.Raw("SELECT id, "+match+" AS score FROM movies WHERE "+match+
+		" ORDER BY score DESC, id LIMIT ?", term, term, limit).Scan(&hits).Error
+	return hits, err
+}
+
+// highlightTerms splits a search term into the lowercased words worth highlighting
+func highlightTerms(term string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) >= minHighlightTermLength && !highlightStopWords[word] && !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	// Longer terms first so overlapping matches mark the longest word
+	sort.SliceStable(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+	return terms
+}
+
+// highlightMovie returns an excerpt of each field containing one of the terms
+func highlightMovie(movie *models.Movie, terms []string) []models.SearchHighlight {
+	highlights := []models.SearchHighlight{}
+	if len(terms) == 0 {
+		return highlights
+	}
+
+	titles := append([]string{movie.Title, movie.OriginalTitle}, movie.AlternateTitles...)
+	for _, title := range titles {
+		if snippet, ok := markTerms(title, terms); ok {
+			highlights = append(highlights, models.SearchHighlight{Field: "title", Snippet: snippet})
+			break
+		}
+	}
+
+	if snippet, ok := overviewExcerpt(movie.Overview, terms); ok {
+		highlights = append(highlights, models.SearchHighlight{Field: "overview", Snippet: snippet})
+	}
+
+	for _, credits := range []struct {
+		field string
+		names []string
+	}{{"cast", movie.Cast}, {"crew", movie.Crew}} {
+		var matched []string
+		for _, name := range credits.names {
+			if snippet, ok := markTerms(name, terms); ok {
+				matched = append(matched, snippet)
+			}
+		}
+		if len(matched) > 0 {
+			highlights = append(highlights, models.SearchHighlight{Field: credits.field, Snippet: strings.Join(matched, ", ")})
+		}
+	}
+	return highlights
+}
+
+// overviewExcerpt returns the part of the overview around its first match
+func overviewExcerpt(overview string, terms []string) (string, bool) {
+	runes := []rune(overview)
+	lower := []rune(strings.ToLower(overview))
+	first := -1
+	for _, term := range terms {
+		if idx := indexWordStart(lower, []rune(term), 0); idx >= 0 && (first < 0 || idx < first) {
+			first = idx
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start := max(0, first-highlightContext)
+	end := min(len(runes), first+highlightContext)
+	// Widen to word boundaries so the excerpt does not cut words in half
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+
+	snippet, _ := markTerms(string(runes[start:end]), terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet, true
+}
+
+// markTerms HTML-escapes text and wraps the words starting with any of the terms in <mark>.
+// Matching on word starts lets "robot" mark "robots" the way stemming matched it.
+func markTerms(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// Lowercasing changed the length; fall back to unmarked text
+		return html.EscapeString(text), false
+	}
+
+	var b strings.Builder
+	matched := false
+	for i := 0; i < len(runes); {
+		length := 0
+		for _, term := range terms {
+			if indexWordStart(lower, []rune(term), i) == i {
+				length = len([]rune(term))
+				break
+			}
+		}
+		if length == 0 {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+
+		// Extend the match to the end of the word
+		end := i + length
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end])) {
+			end++
+		}
+		b.WriteString("<mark>" + html.EscapeString(string(runes[i:end])) + "</mark>")
+		matched = true
+		i = end
+	}
+	return b.String(), matched
+}
+
+// indexWordStart returns the first index at or after from where term starts a word in text
+func indexWordStart(text, term []rune, from int) int {
+	for i := from; i+len(term) <= len(text); i++ {
+		if i > 0 && (unicode.IsLetter(text[i-1]) || unicode.IsDigit(text[i-1])) {
+			continue
+		}
+		if string(text[i:i+len(term)]) == string(term) {
+			return i
+		}
+	}
+	return -1
+}
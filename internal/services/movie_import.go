@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// movieImportBatchSize is how many movies a bulk add creates per transaction
+	movieImportBatchSize = 100
+	// movieImportLookupChunk bounds the IN lists used to check a bulk add against the library
+	movieImportLookupChunk = 500
+)
+
+// ImportMovies adds movies in bulk, such as those found by a library import or an import list
+// preview. Each movie is validated on its own, and the valid ones are created in transactions of
+// movieImportBatchSize, so one bad movie doesn't keep the rest from being added. Movies without a
+// quality profile, minimum availability or tags take their root folder's defaults. The results are
+// in the order of movies.
+func (s *MovieService) ImportMovies(movies []models.Movie) ([]models.MovieImportResult, error) {
+	results := make([]models.MovieImportResult, len(movies))
+	pointers := make([]*models.Movie, len(movies))
+	for i := range movies {
+		results[i] = models.MovieImportResult{Index: i, TmdbID: movies[i].TmdbID, Title: movies[i].Title}
+		pointers[i] = &movies[i]
+	}
+
+	if err := s.ApplyRootFolderDefaults(pointers...); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateImport(movies, results); err != nil {
+		return nil, err
+	}
+
+	var valid []int
+	for i := range results {
+		if results[i].Error == "" {
+			valid = append(valid, i)
+		}
+	}
+
+	for start := 0; start < len(valid); start += movieImportBatchSize {
+		s.createImportBatch(movies, valid[start:min(start+movieImportBatchSize, len(valid))], results)
+	}
+
+	created := 0
+	for i := range results {
+		if results[i].Movie != nil {
+			created++
+		}
+	}
+	s.logger.Info("Imported movies", "requested", len(movies), "created", created)
+	return results, nil
+}
+
+// createImportBatch creates the movies at indexes in one transaction. When the transaction fails,
+// the movies are created one at a time so the failure is reported against the movie that caused it.
+func (s *MovieService) createImportBatch(movies []models.Movie, indexes []int, results []models.MovieImportResult) {
+	batch := make([]*models.Movie, len(indexes))
+	for i, idx := range indexes {
+		batch[i] = &movies[idx]
+	}
+
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(batch).Error
+	})
+	if err == nil {
+		for _, idx := range indexes {
+			s.index.Put(&movies[idx])
+			results[idx].Movie = &movies[idx]
+		}
+		return
+	}
+
+	s.logger.Warn("Bulk movie batch failed, adding its movies one at a time", "size", len(indexes), "error", err)
+	for _, idx := range indexes {
+		movie := &movies[idx]
+		movie.ID = 0
+		if err := s.db.GORM.Create(movie).Error; err != nil {
+			movie.ID = 0
+			results[idx].Error = fmt.Sprintf("failed to create movie: %v", err)
+			continue
+		}
+		s.index.Put(movie)
+		results[idx].Movie = movie
+	}
+}
+
+// validateImport records a validation error in results for each movie that can't be added: those
+// without a title, TMDB ID or title slug, those already in the library, and those repeating the TMDB ID or path
+// of another movie in the request or the library
+func (s *MovieService) validateImport(movies []models.Movie, results []models.MovieImportResult) error {
+	fail := func(i int, field, message string) {
+		if results[i].Error == "" {
+			results[i].Field = field
+			results[i].Error = message
+		}
+	}
+
+	seenTmdb := make(map[int]int)
+	seenPath := make(map[string]int)
+	var tmdbIDs []int
+	var paths []string
+	for i := range movies {
+		movie := &movies[i]
+		movie.ID = 0
+		switch {
+		case strings.TrimSpace(movie.Title) == "":
+			fail(i, "title", "title is required")
+			continue
+		case movie.TmdbID <= 0:
+			fail(i, "tmdbId", "tmdbId is required")
+			continue
+		case movie.TitleSlug == "":
+			fail(i, "titleSlug", "titleSlug is required")
+			continue
+		}
+
+		if first, ok := seenTmdb[movie.TmdbID]; ok {
+			fail(i, "tmdbId", fmt.Sprintf("tmdbId %d is repeated from movie %d of the request", movie.TmdbID, first))
+			continue
+		}
+		seenTmdb[movie.TmdbID] = i
+		tmdbIDs = append(tmdbIDs, movie.TmdbID)
+
+		if path := importPathKey(moviePath(movie)); path != "" {
+			if first, ok := seenPath[path]; ok {
+				fail(i, "path", fmt.Sprintf("path %s is repeated from movie %d of the request", moviePath(movie), first))
+				continue
+			}
+			seenPath[path] = i
+			paths = append(paths, path, path+string(filepath.Separator))
+		}
+	}
+
+	for start := 0; start < len(tmdbIDs); start += movieImportLookupChunk {
+		var existing []models.Movie
+		if err := s.db.GORM.Model(&models.Movie{}).Select("id", "title", "tmdb_id").
+			Where("tmdb_id IN ?", tmdbIDs[start:min(start+movieImportLookupChunk, len(tmdbIDs))]).
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check library for movies: %w", err)
+		}
+		for _, movie := range existing {
+			fail(seenTmdb[movie.TmdbID], "tmdbId",
+				fmt.Sprintf("movie is already in the library as %q (id %d)", movie.Title, movie.ID))
+		}
+	}
+
+	column := "path"
+	if caseInsensitivePaths {
+		column = "LOWER(path)"
+	}
+	for start := 0; start < len(paths); start += movieImportLookupChunk {
+		var existing []models.Movie
+		if err := s.db.GORM.Model(&models.Movie{}).Select("id", "title", "path").
+			Where(column+" IN ?", paths[start:min(start+movieImportLookupChunk, len(paths))]).
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check movie paths: %w", err)
+		}
+		for _, movie := range existing {
+			key := importPathKey(strings.TrimSuffix(movie.Path, string(filepath.Separator)))
+			if i, ok := seenPath[key]; ok {
+				fail(i, "path", fmt.Sprintf("path %s is already used by movie %q (id %d)",
+					moviePath(&movies[i]), movie.Title, movie.ID))
+			}
+		}
+	}
+	return nil
+}
+
+// importPathKey normalizes a movie path for comparison the way ValidatePath compares paths
+func importPathKey(path string) string {
+	if caseInsensitivePaths {
+		return strings.ToLower(path)
+	}
+	return path
+}
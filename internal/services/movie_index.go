@@ -0,0 +1,344 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// movieIndexMaxAge bounds how long the index is trusted before it is rebuilt, so writes that
+// bypass MovieService (raw updates from other services) are eventually picked up
+const movieIndexMaxAge = 10 * time.Minute
+
+// MovieIndexEntry holds the identifiers of a movie kept in the in-memory index
+type MovieIndexEntry struct {
+	ID            int
+	TmdbID        int
+	ImdbID        string
+	TitleSlug     string
+	Title         string
+	OriginalTitle string
+	// AlternateTitles take part in Search but not in MatchTitle
+	AlternateTitles []string
+	Year            int
+}
+
+// MovieIndex is an in-memory lookup table of movie identifiers used by release parsing,
+// import mapping, and TMDB ID lookups so they do not query the database per release.
+// It loads lazily and is kept current by MovieService as movies are created, updated, and deleted.
+type MovieIndex struct {
+	db     *database.Database
+	logger *logger.Logger
+
+	mu       sync.RWMutex
+	loadedAt time.Time
+	byID     map[int]MovieIndexEntry
+	byTmdbID map[int]int
+	byImdbID map[string]int
+	bySlug   map[string]int
+	byTitle  map[string][]int
+}
+
+// NewMovieIndex creates an empty movie index that loads on first use
+func NewMovieIndex(db *database.Database, logger *logger.Logger) *MovieIndex {
+	return &MovieIndex{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Rebuild reloads the index from the database
+func (i *MovieIndex) Rebuild() error {
+	if i.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var movies []models.Movie
+	if err := i.db.GORM.Select("id", "tmdb_id", "imdb_id", "title_slug", "title", "original_title", "alternate_titles", "year").
+		Find(&movies).Error; err != nil {
+		return fmt.Errorf("failed to load movie index: %w", err)
+	}
+
+	i.load(movies)
+	i.logger.Debug("Rebuilt movie index", "movies", len(movies))
+	return nil
+}
+
+// load replaces the index contents with the given movies
+func (i *MovieIndex) load(movies []models.Movie) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.byID = make(map[int]MovieIndexEntry, len(movies))
+	i.byTmdbID = make(map[int]int, len(movies))
+	i.byImdbID = make(map[string]int, len(movies))
+	i.bySlug = make(map[string]int, len(movies))
+	i.byTitle = make(map[string][]int, len(movies))
+	for idx := range movies {
+		i.addLocked(newMovieIndexEntry(&movies[idx]))
+	}
+	i.loadedAt = time.Now()
+}
+
+// Invalidate discards the index so the next lookup reloads it from the database
+func (i *MovieIndex) Invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.loadedAt = time.Time{}
+}
+
+// Put adds or replaces a movie in the index. It is a no-op until the index has been loaded.
+func (i *MovieIndex) Put(movie *models.Movie) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.loadedAt.IsZero() {
+		return
+	}
+	i.removeLocked(movie.ID)
+	i.addLocked(newMovieIndexEntry(movie))
+}
+
+// Remove deletes a movie from the index
+func (i *MovieIndex) Remove(id int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.loadedAt.IsZero() {
+		return
+	}
+	i.removeLocked(id)
+}
+
+// ByTmdbID returns the indexed movie with the given TMDB ID
+func (i *MovieIndex) ByTmdbID(tmdbID int) (MovieIndexEntry, bool, error) {
+	return i.lookup(func() (int, bool) {
+		id, ok := i.byTmdbID[tmdbID]
+		return id, ok
+	})
+}
+
+// ByImdbID returns the indexed movie with the given IMDb ID
+func (i *MovieIndex) ByImdbID(imdbID string) (MovieIndexEntry, bool, error) {
+	return i.lookup(func() (int, bool) {
+		id, ok := i.byImdbID[strings.ToLower(imdbID)]
+		return id, ok
+	})
+}
+
+// ByTitleSlug returns the indexed movie with the given title slug
+func (i *MovieIndex) ByTitleSlug(slug string) (MovieIndexEntry, bool, error) {
+	return i.lookup(func() (int, bool) {
+		id, ok := i.bySlug[strings.ToLower(slug)]
+		return id, ok
+	})
+}
+
+// MatchTitle returns the movies whose title or original title matches the given title.
+// Candidates are tried in order of confidence: exact cleaned title with year, partial title
+// with year, exact title ignoring year, then partial title ignoring year. A year of zero skips
+// the year-restricted passes. Results are ordered by movie ID.
+func (i *MovieIndex) MatchTitle(title string, year int) ([]MovieIndexEntry, error) {
+	clean := cleanIndexTitle(title)
+	if clean == "" {
+		return nil, nil
+	}
+
+	if err := i.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	type pass struct {
+		exact    bool
+		withYear bool
+	}
+	passes := []pass{{true, true}, {false, true}, {true, false}, {false, false}}
+
+	for _, p := range passes {
+		if p.withYear && year <= 0 {
+			continue
+		}
+
+		seen := make(map[int]bool)
+		var matches []MovieIndexEntry
+		collect := func(ids []int) {
+			for _, id := range ids {
+				entry := i.byID[id]
+				if seen[id] || (p.withYear && entry.Year != year) {
+					continue
+				}
+				seen[id] = true
+				matches = append(matches, entry)
+			}
+		}
+
+		if p.exact {
+			collect(i.byTitle[clean])
+		} else {
+			for key, ids := range i.byTitle {
+				if strings.Contains(key, clean) {
+					collect(ids)
+				}
+			}
+		}
+
+		if len(matches) > 0 {
+			sort.Slice(matches, func(a, b int) bool { return matches[a].ID < matches[b].ID })
+			return matches, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Entries returns every indexed movie, loading the index if needed
+func (i *MovieIndex) Entries() ([]MovieIndexEntry, error) {
+	if err := i.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	entries := make([]MovieIndexEntry, 0, len(i.byID))
+	for _, entry := range i.byID {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Len returns the number of indexed movies, loading the index if needed
+func (i *MovieIndex) Len() (int, error) {
+	if err := i.ensureLoaded(); err != nil {
+		return 0, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.byID), nil
+}
+
+func (i *MovieIndex) lookup(find func() (int, bool)) (MovieIndexEntry, bool, error) {
+	if err := i.ensureLoaded(); err != nil {
+		return MovieIndexEntry{}, false, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	id, ok := find()
+	if !ok {
+		return MovieIndexEntry{}, false, nil
+	}
+	return i.byID[id], true, nil
+}
+
+// ensureLoaded loads the index on first use and rebuilds it once it is older than movieIndexMaxAge
+func (i *MovieIndex) ensureLoaded() error {
+	i.mu.RLock()
+	fresh := !i.loadedAt.IsZero() && time.Since(i.loadedAt) < movieIndexMaxAge
+	i.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+	return i.Rebuild()
+}
+
+func (i *MovieIndex) addLocked(entry MovieIndexEntry) {
+	i.byID[entry.ID] = entry
+	if entry.TmdbID > 0 {
+		i.byTmdbID[entry.TmdbID] = entry.ID
+	}
+	if entry.ImdbID != "" {
+		i.byImdbID[strings.ToLower(entry.ImdbID)] = entry.ID
+	}
+	if entry.TitleSlug != "" {
+		i.bySlug[strings.ToLower(entry.TitleSlug)] = entry.ID
+	}
+	for _, key := range entry.titleKeys() {
+		i.byTitle[key] = append(i.byTitle[key], entry.ID)
+	}
+}
+
+func (i *MovieIndex) removeLocked(id int) {
+	entry, ok := i.byID[id]
+	if !ok {
+		return
+	}
+
+	delete(i.byID, id)
+	if i.byTmdbID[entry.TmdbID] == id {
+		delete(i.byTmdbID, entry.TmdbID)
+	}
+	if key := strings.ToLower(entry.ImdbID); i.byImdbID[key] == id {
+		delete(i.byImdbID, key)
+	}
+	if key := strings.ToLower(entry.TitleSlug); i.bySlug[key] == id {
+		delete(i.bySlug, key)
+	}
+	for _, key := range entry.titleKeys() {
+		ids := i.byTitle[key]
+		for idx, existing := range ids {
+			if existing == id {
+				ids = append(ids[:idx], ids[idx+1:]...)
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(i.byTitle, key)
+		} else {
+			i.byTitle[key] = ids
+		}
+	}
+}
+
+func newMovieIndexEntry(movie *models.Movie) MovieIndexEntry {
+	return MovieIndexEntry{
+		ID:              movie.ID,
+		TmdbID:          movie.TmdbID,
+		ImdbID:          movie.ImdbID,
+		TitleSlug:       movie.TitleSlug,
+		Title:           movie.Title,
+		OriginalTitle:   movie.OriginalTitle,
+		AlternateTitles: movie.AlternateTitles,
+		Year:            movie.Year,
+	}
+}
+
+// titleKeys returns the distinct cleaned titles the entry is indexed under
+func (e MovieIndexEntry) titleKeys() []string {
+	keys := make([]string, 0, 2)
+	for _, title := range []string{e.Title, e.OriginalTitle} {
+		if key := cleanIndexTitle(title); key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// cleanIndexTitle lowercases a title and strips everything but letters and digits,
+// so "Spider-Man: No Way Home" and "spider.man.no.way.home" index to the same key
+func cleanIndexTitle(title string) string {
+	var b strings.Builder
+	b.Grow(len(title))
+	for _, r := range strings.ToLower(strings.ReplaceAll(title, "&", "and")) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
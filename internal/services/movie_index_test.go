@@ -0,0 +1,109 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLoadedMovieIndex(movies ...models.Movie) *MovieIndex {
+	index := NewMovieIndex(nil, logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"}))
+	index.load(movies)
+	return index
+}
+
+func TestMovieIndex_Lookups(t *testing.T) {
+	index := newLoadedMovieIndex(
+		models.Movie{ID: 1, TmdbID: 603, ImdbID: "tt0133093", TitleSlug: "the-matrix-603", Title: "The Matrix", Year: 1999},
+		models.Movie{ID: 2, TmdbID: 604, ImdbID: "tt0234215", TitleSlug: "the-matrix-reloaded-604",
+			Title: "The Matrix Reloaded", Year: 2003},
+	)
+
+	entry, found, err := index.ByTmdbID(604)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, 2, entry.ID)
+
+	entry, found, err = index.ByImdbID("TT0133093")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, 1, entry.ID)
+
+	_, found, err = index.ByTitleSlug("the-matrix-reloaded-604")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	_, found, err = index.ByTmdbID(999)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestMovieIndex_MatchTitle(t *testing.T) {
+	index := newLoadedMovieIndex(
+		models.Movie{ID: 1, TmdbID: 1, Title: "Spider-Man: No Way Home", Year: 2021},
+		models.Movie{ID: 2, TmdbID: 2, Title: "Dune", Year: 1984},
+		models.Movie{ID: 3, TmdbID: 3, Title: "Dune", Year: 2021},
+		models.Movie{ID: 4, TmdbID: 4, Title: "Dune: Part Two", Year: 2024},
+		models.Movie{ID: 5, TmdbID: 5, Title: "Amélie", OriginalTitle: "Le Fabuleux Destin d'Amélie Poulain", Year: 2001},
+	)
+
+	tests := []struct {
+		name  string
+		title string
+		year  int
+		want  []int
+	}{
+		{"exact with year", "Dune", 2021, []int{3}},
+		{"exact without year", "Dune", 0, []int{2, 3}},
+		{"punctuation ignored", "spider.man.no.way.home", 2021, []int{1}},
+		{"partial with year", "Part Two", 2024, []int{4}},
+		{"year mismatch falls back to title", "Dune Part Two", 2023, []int{4}},
+		{"original title", "Le Fabuleux Destin d'Amélie Poulain", 2001, []int{5}},
+		{"no match", "Heat", 1995, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := index.MatchTitle(tt.title, tt.year)
+			require.NoError(t, err)
+
+			var ids []int
+			for _, entry := range entries {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestMovieIndex_PutAndRemove(t *testing.T) {
+	index := newLoadedMovieIndex(models.Movie{ID: 1, TmdbID: 10, TitleSlug: "old-title-10", Title: "Old Title", Year: 2000})
+
+	index.Put(&models.Movie{ID: 1, TmdbID: 10, TitleSlug: "new-title-10", Title: "New Title", Year: 2000})
+
+	_, found, err := index.ByTitleSlug("old-title-10")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	entries, err := index.MatchTitle("Old Title", 0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = index.MatchTitle("New Title", 2000)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	index.Remove(1)
+
+	_, found, err = index.ByTmdbID(10)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	count, err := index.Len()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// SetMonitored sets whether a movie is monitored. Only the monitored column is written, so the
+// change can't overwrite, or be overwritten by, a metadata refresh saving the movie meanwhile.
+func (s *MovieService) SetMonitored(movie *models.Movie, monitored bool) error {
+	err := s.db.GORM.Model(&models.Movie{}).Where("id = ?", movie.ID).
+		Updates(map[string]interface{}{"monitored": monitored, "updated_at": time.Now()}).Error
+	if err != nil {
+		s.logger.Error("Failed to set movie monitoring", "id", movie.ID, "error", err)
+		return fmt.Errorf("failed to set movie monitoring: %w", err)
+	}
+
+	movie.Monitored = monitored
+	s.logger.Info("Set movie monitoring", "id", movie.ID, "title", movie.Title, "monitored", monitored)
+	return nil
+}
+
+// SetMonitoredBulk sets whether the movies with the given ids, matching filter when it isn't nil,
+// are monitored. At least one of ids and filter must be given. Like SetMonitored, only the
+// monitored column is written, and movies already in the requested state are left alone.
+func (s *MovieService) SetMonitoredBulk(ids []int, filter *models.CustomFilter,
+	monitored bool) (*models.MovieBulkMonitorResult, error) {
+	if len(ids) == 0 && filter == nil {
+		return nil, models.ValidationError{Field: "movieIds", Message: "movieIds, filterId or filters is required"}
+	}
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	query := s.db.GORM.Model(&models.Movie{})
+	if filter != nil {
+		var err error
+		if query, err = applyMovieFilter(query, filter); err != nil {
+			return nil, models.ValidationError{Field: "filters", Message: err.Error()}
+		}
+	}
+	if len(ids) > 0 {
+		query = query.Where("movies.id IN ?", ids)
+	}
+
+	var matched []models.Movie
+	if err := query.Select("movies.id", "movies.monitored").Order("movies.id").Find(&matched).Error; err != nil {
+		return nil, fmt.Errorf("failed to find movies to monitor: %w", err)
+	}
+
+	result := &models.MovieBulkMonitorResult{Monitored: monitored, Matched: len(matched), MovieIDs: []int{}}
+	for _, movie := range matched {
+		if movie.Monitored != monitored {
+			result.MovieIDs = append(result.MovieIDs, movie.ID)
+		}
+	}
+
+	now := time.Now()
+	for start := 0; start < len(result.MovieIDs); start += movieImportLookupChunk {
+		chunk := result.MovieIDs[start:min(start+movieImportLookupChunk, len(result.MovieIDs))]
+		err := s.db.GORM.Model(&models.Movie{}).Where("id IN ?", chunk).
+			Updates(map[string]interface{}{"monitored": monitored, "updated_at": now}).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to set movie monitoring: %w", err)
+		}
+		result.Updated += len(chunk)
+	}
+
+	s.logger.Info("Set monitoring of movies", "monitored", monitored, "matched", result.Matched,
+		"updated", result.Updated)
+	return result, nil
+}
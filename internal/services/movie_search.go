@@ -0,0 +1,344 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// minMovieSearchScore is the lowest score a movie needs to appear in search results
+	minMovieSearchScore = 0.5
+
+	// maxTrigramCandidates bounds the rows pre-selected by pg_trgm before ranking in Go
+	maxTrigramCandidates = 500
+
+	// alternateTitleWeight ranks alternate title matches just below primary title matches
+	alternateTitleWeight = 0.95
+
+	// Year adjustments applied when the query ends in a release year
+	searchYearExactBonus   = 0.2
+	searchYearNearbyBonus  = 0.05
+	searchYearMismatchCost = 0.15
+
+	// earliestMovieYear is the lowest number treated as a release year in a query
+	earliestMovieYear = 1888
+)
+
+// searchYearPattern matches a trailing release year, optionally in parentheses: "Se7en 1995"
+var searchYearPattern = regexp.MustCompile(`^(.+?)[\s.]+\(?(\d{4})\)?$`)
+
+// movieSearchQuery is a search term split into its title and an optional trailing year
+type movieSearchQuery struct {
+	full  string
+	title string
+	year  int
+}
+
+// parseMovieSearchQuery separates a trailing year from the title. Numbers that cannot be a
+// release year, as in "Blade Runner 2049", stay part of the title.
+func parseMovieSearchQuery(term string) movieSearchQuery {
+	q := movieSearchQuery{full: normalizeSearchTitle(term)}
+	q.title = q.full
+
+	m := searchYearPattern.FindStringSubmatch(strings.TrimSpace(term))
+	if m == nil {
+		return q
+	}
+	year, err := strconv.Atoi(m[2])
+	if err != nil || year < earliestMovieYear || year > time.Now().Year()+5 {
+		return q
+	}
+	if title := normalizeSearchTitle(m[1]); title != "" {
+		q.title = title
+		q.year = year
+	}
+	return q
+}
+
+// searchTitles are the titles and year of a movie considered by the ranking
+type searchTitles struct {
+	title      string
+	original   string
+	alternates []string
+	year       int
+}
+
+func movieSearchTitles(movie *models.Movie) searchTitles {
+	return searchTitles{
+		title:      movie.Title,
+		original:   movie.OriginalTitle,
+		alternates: movie.AlternateTitles,
+		year:       movie.Year,
+	}
+}
+
+func indexSearchTitles(entry *MovieIndexEntry) searchTitles {
+	return searchTitles{
+		title:      entry.Title,
+		original:   entry.OriginalTitle,
+		alternates: entry.AlternateTitles,
+		year:       entry.Year,
+	}
+}
+
+// score rates how well the movie matches the query. A query with a year is also scored as a
+// plain title, so titles that are themselves a year, such as "1917", still match.
+func (q movieSearchQuery) score(titles searchTitles) float64 {
+	best := titles.matchTitle(q.full)
+	if q.year == 0 {
+		return best
+	}
+
+	withYear := titles.matchTitle(q.title)
+	if withYear == 0 {
+		return best
+	}
+	switch diff := titles.year - q.year; {
+	case diff == 0:
+		withYear += searchYearExactBonus
+	case diff == 1 || diff == -1:
+		withYear += searchYearNearbyBonus
+	default:
+		withYear -= searchYearMismatchCost
+	}
+	return max(best, withYear)
+}
+
+// matchTitle returns the best similarity between the query title and any of the movie's titles
+func (t searchTitles) matchTitle(query string) float64 {
+	if query == "" {
+		return 0
+	}
+
+	best := max(titleSimilarity(query, normalizeSearchTitle(t.title)),
+		titleSimilarity(query, normalizeSearchTitle(t.original)))
+	for _, alternate := range t.alternates {
+		best = max(best, alternateTitleWeight*titleSimilarity(query, normalizeSearchTitle(alternate)))
+	}
+	return best
+}
+
+// titleSimilarity scores two normalized titles from 0 to 1. Exact matches score 1, titles that
+// start with or contain the query score by how much of the title the query covers, and other
+// titles score by trigram similarity or edit distance, whichever is higher.
+func titleSimilarity(query, title string) float64 {
+	if query == "" || title == "" {
+		return 0
+	}
+
+	compactQuery := strings.ReplaceAll(query, " ", "")
+	compactTitle := strings.ReplaceAll(title, " ", "")
+	if compactQuery == compactTitle {
+		return 1
+	}
+
+	coverage := float64(len(compactQuery)) / float64(len(compactTitle))
+	fuzzy := max(trigramSimilarity(query, title), levenshteinSimilarity(compactQuery, compactTitle))
+	switch {
+	case strings.HasPrefix(title, query):
+		return max(fuzzy, 0.75+0.2*coverage)
+	case strings.Contains(compactTitle, compactQuery):
+		return max(fuzzy, 0.6+0.2*coverage)
+	default:
+		return fuzzy
+	}
+}
+
+// normalizeSearchTitle lowercases a title split into words as titleWords does, writes roman
+// numerals as numbers and drops a leading "the", so "The Lord of the Rings: The Two Towers" and
+// "lord of the rings two towers" differ only by one word, and "Rocky IV" matches "Rocky 4"
+func normalizeSearchTitle(title string) string {
+	words := titleWords(strings.ToLower(title))
+	for i, word := range words {
+		if number, ok := romanNumerals[word]; ok && i > 0 {
+			words[i] = number
+		}
+	}
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// trigramSimilarity mirrors pg_trgm's similarity: the shared share of the trigrams of each word,
+// padded with two leading spaces and one trailing space
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for gram := range ta {
+		if tb[gram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	grams := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			grams[string(padded[i:i+3])] = true
+		}
+	}
+	return grams
+}
+
+// levenshteinSimilarity is one minus the edit distance relative to the longer string
+func levenshteinSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// rankMovieSearch returns the movies scoring at least minMovieSearchScore, best first. Ties
+// go to the more popular movie, then the lower ID.
+func rankMovieSearch(q movieSearchQuery, movies []models.Movie) []models.Movie {
+	type scored struct {
+		movie models.Movie
+		score float64
+	}
+
+	ranked := make([]scored, 0, len(movies))
+	for i := range movies {
+		if score := q.score(movieSearchTitles(&movies[i])); score >= minMovieSearchScore {
+			ranked = append(ranked, scored{movie: movies[i], score: score})
+		}
+	}
+
+	sort.SliceStable(ranked, func(a, b int) bool {
+		if ranked[a].score != ranked[b].score {
+			return ranked[a].score > ranked[b].score
+		}
+		if ranked[a].movie.Popularity != ranked[b].movie.Popularity {
+			return ranked[a].movie.Popularity > ranked[b].movie.Popularity
+		}
+		return ranked[a].movie.ID < ranked[b].movie.ID
+	})
+
+	results := make([]models.Movie, len(ranked))
+	for i := range ranked {
+		results[i] = ranked[i].movie
+	}
+	return results
+}
+
+// searchCandidates loads the movies worth ranking for a query. PostgreSQL with pg_trgm pre-selects
+// them with trigram indexes; otherwise every indexed movie is scored in memory.
+func (s *MovieService) searchCandidates(q movieSearchQuery) ([]models.Movie, error) {
+	if s.hasTrigramSearch() {
+		return s.trigramCandidates(q)
+	}
+
+	entries, err := s.index.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for i := range entries {
+		if q.score(indexSearchTitles(&entries[i])) >= minMovieSearchScore {
+			ids = append(ids, entries[i].ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var movies []models.Movie
+	if err := s.db.GORM.Joins("MovieFile").Where("movies.id IN ?", ids).Find(&movies).Error; err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+// trigramCandidates selects movies whose titles are trigram-similar to, or contain, the query
+func (s *MovieService) trigramCandidates(q movieSearchQuery) ([]models.Movie, error) {
+	terms := []string{q.full}
+	if q.title != q.full {
+		terms = append(terms, q.title)
+	}
+
+	db := s.db.GORM.Joins("MovieFile")
+	conditions := s.db.GORM.Where("1 = 0")
+	for _, term := range terms {
+		like := containsPattern(term)
+		conditions = conditions.
+			Or("word_similarity(?, lower(movies.title)) > 0.3", term).
+			Or("word_similarity(?, lower(movies.original_title)) > 0.3", term).
+			Or(`movies.title ILIKE ? ESCAPE '\' OR movies.original_title ILIKE ? ESCAPE '\'`, like, like).
+			Or(`movies.alternate_titles ILIKE ? ESCAPE '\'`, like)
+	}
+
+	var movies []models.Movie
+	err := db.Where(conditions).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "similarity(lower(movies.title), ?) DESC",
+			Vars:               []interface{}{q.title},
+			WithoutParentheses: true,
+		}}).
+		Limit(maxTrigramCandidates).Find(&movies).Error
+	if err != nil {
+		return nil, fmt.Errorf("trigram search failed: %w", err)
+	}
+	return movies, nil
+}
+
+// containsPattern is the LIKE pattern matching text containing the words of term in order. The
+// term's own % and _ are matched literally.
+func containsPattern(term string) string {
+	return "%" + strings.ReplaceAll(escapeLike(term), " ", "%") + "%"
+}
+
+// hasTrigramSearch reports whether the pg_trgm extension is installed, checking once
+func (s *MovieService) hasTrigramSearch() bool {
+	if s.db == nil || !s.db.IsPostgres() {
+		return false
+	}
+
+	s.trigramOnce.Do(func() {
+		var installed bool
+		if err := s.db.GORM.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')").
+			Scan(&installed).Error; err != nil {
+			s.logger.Warnw("Failed to check for pg_trgm, using in-memory title search", "error", err)
+			return
+		}
+		if !installed {
+			s.logger.Infow("pg_trgm is not installed, using in-memory title search")
+		}
+		s.trigram = installed
+	})
+	return s.trigram
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMovieSearchQuery(t *testing.T) {
+	q := parseMovieSearchQuery("Se7en 1995")
+	assert.Equal(t, "se7en", q.title)
+	assert.Equal(t, 1995, q.year)
+	assert.Equal(t, "se7en 1995", q.full)
+
+	q = parseMovieSearchQuery("The Matrix (1999)")
+	assert.Equal(t, "matrix", q.title)
+	assert.Equal(t, 1999, q.year)
+
+	q = parseMovieSearchQuery("Blade Runner 2049")
+	assert.Equal(t, "blade runner 2049", q.title, "future years stay part of the title")
+	assert.Zero(t, q.year)
+
+	q = parseMovieSearchQuery("1917")
+	assert.Equal(t, "1917", q.title, "a lone year is the title")
+	assert.Zero(t, q.year)
+}
+
+func TestNormalizeSearchTitle(t *testing.T) {
+	assert.Equal(t, "lord of the rings the two towers", normalizeSearchTitle("The Lord of the Rings: The Two Towers"))
+	assert.Equal(t, "fast and furious", normalizeSearchTitle("Fast & Furious"))
+	assert.Equal(t, "the", normalizeSearchTitle("The"))
+}
+
+func TestContainsPattern(t *testing.T) {
+	assert.Equal(t, "%dune%part%two%", containsPattern("dune part two"))
+	assert.Equal(t, `%100\%%wolf\_man%`, containsPattern("100% wolf_man"), "wildcards are matched literally")
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	assert.InDelta(t, 1, titleSimilarity("spider man", "spiderman"), 0.001)
+	assert.Greater(t, titleSimilarity("godfathr", "godfather"), 0.8, "typos still match")
+	assert.Greater(t, titleSimilarity("star wars", "star wars the empire strikes back"),
+		titleSimilarity("empire", "star wars the empire strikes back"), "prefixes outrank inner matches")
+	assert.Less(t, titleSimilarity("alien", "notting hill"), minMovieSearchScore)
+}
+
+func TestRankMovieSearch(t *testing.T) {
+	movies := []models.Movie{
+		{ID: 1, Title: "Seven Pounds", Year: 2008, Popularity: 30},
+		{ID: 2, Title: "Se7en", AlternateTitles: models.StringArray{"Seven"}, Year: 1995, Popularity: 20},
+		{ID: 3, Title: "Seven Samurai", OriginalTitle: "Shichinin no samurai", Year: 1954, Popularity: 10},
+		{ID: 4, Title: "The Intouchables", OriginalTitle: "Intouchables", Year: 2011},
+		{ID: 5, Title: "1917", Year: 2019},
+		{ID: 6, Title: "Notting Hill", Year: 1999},
+	}
+
+	results := rankMovieSearch(parseMovieSearchQuery("Se7en 1995"), movies)
+	require.NotEmpty(t, results)
+	assert.Equal(t, 2, results[0].ID)
+
+	results = rankMovieSearch(parseMovieSearchQuery("seven"), movies)
+	require.NotEmpty(t, results)
+	assert.Equal(t, 2, results[0].ID, "alternate titles take part in the ranking")
+
+	results = rankMovieSearch(parseMovieSearchQuery("shichinin"), movies)
+	require.Len(t, results, 1)
+	assert.Equal(t, 3, results[0].ID, "original titles take part in the ranking")
+
+	results = rankMovieSearch(parseMovieSearchQuery("1917"), movies)
+	require.NotEmpty(t, results)
+	assert.Equal(t, 5, results[0].ID)
+
+	results = rankMovieSearch(parseMovieSearchQuery("untouchables"), movies)
+	require.NotEmpty(t, results)
+	assert.Equal(t, 4, results[0].ID, "near misses still match")
+
+	for _, movie := range rankMovieSearch(parseMovieSearchQuery("alien"), movies) {
+		assert.NotEqual(t, 6, movie.ID)
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	assert.Equal(t, []string{"nolan", "dark"}, highlightTerms("the Dark, dark of NOLAN"))
+	assert.Empty(t, highlightTerms("a of"))
+}
+
+func TestMarkTerms(t *testing.T) {
+	snippet, ok := markTerms("Robots & <Giants>", []string{"robot", "giant"})
+	assert.True(t, ok)
+	assert.Equal(t, "<mark>Robots</mark> &amp; &lt;<mark>Giants</mark>&gt;", snippet)
+
+	// Terms only match at the start of a word
+	snippet, ok = markTerms("Carrot", []string{"rot"})
+	assert.False(t, ok)
+	assert.Equal(t, "Carrot", snippet)
+}
+
+func TestHighlightMovie(t *testing.T) {
+	movie := &models.Movie{
+		Title:    "Inception",
+		Overview: strings.Repeat("word ", 40) + "a thief who steals corporate secrets through dream-sharing " + strings.Repeat("word ", 40),
+		Cast:     models.StringArray{"Leonardo DiCaprio", "Joseph Gordon-Levitt"},
+		Crew:     models.StringArray{"Christopher Nolan (Director)", "Hans Zimmer (Original Music Composer)"},
+	}
+
+	highlights := highlightMovie(movie, highlightTerms("thief nolan"))
+	require.Len(t, highlights, 2)
+
+	assert.Equal(t, "overview", highlights[0].Field)
+	assert.Contains(t, highlights[0].Snippet, "a <mark>thief</mark> who")
+	assert.True(t, strings.HasPrefix(highlights[0].Snippet, "…"))
+	assert.True(t, strings.HasSuffix(highlights[0].Snippet, "…"))
+
+	assert.Equal(t, "crew", highlights[1].Field)
+	assert.Equal(t, "Christopher <mark>Nolan</mark> (Director)", highlights[1].Snippet)
+
+	highlights = highlightMovie(movie, highlightTerms("inception dicaprio"))
+	require.Len(t, highlights, 2)
+	assert.Equal(t, models.SearchHighlight{Field: "title", Snippet: "<mark>Inception</mark>"}, highlights[0])
+	assert.Equal(t, models.SearchHighlight{Field: "cast", Snippet: "Leonardo <mark>DiCaprio</mark>"}, highlights[1])
+}
+
+func TestMovieService_FullTextSearch_NoDatabase(t *testing.T) {
+	service := &MovieService{}
+	_, err := service.FullTextSearch("nolan", 10)
+	assert.EqualError(t, err, "database not available")
+}
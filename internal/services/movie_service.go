@@ -3,18 +3,34 @@ package services
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 	"gorm.io/gorm"
-	"gorm.io/hints"
 )
 
+// caseInsensitivePaths reports whether movie paths are compared case-insensitively,
+// matching the default filesystem behavior on Windows and macOS.
+var caseInsensitivePaths = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
 // MovieService provides operations for managing movies in the database.
 type MovieService struct {
 	db     *database.Database
 	logger *logger.Logger
+	index  *MovieIndex
+
+	// trigram records whether PostgreSQL's pg_trgm extension is available to Search
+	trigramOnce sync.Once
+	trigram     bool
+
+	// tombstonePeriod is how long deleted movies are kept from import list syncs, see UseTombstones
+	tombstonePeriod time.Duration
 }
 
 // NewMovieService creates a new instance of MovieService with the provided database and logger.
@@ -22,14 +38,20 @@ func NewMovieService(db *database.Database, logger *logger.Logger) *MovieService
 	return &MovieService{
 		db:     db,
 		logger: logger,
+		index:  NewMovieIndex(db, logger),
 	}
 }
 
-// GetAll retrieves all movies from the database with their movie files preloaded.
+// Index returns the in-memory movie index kept current by this service
+func (s *MovieService) Index() *MovieIndex {
+	return s.index
+}
+
+// GetAll retrieves all movies from the database with their movie files loaded in the same query.
 func (s *MovieService) GetAll() ([]models.Movie, error) {
 	var movies []models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").Find(&movies).Error
+	err := s.db.GORM.Joins("MovieFile").Find(&movies).Error
 	if err != nil {
 		s.logger.Error("Failed to get all movies", "error", err)
 		return nil, fmt.Errorf("failed to get movies: %w", err)
@@ -38,11 +60,43 @@ func (s *MovieService) GetAll() ([]models.Movie, error) {
 	return movies, nil
 }
 
-// GetByID retrieves a single movie by its ID with movie file preloaded.
+// GetByFilter retrieves the movies matching a custom filter, evaluating its predicates in the
+// database so large libraries are not loaded to be filtered.
+func (s *MovieService) GetByFilter(filter *models.CustomFilter) ([]models.Movie, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	query, err := applyMovieFilter(s.db.GORM.Joins("MovieFile"), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var movies []models.Movie
+	if err := query.Find(&movies).Error; err != nil {
+		s.logger.Error("Failed to get filtered movies", "filterId", filter.ID, "error", err)
+		return nil, fmt.Errorf("failed to get movies: %w", err)
+	}
+
+	return movies, nil
+}
+
+// RecordSearch records when releases were last searched for a movie. The movie's update time is
+// left alone, as searching doesn't change the movie.
+func (s *MovieService) RecordSearch(movieID int, searchedAt time.Time) error {
+	err := s.db.GORM.Model(&models.Movie{}).Where("id = ?", movieID).
+		UpdateColumn("last_search_time", searchedAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to record search for movie %d: %w", movieID, err)
+	}
+	return nil
+}
+
+// GetByID retrieves a single movie by its ID with its movie file joined.
 func (s *MovieService) GetByID(id int) (*models.Movie, error) {
 	var movie models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").Where("id = ?", id).First(&movie).Error
+	err := s.db.GORM.Joins("MovieFile").Where("movies.id = ?", id).First(&movie).Error
 	if err != nil {
 		s.logger.Error("Failed to get movie by ID", "id", id, "error", err)
 		return nil, fmt.Errorf("failed to get movie: %w", err)
@@ -51,71 +105,216 @@ func (s *MovieService) GetByID(id int) (*models.Movie, error) {
 	return &movie, nil
 }
 
-// GetByTmdbID retrieves a movie by its TMDB ID with movie file preloaded.
+// GetByTmdbID retrieves a movie by its TMDB ID with its movie file joined. Movies in the movie
+// index are fetched by ID; others are looked up in the database, as the index may not have
+// caught up with movies added since it was loaded.
 func (s *MovieService) GetByTmdbID(tmdbID int) (*models.Movie, error) {
+	if entry, found, err := s.index.ByTmdbID(tmdbID); err == nil && found {
+		return s.GetByID(entry.ID)
+	}
+
 	var movie models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").Where("tmdb_id = ?", tmdbID).First(&movie).Error
+	err := s.db.GORM.Joins("MovieFile").Where("movies.tmdb_id = ?", tmdbID).First(&movie).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get movie by TMDB ID: %w", err)
 	}
 
+	s.index.Put(&movie)
 	return &movie, nil
 }
 
 // Create creates a new movie in the database.
 func (s *MovieService) Create(movie *models.Movie) error {
+	if err := s.ValidatePath(movie); err != nil {
+		return err
+	}
+
 	err := s.db.GORM.Create(movie).Error
 	if err != nil {
 		s.logger.Error("Failed to create movie", "title", movie.Title, "error", err)
 		return fmt.Errorf("failed to create movie: %w", err)
 	}
 
+	s.index.Put(movie)
 	s.logger.Info("Created movie", "id", movie.ID, "title", movie.Title)
 	return nil
 }
 
 // Update saves changes to an existing movie in the database.
 func (s *MovieService) Update(movie *models.Movie) error {
-	err := s.db.GORM.Save(movie).Error
+	return s.UpdateIfUnchanged(movie, time.Time{})
+}
+
+// UpdateIfUnchanged saves changes to an existing movie unless it was updated after read, the
+// updated time of the copy the changes were made to, returning a models.ConflictError if it was.
+// A zero read saves unconditionally, like Update.
+func (s *MovieService) UpdateIfUnchanged(movie *models.Movie, read time.Time) error {
+	if err := s.ValidatePath(movie); err != nil {
+		return err
+	}
+
+	err := saveUnlessStale(s.db.GORM, movie, movie.ID, read, "movie")
+	if isConflict(err) {
+		return err
+	}
 	if err != nil {
 		s.logger.Error("Failed to update movie", "id", movie.ID, "error", err)
 		return fmt.Errorf("failed to update movie: %w", err)
 	}
 
+	s.index.Put(movie)
 	s.logger.Info("Updated movie", "id", movie.ID, "title", movie.Title)
 	return nil
 }
 
-// Delete removes a movie from the database by its ID.
+// Delete removes a movie from the database by its ID, leaving a tombstone that keeps import
+// lists from adding it back.
 func (s *MovieService) Delete(id int) error {
-	err := s.db.GORM.Delete(&models.Movie{}, id).Error
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		var movie models.Movie
+		if err := tx.First(&movie, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := tx.Delete(&movie).Error; err != nil {
+			return err
+		}
+		return s.addTombstone(tx, &movie)
+	})
 	if err != nil {
 		s.logger.Error("Failed to delete movie", "id", id, "error", err)
 		return fmt.Errorf("failed to delete movie: %w", err)
 	}
 
+	s.index.Remove(id)
 	s.logger.Info("Deleted movie", "id", id)
 	return nil
 }
 
-// Search finds movies by searching title, original title, and clean title fields.
-func (s *MovieService) Search(query string) ([]models.Movie, error) {
-	var movies []models.Movie
+// BulkEdit applies an editor change to each movie, reporting per-movie progress to the tracker
+func (s *MovieService) BulkEdit(editor *models.MovieEditor, tracker *ActivityTracker) ([]models.Movie, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	updated := make([]models.Movie, 0, len(editor.MovieIDs))
+	failed := 0
+
+	for _, id := range editor.MovieIDs {
+		movie, err := s.GetByID(id)
+		if err == nil {
+			editor.Apply(movie)
+			err = s.Update(movie)
+		}
+		if err != nil {
+			failed++
+			tracker.Advance(fmt.Errorf("movie %d: %w", id, err))
+			continue
+		}
+
+		updated = append(updated, *movie)
+		tracker.Advance(nil)
+	}
+
+	s.logger.Info("Completed movie editor changes", "updated", len(updated), "failed", failed)
+
+	if failed > 0 {
+		return updated, fmt.Errorf("failed to update %d out of %d movies", failed, len(editor.MovieIDs))
+	}
+	return updated, nil
+}
+
+// ValidatePath ensures the movie's folder is not already used by another movie.
+// It returns a models.ValidationError when the path collides.
+func (s *MovieService) ValidatePath(movie *models.Movie) error {
+	path := moviePath(movie)
+	if path == "" {
+		return nil
+	}
 
-	searchQuery := "%" + query + "%"
-	err := s.db.GORM.
-		Clauses(hints.UseIndex("idx_movie_search")).
-		Preload("MovieFile").
-		Where("title LIKE ? OR original_title LIKE ? OR clean_title LIKE ?",
-			searchQuery, searchQuery, searchQuery,
-		).Find(&movies).Error
+	// Match the stored path with or without a trailing separator
+	candidates := []string{path, path + string(filepath.Separator)}
+	query := s.db.GORM.Model(&models.Movie{}).Select("id", "title", "path").Where("id <> ?", movie.ID)
+	if caseInsensitivePaths {
+		query = query.Where("LOWER(path) IN ?", []string{strings.ToLower(candidates[0]), strings.ToLower(candidates[1])})
+	} else {
+		query = query.Where("path IN ?", candidates)
+	}
 
+	var existing models.Movie
+	err := query.First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check movie path: %w", err)
+	}
+
+	s.logger.Warn("Rejected movie path collision", "title", movie.Title, "path", path,
+		"existingId", existing.ID, "existingTitle", existing.Title)
+	return models.ValidationError{
+		Field:   "path",
+		Message: fmt.Sprintf("path %s is already used by movie %q (id %d)", path, existing.Title, existing.ID),
+	}
+}
+
+// moviePath returns the movie's folder, computing it from the root folder and folder name when unset
+func moviePath(movie *models.Movie) string {
+	if movie.Path != "" {
+		return filepath.Clean(movie.Path)
+	}
+	if movie.RootFolderPath != "" && movie.FolderName != "" {
+		return filepath.Join(movie.RootFolderPath, movie.FolderName)
+	}
+	return ""
+}
+
+// Search finds movies whose title, original title, or an alternate title resembles the query,
+// best match first. Matching tolerates typos and punctuation, and a trailing year such as
+// "Se7en 1995" favors movies released that year.
+func (s *MovieService) Search(query string) ([]models.Movie, error) {
+	q := parseMovieSearchQuery(query)
+	if q.full == "" {
+		return []models.Movie{}, nil
+	}
+
+	candidates, err := s.searchCandidates(q)
 	if err != nil {
 		s.logger.Error("Failed to search movies", "query", query, "error", err)
 		return nil, fmt.Errorf("failed to search movies: %w", err)
 	}
 
+	return rankMovieSearch(q, candidates), nil
+}
+
+// FindByTitle returns the movies matching a parsed release or file title, using the movie
+// index to pick candidates so only matching movies are loaded from the database.
+// A year of zero matches any year.
+func (s *MovieService) FindByTitle(title string, year int) ([]models.Movie, error) {
+	entries, err := s.index.MatchTitle(title, year)
+	if err != nil {
+		s.logger.Warn("Movie index unavailable, falling back to database search", "error", err)
+		return s.Search(title)
+	}
+	if len(entries) == 0 {
+		return []models.Movie{}, nil
+	}
+
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	var movies []models.Movie
+	if err := s.db.GORM.Joins("MovieFile").Where("movies.id IN ?", ids).Order("movies.id").
+		Find(&movies).Error; err != nil {
+		s.logger.Error("Failed to get movies by title", "title", title, "error", err)
+		return nil, fmt.Errorf("failed to get movies by title: %w", err)
+	}
+
 	return movies, nil
 }
 
@@ -123,7 +322,7 @@ func (s *MovieService) Search(query string) ([]models.Movie, error) {
 func (s *MovieService) GetMonitored() ([]models.Movie, error) {
 	var movies []models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").Where("monitored = ?", true).Find(&movies).Error
+	err := s.db.GORM.Joins("MovieFile").Where("movies.monitored = ?", true).Find(&movies).Error
 	if err != nil {
 		s.logger.Error("Failed to get monitored movies", "error", err)
 		return nil, fmt.Errorf("failed to get monitored movies: %w", err)
@@ -136,7 +335,7 @@ func (s *MovieService) GetMonitored() ([]models.Movie, error) {
 func (s *MovieService) GetUnmonitored() ([]models.Movie, error) {
 	var movies []models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").Where("monitored = ?", false).Find(&movies).Error
+	err := s.db.GORM.Joins("MovieFile").Where("movies.monitored = ?", false).Find(&movies).Error
 	if err != nil {
 		s.logger.Error("Failed to get unmonitored movies", "error", err)
 		return nil, fmt.Errorf("failed to get unmonitored movies: %w", err)
@@ -158,11 +357,11 @@ func (s *MovieService) GetMoviesWithoutFiles() ([]models.Movie, error) {
 	return movies, nil
 }
 
-// GetMoviesWithFiles retrieves all movies that have associated files with movie files preloaded.
+// GetMoviesWithFiles retrieves all movies that have associated files with movie files joined.
 func (s *MovieService) GetMoviesWithFiles() ([]models.Movie, error) {
 	var movies []models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").Where("has_file = ?", true).Find(&movies).Error
+	err := s.db.GORM.Joins("MovieFile").Where("movies.has_file = ?", true).Find(&movies).Error
 	if err != nil {
 		s.logger.Error("Failed to get movies with files", "error", err)
 		return nil, fmt.Errorf("failed to get movies with files: %w", err)
@@ -173,7 +372,11 @@ func (s *MovieService) GetMoviesWithFiles() ([]models.Movie, error) {
 
 // CreateWithFile creates a new movie and its associated file in a transaction
 func (s *MovieService) CreateWithFile(movie *models.Movie, file *models.MovieFile) error {
-	return s.db.GORM.Transaction(func(tx *gorm.DB) error {
+	if err := s.ValidatePath(movie); err != nil {
+		return err
+	}
+
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(movie).Error; err != nil {
 			s.logger.Error("Failed to create movie in transaction", "title", movie.Title, "error", err)
 			return fmt.Errorf("failed to create movie: %w", err)
@@ -186,6 +389,10 @@ func (s *MovieService) CreateWithFile(movie *models.Movie, file *models.MovieFil
 		s.logger.Info("Created movie with file in transaction", "id", movie.ID, "title", movie.Title)
 		return nil
 	})
+	if err == nil {
+		s.index.Put(movie)
+	}
+	return err
 }
 
 // handleMovieFileInTransaction handles movie file operations within a transaction
@@ -226,7 +433,11 @@ func (s *MovieService) handleMovieFileInTransaction(
 
 // UpdateWithFile updates a movie and creates/updates its associated file in a transaction
 func (s *MovieService) UpdateWithFile(movie *models.Movie, file *models.MovieFile) error {
-	return s.db.GORM.Transaction(func(tx *gorm.DB) error {
+	if err := s.ValidatePath(movie); err != nil {
+		return err
+	}
+
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Save(movie).Error; err != nil {
 			s.logger.Error("Failed to update movie in transaction", "id", movie.ID, "error", err)
 			return fmt.Errorf("failed to update movie: %w", err)
@@ -239,11 +450,15 @@ func (s *MovieService) UpdateWithFile(movie *models.Movie, file *models.MovieFil
 		s.logger.Info("Updated movie with file in transaction", "id", movie.ID, "title", movie.Title)
 		return nil
 	})
+	if err == nil {
+		s.index.Put(movie)
+	}
+	return err
 }
 
 // DeleteWithFile removes a movie and its associated file in a transaction
 func (s *MovieService) DeleteWithFile(id int) error {
-	return s.db.GORM.Transaction(func(tx *gorm.DB) error {
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
 		var movie models.Movie
 		if err := tx.First(&movie, id).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -264,10 +479,17 @@ func (s *MovieService) DeleteWithFile(id int) error {
 			s.logger.Error("Failed to delete movie in transaction", "id", id, "error", err)
 			return fmt.Errorf("failed to delete movie: %w", err)
 		}
+		if err := s.addTombstone(tx, &movie); err != nil {
+			return err
+		}
 
 		s.logger.Info("Deleted movie with file in transaction", "id", id, "title", movie.Title)
 		return nil
 	})
+	if err == nil {
+		s.index.Remove(id)
+	}
+	return err
 }
 
 // GetTotalCount returns the total number of movies in the database
@@ -340,8 +562,8 @@ func (s *MovieService) RemoveFromCollection(movieID int) error {
 func (s *MovieService) GetMoviesByCollection(collectionTmdbID int) ([]models.Movie, error) {
 	var movies []models.Movie
 
-	err := s.db.GORM.Preload("MovieFile").
-		Where("collection_tmdb_id = ?", collectionTmdbID).
+	err := s.db.GORM.Joins("MovieFile").
+		Where("movies.collection_tmdb_id = ?", collectionTmdbID).
 		Find(&movies).Error
 
 	if err != nil {
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
@@ -191,3 +193,91 @@ func BenchmarkMovieService_GetByTmdbID(b *testing.B) {
 			}
 		})
 }
+
+// BenchmarkMovieService_ListLargeLibrary compares preloading movie files with the joined
+// query used by GetAll on a 20k-movie library where half of the movies have a file
+func BenchmarkMovieService_ListLargeLibrary(b *testing.B) {
+	testhelpers.SkipInShortMode(b)
+	testhelpers.RequireAnyDatabase(b)
+
+	const libraryMovies = 20000
+
+	testhelpers.RunBenchmarkWithTestDatabase(b, testhelpers.GetTestDatabaseType(),
+		func(b *testing.B, db *database.Database, log *logger.Logger) {
+			factory := testhelpers.NewTestDataFactory(db.GORM)
+			defer factory.Cleanup()
+
+			profile := factory.CreateQualityProfile()
+			seedLargeLibrary(b, db, profile.ID, libraryMovies)
+
+			movieService := NewMovieService(db, log)
+
+			b.Run("Preload", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					var movies []models.Movie
+					if err := db.GORM.Preload("MovieFile").Find(&movies).Error; err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			b.Run("Joins", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := movieService.GetAll(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+}
+
+// seedLargeLibrary bulk inserts count movies and attaches a file to every other movie
+func seedLargeLibrary(b *testing.B, db *database.Database, profileID, count int) {
+	b.Helper()
+
+	movies := make([]models.Movie, 0, count)
+	for i := 0; i < count; i++ {
+		slug := fmt.Sprintf("library-movie-%d", i)
+		movies = append(movies, models.Movie{
+			TmdbID:              100000 + i,
+			Title:               fmt.Sprintf("Library Movie %d", i),
+			TitleSlug:           slug,
+			Year:                1950 + i%75,
+			Status:              models.MovieStatusReleased,
+			Monitored:           i%3 != 0,
+			MinimumAvailability: models.AvailabilityReleased,
+			QualityProfileID:    profileID,
+			Path:                "/movies/" + slug,
+			RootFolderPath:      "/movies",
+			FolderName:          slug,
+			Genres:              models.StringArray{"Drama"},
+			Tags:                models.IntArray{},
+			Images:              models.MediaCover{},
+		})
+	}
+	if err := db.GORM.CreateInBatches(movies, 500).Error; err != nil {
+		b.Fatalf("failed to seed movies: %v", err)
+	}
+
+	files := make([]models.MovieFile, 0, count/2)
+	for i := 0; i < len(movies); i += 2 {
+		files = append(files, models.MovieFile{
+			MovieID:      movies[i].ID,
+			RelativePath: movies[i].TitleSlug + ".mkv",
+			Path:         movies[i].Path + "/" + movies[i].TitleSlug + ".mkv",
+			Size:         4 * 1024 * 1024 * 1024,
+			DateAdded:    time.Now(),
+		})
+	}
+	if err := db.GORM.CreateInBatches(files, 500).Error; err != nil {
+		b.Fatalf("failed to seed movie files: %v", err)
+	}
+
+	if err := db.GORM.Exec(
+		"UPDATE movies SET has_file = ?, movie_file_id = "+
+			"(SELECT movie_files.id FROM movie_files WHERE movie_files.movie_id = movies.id) "+
+			"WHERE EXISTS (SELECT 1 FROM movie_files WHERE movie_files.movie_id = movies.id)", true,
+	).Error; err != nil {
+		b.Fatalf("failed to link movie files: %v", err)
+	}
+}
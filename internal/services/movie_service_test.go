@@ -0,0 +1,146 @@
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoviePath(t *testing.T) {
+	assert.Equal(t, filepath.Clean("/movies/Movie (2020)"), moviePath(&models.Movie{Path: "/movies/Movie (2020)/"}))
+	assert.Equal(t, filepath.Join("/movies", "Movie (2020)"),
+		moviePath(&models.Movie{RootFolderPath: "/movies", FolderName: "Movie (2020)"}))
+	assert.Empty(t, moviePath(&models.Movie{RootFolderPath: "/movies"}))
+}
+
+func TestMovieEditor_Apply(t *testing.T) {
+	monitored := true
+	profileID := 4
+	availability := models.AvailabilityReleased
+
+	movie := &models.Movie{Tags: models.IntArray{1, 2}}
+	editor := &models.MovieEditor{
+		Monitored:           &monitored,
+		QualityProfileID:    &profileID,
+		MinimumAvailability: &availability,
+		Tags:                []int{2, 3},
+	}
+
+	editor.Apply(movie)
+	assert.True(t, movie.Monitored)
+	assert.Equal(t, 4, movie.QualityProfileID)
+	assert.Equal(t, models.AvailabilityReleased, movie.MinimumAvailability)
+	assert.Equal(t, models.IntArray{1, 2, 3}, movie.Tags)
+
+	editor.ApplyTags = models.MovieEditorTagsRemove
+	editor.Apply(movie)
+	assert.Equal(t, models.IntArray{1}, movie.Tags)
+
+	editor.ApplyTags = models.MovieEditorTagsReplace
+	editor.Apply(movie)
+	assert.Equal(t, models.IntArray{2, 3}, movie.Tags)
+}
+
+func TestMovieService_ValidatePath(t *testing.T) {
+	db, log := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewMovieService(db, log)
+
+	existing := &models.Movie{
+		Title:     "Existing Movie",
+		TmdbID:    9001,
+		TitleSlug: "existing-movie-9001",
+		Path:      "/movies/Shared Folder",
+	}
+	require.NoError(t, service.Create(existing))
+
+	duplicate := &models.Movie{
+		Title:     "Duplicate Movie",
+		TmdbID:    9002,
+		TitleSlug: "duplicate-movie-9002",
+		Path:      "/movies/Shared Folder/",
+	}
+	err := service.Create(duplicate)
+	require.Error(t, err)
+
+	var validationErr models.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "path", validationErr.Field)
+
+	// Updating a movie without changing its path must not collide with itself
+	existing.Monitored = true
+	assert.NoError(t, service.Update(existing))
+
+	original := caseInsensitivePaths
+	defer func() { caseInsensitivePaths = original }()
+
+	caseInsensitivePaths = true
+	duplicate.Path = "/MOVIES/shared folder"
+	assert.Error(t, service.ValidatePath(duplicate))
+
+	caseInsensitivePaths = false
+	assert.NoError(t, service.ValidatePath(duplicate))
+}
+
+func TestMovieService_ImportMovies(t *testing.T) {
+	db, log := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewMovieService(db, log)
+
+	existing := &models.Movie{
+		Title:     "Existing Movie",
+		TmdbID:    9101,
+		TitleSlug: "existing-movie-9101",
+		Path:      "/movies/Existing Movie",
+	}
+	require.NoError(t, service.Create(existing))
+
+	movies := []models.Movie{
+		{Title: "New Movie", TmdbID: 9102, TitleSlug: "new-movie-9102", Path: "/movies/New Movie"},
+		{Title: "", TmdbID: 9103, TitleSlug: "untitled-9103"},
+		{Title: "Existing Movie", TmdbID: 9101, TitleSlug: "existing-movie-9101"},
+		{Title: "New Movie Again", TmdbID: 9102, TitleSlug: "new-movie-again-9102"},
+		{Title: "Path Clash", TmdbID: 9104, TitleSlug: "path-clash-9104", Path: "/movies/Existing Movie/"},
+		{Title: "Another Movie", TmdbID: 9105, TitleSlug: "another-movie-9105"},
+	}
+
+	results, err := service.ImportMovies(movies)
+	require.NoError(t, err)
+	require.Len(t, results, len(movies))
+
+	require.NotNil(t, results[0].Movie)
+	assert.NotZero(t, results[0].Movie.ID)
+	assert.Equal(t, "title", results[1].Field)
+	assert.Equal(t, "tmdbId", results[2].Field)
+	assert.Equal(t, "tmdbId", results[3].Field)
+	assert.Equal(t, "path", results[4].Field)
+	require.NotNil(t, results[5].Movie)
+	assert.Empty(t, results[5].Error)
+
+	var count int64
+	require.NoError(t, db.GORM.Model(&models.Movie{}).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestMovieService_GetByTmdbID_IndexMiss(t *testing.T) {
+	db, log := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewMovieService(db, log)
+	_, err := service.GetByTmdbID(9201)
+	require.Error(t, err)
+
+	// Added behind the loaded index's back, as other services' raw writes are
+	movie := &models.Movie{Title: "Unindexed Movie", TmdbID: 9202, TitleSlug: "unindexed-movie-9202"}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	found, err := service.GetByTmdbID(9202)
+	require.NoError(t, err)
+	assert.Equal(t, movie.ID, found.ID)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultTombstonePeriod is how long a deleted movie is kept from being re-added by list syncs
+const defaultTombstonePeriod = 30 * 24 * time.Hour
+
+// parseTombstonePeriod returns the configured tombstone period, where zero disables tombstones.
+// An invalid period returns the default along with the error.
+func parseTombstonePeriod(cfg config.ImportListConfig) (time.Duration, error) {
+	if cfg.TombstonePeriod == "" {
+		return defaultTombstonePeriod, nil
+	}
+	period, err := time.ParseDuration(cfg.TombstonePeriod)
+	if err != nil || period < 0 {
+		return defaultTombstonePeriod, fmt.Errorf("invalid import_lists.tombstone_period %q", cfg.TombstonePeriod)
+	}
+	return period, nil
+}
+
+// UseTombstones makes deleting a movie leave a tombstone for period, keeping import list syncs
+// from adding the movie back until it expires. A zero period disables tombstones.
+func (s *MovieService) UseTombstones(period time.Duration) {
+	s.tombstonePeriod = period
+}
+
+// addTombstone records the deletion of movie unless tombstones are disabled or the movie is
+// already on the import list exclusion list
+func (s *MovieService) addTombstone(tx *gorm.DB, movie *models.Movie) error {
+	if s.tombstonePeriod <= 0 || movie.TmdbID == 0 {
+		return nil
+	}
+
+	var excluded int64
+	if err := tx.Model(&models.ImportListExclusion{}).Where("tmdb_id = ?", movie.TmdbID).
+		Count(&excluded).Error; err != nil {
+		return fmt.Errorf("failed to check import list exclusions: %w", err)
+	}
+	if excluded > 0 {
+		return nil
+	}
+
+	now := time.Now()
+	tombstone := &models.MovieTombstone{
+		TmdbID:     movie.TmdbID,
+		MovieTitle: movie.Title,
+		MovieYear:  movie.Year,
+		ImdbID:     movie.ImdbID,
+		DeletedAt:  now,
+		ExpiresAt:  now.Add(s.tombstonePeriod),
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"movie_title", "movie_year", "imdb_id", "deleted_at", "expires_at"}),
+	}).Create(tombstone).Error; err != nil {
+		return fmt.Errorf("failed to add movie tombstone: %w", err)
+	}
+	return nil
+}
+
+// GetTombstones returns the tombstones that haven't expired, most recently deleted first,
+// removing expired ones
+func (s *ImportListService) GetTombstones() ([]models.MovieTombstone, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	if err := s.db.GORM.Where("expires_at <= ?", time.Now()).Delete(&models.MovieTombstone{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to remove expired tombstones: %w", err)
+	}
+
+	var tombstones []models.MovieTombstone
+	if err := s.db.GORM.Order("deleted_at DESC").Find(&tombstones).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tombstones: %w", err)
+	}
+	return tombstones, nil
+}
+
+// DeleteTombstone clears a tombstone, letting list syncs add its movie again
+func (s *ImportListService) DeleteTombstone(id int) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	result := s.db.GORM.Delete(&models.MovieTombstone{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete tombstone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("tombstone not found")
+	}
+
+	s.logger.Info("Cleared movie tombstone", "id", id)
+	return nil
+}
+
+// ClearTombstones removes all tombstones and returns how many were removed
+func (s *ImportListService) ClearTombstones() (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	result := s.db.GORM.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.MovieTombstone{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clear tombstones: %w", result.Error)
+	}
+
+	s.logger.Info("Cleared movie tombstones", "count", result.RowsAffected)
+	return result.RowsAffected, nil
+}
+
+// isMovieTombstoned checks if a movie was deleted recently enough that its tombstone still lasts
+func (s *ImportListService) isMovieTombstoned(tmdbID int) (bool, error) {
+	if s.db == nil {
+		return false, nil
+	}
+
+	var count int64
+	if err := s.db.GORM.Model(&models.MovieTombstone{}).
+		Where("tmdb_id = ? AND expires_at > ?", tmdbID, time.Now()).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check movie tombstones: %w", err)
+	}
+	return count > 0, nil
+}
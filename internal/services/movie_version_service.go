@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// MovieVersionService manages the additional quality versions kept of a movie, such as a 2160p
+// copy alongside the movie's 1080p file
+type MovieVersionService struct {
+	db             *database.Database
+	logger         *logger.Logger
+	qualityService *QualityService
+}
+
+// NewMovieVersionService creates a new instance of MovieVersionService
+func NewMovieVersionService(
+	db *database.Database, logger *logger.Logger, qualityService *QualityService,
+) *MovieVersionService {
+	return &MovieVersionService{
+		db:             db,
+		logger:         logger,
+		qualityService: qualityService,
+	}
+}
+
+// GetByMovieID retrieves the versions of a movie with their files, in the order they were added
+func (s *MovieVersionService) GetByMovieID(movieID int) ([]models.MovieVersion, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var versions []models.MovieVersion
+	if err := s.db.GORM.Preload("MovieFile").Where("movie_id = ?", movieID).
+		Order("id").Find(&versions).Error; err != nil {
+		s.logger.Error("Failed to fetch movie versions", "movieId", movieID, "error", err)
+		return nil, fmt.Errorf("failed to fetch movie versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetByID retrieves a movie version by its ID
+func (s *MovieVersionService) GetByID(id int) (*models.MovieVersion, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var version models.MovieVersion
+	if err := s.db.GORM.Preload("MovieFile").Where("id = ?", id).First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("movie version with id %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to fetch movie version with id %d: %w", id, err)
+	}
+
+	return &version, nil
+}
+
+// Create validates and saves a new version of a movie
+func (s *MovieVersionService) Create(version *models.MovieVersion) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := s.validate(version); err != nil {
+		return err
+	}
+
+	// A new version starts without a file, it's found by the wanted refresh and searched for
+	version.HasFile = false
+	version.MovieFileID = nil
+	version.MovieFile = nil
+	if err := s.db.GORM.Create(version).Error; err != nil {
+		s.logger.Error("Failed to create movie version", "movieId", version.MovieID, "error", err)
+		return fmt.Errorf("failed to create movie version: %w", err)
+	}
+
+	s.logger.Info("Created movie version", "id", version.ID, "movieId", version.MovieID,
+		"name", version.Name, "qualityProfileId", version.QualityProfileID)
+	return nil
+}
+
+// Update validates and saves changes to a version's name, quality profile, folder and monitoring.
+// The version's file is managed by imports and cannot be changed here.
+func (s *MovieVersionService) Update(version *models.MovieVersion) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	existing, err := s.GetByID(version.ID)
+	if err != nil {
+		return err
+	}
+	version.MovieID = existing.MovieID
+	if err := s.validate(version); err != nil {
+		return err
+	}
+
+	version.HasFile = existing.HasFile
+	version.MovieFileID = existing.MovieFileID
+	version.MovieFile = nil
+	version.CreatedAt = existing.CreatedAt
+	if err := s.db.GORM.Save(version).Error; err != nil {
+		s.logger.Error("Failed to update movie version", "id", version.ID, "error", err)
+		return fmt.Errorf("failed to update movie version: %w", err)
+	}
+	version.MovieFile = existing.MovieFile
+
+	s.logger.Info("Updated movie version", "id", version.ID, "name", version.Name)
+	return nil
+}
+
+// Delete removes a movie version and its wanted row. The version's file is left on disk and in
+// the library.
+func (s *MovieVersionService) Delete(id int) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.MovieVersion{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete movie version: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("movie version with id %d not found", id)
+		}
+
+		if err := tx.Where("version_id = ?", id).Delete(&models.WantedMovie{}).Error; err != nil {
+			return fmt.Errorf("failed to remove movie version from wanted: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to delete movie version", "id", id, "error", err)
+		return err
+	}
+
+	s.logger.Info("Deleted movie version", "id", id)
+	return nil
+}
+
+// MatchVersion returns the version of movie a file of the given quality belongs to: the first
+// monitored version whose quality profile allows the quality, or nil for the movie's primary file
+func (s *MovieVersionService) MatchVersion(movie *models.Movie, qualityID int) (*models.MovieVersion, error) {
+	if movie == nil || qualityID <= 0 {
+		return nil, nil
+	}
+
+	versions, err := s.GetByMovieID(movie.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range versions {
+		if !versions[i].Monitored {
+			continue
+		}
+		profile, err := s.qualityService.GetQualityProfileByID(versions[i].QualityProfileID)
+		if err != nil {
+			s.logger.Warn("Failed to get quality profile of movie version", "versionId", versions[i].ID,
+				"qualityProfileId", versions[i].QualityProfileID, "error", err)
+			continue
+		}
+		if profileAllowsQuality(profile, qualityID) {
+			return &versions[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// SetFile records movieFile as the current file of a version
+func (s *MovieVersionService) SetFile(version *models.MovieVersion, movieFile *models.MovieFile) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	version.HasFile = true
+	version.MovieFileID = &movieFile.ID
+	if err := s.db.GORM.Model(&models.MovieVersion{}).Where("id = ?", version.ID).
+		Updates(map[string]any{"has_file": true, "movie_file_id": movieFile.ID}).Error; err != nil {
+		return fmt.Errorf("failed to update movie version file: %w", err)
+	}
+
+	return nil
+}
+
+// validate checks a version's fields and that its movie exists and has no other version by the
+// same name
+func (s *MovieVersionService) validate(version *models.MovieVersion) error {
+	if err := version.Validate(); err != nil {
+		return err
+	}
+
+	var movies int64
+	if err := s.db.GORM.Model(&models.Movie{}).Where("id = ?", version.MovieID).Count(&movies).Error; err != nil {
+		return fmt.Errorf("failed to check movie: %w", err)
+	}
+	if movies == 0 {
+		return fmt.Errorf("movie with id %d not found", version.MovieID)
+	}
+
+	var duplicates int64
+	if err := s.db.GORM.Model(&models.MovieVersion{}).
+		Where("movie_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", version.MovieID, version.Name, version.ID).
+		Count(&duplicates).Error; err != nil {
+		return fmt.Errorf("failed to check movie version names: %w", err)
+	}
+	if duplicates > 0 {
+		return models.ValidationError{Field: "name", Message: "The movie already has a version with this name"}
+	}
+
+	return nil
+}
+
+// profileAllowsQuality reports whether a quality profile allows the quality with the given ID
+func profileAllowsQuality(profile *models.QualityProfile, qualityID int) bool {
+	for _, quality := range profile.GetAllowedQualities() {
+		if quality.ID == qualityID {
+			return true
+		}
+	}
+	return false
+}
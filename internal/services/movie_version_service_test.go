@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieVersion_Validate(t *testing.T) {
+	var validationErr models.ValidationError
+
+	version := &models.MovieVersion{MovieID: 1, Name: " 4K ", QualityProfileID: 2, Path: "/movies-4k/Dune (2021)"}
+	require.NoError(t, version.Validate())
+	assert.Equal(t, "4K", version.Name)
+
+	version.Name = " "
+	require.True(t, errors.As(version.Validate(), &validationErr))
+	assert.Equal(t, "name", validationErr.Field)
+
+	version.Name, version.QualityProfileID = "4K", 0
+	require.True(t, errors.As(version.Validate(), &validationErr))
+	assert.Equal(t, "qualityProfileId", validationErr.Field)
+
+	version.QualityProfileID, version.Path = 2, "movies-4k"
+	require.True(t, errors.As(version.Validate(), &validationErr))
+	assert.Equal(t, "path", validationErr.Field)
+}
+
+func TestProfileAllowsQuality(t *testing.T) {
+	profile := &models.QualityProfile{Items: models.QualityProfileItems{
+		{Quality: &models.QualityLevel{ID: 6, Title: "Bluray-1080p"}, Allowed: true},
+		{Quality: &models.QualityLevel{ID: 7, Title: "Bluray-2160p"}, Allowed: false},
+	}}
+
+	assert.True(t, profileAllowsQuality(profile, 6))
+	assert.False(t, profileAllowsQuality(profile, 7))
+	assert.False(t, profileAllowsQuality(profile, 4))
+}
+
+func TestVersionFiles(t *testing.T) {
+	files := []models.MovieFile{{ID: 1}, {ID: 2, VersionID: 5}, {ID: 3, VersionID: 6}}
+
+	assert.Equal(t, []models.MovieFile{{ID: 1}}, versionFiles(files, nil))
+	assert.Equal(t, []models.MovieFile{{ID: 2, VersionID: 5}}, versionFiles(files, &models.MovieVersion{ID: 5}))
+	assert.Empty(t, versionFiles(files, &models.MovieVersion{ID: 7}))
+}
+
+func TestNamingService_BuildVersionFilePath(t *testing.T) {
+	service := &NamingService{
+		logger: logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stdout"}),
+	}
+	movie := &models.Movie{Title: "Dune", Year: 2021}
+	version := &models.MovieVersion{ID: 3, Name: "4K", Path: "/movies-4k/Dune (2021)"}
+	config := &models.NamingConfig{StandardMovieFormat: "{Movie Title} ({Release Year}) {Movie Version}"}
+
+	path, err := service.BuildVersionFilePath(movie, version, nil, nil, "", config)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/movies-4k/Dune (2021)", "Dune (2021) 4K.mkv"), path)
+
+	// The primary file keeps the movie folder and leaves the token empty
+	path, err = service.BuildVersionFilePath(movie, nil, nil, nil, "", config)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/movies", "Dune (2021)", "Dune (2021).mkv"), path)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// previewThumbnailTimeout bounds how long ffmpeg may take to grab a thumbnail
+	previewThumbnailTimeout = 30 * time.Second
+	// previewThumbnailWidth is the width thumbnails are scaled to, keeping the aspect ratio
+	previewThumbnailWidth = 640
+)
+
+// OpenForPreview opens a movie file for streaming. The caller closes the file.
+func (s *MovieFileService) OpenForPreview(id int) (*os.File, os.FileInfo, error) {
+	movieFile, err := s.previewFile(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(movieFile.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, fmt.Errorf("movie file not found on disk: %s", movieFile.Path)
+		}
+		return nil, nil, fmt.Errorf("failed to open movie file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close() //nolint:errcheck // the stat error is the one reported
+		return nil, nil, fmt.Errorf("failed to stat movie file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		_ = file.Close() //nolint:errcheck // nothing was read from it
+		return nil, nil, fmt.Errorf("movie file is not a regular file: %s", movieFile.Path)
+	}
+	return file, info, nil
+}
+
+// Thumbnail grabs a JPEG frame from a movie file with ffmpeg, offset into the file. A file
+// shorter than offset gets its first frame instead.
+func (s *MovieFileService) Thumbnail(
+	ctx context.Context, id int, offset time.Duration, ffmpegPath string,
+) ([]byte, error) {
+	movieFile, err := s.previewFile(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(movieFile.Path); err != nil {
+		return nil, fmt.Errorf("movie file not found on disk: %s", movieFile.Path)
+	}
+	if _, err := exec.LookPath(ffmpegPath); err != nil {
+		return nil, fmt.Errorf("ffmpeg is unavailable at %q, check preview.ffmpeg_path", ffmpegPath)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, previewThumbnailTimeout)
+	defer cancel()
+
+	image, err := grabFrame(ctx, ffmpegPath, movieFile.Path, offset)
+	if err == nil && len(image) == 0 && offset > 0 {
+		image, err = grabFrame(ctx, ffmpegPath, movieFile.Path, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(image) == 0 {
+		return nil, fmt.Errorf("ffmpeg produced no thumbnail for %s", movieFile.Path)
+	}
+	return image, nil
+}
+
+// previewFile loads a movie file record for preview
+func (s *MovieFileService) previewFile(id int) (*models.MovieFile, error) {
+	var movieFile models.MovieFile
+	if err := s.db.GORM.Select("id", "path").Where("id = ?", id).First(&movieFile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("movie file not found")
+		}
+		return nil, fmt.Errorf("failed to get movie file: %w", err)
+	}
+	if movieFile.Path == "" {
+		return nil, fmt.Errorf("movie file not found on disk: no path recorded")
+	}
+	return &movieFile, nil
+}
+
+// grabFrame runs ffmpeg to write one scaled frame at offset as JPEG
+func grabFrame(ctx context.Context, ffmpegPath, path string, offset time.Duration) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffmpegPath, thumbnailArgs(path, offset)...) //nolint:gosec // G204: path comes from the library
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// thumbnailArgs returns the ffmpeg arguments writing one frame of path at offset to stdout as JPEG
+func thumbnailArgs(path string, offset time.Duration) []string {
+	return []string{
+		"-v", "error",
+		"-ss", strconv.FormatFloat(offset.Seconds(), 'f', 3, 64),
+		"-i", path,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf("scale=%d:-2", previewThumbnailWidth),
+		"-f", "image2pipe",
+		"-vcodec", "mjpeg",
+		"-",
+	}
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThumbnailArgs(t *testing.T) {
+	args := thumbnailArgs("/movies/Movie (2020)/movie.mkv", 90*time.Second)
+
+	assert.Equal(t, []string{"-ss", "90.000"}, args[2:4])
+	assert.Contains(t, args, "/movies/Movie (2020)/movie.mkv")
+	assert.Equal(t, "-", args[len(args)-1])
+}
+
+func TestMovieFileService_OpenForPreview(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewMovieFileService(db, logger)
+
+	path := filepath.Join(t.TempDir(), "movie.mkv")
+	require.NoError(t, os.WriteFile(path, []byte("not really a movie"), 0o600))
+
+	movieFile := &models.MovieFile{MovieID: 1, Path: path, Size: 18}
+	require.NoError(t, db.GORM.Create(movieFile).Error)
+	missing := &models.MovieFile{MovieID: 1, Path: filepath.Join(t.TempDir(), "gone.mkv")}
+	require.NoError(t, db.GORM.Create(missing).Error)
+
+	file, info, err := service.OpenForPreview(movieFile.ID)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	assert.Equal(t, int64(18), info.Size())
+
+	_, _, err = service.OpenForPreview(missing.ID)
+	assert.ErrorContains(t, err, "not found on disk")
+
+	_, _, err = service.OpenForPreview(999999)
+	assert.ErrorContains(t, err, "not found")
+}
@@ -35,6 +35,27 @@ func (s *MovieFileService) GetAll() ([]models.MovieFile, error) {
 	return movieFiles, nil
 }
 
+// GetReleases retrieves the releases of the movie files imported with a release name, limited to
+// a movie unless movieID is 0.
+func (s *MovieFileService) GetReleases(movieID int) ([]models.MovieFileRelease, error) {
+	query := s.db.GORM.Where("scene_name <> ''")
+	if movieID > 0 {
+		query = query.Where("movie_id = ?", movieID)
+	}
+
+	var movieFiles []models.MovieFile
+	if err := query.Order("id").Find(&movieFiles).Error; err != nil {
+		s.logger.Error("Failed to get movie file releases", "movieID", movieID, "error", err)
+		return nil, fmt.Errorf("failed to get movie file releases: %w", err)
+	}
+
+	releases := make([]models.MovieFileRelease, len(movieFiles))
+	for i := range movieFiles {
+		releases[i] = movieFiles[i].Release()
+	}
+	return releases, nil
+}
+
 // GetByID retrieves a single movie file by its ID.
 func (s *MovieFileService) GetByID(id int) (*models.MovieFile, error) {
 	var movieFile models.MovieFile
@@ -26,11 +26,13 @@ func NewNamingService(db *database.Database, logger *logger.Logger) *NamingServi
 	}
 }
 
-// BuildMovieFilePath generates the complete file path for a movie using naming configuration
+// BuildMovieFilePath generates the complete file path for a movie using naming configuration;
+// edition is the file's edition, such as "Director's Cut", or "" for the standard edition
 func (s *NamingService) BuildMovieFilePath(
 	movie *models.Movie,
 	quality *models.Quality,
 	mediaInfo *models.MediaInfo,
+	edition string,
 	namingConfig *models.NamingConfig,
 ) (string, error) {
 	if movie == nil {
@@ -51,7 +53,7 @@ func (s *NamingService) BuildMovieFilePath(
 	}
 
 	// Build file name using standard movie format
-	fileName, err := s.BuildFileName(movie, quality, mediaInfo, namingConfig)
+	fileName, err := s.BuildFileName(movie, quality, mediaInfo, edition, namingConfig)
 	if err != nil {
 		return "", fmt.Errorf("failed to build file name: %w", err)
 	}
@@ -79,7 +81,7 @@ func (s *NamingService) BuildFolderName(movie *models.Movie, namingConfig *model
 	}
 
 	// Create token replacement map
-	tokens := s.buildTokenMap(movie, nil, nil)
+	tokens := s.buildTokenMap(movie, nil, nil, "")
 
 	// Replace tokens in the format string
 	folderName := s.replaceTokens(folderFormat, tokens)
@@ -90,11 +92,60 @@ func (s *NamingService) BuildFolderName(movie *models.Movie, namingConfig *model
 	return folderName, nil
 }
 
+// BuildVersionFilePath generates the complete file path for a file of one of a movie's versions.
+// The file goes directly in the version's folder, and {Movie Version} is the version's name.
+func (s *NamingService) BuildVersionFilePath(
+	movie *models.Movie,
+	version *models.MovieVersion,
+	quality *models.Quality,
+	mediaInfo *models.MediaInfo,
+	edition string,
+	namingConfig *models.NamingConfig,
+) (string, error) {
+	if version == nil {
+		return s.BuildMovieFilePath(movie, quality, mediaInfo, edition, namingConfig)
+	}
+	if movie == nil {
+		return "", fmt.Errorf("movie cannot be nil")
+	}
+	if namingConfig == nil {
+		return "", fmt.Errorf("naming config cannot be nil")
+	}
+
+	fileName, err := s.buildFileName(movie, quality, mediaInfo, edition, version.Name, namingConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to build file name: %w", err)
+	}
+
+	fullPath := filepath.Join(version.Path, fileName)
+
+	s.logger.Debug("Built movie version file path",
+		"movie", movie.Title,
+		"version", version.Name,
+		"filename", fileName,
+		"fullPath", fullPath)
+
+	return fullPath, nil
+}
+
 // BuildFileName generates a file name for a movie based on the standard movie format template
 func (s *NamingService) BuildFileName(
 	movie *models.Movie,
 	quality *models.Quality,
 	mediaInfo *models.MediaInfo,
+	edition string,
+	namingConfig *models.NamingConfig,
+) (string, error) {
+	return s.buildFileName(movie, quality, mediaInfo, edition, "", namingConfig)
+}
+
+// buildFileName generates a file name for the named version of a movie, or its primary file
+// when versionName is ""
+func (s *NamingService) buildFileName(
+	movie *models.Movie,
+	quality *models.Quality,
+	mediaInfo *models.MediaInfo,
+	edition, versionName string,
 	namingConfig *models.NamingConfig,
 ) (string, error) {
 	fileFormat := namingConfig.StandardMovieFormat
@@ -103,7 +154,8 @@ func (s *NamingService) BuildFileName(
 	}
 
 	// Create token replacement map
-	tokens := s.buildTokenMap(movie, quality, mediaInfo)
+	tokens := s.buildTokenMap(movie, quality, mediaInfo, edition)
+	tokens["{Movie Version}"] = versionName
 
 	// Replace tokens in the format string
 	fileName := s.replaceTokens(fileFormat, tokens)
@@ -124,12 +176,15 @@ func (s *NamingService) buildTokenMap(
 	movie *models.Movie,
 	quality *models.Quality,
 	mediaInfo *models.MediaInfo,
+	edition string,
 ) map[string]string {
 	tokens := make(map[string]string)
 
 	s.addMovieTokens(tokens, movie)
 	s.addQualityTokens(tokens, quality)
 	s.addMediaInfoTokens(tokens, mediaInfo)
+	tokens["{Edition Tags}"] = edition
+	tokens["{Edition}"] = edition
 	s.setOptionalTokenDefaults(tokens)
 
 	return tokens
@@ -157,6 +212,8 @@ func (s *NamingService) addMovieTokens(tokens map[string]string, movie *models.M
 		tokens["{Release YearFirst}"] = "Unknown"
 	}
 
+	tokens["{Certification}"] = movie.Certification
+
 	// IMDB/TMDB tokens
 	tokens["{ImdbId}"] = movie.ImdbID
 	tokens["{Tmdb Id}"] = strconv.Itoa(movie.TmdbID)
@@ -200,15 +257,15 @@ func (s *NamingService) addMediaInfoTokens(tokens map[string]string, mediaInfo *
 	tokens["{MediaInfo VideoResolution}"] = mediaInfo.Resolution
 	tokens["{MediaInfo AudioCodec}"] = mediaInfo.AudioCodec
 	tokens["{MediaInfo AudioChannels}"] = s.formatAudioChannels(mediaInfo.AudioChannels)
-	tokens["{MediaInfo AudioLanguages}"] = mediaInfo.AudioLanguages
-	tokens["{MediaInfo SubtitleLanguages}"] = mediaInfo.Subtitles
+	tokens["{MediaInfo AudioLanguages}"] = formatLanguageToken(mediaInfo.AudioLanguages)
+	tokens["{MediaInfo SubtitleLanguages}"] = formatLanguageToken(mediaInfo.Subtitles)
 }
 
 // setOptionalTokenDefaults sets empty values for missing optional tokens
 func (s *NamingService) setOptionalTokenDefaults(tokens map[string]string) {
 	optionalTokens := []string{
-		"{Movie OriginalTitle}", "{Movie Collection}", "{Edition Tags}",
-		"{Custom Formats}", "{Release Group}", "{ImdbId}",
+		"{Movie OriginalTitle}", "{Movie Collection}", "{Movie Version}", "{Edition Tags}", "{Edition}",
+		"{Custom Formats}", "{Release Group}", "{ImdbId}", "{Certification}",
 		"{Quality Proper}", "{Quality Real}",
 		"{MediaInfo Simple}", "{MediaInfo Full}", "{MediaInfo VideoCodec}",
 		"{MediaInfo VideoBitDepth}", "{MediaInfo VideoResolution}",
@@ -410,33 +467,52 @@ func (s *NamingService) PreviewNaming(
 	movie *models.Movie,
 	namingConfig *models.NamingConfig,
 ) (*NamingPreview, error) {
-	// Create sample quality and media info for preview
-	sampleQuality := &models.Quality{
-		Quality: models.QualityDefinition{
-			ID:         6,
-			Name:       "Bluray-1080p",
-			Source:     "bluray",
-			Resolution: 1080,
-		},
-		Revision: models.Revision{Version: 1},
-	}
-
-	sampleMediaInfo := &models.MediaInfo{
-		VideoCodec:     "x264",
-		AudioCodec:     "DTS",
-		AudioChannels:  5.1,
-		Resolution:     "1920x1080",
-		VideoBitDepth:  8,
-		AudioLanguages: "[EN]",
-		Subtitles:      "[EN+ES]",
+	return s.PreviewSample(&models.NamingPreviewRequest{Naming: namingConfig, Movie: movie})
+}
+
+// PreviewSample renders the naming formats with user supplied sample data, so formats can be tried
+// out before anything is imported. Missing quality and media info fall back to a sample 1080p
+// Bluray file.
+func (s *NamingService) PreviewSample(request *models.NamingPreviewRequest) (*NamingPreview, error) {
+	if request.Movie == nil || strings.TrimSpace(request.Movie.Title) == "" {
+		return nil, models.ValidationError{Field: "movie.title", Message: "A sample movie title is required"}
+	}
+	if request.Naming == nil {
+		return nil, fmt.Errorf("naming config cannot be nil")
 	}
 
-	folderName, err := s.BuildFolderName(movie, namingConfig)
+	quality := request.Quality
+	if quality == nil {
+		quality = &models.Quality{
+			Quality: models.QualityDefinition{
+				ID:         6,
+				Name:       "Bluray-1080p",
+				Source:     "bluray",
+				Resolution: 1080,
+			},
+			Revision: models.Revision{Version: 1},
+		}
+	}
+	mediaInfo := request.MediaInfo
+	if mediaInfo == nil {
+		mediaInfo = &models.MediaInfo{
+			VideoCodec:     "x264",
+			AudioCodec:     "DTS",
+			AudioChannels:  5.1,
+			Resolution:     "1920x1080",
+			VideoBitDepth:  8,
+			AudioLanguages: "eng",
+			Subtitles:      "eng/spa",
+		}
+	}
+
+	folderName, err := s.BuildFolderName(request.Movie, request.Naming)
 	if err != nil {
 		return nil, err
 	}
 
-	fileName, err := s.BuildFileName(movie, sampleQuality, sampleMediaInfo, namingConfig)
+	fileName, err := s.buildFileName(request.Movie, quality, mediaInfo, request.Edition, request.Version,
+		request.Naming)
 	if err != nil {
 		return nil, err
 	}
@@ -444,12 +520,12 @@ func (s *NamingService) PreviewNaming(
 	fullPath := filepath.Join(folderName, fileName)
 
 	return &NamingPreview{
-		Movie:      movie,
+		Movie:      request.Movie,
 		FolderName: folderName,
 		FileName:   fileName,
 		FullPath:   fullPath,
-		Quality:    sampleQuality,
-		MediaInfo:  sampleMediaInfo,
+		Quality:    quality,
+		MediaInfo:  mediaInfo,
 	}, nil
 }
 
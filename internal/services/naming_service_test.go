@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingService_PreviewSample(t *testing.T) {
+	service := NewNamingService(nil, logger.New(config.LogConfig{Level: "error"}))
+	naming := models.GetDefaultNamingConfig()
+	naming.StandardMovieFormat = "{Movie Title} ({Release Year}) {Edition Tags} {Movie Version} {Quality Full}"
+	naming.MovieFolderFormat = "{Movie TitleThe} ({Release Year})"
+
+	preview, err := service.PreviewSample(&models.NamingPreviewRequest{
+		Naming:  naming,
+		Movie:   &models.Movie{Title: "The Thing", Year: 1982},
+		Quality: &models.Quality{Quality: models.QualityDefinition{Name: "Remux-2160p"}},
+		Edition: "Director's Cut",
+		Version: "4K",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Thing, The (1982)", preview.FolderName)
+	assert.Contains(t, preview.FileName, "The Thing (1982) Director's Cut 4K Remux-2160p")
+	assert.Equal(t, "x264", preview.MediaInfo.VideoCodec)
+
+	_, err = service.PreviewSample(&models.NamingPreviewRequest{Naming: naming, Movie: &models.Movie{}})
+	var validationErr models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "movie.title", validationErr.Field)
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/services/notifications"
+)
+
+// NotificationEventDigest is the event type of a summary combining several import notifications
+const NotificationEventDigest = "digest"
+
+const (
+	quietHoursModeQueue    = "queue"
+	quietHoursModeSuppress = "suppress"
+)
+
+// notificationSchedule is the parsed quiet hours and digest configuration
+type notificationSchedule struct {
+	quietHours bool
+	// quietStart and quietEnd are minutes after local midnight
+	quietStart     int
+	quietEnd       int
+	suppress       bool
+	digestInterval time.Duration
+}
+
+// newNotificationSchedule parses the quiet hours and digest settings. Invalid settings disable the
+// feature they configure and are reported in the returned error.
+func newNotificationSchedule(cfg config.NotificationConfig) (notificationSchedule, error) {
+	var schedule notificationSchedule
+	var problems []string
+
+	if cfg.QuietHoursStart != "" || cfg.QuietHoursEnd != "" {
+		start, startErr := time.Parse("15:04", cfg.QuietHoursStart)
+		end, endErr := time.Parse("15:04", cfg.QuietHoursEnd)
+		mode := cfg.QuietHoursMode
+		switch {
+		case startErr != nil || endErr != nil:
+			problems = append(problems, fmt.Sprintf("quiet hours %q to %q must be HH:MM times",
+				cfg.QuietHoursStart, cfg.QuietHoursEnd))
+		case start.Equal(end):
+			problems = append(problems, "quiet hours must start and end at different times")
+		case mode != "" && mode != quietHoursModeQueue && mode != quietHoursModeSuppress:
+			problems = append(problems, fmt.Sprintf("invalid notifications.quiet_hours_mode %q", mode))
+		default:
+			schedule.quietHours = true
+			schedule.quietStart = start.Hour()*60 + start.Minute()
+			schedule.quietEnd = end.Hour()*60 + end.Minute()
+			schedule.suppress = mode == quietHoursModeSuppress
+		}
+	}
+
+	if cfg.DigestInterval != "" {
+		interval, err := time.ParseDuration(cfg.DigestInterval)
+		if err != nil || interval < 0 {
+			problems = append(problems, fmt.Sprintf("invalid notifications.digest_interval %q", cfg.DigestInterval))
+		} else {
+			schedule.digestInterval = interval
+		}
+	}
+
+	if len(problems) > 0 {
+		return schedule, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return schedule, nil
+}
+
+// quietUntil returns when the quiet hours containing t end, or the zero time outside quiet hours
+func (s notificationSchedule) quietUntil(t time.Time) time.Time {
+	if !s.quietHours {
+		return time.Time{}
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	inside := minute >= s.quietStart && minute < s.quietEnd
+	if s.quietStart > s.quietEnd {
+		// The window spans midnight
+		inside = minute >= s.quietStart || minute < s.quietEnd
+	}
+	if !inside {
+		return time.Time{}
+	}
+
+	end := time.Date(t.Year(), t.Month(), t.Day(), s.quietEnd/60, s.quietEnd%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = time.Date(t.Year(), t.Month(), t.Day()+1, s.quietEnd/60, s.quietEnd%60, 0, 0, t.Location())
+	}
+	return end
+}
+
+// deliverAfter returns when a notification for the event raised at now is due: the zero time to
+// send it right away, or a later time to queue it. suppressed reports an event dropped because
+// it was raised during quiet hours.
+func (s notificationSchedule) deliverAfter(eventType string, now time.Time) (after time.Time, suppressed bool) {
+	if !isCriticalNotificationEvent(eventType) {
+		if until := s.quietUntil(now); !until.IsZero() {
+			if s.suppress {
+				return time.Time{}, true
+			}
+			after = until
+		}
+	}
+
+	if s.digestInterval > 0 && isImportNotificationEvent(eventType) {
+		if window := now.Truncate(s.digestInterval).Add(s.digestInterval); window.After(after) {
+			after = window
+		}
+	}
+	return after, false
+}
+
+// isCriticalNotificationEvent reports events delivered even during quiet hours
+func isCriticalNotificationEvent(eventType string) bool {
+	return eventType == NotificationEventHealth || eventType == "manualInteractionRequired"
+}
+
+// isImportNotificationEvent reports events combined into digests
+func isImportNotificationEvent(eventType string) bool {
+	return eventType == NotificationEventDownload || eventType == "upgrade"
+}
+
+// queueNotification records a delivery held until it is due; FlushQueuedNotifications sends it
+func (s *NotificationService) queueNotification(
+	notification *models.Notification,
+	message *notifications.NotificationMessage,
+	deliverAfter time.Time) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+
+	history := &models.NotificationHistory{
+		NotificationID: notification.ID,
+		EventType:      message.EventType,
+		Subject:        message.Subject,
+		Body:           message.Body,
+		SentAt:         time.Now(),
+		Status:         models.NotificationDeliveryQueued,
+		Payload:        string(payload),
+		DeliverAfter:   &deliverAfter,
+	}
+	if message.Movie != nil {
+		history.MovieID = &message.Movie.ID
+	}
+
+	if err := s.db.GORM.Create(history).Error; err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+
+	s.logger.Debug("Queued notification", "id", notification.ID, "eventType", message.EventType,
+		"deliverAfter", deliverAfter)
+	return nil
+}
+
+// FlushQueuedNotifications delivers the queued notifications that are due. Import notifications
+// queued for the same notification are combined into one digest; other notifications are sent
+// one by one. It returns the number of queued deliveries handled.
+func (s *NotificationService) FlushQueuedNotifications() (int, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	var queued []models.NotificationHistory
+	err := s.db.GORM.Preload("Notification").
+		Where("status = ? AND deliver_after <= ?", models.NotificationDeliveryQueued, time.Now()).
+		Order("date, id").Find(&queued).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch queued notifications: %w", err)
+	}
+
+	var order []int
+	groups := make(map[int][]*models.NotificationHistory)
+	for i := range queued {
+		id := queued[i].NotificationID
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], &queued[i])
+	}
+
+	for _, id := range order {
+		rows := groups[id]
+		notification := rows[0].Notification
+		if notification == nil || !notification.IsEnabled() {
+			s.completeQueuedDeliveries(rows, 0, fmt.Errorf("notification was deleted or disabled while queued"))
+			continue
+		}
+
+		var imports []*models.NotificationHistory
+		for _, row := range rows {
+			if isImportNotificationEvent(row.EventType) {
+				imports = append(imports, row)
+				continue
+			}
+			s.deliverQueued(notification, []*models.NotificationHistory{row})
+		}
+		if len(imports) > 0 {
+			s.deliverQueued(notification, imports)
+		}
+	}
+
+	if len(queued) > 0 {
+		s.logger.Info("Flushed queued notifications", "deliveries", len(queued), "notifications", len(order))
+	}
+	return len(queued), nil
+}
+
+// deliverQueued sends queued deliveries for one notification, as a digest when there are several
+func (s *NotificationService) deliverQueued(notification *models.Notification, rows []*models.NotificationHistory) {
+	var messages []*notifications.NotificationMessage
+	var pending []*models.NotificationHistory
+	for _, row := range rows {
+		var message notifications.NotificationMessage
+		if err := json.Unmarshal([]byte(row.Payload), &message); err != nil {
+			s.completeQueuedDeliveries([]*models.NotificationHistory{row}, 0,
+				fmt.Errorf("failed to read queued notification: %w", err))
+			continue
+		}
+		messages = append(messages, &message)
+		pending = append(pending, row)
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	message := messages[0]
+	if len(messages) > 1 {
+		message = buildDigestMessage(messages)
+	}
+
+	attempts, err := s.deliver(notification, message)
+	if err != nil {
+		s.logger.Error("Failed to send queued notification", "id", notification.ID,
+			"name", notification.Name, "deliveries", len(pending), "error", err)
+	}
+	s.completeQueuedDeliveries(pending, attempts, err)
+}
+
+// completeQueuedDeliveries records the outcome of sending queued deliveries. Failed deliveries
+// keep their own message so each can be retried from the notification history.
+func (s *NotificationService) completeQueuedDeliveries(rows []*models.NotificationHistory, attempts int, err error) {
+	now := time.Now()
+	for _, row := range rows {
+		row.Attempts = attempts
+		row.LastAttemptAt = &now
+		row.Successful = err == nil
+		if err == nil {
+			row.Status = models.NotificationDeliveryDelivered
+			row.Payload = ""
+		} else {
+			row.Status = models.NotificationDeliveryFailed
+			row.ErrorMessage = err.Error()
+		}
+
+		updateErr := s.db.GORM.Model(row).Select("attempts", "last_attempt_at", "successful", "status",
+			"error_message", "payload").Updates(row).Error
+		if updateErr != nil {
+			s.logger.Error("Failed to update queued notification", "historyId", row.ID, "error", updateErr)
+		}
+	}
+}
+
+// buildDigestMessage summarizes several import notifications in one message
+func buildDigestMessage(messages []*notifications.NotificationMessage) *notifications.NotificationMessage {
+	lines := make([]string, len(messages))
+	for i, message := range messages {
+		lines[i] = "• " + digestLine(message)
+	}
+
+	// Queued messages carry the instance name from when they were created
+	serverName := models.DefaultInstanceName
+	if len(messages) > 0 && messages[0].ServerName != "" {
+		serverName = messages[0].ServerName
+	}
+
+	return &notifications.NotificationMessage{
+		Subject:    fmt.Sprintf("%s - %d Movies Imported", serverName, len(messages)),
+		Body:       strings.Join(lines, "\n"),
+		EventType:  NotificationEventDigest,
+		Data:       map[string]interface{}{"count": len(messages)},
+		ServerName: serverName,
+		Timestamp:  time.Now(),
+	}
+}
+
+// digestLine describes one import in a digest
+func digestLine(message *notifications.NotificationMessage) string {
+	if message.Movie == nil {
+		return message.Subject
+	}
+
+	line := message.Movie.Title
+	if message.Movie.Year > 0 {
+		line = fmt.Sprintf("%s (%d)", line, message.Movie.Year)
+	}
+	if message.Quality != nil && message.Quality.Name != "" {
+		line += " [" + message.Quality.Name + "]"
+	}
+	if message.EventType == "upgrade" || message.QualityUpgrade {
+		line += " - upgraded"
+	}
+	return line
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/services/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSchedule_deliverAfter(t *testing.T) {
+	schedule, err := newNotificationSchedule(config.NotificationConfig{
+		QuietHoursStart: "22:00",
+		QuietHoursEnd:   "07:30",
+		DigestInterval:  "15m",
+	})
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		now       time.Time
+		expected  time.Time
+	}{
+		{"outside quiet hours", NotificationEventGrab, at(10, 12, 0), time.Time{}},
+		{"before midnight", NotificationEventGrab, at(10, 23, 10), at(11, 7, 30)},
+		{"after midnight", NotificationEventGrab, at(11, 3, 0), at(11, 7, 30)},
+		{"quiet hours end", NotificationEventGrab, at(11, 7, 30), time.Time{}},
+		{"critical event", NotificationEventHealth, at(11, 3, 0), time.Time{}},
+		{"import digest", NotificationEventDownload, at(10, 12, 7), at(10, 12, 15)},
+		{"import during quiet hours", "upgrade", at(11, 3, 0), at(11, 7, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after, suppressed := schedule.deliverAfter(tt.eventType, tt.now)
+			assert.False(t, suppressed)
+			assert.Equal(t, tt.expected, after)
+		})
+	}
+
+	schedule.suppress = true
+	_, suppressed := schedule.deliverAfter(NotificationEventDownload, at(10, 23, 0))
+	assert.True(t, suppressed)
+	_, suppressed = schedule.deliverAfter(NotificationEventHealth, at(10, 23, 0))
+	assert.False(t, suppressed)
+}
+
+func TestNewNotificationSchedule_Invalid(t *testing.T) {
+	schedule, err := newNotificationSchedule(config.NotificationConfig{
+		QuietHoursStart: "22:00",
+		DigestInterval:  "10m",
+	})
+	assert.Error(t, err)
+	assert.False(t, schedule.quietHours)
+	assert.Equal(t, 10*time.Minute, schedule.digestInterval)
+
+	_, err = newNotificationSchedule(config.NotificationConfig{
+		QuietHoursStart: "22:00", QuietHoursEnd: "07:00", QuietHoursMode: "drop",
+	})
+	assert.ErrorContains(t, err, "quiet_hours_mode")
+
+	schedule, err = newNotificationSchedule(config.NotificationConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, notificationSchedule{}, schedule)
+}
+
+func TestBuildDigestMessage(t *testing.T) {
+	message := buildDigestMessage([]*notifications.NotificationMessage{
+		{
+			EventType: NotificationEventDownload,
+			Movie:     &models.Movie{Title: "Arrival", Year: 2016},
+			Quality:   &models.QualityDefinition{Name: "Bluray-1080p"},
+		},
+		{EventType: "upgrade", Movie: &models.Movie{Title: "Heat", Year: 1995}},
+		{EventType: NotificationEventDownload, Subject: "Movie Downloaded"},
+	})
+
+	assert.Equal(t, NotificationEventDigest, message.EventType)
+	assert.Equal(t, "Radarr - 3 Movies Imported", message.Subject)
+	assert.Equal(t, "• Arrival (2016) [Bluray-1080p]\n• Heat (1995) - upgraded\n• Movie Downloaded", message.Body)
+
+	message = buildDigestMessage([]*notifications.NotificationMessage{
+		{EventType: NotificationEventDownload, Subject: "Movie Downloaded", ServerName: "Radarr 4K"},
+	})
+	assert.Equal(t, "Radarr 4K - 1 Movies Imported", message.Subject)
+	assert.Equal(t, "Radarr 4K", message.ServerName)
+}
+
+func TestNotificationService_FlushQueuedNotifications_NoDatabase(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
+	_, err := service.FlushQueuedNotifications()
+	assert.EqualError(t, err, "database not available")
+}
@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 	"github.com/radarr/radarr-go/internal/services/notifications"
@@ -18,9 +22,18 @@ import (
 const (
 	NotificationEventGrab     = "grab"
 	NotificationEventDownload = "download"
+	NotificationEventUpgrade  = "upgrade"
 	NotificationEventHealth   = "health"
 )
 
+const (
+	// notificationSendTimeout bounds a single delivery attempt
+	notificationSendTimeout = 60 * time.Second
+
+	defaultNotificationRetryBaseDelay = 2 * time.Second
+	defaultNotificationRetryMaxDelay  = time.Minute
+)
+
 // NotificationService provides operations for managing notifications and alerts.
 type NotificationService struct {
 	db               *database.Database
@@ -29,21 +42,93 @@ type NotificationService struct {
 	factory          notifications.ProviderFactory
 	templateEngine   notifications.TemplateEngine
 	defaultTemplates map[string]*notifications.NotificationTemplate
+	retryPolicy      notificationRetryPolicy
+	schedule         notificationSchedule
+	sleep            func(time.Duration)
+}
+
+// notificationRetryPolicy is the parsed notification delivery retry configuration
+type notificationRetryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
 }
 
-// NewNotificationService creates a new instance of NotificationService with the provided database and logger.
-func NewNotificationService(db *database.Database, logger *logger.Logger) *NotificationService {
+// NewNotificationService creates a new instance of NotificationService with the provided database,
+// delivery retry, quiet hours and digest settings, and logger.
+func NewNotificationService(db *database.Database, cfg config.NotificationConfig,
+	logger *logger.Logger) *NotificationService {
 	factory := notifications.NewProviderFactory(logger)
 	templateEngine := notifications.NewTemplateEngine(logger)
 
+	policy, err := newNotificationRetryPolicy(cfg)
+	if err != nil {
+		logger.Error("Invalid notification retry configuration, using defaults", "error", err)
+	}
+	schedule, err := newNotificationSchedule(cfg)
+	if err != nil {
+		logger.Error("Invalid notification quiet hours or digest configuration, disabling them", "error", err)
+	}
+
 	return &NotificationService{
 		db:               db,
 		logger:           logger,
-		httpClient:       &http.Client{Timeout: 30 * time.Second},
+		httpClient:       httpclient.New(30 * time.Second),
 		factory:          factory,
 		templateEngine:   templateEngine,
 		defaultTemplates: notifications.GetDefaultTemplates(),
+		retryPolicy:      policy,
+		schedule:         schedule,
+		sleep:            time.Sleep,
+	}
+}
+
+// newNotificationRetryPolicy parses the retry settings; empty delays use the defaults, and the
+// defaults are returned alongside any parse error
+func newNotificationRetryPolicy(cfg config.NotificationConfig) (notificationRetryPolicy, error) {
+	policy := notificationRetryPolicy{
+		maxRetries: max(cfg.MaxRetries, 0),
+		baseDelay:  defaultNotificationRetryBaseDelay,
+		maxDelay:   defaultNotificationRetryMaxDelay,
+	}
+
+	for _, d := range []struct {
+		value  string
+		target *time.Duration
+		name   string
+	}{
+		{cfg.RetryBaseDelay, &policy.baseDelay, "retry_base_delay"},
+		{cfg.RetryMaxDelay, &policy.maxDelay, "retry_max_delay"},
+	} {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil || parsed < 0 {
+			return notificationRetryPolicy{
+				maxRetries: policy.maxRetries,
+				baseDelay:  defaultNotificationRetryBaseDelay,
+				maxDelay:   defaultNotificationRetryMaxDelay,
+			}, fmt.Errorf("invalid notifications.%s %q", d.name, d.value)
+		}
+		*d.target = parsed
+	}
+	return policy, nil
+}
+
+// delay returns the wait before the next attempt: the base delay doubled for each attempt made,
+// capped at the maximum, or the provider's Retry-After when it gave one
+func (p notificationRetryPolicy) delay(attempt int, err error) time.Duration {
+	var retryAfter *notifications.RetryAfterError
+	if errors.As(err, &retryAfter) && retryAfter.After > 0 {
+		return min(retryAfter.After, p.maxDelay)
 	}
+
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxDelay)
 }
 
 // GetNotifications retrieves all configured notifications from the system.
@@ -229,6 +314,12 @@ func (s *NotificationService) SendNotification(event *models.NotificationEvent)
 		eventType = event.Type
 	}
 
+	deliverAfter, suppressed := s.schedule.deliverAfter(eventType, time.Now())
+	if suppressed {
+		s.logger.Debug("Suppressed notification during quiet hours", "eventType", eventType)
+		return nil
+	}
+
 	// Convert to notification message format
 	notificationMessage := s.convertEventToMessage(event)
 
@@ -239,6 +330,15 @@ func (s *NotificationService) SendNotification(event *models.NotificationEvent)
 			continue
 		}
 
+		// Held for quiet hours or a digest; FlushQueuedNotifications sends it when due
+		if !deliverAfter.IsZero() {
+			if err := s.queueNotification(&notification, notificationMessage, deliverAfter); err != nil {
+				s.logger.Error("Failed to queue notification", "id", notification.ID,
+					"name", notification.Name, "error", err)
+			}
+			continue
+		}
+
 		wg.Add(1)
 		go func(n models.Notification) {
 			defer wg.Done()
@@ -327,81 +427,73 @@ func (s *NotificationService) convertEventToMessage(
 		HealthCheck:    event.HealthCheck,
 		Data:           event.Data,
 		IsTest:         false,
-		ServerName:     "Radarr",
+		ServerName:     instanceName(s.db),
 		Timestamp:      time.Now(),
 	}
 }
 
-// sendNotificationWithRetry sends a notification with retry logic
+// sendNotificationWithRetry delivers a notification, retrying failures with exponential backoff,
+// and records the delivery. Deliveries that still fail are kept with their message so they can be
+// retried from the notification history.
 func (s *NotificationService) sendNotificationWithRetry(
 	notification *models.Notification,
 	message *notifications.NotificationMessage) error {
-	// Create provider
-	provider, err := s.factory.CreateProvider(notification.Implementation)
+	startTime := time.Now()
+	attempts, err := s.deliver(notification, message)
+	s.recordNotificationHistory(notification, message, err, startTime, attempts)
 	if err != nil {
-		return fmt.Errorf("failed to create provider: %w", err)
+		return fmt.Errorf("notification failed after %d attempts: %w", attempts, err)
 	}
+	return nil
+}
 
-	// Get retry configuration
-	retryConfig := provider.GetDefaultRetryConfig()
-	if !provider.SupportsRetry() {
-		retryConfig.MaxRetries = 0
+// deliver sends the message, retrying up to the configured number of times. Providers that
+// support retries can mark errors as permanent through their retry condition, and a
+// RetryAfterError overrides the backoff delay.
+func (s *NotificationService) deliver(
+	notification *models.Notification,
+	message *notifications.NotificationMessage) (int, error) {
+	provider, err := s.factory.CreateProvider(notification.Implementation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create provider: %w", err)
 	}
 
-	// Try sending with retry logic
-	var lastError error
-	for attempt := 0; attempt <= retryConfig.MaxRetries; attempt++ {
-		startTime := time.Now()
+	// Apply template if available
+	renderedMessage, err := s.applyTemplate(notification, message)
+	if err != nil {
+		s.logger.Warn("Failed to apply template, using original message", "error", err)
+		renderedMessage = message
+	}
 
-		// Apply template if available
-		renderedMessage, err := s.applyTemplate(notification, message)
-		if err != nil {
-			s.logger.Warn("Failed to apply template, using original message", "error", err)
-			renderedMessage = message
-		}
+	retryCondition := provider.GetDefaultRetryConfig().RetryCondition
+	if !provider.SupportsRetry() {
+		retryCondition = nil
+	}
 
-		// Send notification
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	attempt := 0
+	for {
+		attempt++
+		ctx, cancel := context.WithTimeout(context.Background(), notificationSendTimeout)
 		err = provider.SendNotification(ctx, notification.Settings, renderedMessage)
 		cancel()
 
-		// Record attempt
-		s.recordNotificationHistory(notification, renderedMessage, err, startTime)
-
 		if err == nil {
-			// Success
-			s.logger.Debug("Notification sent successfully",
-				"provider", provider.GetName(),
-				"attempt", attempt+1)
-			return nil
+			s.logger.Debug("Notification sent successfully", "provider", provider.GetName(), "attempt", attempt)
+			return attempt, nil
 		}
 
-		lastError = err
-
-		// Check if we should retry
-		if attempt < retryConfig.MaxRetries && provider.SupportsRetry() &&
-			retryConfig.RetryCondition != nil && retryConfig.RetryCondition(err) {
-			// Calculate delay with exponential backoff
-			delay := time.Duration(float64(retryConfig.InitialDelay) *
-				pow(retryConfig.BackoffFactor, float64(attempt)))
-			if delay > retryConfig.MaxDelay {
-				delay = retryConfig.MaxDelay
-			}
-
-			s.logger.Warn("Notification failed, retrying",
-				"provider", provider.GetName(),
-				"attempt", attempt+1,
-				"error", err,
-				"delay", delay)
-
-			time.Sleep(delay)
-		} else {
-			break
+		if attempt > s.retryPolicy.maxRetries || (retryCondition != nil && !retryCondition(err)) {
+			return attempt, err
 		}
-	}
 
-	return fmt.Errorf("notification failed after %d attempts: %w",
-		retryConfig.MaxRetries+1, lastError)
+		delay := s.retryPolicy.delay(attempt, err)
+		s.logger.Warn("Notification failed, retrying",
+			"provider", provider.GetName(),
+			"attempt", attempt,
+			"error", err,
+			"delay", delay)
+		s.sleep(delay)
+	}
 }
 
 // applyTemplate applies template rendering to a notification message
@@ -428,12 +520,14 @@ func (s *NotificationService) applyTemplate(
 	return &renderedMessage, nil
 }
 
-// recordNotificationHistory records a notification attempt in the database
+// recordNotificationHistory records a delivery and its attempts in the database
 func (s *NotificationService) recordNotificationHistory(
 	notification *models.Notification,
 	message *notifications.NotificationMessage,
 	err error,
-	startTime time.Time) {
+	startTime time.Time,
+	attempts int) {
+	now := time.Now()
 	history := &models.NotificationHistory{
 		NotificationID: notification.ID,
 		EventType:      message.EventType,
@@ -441,6 +535,9 @@ func (s *NotificationService) recordNotificationHistory(
 		Body:           message.Body,
 		Successful:     err == nil,
 		SentAt:         startTime,
+		Status:         models.NotificationDeliveryDelivered,
+		Attempts:       attempts,
+		LastAttemptAt:  &now,
 	}
 
 	if message.Movie != nil {
@@ -449,6 +546,12 @@ func (s *NotificationService) recordNotificationHistory(
 
 	if err != nil {
 		history.ErrorMessage = err.Error()
+		history.Status = models.NotificationDeliveryFailed
+		if payload, marshalErr := json.Marshal(message); marshalErr == nil {
+			history.Payload = string(payload)
+		} else {
+			s.logger.Warn("Failed to keep failed notification for retry", "error", marshalErr)
+		}
 	}
 
 	if dbErr := s.db.GORM.Create(history).Error; dbErr != nil {
@@ -605,21 +708,6 @@ func (s *NotificationService) formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %s", float64(bytes)/float64(div), units[exp])
 }
 
-// pow calculates base^exp for float64
-func pow(base, exp float64) float64 {
-	if exp == 0 {
-		return 1
-	}
-	if exp == 1 {
-		return base
-	}
-	result := base
-	for i := 1; i < int(exp); i++ {
-		result *= base
-	}
-	return result
-}
-
 // GetProviderInfo returns information about available notification providers
 func (s *NotificationService) GetProviderInfo() ([]*notifications.ProviderInfo, error) {
 	if factory, ok := s.factory.(*notifications.DefaultProviderFactory); ok {
@@ -641,6 +729,13 @@ func (s *NotificationService) GetProviderFields(
 
 // GetNotificationHistory retrieves notification history with optional filters
 func (s *NotificationService) GetNotificationHistory(limit int, offset int) ([]models.NotificationHistory, error) {
+	return s.GetNotificationHistoryByStatus("", limit, offset)
+}
+
+// GetNotificationHistoryByStatus retrieves notification history filtered by delivery status.
+// An empty status returns deliveries in every state.
+func (s *NotificationService) GetNotificationHistoryByStatus(
+	status models.NotificationDeliveryStatus, limit int, offset int) ([]models.NotificationHistory, error) {
 	if s.db == nil {
 		return nil, fmt.Errorf("database not available")
 	}
@@ -648,6 +743,10 @@ func (s *NotificationService) GetNotificationHistory(limit int, offset int) ([]m
 	var history []models.NotificationHistory
 	query := s.db.GORM.Preload("Notification").Preload("Movie")
 
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -655,10 +754,62 @@ func (s *NotificationService) GetNotificationHistory(limit int, offset int) ([]m
 		query = query.Offset(offset)
 	}
 
-	if err := query.Order("sent_at DESC").Find(&history).Error; err != nil {
+	if err := query.Order("date DESC").Find(&history).Error; err != nil {
 		s.logger.Error("Failed to fetch notification history", "error", err)
 		return nil, fmt.Errorf("failed to fetch notification history: %w", err)
 	}
 
 	return history, nil
 }
+
+// RetryNotificationDelivery sends a failed delivery from the notification history again, with the
+// usual automatic retries, and updates the history entry with the outcome
+func (s *NotificationService) RetryNotificationDelivery(historyID int) (*models.NotificationHistory, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var history models.NotificationHistory
+	if err := s.db.GORM.Preload("Notification").First(&history, historyID).Error; err != nil {
+		return nil, fmt.Errorf("notification delivery not found: %w", err)
+	}
+
+	if history.Status != models.NotificationDeliveryFailed {
+		return nil, models.ValidationError{Field: "status", Message: "Only failed deliveries can be retried"}
+	}
+	if history.Notification == nil {
+		return nil, models.ValidationError{Field: "notificationId", Message: "The notification no longer exists"}
+	}
+	if history.Payload == "" {
+		return nil, models.ValidationError{Field: "payload", Message: "The delivery was recorded without its message"}
+	}
+
+	var message notifications.NotificationMessage
+	if err := json.Unmarshal([]byte(history.Payload), &message); err != nil {
+		return nil, fmt.Errorf("failed to read stored notification: %w", err)
+	}
+
+	attempts, sendErr := s.deliver(history.Notification, &message)
+
+	now := time.Now()
+	history.Attempts += attempts
+	history.LastAttemptAt = &now
+	history.Successful = sendErr == nil
+	if sendErr == nil {
+		history.Status = models.NotificationDeliveryDelivered
+		history.ErrorMessage = ""
+		history.Payload = ""
+	} else {
+		history.ErrorMessage = sendErr.Error()
+	}
+
+	err := s.db.GORM.Model(&history).Select("attempts", "last_attempt_at", "successful", "status",
+		"error_message", "payload").Updates(&history).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification history: %w", err)
+	}
+
+	s.logger.Info("Retried notification delivery", "historyId", historyID,
+		"notificationId", history.NotificationID, "successful", history.Successful)
+	return &history, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -15,7 +16,7 @@ import (
 
 func TestNotificationService_CreateNotification(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	notification := &models.Notification{
 		Name:           "Test Discord Notification",
@@ -36,7 +37,7 @@ func TestNotificationService_CreateNotification(t *testing.T) {
 
 func TestNotificationService_GetNotifications(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	// Test with nil database
 	_, err := service.GetNotifications()
@@ -46,7 +47,7 @@ func TestNotificationService_GetNotifications(t *testing.T) {
 
 func TestNotificationService_TestNotification(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	notification := &models.Notification{
 		Name:           "Test Notification",
@@ -66,7 +67,7 @@ func TestNotificationService_TestNotification(t *testing.T) {
 
 func TestNotificationService_SendNotification(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	// Create test event
 	event := &models.NotificationEvent{
@@ -88,7 +89,7 @@ func TestNotificationService_SendNotification(t *testing.T) {
 
 func TestNotificationService_GetProviderInfo(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	providers, err := service.GetProviderInfo()
 	assert.NoError(t, err)
@@ -110,7 +111,7 @@ func TestNotificationService_GetProviderInfo(t *testing.T) {
 
 func TestNotificationService_GetProviderFields(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	fields, err := service.GetProviderFields(models.NotificationTypeDiscord)
 	assert.NoError(t, err)
@@ -196,7 +197,7 @@ func TestNotificationProviderFactory(t *testing.T) {
 
 func TestNotificationValidation(t *testing.T) {
 	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
-	service := NewNotificationService(nil, logger)
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
 
 	// Since database operations fail with nil database, test provider validation instead
 
@@ -257,3 +258,94 @@ func TestStubProviderIntegration(t *testing.T) {
 	err = provider.SendNotification(ctx, settings, message)
 	assert.NoError(t, err)
 }
+
+// flakyProvider fails its first failures sends with err
+type flakyProvider struct {
+	*notifications.StubProvider
+	failures int
+	err      error
+	sends    int
+}
+
+func (p *flakyProvider) SendNotification(
+	_ context.Context, _ models.NotificationSettings, _ *notifications.NotificationMessage,
+) error {
+	p.sends++
+	if p.sends <= p.failures {
+		return p.err
+	}
+	return nil
+}
+
+func (p *flakyProvider) SupportsRetry() bool {
+	return true
+}
+
+func (p *flakyProvider) GetDefaultRetryConfig() notifications.RetryConfig {
+	return notifications.RetryConfig{RetryCondition: func(err error) bool { return err.Error() != "unauthorized" }}
+}
+
+func TestNotificationService_deliver(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	cfg := config.NotificationConfig{MaxRetries: 2, RetryBaseDelay: "1s", RetryMaxDelay: "30s"}
+
+	newService := func(provider *flakyProvider) (*NotificationService, *[]time.Duration) {
+		provider.StubProvider = notifications.NewStubProvider("Discord", models.NotificationTypeDiscord, logger)
+		service := NewNotificationService(nil, cfg, logger)
+		factory, ok := service.factory.(*notifications.DefaultProviderFactory)
+		require.True(t, ok)
+		factory.RegisterProvider(models.NotificationTypeDiscord, func() notifications.Provider { return provider })
+
+		var delays []time.Duration
+		service.sleep = func(d time.Duration) { delays = append(delays, d) }
+		return service, &delays
+	}
+	notification := &models.Notification{Implementation: models.NotificationTypeDiscord}
+	message := &notifications.NotificationMessage{EventType: "custom"}
+
+	provider := &flakyProvider{failures: 2, err: errors.New("timeout")}
+	service, delays := newService(provider)
+	attempts, err := service.deliver(notification, message)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *delays)
+
+	provider = &flakyProvider{failures: 5, err: &notifications.RetryAfterError{Err: errors.New("429"), After: 10 * time.Second}}
+	service, delays = newService(provider)
+	attempts, err = service.deliver(notification, message)
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{10 * time.Second, 10 * time.Second}, *delays)
+
+	// Errors the provider reports as permanent are not retried
+	provider = &flakyProvider{failures: 5, err: errors.New("unauthorized")}
+	service, delays = newService(provider)
+	attempts, err = service.deliver(notification, message)
+	assert.EqualError(t, err, "unauthorized")
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, *delays)
+}
+
+func TestNotificationRetryPolicy_delay(t *testing.T) {
+	policy, err := newNotificationRetryPolicy(config.NotificationConfig{MaxRetries: 10, RetryBaseDelay: "1s", RetryMaxDelay: "5s"})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Second, policy.delay(1, errors.New("failed")))
+	assert.Equal(t, 4*time.Second, policy.delay(3, errors.New("failed")))
+	assert.Equal(t, 5*time.Second, policy.delay(8, errors.New("failed")))
+	assert.Equal(t, 5*time.Second, policy.delay(1, &notifications.RetryAfterError{Err: errors.New("429"), After: time.Hour}))
+
+	policy, err = newNotificationRetryPolicy(config.NotificationConfig{MaxRetries: -1, RetryBaseDelay: "soon"})
+	assert.Error(t, err)
+	assert.Equal(t, 0, policy.maxRetries)
+	assert.Equal(t, defaultNotificationRetryBaseDelay, policy.baseDelay)
+}
+
+func TestNotificationService_RetryNotificationDelivery(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewNotificationService(nil, config.NotificationConfig{}, logger)
+
+	// Test with nil database
+	_, err := service.RetryNotificationDelivery(1)
+	assert.EqualError(t, err, "database not available")
+}
@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/models"
@@ -62,6 +63,21 @@ type RetryConfig struct {
 	RetryCondition func(error) bool
 }
 
+// RetryAfterError is returned by providers told when to try again, such as a rate-limited
+// webhook answering 429 with a Retry-After header
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %s)", e.Err, e.After)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
 // NotificationMessage represents a notification message with context
 type NotificationMessage struct {
 	// Core message fields
@@ -402,39 +402,39 @@ func GetDefaultTemplates() map[string]*NotificationTemplate {
 func addMovieTemplates(templates map[string]*NotificationTemplate) {
 	templates["grab"] = &NotificationTemplate{
 		EventType: "grab",
-		Subject:   "{movie.title} ({movie.year}) - Grabbed",
+		Subject:   "{server} - {movie.title} ({movie.year}) - Grabbed",
 		Body: "Movie '{movie.title} ({movie.year})' was grabbed from {downloadClient}.\n\n" +
 			"Quality: {quality.name}\nSource: {sourceTitle}",
-		DefaultSubject: "{movie.title} ({movie.year}) - Grabbed",
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - Grabbed",
 		DefaultBody: "Movie '{movie.title} ({movie.year})' was grabbed from {downloadClient}.\n\n" +
 			"Quality: {quality.name}\nSource: {sourceTitle}",
 	}
 
 	templates["download"] = &NotificationTemplate{
 		EventType: "download",
-		Subject:   "{movie.title} ({movie.year}) - Downloaded",
+		Subject:   "{server} - {movie.title} ({movie.year}) - Downloaded",
 		Body: "Movie '{movie.title} ({movie.year})' has been downloaded and imported.\n\n" +
 			"Quality: {quality.name}\nFile: {file.relativePath}\nSize: {file.sizeFormatted}",
-		DefaultSubject: "{movie.title} ({movie.year}) - Downloaded",
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - Downloaded",
 		DefaultBody: "Movie '{movie.title} ({movie.year})' has been downloaded and imported.\n\n" +
 			"Quality: {quality.name}\nFile: {file.relativePath}\nSize: {file.sizeFormatted}",
 	}
 
 	templates["upgrade"] = &NotificationTemplate{
 		EventType: "upgrade",
-		Subject:   "{movie.title} ({movie.year}) - Upgraded",
+		Subject:   "{server} - {movie.title} ({movie.year}) - Upgraded",
 		Body: "Movie '{movie.title} ({movie.year})' has been upgraded to better quality.\n\n" +
 			"New Quality: {quality.name}\nFile: {file.relativePath}\nSize: {file.sizeFormatted}",
-		DefaultSubject: "{movie.title} ({movie.year}) - Upgraded",
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - Upgraded",
 		DefaultBody: "Movie '{movie.title} ({movie.year})' has been upgraded to better quality.\n\n" +
 			"New Quality: {quality.name}\nFile: {file.relativePath}\nSize: {file.sizeFormatted}",
 	}
 
 	templates["rename"] = &NotificationTemplate{
 		EventType:      "rename",
-		Subject:        "{movie.title} ({movie.year}) - Renamed",
+		Subject:        "{server} - {movie.title} ({movie.year}) - Renamed",
 		Body:           "Movie '{movie.title} ({movie.year})' has been renamed.\n\nFile: {file.relativePath}",
-		DefaultSubject: "{movie.title} ({movie.year}) - Renamed",
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - Renamed",
 		DefaultBody:    "Movie '{movie.title} ({movie.year})' has been renamed.\n\nFile: {file.relativePath}",
 	}
 
@@ -445,27 +445,27 @@ func addMovieTemplates(templates map[string]*NotificationTemplate) {
 func addMovieLifecycleTemplates(templates map[string]*NotificationTemplate) {
 	templates["movieAdded"] = &NotificationTemplate{
 		EventType: "movieAdded",
-		Subject:   "{movie.title} ({movie.year}) - Added",
-		Body: "Movie '{movie.title} ({movie.year})' has been added to Radarr.\n\n" +
+		Subject:   "{server} - {movie.title} ({movie.year}) - Added",
+		Body: "Movie '{movie.title} ({movie.year})' has been added to {server}.\n\n" +
 			"{if movie.overview}Overview: {movie.overview}\n{/if}Status: {movie.status}\nPath: {movie.path}",
-		DefaultSubject: "{movie.title} ({movie.year}) - Added",
-		DefaultBody: "Movie '{movie.title} ({movie.year})' has been added to Radarr.\n\n" +
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - Added",
+		DefaultBody: "Movie '{movie.title} ({movie.year})' has been added to {server}.\n\n" +
 			"{if movie.overview}Overview: {movie.overview}\n{/if}Status: {movie.status}\nPath: {movie.path}",
 	}
 
 	templates["movieDelete"] = &NotificationTemplate{
 		EventType:      "movieDelete",
-		Subject:        "{movie.title} ({movie.year}) - Deleted",
-		Body:           "Movie '{movie.title} ({movie.year})' has been deleted from Radarr.",
-		DefaultSubject: "{movie.title} ({movie.year}) - Deleted",
-		DefaultBody:    "Movie '{movie.title} ({movie.year})' has been deleted from Radarr.",
+		Subject:        "{server} - {movie.title} ({movie.year}) - Deleted",
+		Body:           "Movie '{movie.title} ({movie.year})' has been deleted from {server}.",
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - Deleted",
+		DefaultBody:    "Movie '{movie.title} ({movie.year})' has been deleted from {server}.",
 	}
 
 	templates["movieFileDelete"] = &NotificationTemplate{
 		EventType:      "movieFileDelete",
-		Subject:        "{movie.title} ({movie.year}) - File Deleted",
+		Subject:        "{server} - {movie.title} ({movie.year}) - File Deleted",
 		Body:           "Movie file for '{movie.title} ({movie.year})' has been deleted.\n\nFile: {file.relativePath}",
-		DefaultSubject: "{movie.title} ({movie.year}) - File Deleted",
+		DefaultSubject: "{server} - {movie.title} ({movie.year}) - File Deleted",
 		DefaultBody:    "Movie file for '{movie.title} ({movie.year})' has been deleted.\n\nFile: {file.relativePath}",
 	}
 }
@@ -474,32 +474,32 @@ func addMovieLifecycleTemplates(templates map[string]*NotificationTemplate) {
 func addSystemTemplates(templates map[string]*NotificationTemplate) {
 	templates["health"] = &NotificationTemplate{
 		EventType: "health",
-		Subject:   "Radarr Health Issue - {health.type}",
-		Body: "A health issue has been detected in Radarr.\n\n" +
+		Subject:   "{server} - Health Issue - {health.type}",
+		Body: "A health issue has been detected in {server}.\n\n" +
 			"Type: {health.type}\nStatus: {health.status}\nMessage: {health.message}\n\n" +
 			"{if health.wikiUrl}More info: {health.wikiUrl}{/if}",
-		DefaultSubject: "Radarr Health Issue - {health.type}",
-		DefaultBody: "A health issue has been detected in Radarr.\n\n" +
+		DefaultSubject: "{server} - Health Issue - {health.type}",
+		DefaultBody: "A health issue has been detected in {server}.\n\n" +
 			"Type: {health.type}\nStatus: {health.status}\nMessage: {health.message}\n\n" +
 			"{if health.wikiUrl}More info: {health.wikiUrl}{/if}",
 	}
 
 	templates["applicationUpdate"] = &NotificationTemplate{
 		EventType: "applicationUpdate",
-		Subject:   "Radarr Application Update Available",
+		Subject:   "{server} - Application Update Available",
 		Body: "A new version of Radarr is available for update.\n\n" +
 			"Current version: {server}\nPlease update when convenient.",
-		DefaultSubject: "Radarr Application Update Available",
+		DefaultSubject: "{server} - Application Update Available",
 		DefaultBody: "A new version of Radarr is available for update.\n\n" +
 			"Current version: {server}\nPlease update when convenient.",
 	}
 
 	templates["test"] = &NotificationTemplate{
 		EventType: "test",
-		Subject:   "Radarr Test Notification",
+		Subject:   "{server} - Test Notification",
 		Body: "This is a test notification from {server} to verify your " +
 			"notification configuration is working correctly.\n\nSent at: {timestamp}",
-		DefaultSubject: "Radarr Test Notification",
+		DefaultSubject: "{server} - Test Notification",
 		DefaultBody: "This is a test notification from {server} to verify your " +
 			"notification configuration is working correctly.\n\nSent at: {timestamp}",
 	}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// outboxPollInterval is how often the dispatcher looks for due events
+	outboxPollInterval = 5 * time.Second
+	// outboxBatchSize is how many due events one poll delivers
+	outboxBatchSize = 50
+	// outboxClaimTimeout is how long a claimed event is left to its dispatcher before another
+	// dispatcher, or this one after a restart, delivers it again
+	outboxClaimTimeout = 2 * time.Minute
+	// outboxMaxAttempts is how many deliveries are tried before an event is given up on
+	outboxMaxAttempts = 10
+	// outboxRetryBaseDelay and outboxRetryMaxDelay bound the wait after a failed delivery, which
+	// doubles with each attempt
+	outboxRetryBaseDelay = 30 * time.Second
+	outboxRetryMaxDelay  = time.Hour
+)
+
+// OutboxSink receives the events delivered from the outbox. An error leaves the event in the
+// outbox to be delivered again, to every sink.
+type OutboxSink func(event *models.NotificationEvent) error
+
+// enqueueOutboxEvent writes event to the outbox within tx, so it is delivered if and only if the
+// change made in tx commits
+func enqueueOutboxEvent(tx *gorm.DB, event *models.NotificationEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox event: %w", err)
+	}
+
+	if err := tx.Create(&models.OutboxEvent{
+		EventType:     event.EventType,
+		Payload:       string(payload),
+		NextAttemptAt: time.Now(),
+	}).Error; err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
+
+// OutboxDispatcher delivers outbox events to its sinks at least once. Instances sharing a
+// database claim events before delivering them, so each event is normally delivered by one.
+type OutboxDispatcher struct {
+	db     *database.Database
+	logger *logger.Logger
+
+	mu     sync.Mutex
+	sinks  map[string]OutboxSink
+	cancel context.CancelFunc
+}
+
+// NewOutboxDispatcher creates an outbox dispatcher without sinks
+func NewOutboxDispatcher(db *database.Database, logger *logger.Logger) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		db:     db,
+		logger: logger,
+		sinks:  make(map[string]OutboxSink),
+	}
+}
+
+// AddSink registers a sink under name, which identifies it in logged delivery failures
+func (d *OutboxDispatcher) AddSink(name string, sink OutboxSink) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sinks[name] = sink
+}
+
+// Start delivers due events in the background until ctx is done or Stop is called
+func (d *OutboxDispatcher) Start(ctx context.Context) {
+	if d.db == nil {
+		return
+	}
+
+	d.mu.Lock()
+	if d.cancel != nil {
+		d.mu.Unlock()
+		return
+	}
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(outboxPollInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := d.DispatchDue(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				d.logger.Error("Failed to dispatch outbox events", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops background delivery; events not yet delivered stay in the outbox for the next start
+func (d *OutboxDispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+}
+
+// DispatchDue delivers the events that are due and returns how many were delivered
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	if d.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	var due []models.OutboxEvent
+	if err := d.db.GORM.WithContext(ctx).
+		Where("next_attempt_at <= ? AND attempts < ?", time.Now(), outboxMaxAttempts).
+		Order("id").Limit(outboxBatchSize).Find(&due).Error; err != nil {
+		return 0, fmt.Errorf("failed to load outbox events: %w", err)
+	}
+
+	delivered := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		claimed, err := d.claim(ctx, &due[i])
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if d.deliver(ctx, &due[i]) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// claim takes an event for delivery by counting the attempt and pushing out its next attempt,
+// provided no other dispatcher counted an attempt since the event was loaded
+func (d *OutboxDispatcher) claim(ctx context.Context, event *models.OutboxEvent) (bool, error) {
+	result := d.db.GORM.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ? AND attempts = ?", event.ID, event.Attempts).
+		Updates(map[string]interface{}{
+			"attempts":        event.Attempts + 1,
+			"next_attempt_at": time.Now().Add(outboxClaimTimeout),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim outbox event: %w", result.Error)
+	}
+	event.Attempts++
+	return result.RowsAffected == 1, nil
+}
+
+// deliver hands a claimed event to every sink, deleting it once all accept it and scheduling a
+// retry otherwise
+func (d *OutboxDispatcher) deliver(ctx context.Context, event *models.OutboxEvent) bool {
+	var notification models.NotificationEvent
+	err := json.Unmarshal([]byte(event.Payload), &notification)
+	if err == nil {
+		err = d.sendToSinks(&notification)
+	}
+
+	if err == nil {
+		if err := d.db.GORM.WithContext(ctx).Delete(&models.OutboxEvent{}, event.ID).Error; err != nil {
+			// Delivered again once the claim times out, which at-least-once delivery allows
+			d.logger.Error("Failed to remove delivered outbox event", "id", event.ID, "error", err)
+		}
+		return true
+	}
+
+	if event.Attempts >= outboxMaxAttempts {
+		d.logger.Error("Giving up on outbox event", "id", event.ID, "eventType", event.EventType,
+			"attempts", event.Attempts, "error", err)
+	} else {
+		d.logger.Warn("Failed to deliver outbox event, retrying later", "id", event.ID,
+			"eventType", event.EventType, "attempts", event.Attempts, "error", err)
+	}
+
+	if updateErr := d.db.GORM.WithContext(ctx).Model(&models.OutboxEvent{}).Where("id = ?", event.ID).
+		Updates(map[string]interface{}{
+			"last_error":      err.Error(),
+			"next_attempt_at": time.Now().Add(outboxRetryDelay(event.Attempts)),
+		}).Error; updateErr != nil {
+		d.logger.Error("Failed to schedule outbox event retry", "id", event.ID, "error", updateErr)
+	}
+	return false
+}
+
+// sendToSinks delivers an event to every sink, returning the failures joined
+func (d *OutboxDispatcher) sendToSinks(event *models.NotificationEvent) error {
+	d.mu.Lock()
+	sinks := make(map[string]OutboxSink, len(d.sinks))
+	for name, sink := range d.sinks {
+		sinks[name] = sink
+	}
+	d.mu.Unlock()
+
+	var errs []error
+	for name, sink := range sinks {
+		if err := sink(event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// outboxRetryDelay returns the wait after the given number of failed attempts
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBaseDelay
+	for i := 1; i < attempts && delay < outboxRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxRetryMaxDelay)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRetryDelay(t *testing.T) {
+	assert.Equal(t, outboxRetryBaseDelay, outboxRetryDelay(1))
+	assert.Equal(t, 2*outboxRetryBaseDelay, outboxRetryDelay(2))
+	assert.Equal(t, 8*outboxRetryBaseDelay, outboxRetryDelay(4))
+	assert.Equal(t, outboxRetryMaxDelay, outboxRetryDelay(outboxMaxAttempts))
+}
+
+func TestOutboxDispatcher_DispatchDue(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	dispatcher := NewOutboxDispatcher(db, logger)
+	var received []string
+	failing := true
+	dispatcher.AddSink("test", func(event *models.NotificationEvent) error {
+		if failing {
+			return errors.New("unreachable")
+		}
+		received = append(received, event.SourceTitle)
+		return nil
+	})
+
+	require.NoError(t, enqueueOutboxEvent(db.GORM, &models.NotificationEvent{
+		EventType: NotificationEventGrab, SourceTitle: "The.Matrix.1999.1080p",
+	}))
+
+	// A failed delivery stays in the outbox until its retry is due
+	delivered, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	var event models.OutboxEvent
+	require.NoError(t, db.GORM.First(&event).Error)
+	assert.Equal(t, 1, event.Attempts)
+	assert.Equal(t, "test: unreachable", event.LastError)
+	assert.True(t, event.NextAttemptAt.After(time.Now()))
+
+	failing = false
+	require.NoError(t, db.GORM.Model(&event).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	delivered, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{"The.Matrix.1999.1080p"}, received)
+
+	var remaining int64
+	require.NoError(t, db.GORM.Model(&models.OutboxEvent{}).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// applyPageCursor orders a query newest first by timeColumn with idColumn as a tie-breaker and,
+// when a cursor is given, restricts it to the records after the cursor. One record beyond limit
+// is requested so trimCursorPage can tell whether another page follows.
+func applyPageCursor(query *gorm.DB, timeColumn, idColumn string, cursor *models.PageCursor, limit int) *gorm.DB {
+	if cursor != nil {
+		query = query.Where(
+			"("+timeColumn+" < ? OR ("+timeColumn+" = ? AND "+idColumn+" < ?))",
+			cursor.Time, cursor.Time, cursor.ID,
+		)
+	}
+
+	return query.Order(timeColumn + " DESC").Order(idColumn + " DESC").Limit(limit + 1)
+}
+
+// trimCursorPage drops the lookahead record fetched by applyPageCursor and returns the cursor
+// for the following page, or nil when records holds the last page
+func trimCursorPage[T any](records []T, limit int, position func(*T) (time.Time, int)) ([]T, *models.PageCursor) {
+	if len(records) <= limit {
+		return records, nil
+	}
+
+	records = records[:limit]
+	t, id := position(&records[limit-1])
+	return records, models.NewPageCursor(t, id)
+}
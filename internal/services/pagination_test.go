@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor_RoundTrip(t *testing.T) {
+	cursor := models.NewPageCursor(time.Date(2026, time.October, 15, 12, 30, 0, 123456000, time.UTC), 42)
+
+	parsed, err := models.ParsePageCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.Time.Equal(parsed.Time))
+	assert.Equal(t, 42, parsed.ID)
+
+	parsed, err = models.ParsePageCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, parsed)
+
+	_, err = models.ParsePageCursor("not a cursor")
+	assert.Error(t, err)
+}
+
+func TestTrimCursorPage(t *testing.T) {
+	base := time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)
+	records := []models.History{{ID: 3, Date: base}, {ID: 2, Date: base}, {ID: 1, Date: base}}
+	position := func(h *models.History) (time.Time, int) { return h.Date, h.ID }
+
+	page, next := trimCursorPage(records, 2, position)
+	assert.Len(t, page, 2)
+	require.NotNil(t, next)
+	assert.Equal(t, 2, next.ID)
+
+	page, next = trimCursorPage(records, 3, position)
+	assert.Len(t, page, 3)
+	assert.Nil(t, next)
+}
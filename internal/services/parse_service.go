@@ -17,15 +17,16 @@ import (
 
 // ParseService handles release name parsing and caching
 type ParseService struct {
-	db     *database.Database
-	logger *logger.Logger
+	db                  *database.Database
+	logger              *logger.Logger
+	movieService        *MovieService
+	sceneMappingService *SceneMappingService
+	qualityService      *QualityService
 
 	// Regular expressions for parsing release names
 	titleYearRegex    *regexp.Regexp
 	qualityRegex      *regexp.Regexp
 	releaseGroupRegex *regexp.Regexp
-	languageRegex     *regexp.Regexp
-	editionRegex      *regexp.Regexp
 	sourceRegex       *regexp.Regexp
 	codecRegex        *regexp.Regexp
 	resolutionRegex   *regexp.Regexp
@@ -45,11 +46,16 @@ func (ParseCacheEntry) TableName() string {
 	return "parse_cache"
 }
 
-// NewParseService creates a new parse service
-func NewParseService(db *database.Database, logger *logger.Logger) *ParseService {
+// NewParseService creates a new parse service that matches releases named under a scene mapping
+// to the mapped movie
+func NewParseService(
+	db *database.Database, logger *logger.Logger, movieService *MovieService, sceneMappingService *SceneMappingService,
+) *ParseService {
 	service := &ParseService{
-		db:     db,
-		logger: logger,
+		db:                  db,
+		logger:              logger,
+		movieService:        movieService,
+		sceneMappingService: sceneMappingService,
 	}
 
 	// Initialize regular expressions for parsing
@@ -58,10 +64,18 @@ func NewParseService(db *database.Database, logger *logger.Logger) *ParseService
 	return service
 }
 
+// UseQualityService has parse results list the custom formats the release matches, scored by
+// the matched movie's quality profile
+func (s *ParseService) UseQualityService(qualityService *QualityService) {
+	s.qualityService = qualityService
+}
+
 // ParseReleaseTitle parses a release title and returns movie information
 func (s *ParseService) ParseReleaseTitle(ctx context.Context, releaseTitle string) (*models.ParseResult, error) {
-	// Check cache first
+	// Check cache first. Custom formats change independently of the title, so they're matched
+	// again rather than cached.
 	if cached, err := s.getCachedResult(ctx, releaseTitle); err == nil {
+		s.matchCustomFormats(cached)
 		return cached, nil
 	}
 
@@ -84,6 +98,7 @@ func (s *ParseService) ParseReleaseTitle(ctx context.Context, releaseTitle strin
 	if err := s.cacheResult(ctx, releaseTitle, result); err != nil {
 		s.logger.Warn("Failed to cache parse result", "title", releaseTitle, "error", err)
 	}
+	s.matchCustomFormats(result)
 
 	s.logger.Debug(
 		"Parsed release title",
@@ -92,6 +107,75 @@ func (s *ParseService) ParseReleaseTitle(ctx context.Context, releaseTitle strin
 	return result, nil
 }
 
+// matchCustomFormats fills in the custom formats the parsed release matches and, when it matched
+// a movie, their score in the movie's quality profile
+func (s *ParseService) matchCustomFormats(result *models.ParseResult) {
+	result.CustomFormats = nil
+	result.CustomFormatScore = 0
+	if s.qualityService == nil || result.ParsedMovieInfo == nil {
+		return
+	}
+
+	formats, err := s.qualityService.MatchCustomFormats(customFormatInput(result))
+	if err != nil {
+		s.logger.Warn("Failed to match custom formats", "title", result.Title, "error", err)
+		return
+	}
+	result.CustomFormats = formats
+
+	if result.Movie == nil || len(formats) == 0 {
+		return
+	}
+	profile, err := s.qualityService.GetQualityProfileByID(result.Movie.QualityProfileID)
+	if err != nil {
+		s.logger.Warn("Failed to load quality profile for custom format score",
+			"profileId", result.Movie.QualityProfileID, "error", err)
+		return
+	}
+	result.CustomFormatScore = profile.CustomFormatScore(formats)
+}
+
+// TestCustomFormats parses a sample release title and evaluates it against every custom format,
+// with the outcome of each specification
+func (s *ParseService) TestCustomFormats(ctx context.Context, releaseTitle string) (
+	*models.CustomFormatTestResult, error,
+) {
+	if s.qualityService == nil {
+		return nil, fmt.Errorf("custom formats not available")
+	}
+
+	result, err := s.ParseReleaseTitle(ctx, releaseTitle)
+	if err != nil {
+		return nil, err
+	}
+	formats, err := s.qualityService.EvaluateCustomFormats(customFormatInput(result))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CustomFormatTestResult{
+		Title:           releaseTitle,
+		ParsedMovieInfo: result.ParsedMovieInfo,
+		CustomFormats:   formats,
+	}, nil
+}
+
+// customFormatInput returns what custom formats are evaluated against for a parse result
+func customFormatInput(result *models.ParseResult) *models.CustomFormatInput {
+	input := &models.CustomFormatInput{Title: result.Title}
+	if parsed := result.ParsedMovieInfo; parsed != nil {
+		input.Edition = parsed.Edition
+		input.ReleaseGroup = parsed.ReleaseGroup
+		input.Quality = parsed.Quality.Quality
+		input.Languages = resolveOriginalLanguage(parsed.Languages, result.Movie)
+		input.Year = parsed.Year
+	}
+	if result.Movie != nil {
+		input.OriginalLanguage = result.Movie.OriginalLanguage
+	}
+	return input
+}
+
 // ParseMultipleTitles parses multiple release titles
 func (s *ParseService) ParseMultipleTitles(ctx context.Context, titles []string) ([]*models.ParseResult, error) {
 	results := make([]*models.ParseResult, 0, len(titles))
@@ -155,16 +239,6 @@ func (s *ParseService) initializeRegexes() {
 	// Release group (usually at the end in brackets or after a dash)
 	s.releaseGroupRegex = regexp.MustCompile(`(?i)[\[\(]?([a-z0-9_\-\.]+)[\]\)]?$`)
 
-	// Language patterns
-	languagePattern := `(?i)\b(?:english|spanish|french|german|italian|portuguese|russian|chinese|` +
-		`japanese|korean|hindi|arabic|dutch|swedish|norwegian|danish|finnish)\b`
-	s.languageRegex = regexp.MustCompile(languagePattern)
-
-	// Edition patterns
-	editionPattern := `(?i)\b(?:director'?s?\.?cut|extended|unrated|theatrical|` +
-		`remastered|criterion|special\.edition)\b`
-	s.editionRegex = regexp.MustCompile(editionPattern)
-
 	// Source patterns
 	sourcePattern := `(?i)\b(?:bluray|bdrip|web-dl|webrip|hdtv|dvdrip|ts|cam|hdcam|` +
 		`r5|dvdscr|workprint|ppv)\b`
@@ -185,7 +259,6 @@ func (s *ParseService) parseTitle(title string) *models.ParsedMovieInfo {
 		ReleaseTitle:       title,
 		SimpleReleaseTitle: cleanTitle,
 		MovieTitles:        []string{},
-		Languages:          []string{"english"}, // default
 	}
 
 	// Extract title and year
@@ -219,15 +292,14 @@ func (s *ParseService) parseTitle(title string) *models.ParsedMovieInfo {
 		parsed.ReleaseGroup = matches[1]
 	}
 
-	// Extract language
-	if matches := s.languageRegex.FindStringSubmatch(title); len(matches) >= 1 {
-		parsed.Languages = []string{strings.ToLower(matches[0])}
+	// Extract languages, English when none are tagged. Without an indexer's multi languages, MULTi
+	// releases are taken to have the original audio besides the languages named.
+	for _, name := range languageNames(parseReleaseLanguages(title, nil).audio) {
+		parsed.Languages = append(parsed.Languages, strings.ToLower(name))
 	}
 
 	// Extract edition
-	if matches := s.editionRegex.FindStringSubmatch(title); len(matches) >= 1 {
-		parsed.Edition = matches[0]
-	}
+	parsed.Edition = parseEdition(title)
 
 	// Generate original title from parsed info
 	if parsed.PrimaryMovieTitle != "" {
@@ -313,48 +385,33 @@ func (s *ParseService) cleanMovieTitle(title string) string {
 	return title
 }
 
-// findMatchingMovie finds a movie that matches the parsed information
-func (s *ParseService) findMatchingMovie(ctx context.Context, parsed *models.ParsedMovieInfo) (*models.Movie, error) {
+// findMatchingMovie finds a movie that matches the parsed information.
+// Scene mappings are checked first, so releases named differently from the TMDB title
+// still match; otherwise matching runs against the in-memory movie index, so releases
+// that match no movie in the library are resolved without a database query.
+func (s *ParseService) findMatchingMovie(_ context.Context, parsed *models.ParsedMovieInfo) (*models.Movie, error) {
 	if parsed.PrimaryMovieTitle == "" {
 		return nil, fmt.Errorf("no movie title to search for")
 	}
 
-	var movie models.Movie
-	query := s.db.GORM.WithContext(ctx)
-
-	// First try: exact title and year match
-	if parsed.Year > 0 {
-		err := query.Where("title ILIKE ? AND year = ?", parsed.PrimaryMovieTitle, parsed.Year).
-			First(&movie).Error
-		if err == nil {
-			return &movie, nil
+	if s.sceneMappingService != nil {
+		if tmdbID, found := s.sceneMappingService.FindTmdbID(parsed.PrimaryMovieTitle, parsed.Year); found {
+			if movie, err := s.movieService.GetByTmdbID(tmdbID); err == nil {
+				parsed.TmdbID = tmdbID
+				return movie, nil
+			}
 		}
 	}
 
-	// Second try: fuzzy title match with year
-	if parsed.Year > 0 {
-		fuzzyTitle := fmt.Sprintf("%%%s%%", parsed.PrimaryMovieTitle)
-		err := query.Where("title ILIKE ? AND year = ?", fuzzyTitle, parsed.Year).
-			First(&movie).Error
-		if err == nil {
-			return &movie, nil
-		}
-	}
-
-	// Third try: exact title match without year
-	err := query.Where("title ILIKE ?", parsed.PrimaryMovieTitle).First(&movie).Error
-	if err == nil {
-		return &movie, nil
+	movies, err := s.movieService.FindByTitle(parsed.PrimaryMovieTitle, parsed.Year)
+	if err != nil {
+		return nil, err
 	}
-
-	// Fourth try: fuzzy title match without year
-	fuzzyTitle := fmt.Sprintf("%%%s%%", parsed.PrimaryMovieTitle)
-	err = query.Where("title ILIKE ?", fuzzyTitle).First(&movie).Error
-	if err == nil {
-		return &movie, nil
+	if len(movies) == 0 {
+		return nil, fmt.Errorf("no matching movie found")
 	}
 
-	return nil, fmt.Errorf("no matching movie found")
+	return &movies[0], nil
 }
 
 // getCachedResult retrieves a cached parse result
@@ -13,7 +13,7 @@ func TestParseService(t *testing.T) {
 	db, logger := setupTestDB(t)
 	defer cleanupTestDB(db)
 
-	service := NewParseService(db, logger)
+	service := NewParseService(db, logger, NewMovieService(db, logger), nil)
 
 	t.Run("ParseReleaseTitle", func(t *testing.T) {
 		testParseReleaseTitle(t, service)
@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"runtime"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
@@ -11,22 +15,132 @@ import (
 	"github.com/radarr/radarr-go/internal/models"
 )
 
-// PerformanceMonitor implements performance metrics collection and monitoring
+// maxLatencySamples bounds the API request durations kept between two samples; beyond it a
+// random sample is replaced so the percentiles stay representative
+const maxLatencySamples = 10000
+
+// PerformanceMonitor implements performance metrics collection and monitoring. It samples the
+// process every interval once started and keeps the state needed to report deltas between
+// samples, such as CPU time, GC pauses, and API request latencies.
 type PerformanceMonitor struct {
-	db     *database.Database
-	logger *logger.Logger
+	db       *database.Database
+	logger   *logger.Logger
+	interval time.Duration
+
+	mu           sync.Mutex
+	lastSample   time.Time
+	lastCPUTime  time.Duration
+	lastNumGC    uint32
+	lastPauseNs  uint64
+	lastDBWaits  int64
+	latencies    []time.Duration
+	requestCount int
+	cancel       context.CancelFunc
+	// bandwidth reports the download clients' transfer speeds for each sample, when set
+	bandwidth bandwidthSource
+}
+
+// bandwidthSource reports the combined transfer speeds of the download clients
+type bandwidthSource interface {
+	GetBandwidth(ctx context.Context) (*models.DownloadClientBandwidth, error)
 }
 
-// NewPerformanceMonitor creates a new performance monitor
-func NewPerformanceMonitor(db *database.Database, logger *logger.Logger) *PerformanceMonitor {
+// NewPerformanceMonitor creates a new performance monitor sampling every interval
+func NewPerformanceMonitor(db *database.Database, interval time.Duration, logger *logger.Logger) *PerformanceMonitor {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	cpuTime, _ := processCPUTime() //nolint:errcheck // A missing baseline only skews the first sample
+
 	return &PerformanceMonitor{
-		db:     db,
-		logger: logger,
+		db:          db,
+		logger:      logger,
+		interval:    interval,
+		lastSample:  time.Now(),
+		lastCPUTime: cpuTime,
+		lastNumGC:   m.NumGC,
+		lastPauseNs: m.PauseTotalNs,
+	}
+}
+
+// SetBandwidthSource sets where samples read the download clients' transfer speeds from
+func (pm *PerformanceMonitor) SetBandwidthSource(source bandwidthSource) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.bandwidth = source
+}
+
+// Start samples and records metrics every interval until Stop is called or ctx ends
+func (pm *PerformanceMonitor) Start(ctx context.Context) {
+	if pm.db == nil || pm.interval <= 0 {
+		return
+	}
+
+	pm.mu.Lock()
+	if pm.cancel != nil {
+		pm.mu.Unlock()
+		return
+	}
+	ctx, pm.cancel = context.WithCancel(ctx)
+	pm.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(pm.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				metrics, err := pm.CollectMetrics(ctx)
+				if err != nil {
+					pm.logger.Warnw("Failed to collect performance metrics", "error", err)
+					continue
+				}
+				if err := pm.RecordMetrics(metrics); err != nil {
+					pm.logger.Warnw("Failed to record performance metrics", "error", err)
+				}
+			}
+		}
+	}()
+
+	pm.logger.Infow("Performance monitoring started", "interval", pm.interval)
+}
+
+// Stop ends the sampling loop started by Start
+func (pm *PerformanceMonitor) Stop() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.cancel != nil {
+		pm.cancel()
+		pm.cancel = nil
+	}
+}
+
+// ObserveRequest records the duration of one API request for the next sample's percentiles
+func (pm *PerformanceMonitor) ObserveRequest(duration time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.requestCount++
+	if len(pm.latencies) < maxLatencySamples {
+		pm.latencies = append(pm.latencies, duration)
+		return
+	}
+	// Reservoir sampling keeps every request equally likely to be represented
+	if i := rand.IntN(pm.requestCount); i < maxLatencySamples { // #nosec G404 -- Sampling, not security sensitive
+		pm.latencies[i] = duration
 	}
 }
 
-// CollectMetrics implements PerformanceMonitorInterface
+// CollectMetrics implements PerformanceMonitorInterface. CPU usage, GC activity, database
+// connection waits, and API latencies cover the period since the previous sample.
 func (pm *PerformanceMonitor) CollectMetrics(ctx context.Context) (*models.PerformanceMetrics, error) {
+	if pm.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
 	startTime := time.Now()
 
 	// Collect memory metrics
@@ -48,17 +162,56 @@ func (pm *PerformanceMonitor) CollectMetrics(ctx context.Context) (*models.Perfo
 	diskAvailableGB := 100.0 // Placeholder
 	diskTotalGB := 200.0     // Placeholder
 
-	// TODO: Implement actual CPU usage measurement
-	cpuUsagePercent := 25.0 // Placeholder
+	// TODO: Implement queue size measurement
+	queueSize := 5 // Placeholder
+
+	// Slow queries recorded by the database logger since the previous sample
+	slowQueryCount := 0
+	if pm.db.SlowQueries != nil {
+		slowQueryCount = pm.db.SlowQueries.TakeCount()
+	}
 
-	// TODO: Implement API latency measurement
-	apiLatencyMs := 50.0 // Placeholder
+	pool := pm.databasePoolStats()
+
+	pm.mu.Lock()
+	source := pm.bandwidth
+	pm.mu.Unlock()
+	var downloadSpeed, uploadSpeed int64
+	if source != nil {
+		if bandwidth, err := source.GetBandwidth(ctx); err != nil {
+			pm.logger.Warnw("Failed to read download client bandwidth", "error", err)
+		} else {
+			downloadSpeed, uploadSpeed = bandwidth.DownloadSpeed, bandwidth.UploadSpeed
+		}
+	}
 
-	// TODO: Implement active connections count
-	activeConnections := 10 // Placeholder
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
 
-	// TODO: Implement queue size measurement
-	queueSize := 5 // Placeholder
+	elapsed := startTime.Sub(pm.lastSample)
+	pm.lastSample = startTime
+
+	cpuUsagePercent := 0.0
+	if cpuTime, err := processCPUTime(); err == nil {
+		if elapsed > 0 {
+			cpuUsagePercent = float64(cpuTime-pm.lastCPUTime) / float64(elapsed) /
+				float64(runtime.NumCPU()) * 100
+		}
+		pm.lastCPUTime = cpuTime
+	}
+
+	gcCount := int(m.NumGC - pm.lastNumGC)
+	gcPauseMs := float64(m.PauseTotalNs-pm.lastPauseNs) / 1e6
+	pm.lastNumGC = m.NumGC
+	pm.lastPauseNs = m.PauseTotalNs
+
+	dbWaitCount := pool.WaitCount - pm.lastDBWaits
+	pm.lastDBWaits = pool.WaitCount
+
+	latency := summarizeLatencies(pm.latencies)
+	requestCount := pm.requestCount
+	pm.latencies = pm.latencies[:0]
+	pm.requestCount = 0
 
 	metrics := &models.PerformanceMetrics{
 		CPUUsagePercent:   cpuUsagePercent,
@@ -68,15 +221,87 @@ func (pm *PerformanceMonitor) CollectMetrics(ctx context.Context) (*models.Perfo
 		DiskAvailableGB:   diskAvailableGB,
 		DiskTotalGB:       diskTotalGB,
 		DatabaseLatencyMs: dbLatency,
-		APILatencyMs:      apiLatencyMs,
-		ActiveConnections: activeConnections,
+		APILatencyMs:      latency.mean,
+		APILatencyP50Ms:   latency.p50,
+		APILatencyP95Ms:   latency.p95,
+		APILatencyP99Ms:   latency.p99,
+		APIRequestCount:   requestCount,
+		ActiveConnections: pool.InUse,
+		DBOpenConnections: pool.Open,
+		DBWaitCount:       int(dbWaitCount),
+		Goroutines:        runtime.NumGoroutine(),
+		GCCount:           gcCount,
+		GCPauseMs:         gcPauseMs,
 		QueueSize:         queueSize,
+		SlowQueryCount:    slowQueryCount,
 		Timestamp:         startTime,
+		DownloadSpeed:     downloadSpeed,
+		UploadSpeed:       uploadSpeed,
 	}
 
 	return metrics, nil
 }
 
+// poolStats is a snapshot of the database connection pools
+type poolStats struct {
+	Open      int
+	InUse     int
+	WaitCount int64
+}
+
+// databasePoolStats combines the database/sql pool with the pgx pool used by sqlc on Postgres
+func (pm *PerformanceMonitor) databasePoolStats() poolStats {
+	var stats poolStats
+	if pm.db.DB != nil {
+		sqlStats := pm.db.DB.Stats()
+		stats.Open += sqlStats.OpenConnections
+		stats.InUse += sqlStats.InUse
+		stats.WaitCount += sqlStats.WaitCount
+	}
+	if pm.db.PgxPool != nil {
+		pgxStats := pm.db.PgxPool.Stat()
+		stats.Open += int(pgxStats.TotalConns())
+		stats.InUse += int(pgxStats.AcquiredConns())
+		stats.WaitCount += pgxStats.EmptyAcquireCount()
+	}
+	return stats
+}
+
+// latencySummary holds API latency statistics in milliseconds
+type latencySummary struct {
+	mean, p50, p95, p99 float64
+}
+
+// summarizeLatencies computes the mean and percentiles of the recorded request durations
+func summarizeLatencies(latencies []time.Duration) latencySummary {
+	if len(latencies) == 0 {
+		return latencySummary{}
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	percentile := func(p float64) float64 {
+		index := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if index < 0 {
+			index = 0
+		}
+		return float64(sorted[index].Microseconds()) / 1000
+	}
+
+	return latencySummary{
+		mean: float64(total.Microseconds()) / float64(len(sorted)) / 1000,
+		p50:  percentile(0.50),
+		p95:  percentile(0.95),
+		p99:  percentile(0.99),
+	}
+}
+
 // measureDatabaseLatency measures the latency of a simple database operation
 func (pm *PerformanceMonitor) measureDatabaseLatency(ctx context.Context) (float64, error) {
 	start := time.Now()
@@ -94,6 +319,10 @@ func (pm *PerformanceMonitor) measureDatabaseLatency(ctx context.Context) (float
 
 // RecordMetrics implements PerformanceMonitorInterface
 func (pm *PerformanceMonitor) RecordMetrics(metrics *models.PerformanceMetrics) error {
+	if pm.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
 	if err := pm.db.GORM.Create(metrics).Error; err != nil {
 		return fmt.Errorf("failed to record performance metrics: %w", err)
 	}
@@ -103,6 +332,10 @@ func (pm *PerformanceMonitor) RecordMetrics(metrics *models.PerformanceMetrics)
 
 // GetMetrics implements PerformanceMonitorInterface
 func (pm *PerformanceMonitor) GetMetrics(since, until *time.Time, limit int) ([]models.PerformanceMetrics, error) {
+	if pm.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
 	query := pm.db.GORM.Model(&models.PerformanceMetrics{})
 
 	if since != nil {
@@ -126,6 +359,10 @@ func (pm *PerformanceMonitor) GetMetrics(since, until *time.Time, limit int) ([]
 
 // GetAverageMetrics implements PerformanceMonitorInterface
 func (pm *PerformanceMonitor) GetAverageMetrics(since, until time.Time) (*models.PerformanceMetrics, error) {
+	if pm.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
 	var result struct {
 		AvgCPUUsage      float64 `gorm:"column:avg_cpu_usage"`
 		AvgMemoryUsage   float64 `gorm:"column:avg_memory_usage"`
@@ -137,6 +374,12 @@ func (pm *PerformanceMonitor) GetAverageMetrics(since, until time.Time) (*models
 		AvgAPILatency    float64 `gorm:"column:avg_api_latency"`
 		AvgConnections   float64 `gorm:"column:avg_connections"`
 		AvgQueueSize     float64 `gorm:"column:avg_queue_size"`
+		AvgSlowQueries   float64 `gorm:"column:avg_slow_queries"`
+		AvgAPILatencyP95 float64 `gorm:"column:avg_api_latency_p95"`
+		AvgGoroutines    float64 `gorm:"column:avg_goroutines"`
+		AvgGCPause       float64 `gorm:"column:avg_gc_pause"`
+		AvgDownloadSpeed float64 `gorm:"column:avg_download_speed"`
+		AvgUploadSpeed   float64 `gorm:"column:avg_upload_speed"`
 	}
 
 	err := pm.db.GORM.Model(&models.PerformanceMetrics{}).
@@ -150,7 +393,13 @@ func (pm *PerformanceMonitor) GetAverageMetrics(since, until time.Time) (*models
 			AVG(database_latency_ms) as avg_db_latency,
 			AVG(api_latency_ms) as avg_api_latency,
 			AVG(active_connections) as avg_connections,
-			AVG(queue_size) as avg_queue_size
+			AVG(queue_size) as avg_queue_size,
+			AVG(slow_query_count) as avg_slow_queries,
+			AVG(api_latency_p95_ms) as avg_api_latency_p95,
+			AVG(goroutines) as avg_goroutines,
+			AVG(gc_pause_ms) as avg_gc_pause,
+			AVG(download_speed) as avg_download_speed,
+			AVG(upload_speed) as avg_upload_speed
 		`).
 		Where("timestamp BETWEEN ? AND ?", since, until).
 		Scan(&result).Error
@@ -170,6 +419,12 @@ func (pm *PerformanceMonitor) GetAverageMetrics(since, until time.Time) (*models
 		APILatencyMs:      result.AvgAPILatency,
 		ActiveConnections: int(result.AvgConnections),
 		QueueSize:         int(result.AvgQueueSize),
+		SlowQueryCount:    int(result.AvgSlowQueries),
+		APILatencyP95Ms:   result.AvgAPILatencyP95,
+		Goroutines:        int(result.AvgGoroutines),
+		GCPauseMs:         result.AvgGCPause,
+		DownloadSpeed:     int64(result.AvgDownloadSpeed),
+		UploadSpeed:       int64(result.AvgUploadSpeed),
 		Timestamp:         since, // Use start time as reference
 	}
 
@@ -177,7 +432,7 @@ func (pm *PerformanceMonitor) GetAverageMetrics(since, until time.Time) (*models
 }
 
 // DetectPerformanceIssues implements PerformanceMonitorInterface
-func (pm *PerformanceMonitor) DetectPerformanceIssues(_ context.Context) ([]models.HealthIssue, error) {
+func (pm *PerformanceMonitor) DetectPerformanceIssues(_ context.Context) ([]models.HealthIssueV2, error) {
 	// Get recent metrics (last 15 minutes)
 	since := time.Now().Add(-15 * time.Minute)
 	metrics, err := pm.GetMetrics(&since, nil, 10)
@@ -186,7 +441,7 @@ func (pm *PerformanceMonitor) DetectPerformanceIssues(_ context.Context) ([]mode
 	}
 
 	if len(metrics) == 0 {
-		return []models.HealthIssue{}, nil
+		return []models.HealthIssueV2{}, nil
 	}
 
 	// Analyze latest metrics
@@ -200,7 +455,16 @@ func (pm *PerformanceMonitor) DetectPerformanceIssues(_ context.Context) ([]mode
 	pm.checkAPILatencyIssues(latest, &issues)
 	pm.checkQueueSizeIssues(latest, &issues)
 
-	return issues, nil
+	issuesV2 := make([]models.HealthIssueV2, 0, len(issues))
+	for _, issue := range issues {
+		issuesV2 = append(issuesV2, models.HealthIssueV2{
+			Type:     string(issue.Type),
+			Source:   issue.Source,
+			Severity: string(issue.Severity),
+			Message:  issue.Message,
+		})
+	}
+	return issuesV2, nil
 }
 
 func (pm *PerformanceMonitor) checkCPUIssues(latest models.PerformanceMetrics, issues *[]models.HealthIssue) {
@@ -283,19 +547,19 @@ func (pm *PerformanceMonitor) checkDatabaseLatencyIssues(
 }
 
 func (pm *PerformanceMonitor) checkAPILatencyIssues(latest models.PerformanceMetrics, issues *[]models.HealthIssue) {
-	if latest.APILatencyMs > 5000 { // 5 seconds
+	if latest.APILatencyP95Ms > 5000 { // 5 seconds
 		*issues = append(*issues, models.HealthIssue{
 			Type:     models.HealthCheckTypePerformance,
 			Source:   "Performance Monitor",
 			Severity: models.HealthSeverityError,
-			Message:  fmt.Sprintf("High API latency: %.1f ms", latest.APILatencyMs),
+			Message:  fmt.Sprintf("High API latency: %.1f ms at the 95th percentile", latest.APILatencyP95Ms),
 		})
-	} else if latest.APILatencyMs > 2000 { // 2 seconds
+	} else if latest.APILatencyP95Ms > 2000 { // 2 seconds
 		*issues = append(*issues, models.HealthIssue{
 			Type:     models.HealthCheckTypePerformance,
 			Source:   "Performance Monitor",
 			Severity: models.HealthSeverityWarning,
-			Message:  fmt.Sprintf("Elevated API latency: %.1f ms", latest.APILatencyMs),
+			Message:  fmt.Sprintf("Elevated API latency: %.1f ms at the 95th percentile", latest.APILatencyP95Ms),
 		})
 	}
 }
@@ -313,6 +577,10 @@ func (pm *PerformanceMonitor) checkQueueSizeIssues(latest models.PerformanceMetr
 
 // CleanupOldMetrics implements PerformanceMonitorInterface
 func (pm *PerformanceMonitor) CleanupOldMetrics(olderThan time.Time) error {
+	if pm.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
 	result := pm.db.GORM.Where("timestamp < ?", olderThan).Delete(&models.PerformanceMetrics{})
 	if result.Error != nil {
 		return fmt.Errorf("failed to cleanup old performance metrics: %w", result.Error)
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeLatencies(t *testing.T) {
+	assert.Equal(t, latencySummary{}, summarizeLatencies(nil))
+
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	summary := summarizeLatencies(latencies)
+	assert.InDelta(t, 50.5, summary.mean, 0.001)
+	assert.InDelta(t, 50, summary.p50, 0.001)
+	assert.InDelta(t, 95, summary.p95, 0.001)
+	assert.InDelta(t, 99, summary.p99, 0.001)
+	assert.Equal(t, 100*time.Millisecond, latencies[0], "the recorded samples are not reordered")
+}
+
+func TestPerformanceMonitor_ObserveRequest(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "error", Output: "none"})
+	monitor := NewPerformanceMonitor(nil, time.Minute, logger)
+
+	for i := 0; i < maxLatencySamples+500; i++ {
+		monitor.ObserveRequest(time.Millisecond)
+	}
+
+	assert.Len(t, monitor.latencies, maxLatencySamples, "samples are capped")
+	assert.Equal(t, maxLatencySamples+500, monitor.requestCount)
+
+	_, err := monitor.CollectMetrics(context.Background())
+	assert.Error(t, err, "collection needs a database")
+}
+
+func TestPerformanceMonitor_CollectMetrics(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	monitor := NewPerformanceMonitor(db, time.Minute, logger)
+	monitor.ObserveRequest(10 * time.Millisecond)
+	monitor.ObserveRequest(30 * time.Millisecond)
+
+	metrics, err := monitor.CollectMetrics(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.APIRequestCount)
+	assert.InDelta(t, 20, metrics.APILatencyMs, 0.001)
+	assert.InDelta(t, 30, metrics.APILatencyP99Ms, 0.001)
+	assert.Positive(t, metrics.Goroutines)
+	assert.Positive(t, metrics.DBOpenConnections)
+	assert.GreaterOrEqual(t, metrics.CPUUsagePercent, 0.0)
+
+	require.NoError(t, monitor.RecordMetrics(metrics))
+	stored, err := monitor.GetMetrics(nil, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, 2, stored[0].APIRequestCount)
+
+	// Request latencies are reset once sampled
+	metrics, err = monitor.CollectMetrics(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, metrics.APIRequestCount)
+}
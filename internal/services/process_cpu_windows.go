@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package services
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user and kernel CPU time consumed by this process
+func processCPUTime() (time.Duration, error) {
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0, err
+	}
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return 0, err
+	}
+
+	// Process times are Filetime values counting 100ns intervals
+	toDuration := func(ft syscall.Filetime) time.Duration {
+		return time.Duration((int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)) * 100)
+	}
+	return toDuration(kernel) + toDuration(user), nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// providerStatusError reports an HTTP status a provider answered with that isn't a success
+type providerStatusError struct {
+	Provider   string
+	StatusCode int
+}
+
+func (e *providerStatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.Provider, e.StatusCode)
+}
+
+// providerAuthError reports credentials a provider rejected in its response body rather than with
+// an HTTP status, such as qBittorrent's failed logins and Newznab's error codes
+type providerAuthError struct {
+	message string
+}
+
+func (e *providerAuthError) Error() string {
+	return e.message
+}
+
+// diagnoseProviderError turns an error talking to a provider into a test failure, categorizing
+// network, TLS and HTTP status errors with a hint on fixing them. Errors that fit no category
+// fail the given check.
+func diagnoseProviderError(check models.ProviderTestCheck, provider string, err error) models.ProviderTestFailure {
+	failure := models.ProviderTestFailure{
+		Check:    check,
+		Category: models.ProviderTestFailureUnexpectedResponse,
+		Message:  err.Error(),
+	}
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var statusErr *providerStatusError
+	var authErr *providerAuthError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	var recordHeaderErr tls.RecordHeaderError
+
+	switch {
+	case errors.As(err, &authErr):
+		failure.Check, failure.Category = models.ProviderTestCheckAuthentication, models.ProviderTestFailureUnauthorized
+		failure.Hint = fmt.Sprintf("Check the API key, username and password match %s's settings", provider)
+	case errors.As(err, &statusErr):
+		return diagnoseProviderStatus(check, provider, statusErr.StatusCode, failure.Message)
+	case errors.As(err, &dnsErr):
+		failure.Check, failure.Category = models.ProviderTestCheckConnectivity, models.ProviderTestFailureHostNotFound
+		failure.Hint = "Check the host name is spelled correctly and resolves from the machine running Radarr"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		failure.Check = models.ProviderTestCheckConnectivity
+		failure.Category = models.ProviderTestFailureConnectionRefused
+		failure.Hint = fmt.Sprintf("Check %s is running and listening on the configured port", provider)
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &invalidCert):
+		failure.Check, failure.Category = models.ProviderTestCheckConnectivity, models.ProviderTestFailureTLS
+		failure.Hint = "Give the server a valid certificate for this host name, or turn off certificate validation"
+	case errors.As(err, &recordHeaderErr):
+		failure.Check, failure.Category = models.ProviderTestCheckConnectivity, models.ProviderTestFailureTLS
+		failure.Hint = "The server doesn't speak HTTPS on this port, turn off SSL"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		failure.Check, failure.Category = models.ProviderTestCheckConnectivity, models.ProviderTestFailureTimeout
+		failure.Hint = "Check the host and port, and that no firewall or proxy blocks Radarr from reaching it"
+	case errors.As(err, &netErr):
+		failure.Check = models.ProviderTestCheckConnectivity
+		failure.Hint = "Check the host and port, and that no firewall or proxy blocks Radarr from reaching it"
+	}
+	return failure
+}
+
+// diagnoseProviderStatus categorizes an HTTP status that isn't a success
+func diagnoseProviderStatus(check models.ProviderTestCheck, provider string, status int,
+	message string) models.ProviderTestFailure {
+	failure := models.ProviderTestFailure{Check: check, Message: message}
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		failure.Check, failure.Category = models.ProviderTestCheckAuthentication, models.ProviderTestFailureUnauthorized
+		failure.Hint = fmt.Sprintf("Check the API key, username and password match %s's settings", provider)
+	case status == http.StatusNotFound:
+		failure.Check, failure.Category = models.ProviderTestCheckCapability, models.ProviderTestFailureNotFound
+		failure.Hint = fmt.Sprintf("Check the URL base; %s's API wasn't found at this address", provider)
+	case status >= http.StatusInternalServerError:
+		failure.Check, failure.Category = models.ProviderTestCheckConnectivity, models.ProviderTestFailureServerError
+		failure.Hint = fmt.Sprintf("%s is reachable but failing, check its logs", provider)
+	default:
+		failure.Category = models.ProviderTestFailureUnexpectedResponse
+		failure.Hint = fmt.Sprintf("Check the URL points to %s and not to a proxy or login page", provider)
+	}
+	return failure
+}
+
+// configurationFailure is a test failure for a missing or invalid setting
+func configurationFailure(field, message string) models.ProviderTestFailure {
+	return models.ProviderTestFailure{
+		Check:    models.ProviderTestCheckConfiguration,
+		Category: models.ProviderTestFailureInvalidSetting,
+		Field:    field,
+		Message:  message,
+	}
+}
+
+// recordProviderTest keeps the outcome of a test on the saved provider it was for. Only the test
+// column is written, so the provider's other settings and its updated time are left alone.
+func recordProviderTest(db *database.Database, model any, id int, report *models.ProviderTestReport) error {
+	if db == nil || id == 0 {
+		return nil
+	}
+	err := db.GORM.Model(model).Where("id = ?", id).UpdateColumn("last_test", report.Status()).Error
+	if err != nil {
+		return fmt.Errorf("failed to record test result: %w", err)
+	}
+	return nil
+}
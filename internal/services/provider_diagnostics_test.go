@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnoseProviderError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		check    models.ProviderTestCheck
+		category models.ProviderTestFailureCategory
+	}{
+		{"unauthorized", &providerStatusError{Provider: "SABnzbd", StatusCode: http.StatusUnauthorized},
+			models.ProviderTestCheckAuthentication, models.ProviderTestFailureUnauthorized},
+		{"wrong URL base", &providerStatusError{Provider: "SABnzbd", StatusCode: http.StatusNotFound},
+			models.ProviderTestCheckCapability, models.ProviderTestFailureNotFound},
+		{"server error", fmt.Errorf("failed to fetch list: %w", &providerStatusError{StatusCode: 502}),
+			models.ProviderTestCheckConnectivity, models.ProviderTestFailureServerError},
+		{"rejected login", &providerAuthError{message: "qBittorrent login failed"},
+			models.ProviderTestCheckAuthentication, models.ProviderTestFailureUnauthorized},
+		{"unknown host", fmt.Errorf("request failed: %w", &net.DNSError{Err: "no such host", Name: "nas"}),
+			models.ProviderTestCheckConnectivity, models.ProviderTestFailureHostNotFound},
+		{"connection refused", fmt.Errorf("request failed: %w", syscall.ECONNREFUSED),
+			models.ProviderTestCheckConnectivity, models.ProviderTestFailureConnectionRefused},
+		{"timeout", fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			models.ProviderTestCheckConnectivity, models.ProviderTestFailureTimeout},
+		{"self-signed certificate", fmt.Errorf("request failed: %w", x509.UnknownAuthorityError{}),
+			models.ProviderTestCheckConnectivity, models.ProviderTestFailureTLS},
+		{"anything else", errors.New("invalid response"),
+			models.ProviderTestCheckCapability, models.ProviderTestFailureUnexpectedResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := diagnoseProviderError(models.ProviderTestCheckCapability, "SABnzbd", tt.err)
+			assert.Equal(t, tt.check, failure.Check)
+			assert.Equal(t, tt.category, failure.Category)
+			assert.Equal(t, tt.err.Error(), failure.Message)
+			if tt.category != models.ProviderTestFailureUnexpectedResponse {
+				assert.NotEmpty(t, failure.Hint)
+			}
+		})
+	}
+}
+
+func TestIndexerService_TestIndexer_Diagnostics(t *testing.T) {
+	caps := `<caps><server version="1.0" title="Indexer"/>` +
+		`<searching><movie-search available="yes"/></searching>` +
+		`<categories><category id="2000"><subcat id="2040"/></category></categories></caps>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "caps", r.URL.Query().Get("t"))
+		switch r.URL.Query().Get("apikey") {
+		case "secret":
+			_, _ = w.Write([]byte(caps))
+		case "blocked":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_, _ = w.Write([]byte(`<error code="100" description="Incorrect user credentials"/>`))
+		}
+	}))
+	defer server.Close()
+
+	service := NewIndexerService(nil, logger.New(config.LogConfig{Level: "error"}))
+	indexer := &models.Indexer{Name: "Indexer", Type: models.IndexerTypeNewznab, BaseURL: server.URL + "/api",
+		APIKey: "secret", Categories: "2000,2040", EnableAutomaticSearch: true}
+
+	result, err := service.TestIndexer(indexer)
+	require.NoError(t, err)
+	assert.True(t, result.IsValid, result.Errors)
+	assert.Empty(t, result.Failures)
+	require.Len(t, result.Checks, 5)
+	assert.Equal(t, models.ProviderTestStage{Check: models.ProviderTestCheckVersion, Passed: true,
+		Message: "Indexer 1.0"}, result.Checks[4])
+
+	indexer.Categories = "2000,5070"
+	result, err = service.TestIndexer(indexer)
+	require.NoError(t, err)
+	assert.False(t, result.IsValid)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestCheckCapability, result.Failures[0].Check)
+	assert.Equal(t, "categories", result.Failures[0].Field)
+	assert.Contains(t, result.Failures[0].Message, "5070")
+	assert.Equal(t, []string{result.Failures[0].Message}, result.Errors)
+
+	indexer.APIKey = "wrong"
+	result, err = service.TestIndexer(indexer)
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestCheckAuthentication, result.Failures[0].Check)
+	assert.Equal(t, models.ProviderTestFailureUnauthorized, result.Failures[0].Category)
+	assert.Equal(t, "apiKey", result.Failures[0].Field)
+
+	indexer.APIKey = "blocked"
+	result, err = service.TestIndexer(indexer)
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestFailureUnauthorized, result.Failures[0].Category)
+
+	indexer.BaseURL, indexer.APIKey = "indexer.example", ""
+	result, err = service.TestIndexer(indexer)
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 2)
+	assert.Equal(t, "baseUrl", result.Failures[0].Field)
+	assert.Equal(t, "apiKey", result.Failures[1].Field)
+	assert.Equal(t, []models.ProviderTestStage{{Check: models.ProviderTestCheckConfiguration,
+		Message: result.Failures[0].Message}}, result.Checks, "no connection is tried with invalid settings")
+}
+
+func TestDownloadService_TestDownloadClient_Diagnostics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api":
+			if r.URL.Query().Get("mode") == "version" {
+				_, _ = w.Write([]byte(`{"version": "4.3.2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status": false, "error": "API Key Incorrect"}`))
+		case "/jsonrpc":
+			_, _ = w.Write([]byte(`{"result": "11.0"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	service := NewDownloadService(nil, logger.New(config.LogConfig{Level: "error"}))
+
+	sabnzbd := testDownloadClient(t, server, models.DownloadClientTypeSABnzbd)
+	sabnzbd.Name, sabnzbd.APIKey = "SABnzbd", "wrong"
+	result, err := service.TestDownloadClient(sabnzbd)
+	require.NoError(t, err)
+	assert.False(t, result.IsValid)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestCheckAuthentication, result.Failures[0].Check)
+	assert.Equal(t, models.ProviderTestFailureUnauthorized, result.Failures[0].Category)
+	assert.Contains(t, result.Errors[0], "API Key Incorrect")
+
+	nzbget := testDownloadClient(t, server, models.DownloadClientTypeNZBGet)
+	nzbget.Name = "NZBGet"
+	result, err = service.TestDownloadClient(nzbget)
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestFailureUnsupportedVersion, result.Failures[0].Category)
+	assert.Equal(t, "11.0", result.Version)
+
+	qbittorrent := testDownloadClient(t, server, models.DownloadClientTypeQBittorrent)
+	qbittorrent.Name, qbittorrent.Username = "qBittorrent", ""
+	result, err = service.TestDownloadClient(qbittorrent)
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestCheckVersion, result.Failures[0].Check, "qBittorrent before 4.1 has no v2 API")
+	assert.Equal(t, models.ProviderTestFailureUnsupportedVersion, result.Failures[0].Category)
+
+	server.Close()
+	result, err = service.TestDownloadClient(sabnzbd)
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, models.ProviderTestFailureConnectionRefused, result.Failures[0].Category)
+}
+
+func TestIndexerService_TestIndexer_RecordsResult(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewIndexerService(db, logger)
+	indexer := &models.Indexer{Name: "RSS", Type: models.IndexerTypeRSS, BaseURL: "http://localhost"}
+	require.NoError(t, db.GORM.Create(indexer).Error)
+
+	updatedAt := indexer.UpdatedAt
+	indexer.BaseURL = ""
+	result, err := service.TestIndexer(indexer)
+	require.NoError(t, err)
+	require.False(t, result.IsValid)
+
+	var stored models.Indexer
+	require.NoError(t, db.GORM.First(&stored, indexer.ID).Error)
+	require.NotNil(t, stored.LastTest.TestedAt)
+	assert.False(t, stored.LastTest.IsValid)
+	assert.Equal(t, result.Failures, stored.LastTest.Failures)
+	assert.Equal(t, "http://localhost", stored.BaseURL, "only the test outcome is saved")
+	assert.True(t, stored.UpdatedAt.Equal(updatedAt))
+}
+
+func TestProviderTestStatus_Value(t *testing.T) {
+	value, err := models.ProviderTestStatus{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value, "providers never tested store no test outcome")
+
+	var status models.ProviderTestStatus
+	require.NoError(t, status.Scan(`{"isValid": false, "failures": [{"check": "authentication",
+		"category": "unauthorized", "message": "denied"}]}`))
+	assert.Equal(t, models.ProviderTestStatus{Failures: []models.ProviderTestFailure{{
+		Check: models.ProviderTestCheckAuthentication, Category: models.ProviderTestFailureUnauthorized,
+		Message: "denied",
+	}}}, status)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// qbittorrentClientTimeout bounds each request to qBittorrent's Web API
+const qbittorrentClientTimeout = 30 * time.Second
+
+// qbittorrentVersion is the version of a qBittorrent instance and of the Web API it serves
+type qbittorrentVersion struct {
+	App    string // e.g. v4.6.7 or v5.0.2
+	WebAPI string // e.g. 2.9.3 or 2.11.2
+}
+
+// qbittorrentClient talks to the qBittorrent Web API of v4 and v5 releases, logging in on first use
+type qbittorrentClient struct {
+	client     *models.DownloadClient
+	httpClient *http.Client
+	loggedIn   bool
+	version    *qbittorrentVersion
+}
+
+// newQBittorrentClient creates a Web API client for a qBittorrent download client
+func newQBittorrentClient(client *models.DownloadClient) (*qbittorrentClient, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+	httpClient := httpclient.New(qbittorrentClientTimeout, httpclient.WithSkipTLSVerify(client.SkipTLSVerify()))
+	httpClient.Jar = jar
+	return &qbittorrentClient{client: client, httpClient: httpClient}, nil
+}
+
+// Version returns the application and Web API versions, fetched once per client
+func (c *qbittorrentClient) Version(ctx context.Context) (*qbittorrentVersion, error) {
+	if c.version != nil {
+		return c.version, nil
+	}
+
+	app, err := c.request(ctx, http.MethodGet, "/api/v2/app/version", nil)
+	if err != nil {
+		return nil, err
+	}
+	webAPI, err := c.request(ctx, http.MethodGet, "/api/v2/app/webapiVersion", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.version = &qbittorrentVersion{
+		App:    strings.TrimSpace(string(app)),
+		WebAPI: strings.TrimSpace(string(webAPI)),
+	}
+	return c.version, nil
+}
+
+// EnsureCategory creates the category when qBittorrent doesn't have it yet, reporting whether it
+// was created
+func (c *qbittorrentClient) EnsureCategory(ctx context.Context, category string) (bool, error) {
+	body, err := c.request(ctx, http.MethodGet, "/api/v2/torrents/categories", nil)
+	if err != nil {
+		return false, err
+	}
+
+	var categories map[string]json.RawMessage
+	if err := json.Unmarshal(body, &categories); err != nil {
+		return false, fmt.Errorf("invalid qBittorrent category list: %w", err)
+	}
+	if _, ok := categories[category]; ok {
+		return false, nil
+	}
+
+	form := url.Values{"category": {category}, "savePath": {""}}
+	if _, err := c.request(ctx, http.MethodPost, "/api/v2/torrents/createCategory", form); err != nil {
+		return false, fmt.Errorf("failed to create category %s: %w", category, err)
+	}
+	return true, nil
+}
+
+// Torrents returns the seeding progress of the torrents still in qBittorrent
+func (c *qbittorrentClient) Torrents(ctx context.Context, hashes []string) (map[string]torrentStatus, error) {
+	query := url.Values{"hashes": {strings.ToLower(strings.Join(hashes, "|"))}}
+	body, err := c.request(ctx, http.MethodGet, "/api/v2/torrents/info?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var torrents []struct {
+		Hash     string  `json:"hash"`
+		Ratio    float64 `json:"ratio"`
+		Progress float64 `json:"progress"`
+		// SeedingTime is missing before Web API 2.8, where it is derived from CompletionOn
+		SeedingTime  *int64 `json:"seeding_time"`
+		CompletionOn int64  `json:"completion_on"`
+	}
+	if err := json.Unmarshal(body, &torrents); err != nil {
+		return nil, fmt.Errorf("invalid qBittorrent torrent list: %w", err)
+	}
+
+	statuses := make(map[string]torrentStatus, len(torrents))
+	for _, torrent := range torrents {
+		status := torrentStatus{Ratio: torrent.Ratio, Complete: torrent.Progress >= 1}
+		switch {
+		case torrent.SeedingTime != nil:
+			status.SeedingTime = time.Duration(*torrent.SeedingTime) * time.Second
+		case torrent.CompletionOn > 0:
+			status.SeedingTime = time.Since(time.Unix(torrent.CompletionOn, 0)).Truncate(time.Second)
+		}
+		statuses[strings.ToLower(torrent.Hash)] = status
+	}
+	return statuses, nil
+}
+
+// TransferRates returns qBittorrent's current download and upload speeds in bytes per second
+func (c *qbittorrentClient) TransferRates(ctx context.Context) (download, upload int64, err error) {
+	body, err := c.request(ctx, http.MethodGet, "/api/v2/transfer/info", nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var info struct {
+		DownloadSpeed int64 `json:"dl_info_speed"`
+		UploadSpeed   int64 `json:"up_info_speed"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return 0, 0, fmt.Errorf("invalid qBittorrent transfer info: %w", err)
+	}
+	return info.DownloadSpeed, info.UploadSpeed, nil
+}
+
+// Remove deletes a torrent and its files from qBittorrent
+func (c *qbittorrentClient) Remove(ctx context.Context, hash string) error {
+	form := url.Values{"hashes": {strings.ToLower(hash)}, "deleteFiles": {"true"}}
+	_, err := c.request(ctx, http.MethodPost, "/api/v2/torrents/delete", form)
+	return err
+}
+
+// request calls the Web API, logging in first when the client has credentials
+func (c *qbittorrentClient) request(ctx context.Context, method, path string, form url.Values) ([]byte, error) {
+	if !c.loggedIn && c.client.Username != "" {
+		if err := c.login(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.client.GetBaseURL()+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return doSeedingRequest(c.httpClient, req, "qBittorrent")
+}
+
+// login authenticates with qBittorrent, which keeps the session in a cookie. Rejected logins
+// still answer 200, with "Fails." as the body.
+func (c *qbittorrentClient) login(ctx context.Context) error {
+	form := url.Values{"username": {c.client.Username}, "password": {c.client.Password}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.client.GetBaseURL()+"/api/v2/auth/login",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := doSeedingRequest(c.httpClient, req, "qBittorrent")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(body)) == "Fails." {
+		return &providerAuthError{message: "qBittorrent login failed, check the username and password"}
+	}
+
+	c.loggedIn = true
+	return nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQBittorrentClient_VersionAndCategory(t *testing.T) {
+	var created url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/auth/login":
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session", Path: "/"})
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/app/version":
+			_, _ = w.Write([]byte("v5.0.2"))
+		case "/api/v2/app/webapiVersion":
+			_, _ = w.Write([]byte("2.11.2"))
+		case "/api/v2/torrents/categories":
+			_, _ = w.Write([]byte(`{"tv-sonarr": {"name": "tv-sonarr", "savePath": ""}}`))
+		case "/api/v2/torrents/createCategory":
+			require.NoError(t, r.ParseForm())
+			created = r.PostForm
+		}
+	}))
+	defer server.Close()
+
+	client, err := newQBittorrentClient(testDownloadClient(t, server, models.DownloadClientTypeQBittorrent))
+	require.NoError(t, err)
+
+	version, err := client.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &qbittorrentVersion{App: "v5.0.2", WebAPI: "2.11.2"}, version)
+
+	ok, err := client.EnsureCategory(context.Background(), "tv-sonarr")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, created)
+
+	ok, err = client.EnsureCategory(context.Background(), "radarr")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "radarr", created.Get("category"))
+}
+
+func TestQBittorrentClient_LoginFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("Fails."))
+	}))
+	defer server.Close()
+
+	client, err := newQBittorrentClient(testDownloadClient(t, server, models.DownloadClientTypeQBittorrent))
+	require.NoError(t, err)
+
+	_, err = client.Version(context.Background())
+	assert.ErrorContains(t, err, "login failed")
+}
+
+func TestQBittorrentClient_TorrentsWithoutSeedingTime(t *testing.T) {
+	completed := time.Now().Add(-time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Older v4 releases don't report seeding_time
+		_, _ = w.Write([]byte(`[{"hash": "abc", "ratio": 0.5, "progress": 1, "completion_on": ` +
+			strconv.FormatInt(completed, 10) + `}]`))
+	}))
+	defer server.Close()
+
+	downloadClient := testDownloadClient(t, server, models.DownloadClientTypeQBittorrent)
+	downloadClient.Username = ""
+	client, err := newQBittorrentClient(downloadClient)
+	require.NoError(t, err)
+
+	statuses, err := client.Torrents(context.Background(), []string{"abc"})
+	require.NoError(t, err)
+	require.Contains(t, statuses, "abc")
+	assert.InDelta(t, time.Hour.Seconds(), statuses["abc"].SeedingTime.Seconds(), 5)
+}
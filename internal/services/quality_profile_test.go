@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityService_CloneQualityProfile(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewQualityService(db, logger)
+	source := &models.QualityProfile{
+		Name:   "HD",
+		Cutoff: 7,
+		Items: models.QualityProfileItems{
+			{Quality: &models.QualityLevel{ID: 7, Title: "Bluray-1080p"}, Allowed: true},
+		},
+		MinFormatScore: 10,
+		FormatItems:    models.CustomFormatItems{{Name: "x265", Score: -100}},
+	}
+	require.NoError(t, service.CreateQualityProfile(source))
+
+	clone, err := service.CloneQualityProfile(source.ID, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, source.ID, clone.ID)
+	assert.Equal(t, "HD (Copy)", clone.Name)
+	assert.Equal(t, 7, clone.Cutoff)
+	assert.Equal(t, 10, clone.MinFormatScore)
+	require.Len(t, clone.FormatItems, 1)
+	assert.Equal(t, -100, clone.FormatItems[0].Score)
+
+	second, err := service.CloneQualityProfile(source.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "HD (Copy 2)", second.Name)
+
+	_, err = service.CloneQualityProfile(source.ID, "HD")
+	var validationErr models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+}
+
+func TestQualityService_ReorderQualityProfileItems(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewQualityService(db, logger)
+	profile := &models.QualityProfile{
+		Name: "Any",
+		Items: models.QualityProfileItems{
+			{Quality: &models.QualityLevel{ID: 3, Title: "WEBDL-720p"}, Allowed: true},
+			{ID: 1000, Name: "WEB 1080p", Allowed: true, Items: []*models.QualityProfileItem{
+				{Quality: &models.QualityLevel{ID: 15, Title: "WEBRip-1080p"}, Allowed: true},
+			}},
+			{Quality: &models.QualityLevel{ID: 7, Title: "Bluray-1080p"}, Allowed: true},
+		},
+	}
+	require.NoError(t, service.CreateQualityProfile(profile))
+
+	reordered, err := service.ReorderQualityProfileItems(profile.ID, []int{7, 3, 1000})
+	require.NoError(t, err)
+	stored, err := service.GetQualityProfileByID(profile.ID)
+	require.NoError(t, err)
+	for _, p := range []*models.QualityProfile{reordered, stored} {
+		require.Len(t, p.Items, 3)
+		assert.Equal(t, 7, p.Items[0].ItemID())
+		assert.Equal(t, 3, p.Items[1].ItemID())
+		assert.Equal(t, "WEB 1080p", p.Items[2].Name)
+	}
+
+	for _, order := range [][]int{{7, 3}, {7, 3, 3}, {7, 3, 99}} {
+		_, err = service.ReorderQualityProfileItems(profile.ID, order)
+		var validationErr models.ValidationError
+		require.ErrorAs(t, err, &validationErr, "order %v", order)
+		assert.Equal(t, "order", validationErr.Field)
+	}
+}
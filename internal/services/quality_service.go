@@ -2,10 +2,14 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
 )
 
 // QualityService provides operations for managing quality profiles and settings.
@@ -59,7 +63,17 @@ func (s *QualityService) CreateQualityProfile(profile *models.QualityProfile) er
 
 // UpdateQualityProfile updates an existing quality profile.
 func (s *QualityService) UpdateQualityProfile(profile *models.QualityProfile) error {
-	if err := s.db.GORM.Save(profile).Error; err != nil {
+	return s.UpdateQualityProfileIfUnchanged(profile, time.Time{})
+}
+
+// UpdateQualityProfileIfUnchanged updates a quality profile unless it was updated after read, the
+// updated time of the copy the changes were made to, returning a models.ConflictError if it was.
+func (s *QualityService) UpdateQualityProfileIfUnchanged(profile *models.QualityProfile, read time.Time) error {
+	err := saveUnlessStale(s.db.GORM, profile, profile.ID, read, "quality profile")
+	if isConflict(err) {
+		return err
+	}
+	if err != nil {
 		s.logger.Error("Failed to update quality profile", "id", profile.ID, "error", err)
 		return fmt.Errorf("failed to update quality profile: %w", err)
 	}
@@ -94,6 +108,105 @@ func (s *QualityService) DeleteQualityProfile(id int) error {
 	return nil
 }
 
+// CloneQualityProfile copies a quality profile, with its qualities, cutoff and custom format
+// scores, under name, or "<name> (Copy)" when name is empty
+func (s *QualityService) CloneQualityProfile(id int, name string) (*models.QualityProfile, error) {
+	source, err := s.GetQualityProfileByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		if name, err = s.copyProfileName(source.Name); err != nil {
+			return nil, err
+		}
+	} else if taken, err := s.profileNameTaken(name); err != nil {
+		return nil, err
+	} else if taken {
+		return nil, models.ValidationError{
+			Field: "name", Message: fmt.Sprintf("A quality profile named %q already exists", name),
+		}
+	}
+
+	clone := *source
+	clone.ID = 0
+	clone.Name = name
+	clone.CreatedAt = time.Time{}
+	clone.UpdatedAt = time.Time{}
+	if err := s.CreateQualityProfile(&clone); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Cloned quality profile", "sourceId", id, "id", clone.ID, "name", clone.Name)
+	return &clone, nil
+}
+
+// copyProfileName returns the first free name of "<name> (Copy)", "<name> (Copy 2)", ...
+func (s *QualityService) copyProfileName(name string) (string, error) {
+	for i := 1; ; i++ {
+		candidate := name + " (Copy)"
+		if i > 1 {
+			candidate = fmt.Sprintf("%s (Copy %d)", name, i)
+		}
+		taken, err := s.profileNameTaken(candidate)
+		if err != nil || !taken {
+			return candidate, err
+		}
+	}
+}
+
+// profileNameTaken reports whether a quality profile is named name
+func (s *QualityService) profileNameTaken(name string) (bool, error) {
+	var count int64
+	if err := s.db.GORM.Model(&models.QualityProfile{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check quality profile name: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ReorderQualityProfileItems puts a profile's top-level items, qualities and quality groups alike,
+// in the order given by their ids. Every item must be listed exactly once.
+func (s *QualityService) ReorderQualityProfileItems(id int, order []int) (*models.QualityProfile, error) {
+	profile, err := s.GetQualityProfileByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*models.QualityProfileItem, len(profile.Items))
+	for _, item := range profile.Items {
+		if item != nil {
+			byID[item.ItemID()] = item
+		}
+	}
+	if len(order) != len(byID) || len(byID) != len(profile.Items) {
+		return nil, models.ValidationError{
+			Field: "order", Message: fmt.Sprintf("Order must list all %d quality profile items", len(profile.Items)),
+		}
+	}
+
+	items := make(models.QualityProfileItems, 0, len(order))
+	for _, itemID := range order {
+		item, ok := byID[itemID]
+		if !ok {
+			return nil, models.ValidationError{
+				Field: "order", Message: fmt.Sprintf("Item %d is not in the quality profile or is listed twice", itemID),
+			}
+		}
+		items = append(items, item)
+		delete(byID, itemID)
+	}
+
+	profile.Items = items
+	if err := s.db.GORM.Model(profile).Update("items", profile.Items).Error; err != nil {
+		s.logger.Error("Failed to reorder quality profile items", "id", id, "error", err)
+		return nil, fmt.Errorf("failed to reorder quality profile items: %w", err)
+	}
+
+	s.logger.Info("Reordered quality profile items", "id", id, "name", profile.Name)
+	return profile, nil
+}
+
 // GetQualityDefinitions retrieves all quality definitions.
 func (s *QualityService) GetQualityDefinitions() ([]*models.QualityLevel, error) {
 	var definitions []*models.QualityLevel
@@ -118,14 +231,49 @@ func (s *QualityService) GetQualityDefinitionByID(id int) (*models.QualityLevel,
 	return &definition, nil
 }
 
-// UpdateQualityDefinition updates an existing quality definition.
+// UpdateQualityDefinition updates the title and size limits of an existing quality definition.
+// As upstream, the weight is fixed, so definition is refreshed with the stored weight.
 func (s *QualityService) UpdateQualityDefinition(definition *models.QualityLevel) error {
-	if err := s.db.GORM.Save(definition).Error; err != nil {
-		s.logger.Error("Failed to update quality definition", "id", definition.ID, "error", err)
-		return fmt.Errorf("failed to update quality definition: %w", err)
+	return s.UpdateQualityDefinitions([]*models.QualityLevel{definition})
+}
+
+// UpdateQualityDefinitions updates the title and size limits of several quality definitions
+// together, rejecting the whole batch if any definition is invalid.
+func (s *QualityService) UpdateQualityDefinitions(definitions []*models.QualityLevel) error {
+	for _, definition := range definitions {
+		if err := definition.Validate(); err != nil {
+			return err
+		}
 	}
 
-	s.logger.Info("Updated quality definition", "id", definition.ID, "title", definition.Title)
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		for _, definition := range definitions {
+			var stored models.QualityLevel
+			if err := tx.Where("id = ?", definition.ID).First(&stored).Error; err != nil {
+				return fmt.Errorf("quality definition with id %d not found: %w", definition.ID, err)
+			}
+
+			if definition.Title != "" {
+				stored.Title = definition.Title
+			}
+			stored.MinSize = definition.MinSize
+			stored.MaxSize = definition.MaxSize
+			stored.PreferredSize = definition.PreferredSize
+			if err := tx.Save(&stored).Error; err != nil {
+				return fmt.Errorf("failed to update quality definition: %w", err)
+			}
+			*definition = stored
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to update quality definitions", "count", len(definitions), "error", err)
+		return err
+	}
+
+	for _, definition := range definitions {
+		s.logger.Info("Updated quality definition", "id", definition.ID, "title", definition.Title)
+	}
 	return nil
 }
 
@@ -155,6 +303,9 @@ func (s *QualityService) GetCustomFormatByID(id int) (*models.CustomFormat, erro
 
 // CreateCustomFormat creates a new custom format.
 func (s *QualityService) CreateCustomFormat(format *models.CustomFormat) error {
+	if err := validateCustomFormatSpecs(format.Specifications); err != nil {
+		return err
+	}
 	if err := s.db.GORM.Create(format).Error; err != nil {
 		s.logger.Error("Failed to create custom format", "name", format.Name, "error", err)
 		return fmt.Errorf("failed to create custom format: %w", err)
@@ -166,6 +317,9 @@ func (s *QualityService) CreateCustomFormat(format *models.CustomFormat) error {
 
 // UpdateCustomFormat updates an existing custom format.
 func (s *QualityService) UpdateCustomFormat(format *models.CustomFormat) error {
+	if err := validateCustomFormatSpecs(format.Specifications); err != nil {
+		return err
+	}
 	if err := s.db.GORM.Save(format).Error; err != nil {
 		s.logger.Error("Failed to update custom format", "id", format.ID, "error", err)
 		return fmt.Errorf("failed to update custom format: %w", err)
@@ -191,6 +345,57 @@ func (s *QualityService) DeleteCustomFormat(id int) error {
 	return nil
 }
 
+// MatchCustomFormats returns the custom formats input matches, ordered by id
+func (s *QualityService) MatchCustomFormats(input *models.CustomFormatInput) ([]models.CustomFormat, error) {
+	formats, err := s.GetCustomFormats()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []models.CustomFormat{}
+	for _, format := range formats {
+		if format.Matches(input) {
+			matched = append(matched, *format)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	return matched, nil
+}
+
+// EvaluateCustomFormats evaluates input against every custom format, ordered by id, with the
+// outcome of each specification
+func (s *QualityService) EvaluateCustomFormats(input *models.CustomFormatInput) ([]models.CustomFormatMatch, error) {
+	formats, err := s.GetCustomFormats()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]models.CustomFormatMatch, 0, len(formats))
+	for _, format := range formats {
+		matches = append(matches, format.Evaluate(input))
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches, nil
+}
+
+// validateCustomFormatSpecs rejects specification types upstream Radarr does not support, so
+// formats synced by Recyclarr or Notifiarr behave the same as against Radarr
+func validateCustomFormatSpecs(specs models.CustomFormatSpecs) error {
+	for i, spec := range specs {
+		if spec == nil || !models.IsKnownCustomFormatImplementation(spec.Implementation) {
+			implementation := ""
+			if spec != nil {
+				implementation = spec.Implementation
+			}
+			return models.ValidationError{
+				Field:   fmt.Sprintf("specifications[%d].implementation", i),
+				Message: fmt.Sprintf("Unknown custom format specification %q", implementation),
+			}
+		}
+	}
+	return nil
+}
+
 // InitializeQualityDefinitions ensures default quality definitions exist.
 func (s *QualityService) InitializeQualityDefinitions() error {
 	// Check if quality definitions already exist
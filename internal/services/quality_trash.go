@@ -0,0 +1,410 @@
+package services
+
+import (
+	"crypto/md5" // #nosec G501 -- used only to derive stable ids, not for security
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// trashQualityGroupIDBase is the first id given to quality groups, as in Radarr, so group ids
+	// never clash with quality ids when used as a profile cutoff
+	trashQualityGroupIDBase = 1000
+	// trashDefaultScoreSet is the TRaSH-Guides score set used when a profile names none
+	trashDefaultScoreSet = "default"
+)
+
+// ExportCustomFormats returns every custom format in the TRaSH-Guides JSON format
+func (s *QualityService) ExportCustomFormats() ([]models.TrashCustomFormat, error) {
+	formats, err := s.GetCustomFormats()
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]models.TrashCustomFormat, 0, len(formats))
+	for _, format := range formats {
+		exported = append(exported, toTrashCustomFormat(format, nil))
+	}
+	return exported, nil
+}
+
+// ImportCustomFormats creates or updates custom formats from TRaSH-Guides JSON. Stored formats
+// are matched by trash_id first and then by name, so re-importing a guide updates it in place.
+func (s *QualityService) ImportCustomFormats(formats []models.TrashCustomFormat) (*models.TrashImportResult, error) {
+	for i := range formats {
+		if err := validateTrashCustomFormat(&formats[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.TrashImportResult{}
+	err := s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		_, err := s.importCustomFormats(tx, formats, result)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Imported custom formats", "created", len(result.Created), "updated", len(result.Updated))
+	return result, nil
+}
+
+// ExportQualityProfile returns a quality profile and the custom formats it scores in the
+// TRaSH-Guides JSON format
+func (s *QualityService) ExportQualityProfile(id int) (*models.TrashProfileBundle, error) {
+	profile, err := s.GetQualityProfileByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	formats, err := s.GetCustomFormats()
+	if err != nil {
+		return nil, err
+	}
+
+	return toTrashProfileBundle(profile, formats), nil
+}
+
+// ImportQualityProfile creates or updates a quality profile, matched by name, from TRaSH-Guides
+// JSON. The bundled custom formats are imported first, and format items take their score from
+// the format's trash_scores for the profile's score set.
+func (s *QualityService) ImportQualityProfile(bundle *models.TrashProfileBundle) (*models.TrashImportResult, error) {
+	trashProfile := &bundle.QualityProfile
+	if strings.TrimSpace(trashProfile.Name) == "" {
+		return nil, models.ValidationError{Field: "name", Message: "Quality profile name is required"}
+	}
+	for i := range bundle.CustomFormats {
+		if err := validateTrashCustomFormat(&bundle.CustomFormats[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	definitions, err := s.GetQualityDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	items, cutoff, err := trashProfileItems(trashProfile, definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TrashImportResult{}
+	err = s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		stored, err := s.importCustomFormats(tx, bundle.CustomFormats, result)
+		if err != nil {
+			return err
+		}
+
+		var profile models.QualityProfile
+		if err := tx.Where("name = ?", trashProfile.Name).Limit(1).Find(&profile).Error; err != nil {
+			return fmt.Errorf("failed to look up quality profile: %w", err)
+		}
+
+		profile.Name = trashProfile.Name
+		profile.Items = items
+		profile.Cutoff = cutoff
+		profile.UpgradeAllowed = trashProfile.UpgradeAllowed
+		profile.MinFormatScore = trashProfile.MinFormatScore
+		profile.CutoffFormatScore = trashProfile.CutoffFormatScore
+		if trashProfile.Language != "" {
+			profile.Language = strings.ToLower(trashProfile.Language)
+		}
+		profile.FormatItems, result.SkippedFormats = trashFormatItems(trashProfile, bundle.CustomFormats, stored)
+
+		if profile.ID == 0 {
+			err = tx.Create(&profile).Error
+		} else {
+			err = tx.Save(&profile).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save quality profile: %w", err)
+		}
+		result.QualityProfile = &profile
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to import quality profile", "name", trashProfile.Name, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Imported quality profile", "id", result.QualityProfile.ID, "name", result.QualityProfile.Name,
+		"formatsCreated", len(result.Created), "formatsUpdated", len(result.Updated),
+		"formatsSkipped", len(result.SkippedFormats))
+	return result, nil
+}
+
+// importCustomFormats upserts formats within tx and returns every stored format keyed by trash_id
+func (s *QualityService) importCustomFormats(
+	tx *gorm.DB, formats []models.TrashCustomFormat, result *models.TrashImportResult,
+) (map[string]*models.CustomFormat, error) {
+	var existing []*models.CustomFormat
+	if err := tx.Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch custom formats: %w", err)
+	}
+
+	byTrashID := make(map[string]*models.CustomFormat, len(existing))
+	byName := make(map[string]*models.CustomFormat, len(existing))
+	for _, format := range existing {
+		byTrashID[trashIDFor(format)] = format
+		byName[strings.ToLower(format.Name)] = format
+	}
+
+	for i := range formats {
+		imported := &formats[i]
+		format := byTrashID[imported.TrashID]
+		if format == nil {
+			format = byName[strings.ToLower(imported.Name)]
+		}
+
+		created := format == nil
+		if created {
+			format = &models.CustomFormat{}
+		} else {
+			delete(byTrashID, trashIDFor(format))
+		}
+		format.Name = imported.Name
+		format.IncludeCustomFormatWhenRenaming = imported.IncludeCustomFormatWhenRenaming
+		format.Specifications = fromTrashSpecs(imported.Specifications)
+		format.TrashID = imported.TrashID
+
+		if created {
+			if err := tx.Create(format).Error; err != nil {
+				return nil, fmt.Errorf("failed to create custom format %q: %w", format.Name, err)
+			}
+			result.Created = append(result.Created, format)
+		} else {
+			if err := tx.Save(format).Error; err != nil {
+				return nil, fmt.Errorf("failed to update custom format %q: %w", format.Name, err)
+			}
+			result.Updated = append(result.Updated, format)
+		}
+		byTrashID[trashIDFor(format)] = format
+		byName[strings.ToLower(format.Name)] = format
+	}
+
+	return byTrashID, nil
+}
+
+// validateTrashCustomFormat checks that an imported format can be stored and evaluated
+func validateTrashCustomFormat(format *models.TrashCustomFormat) error {
+	if strings.TrimSpace(format.Name) == "" {
+		return models.ValidationError{Field: "name", Message: "Custom format name is required"}
+	}
+	return validateCustomFormatSpecs(fromTrashSpecs(format.Specifications))
+}
+
+// fromTrashSpecs converts TRaSH-Guides specifications to stored ones
+func fromTrashSpecs(specs []*models.TrashCustomFormatSpec) models.CustomFormatSpecs {
+	converted := make(models.CustomFormatSpecs, 0, len(specs))
+	for _, spec := range specs {
+		converted = append(converted, (*models.CustomFormatSpec)(spec))
+	}
+	return converted
+}
+
+// trashIDFor returns the format's TRaSH-Guides id, or a stable id derived from its name for
+// formats created in radarr-go, so exports can be re-imported without duplicating formats
+func trashIDFor(format *models.CustomFormat) string {
+	if format.TrashID != "" {
+		return format.TrashID
+	}
+	sum := md5.Sum([]byte(strings.ToLower(format.Name))) // #nosec G401 -- not used for security
+	return hex.EncodeToString(sum[:])
+}
+
+// toTrashCustomFormat converts a stored format, with its profile score when exporting a profile
+func toTrashCustomFormat(format *models.CustomFormat, score *int) models.TrashCustomFormat {
+	exported := models.TrashCustomFormat{
+		TrashID:                         trashIDFor(format),
+		Name:                            format.Name,
+		IncludeCustomFormatWhenRenaming: format.IncludeCustomFormatWhenRenaming,
+		Specifications:                  make([]*models.TrashCustomFormatSpec, 0, len(format.Specifications)),
+	}
+	for _, spec := range format.Specifications {
+		exported.Specifications = append(exported.Specifications, (*models.TrashCustomFormatSpec)(spec))
+	}
+	if score != nil {
+		exported.TrashScores = map[string]int{trashDefaultScoreSet: *score}
+	}
+	return exported
+}
+
+// trashProfileItems converts TRaSH-Guides items, listed highest quality first, into profile
+// items listed lowest first, and resolves the cutoff name to a quality or group id
+func trashProfileItems(
+	profile *models.TrashQualityProfile, definitions []*models.QualityLevel,
+) (models.QualityProfileItems, int, error) {
+	if len(profile.Items) == 0 {
+		return nil, 0, models.ValidationError{Field: "items", Message: "Quality profile must have at least one quality"}
+	}
+
+	qualities := make(map[string]*models.QualityLevel, len(definitions))
+	for _, definition := range definitions {
+		qualities[strings.ToLower(definition.Title)] = definition
+	}
+	lookup := func(name string) (*models.QualityLevel, error) {
+		quality, ok := qualities[strings.ToLower(name)]
+		if !ok {
+			return nil, models.ValidationError{Field: "items", Message: fmt.Sprintf("Unknown quality %q", name)}
+		}
+		return quality, nil
+	}
+
+	items := make(models.QualityProfileItems, 0, len(profile.Items))
+	cutoff, cutoffFound := 0, false
+	groupID := trashQualityGroupIDBase
+	for i := len(profile.Items) - 1; i >= 0; i-- {
+		entry := profile.Items[i]
+		matchesCutoff := strings.EqualFold(entry.Name, profile.Cutoff)
+
+		if len(entry.Items) == 0 {
+			quality, err := lookup(entry.Name)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, &models.QualityProfileItem{Quality: quality, Allowed: entry.Allowed})
+			if matchesCutoff {
+				cutoff, cutoffFound = quality.ID, true
+			}
+			continue
+		}
+
+		group := &models.QualityProfileItem{ID: groupID, Name: entry.Name, Allowed: entry.Allowed}
+		for j := len(entry.Items) - 1; j >= 0; j-- {
+			quality, err := lookup(entry.Items[j])
+			if err != nil {
+				return nil, 0, err
+			}
+			group.Items = append(group.Items, &models.QualityProfileItem{Quality: quality, Allowed: entry.Allowed})
+		}
+		items = append(items, group)
+		if matchesCutoff {
+			cutoff, cutoffFound = groupID, true
+		}
+		groupID++
+	}
+
+	if !cutoffFound {
+		return nil, 0, models.ValidationError{
+			Field:   "cutoff",
+			Message: fmt.Sprintf("Cutoff %q is not a quality or group in the profile", profile.Cutoff),
+		}
+	}
+	return items, cutoff, nil
+}
+
+// trashFormatItems scores the profile's format items, returning the names of formats that are
+// not stored
+func trashFormatItems(
+	profile *models.TrashQualityProfile, supplied []models.TrashCustomFormat, stored map[string]*models.CustomFormat,
+) (models.CustomFormatItems, []string) {
+	scores := make(map[string]map[string]int, len(supplied))
+	for _, format := range supplied {
+		scores[format.TrashID] = format.TrashScores
+	}
+	scoreSet := profile.TrashScoreSet
+	if scoreSet == "" {
+		scoreSet = trashDefaultScoreSet
+	}
+
+	names := make([]string, 0, len(profile.FormatItems))
+	for name := range profile.FormatItems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := models.CustomFormatItems{}
+	var skipped []string
+	for _, name := range names {
+		trashID := profile.FormatItems[name]
+		format := stored[trashID]
+		if format == nil {
+			skipped = append(skipped, name)
+			continue
+		}
+
+		score, ok := scores[trashID][scoreSet]
+		if !ok {
+			score = scores[trashID][trashDefaultScoreSet]
+		}
+		items = append(items, &models.CustomFormatItem{Format: format, Name: format.Name, Score: score})
+	}
+	return items, skipped
+}
+
+// toTrashProfileBundle converts a stored profile, with the formats it scores, to TRaSH-Guides JSON
+func toTrashProfileBundle(profile *models.QualityProfile, formats []*models.CustomFormat) *models.TrashProfileBundle {
+	trashProfile := models.TrashQualityProfile{
+		Name:              profile.Name,
+		UpgradeAllowed:    profile.UpgradeAllowed,
+		MinFormatScore:    profile.MinFormatScore,
+		CutoffFormatScore: profile.CutoffFormatScore,
+		Language:          profile.Language,
+		Items:             make([]models.TrashQualityItem, 0, len(profile.Items)),
+		FormatItems:       make(map[string]string),
+	}
+
+	for i := len(profile.Items) - 1; i >= 0; i-- {
+		item := profile.Items[i]
+		if item == nil {
+			continue
+		}
+		if item.Quality != nil && len(item.Items) == 0 {
+			trashProfile.Items = append(trashProfile.Items,
+				models.TrashQualityItem{Name: item.Quality.Title, Allowed: item.Allowed})
+			if item.Quality.ID == profile.Cutoff {
+				trashProfile.Cutoff = item.Quality.Title
+			}
+			continue
+		}
+
+		group := models.TrashQualityItem{Name: item.Name, Allowed: item.Allowed}
+		for j := len(item.Items) - 1; j >= 0; j-- {
+			if item.Items[j] != nil && item.Items[j].Quality != nil {
+				group.Items = append(group.Items, item.Items[j].Quality.Title)
+			}
+		}
+		trashProfile.Items = append(trashProfile.Items, group)
+		if item.ID == profile.Cutoff {
+			trashProfile.Cutoff = item.Name
+		}
+	}
+
+	byID := make(map[int]*models.CustomFormat, len(formats))
+	byName := make(map[string]*models.CustomFormat, len(formats))
+	for _, format := range formats {
+		byID[format.ID] = format
+		byName[strings.ToLower(format.Name)] = format
+	}
+
+	bundle := &models.TrashProfileBundle{CustomFormats: []models.TrashCustomFormat{}}
+	for _, item := range profile.FormatItems {
+		if item == nil {
+			continue
+		}
+		var format *models.CustomFormat
+		if item.Format != nil {
+			format = byID[item.Format.ID]
+		}
+		if format == nil {
+			format = byName[strings.ToLower(item.Name)]
+		}
+		if format == nil {
+			continue
+		}
+
+		score := item.Score
+		exported := toTrashCustomFormat(format, &score)
+		trashProfile.FormatItems[format.Name] = exported.TrashID
+		bundle.CustomFormats = append(bundle.CustomFormats, exported)
+	}
+
+	bundle.QualityProfile = trashProfile
+	return bundle
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashProfileItems(t *testing.T) {
+	profile := &models.TrashQualityProfile{
+		Name:   "HD Bluray + WEB",
+		Cutoff: "WEB 1080p",
+		Items: []models.TrashQualityItem{
+			{Name: "Bluray-1080p", Allowed: true},
+			{Name: "WEB 1080p", Allowed: true, Items: []string{"WEBDL-1080p", "WEBRip-1080p"}},
+			{Name: "Bluray-720p", Allowed: false},
+		},
+	}
+
+	items, cutoff, err := trashProfileItems(profile, models.DefaultQualityDefinitions())
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, "Bluray-720p", items[0].Quality.Title)
+	assert.False(t, items[0].Allowed)
+	assert.Equal(t, trashQualityGroupIDBase, items[1].ID)
+	assert.Equal(t, "WEB 1080p", items[1].Name)
+	assert.Equal(t, "WEBRip-1080p", items[1].Items[0].Quality.Title)
+	assert.Equal(t, "Bluray-1080p", items[2].Quality.Title)
+	assert.Equal(t, trashQualityGroupIDBase, cutoff)
+
+	profile.Items = append(profile.Items, models.TrashQualityItem{Name: "Bluray-8K"})
+	_, _, err = trashProfileItems(profile, models.DefaultQualityDefinitions())
+	var validationErr models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "items", validationErr.Field)
+
+	profile.Items = profile.Items[:3]
+	profile.Cutoff = "Remux-2160p"
+	_, _, err = trashProfileItems(profile, models.DefaultQualityDefinitions())
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "cutoff", validationErr.Field)
+}
+
+func TestTrashFormatItems(t *testing.T) {
+	profile := &models.TrashQualityProfile{
+		TrashScoreSet: "sqp-1-1080p",
+		FormatItems:   map[string]string{"BR-DISK": "br-disk-id", "x265 (HD)": "x265-id", "Missing": "missing-id"},
+	}
+	supplied := []models.TrashCustomFormat{
+		{TrashID: "br-disk-id", TrashScores: map[string]int{"default": -10000}},
+		{TrashID: "x265-id", TrashScores: map[string]int{"default": -10000, "sqp-1-1080p": 0}},
+	}
+	stored := map[string]*models.CustomFormat{
+		"br-disk-id": {ID: 1, Name: "BR-DISK"},
+		"x265-id":    {ID: 2, Name: "x265 (HD)"},
+	}
+
+	items, skipped := trashFormatItems(profile, supplied, stored)
+	require.Len(t, items, 2)
+	assert.Equal(t, "BR-DISK", items[0].Name)
+	assert.Equal(t, -10000, items[0].Score)
+	assert.Equal(t, "x265 (HD)", items[1].Name)
+	assert.Equal(t, 0, items[1].Score)
+	assert.Equal(t, []string{"Missing"}, skipped)
+}
+
+func TestToTrashProfileBundle_RoundTrip(t *testing.T) {
+	definitions := models.DefaultQualityDefinitions()
+	trashProfile := &models.TrashQualityProfile{
+		Name:   "UHD",
+		Cutoff: "Bluray-2160p",
+		Items: []models.TrashQualityItem{
+			{Name: "Bluray-2160p", Allowed: true},
+			{Name: "WEB 2160p", Allowed: true, Items: []string{"WEBDL-2160p", "WEBRip-2160p"}},
+		},
+	}
+	items, cutoff, err := trashProfileItems(trashProfile, definitions)
+	require.NoError(t, err)
+
+	format := &models.CustomFormat{ID: 7, Name: "HDR", Specifications: models.CustomFormatSpecs{
+		{Name: "HDR", Implementation: "ReleaseTitleSpecification", Fields: map[string]interface{}{"value": "HDR"}},
+	}}
+	profile := &models.QualityProfile{
+		Name:        "UHD",
+		Cutoff:      cutoff,
+		Items:       items,
+		FormatItems: models.CustomFormatItems{{Format: &models.CustomFormat{ID: 7}, Name: "HDR", Score: 500}},
+	}
+
+	bundle := toTrashProfileBundle(profile, []*models.CustomFormat{format})
+	assert.Equal(t, trashProfile.Cutoff, bundle.QualityProfile.Cutoff)
+	assert.Equal(t, trashProfile.Items, bundle.QualityProfile.Items)
+	require.Len(t, bundle.CustomFormats, 1)
+	assert.Equal(t, trashIDFor(format), bundle.QualityProfile.FormatItems["HDR"])
+	assert.Equal(t, map[string]int{"default": 500}, bundle.CustomFormats[0].TrashScores)
+
+	format.TrashID = "e23edd2482476e595fb990b12e7c609c"
+	assert.Equal(t, "e23edd2482476e595fb990b12e7c609c", trashIDFor(format))
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// maxQueueItemSuggestions caps the movies suggested for one unmapped download
+const maxQueueItemSuggestions = 5
+
+// QueueResolutionService lists downloads that could not be matched to a library movie and maps
+// them to a movie chosen by the user
+type QueueResolutionService struct {
+	db              *database.Database
+	logger          *logger.Logger
+	queueService    *QueueService
+	parseService    *ParseService
+	movieService    *MovieService
+	metadataService *MetadataService
+}
+
+// NewQueueResolutionService creates a new queue resolution service
+func NewQueueResolutionService(
+	db *database.Database, logger *logger.Logger, queueService *QueueService, parseService *ParseService,
+	movieService *MovieService, metadataService *MetadataService,
+) *QueueResolutionService {
+	return &QueueResolutionService{
+		db:              db,
+		logger:          logger,
+		queueService:    queueService,
+		parseService:    parseService,
+		movieService:    movieService,
+		metadataService: metadataService,
+	}
+}
+
+// GetUnmappedItems returns the queued and completed downloads without a movie, newest first,
+// each with the movies suggested from its parsed title
+func (s *QueueResolutionService) GetUnmappedItems(ctx context.Context) ([]models.UnmappedQueueItem, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var queue []models.QueueItem
+	if err := s.db.GORM.WithContext(ctx).Where("movie_id IS NULL OR movie_id = 0").
+		Order("added DESC, id DESC").Find(&queue).Error; err != nil {
+		s.logger.Error("Failed to get unmapped queue items", "error", err)
+		return nil, fmt.Errorf("failed to get unmapped queue items: %w", err)
+	}
+
+	items := make([]models.UnmappedQueueItem, 0, len(queue))
+	for _, queueItem := range queue {
+		item := models.UnmappedQueueItem{QueueItem: queueItem, Suggestions: []models.QueueItemSuggestion{}}
+		if result, err := s.parseService.ParseReleaseTitle(ctx, queueItem.Title); err == nil {
+			item.ParsedMovieInfo = result.ParsedMovieInfo
+			item.Suggestions = s.suggestMovies(result)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// suggestMovies suggests library movies matching a parsed download title, followed by TMDB
+// search results, without duplicates
+func (s *QueueResolutionService) suggestMovies(result *models.ParseResult) []models.QueueItemSuggestion {
+	var library []models.Movie
+	if result.Movie != nil {
+		library = append(library, *result.Movie)
+	}
+	parsed := result.ParsedMovieInfo
+	if parsed == nil || parsed.PrimaryMovieTitle == "" {
+		return movieSuggestions(library, nil)
+	}
+
+	if movies, err := s.movieService.FindByTitle(parsed.PrimaryMovieTitle, parsed.Year); err == nil {
+		library = append(library, movies...)
+	}
+
+	var found []models.Movie
+	if s.metadataService != nil {
+		response, err := s.metadataService.SearchMovies(parsed.PrimaryMovieTitle, 1)
+		if err != nil {
+			s.logger.Debug("TMDB search for unmapped download failed", "title", parsed.PrimaryMovieTitle, "error", err)
+		} else {
+			for _, movie := range response.Results {
+				found = append(found, models.Movie{
+					TmdbID: movie.ID, Title: movie.Title, Year: releaseYear(movie.ReleaseDate),
+				})
+			}
+		}
+	}
+
+	// TMDB results already in the library are suggested as the library movie
+	for i := range found {
+		if movie, err := s.movieService.GetByTmdbID(found[i].TmdbID); err == nil {
+			found[i] = *movie
+		}
+	}
+	return movieSuggestions(library, found)
+}
+
+// movieSuggestions merges library movies and TMDB results into suggestions, dropping repeated
+// TMDB IDs and capping the list at maxQueueItemSuggestions
+func movieSuggestions(library, found []models.Movie) []models.QueueItemSuggestion {
+	suggestions := make([]models.QueueItemSuggestion, 0, maxQueueItemSuggestions)
+	seen := make(map[int]bool)
+	for _, movie := range append(library, found...) {
+		if len(suggestions) == maxQueueItemSuggestions {
+			break
+		}
+		if movie.TmdbID == 0 || seen[movie.TmdbID] {
+			continue
+		}
+		seen[movie.TmdbID] = true
+		suggestions = append(suggestions, models.QueueItemSuggestion{
+			TmdbID:    movie.TmdbID,
+			MovieID:   movie.ID,
+			Title:     movie.Title,
+			Year:      movie.Year,
+			InLibrary: movie.ID > 0,
+		})
+	}
+	return suggestions
+}
+
+// releaseYear returns the year of a TMDB "YYYY-MM-DD" release date, or 0
+func releaseYear(releaseDate string) int {
+	date, err := time.Parse("2006-01-02", releaseDate)
+	if err != nil {
+		return 0
+	}
+	return date.Year()
+}
+
+// Resolve maps a download to the chosen movie, adding the TMDB movie to the library first when
+// it isn't there yet, and returns the updated queue item
+func (s *QueueResolutionService) Resolve(id int, resolution *models.QueueItemResolution) (*models.QueueItem, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if err := resolution.Validate(); err != nil {
+		return nil, err
+	}
+
+	queueItem, err := s.queueService.GetQueueByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	movie, err := s.resolveMovie(resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.GORM.Model(&models.QueueItem{}).Where("id = ?", id).
+		Update("movie_id", movie.ID).Error; err != nil {
+		s.logger.Error("Failed to map queue item to movie", "id", id, "movieId", movie.ID, "error", err)
+		return nil, fmt.Errorf("failed to map queue item: %w", err)
+	}
+
+	queueItem.MovieID = movie.ID
+	queueItem.Movie = movie
+	s.logger.Info("Mapped queue item to movie", "id", id, "title", queueItem.Title, "movieId", movie.ID,
+		"movie", movie.Title)
+	return queueItem, nil
+}
+
+// resolveMovie finds the library movie a resolution names, adding the TMDB movie when needed
+func (s *QueueResolutionService) resolveMovie(resolution *models.QueueItemResolution) (*models.Movie, error) {
+	if resolution.MovieID > 0 {
+		movie, err := s.movieService.GetByID(resolution.MovieID)
+		if err != nil {
+			return nil, models.ValidationError{
+				Field: "movieId", Message: fmt.Sprintf("Movie %d is not in the library", resolution.MovieID),
+			}
+		}
+		return movie, nil
+	}
+
+	if movie, err := s.movieService.GetByTmdbID(resolution.TmdbID); err == nil {
+		return movie, nil
+	}
+
+	if err := resolution.ValidateNewMovie(); err != nil {
+		return nil, err
+	}
+	if s.metadataService == nil {
+		return nil, fmt.Errorf("metadata service not available to add TMDB movie %d", resolution.TmdbID)
+	}
+
+	movie, err := s.metadataService.LookupMovieByTMDBID(resolution.TmdbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata for TMDB movie %d: %w", resolution.TmdbID, err)
+	}
+
+	movie.QualityProfileID = resolution.QualityProfileID
+	movie.RootFolderPath = resolution.RootFolderPath
+	movie.Monitored = resolution.Monitored
+	movie.AddOptions = models.AddOptions{Monitor: resolution.Monitored, AddMethod: "queue"}
+	movie.Added = time.Now()
+
+	if err := s.movieService.Create(movie); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Added movie to resolve unmapped download", "tmdbId", movie.TmdbID, "title", movie.Title)
+	return movie, nil
+}
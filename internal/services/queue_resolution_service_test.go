@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieSuggestions(t *testing.T) {
+	library := []models.Movie{{ID: 7, TmdbID: 603, Title: "The Matrix", Year: 1999}}
+	found := []models.Movie{
+		{ID: 7, TmdbID: 603, Title: "The Matrix", Year: 1999},
+		{TmdbID: 604, Title: "The Matrix Reloaded", Year: 2003},
+		{TmdbID: 0, Title: "No TMDB ID"},
+		{TmdbID: 605, Title: "The Matrix Revolutions", Year: 2003},
+		{TmdbID: 624860, Title: "The Matrix Resurrections", Year: 2021},
+		{TmdbID: 55931, Title: "The Animatrix", Year: 2003},
+		{TmdbID: 14543, Title: "The Matrix Revisited", Year: 2001},
+	}
+
+	suggestions := movieSuggestions(library, found)
+	require.Len(t, suggestions, maxQueueItemSuggestions)
+	assert.Equal(t, models.QueueItemSuggestion{TmdbID: 603, MovieID: 7, Title: "The Matrix", Year: 1999, InLibrary: true},
+		suggestions[0])
+	assert.Equal(t, 604, suggestions[1].TmdbID)
+	assert.False(t, suggestions[1].InLibrary)
+	assert.Equal(t, 55931, suggestions[4].TmdbID)
+
+	assert.Empty(t, movieSuggestions(nil, nil))
+}
+
+func TestReleaseYear(t *testing.T) {
+	assert.Equal(t, 1999, releaseYear("1999-03-30"))
+	assert.Equal(t, 0, releaseYear(""))
+}
+
+func TestQueueItemResolution_Validate(t *testing.T) {
+	var validationErr models.ValidationError
+
+	require.NoError(t, (&models.QueueItemResolution{MovieID: 1}).Validate())
+	require.NoError(t, (&models.QueueItemResolution{TmdbID: 603}).Validate())
+
+	require.ErrorAs(t, (&models.QueueItemResolution{}).Validate(), &validationErr)
+	assert.Equal(t, "movieId", validationErr.Field)
+	require.ErrorAs(t, (&models.QueueItemResolution{MovieID: 1, TmdbID: 603}).Validate(), &validationErr)
+
+	newMovie := &models.QueueItemResolution{TmdbID: 603, QualityProfileID: 1}
+	require.ErrorAs(t, newMovie.ValidateNewMovie(), &validationErr)
+	assert.Equal(t, "rootFolderPath", validationErr.Field)
+	newMovie.RootFolderPath = "/movies"
+	assert.NoError(t, newMovie.ValidateNewMovie())
+}
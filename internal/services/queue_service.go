@@ -7,12 +7,14 @@ import (
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
 )
 
 // QueueService handles queue-related operations
 type QueueService struct {
-	db     *database.Database
-	logger *logger.Logger
+	db            *database.Database
+	logger        *logger.Logger
+	importService *ImportService
 }
 
 // NewQueueService creates a new queue service
@@ -32,6 +34,45 @@ func (s *QueueService) GetQueue(
 	}
 
 	var queue []models.QueueItem
+	query := s.queueQuery(movieIDs, protocol, status, includeUnknownMovieItems)
+
+	if err := query.Find(&queue).Error; err != nil {
+		s.logger.Error("Failed to get queue items", "error", err)
+		return nil, fmt.Errorf("failed to get queue items: %w", err)
+	}
+
+	s.logger.Debug("Retrieved queue items", "count", len(queue))
+	return queue, nil
+}
+
+// GetQueueByCursor retrieves queue items newest first, starting after the given cursor, and
+// returns the cursor for the next page or nil on the last page
+func (s *QueueService) GetQueueByCursor(
+	movieIDs []int, protocol *models.DownloadProtocol, status []models.QueueStatus,
+	includeUnknownMovieItems bool, cursor *models.PageCursor, limit int,
+) ([]models.QueueItem, *models.PageCursor, error) {
+	if s.db == nil {
+		return nil, nil, fmt.Errorf("database not available")
+	}
+
+	var queue []models.QueueItem
+	query := applyPageCursor(s.queueQuery(movieIDs, protocol, status, includeUnknownMovieItems),
+		"added", "id", cursor, limit)
+
+	if err := query.Find(&queue).Error; err != nil {
+		s.logger.Error("Failed to get queue items", "error", err)
+		return nil, nil, fmt.Errorf("failed to get queue items: %w", err)
+	}
+
+	queue, next := trimCursorPage(queue, limit, func(item *models.QueueItem) (time.Time, int) {
+		return item.Added, item.ID
+	})
+	return queue, next, nil
+}
+
+// queueQuery builds the queue item query with the given filters applied
+func (s *QueueService) queueQuery(movieIDs []int, protocol *models.DownloadProtocol,
+	status []models.QueueStatus, includeUnknownMovieItems bool) *gorm.DB {
 	query := s.db.GORM.Preload("Movie")
 
 	// Apply filters
@@ -55,13 +96,7 @@ func (s *QueueService) GetQueue(
 		query = query.Where("movie_id IS NOT NULL AND movie_id > 0")
 	}
 
-	if err := query.Find(&queue).Error; err != nil {
-		s.logger.Error("Failed to get queue items", "error", err)
-		return nil, fmt.Errorf("failed to get queue items: %w", err)
-	}
-
-	s.logger.Debug("Retrieved queue items", "count", len(queue))
-	return queue, nil
+	return query
 }
 
 // GetQueueByID retrieves a specific queue item by ID
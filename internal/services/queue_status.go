@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// UseImportService has CheckCompletedDownloads run the import decisions on completed downloads
+func (s *QueueService) UseImportService(importService *ImportService) {
+	s.importService = importService
+}
+
+// CheckCompletedDownloads runs the import checks on the completed downloads in the queue and
+// records why each one can't be imported in its status messages, so the queue shows what holds a
+// download up and how to resolve it. It returns the number of downloads with messages.
+func (s *QueueService) CheckCompletedDownloads(ctx context.Context) (int, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	if s.importService == nil {
+		return 0, fmt.Errorf("import service not available")
+	}
+
+	var items []models.QueueItem
+	if err := s.db.GORM.Preload("DownloadClient").Where("status = ?", models.QueueStatusCompleted).
+		Find(&items).Error; err != nil {
+		return 0, fmt.Errorf("failed to get completed downloads: %w", err)
+	}
+	var mappings []models.RemotePathMapping
+	if err := s.db.GORM.Find(&mappings).Error; err != nil {
+		return 0, fmt.Errorf("failed to get remote path mappings: %w", err)
+	}
+
+	withMessages := 0
+	for i := range items {
+		if ctx.Err() != nil {
+			return withMessages, ctx.Err()
+		}
+
+		status, messages := s.completedDownloadStatus(ctx, &items[i], mappings)
+		if len(messages) > 0 {
+			withMessages++
+		}
+		if err := s.db.GORM.Model(&models.QueueItem{}).Where("id = ?", items[i].ID).Updates(map[string]interface{}{
+			"tracked_download_status": string(status),
+			"status_messages":         messages,
+		}).Error; err != nil {
+			s.logger.Error("Failed to update queue item status messages", "id", items[i].ID, "error", err)
+		}
+	}
+	return withMessages, nil
+}
+
+// completedDownloadStatus returns the tracked status and status messages of a completed download:
+// an error when Radarr can't reach its files, a warning when some of them would be rejected
+func (s *QueueService) completedDownloadStatus(
+	ctx context.Context, item *models.QueueItem, mappings []models.RemotePathMapping,
+) (models.TrackedDownloadStatus, models.StatusMessageArray) {
+	remotePath := item.OutputPath
+	if remotePath == "" {
+		remotePath = item.DownloadedInfo.DownloadedPath
+	}
+	if remotePath == "" {
+		return models.TrackedDownloadStatusWarning, models.StatusMessageArray{{
+			Title: item.Title,
+			Messages: []string{"The download client didn't report where the download was saved. " +
+				"Check the download in the client, or import it manually."},
+			Type: models.StatusMessageTypeWarning,
+		}}
+	}
+
+	host := ""
+	if item.DownloadClient != nil {
+		host = item.DownloadClient.Host
+	}
+	localPath, mapping := translateRemotePath(mappings, host, remotePath)
+	if _, err := os.Stat(localPath); err != nil {
+		return models.TrackedDownloadStatusError, models.StatusMessageArray{{
+			Title:    item.Title,
+			Messages: []string{unreachableDownloadMessage(remotePath, localPath, host, mapping)},
+			Type:     models.StatusMessageTypeError,
+		}}
+	}
+
+	messages := s.importService.DownloadStatusMessages(ctx, localPath, item.MovieID)
+	if len(messages) > 0 {
+		return models.TrackedDownloadStatusWarning, messages
+	}
+	return models.TrackedDownloadStatusOk, models.StatusMessageArray{}
+}
+
+// unreachableDownloadMessage explains that Radarr can't find a download's files and how to fix it
+func unreachableDownloadMessage(
+	remotePath, localPath, host string, mapping *models.RemotePathMapping,
+) string {
+	message := fmt.Sprintf("Import failed, path does not exist or is not accessible by Radarr: %s.", localPath)
+	switch {
+	case mapping != nil:
+		return message + fmt.Sprintf(" The remote path mapping for %s translated it from %s, check the "+
+			"mapping's local path.", mapping.Host, remotePath)
+	case host != "" && host != "localhost" && host != "127.0.0.1":
+		return message + fmt.Sprintf(" If the download client runs on another host or in a container, add a "+
+			"remote path mapping for host %s.", host)
+	}
+	return message + " Ensure the path exists and the user running Radarr can access it."
+}
+
+// DownloadStatusMessages runs the import decisions on the files of a download and returns a
+// status message for each file that would be rejected, or a single message when the download has
+// no importable files. grabbedMovieID is the movie the download was grabbed for, 0 when unknown.
+func (s *ImportService) DownloadStatusMessages(
+	ctx context.Context, path string, grabbedMovieID int,
+) models.StatusMessageArray {
+	files, err := s.fileOrganizationService.ScanDirectory(path)
+	if err != nil {
+		return models.StatusMessageArray{{
+			Title:    filepath.Base(path),
+			Messages: []string{fmt.Sprintf("Unable to read the download's files: %v", err)},
+			Type:     models.StatusMessageTypeError,
+		}}
+	}
+	if len(files) == 0 {
+		return models.StatusMessageArray{{
+			Title: filepath.Base(path),
+			Messages: []string{fmt.Sprintf("No files found are eligible for import in %s. Check that the "+
+				"download finished and contains a video file that isn't a sample.", path)},
+			Type: models.StatusMessageTypeWarning,
+		}}
+	}
+
+	messages := models.StatusMessageArray{}
+	for _, file := range files {
+		decision := s.makeImportDecision(ctx, file, nil)
+		if decision.Decision == models.ImportDecisionApproved {
+			if decision.LocalMovie != nil && grabbedMovieID > 0 && decision.LocalMovie.ID != grabbedMovieID {
+				messages = append(messages, models.StatusMessage{
+					Title: file.Name,
+					Messages: []string{fmt.Sprintf("File was matched to %s, not the movie it was grabbed for. "+
+						"Import it manually to choose the movie.", decision.LocalMovie.Title)},
+					Type: models.StatusMessageTypeWarning,
+				})
+			}
+			continue
+		}
+
+		fileMessages := make([]string, 0, len(decision.Rejections))
+		for _, rejection := range decision.Rejections {
+			fileMessages = append(fileMessages, rejectionStatusMessage(rejection, grabbedMovieID > 0))
+		}
+		messages = append(messages, models.StatusMessage{
+			Title:    file.Name,
+			Messages: fileMessages,
+			Type:     models.StatusMessageTypeWarning,
+		})
+	}
+	return messages
+}
+
+// rejectionStatusMessage describes an import rejection with what the user can do about it.
+// grabbed reports whether the download's movie is known from the grab history.
+func rejectionStatusMessage(rejection models.ImportRejection, grabbed bool) string {
+	reason := string(rejection.Reason)
+	switch rejection.Reason {
+	case models.ImportRejectionUnknownMovie:
+		if grabbed {
+			return "Found matching movie via grab history, but the file name couldn't be matched to it. " +
+				"Import it manually to confirm the movie."
+		}
+		return "Unable to match the file to a movie in the library. Add the movie, or import the file " +
+			"manually to choose it."
+	case models.ImportRejectionExistingFile:
+		return "A file already exists at the destination. Remove or rename it, then retry the import."
+	case models.ImportRejectionSample:
+		return "Sample files are not imported."
+	}
+
+	if reason == qualityNotUpgradeReason {
+		if grabbed {
+			return "Found matching movie via grab history but file quality is lower than the existing file. " +
+				"Import it manually to replace the existing file anyway."
+		}
+		return "File quality is not an upgrade over the existing file. Import it manually to replace the " +
+			"existing file anyway."
+	}
+	if rejection.Type == models.ImportRejectionTypePermanent {
+		return reason + ". Import the file manually to override."
+	}
+	return reason + "."
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueService_CompletedDownloadStatus(t *testing.T) {
+	log := logger.New(config.LogConfig{Level: "error"})
+	service := NewQueueService(nil, log)
+	service.UseImportService(&ImportService{
+		logger:                  log,
+		fileOrganizationService: NewFileOrganizationService(nil, log, nil, nil),
+	})
+	ctx := context.Background()
+	client := &models.DownloadClient{Host: "seedbox"}
+
+	status, messages := service.completedDownloadStatus(ctx, &models.QueueItem{Title: "Movie.2020"}, nil)
+	assert.Equal(t, models.TrackedDownloadStatusWarning, status)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Messages[0], "didn't report where the download was saved")
+
+	item := &models.QueueItem{Title: "Movie.2020", OutputPath: "/downloads/Movie.2020", DownloadClient: client}
+	status, messages = service.completedDownloadStatus(ctx, item, nil)
+	assert.Equal(t, models.TrackedDownloadStatusError, status)
+	require.Len(t, messages, 1)
+	assert.Equal(t, models.StatusMessageTypeError, messages[0].Type)
+	assert.Contains(t, messages[0].Messages[0], "add a remote path mapping for host seedbox")
+
+	mappings := []models.RemotePathMapping{{Host: "seedbox", RemotePath: "/downloads/", LocalPath: "/mnt/missing/"}}
+	_, messages = service.completedDownloadStatus(ctx, item, mappings)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Messages[0], "/mnt/missing/Movie.2020")
+	assert.Contains(t, messages[0].Messages[0], "check the mapping's local path")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Movie.2020.nfo"), []byte("x"), 0o600))
+	status, messages = service.completedDownloadStatus(ctx, &models.QueueItem{Title: "Movie.2020", OutputPath: dir}, nil)
+	assert.Equal(t, models.TrackedDownloadStatusWarning, status)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Messages[0], "No files found are eligible for import")
+}
+
+func TestRejectionStatusMessage(t *testing.T) {
+	lower := models.ImportRejection{Reason: qualityNotUpgradeReason, Type: models.ImportRejectionTypeTemporary}
+	assert.Contains(t, rejectionStatusMessage(lower, true),
+		"Found matching movie via grab history but file quality is lower")
+	assert.Contains(t, rejectionStatusMessage(lower, false), "not an upgrade")
+
+	unknown := models.ImportRejection{Reason: models.ImportRejectionUnknownMovie}
+	assert.Contains(t, rejectionStatusMessage(unknown, true), "grab history")
+	assert.Contains(t, rejectionStatusMessage(unknown, false), "Add the movie")
+
+	language := models.ImportRejection{
+		Reason: "Existing file has the profile's language", Type: models.ImportRejectionTypePermanent,
+	}
+	assert.Equal(t, "Existing file has the profile's language. Import the file manually to override.",
+		rejectionStatusMessage(language, false))
+}
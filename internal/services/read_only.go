@@ -0,0 +1,86 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// ReadOnlyStatus reports whether the instance is in read-only maintenance mode
+type ReadOnlyStatus struct {
+	Enabled bool       `json:"enabled"`
+	Reason  string     `json:"reason,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// ReadOnlyMode is the maintenance toggle under which the API refuses changes and background
+// tasks pause, for example while the database is migrated or backed up
+type ReadOnlyMode struct {
+	mu       sync.RWMutex
+	enabled  bool
+	reason   string
+	since    time.Time
+	onChange []func(enabled bool)
+}
+
+// NewReadOnlyMode creates the maintenance toggle in its configured starting state
+func NewReadOnlyMode(enabled bool, reason string) *ReadOnlyMode {
+	mode := &ReadOnlyMode{}
+	if enabled {
+		mode.enabled = true
+		mode.reason = reason
+		mode.since = time.Now()
+	}
+	return mode
+}
+
+// Enabled reports whether read-only mode is on
+func (m *ReadOnlyMode) Enabled() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// Set turns read-only mode on or off and notifies the OnChange callbacks when the state changes
+func (m *ReadOnlyMode) Set(enabled bool, reason string) {
+	m.mu.Lock()
+	changed := m.enabled != enabled
+	m.enabled = enabled
+	m.reason = ""
+	if enabled {
+		m.reason = reason
+		if changed {
+			m.since = time.Now()
+		}
+	}
+	callbacks := m.onChange
+	m.mu.Unlock()
+
+	if changed {
+		for _, callback := range callbacks {
+			callback(enabled)
+		}
+	}
+}
+
+// OnChange registers a callback run whenever read-only mode is turned on or off
+func (m *ReadOnlyMode) OnChange(callback func(enabled bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = append(m.onChange, callback)
+}
+
+// Status returns the current state for the API
+func (m *ReadOnlyMode) Status() ReadOnlyStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := ReadOnlyStatus{Enabled: m.enabled, Reason: m.reason}
+	if m.enabled {
+		since := m.since
+		status.Since = &since
+	}
+	return status
+}
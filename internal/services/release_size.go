@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	// unknownQualityTitle is the quality definition used for releases whose quality is not parsed
+	unknownQualityTitle = "Unknown"
+	// unknownRuntimeMin and unknownRuntimeMax are the runtimes, in minutes, assumed for movies
+	// whose metadata has no runtime yet: the minimum size is checked as for a short movie and the
+	// maximum as for a long one
+	unknownRuntimeMin = 60
+	unknownRuntimeMax = 240
+	// mislabeledSizeRatio is the fraction of the minimum size below which a release is reported
+	// as likely mislabeled, such as a 200 MB "1080p BluRay", rather than merely too small
+	mislabeledSizeRatio = 0.25
+)
+
+// qualityTitleForRelease maps a quality parsed from a release title to the title of its quality
+// definition, or "" when the source or resolution is not known well enough to pick one
+func qualityTitleForRelease(quality models.QualityDefinition) string {
+	switch quality.Source {
+	case "cam":
+		return "CAM"
+	case "telesync":
+		return "TELESYNC"
+	case "dvd":
+		return "DVD"
+	}
+
+	if quality.Resolution == 0 {
+		return ""
+	}
+	switch quality.Source {
+	case "bluray":
+		return fmt.Sprintf("Bluray-%dp", quality.Resolution)
+	case "webdl":
+		return fmt.Sprintf("WEBDL-%dp", quality.Resolution)
+	case "webrip":
+		return fmt.Sprintf("WEBRip-%dp", quality.Resolution)
+	case "hdtv":
+		if quality.Resolution <= 480 {
+			return "SDTV"
+		}
+		return fmt.Sprintf("HDTV-%dp", quality.Resolution)
+	}
+	return ""
+}
+
+// releaseSizeRejection checks a release's size against its quality definition for a movie of the
+// given runtime in minutes, or a range of plausible runtimes when it is 0, returning the
+// rejection reason or ""
+func releaseSizeRejection(size int64, definition *models.QualityLevel, runtime int) string {
+	minRuntime, maxRuntime := runtime, runtime
+	runtimeText := fmt.Sprintf("at %d minutes", runtime)
+	if runtime <= 0 {
+		minRuntime, maxRuntime = unknownRuntimeMin, unknownRuntimeMax
+		runtimeText = "for an unknown runtime"
+	}
+
+	minBytes, _ := definition.SizeLimits(minRuntime)
+	_, maxBytes := definition.SizeLimits(maxRuntime)
+	if size < int64(float64(minBytes)*mislabeledSizeRatio) {
+		return fmt.Sprintf("%s is far below the %s minimum for %s %s, the release is likely mislabeled",
+			formatReleaseSize(size), formatReleaseSize(minBytes), definition.Title, runtimeText)
+	}
+	if size < minBytes {
+		return fmt.Sprintf("%s is smaller than the %s minimum for %s %s",
+			formatReleaseSize(size), formatReleaseSize(minBytes), definition.Title, runtimeText)
+	}
+	if maxBytes > 0 && size > maxBytes {
+		return fmt.Sprintf("%s is larger than the %s maximum for %s %s",
+			formatReleaseSize(size), formatReleaseSize(maxBytes), definition.Title, runtimeText)
+	}
+	return ""
+}
+
+// formatReleaseSize formats a size in bytes for rejection messages
+func formatReleaseSize(size int64) string {
+	const gb = 1024 * 1024 * 1024
+	if size >= gb {
+		return fmt.Sprintf("%.1f GB", float64(size)/gb)
+	}
+	return fmt.Sprintf("%.0f MB", float64(size)/(1024*1024))
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualityTitleForRelease(t *testing.T) {
+	tests := []struct {
+		quality models.QualityDefinition
+		want    string
+	}{
+		{models.QualityDefinition{Source: "bluray", Resolution: 1080}, "Bluray-1080p"},
+		{models.QualityDefinition{Source: "webrip", Resolution: 2160}, "WEBRip-2160p"},
+		{models.QualityDefinition{Source: "hdtv", Resolution: 480}, "SDTV"},
+		{models.QualityDefinition{Source: "cam"}, "CAM"},
+		{models.QualityDefinition{Source: "unknown", Resolution: 1080}, ""},
+		{models.QualityDefinition{Source: "bluray"}, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, qualityTitleForRelease(tt.quality))
+	}
+}
+
+func TestSearchService_evaluateRelease_SizeLimits(t *testing.T) {
+	service := &SearchService{}
+	definitions := make(map[string]*models.QualityLevel)
+	for _, definition := range models.DefaultQualityDefinitions() {
+		definitions[definition.Title] = definition
+	}
+	movie := &models.Movie{Runtime: 120}
+	bluray := models.Quality{Quality: models.QualityDefinition{Source: "bluray", Resolution: 1080}}
+	evaluate := func(release models.Release, movie *models.Movie) models.Release {
+		return service.evaluateRelease(release, &releaseEvaluation{movie: movie, definitions: definitions})
+	}
+
+	// Bluray-1080p allows 4.3 to 258.1 MB per minute: 516 MB to ~30 GB for two hours
+	tooSmall := evaluate(models.Release{Size: 200 << 20, Quality: bluray}, movie)
+	assert.Equal(t, models.ReleaseStatusRejected, tooSmall.Status)
+	assert.Contains(t, tooSmall.RejectionReasons[0], "smaller than the 516 MB minimum for Bluray-1080p")
+
+	tooLarge := evaluate(models.Release{Size: 40 << 30, Quality: bluray}, movie)
+	assert.Contains(t, tooLarge.RejectionReasons[0], "larger than the 30.2 GB maximum")
+
+	accepted := evaluate(models.Release{Size: 10 << 30, Quality: bluray}, movie)
+	assert.Empty(t, accepted.RejectionReasons)
+
+	mislabeled := evaluate(models.Release{Size: 100 << 20, Quality: bluray}, movie)
+	assert.Contains(t, mislabeled.RejectionReasons[0], "likely mislabeled")
+
+	// Without a runtime the minimum is checked as for an hour and the maximum as for four hours
+	noRuntime := &models.Movie{}
+	short := evaluate(models.Release{Size: 200 << 20, Quality: bluray}, noRuntime)
+	assert.Contains(t, short.RejectionReasons[0], "smaller than the 258 MB minimum for Bluray-1080p for an unknown runtime")
+	long := evaluate(models.Release{Size: 50 << 30, Quality: bluray}, nil)
+	assert.Empty(t, long.RejectionReasons)
+
+	// Releases without a parsed quality are held to the Unknown definition
+	unknown := evaluate(models.Release{Size: 30 << 30}, movie)
+	assert.Contains(t, unknown.RejectionReasons[0], "maximum for Unknown")
+
+	remux := models.Quality{Quality: models.QualityDefinition{Source: "bluray", Resolution: 2160}}
+	definitions["Bluray-2160p"].MaxSize = 0
+	unlimited := evaluate(models.Release{Size: 80 << 30, Quality: remux}, movie)
+	assert.Empty(t, unlimited.RejectionReasons)
+}
+
+func TestQualityLevel_Validate(t *testing.T) {
+	valid := &models.QualityLevel{MinSize: 2, PreferredSize: 95, MaxSize: 137.3}
+	assert.NoError(t, valid.Validate())
+
+	unlimited := &models.QualityLevel{MinSize: 2, PreferredSize: 400}
+	assert.NoError(t, unlimited.Validate())
+
+	var validationErr models.ValidationError
+	outOfRange := &models.QualityLevel{MinSize: 2, PreferredSize: 150, MaxSize: 137.3}
+	assert.ErrorAs(t, outOfRange.Validate(), &validationErr)
+	assert.Equal(t, "preferredSize", validationErr.Field)
+
+	inverted := &models.QualityLevel{MinSize: 200, MaxSize: 137.3}
+	assert.ErrorAs(t, inverted.Validate(), &validationErr)
+	assert.Equal(t, "minSize", validationErr.Field)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// RemotePathMappingService provides operations for managing remote path mappings.
+type RemotePathMappingService struct {
+	db     *database.Database
+	logger *logger.Logger
+}
+
+// NewRemotePathMappingService creates a new instance of RemotePathMappingService with the provided database and logger.
+func NewRemotePathMappingService(db *database.Database, logger *logger.Logger) *RemotePathMappingService {
+	return &RemotePathMappingService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAll retrieves all remote path mappings ordered by host and remote path.
+func (s *RemotePathMappingService) GetAll() ([]models.RemotePathMapping, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var mappings []models.RemotePathMapping
+	if err := s.db.GORM.Order("host, remote_path").Find(&mappings).Error; err != nil {
+		s.logger.Error("Failed to fetch remote path mappings", "error", err)
+		return nil, fmt.Errorf("failed to fetch remote path mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// GetByID retrieves a remote path mapping by its ID.
+func (s *RemotePathMappingService) GetByID(id int) (*models.RemotePathMapping, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var mapping models.RemotePathMapping
+	if err := s.db.GORM.Where("id = ?", id).First(&mapping).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch remote path mapping with id %d: %w", id, err)
+	}
+
+	return &mapping, nil
+}
+
+// Create validates and saves a new remote path mapping.
+func (s *RemotePathMappingService) Create(mapping *models.RemotePathMapping) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.db.GORM.Create(mapping).Error; err != nil {
+		s.logger.Error("Failed to create remote path mapping", "host", mapping.Host, "error", err)
+		return fmt.Errorf("failed to create remote path mapping: %w", err)
+	}
+
+	s.logger.Info("Created remote path mapping", "id", mapping.ID, "host", mapping.Host,
+		"remotePath", mapping.RemotePath, "localPath", mapping.LocalPath)
+	return nil
+}
+
+// Update validates and saves changes to an existing remote path mapping.
+func (s *RemotePathMappingService) Update(mapping *models.RemotePathMapping) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.GetByID(mapping.ID)
+	if err != nil {
+		return err
+	}
+	mapping.CreatedAt = existing.CreatedAt
+
+	if err := s.db.GORM.Save(mapping).Error; err != nil {
+		s.logger.Error("Failed to update remote path mapping", "id", mapping.ID, "error", err)
+		return fmt.Errorf("failed to update remote path mapping: %w", err)
+	}
+
+	s.logger.Info("Updated remote path mapping", "id", mapping.ID, "host", mapping.Host)
+	return nil
+}
+
+// Delete removes a remote path mapping.
+func (s *RemotePathMappingService) Delete(id int) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	result := s.db.GORM.Delete(&models.RemotePathMapping{}, id)
+	if result.Error != nil {
+		s.logger.Error("Failed to delete remote path mapping", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete remote path mapping: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("remote path mapping with id %d not found", id)
+	}
+
+	s.logger.Info("Deleted remote path mapping", "id", id)
+	return nil
+}
+
+// translateRemotePath applies the most specific mapping for the host to remotePath. Without a
+// matching mapping the path is returned unchanged, along with a nil mapping.
+func translateRemotePath(
+	mappings []models.RemotePathMapping, host, remotePath string,
+) (string, *models.RemotePathMapping) {
+	var best *models.RemotePathMapping
+	local := remotePath
+	for i := range mappings {
+		translated, ok := mappings[i].Translate(host, remotePath)
+		if ok && (best == nil || len(mappings[i].RemotePath) > len(best.RemotePath)) {
+			best = &mappings[i]
+			local = translated
+		}
+	}
+	return local, best
+}
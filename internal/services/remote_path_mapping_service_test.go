@@ -0,0 +1,67 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateRemotePath(t *testing.T) {
+	mappings := []models.RemotePathMapping{
+		{ID: 1, Host: "nas", RemotePath: "/downloads/", LocalPath: "/mnt/nas/downloads"},
+		{ID: 2, Host: "nas", RemotePath: "/downloads/complete", LocalPath: "/mnt/complete"},
+		{ID: 3, Host: "seedbox", RemotePath: `D:\Torrents`, LocalPath: "/mnt/seedbox"},
+	}
+
+	tests := []struct {
+		name      string
+		host      string
+		path      string
+		expected  string
+		mappingID int
+	}{
+		{"most specific mapping", "nas", "/downloads/complete/Movie.2020", "/mnt/complete/Movie.2020", 2},
+		{"mapping root", "NAS", "/downloads", "/mnt/nas/downloads", 1},
+		{"windows client", "seedbox", `D:\Torrents\Movie (2020)\movie.mkv`,
+			filepath.Join("/mnt/seedbox", "Movie (2020)", "movie.mkv"), 3},
+		{"similar prefix", "nas", "/downloads-old/Movie", "/downloads-old/Movie", 0},
+		{"other host", "client", "/downloads/Movie", "/downloads/Movie", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, mapping := translateRemotePath(mappings, tt.host, tt.path)
+			assert.Equal(t, tt.expected, local)
+			if tt.mappingID == 0 {
+				assert.Nil(t, mapping)
+				return
+			}
+			require.NotNil(t, mapping)
+			assert.Equal(t, tt.mappingID, mapping.ID)
+		})
+	}
+}
+
+func TestRemotePathMapping_Validate(t *testing.T) {
+	var validationErr models.ValidationError
+
+	mapping := &models.RemotePathMapping{Host: " nas ", RemotePath: "/downloads", LocalPath: "/mnt/downloads"}
+	require.NoError(t, mapping.Validate())
+	assert.Equal(t, "nas", mapping.Host)
+
+	mapping.LocalPath = "downloads"
+	require.ErrorAs(t, mapping.Validate(), &validationErr)
+	assert.Equal(t, "localPath", validationErr.Field)
+
+	require.ErrorAs(t, (&models.RemotePathMapping{Host: "nas"}).Validate(), &validationErr)
+	assert.Equal(t, "remotePath", validationErr.Field)
+}
+
+func TestRemotePathMappingService_NoDatabase(t *testing.T) {
+	service := NewRemotePathMappingService(nil, nil)
+	_, err := service.GetAll()
+	assert.EqualError(t, err, "database not available")
+	assert.EqualError(t, service.Delete(1), "database not available")
+}
@@ -18,17 +18,21 @@ import (
 
 // RenameService handles file renaming operations
 type RenameService struct {
-	db            *database.Database
-	logger        *logger.Logger
-	namingService *NamingService
+	db             *database.Database
+	logger         *logger.Logger
+	namingService  *NamingService
+	historyService *HistoryService
 }
 
 // NewRenameService creates a new rename service
-func NewRenameService(db *database.Database, logger *logger.Logger, namingService *NamingService) *RenameService {
+func NewRenameService(
+	db *database.Database, logger *logger.Logger, namingService *NamingService, historyService *HistoryService,
+) *RenameService {
 	return &RenameService{
-		db:            db,
-		logger:        logger,
-		namingService: namingService,
+		db:             db,
+		logger:         logger,
+		namingService:  namingService,
+		historyService: historyService,
 	}
 }
 
@@ -54,13 +58,20 @@ func (s *RenameService) RenameMovies(ctx context.Context, movieIDs []int) error
 	successCount := 0
 	errorCount := 0
 
+	tracker := s.historyService.TrackActivity(models.ActivityTypeRename,
+		fmt.Sprintf("Rename files for %d movies", len(movieIDs)), len(movieIDs))
+	defer tracker.Finish(nil)
+
 	for _, movieID := range movieIDs {
-		if err := s.renameMovie(ctx, movieID); err != nil {
+		err := s.renameMovie(ctx, movieID)
+		if err != nil {
 			s.logger.Error("Failed to rename movie files", "movieId", movieID, "error", err)
 			errorCount++
+			err = fmt.Errorf("movie %d: %w", movieID, err)
 		} else {
 			successCount++
 		}
+		tracker.Advance(err)
 	}
 
 	s.logger.Info("Completed movie renaming", "success", successCount, "errors", errorCount)
@@ -96,13 +107,20 @@ func (s *RenameService) RenameMovieFolders(ctx context.Context, movieIDs []int)
 	successCount := 0
 	errorCount := 0
 
+	tracker := s.historyService.TrackActivity(models.ActivityTypeFolderMove,
+		fmt.Sprintf("Move folders for %d movies", len(movieIDs)), len(movieIDs))
+	defer tracker.Finish(nil)
+
 	for _, movieID := range movieIDs {
-		if err := s.renameMovieFolder(ctx, movieID); err != nil {
+		err := s.renameMovieFolder(ctx, movieID)
+		if err != nil {
 			s.logger.Error("Failed to rename movie folder", "movieId", movieID, "error", err)
 			errorCount++
+			err = fmt.Errorf("movie %d: %w", movieID, err)
 		} else {
 			successCount++
 		}
+		tracker.Advance(err)
 	}
 
 	s.logger.Info("Completed movie folder renaming", "success", successCount, "errors", errorCount)
@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// releasePartitionPrefix names the monthly partitions, e.g. releases_p202610
+	releasePartitionPrefix = "releases_p"
+	// releasePartitionMonthsAhead is how many future months get a partition ahead of time
+	releasePartitionMonthsAhead = 2
+)
+
+// RetentionService prunes search results, history and retired rows according to the configured
+// retention policy
+type RetentionService struct {
+	db     *database.Database
+	logger *logger.Logger
+	config config.RetentionConfig
+
+	partitionMu sync.Mutex
+	partitioned *bool
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(db *database.Database, cfg config.RetentionConfig, logger *logger.Logger) *RetentionService {
+	return &RetentionService{
+		db:     db,
+		logger: logger,
+		config: cfg,
+	}
+}
+
+// MaxReleasesPerMovie returns the number of releases kept per movie, or 0 for no limit
+func (s *RetentionService) MaxReleasesPerMovie() int {
+	return s.config.ReleaseMaxPerMovie
+}
+
+// ReleasesPartitioned reports whether the releases table is partitioned by month.
+// The result is looked up once and cached.
+func (s *RetentionService) ReleasesPartitioned() bool {
+	s.partitionMu.Lock()
+	defer s.partitionMu.Unlock()
+
+	if s.partitioned != nil {
+		return *s.partitioned
+	}
+
+	partitioned := false
+	if s.db != nil && s.db.IsPostgres() {
+		var count int64
+		err := s.db.GORM.Raw(
+			"SELECT COUNT(*) FROM pg_partitioned_table pt JOIN pg_class c ON c.oid = pt.partrelid " +
+				"WHERE c.relname = 'releases' AND pg_table_is_visible(c.oid)",
+		).Scan(&count).Error
+		if err != nil {
+			s.logger.Warn("Failed to check releases partitioning", "error", err)
+			return false
+		}
+		partitioned = count > 0
+	}
+
+	s.partitioned = &partitioned
+	return partitioned
+}
+
+// PruneReleases applies the release retention policy and returns the number of releases removed.
+// Grabbed releases are never removed. When partitioning is enabled on PostgreSQL the releases
+// table is converted on first run, and months older than the retention period are dropped whole.
+// A simulated run counts the releases instead, leaving partitions alone.
+func (s *RetentionService) PruneReleases(ctx context.Context) (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	simulate := Simulating(ctx)
+	if s.config.ReleasePartitioning && s.db.IsPostgres() && !simulate {
+		if err := s.ensureReleasePartitions(s.db.GORM.WithContext(ctx)); err != nil {
+			s.logger.Error("Failed to maintain release partitions", "error", err)
+		}
+	}
+
+	removed, err := prune(ctx, s.db.GORM, func(tx *gorm.DB) (int64, error) {
+		var removed int64
+		if s.config.ReleaseMaxAgeDays > 0 {
+			cutoff := time.Now().UTC().AddDate(0, 0, -s.config.ReleaseMaxAgeDays)
+
+			if s.ReleasesPartitioned() && !simulate {
+				dropped, err := s.dropExpiredReleasePartitions(tx, cutoff)
+				if err != nil {
+					s.logger.Error("Failed to drop expired release partitions", "error", err)
+				}
+				removed += dropped
+			}
+
+			result := tx.Where("created_at < ? AND status <> ?", cutoff, models.ReleaseStatusGrabbed).
+				Delete(&models.Release{})
+			if result.Error != nil {
+				return removed, fmt.Errorf("failed to delete expired releases: %w", result.Error)
+			}
+			removed += result.RowsAffected
+		}
+
+		if s.config.ReleaseMaxPerMovie > 0 {
+			// The derived table lets MySQL delete from the table it ranks
+			result := tx.Exec(
+				"DELETE FROM releases WHERE status <> ? AND id IN (SELECT id FROM ("+
+					"SELECT id, ROW_NUMBER() OVER (PARTITION BY movie_id ORDER BY publish_date DESC, id DESC) AS position "+
+					"FROM releases WHERE movie_id IS NOT NULL) AS ranked WHERE position > ?)",
+				models.ReleaseStatusGrabbed, s.config.ReleaseMaxPerMovie,
+			)
+			if result.Error != nil {
+				return removed, fmt.Errorf("failed to cap releases per movie: %w", result.Error)
+			}
+			removed += result.RowsAffected
+		}
+		return removed, nil
+	})
+	if err != nil {
+		return removed, err
+	}
+
+	s.logger.Info("Pruned releases", "removed", removed, "simulated", simulate,
+		"maxAgeDays", s.config.ReleaseMaxAgeDays, "maxPerMovie", s.config.ReleaseMaxPerMovie)
+	return removed, nil
+}
+
+// PruneHistory applies the history retention policy and returns the number of records removed.
+// Records of the configured protected event types are kept, as are grabs whose download is still
+// in the queue, since seeding torrents and pending imports are matched back to them.
+func (s *RetentionService) PruneHistory(ctx context.Context) (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	var removedByAge, removedByLimit int64
+	_, err := prune(ctx, s.db.GORM, func(tx *gorm.DB) (int64, error) {
+		if s.config.HistoryMaxAgeDays > 0 {
+			cutoff := time.Now().AddDate(0, 0, -s.config.HistoryMaxAgeDays)
+
+			result := s.unprotectedHistory(tx).Where("date < ?", cutoff).Delete(&models.History{})
+			if result.Error != nil {
+				return 0, fmt.Errorf("failed to delete expired history: %w", result.Error)
+			}
+			removedByAge = result.RowsAffected
+		}
+
+		if s.config.HistoryMaxRows > 0 {
+			// Protected records count toward the limit but are never removed by it
+			result := s.unprotectedHistory(tx).Where(
+				"id IN (SELECT id FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY date DESC, id DESC) AS position "+
+					"FROM history) AS ranked WHERE position > ?)",
+				s.config.HistoryMaxRows,
+			).Delete(&models.History{})
+			if result.Error != nil {
+				return removedByAge, fmt.Errorf("failed to cap history records: %w", result.Error)
+			}
+			removedByLimit = result.RowsAffected
+		}
+		return removedByAge + removedByLimit, nil
+	})
+	if err != nil {
+		return removedByAge, err
+	}
+
+	s.logger.Info("Pruned history", "removedByAge", removedByAge, "removedByLimit", removedByLimit,
+		"simulated", Simulating(ctx), "maxAgeDays", s.config.HistoryMaxAgeDays, "maxRows", s.config.HistoryMaxRows)
+	return removedByAge + removedByLimit, nil
+}
+
+// PruneRemovedSeedingTorrents deletes torrents removed from their client after seeding longer
+// ago than the retired row age and returns the number deleted
+func (s *RetentionService) PruneRemovedSeedingTorrents(ctx context.Context) (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	if s.config.RetiredMaxAgeDays <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.config.RetiredMaxAgeDays)
+	removed, err := prune(ctx, s.db.GORM, func(tx *gorm.DB) (int64, error) {
+		result := tx.Where("status = ? AND removed_at < ?", models.SeedingStatusRemoved, cutoff).
+			Delete(&models.SeedingTorrent{})
+		return result.RowsAffected, result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete removed seeding torrents: %w", err)
+	}
+
+	s.logger.Info("Pruned removed seeding torrents", "removed", removed, "simulated", Simulating(ctx),
+		"maxAgeDays", s.config.RetiredMaxAgeDays)
+	return removed, nil
+}
+
+// PruneExpiredTombstones deletes movie tombstones that have expired and returns the number deleted
+func (s *RetentionService) PruneExpiredTombstones(ctx context.Context) (int64, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+
+	removed, err := prune(ctx, s.db.GORM, func(tx *gorm.DB) (int64, error) {
+		result := tx.Where("expires_at <= ?", time.Now()).Delete(&models.MovieTombstone{})
+		return result.RowsAffected, result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired tombstones: %w", err)
+	}
+
+	s.logger.Info("Pruned expired movie tombstones", "removed", removed, "simulated", Simulating(ctx))
+	return removed, nil
+}
+
+// unprotectedHistory scopes a query to history records the retention policy may delete
+func (s *RetentionService) unprotectedHistory(tx *gorm.DB) *gorm.DB {
+	queued := tx.Model(&models.QueueItem{}).Select("download_id").Where("download_id <> ''")
+	query := tx.Where("NOT (event_type = ? AND COALESCE(download_id, '') IN (?))",
+		models.HistoryEventTypeGrabbed, queued)
+
+	if len(s.config.HistoryProtectedEventTypes) > 0 {
+		query = query.Where("event_type NOT IN ?", s.config.HistoryProtectedEventTypes)
+	}
+
+	return query
+}
+
+// ensureReleasePartitions converts releases to a partitioned table if needed and creates
+// partitions for the current month and the months ahead
+func (s *RetentionService) ensureReleasePartitions(tx *gorm.DB) error {
+	if !s.ReleasesPartitioned() {
+		if err := s.partitionReleases(tx); err != nil {
+			return err
+		}
+	}
+
+	month := monthStart(time.Now().UTC())
+	for i := 0; i <= releasePartitionMonthsAhead; i++ {
+		if err := createReleasePartition(tx, month.AddDate(0, i, 0)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partitionReleases rebuilds the releases table as a table partitioned by created_at month.
+// PostgreSQL requires the partition key in every unique index, so the primary key becomes
+// (id, created_at) and the guid/indexer uniqueness also includes created_at.
+func (s *RetentionService) partitionReleases(tx *gorm.DB) error {
+	s.logger.Info("Converting releases table to monthly partitions")
+
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		var oldest *time.Time
+		if err := tx.Raw("SELECT MIN(created_at) FROM releases").Scan(&oldest).Error; err != nil {
+			return fmt.Errorf("failed to read oldest release: %w", err)
+		}
+
+		statements := []string{
+			"LOCK TABLE releases IN ACCESS EXCLUSIVE MODE",
+			"UPDATE releases SET created_at = NOW() WHERE created_at IS NULL",
+			"ALTER TABLE releases RENAME TO releases_unpartitioned",
+			"CREATE TABLE releases (LIKE releases_unpartitioned INCLUDING DEFAULTS) PARTITION BY RANGE (created_at)",
+			"ALTER TABLE releases ALTER COLUMN created_at SET NOT NULL",
+			"ALTER TABLE releases ADD PRIMARY KEY (id, created_at)",
+			"ALTER TABLE releases ADD FOREIGN KEY (indexer_id) REFERENCES indexers(id) ON DELETE CASCADE",
+			"ALTER TABLE releases ADD FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE SET NULL",
+			"ALTER TABLE releases ADD FOREIGN KEY (download_client_id) REFERENCES download_clients(id) ON DELETE SET NULL",
+			"CREATE UNIQUE INDEX idx_releases_part_guid_indexer ON releases(guid, indexer_id, created_at)",
+			"CREATE INDEX idx_releases_part_movie_publish ON releases(movie_id, publish_date)",
+			"CREATE INDEX idx_releases_part_status ON releases(status)",
+			"CREATE TABLE releases_default PARTITION OF releases DEFAULT",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to partition releases: %w", err)
+			}
+		}
+
+		// Create monthly partitions for existing rows so the copy does not land them in the default partition
+		first := monthStart(time.Now().UTC())
+		if oldest != nil && oldest.Before(first) {
+			first = monthStart(oldest.UTC())
+		}
+		for month := first; !month.After(monthStart(time.Now().UTC())); month = month.AddDate(0, 1, 0) {
+			if err := createReleasePartition(tx, month); err != nil {
+				return err
+			}
+		}
+
+		statements = []string{
+			"INSERT INTO releases SELECT * FROM releases_unpartitioned",
+			"ALTER SEQUENCE releases_id_seq OWNED BY releases.id",
+			"DROP TABLE releases_unpartitioned",
+			"CREATE TRIGGER trigger_releases_updated_at BEFORE UPDATE ON releases " +
+				"FOR EACH ROW EXECUTE FUNCTION update_releases_updated_at()",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to partition releases: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.partitionMu.Lock()
+	partitioned := true
+	s.partitioned = &partitioned
+	s.partitionMu.Unlock()
+
+	s.logger.Info("Converted releases table to monthly partitions")
+	return nil
+}
+
+// dropExpiredReleasePartitions drops monthly partitions that end before the cutoff and hold
+// no grabbed releases, returning the number of releases removed
+func (s *RetentionService) dropExpiredReleasePartitions(tx *gorm.DB, cutoff time.Time) (int64, error) {
+	var partitions []string
+	if err := tx.Raw(
+		"SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid "+
+			"JOIN pg_class p ON p.oid = i.inhparent WHERE p.relname = 'releases' AND c.relname LIKE ?",
+		releasePartitionPrefix+"%",
+	).Scan(&partitions).Error; err != nil {
+		return 0, fmt.Errorf("failed to list release partitions: %w", err)
+	}
+
+	var removed int64
+	for _, name := range partitions {
+		month, err := time.Parse("200601", strings.TrimPrefix(name, releasePartitionPrefix))
+		if err != nil || month.AddDate(0, 1, 0).After(cutoff) {
+			continue
+		}
+
+		var counts struct {
+			Total   int64
+			Grabbed int64
+		}
+		if err := tx.Raw(
+			fmt.Sprintf("SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS grabbed FROM %s", name),
+			models.ReleaseStatusGrabbed,
+		).Scan(&counts).Error; err != nil {
+			return removed, fmt.Errorf("failed to count releases in %s: %w", name, err)
+		}
+		if counts.Grabbed > 0 {
+			// Leave the month in place; expired rows are deleted individually instead
+			continue
+		}
+
+		if err := tx.Exec(fmt.Sprintf("DROP TABLE %s", name)).Error; err != nil {
+			return removed, fmt.Errorf("failed to drop partition %s: %w", name, err)
+		}
+		removed += counts.Total
+		s.logger.Info("Dropped expired release partition", "partition", name, "releases", counts.Total)
+	}
+
+	return removed, nil
+}
+
+// createReleasePartition creates the partition holding releases created in the given month
+func createReleasePartition(tx *gorm.DB, month time.Time) error {
+	statement := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s PARTITION OF releases FOR VALUES FROM ('%s') TO ('%s')",
+		releasePartitionName(month), month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339),
+	)
+	if err := tx.Exec(statement).Error; err != nil {
+		return fmt.Errorf("failed to create release partition for %s: %w", month.Format("2006-01"), err)
+	}
+	return nil
+}
+
+// releasePartitionName returns the partition table name for a month
+func releasePartitionName(month time.Time) string {
+	return releasePartitionPrefix + month.Format("200601")
+}
+
+// monthStart returns midnight UTC on the first day of t's month
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
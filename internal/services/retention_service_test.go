@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionService_NilDatabase(t *testing.T) {
+	logger := logger.New(config.LogConfig{Level: "debug", Format: "text", Output: "stdout"})
+	service := NewRetentionService(nil, config.RetentionConfig{ReleaseMaxPerMovie: 10}, logger)
+
+	_, err := service.PruneReleases(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.PruneHistory(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.PruneRemovedSeedingTorrents(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	_, err = service.PruneExpiredTombstones(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database not available")
+
+	assert.False(t, service.ReleasesPartitioned())
+	assert.Equal(t, 10, service.MaxReleasesPerMovie())
+}
+
+func TestReleasePartitionName(t *testing.T) {
+	month := monthStart(time.Date(2026, time.October, 15, 22, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), month)
+	assert.Equal(t, "releases_p202610", releasePartitionName(month))
+	assert.Equal(t, "releases_p202701", releasePartitionName(month.AddDate(0, 3, 0)))
+}
+
+func TestRetentionService_PruneReleases(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewRetentionService(db, config.RetentionConfig{ReleaseMaxAgeDays: 30, ReleaseMaxPerMovie: 2}, logger)
+
+	indexer := &models.Indexer{Name: "Test Indexer", Type: models.IndexerTypeTorznab, BaseURL: "http://localhost:9117"}
+	require.NoError(t, db.GORM.Create(indexer).Error)
+	movie := &models.Movie{TmdbID: 603, Title: "The Matrix", TitleSlug: "the-matrix-603", Year: 1999}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	create := func(guid string, age time.Duration, status models.ReleaseStatus) {
+		release := &models.Release{
+			GUID:        guid,
+			Title:       fmt.Sprintf("The.Matrix.1999.%s", guid),
+			IndexerID:   indexer.ID,
+			MovieID:     &movie.ID,
+			Protocol:    models.ProtocolTorrent,
+			DownloadURL: "http://localhost/download/" + guid,
+			PublishDate: time.Now().Add(-age),
+			Status:      status,
+			Source:      models.ReleaseSourceSearch,
+			CreatedAt:   time.Now().Add(-age),
+		}
+		require.NoError(t, db.GORM.Create(release).Error)
+	}
+
+	create("expired", 60*24*time.Hour, models.ReleaseStatusAvailable)
+	create("expired-grabbed", 60*24*time.Hour, models.ReleaseStatusGrabbed)
+	create("newest", time.Hour, models.ReleaseStatusAvailable)
+	create("newer", 2*time.Hour, models.ReleaseStatusAvailable)
+	create("older", 3*time.Hour, models.ReleaseStatusAvailable)
+
+	removed, err := service.PruneReleases(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+
+	var guids []string
+	require.NoError(t, db.GORM.Model(&models.Release{}).Order("guid").Pluck("guid", &guids).Error)
+	assert.Equal(t, []string{"expired-grabbed", "newer", "newest"}, guids)
+}
+
+func TestRetentionService_PruneHistory(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewRetentionService(db, config.RetentionConfig{
+		HistoryMaxAgeDays:          90,
+		HistoryMaxRows:             2,
+		HistoryProtectedEventTypes: []string{string(models.HistoryEventTypeMovieFileDeleted)},
+	}, logger)
+
+	movie := &models.Movie{TmdbID: 603, Title: "The Matrix", TitleSlug: "the-matrix-603", Year: 1999}
+	require.NoError(t, db.GORM.Create(movie).Error)
+	require.NoError(t, db.GORM.Create(&models.QueueItem{
+		MovieID: movie.ID, DownloadID: "seeding", Title: "The.Matrix.1999.1080p",
+	}).Error)
+
+	create := func(title string, eventType models.HistoryEventType, downloadID string, age time.Duration) {
+		require.NoError(t, db.GORM.Create(&models.History{
+			MovieID:     &movie.ID,
+			EventType:   eventType,
+			Date:        time.Now().Add(-age),
+			SourceTitle: title,
+			DownloadID:  downloadID,
+			Successful:  true,
+		}).Error)
+	}
+
+	day := 24 * time.Hour
+	create("expired", models.HistoryEventTypeDownloadFolderImported, "", 200*day)
+	create("expired-seeding", models.HistoryEventTypeGrabbed, "seeding", 200*day)
+	create("expired-finished", models.HistoryEventTypeGrabbed, "finished", 200*day)
+	create("expired-protected", models.HistoryEventTypeMovieFileDeleted, "", 200*day)
+	create("newest", models.HistoryEventTypeDownloadFolderImported, "", day)
+	create("newer", models.HistoryEventTypeDownloadFolderImported, "", 2*day)
+	create("older", models.HistoryEventTypeDownloadFolderImported, "", 3*day)
+
+	removed, err := service.PruneHistory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), removed)
+
+	var titles []string
+	require.NoError(t, db.GORM.Model(&models.History{}).Order("source_title").Pluck("source_title", &titles).Error)
+	assert.Equal(t, []string{"expired-protected", "expired-seeding", "newest", "newer"}, titles)
+}
+
+func TestRetentionService_PruneRetiredRows(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewRetentionService(db, config.RetentionConfig{RetiredMaxAgeDays: 30}, logger)
+
+	day := 24 * time.Hour
+	longAgo, recently := time.Now().Add(-60*day), time.Now().Add(-day)
+	for _, torrent := range []models.SeedingTorrent{
+		{DownloadClientID: 1, DownloadID: "old", Status: models.SeedingStatusRemoved, RemovedAt: &longAgo},
+		{DownloadClientID: 1, DownloadID: "recent", Status: models.SeedingStatusRemoved, RemovedAt: &recently},
+		{DownloadClientID: 1, DownloadID: "seeding", Status: models.SeedingStatusSeeding},
+	} {
+		require.NoError(t, db.GORM.Create(&torrent).Error)
+	}
+
+	removed, err := service.PruneRemovedSeedingTorrents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	require.NoError(t, db.GORM.Create(&models.MovieTombstone{
+		TmdbID: 603, MovieTitle: "The Matrix", DeletedAt: longAgo, ExpiresAt: recently,
+	}).Error)
+	require.NoError(t, db.GORM.Create(&models.MovieTombstone{
+		TmdbID: 604, MovieTitle: "The Matrix Reloaded", DeletedAt: recently, ExpiresAt: time.Now().Add(day),
+	}).Error)
+
+	removed, err = service.PruneExpiredTombstones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// ApplyRootFolderDefaults fills in the quality profile, minimum availability and tags movies leave
+// unset from the defaults of the root folder each is added under, so libraries split across
+// folders such as /movies-4k and /movies-hd don't need every addition to spell them out. A
+// movie's root folder is the one matching its root folder path, or else the deepest one holding
+// its path. Values a movie sets are kept.
+func (s *MovieService) ApplyRootFolderDefaults(movies ...*models.Movie) error {
+	var rootFolders []models.RootFolder
+	if err := s.db.GORM.Find(&rootFolders).Error; err != nil {
+		return fmt.Errorf("failed to load root folders: %w", err)
+	}
+
+	for _, movie := range movies {
+		if rootFolder := movieRootFolder(movie, rootFolders); rootFolder != nil {
+			applyRootFolderDefaults(movie, rootFolder)
+		}
+	}
+	return nil
+}
+
+// movieRootFolder returns the root folder a movie is added under, or nil when it's under none
+func movieRootFolder(movie *models.Movie, rootFolders []models.RootFolder) *models.RootFolder {
+	if movie.RootFolderPath != "" {
+		key := importPathKey(filepath.Clean(movie.RootFolderPath))
+		for i := range rootFolders {
+			if importPathKey(filepath.Clean(rootFolders[i].Path)) == key {
+				return &rootFolders[i]
+			}
+		}
+	}
+
+	path := importPathKey(moviePath(movie))
+	if path == "" {
+		return nil
+	}
+	var deepest *models.RootFolder
+	for i := range rootFolders {
+		root := importPathKey(filepath.Clean(rootFolders[i].Path))
+		if !strings.HasPrefix(path, strings.TrimSuffix(root, string(filepath.Separator))+string(filepath.Separator)) {
+			continue
+		}
+		if deepest == nil || len(rootFolders[i].Path) > len(deepest.Path) {
+			deepest = &rootFolders[i]
+		}
+	}
+	return deepest
+}
+
+// applyRootFolderDefaults copies the root folder's defaults onto the movie's unset fields
+func applyRootFolderDefaults(movie *models.Movie, rootFolder *models.RootFolder) {
+	if movie.QualityProfileID <= 0 && rootFolder.DefaultQualityProfileID > 0 {
+		movie.QualityProfileID = rootFolder.DefaultQualityProfileID
+	}
+	if movie.MinimumAvailability == "" && rootFolder.DefaultMinimumAvailability != "" {
+		movie.MinimumAvailability = rootFolder.DefaultMinimumAvailability
+	}
+	if len(movie.Tags) == 0 && len(rootFolder.DefaultTags) > 0 {
+		movie.Tags = append(models.IntArray{}, rootFolder.DefaultTags...)
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovieRootFolder(t *testing.T) {
+	rootFolders := []models.RootFolder{
+		{ID: 1, Path: "/movies"},
+		{ID: 2, Path: "/movies-4k/"},
+		{ID: 3, Path: "/movies/kids"},
+	}
+
+	assert.Equal(t, 2, movieRootFolder(&models.Movie{RootFolderPath: "/movies-4k"}, rootFolders).ID)
+	assert.Equal(t, 2, movieRootFolder(&models.Movie{Path: "/movies-4k/Dune (2021)"}, rootFolders).ID)
+	assert.Equal(t, 3, movieRootFolder(&models.Movie{Path: "/movies/kids/Up (2009)"}, rootFolders).ID)
+	assert.Equal(t, 1, movieRootFolder(&models.Movie{RootFolderPath: "/movies", FolderName: "Heat (1995)"},
+		rootFolders).ID)
+	assert.Nil(t, movieRootFolder(&models.Movie{Path: "/other/Heat (1995)"}, rootFolders))
+	assert.Nil(t, movieRootFolder(&models.Movie{}, rootFolders))
+}
+
+func TestApplyRootFolderDefaults(t *testing.T) {
+	rootFolder := &models.RootFolder{
+		Path: "/movies-4k", DefaultQualityProfileID: 4, DefaultTags: models.IntArray{7, 8},
+		DefaultMinimumAvailability: models.AvailabilityReleased,
+	}
+
+	movie := &models.Movie{RootFolderPath: "/movies-4k"}
+	applyRootFolderDefaults(movie, rootFolder)
+	assert.Equal(t, 4, movie.QualityProfileID)
+	assert.Equal(t, models.AvailabilityReleased, movie.MinimumAvailability)
+	assert.Equal(t, models.IntArray{7, 8}, movie.Tags)
+
+	explicit := &models.Movie{
+		QualityProfileID: 1, MinimumAvailability: models.AvailabilityInCinemas, Tags: models.IntArray{2},
+	}
+	applyRootFolderDefaults(explicit, rootFolder)
+	assert.Equal(t, 1, explicit.QualityProfileID)
+	assert.Equal(t, models.AvailabilityInCinemas, explicit.MinimumAvailability)
+	assert.Equal(t, models.IntArray{2}, explicit.Tags)
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxSceneMappingListSize caps the size of a remote scene mapping list
+const maxSceneMappingListSize = 10 << 20
+
+// SceneMappingService manages scene mappings, the release titles that don't match a movie's TMDB
+// title, and refreshes them from the configured remote list
+type SceneMappingService struct {
+	db         *database.Database
+	config     config.SceneMappingConfig
+	logger     *logger.Logger
+	httpClient *http.Client
+}
+
+// NewSceneMappingService creates a new scene mapping service
+func NewSceneMappingService(
+	db *database.Database, cfg config.SceneMappingConfig, logger *logger.Logger,
+) *SceneMappingService {
+	return &SceneMappingService{
+		db:         db,
+		config:     cfg,
+		logger:     logger,
+		httpClient: httpclient.New(30 * time.Second),
+	}
+}
+
+// GetAll retrieves all scene mappings ordered by title
+func (s *SceneMappingService) GetAll() ([]models.SceneMapping, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var mappings []models.SceneMapping
+	if err := s.db.GORM.Order("title, id").Find(&mappings).Error; err != nil {
+		s.logger.Error("Failed to fetch scene mappings", "error", err)
+		return nil, fmt.Errorf("failed to fetch scene mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// GetByID retrieves a scene mapping by its ID
+func (s *SceneMappingService) GetByID(id int) (*models.SceneMapping, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var mapping models.SceneMapping
+	if err := s.db.GORM.Where("id = ?", id).First(&mapping).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch scene mapping with id %d: %w", id, err)
+	}
+
+	return &mapping, nil
+}
+
+// Create validates and saves a new manual scene mapping
+func (s *SceneMappingService) Create(mapping *models.SceneMapping) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+
+	mapping.Source = models.SceneMappingSourceManual
+	mapping.CleanTitle = cleanIndexTitle(mapping.Title)
+	if err := s.db.GORM.Create(mapping).Error; err != nil {
+		s.logger.Error("Failed to create scene mapping", "title", mapping.Title, "error", err)
+		return fmt.Errorf("failed to create scene mapping: %w", err)
+	}
+
+	s.invalidateParseCache()
+	s.logger.Info("Created scene mapping", "id", mapping.ID, "title", mapping.Title, "tmdbId", mapping.TmdbID)
+	return nil
+}
+
+// Update validates and saves changes to a scene mapping. Edited remote mappings become manual
+// mappings, so the next refresh doesn't overwrite the change.
+func (s *SceneMappingService) Update(mapping *models.SceneMapping) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.GetByID(mapping.ID)
+	if err != nil {
+		return err
+	}
+	mapping.CreatedAt = existing.CreatedAt
+	mapping.Source = models.SceneMappingSourceManual
+	mapping.CleanTitle = cleanIndexTitle(mapping.Title)
+
+	if err := s.db.GORM.Save(mapping).Error; err != nil {
+		s.logger.Error("Failed to update scene mapping", "id", mapping.ID, "error", err)
+		return fmt.Errorf("failed to update scene mapping: %w", err)
+	}
+
+	s.invalidateParseCache()
+	s.logger.Info("Updated scene mapping", "id", mapping.ID, "title", mapping.Title)
+	return nil
+}
+
+// Delete removes a scene mapping
+func (s *SceneMappingService) Delete(id int) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	result := s.db.GORM.Delete(&models.SceneMapping{}, id)
+	if result.Error != nil {
+		s.logger.Error("Failed to delete scene mapping", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete scene mapping: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("scene mapping with id %d not found", id)
+	}
+
+	s.invalidateParseCache()
+	s.logger.Info("Deleted scene mapping", "id", id)
+	return nil
+}
+
+// FindTmdbID returns the TMDB ID a release title is mapped to, preferring manual mappings and
+// mappings for the release's year over ones for any year
+func (s *SceneMappingService) FindTmdbID(title string, year int) (int, bool) {
+	if s.db == nil {
+		return 0, false
+	}
+	cleanTitle := cleanIndexTitle(title)
+	if cleanTitle == "" {
+		return 0, false
+	}
+
+	var mappings []models.SceneMapping
+	if err := s.db.GORM.Where("clean_title = ?", cleanTitle).Find(&mappings).Error; err != nil {
+		s.logger.Warn("Failed to look up scene mappings", "title", title, "error", err)
+		return 0, false
+	}
+
+	if mapping := selectSceneMapping(mappings, year); mapping != nil {
+		return mapping.TmdbID, true
+	}
+	return 0, false
+}
+
+// Refresh replaces the remote scene mappings with the configured list, returning how many were
+// loaded. Manual mappings are kept. Without a configured URL nothing is fetched.
+func (s *SceneMappingService) Refresh(ctx context.Context) (int, error) {
+	if s.db == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	if s.config.URL == "" {
+		return 0, nil
+	}
+
+	mappings, err := s.fetchRemoteMappings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.db.GORM.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source = ?", models.SceneMappingSourceRemote).
+			Delete(&models.SceneMapping{}).Error; err != nil {
+			return err
+		}
+		if len(mappings) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(mappings, 500).Error
+	})
+	if err != nil {
+		s.logger.Error("Failed to save remote scene mappings", "error", err)
+		return 0, fmt.Errorf("failed to save remote scene mappings: %w", err)
+	}
+
+	s.invalidateParseCache()
+	s.logger.Info("Refreshed scene mappings", "count", len(mappings), "url", s.config.URL)
+	return len(mappings), nil
+}
+
+// fetchRemoteMappings downloads and validates the remote scene mapping list
+func (s *SceneMappingService) fetchRemoteMappings(ctx context.Context) ([]models.SceneMapping, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scene mapping request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scene mappings: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			s.logger.Warn("Failed to close response body", "error", closeErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scene mapping list returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSceneMappingListSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scene mappings: %w", err)
+	}
+
+	mappings, skipped, err := parseSceneMappingList(body)
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		s.logger.Warn("Skipped invalid scene mappings", "count", skipped)
+	}
+	return mappings, nil
+}
+
+// invalidateParseCache drops cached parse results, which may have matched a movie through a
+// mapping that has since changed
+func (s *SceneMappingService) invalidateParseCache() {
+	if err := s.db.GORM.Delete(&ParseCacheEntry{}, "1=1").Error; err != nil {
+		s.logger.Warn("Failed to clear parse cache after scene mapping change", "error", err)
+	}
+}
+
+// parseSceneMappingList decodes a remote list of scene mappings, skipping entries without a
+// title or TMDB ID and returning how many were skipped
+func parseSceneMappingList(data []byte) ([]models.SceneMapping, int, error) {
+	var entries []models.SceneMapping
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, 0, fmt.Errorf("invalid scene mapping list: %w", err)
+	}
+
+	mappings := make([]models.SceneMapping, 0, len(entries))
+	skipped := 0
+	for _, entry := range entries {
+		mapping := models.SceneMapping{Title: entry.Title, TmdbID: entry.TmdbID, Year: entry.Year}
+		if mapping.Validate() != nil {
+			skipped++
+			continue
+		}
+		mapping.Source = models.SceneMappingSourceRemote
+		mapping.CleanTitle = cleanIndexTitle(mapping.Title)
+		mappings = append(mappings, mapping)
+	}
+	return mappings, skipped, nil
+}
+
+// selectSceneMapping picks the mapping that applies to a release of the given year: mappings for
+// that year beat mappings for any year, and manual mappings beat remote ones
+func selectSceneMapping(mappings []models.SceneMapping, year int) *models.SceneMapping {
+	var best *models.SceneMapping
+	bestRank := -1
+	for i := range mappings {
+		mapping := &mappings[i]
+		if mapping.Year != 0 && mapping.Year != year {
+			continue
+		}
+
+		rank := 0
+		if mapping.Year != 0 {
+			rank += 2
+		}
+		if mapping.Source == models.SceneMappingSourceManual {
+			rank++
+		}
+		if rank > bestRank {
+			best, bestRank = mapping, rank
+		}
+	}
+	return best
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSceneMappingList(t *testing.T) {
+	data := []byte(`[
+		{"title": "Borat: Cultural Learnings", "tmdbId": 496},
+		{"title": "Les Intouchables", "tmdbId": 77338, "year": 2011, "source": "manual"},
+		{"title": "", "tmdbId": 1},
+		{"title": "No Movie"}
+	]`)
+
+	mappings, skipped, err := parseSceneMappingList(data)
+	require.NoError(t, err)
+	assert.Equal(t, 2, skipped)
+	require.Len(t, mappings, 2)
+	assert.Equal(t, "boratculturallearnings", mappings[0].CleanTitle)
+	assert.Equal(t, models.SceneMappingSourceRemote, mappings[1].Source)
+	assert.Equal(t, 2011, mappings[1].Year)
+
+	_, _, err = parseSceneMappingList([]byte(`{"title": "not a list"}`))
+	assert.Error(t, err)
+}
+
+func TestSelectSceneMapping(t *testing.T) {
+	mappings := []models.SceneMapping{
+		{ID: 1, TmdbID: 10, Source: models.SceneMappingSourceRemote},
+		{ID: 2, TmdbID: 20, Source: models.SceneMappingSourceManual},
+		{ID: 3, TmdbID: 30, Year: 2005, Source: models.SceneMappingSourceRemote},
+	}
+
+	assert.Equal(t, 30, selectSceneMapping(mappings, 2005).TmdbID)
+	assert.Equal(t, 20, selectSceneMapping(mappings, 2010).TmdbID)
+	assert.Equal(t, 10, selectSceneMapping(mappings[:1], 0).TmdbID)
+	assert.Nil(t, selectSceneMapping(mappings[2:], 2010))
+}
+
+func TestSceneMapping_Validate(t *testing.T) {
+	var validationErr models.ValidationError
+
+	mapping := &models.SceneMapping{Title: " Les Intouchables ", TmdbID: 77338}
+	require.NoError(t, mapping.Validate())
+	assert.Equal(t, "Les Intouchables", mapping.Title)
+
+	require.ErrorAs(t, (&models.SceneMapping{TmdbID: 1}).Validate(), &validationErr)
+	assert.Equal(t, "title", validationErr.Field)
+
+	require.ErrorAs(t, (&models.SceneMapping{Title: "Untouchable"}).Validate(), &validationErr)
+	assert.Equal(t, "tmdbId", validationErr.Field)
+}
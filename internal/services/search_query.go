@@ -0,0 +1,65 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// searchQueryYearSuffix matches a release year appended to a title, as in "Dune (2021)"
+var searchQueryYearSuffix = regexp.MustCompile(`\s*[(\[](?:18|19|20)\d{2}[)\]]\s*$`)
+
+// titleApostrophes are dropped without splitting the word, so "Ocean's" is searched as "Oceans"
+var titleApostrophes = strings.NewReplacer("'", "", "’", "", "‘", "", "`", "", "´", "")
+
+// diacriticFolds spell out the letters that don't decompose into a base letter and a mark
+var diacriticFolds = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "Æ", "AE", "œ", "oe", "Œ", "OE", "ø", "o", "Ø", "O", "đ", "d", "Đ", "D",
+	"ł", "l", "Ł", "L", "þ", "th", "Þ", "TH", "ı", "i",
+)
+
+// romanNumerals are the sequel numbers 2 to 20 written as roman numerals. One is left out, as "I"
+// and "X" are more often words or letters in titles than numbers.
+var romanNumerals = map[string]string{
+	"ii": "2", "iii": "3", "iv": "4", "v": "5", "vi": "6", "vii": "7", "viii": "8", "ix": "9",
+	"xi": "11", "xii": "12", "xiii": "13", "xiv": "14", "xv": "15", "xvi": "16", "xvii": "17",
+	"xviii": "18", "xix": "19", "xx": "20",
+}
+
+// foldDiacritics removes accents and spells out special letters, so "Amélie" reads "Amelie" and
+// "Æon Flux" reads "AEon Flux", as release names are written
+func foldDiacritics(title string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), title)
+	if err != nil {
+		folded = title
+	}
+	return diacriticFolds.Replace(folded)
+}
+
+// titleWords splits a title into its words the way titles are compared and searched: accents
+// removed, "&" spelled "and", apostrophes dropped and any other punctuation separating words
+func titleWords(title string) []string {
+	title = titleApostrophes.Replace(foldDiacritics(title))
+	title = strings.ReplaceAll(title, "&", " and ")
+	return strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// cleanSearchQuery turns a title into the q= query of an indexer search. Picky indexers match
+// queries word for word against release names, which have no punctuation or accents, so
+// "Spider-Man: No Way Home" is searched as "Spider Man No Way Home" and "Amélie (2001)" as
+// "Amelie". Roman numerals are kept and uppercased, as release names write them.
+func cleanSearchQuery(title string) string {
+	words := titleWords(searchQueryYearSuffix.ReplaceAllString(title, ""))
+	for i, word := range words {
+		if _, ok := romanNumerals[strings.ToLower(word)]; ok && len(word) > 1 {
+			words[i] = strings.ToUpper(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
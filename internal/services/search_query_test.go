@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanSearchQuery(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Spider-Man: No Way Home", "Spider Man No Way Home"},
+		{"Amélie", "Amelie"},
+		{"Léon: The Professional", "Leon The Professional"},
+		{"Fast & Furious", "Fast and Furious"},
+		{"Ocean's Eleven", "Oceans Eleven"},
+		{"Schindler’s List", "Schindlers List"},
+		{"Dune (2021)", "Dune"},
+		{"Blade Runner 2049", "Blade Runner 2049"},
+		{"1917", "1917"},
+		{"Rocky iv", "Rocky IV"},
+		{"V for Vendetta", "V for Vendetta"},
+		{"Æon Flux", "AEon Flux"},
+		{"Der Untergang: Hitler und das Ende des Dritten Reichs", "Der Untergang Hitler und das Ende des Dritten Reichs"},
+		{"Mission: Impossible - Dead Reckoning Part One", "Mission Impossible Dead Reckoning Part One"},
+		{"WALL·E", "WALL E"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, cleanSearchQuery(tt.title))
+		})
+	}
+}
+
+func TestNormalizeSearchTitle_Rules(t *testing.T) {
+	assert.Equal(t, normalizeSearchTitle("Spider-Man: No Way Home"), normalizeSearchTitle("Spider.Man.No.Way.Home"))
+	assert.Equal(t, "amelie", normalizeSearchTitle("Amélie"))
+	assert.Equal(t, "oceans eleven", normalizeSearchTitle("Ocean's Eleven"))
+	assert.Equal(t, normalizeSearchTitle("Rocky 4"), normalizeSearchTitle("Rocky IV"))
+	assert.Equal(t, "v for vendetta", normalizeSearchTitle("V for Vendetta"))
+	assert.Equal(t, "star wars episode 5 empire strikes back",
+		normalizeSearchTitle("Star Wars: Episode V - Empire Strikes Back"))
+}
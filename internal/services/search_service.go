@@ -10,11 +10,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 )
@@ -22,8 +25,19 @@ import (
 const (
 	// defaultSortOrder is the default sort order for search results
 	defaultSortOrder = "desc"
+	// releaseBatchSize is the number of releases written per upsert statement
+	releaseBatchSize = 100
 )
 
+// releaseUpsertColumns are refreshed when a release is seen again. Status and grab/failure
+// bookkeeping are left untouched so repeated searches do not reset a grabbed release.
+var releaseUpsertColumns = []string{
+	"title", "sort_title", "overview", "edition", "quality", "quality_weight", "age", "age_hours", "age_minutes",
+	"size", "movie_id", "imdb_id", "tmdb_id", "protocol", "download_url", "info_url", "comment_url",
+	"seeders", "leechers", "peer_count", "publish_date", "release_info", "categories",
+	"rejection_reasons", "indexer_flags", "scene_mapping", "magnet_url", "updated_at",
+}
+
 // NewznabResponse represents a Newznab/Torznab XML response structure
 type NewznabResponse struct {
 	XMLName xml.Name `xml:"rss"`
@@ -65,10 +79,13 @@ type SearchService struct {
 	movieService        *MovieService
 	downloadService     *DownloadService
 	notificationService *NotificationService
+	retentionService    *RetentionService
 	httpClient          *http.Client
+	insecureHTTPClient  *http.Client
+	searchWorkers       int
 }
 
-// NewSearchService creates a new search service
+// NewSearchService creates a new search service that queries up to searchWorkers indexers at once
 func NewSearchService(
 	db *database.Database,
 	logger *logger.Logger,
@@ -77,6 +94,8 @@ func NewSearchService(
 	movieService *MovieService,
 	downloadService *DownloadService,
 	notificationService *NotificationService,
+	retentionService *RetentionService,
+	searchWorkers int,
 ) *SearchService {
 	return &SearchService{
 		db:                  db,
@@ -86,9 +105,10 @@ func NewSearchService(
 		movieService:        movieService,
 		downloadService:     downloadService,
 		notificationService: notificationService,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		retentionService:    retentionService,
+		httpClient:          httpclient.New(30 * time.Second),
+		insecureHTTPClient:  httpclient.New(30*time.Second, httpclient.WithSkipTLSVerify(true)),
+		searchWorkers:       searchWorkers,
 	}
 }
 
@@ -100,16 +120,25 @@ func (s *SearchService) SearchMovieReleases(movieID int, forceSearch bool) (*mod
 	}
 
 	searchRequest := &models.SearchRequest{
-		MovieID: &movieID,
-		Title:   movie.Title,
-		Year:    &movie.Year,
-		ImdbID:  movie.ImdbID,
-		TmdbID:  &movie.TmdbID,
-		Source:  models.ReleaseSourceSearch,
-		Limit:   100,
+		MovieID:         &movieID,
+		Title:           movie.Title,
+		Year:            &movie.Year,
+		ImdbID:          movie.ImdbID,
+		TmdbID:          &movie.TmdbID,
+		AlternateTitles: alternateSearchTitles(movie),
+		Source:          models.ReleaseSourceSearch,
+		Limit:           100,
+	}
+
+	response, err := s.SearchReleases(searchRequest, forceSearch)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.SearchReleases(searchRequest, forceSearch)
+	if err := s.movieService.RecordSearch(movieID, time.Now()); err != nil {
+		s.logger.Warn("Failed to record movie search", "movieId", movieID, "error", err)
+	}
+	return response, nil
 }
 
 // SearchReleases performs a search across enabled indexers
@@ -141,20 +170,42 @@ func (s *SearchService) SearchReleases(request *models.SearchRequest, forceSearc
 	}, nil
 }
 
-// searchAllIndexers searches across all enabled indexers
+// searchAllIndexers searches across all enabled indexers, up to searchWorkers at a time. Results
+// are combined in indexer order so the response does not depend on which indexer answers first.
 func (s *SearchService) searchAllIndexers(indexers []*models.Indexer, request *models.SearchRequest,
 	forceSearch bool) ([]models.Release, float64) {
-	allReleases := make([]models.Release, 0)
-	totalSearchTime := 0.0
+	type indexerResult struct {
+		releases   []models.Release
+		searchTime float64
+	}
 
-	for _, indexer := range indexers {
+	results := make([]indexerResult, len(indexers))
+	sem := make(chan struct{}, max(s.searchWorkers, 1))
+	var wg sync.WaitGroup
+
+	for i, indexer := range indexers {
 		if !s.shouldSearchIndexer(indexer, request) {
 			continue
 		}
 
-		releases, searchTime := s.performIndexerSearch(indexer, request, forceSearch)
-		totalSearchTime += searchTime
-		allReleases = append(allReleases, releases...)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, indexer *models.Indexer) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			releases, searchTime := s.performIndexerSearch(indexer, request, forceSearch)
+			results[i] = indexerResult{releases: releases, searchTime: searchTime}
+		}(i, indexer)
+	}
+	wg.Wait()
+
+	allReleases := make([]models.Release, 0)
+	totalSearchTime := 0.0
+	for _, result := range results {
+		totalSearchTime += result.searchTime
+		allReleases = append(allReleases, result.releases...)
 	}
 
 	return allReleases, totalSearchTime
@@ -193,7 +244,7 @@ func (s *SearchService) performIndexerSearch(indexer *models.Indexer, request *m
 	for i := range releases {
 		releases[i].IndexerID = indexer.ID
 		releases[i].Source = request.Source
-		releases[i] = s.processRelease(releases[i])
+		releases[i] = s.processRelease(releases[i], indexer)
 	}
 
 	if !forceSearch {
@@ -230,13 +281,19 @@ func (s *SearchService) processSearchResults(releases []models.Release,
 // InteractiveSearch performs an interactive search for manual release selection
 func (s *SearchService) InteractiveSearch(request *models.SearchRequest) (*models.SearchResponse, error) {
 	request.Source = models.ReleaseSourceInteractiveSearch
+	if request.MovieID != nil && request.AlternateTitles == nil && s.movieService != nil {
+		if movie, err := s.movieService.GetByID(*request.MovieID); err == nil {
+			request.AlternateTitles = alternateSearchTitles(movie)
+		}
+	}
 	response, err := s.SearchReleases(request, true)
 	if err != nil {
 		return nil, err
 	}
 
+	evaluation := s.evaluationContext(request.MovieID)
 	for i := range response.Releases {
-		response.Releases[i] = s.evaluateRelease(response.Releases[i])
+		response.Releases[i] = s.evaluateRelease(response.Releases[i], evaluation)
 	}
 
 	return response, nil
@@ -253,6 +310,10 @@ func (s *SearchService) GrabRelease(request *models.GrabRequest) (*models.GrabRe
 		return nil, err
 	}
 
+	if err := s.applyGrabOverrides(release, request); err != nil {
+		return nil, err
+	}
+
 	if !release.IsGrabbable() {
 		return s.createRejectedResponse(release), nil
 	}
@@ -280,6 +341,40 @@ func (s *SearchService) findReleaseForGrab(request *models.GrabRequest) (*models
 	return &release, nil
 }
 
+// applyGrabOverrides applies the corrections of a grab request to the release: the movie an
+// unmapped or mismatched release is for, and the quality the parser got wrong. A corrected release
+// is evaluated again so its rejections reflect the corrections.
+func (s *SearchService) applyGrabOverrides(release *models.Release, request *models.GrabRequest) error {
+	if request.MovieID == nil && request.Quality == nil {
+		return nil
+	}
+
+	if request.MovieID != nil {
+		if s.movieService == nil {
+			return fmt.Errorf("movie service not available")
+		}
+		movie, err := s.movieService.GetByID(*request.MovieID)
+		if err != nil {
+			return models.ValidationError{Field: "movieId", Message: "The movie does not exist"}
+		}
+		release.MovieID, release.Movie = &movie.ID, movie
+	}
+
+	if request.Quality != nil {
+		release.Quality = *request.Quality
+		release.QualityWeight = s.calculateQualityWeight(release.Quality)
+	}
+
+	if release.Status == models.ReleaseStatusRejected {
+		release.Status = models.ReleaseStatusAvailable
+	}
+	*release = s.evaluateRelease(*release, s.evaluationContext(release.MovieID))
+
+	s.logger.Info("Applied grab overrides", "release", release.Title, "movieId", request.MovieID,
+		"quality", release.Quality.Quality.Name, "rejections", len(release.RejectionReasons))
+	return nil
+}
+
 // createRejectedResponse creates a response for a rejected release
 func (s *SearchService) createRejectedResponse(release *models.Release) *models.GrabResponse {
 	return &models.GrabResponse{
@@ -298,17 +393,53 @@ func (s *SearchService) getDownloadClientForRelease(
 	if downloadClientID == nil && release.Indexer != nil && release.Indexer.DownloadClientID != nil {
 		downloadClientID = release.Indexer.DownloadClientID
 	}
+	if downloadClientID == nil {
+		return nil, models.ValidationError{
+			Field:   "downloadClientId",
+			Message: "The release's indexer has no download client, choose one to grab it with",
+		}
+	}
 
-	return s.downloadService.GetDownloadClientByID(*downloadClientID)
+	downloadClient, err := s.downloadService.GetDownloadClientByID(*downloadClientID)
+	if err != nil {
+		if request.DownloadClientID != nil {
+			return nil, models.ValidationError{Field: "downloadClientId", Message: "The download client does not exist"}
+		}
+		return nil, err
+	}
+	if string(downloadClient.Protocol) != string(release.Protocol) {
+		return nil, models.ValidationError{
+			Field:   "downloadClientId",
+			Message: fmt.Sprintf("%s cannot download %s releases", downloadClient.Name, release.Protocol),
+		}
+	}
+	return downloadClient, nil
 }
 
-// markReleaseAsGrabbed updates the release status to grabbed
+// markReleaseAsGrabbed updates the release status to grabbed and records the grab notification
+// in the outbox with it
 func (s *SearchService) markReleaseAsGrabbed(release *models.Release, downloadClient *models.DownloadClient) error {
 	now := time.Now()
 	release.Status = models.ReleaseStatusGrabbed
 	release.GrabbedAt = &now
 	release.DownloadClientID = &downloadClient.ID
-	return s.db.GORM.Save(release).Error
+
+	event := &models.NotificationEvent{
+		EventType:      NotificationEventGrab,
+		Movie:          release.Movie,
+		SourceTitle:    release.Title,
+		DownloadClient: downloadClient.Name,
+	}
+	if release.Indexer != nil {
+		event.Data = map[string]interface{}{"indexer": release.Indexer.Name}
+	}
+
+	return s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(release).Error; err != nil {
+			return err
+		}
+		return enqueueOutboxEvent(tx, event)
+	})
 }
 
 // logGrabSuccess logs successful grab information
@@ -365,6 +496,35 @@ func (s *SearchService) GetReleases(filter *models.ReleaseFilter, limit, offset
 	return releases, int(total), nil
 }
 
+// GetReleasesByCursor retrieves releases newest first, starting after the given cursor, and
+// returns the cursor for the next page or nil on the last page. It skips the total count so it
+// stays fast on large release tables.
+func (s *SearchService) GetReleasesByCursor(filter *models.ReleaseFilter, limit int,
+	cursor *models.PageCursor) ([]models.Release, *models.PageCursor, error) {
+	if s.db == nil {
+		return nil, nil, fmt.Errorf("database not available")
+	}
+
+	query := s.db.GORM.Model(&models.Release{}).
+		Preload("Indexer").
+		Preload("Movie").
+		Preload("DownloadClient")
+
+	if filter != nil {
+		query = s.applyReleaseFilter(query, filter)
+	}
+
+	var releases []models.Release
+	if err := applyPageCursor(query, "created_at", "id", cursor, limit).Find(&releases).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get releases: %w", err)
+	}
+
+	releases, next := trimCursorPage(releases, limit, func(r *models.Release) (time.Time, int) {
+		return r.CreatedAt, r.ID
+	})
+	return releases, next, nil
+}
+
 // GetReleaseByID retrieves a release by ID
 func (s *SearchService) GetReleaseByID(id int) (*models.Release, error) {
 	if s.db == nil {
@@ -517,7 +677,11 @@ func (s *SearchService) searchIndexer(indexer *models.Indexer, request *models.S
 	[]models.Release, error) {
 	switch indexer.Type {
 	case models.IndexerTypeTorznab, models.IndexerTypeNewznab:
-		return s.searchNewznabIndexer(indexer, request)
+		releases, err := s.searchNewznabIndexer(indexer, request)
+		if err != nil {
+			return nil, err
+		}
+		return s.searchAlternateTitles(indexer, request, releases), nil
 	case models.IndexerTypeRSS:
 		return s.searchRSSIndexer(indexer, request)
 	default:
@@ -525,6 +689,14 @@ func (s *SearchService) searchIndexer(indexer *models.Indexer, request *models.S
 	}
 }
 
+// clientForIndexer returns the HTTP client to use for an indexer, honoring its TLS settings
+func (s *SearchService) clientForIndexer(indexer *models.Indexer) *http.Client {
+	if indexer.SkipTLSVerify() {
+		return s.insecureHTTPClient
+	}
+	return s.httpClient
+}
+
 // searchNewznabIndexer searches a Newznab/Torznab indexer
 func (s *SearchService) searchNewznabIndexer(indexer *models.Indexer, request *models.SearchRequest) (
 	[]models.Release, error) {
@@ -537,7 +709,7 @@ func (s *SearchService) searchNewznabIndexer(indexer *models.Indexer, request *m
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	resp, err := s.httpClient.Do(req)
+	resp, err := s.clientForIndexer(indexer).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform search request: %w", err)
 	}
@@ -562,7 +734,7 @@ func (s *SearchService) searchRSSIndexer(indexer *models.Indexer, request *model
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	resp, err := s.httpClient.Do(req)
+	resp, err := s.clientForIndexer(indexer).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform RSS request: %w", err)
 	}
@@ -603,8 +775,8 @@ func (s *SearchService) buildNewznabURL(indexer *models.Indexer, request *models
 		params.Set("tmdbid", strconv.Itoa(*request.TmdbID))
 	}
 
-	if request.Title != "" {
-		params.Set("q", request.Title)
+	if query := cleanSearchQuery(request.Title); query != "" {
+		params.Set("q", query)
 	}
 
 	if request.Year != nil && *request.Year > 0 {
@@ -774,7 +946,7 @@ func (s *SearchService) parseRSSResponse(data []byte, _ *models.Indexer,
 	releases := make([]models.Release, 0)
 
 	for _, item := range response.Channel.Items {
-		if request.Title != "" && !strings.Contains(strings.ToLower(item.Title), strings.ToLower(request.Title)) {
+		if request.Title != "" && !rssItemMatches(item.Title, request) {
 			continue
 		}
 
@@ -806,11 +978,17 @@ func (s *SearchService) parseRSSResponse(data []byte, _ *models.Indexer,
 	return releases, nil
 }
 
-// processRelease processes a release to extract metadata and quality information
-func (s *SearchService) processRelease(release models.Release) models.Release {
+// processRelease processes a release to extract metadata, quality information and languages,
+// taking MULTi releases to have the indexer's multi languages
+func (s *SearchService) processRelease(release models.Release, indexer *models.Indexer) models.Release {
 	release.Quality = s.parseQualityFromTitle(release.Title)
 	release.QualityWeight = s.calculateQualityWeight(release.Quality)
 	release.ReleaseInfo = s.extractReleaseInfo(release.Title)
+	release.Edition = release.ReleaseInfo.Edition
+
+	languages := parseReleaseLanguages(release.Title, indexer.MultiLanguages())
+	release.ReleaseInfo.Languages = languageNames(languages.audio)
+	release.ReleaseInfo.Subtitles = languageNames(languages.subtitles)
 
 	return release
 }
@@ -923,25 +1101,117 @@ func (s *SearchService) extractReleaseInfo(title string) models.ReleaseInfo {
 		info.Scene = true
 	}
 
+	info.Edition = parseEdition(title)
+
 	return info
 }
 
-// evaluateRelease evaluates a release and adds rejection reasons if applicable
-func (s *SearchService) evaluateRelease(release models.Release) models.Release {
-	var rejections []string
+// releaseEvaluation is what evaluateRelease checks releases against: the movie searched for and
+// its quality profile, the quality definitions keyed by title, the custom formats, and the
+// retention in days of the indexers that set one, by indexer ID. Any of them may be missing.
+type releaseEvaluation struct {
+	movie       *models.Movie
+	profile     *models.QualityProfile
+	definitions map[string]*models.QualityLevel
+	formats     []*models.CustomFormat
+	retention   map[int]int
+}
+
+// evaluationContext loads what releases for a movie are evaluated against
+func (s *SearchService) evaluationContext(movieID *int) *releaseEvaluation {
+	evaluation := &releaseEvaluation{definitions: make(map[string]*models.QualityLevel)}
+	if movieID != nil && s.movieService != nil {
+		movie, err := s.movieService.GetByID(*movieID)
+		if err != nil {
+			s.logger.Warn("Failed to load movie for release evaluation", "movieId", *movieID, "error", err)
+		}
+		evaluation.movie = movie
+	}
+
+	if s.qualityService != nil {
+		levels, err := s.qualityService.GetQualityDefinitions()
+		if err != nil {
+			s.logger.Warn("Failed to load quality definitions for release evaluation", "error", err)
+		}
+		for _, level := range levels {
+			evaluation.definitions[level.Title] = level
+		}
+
+		if evaluation.formats, err = s.qualityService.GetCustomFormats(); err != nil {
+			s.logger.Warn("Failed to load custom formats for release evaluation", "error", err)
+		}
+		if movie := evaluation.movie; movie != nil {
+			if evaluation.profile, err = s.qualityService.GetQualityProfileByID(movie.QualityProfileID); err != nil {
+				s.logger.Warn("Failed to load quality profile for release evaluation",
+					"profileId", movie.QualityProfileID, "error", err)
+			}
+		}
+	}
+
+	evaluation.retention = s.indexerRetention()
+	return evaluation
+}
+
+// indexerRetention returns the retention in days of the indexers that set one, by indexer ID
+func (s *SearchService) indexerRetention() map[int]int {
+	retention := make(map[int]int)
+	if s.indexerService == nil {
+		return retention
+	}
 
-	if release.Size < 100*1024*1024 {
-		rejections = append(rejections, "File too small")
+	indexers, err := s.indexerService.GetIndexers()
+	if err != nil {
+		s.logger.Warn("Failed to load indexers for release evaluation", "error", err)
+		return retention
+	}
+	for _, indexer := range indexers {
+		if days := indexer.Retention(); days > 0 {
+			retention[indexer.ID] = days
+		}
 	}
+	return retention
+}
+
+// evaluateRelease evaluates a release and adds rejection reasons if applicable. Its size must fall
+// within its quality definition's limits for the movie's runtime, so a release far smaller than
+// its claimed quality allows is flagged as mislabeled. Usenet releases older than their indexer's
+// retention are rejected, as the news server no longer has all of their articles. Releases must
+// have the audio language the movie's quality profile wants, and are matched against the custom
+// formats with their languages, the original one standing for the movie's.
+func (s *SearchService) evaluateRelease(release models.Release, evaluation *releaseEvaluation) models.Release {
+	var rejections []string
+	movie := evaluation.movie
 
-	if release.Size > 50*1024*1024*1024 {
-		rejections = append(rejections, "File too large")
+	runtime := 0
+	if movie != nil {
+		runtime = movie.Runtime
+	}
+	definition := evaluation.definitions[qualityTitleForRelease(release.Quality.Quality)]
+	if definition == nil {
+		definition = evaluation.definitions[unknownQualityTitle]
+	}
+	if definition != nil {
+		if reason := releaseSizeRejection(release.Size, definition, runtime); reason != "" {
+			rejections = append(rejections, reason)
+		}
 	}
 
 	if release.IsTorrent() && release.Seeders != nil && *release.Seeders == 0 {
 		rejections = append(rejections, "No seeders")
 	}
 
+	if days := evaluation.retention[release.IndexerID]; release.IsUsenet() && days > 0 && release.Age > days {
+		rejections = append(rejections, fmt.Sprintf("Older than indexer retention (%d days)", days))
+	}
+
+	release.ReleaseInfo.Languages = resolveOriginalLanguage(release.ReleaseInfo.Languages, movie)
+	if profile := evaluation.profile; profile != nil {
+		if reason := releaseLanguageRejection(profile.Language, release.ReleaseInfo.Languages, movie); reason != "" {
+			rejections = append(rejections, reason)
+		}
+	}
+	s.matchReleaseCustomFormats(&release, evaluation)
+
 	if release.Age > 365 {
 		rejections = append(rejections, "Too old")
 	}
@@ -954,37 +1224,135 @@ func (s *SearchService) evaluateRelease(release models.Release) models.Release {
 	return release
 }
 
-// saveReleases saves releases to the database
+// matchReleaseCustomFormats fills in the custom formats a release matches and their score in the
+// quality profile
+func (s *SearchService) matchReleaseCustomFormats(release *models.Release, evaluation *releaseEvaluation) {
+	input := &models.CustomFormatInput{
+		Title:        release.Title,
+		Edition:      release.Edition,
+		ReleaseGroup: release.ReleaseInfo.ReleaseGroup,
+		Quality:      release.Quality.Quality,
+		Languages:    release.ReleaseInfo.Languages,
+		IndexerFlags: release.IndexerFlags,
+		Size:         release.Size,
+		Year:         release.ReleaseInfo.Year,
+	}
+	if evaluation.movie != nil {
+		input.OriginalLanguage = evaluation.movie.OriginalLanguage
+	}
+
+	release.CustomFormats = nil
+	for _, format := range evaluation.formats {
+		if format.Matches(input) {
+			release.CustomFormats = append(release.CustomFormats, *format)
+		}
+	}
+	release.CustomFormatScore = 0
+	if evaluation.profile != nil {
+		release.CustomFormatScore = evaluation.profile.CustomFormatScore(release.CustomFormats)
+	}
+}
+
+// saveReleases upserts releases in batches keyed on guid and indexer, then prunes the
+// oldest releases of every affected movie beyond the retention limit
 func (s *SearchService) saveReleases(releases []models.Release) error {
 	if len(releases) == 0 {
 		return nil
 	}
 
-	tx := s.db.GORM.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	// A batch may not touch the same row twice, and callers keep their own copies without IDs
+	rows := s.dedupReleases(releases)
+
+	movieIDs := make(map[int]bool)
+	for _, release := range rows {
+		if release.MovieID != nil {
+			movieIDs[*release.MovieID] = true
+		}
+	}
+
+	maxPerMovie := 0
+	partitioned := false
+	if s.retentionService != nil {
+		maxPerMovie = s.retentionService.MaxReleasesPerMovie()
+		partitioned = s.retentionService.ReleasesPartitioned()
+	}
+
+	return s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		conflictColumns := []clause.Column{{Name: "guid"}, {Name: "indexer_id"}}
+		if partitioned {
+			// Unique indexes on a partitioned table include the partition key, so releases seen
+			// before keep their original created_at for the upsert to find them
+			if err := carryOverReleaseCreatedAt(tx, rows); err != nil {
+				return err
+			}
+			conflictColumns = append(conflictColumns, clause.Column{Name: "created_at"})
 		}
-	}()
 
-	for _, release := range releases {
-		var existing models.Release
-		if err := tx.Where("guid = ? AND indexer_id = ?", release.GUID, release.IndexerID).
-			First(&existing).Error; err != nil {
-			if err := tx.Create(&release).Error; err != nil {
-				s.logger.Error("Failed to create release", "error", err, "title", release.Title)
-				continue
+		if err := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   conflictColumns,
+				DoUpdates: clause.AssignmentColumns(releaseUpsertColumns),
+			}).
+			CreateInBatches(&rows, releaseBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to upsert releases: %w", err)
+		}
+
+		if maxPerMovie <= 0 {
+			return nil
+		}
+		for movieID := range movieIDs {
+			if err := pruneMovieReleases(tx, movieID, maxPerMovie); err != nil {
+				return err
 			}
-		} else {
-			release.ID = existing.ID
-			if err := tx.Save(&release).Error; err != nil {
-				s.logger.Error("Failed to update release", "error", err, "title", release.Title)
-				continue
+		}
+
+		return nil
+	})
+}
+
+// carryOverReleaseCreatedAt copies created_at from stored releases onto matching rows, one query per indexer
+func carryOverReleaseCreatedAt(tx *gorm.DB, rows []models.Release) error {
+	guidsByIndexer := make(map[int][]string)
+	for _, release := range rows {
+		guidsByIndexer[release.IndexerID] = append(guidsByIndexer[release.IndexerID], release.GUID)
+	}
+
+	for indexerID, guids := range guidsByIndexer {
+		var existing []models.Release
+		if err := tx.Select("guid", "created_at").
+			Where("indexer_id = ? AND guid IN ?", indexerID, guids).
+			Find(&existing).Error; err != nil {
+			return fmt.Errorf("failed to look up existing releases: %w", err)
+		}
+
+		createdAt := make(map[string]time.Time, len(existing))
+		for _, release := range existing {
+			createdAt[release.GUID] = release.CreatedAt
+		}
+		for i := range rows {
+			if t, ok := createdAt[rows[i].GUID]; ok && rows[i].IndexerID == indexerID {
+				rows[i].CreatedAt = t
 			}
 		}
 	}
 
-	return tx.Commit().Error
+	return nil
+}
+
+// pruneMovieReleases deletes a movie's releases beyond the newest keep, never removing grabbed releases
+func pruneMovieReleases(tx *gorm.DB, movieID, keep int) error {
+	// The derived table lets MySQL use LIMIT in a subquery on the table being deleted from
+	result := tx.Exec(
+		"DELETE FROM releases WHERE movie_id = ? AND status <> ? AND id NOT IN "+
+			"(SELECT id FROM (SELECT id FROM releases WHERE movie_id = ? "+
+			"ORDER BY publish_date DESC, id DESC LIMIT ?) AS kept)",
+		movieID, models.ReleaseStatusGrabbed, movieID, keep,
+	)
+	if result.Error != nil {
+		return fmt.Errorf("failed to prune releases for movie %d: %w", movieID, result.Error)
+	}
+
+	return nil
 }
 
 // dedupReleases removes duplicate releases
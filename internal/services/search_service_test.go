@@ -0,0 +1,212 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_SaveReleases(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := NewSearchService(db, logger, nil, nil, nil, nil, nil,
+		NewRetentionService(db, config.RetentionConfig{ReleaseMaxPerMovie: 250}, logger), 1)
+
+	indexer := &models.Indexer{Name: "Test Indexer", Type: models.IndexerTypeTorznab, BaseURL: "http://localhost:9117"}
+	require.NoError(t, db.GORM.Create(indexer).Error)
+	movie := &models.Movie{TmdbID: 603, Title: "The Matrix", TitleSlug: "the-matrix-603", Year: 1999}
+	require.NoError(t, db.GORM.Create(movie).Error)
+
+	newRelease := func(i int) models.Release {
+		return models.Release{
+			GUID:        fmt.Sprintf("guid-%d", i),
+			Title:       fmt.Sprintf("The.Matrix.1999.1080p-%d", i),
+			IndexerID:   indexer.ID,
+			MovieID:     &movie.ID,
+			Protocol:    models.ProtocolTorrent,
+			DownloadURL: fmt.Sprintf("http://localhost/download/%d", i),
+			PublishDate: time.Now().Add(-time.Duration(i) * time.Hour),
+			Source:      models.ReleaseSourceSearch,
+		}
+	}
+
+	releases := []models.Release{newRelease(1), newRelease(2), newRelease(2)}
+	require.NoError(t, service.saveReleases(releases))
+	assert.Zero(t, releases[0].ID, "caller's releases should not be modified")
+
+	var count int64
+	require.NoError(t, db.GORM.Model(&models.Release{}).Where("movie_id = ?", movie.ID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	// Seeing a release again updates it in place without resetting its status
+	require.NoError(t, db.GORM.Model(&models.Release{}).Where("guid = ?", "guid-1").
+		Update("status", models.ReleaseStatusGrabbed).Error)
+	updated := newRelease(1)
+	updated.Title = "The.Matrix.1999.REPACK.1080p"
+	require.NoError(t, service.saveReleases([]models.Release{updated}))
+
+	var stored models.Release
+	require.NoError(t, db.GORM.Where("guid = ? AND indexer_id = ?", "guid-1", indexer.ID).First(&stored).Error)
+	assert.Equal(t, "The.Matrix.1999.REPACK.1080p", stored.Title)
+	assert.Equal(t, models.ReleaseStatusGrabbed, stored.Status)
+
+	// Pruning keeps the newest releases and never removes grabbed ones
+	batch := make([]models.Release, 0, 5)
+	for i := 3; i <= 7; i++ {
+		batch = append(batch, newRelease(i))
+	}
+	require.NoError(t, service.saveReleases(batch))
+	require.NoError(t, pruneMovieReleases(db.GORM, movie.ID, 3))
+
+	var guids []string
+	require.NoError(t, db.GORM.Model(&models.Release{}).Where("movie_id = ?", movie.ID).
+		Order("guid").Pluck("guid", &guids).Error)
+	assert.Equal(t, []string{"guid-1", "guid-2", "guid-3"}, guids)
+}
+
+func TestSearchService_evaluateRelease_Retention(t *testing.T) {
+	service := &SearchService{}
+	evaluation := &releaseEvaluation{retention: map[int]int{1: 1000}}
+
+	expired := service.evaluateRelease(models.Release{IndexerID: 1, Protocol: models.ProtocolUsenet, Age: 1200},
+		evaluation)
+	assert.Equal(t, models.ReleaseStatusRejected, expired.Status)
+	assert.Contains(t, expired.RejectionReasons, "Older than indexer retention (1000 days)")
+
+	retained := service.evaluateRelease(models.Release{IndexerID: 1, Protocol: models.ProtocolUsenet, Age: 200},
+		evaluation)
+	assert.Empty(t, retained.RejectionReasons)
+
+	// Torrents and indexers without retention aren't limited
+	torrent := service.evaluateRelease(models.Release{IndexerID: 1, Protocol: models.ProtocolTorrent, Age: 1200},
+		evaluation)
+	assert.NotContains(t, torrent.RejectionReasons, "Older than indexer retention (1000 days)")
+	unlimited := service.evaluateRelease(models.Release{IndexerID: 2, Protocol: models.ProtocolUsenet, Age: 1200},
+		evaluation)
+	assert.Equal(t, models.StringArray{"Too old"}, unlimited.RejectionReasons)
+}
+
+func TestSearchService_evaluateRelease_Languages(t *testing.T) {
+	service := &SearchService{}
+	french, _ := models.LanguageByName("French")
+	indexer := &models.Indexer{Settings: models.IndexerSettings{
+		models.SettingMultiLanguages: []interface{}{float64(french.ID), "1"},
+	}}
+	frenchAudio := &models.CustomFormat{ID: 1, Name: "French Audio", Specifications: models.CustomFormatSpecs{
+		{Implementation: "LanguageSpecification", Fields: map[string]interface{}{"value": float64(french.ID)}},
+	}}
+	evaluation := &releaseEvaluation{
+		movie: &models.Movie{OriginalLanguage: french},
+		profile: &models.QualityProfile{
+			Language:    "english",
+			FormatItems: models.CustomFormatItems{{Format: frenchAudio, Score: 100}},
+		},
+		formats: []*models.CustomFormat{frenchAudio},
+	}
+
+	// MULTi releases have the indexer's multi languages
+	multi := service.processRelease(models.Release{Title: "Amelie.2001.MULTi.1080p.BluRay.x264-GROUP"}, indexer)
+	assert.Equal(t, []string{"French", "English", "Original"}, multi.ReleaseInfo.Languages)
+	multi = service.evaluateRelease(multi, evaluation)
+	assert.Equal(t, []string{"French", "English"}, multi.ReleaseInfo.Languages)
+	assert.Empty(t, multi.RejectionReasons)
+	assert.Equal(t, 100, multi.CustomFormatScore)
+	require.Len(t, multi.CustomFormats, 1)
+
+	// VOSTFR releases have the original audio, here French, with French subtitles
+	vostfr := service.processRelease(models.Release{Title: "Amelie.2001.VOSTFR.1080p.WEB-DL.x264"}, indexer)
+	assert.Equal(t, []string{"French"}, vostfr.ReleaseInfo.Subtitles)
+	vostfr = service.evaluateRelease(vostfr, evaluation)
+	assert.Equal(t, models.ReleaseStatusRejected, vostfr.Status)
+	assert.Equal(t, models.StringArray{"Language French is not wanted in profile"}, vostfr.RejectionReasons)
+	assert.Equal(t, 100, vostfr.CustomFormatScore)
+
+	english := service.processRelease(models.Release{Title: "Amelie.2001.1080p.BluRay.x264"}, indexer)
+	english = service.evaluateRelease(english, evaluation)
+	assert.Empty(t, english.RejectionReasons)
+	assert.Empty(t, english.CustomFormats)
+	assert.Zero(t, english.CustomFormatScore)
+}
+
+func TestSearchService_applyGrabOverrides(t *testing.T) {
+	service := &SearchService{logger: logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stdout"})}
+	release := &models.Release{
+		Title:            "Movie.2024.CAM-GROUP",
+		Protocol:         models.ProtocolUsenet,
+		Age:              10,
+		Status:           models.ReleaseStatusRejected,
+		RejectionReasons: models.StringArray{"Below minimum size for CAM"},
+	}
+
+	// Without overrides the release is left as found
+	require.NoError(t, service.applyGrabOverrides(release, &models.GrabRequest{}))
+	assert.False(t, release.IsGrabbable())
+
+	// A corrected quality is evaluated again
+	quality := &models.Quality{Quality: models.QualityDefinition{Name: "Bluray-1080p", Source: "bluray", Resolution: 1080}}
+	require.NoError(t, service.applyGrabOverrides(release, &models.GrabRequest{Quality: quality}))
+	assert.Equal(t, *quality, release.Quality)
+	assert.Equal(t, service.calculateQualityWeight(*quality), release.QualityWeight)
+	assert.True(t, release.IsGrabbable())
+}
+
+func TestSearchService_GrabRelease_Overrides(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	downloadService := NewDownloadService(db, logger)
+	service := NewSearchService(db, logger, nil, nil, NewMovieService(db, logger), downloadService, nil, nil, 1)
+
+	indexer := &models.Indexer{Name: "Test Indexer", Type: models.IndexerTypeNewznab, BaseURL: "http://localhost:5076"}
+	require.NoError(t, db.GORM.Create(indexer).Error)
+	movie := &models.Movie{TmdbID: 603, Title: "The Matrix", TitleSlug: "the-matrix-603", Year: 1999}
+	require.NoError(t, db.GORM.Create(movie).Error)
+	sabnzbd := &models.DownloadClient{Name: "SABnzbd", Type: models.DownloadClientTypeSABnzbd,
+		Protocol: models.DownloadProtocolUsenet, Host: "localhost", Port: 8080, Enable: true}
+	qbittorrent := &models.DownloadClient{Name: "qBittorrent", Type: models.DownloadClientTypeQBittorrent,
+		Protocol: models.DownloadProtocolTorrent, Host: "localhost", Port: 8081, Enable: true}
+	require.NoError(t, db.GORM.Create(sabnzbd).Error)
+	require.NoError(t, db.GORM.Create(qbittorrent).Error)
+	release := &models.Release{GUID: "unmapped", Title: "Matrix.1999.1080p.BluRay-GROUP", IndexerID: indexer.ID,
+		Protocol: models.ProtocolUsenet, DownloadURL: "http://localhost/nzb/1", PublishDate: time.Now(),
+		Source: models.ReleaseSourceInteractiveSearch}
+	require.NoError(t, db.GORM.Create(release).Error)
+
+	var validationErr models.ValidationError
+	request := &models.GrabRequest{GUID: release.GUID, IndexerID: indexer.ID}
+
+	// The indexer has no download client of its own
+	_, err := service.GrabRelease(request)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "downloadClientId", validationErr.Field)
+
+	// A torrent client can't download usenet releases
+	request.DownloadClientID = &qbittorrent.ID
+	_, err = service.GrabRelease(request)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "downloadClientId", validationErr.Field)
+
+	missing := movie.ID + 1000
+	request.DownloadClientID, request.MovieID = &sabnzbd.ID, &missing
+	_, err = service.GrabRelease(request)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "movieId", validationErr.Field)
+
+	request.MovieID = &movie.ID
+	response, err := service.GrabRelease(request)
+	require.NoError(t, err)
+	assert.Equal(t, "grabbed", response.Status)
+
+	var stored models.Release
+	require.NoError(t, db.GORM.First(&stored, release.ID).Error)
+	require.NotNil(t, stored.MovieID)
+	assert.Equal(t, movie.ID, *stored.MovieID)
+	assert.Equal(t, sabnzbd.ID, *stored.DownloadClientID)
+}
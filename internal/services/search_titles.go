@@ -0,0 +1,120 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// maxAlternateSearchTitles caps the alternate titles, besides the original title, searched for a
+// foreign language movie, so a movie with dozens of regional titles doesn't flood its indexers
+const maxAlternateSearchTitles = 2
+
+// englishLanguageNames are the original language values that mean English: TMDB's ISO 639-1 code,
+// the ISO 639-2 code, and the name
+var englishLanguageNames = map[string]bool{"en": true, "eng": true, "english": true}
+
+// alternateSearchTitles returns the titles, besides its title, a movie whose original language
+// isn't English is also queried by: its original title, then its first significant alternate
+// titles. Alternate titles are significant when written in Latin script, as release names are,
+// and different from the titles already listed once normalized. Movies in English, or of an
+// unknown original language, are only searched by their title.
+func alternateSearchTitles(movie *models.Movie) []string {
+	language := strings.ToLower(strings.TrimSpace(movie.OriginalLanguage.Name))
+	if language == "" || englishLanguageNames[language] || movie.OriginalLanguage.ID == 1 {
+		return nil
+	}
+
+	seen := map[string]bool{normalizeSearchTitle(movie.Title): true}
+	var titles []string
+	add := func(title string) bool {
+		normalized := normalizeSearchTitle(title)
+		if normalized == "" || seen[normalized] {
+			return false
+		}
+		seen[normalized] = true
+		titles = append(titles, strings.TrimSpace(title))
+		return true
+	}
+
+	add(movie.OriginalTitle)
+	alternates := 0
+	for _, title := range movie.AlternateTitles {
+		if alternates == maxAlternateSearchTitles {
+			break
+		}
+		if isLatinTitle(title) && add(title) {
+			alternates++
+		}
+	}
+	return titles
+}
+
+// isLatinTitle reports whether every letter of title is in Latin script
+func isLatinTitle(title string) bool {
+	letters := 0
+	for _, r := range title {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+		letters++
+	}
+	return letters > 0
+}
+
+// searchAlternateTitles runs a title query for each of the request's alternate titles and merges
+// their releases into releases, skipping those the indexer already returned. The alternate queries
+// drop the movie's IDs, which indexers would otherwise answer with the same releases as the main
+// query. A failed alternate query is logged and the releases found so far kept.
+func (s *SearchService) searchAlternateTitles(
+	indexer *models.Indexer, request *models.SearchRequest, releases []models.Release,
+) []models.Release {
+	if len(request.AlternateTitles) == 0 {
+		return releases
+	}
+
+	seen := make(map[string]bool, len(releases))
+	for i := range releases {
+		seen[releases[i].GUID] = true
+	}
+
+	for _, title := range request.AlternateTitles {
+		alternate := *request
+		alternate.Title, alternate.ImdbID, alternate.TmdbID, alternate.AlternateTitles = title, "", nil, nil
+
+		found, err := s.searchNewznabIndexer(indexer, &alternate)
+		if err != nil {
+			s.logger.Warn("Failed to search indexer by alternate title", "indexer", indexer.Name, "title", title,
+				"error", err)
+			continue
+		}
+		added := 0
+		for i := range found {
+			if !seen[found[i].GUID] {
+				seen[found[i].GUID] = true
+				releases = append(releases, found[i])
+				added++
+			}
+		}
+		s.logger.Debug("Searched indexer by alternate title", "indexer", indexer.Name, "title", title,
+			"releases", len(found), "added", added)
+	}
+	return releases
+}
+
+// rssItemMatches reports whether an RSS item's title contains the request's title or one of its
+// alternate titles, compared as normalizeSearchTitle writes them so punctuation and accents don't
+// prevent a match
+func rssItemMatches(itemTitle string, request *models.SearchRequest) bool {
+	itemTitle = normalizeSearchTitle(itemTitle)
+	for _, title := range append([]string{request.Title}, request.AlternateTitles...) {
+		if title := normalizeSearchTitle(title); title != "" && strings.Contains(itemTitle, title) {
+			return true
+		}
+	}
+	return false
+}
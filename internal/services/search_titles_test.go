@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlternateSearchTitles(t *testing.T) {
+	spirited := &models.Movie{
+		Title:            "Spirited Away",
+		OriginalTitle:    "千と千尋の神隠し",
+		OriginalLanguage: models.Language{Name: "ja"},
+		AlternateTitles: models.StringArray{
+			"Spirited away", "Sen to Chihiro no Kamikakushi", "Le Voyage de Chihiro", "El viaje de Chihiro",
+		},
+	}
+	assert.Equal(t, []string{"千と千尋の神隠し", "Sen to Chihiro no Kamikakushi", "Le Voyage de Chihiro"},
+		alternateSearchTitles(spirited))
+
+	amelie := &models.Movie{
+		Title:            "Amélie",
+		OriginalTitle:    "Le Fabuleux Destin d'Amélie Poulain",
+		OriginalLanguage: models.Language{Name: "fr"},
+		AlternateTitles:  models.StringArray{"アメリ", "Amelie from Montmartre"},
+	}
+	assert.Equal(t, []string{"Le Fabuleux Destin d'Amélie Poulain", "Amelie from Montmartre"},
+		alternateSearchTitles(amelie))
+
+	english := &models.Movie{
+		Title: "Heat", OriginalTitle: "Heat", OriginalLanguage: models.Language{Name: "en"},
+		AlternateTitles: models.StringArray{"Heat - Showdown"},
+	}
+	assert.Empty(t, alternateSearchTitles(english))
+	assert.Empty(t, alternateSearchTitles(&models.Movie{Title: "Unknown", OriginalTitle: "Inconnu"}))
+}
+
+func TestSearchService_searchIndexer_AlternateTitles(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, fmt.Sprintf("q=%s imdbid=%s", query.Get("q"), query.Get("imdbid")))
+		items := `<item><title>Amelie.2001.1080p.BluRay-GRP</title><guid>guid-1</guid></item>`
+		if query.Get("q") == "Le Fabuleux Destin dAmelie Poulain" {
+			items += `<item><title>Le.Fabuleux.Destin.dAmelie.Poulain.2001.1080p.BluRay-GRP</title>` +
+				`<guid>guid-2</guid></item>`
+		}
+		_, _ = fmt.Fprintf(w, `<rss><channel>%s</channel></rss>`, items)
+	}))
+	defer server.Close()
+
+	service := NewSearchService(nil, logger.New(config.LogConfig{Level: "error"}), nil, nil, nil, nil, nil, nil, 1)
+	indexer := &models.Indexer{Name: "Test", Type: models.IndexerTypeNewznab, BaseURL: server.URL}
+	request := &models.SearchRequest{
+		Title: "Amélie", ImdbID: "tt0211915",
+		AlternateTitles: []string{"Le Fabuleux Destin d'Amélie Poulain"},
+	}
+
+	releases, err := service.searchIndexer(indexer, request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"q=Amelie imdbid=0211915", "q=Le Fabuleux Destin dAmelie Poulain imdbid="}, queries)
+	require.Len(t, releases, 2)
+	assert.Equal(t, "guid-1", releases[0].GUID)
+	assert.Equal(t, "guid-2", releases[1].GUID)
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/httpclient"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// seedingClientTimeout bounds each request to a download client while checking seeding torrents
+const seedingClientTimeout = 30 * time.Second
+
+// transmissionSessionHeader carries Transmission's CSRF token, returned with a 409 response
+const transmissionSessionHeader = "X-Transmission-Session-Id"
+
+// torrentStatus is the seeding progress of a torrent as its download client reports it
+type torrentStatus struct {
+	Ratio       float64
+	SeedingTime time.Duration
+	Complete    bool
+}
+
+// seedingClient reads the seeding progress of torrents from a download client and removes them
+type seedingClient interface {
+	// Torrents returns the status of the torrents with the given hashes that are still in the
+	// client, keyed by lowercase hash
+	Torrents(ctx context.Context, hashes []string) (map[string]torrentStatus, error)
+	// Remove removes a torrent and its downloaded data from the client
+	Remove(ctx context.Context, hash string) error
+}
+
+// newSeedingClient returns the seeding client for a download client, or an error for clients
+// whose seeding torrents cannot be managed
+func newSeedingClient(client *models.DownloadClient) (seedingClient, error) {
+	switch client.Type {
+	case models.DownloadClientTypeQBittorrent:
+		return newQBittorrentClient(client)
+	case models.DownloadClientTypeTransmission:
+		httpClient := httpclient.New(seedingClientTimeout, httpclient.WithSkipTLSVerify(client.SkipTLSVerify()))
+		return &transmissionSeedingClient{client: client, httpClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("seeding management is not supported for %s", client.Type)
+	}
+}
+
+// transmissionSeedingClient manages seeding torrents through the Transmission RPC interface
+type transmissionSeedingClient struct {
+	client     *models.DownloadClient
+	httpClient *http.Client
+	sessionID  string
+}
+
+// Torrents returns the seeding progress of the torrents still in Transmission
+func (c *transmissionSeedingClient) Torrents(
+	ctx context.Context, hashes []string,
+) (map[string]torrentStatus, error) {
+	ids := make([]string, len(hashes))
+	for i, hash := range hashes {
+		ids[i] = strings.ToLower(hash)
+	}
+
+	var arguments struct {
+		Torrents []struct {
+			HashString     string  `json:"hashString"`
+			UploadRatio    float64 `json:"uploadRatio"`
+			SecondsSeeding int64   `json:"secondsSeeding"`
+			PercentDone    float64 `json:"percentDone"`
+		} `json:"torrents"`
+	}
+	// Reading torrents changes nothing, so it is retried like a GET
+	err := c.call(httpclient.WithRetries(ctx), "torrent-get", map[string]any{
+		"ids":    ids,
+		"fields": []string{"hashString", "uploadRatio", "secondsSeeding", "percentDone"},
+	}, &arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]torrentStatus, len(arguments.Torrents))
+	for _, torrent := range arguments.Torrents {
+		statuses[strings.ToLower(torrent.HashString)] = torrentStatus{
+			// Transmission reports -1 before anything was uploaded
+			Ratio:       max(torrent.UploadRatio, 0),
+			SeedingTime: time.Duration(torrent.SecondsSeeding) * time.Second,
+			Complete:    torrent.PercentDone >= 1,
+		}
+	}
+	return statuses, nil
+}
+
+// TransferRates returns Transmission's current download and upload speeds in bytes per second
+func (c *transmissionSeedingClient) TransferRates(ctx context.Context) (download, upload int64, err error) {
+	var stats struct {
+		DownloadSpeed int64 `json:"downloadSpeed"`
+		UploadSpeed   int64 `json:"uploadSpeed"`
+	}
+	if err := c.call(httpclient.WithRetries(ctx), "session-stats", map[string]any{}, &stats); err != nil {
+		return 0, 0, err
+	}
+	return stats.DownloadSpeed, stats.UploadSpeed, nil
+}
+
+// Remove deletes a torrent and its files from Transmission
+func (c *transmissionSeedingClient) Remove(ctx context.Context, hash string) error {
+	return c.call(ctx, "torrent-remove", map[string]any{
+		"ids":               []string{strings.ToLower(hash)},
+		"delete-local-data": true,
+	}, nil)
+}
+
+// call invokes an RPC method, fetching a new session ID when Transmission rejects the current one
+func (c *transmissionSeedingClient) call(ctx context.Context, method string, arguments, result any) error {
+	payload, err := json.Marshal(map[string]any{"method": method, "arguments": arguments})
+	if err != nil {
+		return fmt.Errorf("failed to encode Transmission request: %w", err)
+	}
+
+	var resp *http.Response
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.client.GetBaseURL()+"/transmission/rpc",
+			bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(transmissionSessionHeader, c.sessionID)
+		if c.client.Username != "" {
+			req.SetBasicAuth(c.client.Username, c.client.Password)
+		}
+
+		resp, err = c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("transmission request failed: %w", err)
+		}
+		if resp.StatusCode != http.StatusConflict {
+			break
+		}
+		c.sessionID = resp.Header.Get(transmissionSessionHeader)
+		_ = resp.Body.Close()
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return &providerStatusError{Provider: "transmission", StatusCode: resp.StatusCode}
+	}
+
+	var response struct {
+		Result    string          `json:"result"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("invalid Transmission response: %w", err)
+	}
+	if response.Result != "success" {
+		return fmt.Errorf("transmission %s failed: %s", method, response.Result)
+	}
+	if result != nil {
+		if err := json.Unmarshal(response.Arguments, result); err != nil {
+			return fmt.Errorf("invalid Transmission %s response: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// doSeedingRequest sends a request to a download client and returns the response body, failing
+// on non-2xx statuses
+func doSeedingRequest(httpClient *http.Client, req *http.Request, clientName string) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", clientName, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", clientName, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &providerStatusError{Provider: clientName, StatusCode: resp.StatusCode}
+	}
+	return body, nil
+}
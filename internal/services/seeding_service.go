@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/database"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedingService tracks imported torrents while they seed and removes them from their download
+// clients once the seed goals of their indexer or client are met, freeing the space they take
+type SeedingService struct {
+	db              *database.Database
+	logger          *logger.Logger
+	downloadService *DownloadService
+	newClient       func(*models.DownloadClient) (seedingClient, error)
+}
+
+// NewSeedingService creates a new instance of SeedingService
+func NewSeedingService(
+	db *database.Database, logger *logger.Logger, downloadService *DownloadService,
+) *SeedingService {
+	return &SeedingService{
+		db:              db,
+		logger:          logger,
+		downloadService: downloadService,
+		newClient:       newSeedingClient,
+	}
+}
+
+// Track starts tracking the torrent of an imported download until it has seeded. Usenet
+// downloads and downloads not in the queue are ignored.
+func (s *SeedingService) Track(downloadID string) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	var queueItem models.QueueItem
+	if err := s.db.GORM.Where("download_id = ?", downloadID).First(&queueItem).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get queue item for download %s: %w", downloadID, err)
+	}
+	if queueItem.Protocol != models.DownloadProtocolTorrent || queueItem.DownloadClientID == 0 {
+		return nil
+	}
+
+	torrent := &models.SeedingTorrent{
+		DownloadClientID: queueItem.DownloadClientID,
+		IndexerID:        s.grabbedIndexerID(downloadID),
+		MovieID:          queueItem.MovieID,
+		DownloadID:       downloadID,
+		Title:            queueItem.Title,
+		Status:           models.SeedingStatusSeeding,
+		ImportedAt:       time.Now(),
+	}
+	result := s.db.GORM.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "download_client_id"}, {Name: "download_id"}},
+		DoNothing: true,
+	}).Create(torrent)
+	if result.Error != nil {
+		return fmt.Errorf("failed to track seeding torrent: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		s.logger.Info("Tracking seeding torrent", "downloadId", downloadID, "title", queueItem.Title,
+			"downloadClientId", queueItem.DownloadClientID)
+	}
+	return nil
+}
+
+// grabbedIndexerID returns the ID of the indexer a download was grabbed from, or nil when the
+// grab isn't in the download history or the indexer no longer exists
+func (s *SeedingService) grabbedIndexerID(downloadID string) *int {
+	var grab models.DownloadHistory
+	if err := s.db.GORM.Where("download_id = ? AND indexer_name <> ''", downloadID).
+		Order("date DESC").First(&grab).Error; err != nil {
+		return nil
+	}
+
+	var indexer models.Indexer
+	if err := s.db.GORM.Select("id").Where("name = ?", grab.IndexerName).First(&indexer).Error; err != nil {
+		return nil
+	}
+	return &indexer.ID
+}
+
+// GetAll returns the tracked torrents, most recently imported first, limited to status unless
+// it is empty
+func (s *SeedingService) GetAll(status models.SeedingStatus) ([]models.SeedingTorrent, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	query := s.db.GORM.Order("imported_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var torrents []models.SeedingTorrent
+	if err := query.Find(&torrents).Error; err != nil {
+		return nil, fmt.Errorf("failed to get seeding torrents: %w", err)
+	}
+	return torrents, nil
+}
+
+// ProcessSeeding checks the seeding torrents against their download clients, recording their
+// progress and removing those that met their seed goals along with their data. Clients that are
+// disabled or don't remove completed downloads are left alone, as are torrents without goals.
+// A simulated run only reports the torrents it would remove, recording nothing.
+func (s *SeedingService) ProcessSeeding(ctx context.Context) (*models.SeedingCleanupResult, error) {
+	torrents, err := s.GetAll(models.SeedingStatusSeeding)
+	if err != nil {
+		return nil, err
+	}
+
+	byClient := make(map[int][]models.SeedingTorrent)
+	for _, torrent := range torrents {
+		byClient[torrent.DownloadClientID] = append(byClient[torrent.DownloadClientID], torrent)
+	}
+
+	indexerGoals, err := s.indexerSeedGoals()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.SeedingCleanupResult{RemovedTitles: []string{}}
+	clientIDs := make([]int, 0, len(byClient))
+	for clientID := range byClient {
+		clientIDs = append(clientIDs, clientID)
+	}
+	slices.Sort(clientIDs)
+
+	for _, clientID := range clientIDs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		s.processClient(ctx, clientID, byClient[clientID], indexerGoals, result)
+	}
+
+	if result.Removed > 0 || result.Missing > 0 {
+		s.logger.Info("Seeding cleanup completed", "checked", result.Checked, "removed", result.Removed,
+			"missing", result.Missing, "simulated", Simulating(ctx))
+	}
+	return result, nil
+}
+
+// indexerSeedGoals returns the seed goals of the indexers that set any, by indexer ID
+func (s *SeedingService) indexerSeedGoals() (map[int]models.SeedGoals, error) {
+	var indexers []models.Indexer
+	if err := s.db.GORM.Find(&indexers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get indexers: %w", err)
+	}
+
+	goals := make(map[int]models.SeedGoals)
+	for i := range indexers {
+		if indexerGoals := indexers[i].SeedGoals(); indexerGoals.IsSet() {
+			goals[indexers[i].ID] = indexerGoals
+		}
+	}
+	return goals, nil
+}
+
+// processClient checks the seeding torrents of one download client
+func (s *SeedingService) processClient(ctx context.Context, clientID int, torrents []models.SeedingTorrent,
+	indexerGoals map[int]models.SeedGoals, result *models.SeedingCleanupResult) {
+	client, err := s.downloadService.GetDownloadClientByID(clientID)
+	if err != nil {
+		s.logger.Warn("Skipping seeding torrents of missing download client", "downloadClientId", clientID)
+		return
+	}
+	if !client.Enable || !client.RemoveCompletedDownloads {
+		s.logger.Debug("Skipping seeding torrents, client is disabled or keeps completed downloads",
+			"client", client.Name)
+		return
+	}
+
+	seeding, err := s.newClient(client)
+	if err != nil {
+		s.logger.Debug("Skipping seeding torrents", "client", client.Name, "reason", err)
+		return
+	}
+
+	hashes := make([]string, len(torrents))
+	for i := range torrents {
+		hashes[i] = torrents[i].DownloadID
+	}
+	statuses, err := seeding.Torrents(ctx, hashes)
+	if err != nil {
+		s.logger.Warn("Failed to check seeding torrents", "client", client.Name, "error", err)
+		return
+	}
+
+	now := time.Now()
+	simulate := Simulating(ctx)
+	for i := range torrents {
+		torrent := &torrents[i]
+		torrent.LastCheckedAt = &now
+		result.Checked++
+
+		status, ok := statuses[strings.ToLower(torrent.DownloadID)]
+		switch {
+		case !ok:
+			torrent.Status = models.SeedingStatusMissing
+			result.Missing++
+		case s.seedGoals(client, torrent, indexerGoals).Met(status.Ratio, status.SeedingTime) && status.Complete:
+			torrent.Ratio, torrent.SeedingTime = status.Ratio, int64(status.SeedingTime/time.Second)
+			if simulate {
+				result.Removed++
+				result.RemovedTitles = append(result.RemovedTitles, torrent.Title)
+				s.logger.Info("Would remove seeded torrent", "client", client.Name, "title", torrent.Title,
+					"ratio", status.Ratio, "seedingTime", status.SeedingTime)
+				continue
+			}
+			if err := seeding.Remove(ctx, torrent.DownloadID); err != nil {
+				s.logger.Warn("Failed to remove seeded torrent", "client", client.Name, "title", torrent.Title,
+					"error", err)
+				break
+			}
+			torrent.Status = models.SeedingStatusRemoved
+			torrent.RemovedAt = &now
+			result.Removed++
+			result.RemovedTitles = append(result.RemovedTitles, torrent.Title)
+			s.logger.Info("Removed seeded torrent", "client", client.Name, "title", torrent.Title,
+				"ratio", status.Ratio, "seedingTime", status.SeedingTime)
+		default:
+			torrent.Ratio, torrent.SeedingTime = status.Ratio, int64(status.SeedingTime/time.Second)
+		}
+
+		if simulate {
+			continue
+		}
+		if err := s.db.GORM.Save(torrent).Error; err != nil {
+			s.logger.Error("Failed to update seeding torrent", "id", torrent.ID, "error", err)
+		}
+	}
+}
+
+// seedGoals returns the goals a torrent seeds to: its indexer's, as the tracker requires, with
+// the client's goals filling in those the indexer doesn't set
+func (s *SeedingService) seedGoals(client *models.DownloadClient, torrent *models.SeedingTorrent,
+	indexerGoals map[int]models.SeedGoals) models.SeedGoals {
+	goals := client.SeedGoals()
+	if torrent.IndexerID != nil {
+		goals = goals.Override(indexerGoals[*torrent.IndexerID])
+	}
+	return goals
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDownloadClient returns a download client pointing at server
+func testDownloadClient(
+	t *testing.T, server *httptest.Server, clientType models.DownloadClientType,
+) *models.DownloadClient {
+	t.Helper()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+	return &models.DownloadClient{Type: clientType, Host: serverURL.Hostname(), Port: port,
+		Username: "admin", Password: "secret"}
+}
+
+func TestSeedGoals(t *testing.T) {
+	client := &models.DownloadClient{Settings: models.DownloadClientSettings{"seedRatio": 1.0, "seedTime": "60"}}
+	indexer := &models.Indexer{Settings: models.IndexerSettings{"seedRatio": 2.5}}
+
+	goals := client.SeedGoals()
+	assert.Equal(t, models.SeedGoals{Ratio: 1, Time: time.Hour}, goals)
+
+	// The indexer's ratio applies, the client's time fills in
+	goals = goals.Override(indexer.SeedGoals())
+	assert.Equal(t, models.SeedGoals{Ratio: 2.5, Time: time.Hour}, goals)
+
+	assert.False(t, goals.Met(2, 30*time.Minute))
+	assert.True(t, goals.Met(2.5, 0))
+	assert.True(t, goals.Met(0, time.Hour))
+	assert.False(t, models.SeedGoals{}.Met(10, 24*time.Hour))
+}
+
+func TestQBittorrentSeedingClient(t *testing.T) {
+	var deleted url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/auth/login":
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session", Path: "/"})
+			_, _ = w.Write([]byte("Ok."))
+		case "/api/v2/torrents/info":
+			if _, err := r.Cookie("SID"); err != nil {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			assert.Equal(t, "abc|def", r.URL.Query().Get("hashes"))
+			_, _ = w.Write([]byte(`[{"hash": "abc", "ratio": 1.5, "seeding_time": 7200, "progress": 1}]`))
+		case "/api/v2/torrents/delete":
+			require.NoError(t, r.ParseForm())
+			deleted = r.PostForm
+		}
+	}))
+	defer server.Close()
+
+	client, err := newSeedingClient(testDownloadClient(t, server, models.DownloadClientTypeQBittorrent))
+	require.NoError(t, err)
+
+	statuses, err := client.Torrents(context.Background(), []string{"ABC", "DEF"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]torrentStatus{"abc": {Ratio: 1.5, SeedingTime: 2 * time.Hour, Complete: true}}, statuses)
+
+	require.NoError(t, client.Remove(context.Background(), "ABC"))
+	assert.Equal(t, "abc", deleted.Get("hashes"))
+	assert.Equal(t, "true", deleted.Get("deleteFiles"))
+}
+
+func TestTransmissionSeedingClient(t *testing.T) {
+	var removed map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(transmissionSessionHeader) != "token" {
+			w.Header().Set(transmissionSessionHeader, "token")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		var request struct {
+			Method    string         `json:"method"`
+			Arguments map[string]any `json:"arguments"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch request.Method {
+		case "torrent-get":
+			_, _ = w.Write([]byte(`{"result": "success", "arguments": {"torrents": [
+				{"hashString": "abc", "uploadRatio": -1, "secondsSeeding": 60, "percentDone": 1}]}}`))
+		case "torrent-remove":
+			removed = request.Arguments
+			_, _ = w.Write([]byte(`{"result": "success", "arguments": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := newSeedingClient(testDownloadClient(t, server, models.DownloadClientTypeTransmission))
+	require.NoError(t, err)
+
+	statuses, err := client.Torrents(context.Background(), []string{"ABC"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]torrentStatus{"abc": {Ratio: 0, SeedingTime: time.Minute, Complete: true}}, statuses)
+
+	require.NoError(t, client.Remove(context.Background(), "ABC"))
+	assert.Equal(t, []any{"abc"}, removed["ids"])
+	assert.Equal(t, true, removed["delete-local-data"])
+}
+
+func TestNewSeedingClient_Unsupported(t *testing.T) {
+	_, err := newSeedingClient(&models.DownloadClient{Type: models.DownloadClientTypeSABnzbd})
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// simulateTaskBodyKey is the command body flag that runs a destructive task as a dry run
+const simulateTaskBodyKey = "simulate"
+
+// simulateKey marks a context as a dry run
+type simulateKey struct{}
+
+// WithSimulate marks ctx so destructive maintenance run with it only logs and reports what it
+// would delete, leaving the database, download clients and disk untouched
+func WithSimulate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, simulateKey{}, true)
+}
+
+// Simulating reports whether ctx was marked by WithSimulate
+func Simulating(ctx context.Context) bool {
+	simulate, _ := ctx.Value(simulateKey{}).(bool)
+	return simulate
+}
+
+// simulateTask reports whether the task's command body asks for a dry run, and returns the context
+// to run it with
+func simulateTask(ctx context.Context, task *models.TaskV2) (context.Context, bool) {
+	if task == nil {
+		return ctx, false
+	}
+	if simulate, _ := task.Body[simulateTaskBodyKey].(bool); simulate {
+		return WithSimulate(ctx), true
+	}
+	return ctx, Simulating(ctx)
+}
+
+// prune runs deletes that return the number of rows removed. When ctx is simulating they run in a
+// transaction that is rolled back, so the count is what they would remove.
+func prune(ctx context.Context, db *gorm.DB, deletes func(tx *gorm.DB) (int64, error)) (int64, error) {
+	tx := db.WithContext(ctx)
+	if !Simulating(ctx) {
+		return deletes(tx)
+	}
+
+	tx = tx.Begin()
+	if tx.Error != nil {
+		return 0, fmt.Errorf("failed to begin simulated prune: %w", tx.Error)
+	}
+	defer tx.Rollback()
+	return deletes(tx)
+}
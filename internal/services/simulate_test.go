@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateTask(t *testing.T) {
+	ctx := context.Background()
+
+	_, simulate := simulateTask(ctx, nil)
+	assert.False(t, simulate)
+
+	_, simulate = simulateTask(ctx, &models.TaskV2{Body: models.JSONField{"simulate": "yes"}})
+	assert.False(t, simulate)
+
+	simulated, simulate := simulateTask(ctx, &models.TaskV2{Body: models.JSONField{"simulate": true}})
+	assert.True(t, simulate)
+	assert.True(t, Simulating(simulated))
+	assert.False(t, Simulating(ctx))
+
+	_, simulate = simulateTask(WithSimulate(ctx), &models.TaskV2{})
+	assert.True(t, simulate)
+}
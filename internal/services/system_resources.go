@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// cpuSampleWindow is how long the first CPU usage reading waits between samples, since usage is
+// measured as the busy share of the time elapsed between two readings
+const cpuSampleWindow = 250 * time.Millisecond
+
+// errSystemStatUnsupported is returned for statistics the current platform does not expose
+var errSystemStatUnsupported = errors.New("not supported on this platform")
+
+// SystemResourceChecker reads host CPU, memory, disk, uptime and load statistics using the
+// platform's native interfaces
+type SystemResourceChecker struct {
+	cpu cpuSampler
+}
+
+// NewSystemResourceChecker creates a new system resource checker
+func NewSystemResourceChecker() *SystemResourceChecker {
+	return &SystemResourceChecker{}
+}
+
+// GetCPUUsage implements SystemResourceCheckerInterface. The usage covers the time since the
+// previous call, or a short sampling window on the first call.
+func (c *SystemResourceChecker) GetCPUUsage() (float64, error) {
+	usage, err := systemCPUUsage(&c.cpu)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CPU usage: %w", err)
+	}
+	return usage, nil
+}
+
+// GetMemoryUsage implements SystemResourceCheckerInterface, returning physical memory in bytes
+func (c *SystemResourceChecker) GetMemoryUsage() (used, total int64, err error) {
+	used, total, err = systemMemory()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read memory usage: %w", err)
+	}
+	return used, total, nil
+}
+
+// GetDiskUsage implements SystemResourceCheckerInterface, returning the used and total bytes of
+// the filesystem holding path
+func (c *SystemResourceChecker) GetDiskUsage(path string) (used, total int64, err error) {
+	usage, err := getDiskUsageForPath(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read disk usage for %s: %w", path, err)
+	}
+	return usage.Total - usage.Free, usage.Total, nil
+}
+
+// GetSystemUptime implements SystemResourceCheckerInterface
+func (c *SystemResourceChecker) GetSystemUptime() (int64, error) {
+	uptime, err := systemUptime()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read system uptime: %w", err)
+	}
+	return int64(uptime / time.Second), nil
+}
+
+// GetLoadAverage implements SystemResourceCheckerInterface, returning the one minute load average
+func (c *SystemResourceChecker) GetLoadAverage() (float64, error) {
+	load, err := systemLoadAverage()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read load average: %w", err)
+	}
+	return load, nil
+}
+
+// IsPathAccessible implements SystemResourceCheckerInterface. Directories must also be listable.
+func (c *SystemResourceChecker) IsPathAccessible(path string) bool {
+	if path == "" {
+		return false
+	}
+
+	f, err := os.Open(path) // #nosec G304 -- Paths come from configuration and root folders
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = f.Close() //nolint:errcheck // Read-only handle
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	if info.IsDir() {
+		if _, err := f.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+			return false
+		}
+	}
+	return true
+}
+
+// cpuSampler turns cumulative idle and total CPU time counters into a usage percentage
+type cpuSampler struct {
+	mu    sync.Mutex
+	idle  uint64
+	total uint64
+}
+
+// usage returns the busy share of the CPU time elapsed since the previous reading
+func (s *cpuSampler) usage(read func() (idle, total uint64, err error)) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idle, total, err := read()
+	if err != nil {
+		return 0, err
+	}
+
+	if s.total == 0 || total <= s.total {
+		s.idle, s.total = idle, total
+		time.Sleep(cpuSampleWindow)
+		if idle, total, err = read(); err != nil {
+			return 0, err
+		}
+	}
+
+	usage := cpuBusyPercent(s.idle, s.total, idle, total)
+	s.idle, s.total = idle, total
+	return usage, nil
+}
+
+// cpuBusyPercent computes the busy percentage between two idle/total counter readings
+func cpuBusyPercent(prevIdle, prevTotal, idle, total uint64) float64 {
+	if total <= prevTotal {
+		return 0
+	}
+	elapsed := float64(total - prevTotal)
+	idleDelta := 0.0
+	if idle > prevIdle {
+		idleDelta = float64(idle - prevIdle)
+	}
+
+	usage := (1 - idleDelta/elapsed) * 100
+	if usage < 0 {
+		return 0
+	}
+	return usage
+}
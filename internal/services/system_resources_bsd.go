@@ -0,0 +1,51 @@
+//go:build darwin || freebsd
+// +build darwin freebsd
+
+package services
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// systemUptime derives the uptime from the kern.boottime sysctl
+func systemUptime() (time.Duration, error) {
+	boot, err := unix.SysctlTimeval("kern.boottime")
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(time.Unix(boot.Unix())), nil
+}
+
+// systemLoadAverage reads the one minute load average from the vm.loadavg sysctl, a struct
+// loadavg of three fixed point uint32 values followed by their C long scale
+func systemLoadAverage() (float64, error) {
+	raw, err := unix.SysctlRaw("vm.loadavg")
+	if err != nil {
+		return 0, err
+	}
+
+	longSize := strconv.IntSize / 8
+	if len(raw) < 12+longSize {
+		return 0, fmt.Errorf("unexpected vm.loadavg size %d", len(raw))
+	}
+
+	load := binary.NativeEndian.Uint32(raw[0:4])
+	scale := readNativeLong(raw[len(raw)-longSize:])
+	if scale == 0 {
+		return 0, fmt.Errorf("vm.loadavg has no scale")
+	}
+	return float64(load) / float64(scale), nil
+}
+
+// readNativeLong decodes a C unsigned long in the platform's byte order
+func readNativeLong(b []byte) uint64 {
+	if len(b) == 8 {
+		return binary.NativeEndian.Uint64(b)
+	}
+	return uint64(binary.NativeEndian.Uint32(b))
+}
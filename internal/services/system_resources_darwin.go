@@ -0,0 +1,74 @@
+//go:build darwin
+// +build darwin
+
+package services
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// psTimeout bounds the ps invocation used for CPU usage
+const psTimeout = 5 * time.Second
+
+// systemCPUUsage sums the per-process CPU usage reported by ps, since macOS exposes its CPU tick
+// counters only through Mach calls. ps reports each process as a share of one core.
+func systemCPUUsage(_ *cpuSampler) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), psTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "ps", "-A", "-o", "%cpu=").Output()
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, field := range strings.Fields(string(output)) {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(field, ",", "."), 64)
+		if err == nil {
+			total += value
+		}
+	}
+
+	usage := total / float64(runtime.NumCPU())
+	if usage > 100 {
+		usage = 100
+	}
+	return usage, nil
+}
+
+// reclaimablePageCounts are the sysctls of the pages macOS hands out again under memory pressure,
+// which Activity Monitor doesn't count as used either: speculative read-ahead, purgeable, and
+// file-backed pages, which hold the page cache of the inactive list as well as the active one
+var reclaimablePageCounts = []string{
+	"vm.page_speculative_count", "vm.page_purgeable_count", "vm.page_pageable_external_count",
+}
+
+// systemMemory reports physical memory from hw.memsize, counting free and reclaimable pages as
+// available as Linux's MemAvailable does. Counters the running version lacks are skipped.
+func systemMemory() (used, total int64, err error) {
+	memsize, err := unix.SysctlUint64("hw.memsize")
+	if err != nil {
+		return 0, 0, err
+	}
+	freePages, err := unix.SysctlUint32("vm.page_free_count")
+	if err != nil {
+		return 0, 0, err
+	}
+	availablePages := int64(freePages)
+	for _, name := range reclaimablePageCounts {
+		if pages, countErr := unix.SysctlUint32(name); countErr == nil {
+			availablePages += int64(pages)
+		}
+	}
+
+	total = int64(memsize)                                            //#nosec G115 // Physical memory fits in int64
+	available := min(availablePages*int64(unix.Getpagesize()), total) //#nosec G115 // Page counts fit in int64
+	return total - available, total, nil
+}
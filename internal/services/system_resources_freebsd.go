@@ -0,0 +1,66 @@
+//go:build freebsd
+// +build freebsd
+
+package services
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// systemCPUUsage samples the kern.cp_time tick counters: user, nice, system, interrupt and idle
+func systemCPUUsage(sampler *cpuSampler) (float64, error) {
+	return sampler.usage(func() (uint64, uint64, error) {
+		raw, err := unix.SysctlRaw("kern.cp_time")
+		if err != nil {
+			return 0, 0, err
+		}
+
+		longSize := strconv.IntSize / 8
+		if len(raw) < 5*longSize {
+			return 0, 0, fmt.Errorf("unexpected kern.cp_time size %d", len(raw))
+		}
+
+		var idle, total uint64
+		for i := 0; i < 5; i++ {
+			value := readNativeLong(raw[i*longSize : (i+1)*longSize])
+			total += value
+			if i == 4 {
+				idle = value
+			}
+		}
+		return idle, total, nil
+	})
+}
+
+// reclaimablePageCounts are the sysctls of the page queues FreeBSD reclaims under memory
+// pressure: inactive pages, the cache queue of releases before 12, and the laundry queue of dirty
+// pages being written back since 12
+var reclaimablePageCounts = []string{
+	"vm.stats.vm.v_inactive_count", "vm.stats.vm.v_cache_count", "vm.stats.vm.v_laundry_count",
+}
+
+// systemMemory reports physical memory from hw.physmem, counting free and reclaimable pages as
+// available as Linux's MemAvailable does. Counters the running release lacks are skipped.
+func systemMemory() (used, total int64, err error) {
+	physmem, err := unix.SysctlUint64("hw.physmem")
+	if err != nil {
+		return 0, 0, err
+	}
+	freePages, err := unix.SysctlUint32("vm.stats.vm.v_free_count")
+	if err != nil {
+		return 0, 0, err
+	}
+	availablePages := int64(freePages)
+	for _, name := range reclaimablePageCounts {
+		if pages, countErr := unix.SysctlUint32(name); countErr == nil {
+			availablePages += int64(pages)
+		}
+	}
+
+	total = int64(physmem)                                            //#nosec G115 // Physical memory fits in int64
+	available := min(availablePages*int64(unix.Getpagesize()), total) //#nosec G115 // Page counts fit in int64
+	return total - available, total, nil
+}
@@ -0,0 +1,130 @@
+//go:build linux
+// +build linux
+
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const procDir = "/proc"
+
+// systemCPUUsage samples the aggregate CPU counters in /proc/stat
+func systemCPUUsage(sampler *cpuSampler) (float64, error) {
+	return sampler.usage(func() (uint64, uint64, error) {
+		data, err := os.ReadFile(procDir + "/stat")
+		if err != nil {
+			return 0, 0, err
+		}
+		return parseProcStat(data)
+	})
+}
+
+// parseProcStat reads the idle and total jiffies from the aggregate cpu line of /proc/stat.
+// iowait counts as idle; guest time is already included in user time and is skipped.
+func parseProcStat(data []byte) (idle, total uint64, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+
+		// user nice system idle iowait irq softirq steal guest guest_nice
+		for i, field := range fields[1:] {
+			if i >= 8 {
+				break
+			}
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid cpu counter %q: %w", field, err)
+			}
+			total += value
+			if i == 3 || i == 4 {
+				idle += value
+			}
+		}
+		return idle, total, nil
+	}
+	return 0, 0, fmt.Errorf("no cpu line in /proc/stat")
+}
+
+// systemMemory reads physical memory from /proc/meminfo
+func systemMemory() (used, total int64, err error) {
+	data, err := os.ReadFile(procDir + "/meminfo")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseMeminfo(data)
+}
+
+// parseMeminfo returns used and total bytes, treating MemAvailable as free. Kernels older than
+// 3.14 lack MemAvailable, so free, buffers and cache are summed instead.
+func parseMeminfo(data []byte) (used, total int64, err error) {
+	values := make(map[string]int64)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[name] = kb * 1024
+	}
+
+	total, ok := values["MemTotal"]
+	if !ok || total <= 0 {
+		return 0, 0, fmt.Errorf("no MemTotal in /proc/meminfo")
+	}
+
+	available, ok := values["MemAvailable"]
+	if !ok {
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+	return total - available, total, nil
+}
+
+// systemUptime reads the seconds since boot from /proc/uptime
+func systemUptime() (time.Duration, error) {
+	seconds, err := readProcFloat(procDir + "/uptime")
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// systemLoadAverage reads the one minute load average from /proc/loadavg
+func systemLoadAverage() (float64, error) {
+	return readProcFloat(procDir + "/loadavg")
+}
+
+// readProcFloat parses the first field of a single line /proc file
+func readProcFloat(path string) (float64, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- Fixed /proc paths
+	if err != nil {
+		return 0, err
+	}
+	return parseFirstFloat(data)
+}
+
+// parseFirstFloat parses the first whitespace separated field of data
+func parseFirstFloat(data []byte) (float64, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty value")
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcStat(t *testing.T) {
+	data := []byte("cpu  100 10 50 800 40 5 5 0 30 0\ncpu0 50 5 25 400 20 2 3 0 15 0\nintr 12345\n")
+
+	idle, total, err := parseProcStat(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(840), idle, "iowait counts as idle")
+	assert.Equal(t, uint64(1010), total, "guest time is not counted twice")
+
+	_, _, err = parseProcStat([]byte("intr 12345\n"))
+	assert.Error(t, err)
+}
+
+func TestParseMeminfo(t *testing.T) {
+	used, total, err := parseMeminfo([]byte("MemTotal:       16000 kB\nMemFree:         2000 kB\nMemAvailable:    6000 kB\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(16000*1024), total)
+	assert.Equal(t, int64(10000*1024), used)
+
+	used, _, err = parseMeminfo([]byte("MemTotal: 16000 kB\nMemFree: 2000 kB\nBuffers: 1000 kB\nCached: 3000 kB\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000*1024), used, "older kernels fall back to free, buffers and cache")
+
+	_, _, err = parseMeminfo([]byte("MemFree: 2000 kB\n"))
+	assert.Error(t, err)
+}
+
+func TestParseFirstFloat(t *testing.T) {
+	load, err := parseFirstFloat([]byte("0.52 0.58 0.59 1/467 12345\n"))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.52, load, 0.001)
+
+	_, err = parseFirstFloat(nil)
+	assert.Error(t, err)
+}
+
+func TestSystemResourceChecker_Linux(t *testing.T) {
+	checker := NewSystemResourceChecker()
+
+	cpu, err := checker.GetCPUUsage()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, cpu, 0.0)
+	assert.LessOrEqual(t, cpu, 100.0)
+
+	used, total, err := checker.GetMemoryUsage()
+	require.NoError(t, err)
+	assert.Positive(t, total)
+	assert.LessOrEqual(t, used, total)
+
+	uptime, err := checker.GetSystemUptime()
+	require.NoError(t, err)
+	assert.Positive(t, uptime)
+
+	_, err = checker.GetLoadAverage()
+	assert.NoError(t, err)
+}
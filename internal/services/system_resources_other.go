@@ -0,0 +1,26 @@
+//go:build !linux && !darwin && !freebsd && !windows
+// +build !linux,!darwin,!freebsd,!windows
+
+package services
+
+import "time"
+
+// systemCPUUsage is not implemented on this platform
+func systemCPUUsage(_ *cpuSampler) (float64, error) {
+	return 0, errSystemStatUnsupported
+}
+
+// systemMemory is not implemented on this platform
+func systemMemory() (used, total int64, err error) {
+	return 0, 0, errSystemStatUnsupported
+}
+
+// systemUptime is not implemented on this platform
+func systemUptime() (time.Duration, error) {
+	return 0, errSystemStatUnsupported
+}
+
+// systemLoadAverage is not implemented on this platform
+func systemLoadAverage() (float64, error) {
+	return 0, errSystemStatUnsupported
+}
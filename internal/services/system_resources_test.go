@@ -0,0 +1,51 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUBusyPercent(t *testing.T) {
+	assert.InDelta(t, 75, cpuBusyPercent(100, 1000, 150, 1200), 0.001)
+	assert.InDelta(t, 0, cpuBusyPercent(100, 1000, 300, 1200), 0.001)
+	assert.Zero(t, cpuBusyPercent(100, 1000, 100, 1000), "no elapsed time")
+}
+
+func TestCPUSampler_Usage(t *testing.T) {
+	readings := [][2]uint64{{0, 100}, {50, 200}, {60, 300}}
+	read := func() (uint64, uint64, error) {
+		r := readings[0]
+		readings = readings[1:]
+		return r[0], r[1], nil
+	}
+
+	var sampler cpuSampler
+	usage, err := sampler.usage(read)
+	require.NoError(t, err)
+	assert.InDelta(t, 50, usage, 0.001, "the first call samples twice")
+
+	usage, err = sampler.usage(read)
+	require.NoError(t, err)
+	assert.InDelta(t, 90, usage, 0.001, "later calls measure since the previous reading")
+}
+
+func TestSystemResourceChecker_IsPathAccessible(t *testing.T) {
+	checker := NewSystemResourceChecker()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "movie.mkv")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0o600))
+
+	assert.True(t, checker.IsPathAccessible(dir))
+	assert.True(t, checker.IsPathAccessible(file))
+	assert.False(t, checker.IsPathAccessible(filepath.Join(dir, "missing")))
+	assert.False(t, checker.IsPathAccessible(""))
+
+	used, total, err := checker.GetDiskUsage(dir)
+	require.NoError(t, err)
+	assert.Positive(t, total)
+	assert.LessOrEqual(t, used, total)
+}
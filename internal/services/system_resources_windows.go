@@ -0,0 +1,75 @@
+//go:build windows
+// +build windows
+
+package services
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procGetSystemTimes       = kernel32.NewProc("GetSystemTimes")
+	procGlobalMemoryStatusEx = kernel32.NewProc("GlobalMemoryStatusEx")
+	procGetTickCount64       = kernel32.NewProc("GetTickCount64")
+)
+
+// memoryStatusEx mirrors the Win32 MEMORYSTATUSEX structure
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// systemCPUUsage samples GetSystemTimes, whose kernel time includes idle time
+func systemCPUUsage(sampler *cpuSampler) (float64, error) {
+	return sampler.usage(func() (uint64, uint64, error) {
+		var idle, kernel, user syscall.Filetime
+		r1, _, err := procGetSystemTimes.Call(
+			uintptr(unsafe.Pointer(&idle)),
+			uintptr(unsafe.Pointer(&kernel)),
+			uintptr(unsafe.Pointer(&user)),
+		)
+		if r1 == 0 {
+			return 0, 0, err
+		}
+		return filetimeTicks(idle), filetimeTicks(kernel) + filetimeTicks(user), nil
+	})
+}
+
+// filetimeTicks returns a FILETIME as a count of 100ns intervals
+func filetimeTicks(ft syscall.Filetime) uint64 {
+	return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+}
+
+// systemMemory reports physical memory from GlobalMemoryStatusEx
+func systemMemory() (used, total int64, err error) {
+	status := memoryStatusEx{}
+	status.Length = uint32(unsafe.Sizeof(status))
+
+	r1, _, callErr := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if r1 == 0 {
+		return 0, 0, callErr
+	}
+
+	total = int64(status.TotalPhys)                    //#nosec G115 // Physical memory fits in int64
+	return total - int64(status.AvailPhys), total, nil //#nosec G115 // Physical memory fits in int64
+}
+
+// systemUptime reads the milliseconds since boot from GetTickCount64
+func systemUptime() (time.Duration, error) {
+	ms, _, _ := procGetTickCount64.Call()
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// systemLoadAverage is unavailable because Windows has no load average
+func systemLoadAverage() (float64, error) {
+	return 0, errSystemStatUnsupported
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/radarr/radarr-go/internal/models"
+	"gorm.io/gorm"
 )
 
 // RefreshMovieHandler handles refreshing metadata for a single movie
@@ -137,7 +138,7 @@ func NewRefreshAllMoviesHandler(
 
 // Execute refreshes metadata for all movies
 func (h *RefreshAllMoviesHandler) Execute(
-	ctx context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
 ) error {
 	updateProgress(0, "Starting bulk movie refresh")
 
@@ -153,8 +154,9 @@ func (h *RefreshAllMoviesHandler) Execute(
 
 	updateProgress(10, fmt.Sprintf("Found %d movies to refresh", len(movies)))
 
-	processed := h.processMoviesInBatches(ctx, movies, updateProgress)
+	processed, refreshed := h.refreshMovies(ctx, movies, updateProgress)
 
+	task.Result = models.JSONField{"moviesRefreshed": refreshed, "moviesFailed": processed - refreshed}
 	updateProgress(100, fmt.Sprintf("Completed refreshing %d movies", processed))
 	return nil
 }
@@ -168,63 +170,33 @@ func (h *RefreshAllMoviesHandler) getAllMoviesForRefresh() ([]models.Movie, erro
 	return movies, nil
 }
 
-// processMoviesInBatches processes movies in batches to refresh their metadata
-func (h *RefreshAllMoviesHandler) processMoviesInBatches(
+// refreshMovies refreshes the metadata of each movie in turn, returning how many were processed
+// and how many of those were refreshed. Requests are paced by the TMDB client's shared rate limit.
+func (h *RefreshAllMoviesHandler) refreshMovies(
 	ctx context.Context, movies []models.Movie,
 	updateProgress func(percent int, message string),
-) int {
-	processed := 0
-	batchSize := 10
+) (int, int) {
+	processed, refreshed := 0, 0
 
-	for i := 0; i < len(movies); i += batchSize {
+	for _, movie := range movies {
 		if ctx.Err() != nil {
 			break
 		}
 
-		batch := h.getBatch(movies, i, batchSize)
-		processed += h.processBatch(ctx, batch, movies, processed, updateProgress)
-
-		// Small delay between batches to avoid overwhelming TMDB API
-		time.Sleep(1 * time.Second)
-	}
-
-	return processed
-}
-
-// getBatch extracts a batch of movies from the full list
-func (h *RefreshAllMoviesHandler) getBatch(movies []models.Movie, start, batchSize int) []models.Movie {
-	end := start + batchSize
-	if end > len(movies) {
-		end = len(movies)
-	}
-	return movies[start:end]
-}
-
-// processBatch processes a single batch of movies
-func (h *RefreshAllMoviesHandler) processBatch(
-	ctx context.Context, batch []models.Movie, allMovies []models.Movie,
-	startProcessed int, updateProgress func(percent int, message string),
-) int {
-	batchProcessed := 0
-
-	for _, movie := range batch {
-		if ctx.Err() != nil {
-			break
+		if h.processMovie(movie, movies, processed, updateProgress) {
+			refreshed++
 		}
-
-		processed := startProcessed + batchProcessed
-		h.processMovie(movie, allMovies, processed, updateProgress)
-		batchProcessed++
+		processed++
 	}
 
-	return batchProcessed
+	return processed, refreshed
 }
 
-// processMovie processes a single movie for metadata refresh
+// processMovie processes a single movie for metadata refresh, reporting whether it was refreshed
 func (h *RefreshAllMoviesHandler) processMovie(
 	movie models.Movie, allMovies []models.Movie, processed int,
 	updateProgress func(percent int, message string),
-) {
+) bool {
 	updateProgress(10+((processed*80)/len(allMovies)),
 		fmt.Sprintf("Refreshing movie: %s (%d/%d)", movie.Title, processed+1, len(allMovies)))
 
@@ -232,12 +204,14 @@ func (h *RefreshAllMoviesHandler) processMovie(
 		// Log error but continue with other movies
 		updateProgress(10+((processed*80)/len(allMovies)),
 			fmt.Sprintf("Failed to refresh movie: %s - %v", movie.Title, err))
-	} else {
-		if err := h.movieService.Update(&movie); err != nil {
-			updateProgress(10+((processed*80)/len(allMovies)),
-				fmt.Sprintf("Failed to save movie: %s - %v", movie.Title, err))
-		}
+		return false
+	}
+	if err := h.movieService.Update(&movie); err != nil {
+		updateProgress(10+((processed*80)/len(allMovies)),
+			fmt.Sprintf("Failed to save movie: %s - %v", movie.Title, err))
+		return false
 	}
+	return true
 }
 
 // GetName returns the command name this handler processes
@@ -289,6 +263,7 @@ func (h *SyncImportListHandler) Execute(
 
 	totalAdded := h.syncAllImportLists(ctx, importLists, updateProgress)
 
+	task.Result = models.JSONField{"moviesAdded": totalAdded}
 	updateProgress(100, fmt.Sprintf("Import list sync completed. Added %d movies total", totalAdded))
 	return nil
 }
@@ -554,22 +529,36 @@ func NewCleanupHandler(container *Container) *CleanupHandler {
 	}
 }
 
-// Execute performs cleanup tasks
+// Execute performs cleanup tasks and records the number of rows each step removed in the task result.
+// With simulate set in the command body nothing is deleted, and the result holds what would be.
 func (h *CleanupHandler) Execute(
-	ctx context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
 ) error {
+	ctx, simulate := simulateTask(ctx, task)
+	verb := "removed"
+	if simulate {
+		verb = "would be removed"
+	}
 	updateProgress(0, "Starting cleanup")
 
 	cleanupTasks := []struct {
 		name string
-		fn   func(ctx context.Context) error
+		key  string
+		fn   func(ctx context.Context) (int64, error)
 	}{
-		{"Completed Tasks", h.cleanupCompletedTasks},
-		{"Old History Records", h.cleanupOldHistory},
-		{"Failed Downloads", h.cleanupFailedDownloads},
-		{"Orphaned Files", h.cleanupOrphanedFiles},
+		{"Completed Tasks", "completedTasks", h.cleanupCompletedTasks},
+		{"Old Releases", "releases", h.cleanupOldReleases},
+		{"Old History Records", "history", h.cleanupOldHistory},
+		{"Removed Seeding Torrents", "seedingTorrents", h.cleanupRemovedSeedingTorrents},
+		{"Expired Movie Tombstones", "movieTombstones", h.cleanupExpiredTombstones},
+		{"Expired Idempotency Keys", "idempotencyKeys", h.cleanupExpiredIdempotencyKeys},
+		{"Failed Downloads", "failedDownloads", h.cleanupFailedDownloads},
+		{"Orphaned Files", "orphanedFiles", h.cleanupOrphanedFiles},
 	}
 
+	removed := models.JSONField{}
+	var total int64
+
 	for i, cleanupTask := range cleanupTasks {
 		select {
 		case <-ctx.Done():
@@ -580,14 +569,22 @@ func (h *CleanupHandler) Execute(
 		progress := (i * 100) / len(cleanupTasks)
 		updateProgress(progress, fmt.Sprintf("Running cleanup: %s", cleanupTask.name))
 
-		if err := cleanupTask.fn(ctx); err != nil {
+		count, err := cleanupTask.fn(ctx)
+		if err != nil {
 			updateProgress(progress, fmt.Sprintf("Cleanup failed: %s - %v", cleanupTask.name, err))
-		} else {
-			updateProgress(progress, fmt.Sprintf("Cleanup completed: %s", cleanupTask.name))
+			continue
 		}
+
+		removed[cleanupTask.key] = count
+		total += count
+		updateProgress(progress, fmt.Sprintf("Cleanup completed: %s (%d %s)", cleanupTask.name, count, verb))
 	}
 
-	updateProgress(100, "Cleanup completed")
+	if task != nil {
+		task.Result = models.JSONField{"removed": removed, "totalRemoved": total, "simulated": simulate}
+	}
+
+	updateProgress(100, fmt.Sprintf("Cleanup completed: %d rows %s", total, verb))
 	return nil
 }
 
@@ -602,47 +599,86 @@ func (h *CleanupHandler) GetDescription() string {
 }
 
 // cleanupCompletedTasks removes old completed tasks
-func (h *CleanupHandler) cleanupCompletedTasks(_ context.Context) error {
+func (h *CleanupHandler) cleanupCompletedTasks(ctx context.Context) (int64, error) {
 	if h.container.DB == nil {
-		return fmt.Errorf("database not initialized")
+		return 0, fmt.Errorf("database not initialized")
 	}
 
 	// Remove completed tasks older than 7 days
 	cutoff := time.Now().AddDate(0, 0, -7)
 
-	result := h.container.DB.GORM.Where("status IN (?, ?, ?) AND ended_at < ?",
-		"completed", "failed", "aborted", cutoff).
-		Delete(&models.TaskV2{})
-
-	if result.Error != nil {
-		return fmt.Errorf("failed to cleanup completed tasks: %w", result.Error)
+	removed, err := prune(ctx, h.container.DB.GORM, func(tx *gorm.DB) (int64, error) {
+		result := tx.Where("status IN (?, ?, ?) AND ended_at < ?", "completed", "failed", "aborted", cutoff).
+			Delete(&models.TaskV2{})
+		return result.RowsAffected, result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup completed tasks: %w", err)
 	}
 
 	if h.container.Logger != nil {
-		h.container.Logger.Infow("Cleaned up completed tasks", "count", result.RowsAffected)
+		h.container.Logger.Infow("Cleaned up completed tasks", "count", removed, "simulated", Simulating(ctx))
 	}
-	return nil
+	return removed, nil
 }
 
-// cleanupOldHistory removes old history records
-func (h *CleanupHandler) cleanupOldHistory(_ context.Context) error {
-	// This would implement history cleanup
-	// For now, just return success
-	return nil
+// cleanupOldReleases applies the release retention policy
+func (h *CleanupHandler) cleanupOldReleases(ctx context.Context) (int64, error) {
+	if h.container.RetentionService == nil {
+		return 0, fmt.Errorf("retention service not initialized")
+	}
+
+	return h.container.RetentionService.PruneReleases(ctx)
+}
+
+// cleanupOldHistory applies the history retention policy
+func (h *CleanupHandler) cleanupOldHistory(ctx context.Context) (int64, error) {
+	if h.container.RetentionService == nil {
+		return 0, fmt.Errorf("retention service not initialized")
+	}
+
+	return h.container.RetentionService.PruneHistory(ctx)
+}
+
+// cleanupRemovedSeedingTorrents deletes torrents removed after seeding past the retired row age
+func (h *CleanupHandler) cleanupRemovedSeedingTorrents(ctx context.Context) (int64, error) {
+	if h.container.RetentionService == nil {
+		return 0, fmt.Errorf("retention service not initialized")
+	}
+
+	return h.container.RetentionService.PruneRemovedSeedingTorrents(ctx)
+}
+
+// cleanupExpiredTombstones deletes movie tombstones that no longer keep movies from list syncs
+func (h *CleanupHandler) cleanupExpiredTombstones(ctx context.Context) (int64, error) {
+	if h.container.RetentionService == nil {
+		return 0, fmt.Errorf("retention service not initialized")
+	}
+
+	return h.container.RetentionService.PruneExpiredTombstones(ctx)
+}
+
+// cleanupExpiredIdempotencyKeys deletes idempotency keys past the time their responses are replayed
+func (h *CleanupHandler) cleanupExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	if h.container.IdempotencyService == nil {
+		return 0, fmt.Errorf("idempotency service not initialized")
+	}
+
+	return h.container.IdempotencyService.PruneExpired(ctx)
 }
 
 // cleanupFailedDownloads removes failed downloads
-func (h *CleanupHandler) cleanupFailedDownloads(_ context.Context) error {
+func (h *CleanupHandler) cleanupFailedDownloads(_ context.Context) (int64, error) {
 	// This would implement failed download cleanup
 	// For now, just return success
-	return nil
+	return 0, nil
 }
 
 // cleanupOrphanedFiles removes orphaned files
-func (h *CleanupHandler) cleanupOrphanedFiles(_ context.Context) error {
+func (h *CleanupHandler) cleanupOrphanedFiles(_ context.Context) (int64, error) {
 	// This would implement orphaned file cleanup
 	// For now, just return success
-	return nil
+	return 0, nil
 }
 
 // RefreshWantedMoviesHandler handles refreshing the wanted movies list
@@ -655,6 +691,7 @@ type WantedMoviesServiceInterface interface {
 	RefreshWantedMovies() error
 	GetWantedStats() (*models.WantedMoviesStats, error)
 	GetEligibleForSearch(limit int) ([]models.WantedMovie, error)
+	GetSearchCandidates(statuses ...models.WantedStatus) ([]models.WantedMovie, error)
 	UpdateSearchAttempt(id int, success bool, reason, indexer, errorCode string) error
 }
 
@@ -667,7 +704,7 @@ func NewRefreshWantedMoviesHandler(wantedService WantedMoviesServiceInterface) *
 
 // Execute refreshes the wanted movies list
 func (h *RefreshWantedMoviesHandler) Execute(
-	_ context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+	_ context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
 ) error {
 	updateProgress(0, "Starting wanted movies refresh")
 
@@ -685,6 +722,7 @@ func (h *RefreshWantedMoviesHandler) Execute(
 		return nil // Don't fail the task if stats fail
 	}
 
+	task.Result = models.JSONField{"missing": stats.MissingCount, "cutoffUnmet": stats.CutoffUnmetCount}
 	updateProgress(100, fmt.Sprintf("Wanted movies refresh completed - Found %d missing, %d cutoff unmet",
 		stats.MissingCount, stats.CutoffUnmetCount))
 
@@ -725,7 +763,7 @@ func NewAutoWantedSearchHandler(
 
 // Execute performs automatic searching for eligible wanted movies
 func (h *AutoWantedSearchHandler) Execute(
-	ctx context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
 ) error {
 	updateProgress(0, "Getting eligible wanted movies")
 
@@ -781,6 +819,7 @@ func (h *AutoWantedSearchHandler) Execute(
 		time.Sleep(100 * time.Millisecond)
 	}
 
+	task.Result = models.JSONField{"searched": searchedCount, "successful": successCount}
 	updateProgress(100, fmt.Sprintf("Automatic search completed - %d searched, %d successful",
 		searchedCount, successCount))
 
@@ -796,3 +835,393 @@ func (h *AutoWantedSearchHandler) GetName() string {
 func (h *AutoWantedSearchHandler) GetDescription() string {
 	return "Automatically searches for wanted movies that are eligible for search"
 }
+
+// LibraryMaintenanceHandler generates the library maintenance report
+type LibraryMaintenanceHandler struct {
+	maintenanceService *LibraryMaintenanceService
+}
+
+// NewLibraryMaintenanceHandler creates a new library maintenance handler
+func NewLibraryMaintenanceHandler(maintenanceService *LibraryMaintenanceService) *LibraryMaintenanceHandler {
+	return &LibraryMaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// Execute scans the library for inconsistencies between the database and disk and stores the
+// report as the task result
+func (h *LibraryMaintenanceHandler) Execute(
+	_ context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Scanning library for inconsistencies")
+
+	report, err := h.maintenanceService.GenerateReport()
+	if err != nil {
+		return fmt.Errorf("failed to generate library maintenance report: %w", err)
+	}
+
+	if task != nil {
+		result, resultErr := reportTaskResult(report)
+		if resultErr != nil {
+			return fmt.Errorf("failed to store library maintenance report: %w", resultErr)
+		}
+		task.Result = result
+	}
+
+	updateProgress(100, fmt.Sprintf("Library maintenance scan completed - Found %d issues", report.TotalIssues))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *LibraryMaintenanceHandler) GetName() string {
+	return libraryMaintenanceCommand
+}
+
+// GetDescription returns a human-readable description
+func (h *LibraryMaintenanceHandler) GetDescription() string {
+	return "Reports orphaned files, missing files, and folders out of sync with the library"
+}
+
+// FingerprintDuplicatesHandler fingerprints the files of movies that have more than one
+type FingerprintDuplicatesHandler struct {
+	duplicateService *DuplicateService
+}
+
+// NewFingerprintDuplicatesHandler creates a new fingerprint duplicates handler
+func NewFingerprintDuplicatesHandler(duplicateService *DuplicateService) *FingerprintDuplicatesHandler {
+	return &FingerprintDuplicatesHandler{
+		duplicateService: duplicateService,
+	}
+}
+
+// Execute fingerprints the duplicate candidates not fingerprinted yet and records how many were
+// done in the task result
+func (h *FingerprintDuplicatesHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Fingerprinting duplicate movie files")
+
+	fingerprinted, err := h.duplicateService.FingerprintCandidates(ctx, func(done, total int) {
+		if total > 0 {
+			updateProgress(done*100/total, fmt.Sprintf("Fingerprinted %d of %d files", done, total))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fingerprint duplicate movie files: %w", err)
+	}
+
+	if task != nil {
+		task.Result = models.JSONField{"fingerprinted": fingerprinted}
+	}
+	updateProgress(100, fmt.Sprintf("Fingerprinted %d files", fingerprinted))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *FingerprintDuplicatesHandler) GetName() string {
+	return "FingerprintDuplicates"
+}
+
+// GetDescription returns a human-readable description
+func (h *FingerprintDuplicatesHandler) GetDescription() string {
+	return "Fingerprints movie files that may be duplicates so the copies can be compared"
+}
+
+// ProcessQueuedImportsHandler imports the files queued while imports were paused
+type ProcessQueuedImportsHandler struct {
+	importService *ImportService
+}
+
+// NewProcessQueuedImportsHandler creates a new process queued imports handler
+func NewProcessQueuedImportsHandler(importService *ImportService) *ProcessQueuedImportsHandler {
+	return &ProcessQueuedImportsHandler{
+		importService: importService,
+	}
+}
+
+// Execute imports the pending file organizations, highest priority first, and records how many
+// were imported in the task result
+func (h *ProcessQueuedImportsHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Importing queued files")
+
+	imported, err := h.importService.ProcessPendingOrganizations(ctx, func(done, total int) {
+		if total > 0 {
+			updateProgress(done*100/total, fmt.Sprintf("Processed %d of %d queued files", done, total))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import queued files: %w", err)
+	}
+
+	if task != nil {
+		task.Result = models.JSONField{"imported": imported}
+	}
+	updateProgress(100, fmt.Sprintf("Imported %d queued files", imported))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *ProcessQueuedImportsHandler) GetName() string {
+	return "ProcessQueuedImports"
+}
+
+// GetDescription returns a human-readable description
+func (h *ProcessQueuedImportsHandler) GetDescription() string {
+	return "Imports the files queued while imports were paused"
+}
+
+// RecordLibraryStatsHandler records the daily library statistics snapshot
+type RecordLibraryStatsHandler struct {
+	libraryStatsService *LibraryStatsService
+}
+
+// NewRecordLibraryStatsHandler creates a new record library stats handler
+func NewRecordLibraryStatsHandler(libraryStatsService *LibraryStatsService) *RecordLibraryStatsHandler {
+	return &RecordLibraryStatsHandler{
+		libraryStatsService: libraryStatsService,
+	}
+}
+
+// Execute records today's library snapshot
+func (h *RecordLibraryStatsHandler) Execute(
+	_ context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Recording library statistics")
+
+	snapshot, err := h.libraryStatsService.RecordSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to record library statistics: %w", err)
+	}
+
+	updateProgress(100, fmt.Sprintf("Recorded library statistics for %d movies", snapshot.MovieCount))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *RecordLibraryStatsHandler) GetName() string {
+	return "RecordLibraryStats"
+}
+
+// GetDescription returns a human-readable description
+func (h *RecordLibraryStatsHandler) GetDescription() string {
+	return "Records the daily snapshot of library size, movie counts and quality distribution"
+}
+
+// FlushNotificationsHandler delivers notifications queued for quiet hours or digests
+type FlushNotificationsHandler struct {
+	notificationService *NotificationService
+}
+
+// NewFlushNotificationsHandler creates a new flush notifications handler
+func NewFlushNotificationsHandler(notificationService *NotificationService) *FlushNotificationsHandler {
+	return &FlushNotificationsHandler{
+		notificationService: notificationService,
+	}
+}
+
+// Execute sends the queued notifications that are due
+func (h *FlushNotificationsHandler) Execute(
+	_ context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Sending queued notifications")
+
+	count, err := h.notificationService.FlushQueuedNotifications()
+	if err != nil {
+		return fmt.Errorf("failed to flush queued notifications: %w", err)
+	}
+
+	updateProgress(100, fmt.Sprintf("Sent %d queued notifications", count))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *FlushNotificationsHandler) GetName() string {
+	return "FlushNotifications"
+}
+
+// GetDescription returns a human-readable description
+func (h *FlushNotificationsHandler) GetDescription() string {
+	return "Delivers notifications held for quiet hours or combined into digests"
+}
+
+// RefreshSceneMappingsHandler refreshes scene mappings from the configured remote list
+type RefreshSceneMappingsHandler struct {
+	sceneMappingService *SceneMappingService
+}
+
+// NewRefreshSceneMappingsHandler creates a new refresh scene mappings handler
+func NewRefreshSceneMappingsHandler(sceneMappingService *SceneMappingService) *RefreshSceneMappingsHandler {
+	return &RefreshSceneMappingsHandler{
+		sceneMappingService: sceneMappingService,
+	}
+}
+
+// Execute replaces the remote scene mappings with the current list
+func (h *RefreshSceneMappingsHandler) Execute(
+	ctx context.Context, _ *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Refreshing scene mappings")
+
+	count, err := h.sceneMappingService.Refresh(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh scene mappings: %w", err)
+	}
+
+	updateProgress(100, fmt.Sprintf("Loaded %d remote scene mappings", count))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *RefreshSceneMappingsHandler) GetName() string {
+	return "RefreshSceneMappings"
+}
+
+// GetDescription returns a human-readable description
+func (h *RefreshSceneMappingsHandler) GetDescription() string {
+	return "Refreshes release titles mapped to movies from the configured scene mapping list"
+}
+
+// CleanupSeededTorrentsHandler removes imported torrents that met their seed goals from their clients
+type CleanupSeededTorrentsHandler struct {
+	seedingService *SeedingService
+}
+
+// NewCleanupSeededTorrentsHandler creates a new cleanup seeded torrents handler
+func NewCleanupSeededTorrentsHandler(seedingService *SeedingService) *CleanupSeededTorrentsHandler {
+	return &CleanupSeededTorrentsHandler{
+		seedingService: seedingService,
+	}
+}
+
+// Execute checks the seeding torrents and removes those that have seeded enough. With simulate set
+// in the command body the torrents are only checked, and the result lists those that would be removed.
+func (h *CleanupSeededTorrentsHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	ctx, simulate := simulateTask(ctx, task)
+	updateProgress(0, "Checking seeding torrents")
+
+	result, err := h.seedingService.ProcessSeeding(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clean up seeded torrents: %w", err)
+	}
+
+	if task != nil {
+		task.Result = models.JSONField{
+			"checked": result.Checked, "removed": result.Removed, "missing": result.Missing,
+			"removedTitles": result.RemovedTitles, "simulated": simulate,
+		}
+	}
+	if simulate {
+		updateProgress(100, fmt.Sprintf("Checked %d seeding torrents, %d would be removed", result.Checked,
+			result.Removed))
+		return nil
+	}
+
+	updateProgress(100, fmt.Sprintf("Checked %d seeding torrents, removed %d", result.Checked, result.Removed))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *CleanupSeededTorrentsHandler) GetName() string {
+	return "CleanupSeededTorrents"
+}
+
+// GetDescription returns a human-readable description
+func (h *CleanupSeededTorrentsHandler) GetDescription() string {
+	return "Removes imported torrents from download clients once their seed ratio or time goal is met"
+}
+
+// ScanCompletedDownloadFoldersHandler imports finished downloads from the completed download
+// folders of clients without an API to track them through
+type ScanCompletedDownloadFoldersHandler struct {
+	completedFolderService *CompletedFolderService
+}
+
+// NewScanCompletedDownloadFoldersHandler creates a new scan completed download folders handler
+func NewScanCompletedDownloadFoldersHandler(
+	completedFolderService *CompletedFolderService,
+) *ScanCompletedDownloadFoldersHandler {
+	return &ScanCompletedDownloadFoldersHandler{
+		completedFolderService: completedFolderService,
+	}
+}
+
+// Execute scans the folders due a scan, or every folder with force set in the command body, and
+// records what was found in the task result
+func (h *ScanCompletedDownloadFoldersHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	force := false
+	if task != nil {
+		force, _ = task.Body["force"].(bool)
+	}
+	updateProgress(0, "Scanning completed download folders")
+
+	result, err := h.completedFolderService.Scan(ctx, force)
+	if err != nil {
+		return fmt.Errorf("failed to scan completed download folders: %w", err)
+	}
+
+	if task != nil {
+		task.Result = models.JSONField{
+			"clients": result.Clients, "scanned": result.Scanned, "matched": result.Matched,
+			"imported": result.Imported, "unmatched": result.Unmatched,
+		}
+	}
+	updateProgress(100, fmt.Sprintf("Scanned %d completed downloads, imported %d", result.Scanned, result.Imported))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *ScanCompletedDownloadFoldersHandler) GetName() string {
+	return "ScanCompletedDownloadFolders"
+}
+
+// GetDescription returns a human-readable description
+func (h *ScanCompletedDownloadFoldersHandler) GetDescription() string {
+	return "Imports finished downloads from the completed download folders of watch folder based clients"
+}
+
+// CheckCompletedDownloadsHandler records why completed downloads can't be imported
+type CheckCompletedDownloadsHandler struct {
+	queueService *QueueService
+}
+
+// NewCheckCompletedDownloadsHandler creates a new check completed downloads handler
+func NewCheckCompletedDownloadsHandler(queueService *QueueService) *CheckCompletedDownloadsHandler {
+	return &CheckCompletedDownloadsHandler{
+		queueService: queueService,
+	}
+}
+
+// Execute runs the import checks on the completed downloads in the queue and updates their status
+// messages
+func (h *CheckCompletedDownloadsHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Checking completed downloads")
+
+	withMessages, err := h.queueService.CheckCompletedDownloads(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check completed downloads: %w", err)
+	}
+
+	if task != nil {
+		task.Result = models.JSONField{"withMessages": withMessages}
+	}
+	updateProgress(100, fmt.Sprintf("Checked completed downloads, %d need attention", withMessages))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *CheckCompletedDownloadsHandler) GetName() string {
+	return "CheckCompletedDownloads"
+}
+
+// GetDescription returns a human-readable description
+func (h *CheckCompletedDownloadsHandler) GetDescription() string {
+	return "Runs the import checks on completed downloads and explains in the queue why they can't be imported"
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	defaultTaskHistoryDays  = 30
+	defaultTaskHistoryLimit = 50
+)
+
+// recordRun keeps a finished task in the run history. Failing to record it only loses history, so
+// errors are logged.
+func (ts *TaskService) recordRun(
+	task *models.TaskV2, outcome models.TaskStatus, startTime, endTime time.Time, errorMessage string,
+) {
+	run := &models.TaskRun{
+		TaskID:       task.ID,
+		Name:         task.Name,
+		CommandName:  task.CommandName,
+		Outcome:      outcome,
+		StartedAt:    startTime,
+		EndedAt:      endTime,
+		DurationMs:   endTime.Sub(startTime).Milliseconds(),
+		Metrics:      task.Result,
+		ErrorMessage: errorMessage,
+	}
+	if err := ts.db.GORM.Create(run).Error; err != nil {
+		ts.logger.Errorw("Failed to record task run", "taskId", task.ID, "command", task.CommandName, "error", err)
+	}
+}
+
+// GetTaskHistory returns the runs of a command started in the last days, summarized overall and by
+// day, with up to limit of the most recent runs. name is a command name, or the name or ID of the
+// scheduled task running it.
+func (ts *TaskService) GetTaskHistory(name string, days, limit int) (*models.TaskRunHistory, error) {
+	if days <= 0 {
+		days = defaultTaskHistoryDays
+	}
+	if limit <= 0 {
+		limit = defaultTaskHistoryLimit
+	}
+
+	commandName := ts.commandNameFor(name)
+	since := time.Now().AddDate(0, 0, -days)
+
+	var runs []models.TaskRun
+	if err := ts.db.GORM.Where("command_name = ? AND started_at >= ?", commandName, since).
+		Order("started_at DESC").Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get task runs: %w", err)
+	}
+
+	summary, daily := summarizeTaskRuns(runs)
+	history := &models.TaskRunHistory{
+		CommandName: commandName,
+		Days:        days,
+		Summary:     summary,
+		Daily:       daily,
+		Runs:        runs[:min(limit, len(runs))],
+	}
+	return history, nil
+}
+
+// commandNameFor resolves the name or ID of a scheduled task to its command, leaving command names
+// as they are
+func (ts *TaskService) commandNameFor(name string) string {
+	query := ts.db.GORM.Where("name = ?", name)
+	if id, err := strconv.Atoi(name); err == nil {
+		query = ts.db.GORM.Where("id = ?", id)
+	}
+
+	var scheduledTask models.ScheduledTaskV2
+	if err := query.First(&scheduledTask).Error; err != nil {
+		return name
+	}
+	return scheduledTask.CommandName
+}
+
+// summarizeTaskRuns aggregates runs, given most recent first, overall and by the local day they
+// started on, oldest day first
+func summarizeTaskRuns(runs []models.TaskRun) (models.TaskRunSummary, []models.TaskRunDay) {
+	var summary models.TaskRunSummary
+	daily := []models.TaskRunDay{}
+	durations := make(map[string]int64)
+	var totalDuration int64
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := &runs[i]
+		date := run.StartedAt.Local().Format("2006-01-02")
+		if len(daily) == 0 || daily[len(daily)-1].Date != date {
+			daily = append(daily, models.TaskRunDay{Date: date})
+		}
+		day := &daily[len(daily)-1]
+
+		day.Runs++
+		summary.TotalRuns++
+		switch run.Outcome {
+		case models.TaskStatusCompleted:
+			day.Completed++
+			summary.Completed++
+			summary.LastSuccess = &run.StartedAt
+		case models.TaskStatusFailed:
+			day.Failed++
+			summary.Failed++
+		default:
+			day.Aborted++
+			summary.Aborted++
+		}
+
+		durations[date] += run.DurationMs
+		totalDuration += run.DurationMs
+		summary.MaxDurationMs = max(summary.MaxDurationMs, run.DurationMs)
+		summary.LastRun = &run.StartedAt
+
+		for key, value := range run.Metrics {
+			if count, ok := metricValue(value); ok {
+				if day.Metrics == nil {
+					day.Metrics = make(map[string]float64)
+				}
+				day.Metrics[key] += count
+			}
+		}
+	}
+
+	for i := range daily {
+		daily[i].AverageDurationMs = durations[daily[i].Date] / int64(daily[i].Runs)
+	}
+	if summary.TotalRuns > 0 {
+		summary.AverageDurationMs = totalDuration / int64(summary.TotalRuns)
+		summary.SuccessRate = float64(summary.Completed) * 100 / float64(summary.TotalRuns)
+	}
+	return summary, daily
+}
+
+// metricValue returns a reported count as a number; results read back from the database hold
+// float64s, results of the current process may hold ints
+func metricValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
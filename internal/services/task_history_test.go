@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeTaskRuns(t *testing.T) {
+	today := time.Date(2026, 3, 2, 3, 0, 0, 0, time.Local)
+	yesterday := today.AddDate(0, 0, -1)
+
+	// Most recent first, as loaded; metrics read back from the database hold float64s
+	runs := []models.TaskRun{
+		{Outcome: models.TaskStatusFailed, StartedAt: today.Add(time.Hour), DurationMs: 500},
+		{Outcome: models.TaskStatusCompleted, StartedAt: today, DurationMs: 1500,
+			Metrics: models.JSONField{"moviesRefreshed": 12, "status": "ok"}},
+		{Outcome: models.TaskStatusCompleted, StartedAt: yesterday, DurationMs: 4000,
+			Metrics: models.JSONField{"moviesRefreshed": float64(10)}},
+	}
+
+	summary, daily := summarizeTaskRuns(runs)
+
+	assert.Equal(t, 3, summary.TotalRuns)
+	assert.Equal(t, 2, summary.Completed)
+	assert.Equal(t, 1, summary.Failed)
+	assert.InDelta(t, 66.67, summary.SuccessRate, 0.01)
+	assert.Equal(t, int64(2000), summary.AverageDurationMs)
+	assert.Equal(t, int64(4000), summary.MaxDurationMs)
+	assert.Equal(t, today.Add(time.Hour), *summary.LastRun)
+	assert.Equal(t, today, *summary.LastSuccess)
+
+	assert.Equal(t, []models.TaskRunDay{
+		{Date: "2026-03-01", Runs: 1, Completed: 1, AverageDurationMs: 4000,
+			Metrics: map[string]float64{"moviesRefreshed": 10}},
+		{Date: "2026-03-02", Runs: 2, Completed: 1, Failed: 1, AverageDurationMs: 1000,
+			Metrics: map[string]float64{"moviesRefreshed": 12}},
+	}, daily)
+
+	summary, daily = summarizeTaskRuns(nil)
+	assert.Zero(t, summary.TotalRuns)
+	assert.Empty(t, daily)
+}
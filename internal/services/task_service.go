@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/database"
@@ -18,6 +20,16 @@ import (
 // Task status constants
 const (
 	taskStatusCancelling = "cancelling"
+	taskStatusQueued     = "queued"
+)
+
+const (
+	// workerHeartbeatInterval is how often an idle worker records that it is still running
+	workerHeartbeatInterval = 15 * time.Second
+	// checkpointGracePeriod is how long cancelled tasks get to unwind after a drain times out
+	checkpointGracePeriod = 5 * time.Second
+	// taskWaitInterval is how often WaitForTask checks whether a task finished
+	taskWaitInterval = 250 * time.Millisecond
 )
 
 // TaskService provides task scheduling and management functionality
@@ -34,6 +46,21 @@ type TaskService struct {
 	// Lifecycle
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// stopIntake is closed by Drain so workers stop taking queued tasks
+	stopIntake chan struct{}
+	stopOnce   sync.Once
+	workerWG   sync.WaitGroup
+	// interrupted is set once Drain has checkpointed the running tasks for resume
+	interrupted atomic.Bool
+
+	// isLeader reports whether this instance runs scheduled tasks; nil means always
+	isLeader func() bool
+
+	// paused holds back queued and scheduled tasks; resume is closed when the pause ends
+	pauseMu sync.Mutex
+	paused  bool
+	resume  chan struct{}
 }
 
 // TaskHandler defines the interface for task execution handlers
@@ -55,6 +82,35 @@ type TaskWorkerPool struct {
 	active     map[int]*models.TaskV2
 	activeMu   sync.RWMutex
 	logger     *logger.Logger
+
+	heartbeats []*workerHeartbeat
+}
+
+// workerHeartbeat records when a worker last showed signs of life and what it is running
+type workerHeartbeat struct {
+	mu        sync.Mutex
+	lastBeat  time.Time
+	taskID    int
+	busySince time.Time
+}
+
+// beat records that the worker is alive
+func (h *workerHeartbeat) beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastBeat = time.Now()
+}
+
+// setTask records the task the worker is running, or 0 once it is idle again
+func (h *workerHeartbeat) setTask(taskID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastBeat = time.Now()
+	h.taskID = taskID
+	h.busySince = time.Time{}
+	if taskID != 0 {
+		h.busySince = h.lastBeat
+	}
 }
 
 // TaskScheduler manages recurring scheduled tasks
@@ -62,10 +118,20 @@ type TaskScheduler struct {
 	service *TaskService
 	ticker  *time.Ticker
 	logger  *logger.Logger
+
+	// mu keeps a warm-up pass and a tick from queueing the same due task twice
+	mu sync.Mutex
+	// lastTick is when the scheduler loop last ran, in Unix nanoseconds
+	lastTick atomic.Int64
 }
 
-// NewTaskService creates a new task service instance
-func NewTaskService(db *database.Database, logger *logger.Logger) *TaskService {
+// NewTaskService creates a new task service instance whose default pool runs up to workers tasks
+// at once; workers <= 0 uses the default pool size
+func NewTaskService(db *database.Database, workers int, logger *logger.Logger) *TaskService {
+	if workers <= 0 {
+		workers = defaultTaskWorkers
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	service := &TaskService{
@@ -75,6 +141,8 @@ func NewTaskService(db *database.Database, logger *logger.Logger) *TaskService {
 		handlers: make(map[string]TaskHandler),
 		ctx:      ctx,
 		cancel:   cancel,
+
+		stopIntake: make(chan struct{}),
 	}
 
 	// Initialize scheduler
@@ -83,10 +151,11 @@ func NewTaskService(db *database.Database, logger *logger.Logger) *TaskService {
 		ticker:  time.NewTicker(30 * time.Second), // Check for scheduled tasks every 30 seconds
 		logger:  logger,
 	}
+	service.scheduler.lastTick.Store(time.Now().UnixNano())
 
-	// Create default worker pools
-	service.createWorkerPool("default", 3)
-	service.createWorkerPool("high-priority", 2)
+	// Create default worker pools, with high-priority tasks given half as many workers
+	service.createWorkerPool("default", workers)
+	service.createWorkerPool("high-priority", (workers+1)/2)
 	service.createWorkerPool("background", 1)
 
 	// Start scheduler
@@ -104,6 +173,19 @@ func (ts *TaskService) RegisterHandler(handler TaskHandler) {
 	ts.logger.Infow("Registered task handler", "command", handler.GetName(), "description", handler.GetDescription())
 }
 
+// HandlerNames returns the commands tasks can be queued for, sorted
+func (ts *TaskService) HandlerNames() []string {
+	ts.executionMutex.RLock()
+	defer ts.executionMutex.RUnlock()
+
+	names := make([]string, 0, len(ts.handlers))
+	for name := range ts.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // QueueTask queues a new task for execution
 func (ts *TaskService) QueueTask(
 	name, commandName string,
@@ -115,27 +197,30 @@ func (ts *TaskService) QueueTask(
 		CommandName: commandName,
 		Body:        body,
 		Priority:    priority,
-		Status:      "queued",
+		Status:      taskStatusQueued,
 	}
 
 	if err := ts.db.GORM.Create(task).Error; err != nil {
 		return nil, fmt.Errorf("failed to queue task: %w", err)
 	}
 
-	// Add to appropriate worker pool
+	ts.dispatch(task)
+	return task, nil
+}
+
+// dispatch hands a queued task to its worker pool
+func (ts *TaskService) dispatch(task *models.TaskV2) {
 	poolName := ts.getPoolNameForTask(task)
 	if pool, exists := ts.workers[poolName]; exists {
 		select {
 		case pool.queue <- task:
 			ts.logger.Infow("Task queued for execution",
-				"taskId", task.ID, "command", commandName, "pool", poolName)
+				"taskId", task.ID, "command", task.CommandName, "pool", poolName)
 		default:
-			ts.logger.Warnw("Task queue full, task will be picked up by scheduler",
-				"taskId", task.ID, "command", commandName, "pool", poolName)
+			ts.logger.Warnw("Task queue full, task will be resumed on next start",
+				"taskId", task.ID, "command", task.CommandName, "pool", poolName)
 		}
 	}
-
-	return task, nil
 }
 
 // GetTask retrieves a task by ID
@@ -150,6 +235,29 @@ func (ts *TaskService) GetTask(id int) (*models.TaskV2, error) {
 	return &task, nil
 }
 
+// WaitForTask waits until a task completes, fails or is aborted and returns it as it finished
+func (ts *TaskService) WaitForTask(ctx context.Context, id int) (*models.TaskV2, error) {
+	ticker := time.NewTicker(taskWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		task, err := ts.GetTask(id)
+		if err != nil {
+			return nil, err
+		}
+		switch task.Status {
+		case "completed", "failed", "aborted":
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // ListTasks retrieves tasks with optional filtering
 func (ts *TaskService) ListTasks(
 	status string,
@@ -289,7 +397,10 @@ func (ts *TaskService) createWorkerPool(name string, maxWorkers int) {
 
 	// Start worker goroutines
 	for i := 0; i < maxWorkers; i++ {
-		go pool.worker(ts.ctx, ts)
+		heartbeat := &workerHeartbeat{lastBeat: time.Now()}
+		pool.heartbeats = append(pool.heartbeats, heartbeat)
+		ts.workerWG.Add(1)
+		go pool.worker(ts.ctx, ts, heartbeat)
 	}
 
 	ts.logger.Infow("Created task worker pool",
@@ -310,13 +421,32 @@ func (ts *TaskService) getPoolNameForTask(task *models.TaskV2) string {
 	}
 }
 
-// worker processes tasks from the queue
-func (pool *TaskWorkerPool) worker(ctx context.Context, service *TaskService) {
+// worker processes tasks from the queue until the service shuts down or drains, recording a
+// heartbeat while idle so the liveness probe can tell a stuck worker from a quiet one
+func (pool *TaskWorkerPool) worker(ctx context.Context, service *TaskService, heartbeat *workerHeartbeat) {
+	defer service.workerWG.Done()
+
+	ticker := time.NewTicker(workerHeartbeatInterval)
+	defer ticker.Stop()
+
 	for {
+		queue := pool.queue
+		paused, resumed := service.pauseState()
+		if paused {
+			queue = nil
+		}
+
 		select {
 		case <-ctx.Done():
 			return
-		case task := <-pool.queue:
+		case <-service.stopIntake:
+			return
+		case <-ticker.C:
+			heartbeat.beat()
+		case <-resumed:
+		case task := <-queue:
+			heartbeat.setTask(task.ID)
+
 			// Acquire worker slot
 			pool.workers <- struct{}{}
 
@@ -335,6 +465,8 @@ func (pool *TaskWorkerPool) worker(ctx context.Context, service *TaskService) {
 			pool.activeMu.Lock()
 			delete(pool.active, task.ID)
 			pool.activeMu.Unlock()
+
+			heartbeat.setTask(0)
 		}
 	}
 }
@@ -382,16 +514,23 @@ func (pool *TaskWorkerPool) shouldAbortTaskBeforeExecution(service *TaskService,
 	return false
 }
 
-// markTaskAsStarted marks task as started and returns start time
+// markTaskAsStarted claims a queued task and returns its start time. The claim only succeeds while
+// the task is still queued, so a task dispatched twice, such as by two instances sharing the
+// database, runs once.
 func (pool *TaskWorkerPool) markTaskAsStarted(service *TaskService, task *models.TaskV2) time.Time {
 	logger := pool.logger.With("taskId", task.ID)
 	startTime := time.Now()
-	err := service.updateTaskStatus(task.ID, "started",
-		"Task execution started", &startTime)
-	if err != nil {
-		logger.Errorw("Failed to update task status to started", "error", err)
+	result := service.db.GORM.Model(&models.TaskV2{}).
+		Where("id = ? AND status = ?", task.ID, taskStatusQueued).
+		Updates(map[string]interface{}{"status": "started", "started_at": startTime, "updated_at": startTime})
+	if result.Error != nil {
+		logger.Errorw("Failed to update task status to started", "error", result.Error)
 		return time.Time{} // Return zero time to indicate failure
 	}
+	if result.RowsAffected == 0 {
+		logger.Infow("Task already started elsewhere, skipping")
+		return time.Time{}
+	}
 
 	logger.Infow("Task execution started")
 	return startTime
@@ -425,6 +564,7 @@ func (pool *TaskWorkerPool) executeTaskWithHandler(
 	logger := pool.logger.With("taskId", task.ID)
 	// Create progress update function
 	updateProgress := func(percent int, message string) {
+		pool.beatFor(task.ID)
 		if err := service.updateTaskProgress(task.ID, percent, message); err != nil {
 			logger.Errorw("Failed to update task progress", "taskId", task.ID, "error", err)
 		}
@@ -447,6 +587,19 @@ func (pool *TaskWorkerPool) executeTaskWithHandler(
 	return taskErr
 }
 
+// beatFor records a heartbeat for the worker running taskID
+func (pool *TaskWorkerPool) beatFor(taskID int) {
+	for _, heartbeat := range pool.heartbeats {
+		heartbeat.mu.Lock()
+		running := heartbeat.taskID == taskID
+		heartbeat.mu.Unlock()
+		if running {
+			heartbeat.beat()
+			return
+		}
+	}
+}
+
 // createCancellableTaskContext creates a context that can be cancelled by monitoring task status
 func (pool *TaskWorkerPool) createCancellableTaskContext(
 	ctx context.Context, service *TaskService, task *models.TaskV2,
@@ -487,7 +640,7 @@ func (pool *TaskWorkerPool) finalizeTaskExecution(
 	logger := pool.logger.With("taskId", task.ID)
 
 	if taskErr != nil {
-		pool.handleTaskFailure(service, task, taskErr, endTime, logger)
+		pool.handleTaskFailure(service, task, taskErr, endTime, startTime, logger)
 	} else {
 		pool.handleTaskSuccess(service, task, endTime, startTime, logger)
 	}
@@ -496,17 +649,23 @@ func (pool *TaskWorkerPool) finalizeTaskExecution(
 // handleTaskFailure handles task failure scenarios
 func (pool *TaskWorkerPool) handleTaskFailure(
 	service *TaskService, task *models.TaskV2, taskErr error,
-	endTime time.Time, logger *zap.SugaredLogger,
+	endTime, startTime time.Time, logger *zap.SugaredLogger,
 ) {
+	if errors.Is(taskErr, context.Canceled) && service.interrupted.Load() {
+		logger.Infow("Task interrupted by shutdown, it will resume on next start")
+		return
+	}
 	if errors.Is(taskErr, context.Canceled) {
 		if err := service.updateTaskStatus(task.ID, "aborted", "Task was cancelled", &endTime); err != nil {
 			logger.Errorw("Failed to update task status to aborted", "taskId", task.ID, "error", err)
 		}
+		service.recordRun(task, models.TaskStatusAborted, startTime, endTime, "Task was cancelled")
 		logger.Infow("Task was cancelled")
 	} else {
 		if err := service.updateTaskStatus(task.ID, "failed", taskErr.Error(), &endTime); err != nil {
 			logger.Errorw("Failed to update task status to failed", "taskId", task.ID, "error", err)
 		}
+		service.recordRun(task, models.TaskStatusFailed, startTime, endTime, taskErr.Error())
 		logger.Errorw("Task failed", "error", taskErr)
 	}
 }
@@ -521,6 +680,15 @@ func (pool *TaskWorkerPool) handleTaskSuccess(
 	); err != nil {
 		logger.Errorw("Failed to update task status to completed", "taskId", task.ID, "error", err)
 	}
+
+	// Handlers may report what they did through the task result
+	if len(task.Result) > 0 {
+		if err := service.db.GORM.Model(&models.TaskV2{}).Where("id = ?", task.ID).
+			Update("result", task.Result).Error; err != nil {
+			logger.Errorw("Failed to save task result", "taskId", task.ID, "error", err)
+		}
+	}
+	service.recordRun(task, models.TaskStatusCompleted, startTime, endTime, "")
 	logger.Infow("Task completed successfully", "duration", endTime.Sub(startTime))
 }
 
@@ -584,7 +752,11 @@ func (scheduler *TaskScheduler) run() {
 		case <-scheduler.service.ctx.Done():
 			scheduler.ticker.Stop()
 			return
+		case <-scheduler.service.stopIntake:
+			scheduler.ticker.Stop()
+			return
 		case <-scheduler.ticker.C:
+			scheduler.lastTick.Store(time.Now().UnixNano())
 			scheduler.processScheduledTasks()
 		}
 	}
@@ -592,6 +764,13 @@ func (scheduler *TaskScheduler) run() {
 
 // processScheduledTasks checks for and executes scheduled tasks
 func (scheduler *TaskScheduler) processScheduledTasks() {
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+
+	if paused, _ := scheduler.service.pauseState(); paused || !scheduler.service.leading() {
+		return
+	}
+
 	var scheduledTasks []*models.ScheduledTaskV2
 	if err := scheduler.service.db.GORM.Where("enabled = ? AND next_run <= ?", true, time.Now()).
 		Find(&scheduledTasks).Error; err != nil {
@@ -628,6 +807,120 @@ func (scheduler *TaskScheduler) processScheduledTasks() {
 	}
 }
 
+// SetLeaderCheck restricts the scheduler to instances for which isLeader returns true
+func (ts *TaskService) SetLeaderCheck(isLeader func() bool) {
+	ts.isLeader = isLeader
+}
+
+// leading reports whether this instance should run scheduled tasks
+func (ts *TaskService) leading() bool {
+	return ts.isLeader == nil || ts.isLeader()
+}
+
+// SetPaused stops workers from starting queued tasks and the scheduler from queueing due tasks
+// while paused. Running tasks finish; due scheduled tasks are queued once the pause ends.
+func (ts *TaskService) SetPaused(paused bool) {
+	ts.pauseMu.Lock()
+	defer ts.pauseMu.Unlock()
+
+	if paused == ts.paused {
+		return
+	}
+	ts.paused = paused
+	if paused {
+		ts.resume = make(chan struct{})
+		ts.logger.Infow("Task processing paused")
+		return
+	}
+	close(ts.resume)
+	ts.logger.Infow("Task processing resumed")
+}
+
+// pauseState reports whether tasks are paused, with a channel closed when the pause ends
+func (ts *TaskService) pauseState() (bool, <-chan struct{}) {
+	ts.pauseMu.Lock()
+	defer ts.pauseMu.Unlock()
+	if !ts.paused {
+		return false, nil
+	}
+	return true, ts.resume
+}
+
+// WarmUp resumes tasks left queued by the previous run and queues scheduled tasks that came due
+// while the instance was down, rather than waiting for the first scheduler tick. Only the leader
+// does either, so instances sharing a database do not run the same tasks.
+func (ts *TaskService) WarmUp() {
+	if !ts.leading() {
+		return
+	}
+	ts.resumeQueuedTasks()
+	ts.scheduler.processScheduledTasks()
+}
+
+// TakeOver resumes the tasks left queued by a leader that went away, when this instance is promoted
+// while running. Tasks this instance already dispatched are claimed by one worker only.
+func (ts *TaskService) TakeOver() {
+	if !ts.leading() {
+		return
+	}
+	ts.resumeQueuedTasks()
+}
+
+// resumeQueuedTasks dispatches tasks still queued in the database, including those checkpointed
+// by Drain during the previous shutdown
+func (ts *TaskService) resumeQueuedTasks() {
+	var tasks []*models.TaskV2
+	if err := ts.db.GORM.Where("status = ?", taskStatusQueued).Order("id").Find(&tasks).Error; err != nil {
+		ts.logger.Errorw("Failed to fetch queued tasks to resume", "error", err)
+		return
+	}
+
+	for _, task := range tasks {
+		ts.dispatch(task)
+	}
+	if len(tasks) > 0 {
+		ts.logger.Infow("Resumed queued tasks", "count", len(tasks))
+	}
+}
+
+// Drain stops the scheduler and workers from starting new tasks and waits for running tasks to
+// finish. Tasks still running when ctx ends are put back in the queue so the next start resumes
+// them, then cancelled.
+func (ts *TaskService) Drain(ctx context.Context) {
+	ts.stopOnce.Do(func() { close(ts.stopIntake) })
+
+	done := make(chan struct{})
+	go func() {
+		ts.workerWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		ts.logger.Infow("Running tasks finished")
+		return
+	case <-ctx.Done():
+	}
+
+	ts.interrupted.Store(true)
+	for _, pool := range ts.workers {
+		for _, taskID := range pool.getActiveTasks() {
+			if err := ts.updateTaskStatus(taskID, taskStatusQueued, "", nil); err != nil {
+				ts.logger.Errorw("Failed to checkpoint running task", "taskId", taskID, "error", err)
+				continue
+			}
+			ts.logger.Warnw("Task still running at shutdown, checkpointed to resume on next start", "taskId", taskID)
+		}
+	}
+	ts.cancel()
+
+	select {
+	case <-done:
+	case <-time.After(checkpointGracePeriod):
+		ts.logger.Warnw("Cancelled tasks did not stop within the grace period")
+	}
+}
+
 // Shutdown gracefully shuts down the task service
 func (ts *TaskService) Shutdown() {
 	ts.logger.Infow("Shutting down task service")
@@ -15,10 +15,10 @@ import (
 // setupTaskServiceForTesting sets up the task service with required migrations
 func setupTaskServiceForTesting(t *testing.T, db *database.Database, logger *logger.Logger) *TaskService {
 	// Auto-migrate task tables
-	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
+	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{}, &models.TaskRun{})
 	require.NoError(t, err)
 
-	return NewTaskService(db, logger)
+	return NewTaskService(db, 0, logger)
 }
 
 // createTestTasks creates standard test tasks for testing
@@ -152,7 +152,7 @@ func TestTaskService_QueueTask(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Register test handler
@@ -194,7 +194,7 @@ func TestTaskService_GetTask(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Create a task directly in database
@@ -250,7 +250,7 @@ func TestTaskService_CancelTask(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Create a long-running task handler
@@ -291,7 +291,7 @@ func TestTaskService_ScheduledTasks(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Create a scheduled task
@@ -358,7 +358,7 @@ func TestTaskService_QueueStatus(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Get queue status
@@ -387,7 +387,7 @@ func TestTaskService_TaskProgress(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Register test handler
@@ -421,7 +421,7 @@ func TestTaskService_TaskFailure(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Register failing test handler
@@ -448,6 +448,33 @@ func TestTaskService_TaskFailure(t *testing.T) {
 	assert.NotEmpty(t, updatedTask.ErrorMessage)
 }
 
+func TestTaskService_GetTaskHistory(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	handler := NewTestTaskHandler("FailCommand", "Failing test handler")
+	handler.shouldFail = true
+	service.RegisterHandler(handler)
+	_, err := service.CreateScheduledTask("Nightly Failure", "FailCommand", models.JSONField{}, time.Hour, "normal")
+	require.NoError(t, err)
+
+	_, err = service.QueueTask("Failing Task", "FailCommand", models.JSONField{}, "normal")
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// The scheduled task's name resolves to its command
+	history, err := service.GetTaskHistory("Nightly Failure", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "FailCommand", history.CommandName)
+	assert.Equal(t, 1, history.Summary.Failed)
+	require.Len(t, history.Runs, 1)
+	assert.Equal(t, models.TaskStatusFailed, history.Runs[0].Outcome)
+	assert.NotEmpty(t, history.Runs[0].ErrorMessage)
+}
+
 func TestTaskService_UnknownHandler(t *testing.T) {
 	db, logger := setupTestDB(t)
 	defer cleanupTestDB(db)
@@ -456,7 +483,7 @@ func TestTaskService_UnknownHandler(t *testing.T) {
 	err := db.GORM.AutoMigrate(&models.TaskV2{}, &models.ScheduledTaskV2{}, &models.TaskQueue{})
 	require.NoError(t, err)
 
-	service := NewTaskService(db, logger)
+	service := NewTaskService(db, 0, logger)
 	defer service.Shutdown()
 
 	// Queue a task with unknown command
@@ -477,3 +504,168 @@ func TestTaskService_UnknownHandler(t *testing.T) {
 	assert.Equal(t, "failed", updatedTask.Status)
 	assert.Contains(t, updatedTask.ErrorMessage, "no handler registered")
 }
+
+func TestTaskService_Drain(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	handler := NewTestTaskHandler("SlowCommand", "Slow test handler")
+	handler.delay = 5 * time.Second
+	service.RegisterHandler(handler)
+
+	task, err := service.QueueTask("Slow Task", "SlowCommand", models.JSONField{}, "normal")
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	service.Drain(ctx)
+
+	// The interrupted task is checkpointed rather than aborted, so the next start resumes it
+	updatedTask, err := service.GetTask(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "queued", updatedTask.Status)
+}
+
+func TestTaskService_liveness(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	scheduler, workers := service.liveness(time.Now())
+	assert.True(t, scheduler.Alive)
+	require.NotEmpty(t, workers)
+	for _, worker := range workers {
+		assert.True(t, worker.Alive)
+	}
+
+	later := time.Now().Add(schedulerStaleAfter)
+	scheduler, workers = service.liveness(later)
+	assert.False(t, scheduler.Alive)
+	assert.Contains(t, scheduler.Message, "Task scheduler has not run since")
+	assert.False(t, workers[0].Alive)
+
+	// A worker running a long task stays alive without heartbeats
+	service.workers[workers[0].Pool].heartbeats[0].setTask(42)
+	_, workers = service.liveness(later)
+	assert.True(t, workers[0].Alive)
+	assert.Equal(t, 42, workers[0].TaskID)
+}
+
+func TestTaskService_SchedulerFollowsLeader(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	leader := false
+	service.SetLeaderCheck(func() bool { return leader })
+
+	scheduledTask, err := service.CreateScheduledTask("Due Task", "TestCommand", models.JSONField{}, time.Minute, "low")
+	require.NoError(t, err)
+	require.NoError(t, db.GORM.Model(scheduledTask).Update("next_run", time.Now().Add(-time.Minute)).Error)
+
+	service.scheduler.processScheduledTasks()
+	var queued int64
+	require.NoError(t, db.GORM.Model(&models.TaskV2{}).Count(&queued).Error)
+	assert.Zero(t, queued, "a follower must not queue scheduled tasks")
+
+	leader = true
+	service.scheduler.processScheduledTasks()
+	require.NoError(t, db.GORM.Model(&models.TaskV2{}).Count(&queued).Error)
+	assert.Equal(t, int64(1), queued)
+}
+
+func TestTaskService_TakeOver(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	handler := NewTestTaskHandler("TestCommand", "Test handler description")
+	service.RegisterHandler(handler)
+
+	// A task the crashed leader queued but never ran
+	task := &models.TaskV2{Name: "Orphaned Task", CommandName: "TestCommand", Priority: "normal", Status: "queued"}
+	require.NoError(t, db.GORM.Create(task).Error)
+
+	leader := false
+	service.SetLeaderCheck(func() bool { return leader })
+	service.TakeOver()
+	time.Sleep(100 * time.Millisecond)
+	followerTask, err := service.GetTask(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "queued", followerTask.Status, "a follower must not resume queued tasks")
+
+	leader = true
+	service.TakeOver()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	finished, err := service.WaitForTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", finished.Status)
+}
+
+func TestTaskService_SetPaused(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	handler := NewTestTaskHandler("TestCommand", "Test handler description")
+	service.RegisterHandler(handler)
+
+	service.SetPaused(true)
+	task, err := service.QueueTask("Paused Task", "TestCommand", models.JSONField{}, "normal")
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	pausedTask, err := service.GetTask(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "queued", pausedTask.Status)
+
+	service.SetPaused(false)
+	time.Sleep(100 * time.Millisecond)
+
+	resumedTask, err := service.GetTask(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskStatusCompleted, resumedTask.Status)
+}
+
+func TestTaskService_WaitForTask(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	service := setupTaskServiceForTesting(t, db, logger)
+	defer service.Shutdown()
+
+	handler := NewTestTaskHandler("SlowCommand", "Slow test handler")
+	handler.delay = 300 * time.Millisecond
+	service.RegisterHandler(handler)
+	assert.Contains(t, service.HandlerNames(), "SlowCommand")
+
+	task, err := service.QueueTask("Slow Task", "SlowCommand", models.JSONField{}, "normal")
+	require.NoError(t, err)
+
+	finished, err := service.WaitForTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", finished.Status)
+	assert.True(t, handler.executed)
+
+	// Giving up returns the task as it was
+	task, err = service.QueueTask("Slow Task", "SlowCommand", models.JSONField{}, "normal")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	unfinished, err := service.WaitForTask(ctx, task.ID)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotEqual(t, "completed", unfinished.Status)
+}
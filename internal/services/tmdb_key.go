@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/radarr/radarr-go/internal/secrets"
+	"github.com/radarr/radarr-go/internal/tmdb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// tmdbAPIKeyConfigKey is the app_config entry holding the encrypted API key set through the API
+	tmdbAPIKeyConfigKey = "tmdb.api_key"
+	// tmdbKeyValidationTimeout bounds a validation request made while saving or testing a key
+	tmdbKeyValidationTimeout = 15 * time.Second
+	// unmaskedKeyChars is how many trailing characters of the key are shown in its status
+	unmaskedKeyChars = 4
+)
+
+// UseStoredAPIKey enables storing the API key in the database, encrypted with box, and switches
+// to a key stored earlier. Without a box the config file key stays in use.
+func (s *MetadataService) UseStoredAPIKey(box *secrets.Box) {
+	s.secrets = box
+	if box == nil || s.db == nil {
+		return
+	}
+
+	key, err := s.loadStoredKey()
+	if err != nil {
+		s.logger.Error("Failed to load the stored TMDB API key, using the config file key", "error", err)
+		return
+	}
+	if key != "" {
+		s.tmdb.SetAPIKey(key)
+		s.logger.Info("Using the TMDB API key stored in the database")
+	}
+}
+
+// GetAPIKeyStatus returns what is known about the API key in use
+func (s *MetadataService) GetAPIKeyStatus() *models.TMDBKeyStatus {
+	key := s.tmdb.APIKey()
+	keyStatus := s.tmdb.KeyStatus()
+
+	status := &models.TMDBKeyStatus{
+		Configured: keyStatus.Configured,
+		Source:     models.TMDBKeySourceNone,
+		APIKey:     maskAPIKey(key),
+		Checked:    keyStatus.Checked,
+		Valid:      keyStatus.Valid,
+	}
+	switch {
+	case key == "":
+	case key == s.configKey:
+		status.Source = models.TMDBKeySourceConfig
+	default:
+		status.Source = models.TMDBKeySourceDatabase
+	}
+	if keyStatus.Checked {
+		status.CheckedAt = &keyStatus.CheckedAt
+	}
+	return status
+}
+
+// SetAPIKey validates key and stores it encrypted, overriding the config file key. A key TMDB
+// rejects is refused; a key that can't be checked because TMDB is unreachable is stored anyway.
+func (s *MetadataService) SetAPIKey(ctx context.Context, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return models.ValidationError{Field: "apiKey", Message: "API key is required"}
+	}
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	if s.secrets == nil {
+		return fmt.Errorf("secrets key not available, API key cannot be stored")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tmdbKeyValidationTimeout)
+	defer cancel()
+	if err := s.tmdb.ValidateKey(ctx, key); err != nil {
+		if errors.Is(err, tmdb.ErrInvalidAPIKey) {
+			return models.ValidationError{Field: "apiKey", Message: "TMDB rejected the API key"}
+		}
+		s.logger.Warn("Could not validate the TMDB API key, storing it anyway", "error", err)
+	}
+
+	encrypted, err := s.secrets.Encrypt(key)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt API key: %w", err)
+	}
+
+	entry := &models.AppConfig{
+		Key:         tmdbAPIKeyConfigKey,
+		Value:       models.JSON{"encrypted": encrypted},
+		Description: "TMDB API key, overrides tmdb.api_key in the config file",
+	}
+	if err := s.db.GORM.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "description", "updated_at"}),
+	}).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	s.tmdb.SetAPIKey(key)
+	s.logger.Info("Stored a new TMDB API key")
+	return nil
+}
+
+// ClearAPIKey removes the stored API key, going back to the config file key
+func (s *MetadataService) ClearAPIKey() error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
+	stored := &models.AppConfig{Key: tmdbAPIKeyConfigKey}
+	if err := s.db.GORM.Where(stored).Delete(&models.AppConfig{}).Error; err != nil {
+		return fmt.Errorf("failed to remove stored API key: %w", err)
+	}
+
+	s.tmdb.SetAPIKey(s.configKey)
+	s.logger.Info("Removed the stored TMDB API key, using the config file key")
+	return nil
+}
+
+// ValidateAPIKey checks key against TMDB without storing it. An empty key checks the key in use.
+func (s *MetadataService) ValidateAPIKey(ctx context.Context, key string) *models.TMDBKeyValidation {
+	ctx, cancel := context.WithTimeout(ctx, tmdbKeyValidationTimeout)
+	defer cancel()
+
+	err := s.tmdb.ValidateKey(ctx, strings.TrimSpace(key))
+	switch {
+	case err == nil:
+		return &models.TMDBKeyValidation{Valid: true, Message: "API key is valid"}
+	case errors.Is(err, tmdb.ErrNoAPIKey):
+		return &models.TMDBKeyValidation{Message: "No API key configured"}
+	case errors.Is(err, tmdb.ErrInvalidAPIKey):
+		return &models.TMDBKeyValidation{Message: "TMDB rejected the API key"}
+	default:
+		return &models.TMDBKeyValidation{Message: fmt.Sprintf("Could not reach TMDB: %v", err)}
+	}
+}
+
+// loadStoredKey returns the decrypted API key stored in the database, or "" when none is stored
+func (s *MetadataService) loadStoredKey() (string, error) {
+	var entry models.AppConfig
+	if err := s.db.GORM.Where(&models.AppConfig{Key: tmdbAPIKeyConfigKey}).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	encrypted, _ := entry.Value["encrypted"].(string)
+	return s.secrets.Decrypt(encrypted)
+}
+
+// maskAPIKey hides all but the last characters of key
+func maskAPIKey(key string) string {
+	if len(key) <= unmaskedKeyChars {
+		return strings.Repeat("*", len(key))
+	}
+	return strings.Repeat("*", len(key)-unmaskedKeyChars) + key[len(key)-unmaskedKeyChars:]
+}
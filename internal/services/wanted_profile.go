@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+// maxProfileUpgradeSearches caps the searches a profile refresh runs for the movies it newly
+// finds below cutoff; the rest are left to the scheduled cutoff unmet search
+const maxProfileUpgradeSearches = 50
+
+// WantedProfileRefresh summarizes the wanted rows a quality profile refresh changed
+type WantedProfileRefresh struct {
+	QualityProfileID int `json:"qualityProfileId"`
+	Created          int `json:"created"`
+	Updated          int `json:"updated"`
+	Removed          int `json:"removed"`
+	// NewUpgrades are the rows that became cutoff unmet or upgradeable with the refresh
+	NewUpgrades []models.WantedMovie `json:"-"`
+}
+
+// RefreshWantedForProfile re-evaluates the wanted rows of the monitored movies and versions using
+// a quality profile, so an edit to its cutoff or allowed qualities shows in the wanted lists
+// without waiting for the next full refresh. Movies the edit left without a reason to upgrade are
+// removed from wanted.
+func (s *WantedMoviesService) RefreshWantedForProfile(profileID int) (*WantedProfileRefresh, error) {
+	if s.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	profile, err := s.qualityService.GetQualityProfileByID(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	upgrades, err := s.profileUpgradeRows(profileID)
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[[2]int]bool, len(upgrades))
+	for _, row := range upgrades {
+		before[[2]int{row.MovieID, row.VersionID}] = true
+	}
+
+	profilesByID := map[int]*models.QualityProfile{profile.ID: profile}
+	created, updated, removed, _, err := s.refreshWantedPasses(profileID, profilesByID)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.profileUpgradeRows(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := &WantedProfileRefresh{
+		QualityProfileID: profileID, Created: created, Updated: updated, Removed: removed,
+	}
+	for _, row := range after {
+		if !before[[2]int{row.MovieID, row.VersionID}] {
+			refresh.NewUpgrades = append(refresh.NewUpgrades, row)
+		}
+	}
+
+	s.logger.Info("Refreshed wanted movies for quality profile", "qualityProfileId", profileID,
+		"created", created, "updated", updated, "removed", removed, "newUpgrades", len(refresh.NewUpgrades))
+	return refresh, nil
+}
+
+// profileUpgradeRows returns the cutoff unmet and upgrade rows of the movies and versions using a
+// quality profile, highest priority first
+func (s *WantedMoviesService) profileUpgradeRows(profileID int) ([]models.WantedMovie, error) {
+	movies := s.db.GORM.Model(&models.Movie{}).Select("id").Where("quality_profile_id = ?", profileID)
+	versions := s.db.GORM.Model(&models.MovieVersion{}).Select("id").Where("quality_profile_id = ?", profileID)
+
+	var wanted []models.WantedMovie
+	err := s.db.GORM.
+		Where("status IN ?", []models.WantedStatus{models.WantedStatusCutoffUnmet, models.WantedStatusUpgrade}).
+		Where(s.db.GORM.Where("version_id = ? AND movie_id IN (?)", models.PrimaryVersionID, movies).
+			Or("version_id IN (?)", versions)).
+		Order("priority DESC, id").
+		Find(&wanted).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wanted upgrades for quality profile: %w", err)
+	}
+	return wanted, nil
+}
+
+// profileWantedRefresher is the part of the wanted movies service a quality profile refresh uses
+type profileWantedRefresher interface {
+	RefreshWantedForProfile(profileID int) (*WantedProfileRefresh, error)
+	UpdateSearchAttempt(id int, success bool, reason, indexer, errorCode string) error
+}
+
+// RefreshQualityProfileWantedHandler re-evaluates the wanted movies of a quality profile after it
+// was edited, and with searchUpgrades in the task body searches the movies the edit put below
+// cutoff
+type RefreshQualityProfileWantedHandler struct {
+	wantedService profileWantedRefresher
+	searchService SearchServiceInterface
+	sleep         func(time.Duration)
+}
+
+// NewRefreshQualityProfileWantedHandler creates a new quality profile wanted refresh handler
+func NewRefreshQualityProfileWantedHandler(wantedService profileWantedRefresher,
+	searchService SearchServiceInterface) *RefreshQualityProfileWantedHandler {
+	return &RefreshQualityProfileWantedHandler{
+		wantedService: wantedService,
+		searchService: searchService,
+		sleep:         time.Sleep,
+	}
+}
+
+// Execute refreshes the profile's wanted rows, then searches the new upgrades when asked to
+func (h *RefreshQualityProfileWantedHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	var profileID int
+	switch v := task.Body["qualityProfileId"].(type) {
+	case int:
+		profileID = v
+	case float64:
+		profileID = int(v)
+	default:
+		return fmt.Errorf("qualityProfileId not found in task body")
+	}
+	searchUpgrades, _ := task.Body["searchUpgrades"].(bool)
+
+	updateProgress(0, fmt.Sprintf("Refreshing wanted movies for quality profile %d", profileID))
+
+	refresh, err := h.wantedService.RefreshWantedForProfile(profileID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh wanted movies for quality profile: %w", err)
+	}
+
+	task.Result = models.JSONField{
+		"created": refresh.Created, "updated": refresh.Updated, "removed": refresh.Removed,
+		"newUpgrades": len(refresh.NewUpgrades), "searched": 0, "failed": 0,
+	}
+	if !searchUpgrades || len(refresh.NewUpgrades) == 0 {
+		updateProgress(100, fmt.Sprintf("Refreshed wanted movies, %d newly below cutoff",
+			len(refresh.NewUpgrades)))
+		return nil
+	}
+
+	upgrades := refresh.NewUpgrades
+	if len(upgrades) > maxProfileUpgradeSearches {
+		upgrades = upgrades[:maxProfileUpgradeSearches]
+	}
+
+	searched, failed := 0, 0
+	searchedMovies := make(map[int]bool, len(upgrades))
+	for i, wantedMovie := range upgrades {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Versions of one movie share its search
+		if searchedMovies[wantedMovie.MovieID] {
+			continue
+		}
+		searchedMovies[wantedMovie.MovieID] = true
+		if searched > 0 {
+			h.sleep(wantedSearchDelay)
+		}
+
+		updateProgress(10+(i*90/len(upgrades)), fmt.Sprintf("Searching for an upgrade of movie %d (%d/%d)",
+			wantedMovie.MovieID, i+1, len(upgrades)))
+
+		_, searchErr := h.searchService.SearchMovieReleases(wantedMovie.MovieID, false)
+		searched++
+		if searchErr != nil {
+			failed++
+			_ = h.wantedService.UpdateSearchAttempt(wantedMovie.ID, false, //nolint:errcheck // Recorded best effort
+				"Quality profile upgrade search failed", "", searchErr.Error())
+			continue
+		}
+		_ = h.wantedService.UpdateSearchAttempt(wantedMovie.ID, true, //nolint:errcheck // Recorded best effort
+			"Quality profile upgrade search completed", "", "")
+	}
+
+	task.Result["searched"], task.Result["failed"] = searched, failed
+	updateProgress(100, fmt.Sprintf("Refreshed wanted movies, searched %d of %d newly below cutoff, %d failed",
+		searched, len(refresh.NewUpgrades), failed))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *RefreshQualityProfileWantedHandler) GetName() string {
+	return "RefreshQualityProfileWanted"
+}
+
+// GetDescription returns a human-readable description
+func (h *RefreshQualityProfileWantedHandler) GetDescription() string {
+	return "Re-evaluates the wanted movies of an edited quality profile and optionally searches for their upgrades"
+}
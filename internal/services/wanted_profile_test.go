@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProfileRefresh returns a fixed profile refresh and records the searches that follow it
+type fakeProfileRefresh struct {
+	fakeWantedSearch
+	refresh   *WantedProfileRefresh
+	profileID int
+}
+
+func (f *fakeProfileRefresh) RefreshWantedForProfile(profileID int) (*WantedProfileRefresh, error) {
+	f.profileID = profileID
+	return f.refresh, nil
+}
+
+func TestRefreshQualityProfileWantedHandler_Execute(t *testing.T) {
+	newFake := func() *fakeProfileRefresh {
+		return &fakeProfileRefresh{
+			fakeWantedSearch: fakeWantedSearch{attempts: map[int]bool{}},
+			refresh: &WantedProfileRefresh{
+				QualityProfileID: 2, Created: 2, Updated: 5, Removed: 1,
+				NewUpgrades: []models.WantedMovie{
+					{ID: 10, MovieID: 1}, {ID: 11, MovieID: 3}, {ID: 12, MovieID: 1, VersionID: 4},
+				},
+			},
+		}
+	}
+
+	fake := newFake()
+	handler := NewRefreshQualityProfileWantedHandler(fake, fake)
+	task := &models.TaskV2{Body: models.JSONField{"qualityProfileId": float64(2)}}
+	require.NoError(t, handler.Execute(context.Background(), task, func(int, string) {}))
+	assert.Equal(t, 2, fake.profileID)
+	assert.Empty(t, fake.searched)
+	assert.Equal(t, models.JSONField{
+		"created": 2, "updated": 5, "removed": 1, "newUpgrades": 3, "searched": 0, "failed": 0,
+	}, task.Result)
+
+	fake = newFake()
+	handler = NewRefreshQualityProfileWantedHandler(fake, fake)
+	handler.sleep = func(time.Duration) {}
+	task = &models.TaskV2{Body: models.JSONField{"qualityProfileId": 2, "searchUpgrades": true}}
+	require.NoError(t, handler.Execute(context.Background(), task, func(int, string) {}))
+	assert.Equal(t, []int{1, 3}, fake.searched)
+	assert.Equal(t, map[int]bool{10: true, 11: false}, fake.attempts)
+	assert.Equal(t, 2, task.Result["searched"])
+	assert.Equal(t, 1, task.Result["failed"])
+
+	assert.Error(t, handler.Execute(context.Background(), &models.TaskV2{Body: models.JSONField{}},
+		func(int, string) {}))
+}
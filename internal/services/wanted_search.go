@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/models"
+)
+
+const (
+	defaultSearchMinInterval = 6 * time.Hour
+	defaultSearchMaxInterval = 7 * 24 * time.Hour
+
+	// wantedSearchDelay spaces out searches to avoid overwhelming indexers
+	wantedSearchDelay = 100 * time.Millisecond
+)
+
+// searchBackoff is the parsed wait between searches of a wanted movie
+type searchBackoff struct {
+	minInterval time.Duration
+	maxInterval time.Duration
+}
+
+// newSearchBackoff parses the search intervals; empty intervals use the defaults, and the defaults
+// are returned alongside any parse error
+func newSearchBackoff(cfg config.SearchConfig) (searchBackoff, error) {
+	backoff := searchBackoff{minInterval: defaultSearchMinInterval, maxInterval: defaultSearchMaxInterval}
+
+	for _, d := range []struct {
+		value  string
+		target *time.Duration
+		name   string
+	}{
+		{cfg.MinInterval, &backoff.minInterval, "min_interval"},
+		{cfg.MaxInterval, &backoff.maxInterval, "max_interval"},
+	} {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil || parsed <= 0 {
+			return searchBackoff{minInterval: defaultSearchMinInterval, maxInterval: defaultSearchMaxInterval},
+				fmt.Errorf("invalid search.%s %q", d.name, d.value)
+		}
+		*d.target = parsed
+	}
+	return backoff, nil
+}
+
+// interval returns the wait after a movie's searches: the minimum interval doubled for each
+// search after the first, capped at the maximum
+func (b searchBackoff) interval(attempts int) time.Duration {
+	interval := b.minInterval
+	for i := 1; i < attempts && interval < b.maxInterval; i++ {
+		interval *= 2
+	}
+	return min(interval, b.maxInterval)
+}
+
+// due reports whether a wanted movie is searched again at now; movies never searched, or whose
+// attempts were reset, always are
+func (b searchBackoff) due(wantedMovie *models.WantedMovie, now time.Time) bool {
+	if wantedMovie.SearchAttempts == 0 || wantedMovie.LastSearchTime == nil {
+		return true
+	}
+	return !now.Before(wantedMovie.LastSearchTime.Add(b.interval(wantedMovie.SearchAttempts)))
+}
+
+// WantedMoviesSearchHandler searches every wanted movie of some statuses whose backoff has passed
+type WantedMoviesSearchHandler struct {
+	name          string
+	description   string
+	statuses      []models.WantedStatus
+	wantedService WantedMoviesServiceInterface
+	searchService SearchServiceInterface
+	backoff       searchBackoff
+	sleep         func(time.Duration)
+}
+
+// NewMissingMoviesSearchHandler creates a handler searching all missing movies
+func NewMissingMoviesSearchHandler(wantedService WantedMoviesServiceInterface,
+	searchService SearchServiceInterface, backoff searchBackoff) *WantedMoviesSearchHandler {
+	return &WantedMoviesSearchHandler{
+		name:          "MissingMoviesSearch",
+		description:   "Searches for all monitored movies without a file that are due for another search",
+		statuses:      []models.WantedStatus{models.WantedStatusMissing},
+		wantedService: wantedService,
+		searchService: searchService,
+		backoff:       backoff,
+		sleep:         time.Sleep,
+	}
+}
+
+// NewCutoffUnmetMoviesSearchHandler creates a handler searching all movies below their quality cutoff
+func NewCutoffUnmetMoviesSearchHandler(wantedService WantedMoviesServiceInterface,
+	searchService SearchServiceInterface, backoff searchBackoff) *WantedMoviesSearchHandler {
+	return &WantedMoviesSearchHandler{
+		name:          "CutoffUnmetMoviesSearch",
+		description:   "Searches for upgrades of all monitored movies below their quality cutoff that are due for another search",
+		statuses:      []models.WantedStatus{models.WantedStatusCutoffUnmet, models.WantedStatusUpgrade},
+		wantedService: wantedService,
+		searchService: searchService,
+		backoff:       backoff,
+		sleep:         time.Sleep,
+	}
+}
+
+// Execute searches the wanted movies that are due, recording each attempt so the next run waits longer
+func (h *WantedMoviesSearchHandler) Execute(
+	ctx context.Context, task *models.TaskV2, updateProgress func(percent int, message string),
+) error {
+	updateProgress(0, "Getting wanted movies")
+
+	candidates, err := h.wantedService.GetSearchCandidates(h.statuses...)
+	if err != nil {
+		return fmt.Errorf("failed to get wanted movies: %w", err)
+	}
+
+	now := time.Now()
+	var due []models.WantedMovie
+	for i := range candidates {
+		if h.backoff.due(&candidates[i], now) {
+			due = append(due, candidates[i])
+		}
+	}
+	skipped := len(candidates) - len(due)
+
+	if len(due) == 0 {
+		task.Result = models.JSONField{"searched": 0, "failed": 0, "skipped": skipped}
+		updateProgress(100, fmt.Sprintf("No wanted movies due for search, %d waiting", skipped))
+		return nil
+	}
+
+	updateProgress(5, fmt.Sprintf("Searching %d wanted movies, %d waiting", len(due), skipped))
+
+	searched, failed := 0, 0
+	for i, wantedMovie := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if i > 0 {
+			h.sleep(wantedSearchDelay)
+		}
+
+		updateProgress(5+(i*95/len(due)), fmt.Sprintf("Searching for movie %d (%d/%d)",
+			wantedMovie.MovieID, i+1, len(due)))
+
+		_, searchErr := h.searchService.SearchMovieReleases(wantedMovie.MovieID, false)
+		searched++
+		if searchErr != nil {
+			failed++
+			_ = h.wantedService.UpdateSearchAttempt(wantedMovie.ID, false, //nolint:errcheck // Recorded best effort
+				"Automatic search failed", "", searchErr.Error())
+			continue
+		}
+		_ = h.wantedService.UpdateSearchAttempt(wantedMovie.ID, true, //nolint:errcheck // Recorded best effort
+			"Automatic search completed", "", "")
+	}
+
+	task.Result = models.JSONField{"searched": searched, "failed": failed, "skipped": skipped}
+	updateProgress(100, fmt.Sprintf("Searched %d wanted movies, %d failed, %d waiting", searched, failed, skipped))
+	return nil
+}
+
+// GetName returns the command name this handler processes
+func (h *WantedMoviesSearchHandler) GetName() string {
+	return h.name
+}
+
+// GetDescription returns a human-readable description
+func (h *WantedMoviesSearchHandler) GetDescription() string {
+	return h.description
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWantedSearch records the searches and attempts of a wanted movies search
+type fakeWantedSearch struct {
+	WantedMoviesServiceInterface
+	candidates []models.WantedMovie
+	statuses   []models.WantedStatus
+	searched   []int
+	attempts   map[int]bool
+}
+
+func (f *fakeWantedSearch) GetSearchCandidates(statuses ...models.WantedStatus) ([]models.WantedMovie, error) {
+	f.statuses = statuses
+	return f.candidates, nil
+}
+
+func (f *fakeWantedSearch) UpdateSearchAttempt(id int, success bool, _, _, _ string) error {
+	f.attempts[id] = success
+	return nil
+}
+
+func (f *fakeWantedSearch) SearchMovieReleases(movieID int, _ bool) (*models.SearchResponse, error) {
+	f.searched = append(f.searched, movieID)
+	if movieID == 3 {
+		return nil, errors.New("no indexers available")
+	}
+	return &models.SearchResponse{}, nil
+}
+
+func TestSearchBackoff(t *testing.T) {
+	backoff, err := newSearchBackoff(config.SearchConfig{MinInterval: "1h", MaxInterval: "6h"})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Hour, backoff.interval(1))
+	assert.Equal(t, 2*time.Hour, backoff.interval(2))
+	assert.Equal(t, 4*time.Hour, backoff.interval(3))
+	assert.Equal(t, 6*time.Hour, backoff.interval(10))
+
+	now := time.Now()
+	searched := now.Add(-3 * time.Hour)
+	assert.True(t, backoff.due(&models.WantedMovie{}, now))
+	assert.True(t, backoff.due(&models.WantedMovie{SearchAttempts: 2, LastSearchTime: &searched}, now))
+	assert.False(t, backoff.due(&models.WantedMovie{SearchAttempts: 3, LastSearchTime: &searched}, now))
+
+	backoff, err = newSearchBackoff(config.SearchConfig{MinInterval: "soon"})
+	assert.Error(t, err)
+	assert.Equal(t, defaultSearchMinInterval, backoff.minInterval)
+}
+
+func TestWantedMoviesSearchHandler_Execute(t *testing.T) {
+	recent := time.Now().Add(-time.Minute)
+	fake := &fakeWantedSearch{
+		candidates: []models.WantedMovie{
+			{ID: 1, MovieID: 1},
+			{ID: 2, MovieID: 2, SearchAttempts: 1, LastSearchTime: &recent},
+			{ID: 3, MovieID: 3},
+		},
+		attempts: map[int]bool{},
+	}
+	backoff, err := newSearchBackoff(config.SearchConfig{})
+	require.NoError(t, err)
+
+	handler := NewCutoffUnmetMoviesSearchHandler(fake, fake, backoff)
+	handler.sleep = func(time.Duration) {}
+
+	task := &models.TaskV2{}
+	require.NoError(t, handler.Execute(context.Background(), task, func(int, string) {}))
+
+	assert.Equal(t, []models.WantedStatus{models.WantedStatusCutoffUnmet, models.WantedStatusUpgrade}, fake.statuses)
+	assert.Equal(t, []int{1, 3}, fake.searched)
+	assert.Equal(t, map[int]bool{1: true, 3: false}, fake.attempts)
+	assert.Equal(t, models.JSONField{"searched": 2, "failed": 1, "skipped": 1}, task.Result)
+}
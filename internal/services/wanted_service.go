@@ -1,34 +1,81 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/radarr/radarr-go/internal/config"
 	"github.com/radarr/radarr-go/internal/database"
 	"github.com/radarr/radarr-go/internal/logger"
 	"github.com/radarr/radarr-go/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/hints"
 )
 
+// defaultWantedPriority weighs wanted movie priorities without a config
+var defaultWantedPriority = config.SearchPriorityConfig{
+	Missing:              int(models.PriorityHigh),
+	CutoffUnmet:          int(models.PriorityNormal),
+	CurrentYear:          int(models.PriorityHigh),
+	PreviousYear:         int(models.PriorityNormal),
+	PopularityWeighting:  true,
+	VeryPopular:          int(models.PriorityHigh),
+	VeryPopularThreshold: 50,
+	Popular:              int(models.PriorityNormal),
+	PopularThreshold:     20,
+}
+
 // WantedMoviesService provides operations for managing wanted movies
 type WantedMoviesService struct {
 	db             *database.Database
 	logger         *logger.Logger
 	movieService   *MovieService
 	qualityService *QualityService
+	priority       config.SearchPriorityConfig
 }
 
-// NewWantedMoviesService creates a new instance of WantedMoviesService
+// NewWantedMoviesService creates a new instance of WantedMoviesService weighing priorities as
+// configured
 func NewWantedMoviesService(db *database.Database, logger *logger.Logger,
-	movieService *MovieService, qualityService *QualityService) *WantedMoviesService {
+	movieService *MovieService, qualityService *QualityService,
+	priority config.SearchPriorityConfig) *WantedMoviesService {
 	return &WantedMoviesService{
 		db:             db,
 		logger:         logger,
 		movieService:   movieService,
 		qualityService: qualityService,
+		priority:       priority,
+	}
+}
+
+// newWantedPriority checks the priority weights, returning the defaults alongside an error for a
+// level outside 0 to 5 or a starting priority outside 1 to 5
+func newWantedPriority(cfg config.SearchPriorityConfig) (config.SearchPriorityConfig, error) {
+	for _, level := range []struct {
+		value int
+		name  string
+		base  bool
+	}{
+		{cfg.Missing, "missing", true},
+		{cfg.CutoffUnmet, "cutoff_unmet", true},
+		{cfg.CurrentYear, "current_year", false},
+		{cfg.PreviousYear, "previous_year", false},
+		{cfg.VeryPopular, "very_popular", false},
+		{cfg.Popular, "popular", false},
+		{cfg.Available, "available", false},
+	} {
+		minimum := 0
+		if level.base {
+			minimum = int(models.PriorityVeryLow)
+		}
+		if level.value < minimum || level.value > int(models.PriorityVeryHigh) {
+			return defaultWantedPriority, fmt.Errorf("invalid search.priority.%s %d", level.name, level.value)
+		}
 	}
+	return cfg, nil
 }
 
 // GetMissingMovies retrieves all monitored movies that don't have files
@@ -56,7 +103,7 @@ func (s *WantedMoviesService) GetMissingMovies(filter *models.WantedMovieFilter)
 		Preload("Movie").
 		Preload("Movie.MovieFile").
 		Preload("TargetQuality").
-		Where("status = ?", models.WantedStatusMissing)
+		Where("wanted_movies.status = ?", models.WantedStatusMissing)
 
 	query = s.applyFilters(query, filter)
 
@@ -118,7 +165,8 @@ func (s *WantedMoviesService) GetCutoffUnmetMovies(
 		Preload("Movie.MovieFile").
 		Preload("CurrentQuality").
 		Preload("TargetQuality").
-		Where("status IN ?", []models.WantedStatus{models.WantedStatusCutoffUnmet, models.WantedStatusUpgrade})
+		Where("wanted_movies.status IN ?",
+			[]models.WantedStatus{models.WantedStatusCutoffUnmet, models.WantedStatusUpgrade})
 
 	query = s.applyFilters(query, filter)
 
@@ -212,34 +260,96 @@ func (s *WantedMoviesService) GetAllWanted(filter *models.WantedMovieFilter) (*m
 	}, nil
 }
 
+// wantedExportBatchSize is the number of wanted movies read per query while exporting
+const wantedExportBatchSize = 500
+
+// ExportWanted passes every wanted movie matching the filters of filter to each, in the order they
+// were added. Wanted movies are read in batches, so exporting them all doesn't hold them in memory.
+// Paging and sorting in filter are ignored.
+func (s *WantedMoviesService) ExportWanted(
+	ctx context.Context, filter *models.WantedMovieFilter, each func(*models.WantedMovie) error,
+) error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+	// Sorting doesn't apply, so the movies table is joined only when a filter needs it
+	unsorted := models.WantedMovieFilter{}
+	if filter != nil {
+		unsorted = *filter
+		unsorted.SortBy = ""
+	}
+
+	lastID := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		query := s.db.GORM.Model(&models.WantedMovie{}).
+			Preload("Movie").
+			Preload("CurrentQuality").
+			Preload("TargetQuality")
+
+		var wantedMovies []models.WantedMovie
+		if err := s.applyFilters(query, &unsorted).
+			Where("wanted_movies.id > ?", lastID).
+			Order("wanted_movies.id").
+			Limit(wantedExportBatchSize).
+			Find(&wantedMovies).Error; err != nil {
+			return fmt.Errorf("failed to get wanted movies: %w", err)
+		}
+
+		for i := range wantedMovies {
+			if err := each(&wantedMovies[i]); err != nil {
+				return err
+			}
+		}
+		if len(wantedMovies) < wantedExportBatchSize {
+			return nil
+		}
+		lastID = wantedMovies[len(wantedMovies)-1].ID
+	}
+}
+
 // applyFilters applies filtering criteria to the query
 func (s *WantedMoviesService) applyFilters(query *gorm.DB, filter *models.WantedMovieFilter) *gorm.DB {
 	query = s.applyBasicFilters(query, filter)
 	query = s.applySearchFilters(query, filter)
+
+	// Join the movies table once for every filter and sort key that needs it
+	if needsMovieJoin(filter) {
+		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id")
+	}
 	query = s.applyMovieRelatedFilters(query, filter)
 	return query
 }
 
+// needsMovieJoin reports whether filtering or sorting references columns on the movies table
+func needsMovieJoin(filter *models.WantedMovieFilter) bool {
+	return filter.QualityProfileID != nil || filter.Monitored != nil || filter.Year != nil ||
+		filter.Genre != nil || filter.SortBy == "title" || filter.SortBy == "year"
+}
+
 // applyBasicFilters applies basic status and priority filters
 func (s *WantedMoviesService) applyBasicFilters(query *gorm.DB, filter *models.WantedMovieFilter) *gorm.DB {
 	if filter.Status != nil {
-		query = query.Where("status = ?", *filter.Status)
+		query = query.Where("wanted_movies.status = ?", *filter.Status)
 	}
 
 	if filter.Priority != nil {
-		query = query.Where("priority = ?", *filter.Priority)
+		query = query.Where("wanted_movies.priority = ?", *filter.Priority)
 	}
 
 	if filter.MinPriority != nil {
-		query = query.Where("priority >= ?", *filter.MinPriority)
+		query = query.Where("wanted_movies.priority >= ?", *filter.MinPriority)
 	}
 
 	if filter.MaxPriority != nil {
-		query = query.Where("priority <= ?", *filter.MaxPriority)
+		query = query.Where("wanted_movies.priority <= ?", *filter.MaxPriority)
 	}
 
 	if filter.IsAvailable != nil {
-		query = query.Where("is_available = ?", *filter.IsAvailable)
+		query = query.Where("wanted_movies.is_available = ?", *filter.IsAvailable)
 	}
 
 	return query
@@ -250,46 +360,45 @@ func (s *WantedMoviesService) applySearchFilters(query *gorm.DB, filter *models.
 	if filter.SearchRequired != nil {
 		if *filter.SearchRequired {
 			query = query.Where(
-				"search_attempts < max_search_attempts AND (next_search_time IS NULL OR next_search_time <= ?)",
+				"wanted_movies.search_attempts < wanted_movies.max_search_attempts AND "+
+					"(wanted_movies.next_search_time IS NULL OR wanted_movies.next_search_time <= ?)",
 				time.Now())
 		} else {
 			query = query.Where(
-				"search_attempts >= max_search_attempts OR (next_search_time IS NOT NULL AND next_search_time > ?)",
+				"wanted_movies.search_attempts >= wanted_movies.max_search_attempts OR "+
+					"(wanted_movies.next_search_time IS NOT NULL AND wanted_movies.next_search_time > ?)",
 				time.Now())
 		}
 	}
 
 	if filter.LastSearchBefore != nil {
-		query = query.Where("last_search_time < ? OR last_search_time IS NULL", *filter.LastSearchBefore)
+		query = query.Where("wanted_movies.last_search_time < ? OR wanted_movies.last_search_time IS NULL",
+			*filter.LastSearchBefore)
 	}
 
 	if filter.LastSearchAfter != nil {
-		query = query.Where("last_search_time > ?", *filter.LastSearchAfter)
+		query = query.Where("wanted_movies.last_search_time > ?", *filter.LastSearchAfter)
 	}
 
 	return query
 }
 
-// applyMovieRelatedFilters applies filters that require joining with the movies table
+// applyMovieRelatedFilters applies filters on the movies table joined by applyFilters
 func (s *WantedMoviesService) applyMovieRelatedFilters(query *gorm.DB, filter *models.WantedMovieFilter) *gorm.DB {
 	if filter.QualityProfileID != nil {
-		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
-			Where("movies.quality_profile_id = ?", *filter.QualityProfileID)
+		query = query.Where("movies.quality_profile_id = ?", *filter.QualityProfileID)
 	}
 
 	if filter.Monitored != nil {
-		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
-			Where("movies.monitored = ?", *filter.Monitored)
+		query = query.Where("movies.monitored = ?", *filter.Monitored)
 	}
 
 	if filter.Year != nil {
-		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
-			Where("movies.year = ?", *filter.Year)
+		query = query.Where("movies.year = ?", *filter.Year)
 	}
 
 	if filter.Genre != nil {
-		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
-			Where("JSON_CONTAINS(movies.genres, ?)", fmt.Sprintf(`"%s"`, *filter.Genre))
+		query = query.Where("JSON_CONTAINS(movies.genres, ?)", fmt.Sprintf(`"%s"`, *filter.Genre))
 	}
 
 	return query
@@ -309,11 +418,9 @@ func (s *WantedMoviesService) applySorting(query *gorm.DB, filter *models.Wanted
 
 	switch sortBy {
 	case "title":
-		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
-			Order(fmt.Sprintf("movies.title %s", sortDir))
+		query = query.Order(fmt.Sprintf("movies.title %s", sortDir))
 	case "year":
-		query = query.Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
-			Order(fmt.Sprintf("movies.year %s", sortDir))
+		query = query.Order(fmt.Sprintf("movies.year %s", sortDir))
 	case "added":
 		query = query.Order(fmt.Sprintf("wanted_movies.created_at %s", sortDir))
 	case "lastSearchTime":
@@ -329,91 +436,288 @@ func (s *WantedMoviesService) applySorting(query *gorm.DB, filter *models.Wanted
 	return query
 }
 
-// RefreshWantedMovies analyzes all monitored movies and updates wanted status
+// wantedRefreshBatchSize is the number of movies analyzed and written per round of statements
+// during a wanted refresh
+const wantedRefreshBatchSize = 1000
+
+// wantedRefreshColumns are the analysis columns a refresh overwrites on existing wanted rows.
+// Search bookkeeping (attempts, failures, search times) is left alone.
+var wantedRefreshColumns = []string{
+	"status", "reason", "current_quality_id", "target_quality_id", "is_available", "priority", "updated_at",
+}
+
+// wantedCandidate is the projection of a monitored, available movie, or a monitored version of
+// one, joined with its file and current wanted row that a refresh needs to decide whether the
+// movie or version is wanted
+type wantedCandidate struct {
+	MovieID          int
+	VersionID        int
+	Year             int
+	Popularity       float64
+	HasFile          bool
+	QualityProfileID int
+	FileID           *int
+	FileQuality      models.Quality
+	WantedID         *int
+}
+
+// RefreshWantedMovies analyzes all monitored movies and updates wanted status.
+// Movies are read through a single join per batch and their wanted rows are upserted and
+// deleted with set-based statements, so the cost grows with the number of batches rather
+// than the number of movies.
 func (s *WantedMoviesService) RefreshWantedMovies() error {
+	if s.db == nil {
+		return fmt.Errorf("database not available")
+	}
+
 	s.logger.Info("Starting wanted movies refresh")
 
-	// Get all monitored movies
-	movies, err := s.movieService.GetMonitored()
+	profiles, err := s.qualityService.GetQualityProfiles()
 	if err != nil {
-		return fmt.Errorf("failed to get monitored movies: %w", err)
+		return fmt.Errorf("failed to get quality profiles: %w", err)
+	}
+	profilesByID := make(map[int]*models.QualityProfile, len(profiles))
+	for _, profile := range profiles {
+		profilesByID[profile.ID] = profile
 	}
 
-	var created, updated, removed int
+	// Movies that are no longer monitored, available, or present are never wanted, and neither
+	// are versions that are no longer monitored or present
+	eligible := s.db.GORM.Model(&models.Movie{}).Select("id").
+		Where("monitored = ? AND is_available = ?", true, true)
+	result := s.db.GORM.Where("movie_id NOT IN (?)", eligible).Delete(&models.WantedMovie{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove unmonitored movies from wanted: %w", result.Error)
+	}
+	removed := int(result.RowsAffected)
 
-	for _, movie := range movies {
-		if err := s.analyzeMovie(&movie, &created, &updated, &removed); err != nil {
-			s.logger.Error("Failed to analyze movie for wanted status", "movieId", movie.ID, "title", movie.Title, "error", err)
-			continue
-		}
+	eligibleVersions := s.db.GORM.Model(&models.MovieVersion{}).Select("id").Where("monitored = ?", true)
+	result = s.db.GORM.Where("version_id <> ? AND version_id NOT IN (?)", models.PrimaryVersionID, eligibleVersions).
+		Delete(&models.WantedMovie{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove unmonitored movie versions from wanted: %w", result.Error)
+	}
+	removed += int(result.RowsAffected)
+
+	created, updated, passRemoved, batches, err := s.refreshWantedPasses(0, profilesByID)
+	if err != nil {
+		return err
 	}
+	removed += passRemoved
 
 	s.logger.Info("Wanted movies refresh completed",
-		"created", created, "updated", updated, "removed", removed)
+		"created", created, "updated", updated, "removed", removed, "batches", batches)
 
 	return nil
 }
 
-// analyzeMovie analyzes a single movie and updates its wanted status
-func (s *WantedMoviesService) analyzeMovie(movie *models.Movie, created, updated, removed *int) error {
-	// Get quality profile for the movie
-	profile, err := s.qualityService.GetQualityProfileByID(movie.QualityProfileID)
+// refreshWantedPasses refreshes the wanted rows of the monitored movies, then of their monitored
+// versions, limited to those using quality profile profileID unless it's 0
+func (s *WantedMoviesService) refreshWantedPasses(profileID int,
+	profilesByID map[int]*models.QualityProfile) (created, updated, removed, batches int, err error) {
+	loaders := []func(profileID, lastID int) ([]wantedCandidate, error){
+		s.loadWantedCandidates, s.loadVersionCandidates,
+	}
+	for _, loader := range loaders {
+		load := func(lastID int) ([]wantedCandidate, error) { return loader(profileID, lastID) }
+		passCreated, passUpdated, passRemoved, passBatches, err := s.refreshWantedPass(load, profilesByID)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		created += passCreated
+		updated += passUpdated
+		removed += passRemoved
+		batches += passBatches
+	}
+	return created, updated, removed, batches, nil
+}
+
+// refreshWantedPass refreshes the wanted rows of the candidates load returns, batch by batch
+func (s *WantedMoviesService) refreshWantedPass(load func(lastID int) ([]wantedCandidate, error),
+	profilesByID map[int]*models.QualityProfile) (created, updated, removed, batches int, err error) {
+	lastID := 0
+
+	for {
+		candidates, err := load(lastID)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		lastID = candidates[len(candidates)-1].key()
+
+		batchCreated, batchUpdated, batchRemoved, err := s.applyWantedBatch(candidates, profilesByID)
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		created += batchCreated
+		updated += batchUpdated
+		removed += batchRemoved
+		batches++
+
+		s.logger.Debug("Refreshed wanted movies batch", "batch", batches, "movies", len(candidates),
+			"created", batchCreated, "updated", batchUpdated, "removed", batchRemoved)
+
+		if len(candidates) < wantedRefreshBatchSize {
+			break
+		}
+	}
+
+	return created, updated, removed, batches, nil
+}
+
+// loadWantedCandidates returns the next batch of monitored, available movies after lastID, using
+// quality profile profileID unless it's 0, joined with their movie file and existing wanted row
+func (s *WantedMoviesService) loadWantedCandidates(profileID, lastID int) ([]wantedCandidate, error) {
+	var candidates []wantedCandidate
+
+	query := s.db.GORM.Model(&models.Movie{})
+	if profileID != 0 {
+		query = query.Where("movies.quality_profile_id = ?", profileID)
+	}
+	err := query.
+		Select("movies.id AS movie_id, movies.year, movies.popularity, movies.has_file, "+
+			"movies.quality_profile_id, movie_files.id AS file_id, movie_files.quality AS file_quality, "+
+			"wanted_movies.id AS wanted_id").
+		Joins("LEFT JOIN movie_files ON movie_files.id = movies.movie_file_id").
+		Joins("LEFT JOIN wanted_movies ON wanted_movies.movie_id = movies.id AND wanted_movies.version_id = ?",
+			models.PrimaryVersionID).
+		Where("movies.monitored = ? AND movies.is_available = ? AND movies.id > ?", true, true, lastID).
+		Order("movies.id").
+		Limit(wantedRefreshBatchSize).
+		Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load movies for wanted refresh: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// loadVersionCandidates returns the next batch of monitored versions of monitored, available
+// movies after version lastID, using quality profile profileID unless it's 0, joined with their
+// movie file and existing wanted row
+func (s *WantedMoviesService) loadVersionCandidates(profileID, lastID int) ([]wantedCandidate, error) {
+	var candidates []wantedCandidate
+
+	query := s.db.GORM.Model(&models.MovieVersion{})
+	if profileID != 0 {
+		query = query.Where("movie_versions.quality_profile_id = ?", profileID)
+	}
+	err := query.
+		Select("movie_versions.movie_id, movie_versions.id AS version_id, movies.year, movies.popularity, "+
+			"movie_versions.has_file, movie_versions.quality_profile_id, movie_files.id AS file_id, "+
+			"movie_files.quality AS file_quality, wanted_movies.id AS wanted_id").
+		Joins("JOIN movies ON movies.id = movie_versions.movie_id").
+		Joins("LEFT JOIN movie_files ON movie_files.id = movie_versions.movie_file_id").
+		Joins("LEFT JOIN wanted_movies ON wanted_movies.movie_id = movie_versions.movie_id "+
+			"AND wanted_movies.version_id = movie_versions.id").
+		Where("movies.monitored = ? AND movies.is_available = ? AND movie_versions.monitored = ? "+
+			"AND movie_versions.id > ?", true, true, true, lastID).
+		Order("movie_versions.id").
+		Limit(wantedRefreshBatchSize).
+		Scan(&candidates).Error
 	if err != nil {
-		return fmt.Errorf("failed to get quality profile: %w", err)
+		return nil, fmt.Errorf("failed to load movie versions for wanted refresh: %w", err)
 	}
 
-	// Check current wanted status
-	var existingWanted models.WantedMovie
-	err = s.db.GORM.Where("movie_id = ?", movie.ID).First(&existingWanted).Error
-	hasExisting := err == nil
+	return candidates, nil
+}
+
+// applyWantedBatch works out the wanted status of each candidate and writes the batch with
+// one upsert and one delete
+func (s *WantedMoviesService) applyWantedBatch(candidates []wantedCandidate,
+	profilesByID map[int]*models.QualityProfile) (created, updated, removed int, err error) {
+	wanted := make([]models.WantedMovie, 0, len(candidates))
+	var notWanted []int
 
-	wantedStatus, currentQualityID, targetQualityID, reason := s.determineWantedStatus(movie, profile)
+	for i := range candidates {
+		candidate := &candidates[i]
 
-	if wantedStatus == "" {
-		// Movie is not wanted anymore
-		if hasExisting {
-			if err := s.db.GORM.Delete(&existingWanted).Error; err != nil {
-				return fmt.Errorf("failed to remove from wanted: %w", err)
+		profile, ok := profilesByID[candidate.QualityProfileID]
+		if !ok {
+			s.logger.Error("Failed to analyze movie for wanted status", "movieId", candidate.MovieID,
+				"error", fmt.Sprintf("quality profile %d not found", candidate.QualityProfileID))
+			continue
+		}
+
+		movie := candidate.movie()
+		status, currentQualityID, targetQualityID, reason := s.determineWantedStatus(movie, profile)
+		if status == "" {
+			if candidate.WantedID != nil {
+				notWanted = append(notWanted, *candidate.WantedID)
 			}
-			(*removed)++
+			continue
+		}
+
+		wanted = append(wanted, models.WantedMovie{
+			MovieID:           candidate.MovieID,
+			VersionID:         candidate.VersionID,
+			Status:            status,
+			Reason:            reason,
+			CurrentQualityID:  currentQualityID,
+			TargetQualityID:   targetQualityID,
+			IsAvailable:       true,
+			Priority:          s.calculatePriority(movie, status),
+			MaxSearchAttempts: 10,
+		})
+		if candidate.WantedID != nil {
+			updated++
+		} else {
+			created++
 		}
-		return nil
 	}
 
-	// Movie is wanted
-	wantedMovie := models.WantedMovie{
-		MovieID:           movie.ID,
-		Status:            wantedStatus,
-		Reason:            reason,
-		CurrentQualityID:  currentQualityID,
-		TargetQualityID:   targetQualityID,
-		IsAvailable:       movie.IsAvailable,
-		Priority:          s.calculatePriority(movie, wantedStatus),
-		MaxSearchAttempts: 10,
-	}
-
-	if hasExisting {
-		// Update existing wanted movie
-		wantedMovie.ID = existingWanted.ID
-		wantedMovie.SearchAttempts = existingWanted.SearchAttempts
-		wantedMovie.LastSearchTime = existingWanted.LastSearchTime
-		wantedMovie.NextSearchTime = existingWanted.NextSearchTime
-		wantedMovie.SearchFailures = existingWanted.SearchFailures
-		wantedMovie.CreatedAt = existingWanted.CreatedAt
-
-		if err := s.db.GORM.Save(&wantedMovie).Error; err != nil {
-			return fmt.Errorf("failed to update wanted movie: %w", err)
+	err = s.db.GORM.Transaction(func(tx *gorm.DB) error {
+		if len(wanted) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "movie_id"}, {Name: "version_id"}},
+				DoUpdates: clause.AssignmentColumns(wantedRefreshColumns),
+			}).Create(&wanted).Error; err != nil {
+				return fmt.Errorf("failed to upsert wanted movies: %w", err)
+			}
 		}
-		(*updated)++
-	} else {
-		// Create new wanted movie
-		if err := s.db.GORM.Create(&wantedMovie).Error; err != nil {
-			return fmt.Errorf("failed to create wanted movie: %w", err)
+
+		if len(notWanted) > 0 {
+			result := tx.Where("id IN ?", notWanted).Delete(&models.WantedMovie{})
+			if result.Error != nil {
+				return fmt.Errorf("failed to remove from wanted: %w", result.Error)
+			}
+			removed = int(result.RowsAffected)
 		}
-		(*created)++
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
-	return nil
+	return created, updated, removed, nil
+}
+
+// key returns the candidate's position in its refresh pass: the version ID for versions and the
+// movie ID for the movies themselves
+func (c *wantedCandidate) key() int {
+	if c.VersionID != models.PrimaryVersionID {
+		return c.VersionID
+	}
+	return c.MovieID
+}
+
+// movie returns the subset of the candidate's movie that wanted analysis looks at
+func (c *wantedCandidate) movie() *models.Movie {
+	movie := &models.Movie{
+		ID:          c.MovieID,
+		Year:        c.Year,
+		Popularity:  c.Popularity,
+		HasFile:     c.HasFile,
+		Monitored:   true,
+		IsAvailable: true,
+	}
+	if c.FileID != nil {
+		movie.MovieFile = &models.MovieFile{ID: *c.FileID, MovieID: c.MovieID, Quality: c.FileQuality}
+	}
+	return movie
 }
 
 // determineWantedStatus determines if a movie is wanted and why
@@ -453,37 +757,32 @@ func (s *WantedMoviesService) determineWantedStatus(movie *models.Movie,
 	return "", nil, 0, ""
 }
 
-// calculatePriority determines the priority for a wanted movie
+// calculatePriority determines the priority for a wanted movie: missing movies start higher than
+// upgrades, and recency, popularity and availability raise it as configured
 func (s *WantedMoviesService) calculatePriority(movie *models.Movie, status models.WantedStatus) models.WantedPriority {
-	priority := models.PriorityNormal
-
-	// Missing movies get higher priority than upgrades
+	weights := s.priority
+	priority := models.WantedPriority(weights.CutoffUnmet)
 	if status == models.WantedStatusMissing {
-		priority = models.PriorityHigh
+		priority = models.WantedPriority(weights.Missing)
 	}
 
-	// Newer movies get higher priority
-	if movie.Year >= time.Now().Year() {
-		if priority < models.PriorityHigh {
-			priority = models.PriorityHigh
-		}
-	} else if movie.Year >= time.Now().Year()-1 {
-		if priority < models.PriorityNormal {
-			priority = models.PriorityNormal
+	raise := func(level int, applies bool) {
+		if applies && models.WantedPriority(level) > priority {
+			priority = models.WantedPriority(level)
 		}
 	}
 
-	// Popular movies get higher priority (based on popularity score)
-	if movie.Popularity > 50.0 {
-		if priority < models.PriorityHigh {
-			priority = models.PriorityHigh
-		}
-	} else if movie.Popularity > 20.0 {
-		if priority < models.PriorityNormal {
-			priority = models.PriorityNormal
-		}
+	year := time.Now().Year()
+	raise(weights.CurrentYear, movie.Year >= year)
+	raise(weights.PreviousYear, movie.Year == year-1)
+
+	if weights.PopularityWeighting {
+		raise(weights.VeryPopular, movie.Popularity > weights.VeryPopularThreshold)
+		raise(weights.Popular, movie.Popularity > weights.PopularThreshold &&
+			movie.Popularity <= weights.VeryPopularThreshold)
 	}
 
+	raise(weights.Available, movie.IsAvailable)
 	return priority
 }
 
@@ -498,7 +797,7 @@ func (s *WantedMoviesService) GetWantedStats() (*models.WantedMoviesStats, error
 
 	// Missing count
 	if err := s.db.GORM.Model(&models.WantedMovie{}).
-		Where("status = ?", models.WantedStatusMissing).
+		Where("wanted_movies.status = ?", models.WantedStatusMissing).
 		Count(&stats.MissingCount).Error; err != nil {
 		return nil, fmt.Errorf("failed to get missing count: %w", err)
 	}
@@ -561,7 +860,7 @@ func (s *WantedMoviesService) GetByID(id int) (*models.WantedMovie, error) {
 	return &wantedMovie, nil
 }
 
-// GetByMovieID retrieves a wanted movie by its movie ID
+// GetByMovieID retrieves the wanted row of a movie's primary file by its movie ID
 func (s *WantedMoviesService) GetByMovieID(movieID int) (*models.WantedMovie, error) {
 	var wantedMovie models.WantedMovie
 	err := s.db.GORM.
@@ -569,7 +868,7 @@ func (s *WantedMoviesService) GetByMovieID(movieID int) (*models.WantedMovie, er
 		Preload("Movie.MovieFile").
 		Preload("CurrentQuality").
 		Preload("TargetQuality").
-		Where("movie_id = ?", movieID).
+		Where("movie_id = ? AND version_id = ?", movieID, models.PrimaryVersionID).
 		First(&wantedMovie).Error
 
 	if err != nil {
@@ -701,11 +1000,18 @@ func (s *WantedMoviesService) bulkSearch(movieIDs []int) error {
 		return fmt.Errorf("failed to update search metadata: %w", updateResult.Error)
 	}
 
+	err := s.db.GORM.Model(&models.Movie{}).Where("id IN ?", movieIDs).
+		UpdateColumn("last_search_time", &now).Error
+	if err != nil {
+		return fmt.Errorf("failed to update movie search time: %w", err)
+	}
+
 	s.logger.Info("Bulk search initiated for wanted movies", "count", len(wantedMovies))
 	return nil
 }
 
-// GetEligibleForSearch returns wanted movies that are eligible for search
+// GetEligibleForSearch returns wanted movies that are eligible for search, leaving out snoozed
+// movies and movies excluded from automatic searches
 func (s *WantedMoviesService) GetEligibleForSearch(limit int) ([]models.WantedMovie, error) {
 	if limit <= 0 {
 		limit = 50 // Default limit
@@ -718,6 +1024,8 @@ func (s *WantedMoviesService) GetEligibleForSearch(limit int) ([]models.WantedMo
 		Preload("TargetQuality").
 		Where("is_available = ? AND search_attempts < max_search_attempts", true).
 		Where("next_search_time IS NULL OR next_search_time <= ?", time.Now()).
+		Where("excluded_from_auto_search = ?", false).
+		Where("snoozed_until IS NULL OR snoozed_until <= ?", time.Now()).
 		Order("priority DESC, created_at ASC").
 		Limit(limit).
 		Find(&wantedMovies).Error
@@ -729,6 +1037,78 @@ func (s *WantedMoviesService) GetEligibleForSearch(limit int) ([]models.WantedMo
 	return wantedMovies, nil
 }
 
+// GetSearchCandidates returns the available wanted movies of the given statuses whose movies are
+// monitored and that are neither snoozed nor excluded from automatic searches, highest priority
+// and longest unsearched first, without a limit
+func (s *WantedMoviesService) GetSearchCandidates(statuses ...models.WantedStatus) ([]models.WantedMovie, error) {
+	var wantedMovies []models.WantedMovie
+	err := s.db.GORM.
+		Joins("JOIN movies ON movies.id = wanted_movies.movie_id").
+		Where("wanted_movies.is_available = ? AND movies.monitored = ?", true, true).
+		Where("wanted_movies.status IN ?", statuses).
+		Where("wanted_movies.excluded_from_auto_search = ?", false).
+		Where("wanted_movies.snoozed_until IS NULL OR wanted_movies.snoozed_until <= ?", time.Now()).
+		Order("wanted_movies.priority DESC").
+		Order("wanted_movies.last_search_time IS NOT NULL, wanted_movies.last_search_time ASC").
+		Find(&wantedMovies).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wanted movies to search: %w", err)
+	}
+
+	return wantedMovies, nil
+}
+
+// SnoozeWantedMovie keeps automatic searches away from a wanted movie until the given time.
+// Manual searches still pick it up.
+func (s *WantedMoviesService) SnoozeWantedMovie(id int, until time.Time) error {
+	if !until.After(time.Now()) {
+		return models.ValidationError{Field: "until", Message: "Snooze time must be in the future"}
+	}
+	if err := s.updateAutoSearchOptOut(id, "snoozed_until", until); err != nil {
+		return err
+	}
+
+	s.logger.Info("Snoozed wanted movie", "wantedMovieId", id, "until", until)
+	return nil
+}
+
+// UnsnoozeWantedMovie lets automatic searches pick up a snoozed wanted movie again
+func (s *WantedMoviesService) UnsnoozeWantedMovie(id int) error {
+	if err := s.updateAutoSearchOptOut(id, "snoozed_until", nil); err != nil {
+		return err
+	}
+
+	s.logger.Info("Unsnoozed wanted movie", "wantedMovieId", id)
+	return nil
+}
+
+// SetAutoSearchExcluded excludes a wanted movie from automatic searches, or includes it again.
+// An excluded movie stays monitored and wanted, so manual searches still pick it up.
+func (s *WantedMoviesService) SetAutoSearchExcluded(id int, excluded bool) error {
+	if err := s.updateAutoSearchOptOut(id, "excluded_from_auto_search", excluded); err != nil {
+		return err
+	}
+
+	s.logger.Info("Updated wanted movie automatic search exclusion", "wantedMovieId", id, "excluded", excluded)
+	return nil
+}
+
+// updateAutoSearchOptOut sets one of the automatic search opt-out columns of a wanted movie
+func (s *WantedMoviesService) updateAutoSearchOptOut(id int, column string, value interface{}) error {
+	var wantedMovie models.WantedMovie
+	if err := s.db.GORM.Select("id").First(&wantedMovie, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("wanted movie not found")
+		}
+		return fmt.Errorf("failed to get wanted movie: %w", err)
+	}
+
+	if err := s.db.GORM.Model(&wantedMovie).Update(column, value).Error; err != nil {
+		return fmt.Errorf("failed to update wanted movie: %w", err)
+	}
+	return nil
+}
+
 // MarkSearchCompleted marks a wanted movie search as completed (successful)
 func (s *WantedMoviesService) MarkSearchCompleted(movieID int) error {
 	// Remove from wanted list if movie now has adequate file
@@ -22,7 +22,7 @@ type testServices struct {
 func setupWantedTestServices(db *database.Database, logger *logger.Logger) *testServices {
 	movieService := NewMovieService(db, logger)
 	qualityService := NewQualityService(db, logger)
-	wantedService := NewWantedMoviesService(db, logger, movieService, qualityService)
+	wantedService := NewWantedMoviesService(db, logger, movieService, qualityService, defaultWantedPriority)
 
 	return &testServices{
 		movieService:   movieService,
@@ -208,6 +208,37 @@ func TestWantedMoviesService_RefreshWantedMovies(t *testing.T) {
 	verifyRefreshWantedResults(t, services.wantedService, db, missingMovie.ID)
 }
 
+func TestWantedMoviesService_RefreshWantedMoviesUpdatesExisting(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	services := setupWantedTestServices(db, logger)
+	initializeQualityDefinitions(t, services.qualityService)
+	profile := createTestQualityProfile(t, services.qualityService)
+
+	missingMovie := createTestMissingMovie(t, services.movieService, profile.ID)
+	require.NoError(t, services.wantedService.RefreshWantedMovies())
+
+	// A second refresh keeps search bookkeeping on rows that are still wanted
+	require.NoError(t, db.GORM.Model(&models.WantedMovie{}).Where("movie_id = ?", missingMovie.ID).
+		Update("search_attempts", 4).Error)
+	require.NoError(t, services.wantedService.RefreshWantedMovies())
+
+	wanted, err := services.wantedService.GetByMovieID(missingMovie.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, wanted.SearchAttempts)
+	assert.Equal(t, models.WantedStatusMissing, wanted.Status)
+
+	// Unmonitored movies drop out of the wanted list
+	missingMovie.Monitored = false
+	require.NoError(t, services.movieService.Update(missingMovie))
+	require.NoError(t, services.wantedService.RefreshWantedMovies())
+
+	var count int64
+	require.NoError(t, db.GORM.Model(&models.WantedMovie{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
 func TestWantedMoviesService_GetEligibleForSearch(t *testing.T) {
 	// Setup test database
 	db, logger := setupTestDB(t)
@@ -215,7 +246,7 @@ func TestWantedMoviesService_GetEligibleForSearch(t *testing.T) {
 
 	movieService := NewMovieService(db, logger)
 	qualityService := NewQualityService(db, logger)
-	wantedService := NewWantedMoviesService(db, logger, movieService, qualityService)
+	wantedService := NewWantedMoviesService(db, logger, movieService, qualityService, defaultWantedPriority)
 
 	// Create test movies
 	movie1 := &models.Movie{
@@ -273,6 +304,50 @@ func TestWantedMoviesService_GetEligibleForSearch(t *testing.T) {
 	assert.True(t, eligible[0].IsEligibleForSearch())
 }
 
+func TestWantedMoviesService_AutoSearchOptOut(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer cleanupTestDB(db)
+
+	movieService := NewMovieService(db, logger)
+	qualityService := NewQualityService(db, logger)
+	wantedService := NewWantedMoviesService(db, logger, movieService, qualityService, defaultWantedPriority)
+
+	movie := &models.Movie{Title: "Snoozed Movie", TmdbID: 22231, TitleSlug: "snoozed-movie", Monitored: true}
+	require.NoError(t, movieService.Create(movie))
+
+	wanted := &models.WantedMovie{
+		MovieID:           movie.ID,
+		Status:            models.WantedStatusMissing,
+		TargetQualityID:   7,
+		IsAvailable:       true,
+		MaxSearchAttempts: 10,
+	}
+	require.NoError(t, db.GORM.Create(wanted).Error)
+
+	eligible, err := wantedService.GetEligibleForSearch(10)
+	require.NoError(t, err)
+	assert.Len(t, eligible, 1)
+
+	require.Error(t, wantedService.SnoozeWantedMovie(wanted.ID, time.Now().Add(-time.Hour)))
+	require.NoError(t, wantedService.SnoozeWantedMovie(wanted.ID, time.Now().Add(time.Hour)))
+	eligible, err = wantedService.GetEligibleForSearch(10)
+	require.NoError(t, err)
+	assert.Empty(t, eligible)
+
+	require.NoError(t, wantedService.UnsnoozeWantedMovie(wanted.ID))
+	require.NoError(t, wantedService.SetAutoSearchExcluded(wanted.ID, true))
+	candidates, err := wantedService.GetSearchCandidates(models.WantedStatusMissing)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	require.NoError(t, wantedService.SetAutoSearchExcluded(wanted.ID, false))
+	candidates, err = wantedService.GetSearchCandidates(models.WantedStatusMissing)
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+
+	assert.Error(t, wantedService.SetAutoSearchExcluded(wanted.ID+1000, true))
+}
+
 func TestWantedMovie_IsEligibleForSearch(t *testing.T) {
 	// Test eligible movie
 	eligible := &models.WantedMovie{
@@ -322,6 +397,17 @@ func TestWantedMovie_IsEligibleForSearch(t *testing.T) {
 	assert.True(t, pastSearch.IsEligibleForSearch())
 }
 
+func TestWantedMovie_IsAutoSearchAllowed(t *testing.T) {
+	assert.True(t, (&models.WantedMovie{}).IsAutoSearchAllowed())
+	assert.False(t, (&models.WantedMovie{ExcludedFromAutoSearch: true}).IsAutoSearchAllowed())
+
+	future := time.Now().Add(time.Hour)
+	assert.False(t, (&models.WantedMovie{SnoozedUntil: &future}).IsAutoSearchAllowed())
+
+	past := time.Now().Add(-time.Hour)
+	assert.True(t, (&models.WantedMovie{SnoozedUntil: &past}).IsAutoSearchAllowed())
+}
+
 func TestWantedMovie_CalculateNextSearchTime(t *testing.T) {
 	// Test first attempt
 	wanted := &models.WantedMovie{
@@ -350,3 +436,46 @@ func TestWantedMovie_CalculateNextSearchTime(t *testing.T) {
 
 	assert.True(t, lowPriorityDelay > highPriorityDelay)
 }
+
+func TestWantedMoviesService_calculatePriority(t *testing.T) {
+	service := &WantedMoviesService{priority: defaultWantedPriority}
+	year := time.Now().Year()
+
+	old := &models.Movie{Year: 1999}
+	assert.Equal(t, models.PriorityHigh, service.calculatePriority(old, models.WantedStatusMissing))
+	assert.Equal(t, models.PriorityNormal, service.calculatePriority(old, models.WantedStatusUpgrade))
+
+	newRelease := &models.Movie{Year: year}
+	assert.Equal(t, models.PriorityHigh, service.calculatePriority(newRelease, models.WantedStatusCutoffUnmet))
+
+	popular := &models.Movie{Year: 1999, Popularity: 80}
+	assert.Equal(t, models.PriorityHigh, service.calculatePriority(popular, models.WantedStatusCutoffUnmet))
+
+	// Disabling popularity weighting ignores it entirely
+	service.priority.PopularityWeighting = false
+	assert.Equal(t, models.PriorityNormal, service.calculatePriority(popular, models.WantedStatusCutoffUnmet))
+
+	// Lower starting priorities and an availability factor
+	service.priority.CutoffUnmet = int(models.PriorityLow)
+	service.priority.Available = int(models.PriorityVeryHigh)
+	assert.Equal(t, models.PriorityLow, service.calculatePriority(old, models.WantedStatusUpgrade))
+	available := &models.Movie{Year: 1999, IsAvailable: true}
+	assert.Equal(t, models.PriorityVeryHigh, service.calculatePriority(available, models.WantedStatusUpgrade))
+}
+
+func TestNewWantedPriority(t *testing.T) {
+	priority, err := newWantedPriority(defaultWantedPriority)
+	require.NoError(t, err)
+	assert.Equal(t, defaultWantedPriority, priority)
+
+	invalid := defaultWantedPriority
+	invalid.Missing = 0
+	_, err = newWantedPriority(invalid)
+	assert.Error(t, err)
+
+	invalid = defaultWantedPriority
+	invalid.Popular = 6
+	priority, err = newWantedPriority(invalid)
+	assert.Error(t, err)
+	assert.Equal(t, defaultWantedPriority, priority)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"runtime"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+)
+
+const (
+	// lowMemoryBytes is the total memory below which pools are kept to their minimum
+	lowMemoryBytes = 2 << 30
+	// moderateMemoryBytes is the total memory below which pools are capped at a modest size
+	moderateMemoryBytes = 4 << 30
+
+	maxAutoSearchWorkers = 8
+	maxAutoImportWorkers = 4
+	maxAutoTaskWorkers   = 6
+
+	// defaultTaskWorkers matches the task pool size used before pools were sized automatically
+	defaultTaskWorkers = 3
+)
+
+// WorkerLimits holds the effective worker pool sizes along with the hardware they were sized for
+type WorkerLimits struct {
+	SearchWorkers int   `json:"searchWorkers"`
+	ImportWorkers int   `json:"importWorkers"`
+	TaskWorkers   int   `json:"taskWorkers"`
+	CPUCount      int   `json:"cpuCount"`
+	TotalMemory   int64 `json:"totalMemory"`
+}
+
+// ResolveWorkerLimits sizes the worker pools for this host, letting configured values override
+// the automatic sizes
+func ResolveWorkerLimits(cfg config.WorkerConfig, logger *logger.Logger) WorkerLimits {
+	_, totalMemory, err := systemMemory()
+	if err != nil {
+		logger.Warn("Failed to read system memory, sizing worker pools by CPU count only", "error", err)
+		totalMemory = 0
+	}
+
+	limits := resolveWorkerLimits(cfg, runtime.NumCPU(), totalMemory)
+	logger.Info("Sized worker pools", "searchWorkers", limits.SearchWorkers,
+		"importWorkers", limits.ImportWorkers, "taskWorkers", limits.TaskWorkers,
+		"cpuCount", limits.CPUCount, "totalMemory", limits.TotalMemory)
+	return limits
+}
+
+// resolveWorkerLimits derives the pool sizes from the CPU count and total memory. Searches wait
+// on indexers rather than the CPU, so they get the most workers; imports are bound by disk I/O.
+// An unknown totalMemory (0) leaves the sizes capped by CPU count alone.
+func resolveWorkerLimits(cfg config.WorkerConfig, cpus int, totalMemory int64) WorkerLimits {
+	cpus = max(cpus, 1)
+
+	search := clampWorkers(cpus*2, 2, maxAutoSearchWorkers)
+	imports := clampWorkers(cpus/2, 1, maxAutoImportWorkers)
+	tasks := clampWorkers(cpus, 2, maxAutoTaskWorkers)
+
+	switch {
+	case totalMemory <= 0:
+	case totalMemory < lowMemoryBytes:
+		search, imports, tasks = min(search, 2), 1, min(tasks, 2)
+	case totalMemory < moderateMemoryBytes:
+		search, imports, tasks = min(search, 4), min(imports, 2), min(tasks, defaultTaskWorkers)
+	}
+
+	if cfg.SearchWorkers > 0 {
+		search = cfg.SearchWorkers
+	}
+	if cfg.ImportWorkers > 0 {
+		imports = cfg.ImportWorkers
+	}
+	if cfg.TaskWorkers > 0 {
+		tasks = cfg.TaskWorkers
+	}
+
+	return WorkerLimits{
+		SearchWorkers: search,
+		ImportWorkers: imports,
+		TaskWorkers:   tasks,
+		CPUCount:      cpus,
+		TotalMemory:   totalMemory,
+	}
+}
+
+func clampWorkers(n, lower, upper int) int {
+	return min(max(n, lower), upper)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveWorkerLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.WorkerConfig
+		cpus        int
+		totalMemory int64
+		want        [3]int
+	}{
+		{"small NAS", config.WorkerConfig{}, 4, 1 << 30, [3]int{2, 1, 2}},
+		{"modest memory", config.WorkerConfig{}, 4, 3 << 30, [3]int{4, 2, 3}},
+		{"server", config.WorkerConfig{}, 16, 32 << 30, [3]int{8, 4, 6}},
+		{"single core", config.WorkerConfig{}, 1, 16 << 30, [3]int{2, 1, 2}},
+		{"unknown memory", config.WorkerConfig{}, 2, 0, [3]int{4, 1, 2}},
+		{"overrides", config.WorkerConfig{SearchWorkers: 12, TaskWorkers: 1}, 4, 1 << 30, [3]int{12, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := resolveWorkerLimits(tt.cfg, tt.cpus, tt.totalMemory)
+			assert.Equal(t, tt.want, [3]int{limits.SearchWorkers, limits.ImportWorkers, limits.TaskWorkers})
+			assert.Equal(t, tt.cpus, limits.CPUCount)
+			assert.Equal(t, tt.totalMemory, limits.TotalMemory)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrNoAPIKey is returned by every request while no API key is configured
+	ErrNoAPIKey = errors.New("TMDB API key not configured")
+	// ErrInvalidAPIKey is returned when TMDB rejected the API key, and by every further request
+	// until the key is replaced or validates again
+	ErrInvalidAPIKey = errors.New("TMDB API key is invalid")
+)
+
+// KeyStatus is what is known about the API key the client uses
+type KeyStatus struct {
+	Configured bool
+	// Checked is set once TMDB answered a request made with the key, telling whether it is Valid
+	Checked   bool
+	Valid     bool
+	CheckedAt time.Time
+}
+
+// SetAPIKey replaces the API key used by all further requests, forgetting what was known about the
+// previous key. A key validated just before keeps the answer.
+func (c *Client) SetAPIKey(key string) {
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+
+	c.apiKey = key
+	c.keyStatus = KeyStatus{Configured: key != ""}
+	if key != "" && c.candidateKey == key {
+		c.keyStatus = c.candidateStatus
+	}
+	c.candidateKey = ""
+}
+
+// APIKey returns the API key in use
+func (c *Client) APIKey() string {
+	key, _ := c.currentKey()
+	return key
+}
+
+// KeyStatus returns what is known about the API key in use
+func (c *Client) KeyStatus() KeyStatus {
+	_, status := c.currentKey()
+	return status
+}
+
+// ValidateKey checks a key against TMDB's configuration endpoint, returning ErrInvalidAPIKey when
+// TMDB rejects it. An empty key validates the key in use. Validating the key in use records the
+// answer, so a key that was rejected is used again once it validates.
+func (c *Client) ValidateKey(ctx context.Context, key string) error {
+	if key == "" {
+		key = c.APIKey()
+	}
+	if key == "" {
+		return ErrNoAPIKey
+	}
+
+	var configuration struct {
+		Images struct {
+			BaseURL string `json:"base_url"`
+		} `json:"images"`
+	}
+	err := c.get(ctx, "/configuration", url.Values{}, key, &configuration)
+	c.recordKeyResult(key, err)
+	return err
+}
+
+// currentKey returns the API key in use with its status
+func (c *Client) currentKey() (string, KeyStatus) {
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
+	return c.apiKey, c.keyStatus
+}
+
+// recordKeyResult notes whether TMDB accepted the key from the outcome of a request made with it.
+// Failures other than a rejected key say nothing about the key and are ignored. The result for a
+// key not in use is kept for when it is set.
+func (c *Client) recordKeyResult(key string, err error) {
+	if err != nil && !errors.Is(err, ErrInvalidAPIKey) {
+		return
+	}
+
+	valid := err == nil
+	status := KeyStatus{Configured: true, Checked: true, Valid: valid, CheckedAt: time.Now()}
+
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	if key != c.apiKey {
+		c.candidateKey, c.candidateStatus = key, status
+		return
+	}
+
+	if !valid && (!c.keyStatus.Checked || c.keyStatus.Valid) {
+		c.logger.Error("TMDB rejected the API key, metadata requests are stopped until it is replaced")
+	}
+	c.keyStatus = status
+}
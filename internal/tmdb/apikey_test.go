@@ -0,0 +1,56 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_APIKey(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("api_key") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 603, "title": "The Matrix"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{TMDB: config.TMDBConfig{APIKey: "bad"}}
+	client := NewClient(cfg, logger.New(config.LogConfig{Level: "error", Output: "none"}))
+	client.baseURL = server.URL
+	assert.Equal(t, KeyStatus{Configured: true}, client.KeyStatus())
+
+	// A rejected key stops further requests until it is replaced
+	_, err := client.GetMovie(603)
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, err = client.GetMovie(603)
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.True(t, client.KeyStatus().Checked)
+	assert.False(t, client.KeyStatus().Valid)
+
+	// Validating another key doesn't touch the key in use, and its answer carries over when it is set
+	require.NoError(t, client.ValidateKey(context.Background(), "good"))
+	assert.False(t, client.KeyStatus().Valid)
+	client.SetAPIKey("good")
+	assert.True(t, client.KeyStatus().Valid)
+
+	movie, err := client.GetMovie(603)
+	require.NoError(t, err)
+	assert.Equal(t, "The Matrix", movie.Title)
+
+	client.SetAPIKey("")
+	_, err = client.GetMovie(603)
+	assert.ErrorIs(t, err, ErrNoAPIKey)
+	assert.ErrorIs(t, client.ValidateKey(context.Background(), ""), ErrNoAPIKey)
+}
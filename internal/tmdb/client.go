@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/radarr/radarr-go/internal/config"
+	"github.com/radarr/radarr-go/internal/httpclient"
 	"github.com/radarr/radarr-go/internal/logger"
 )
 
@@ -18,29 +21,36 @@ const (
 	baseURL          = "https://api.themoviedb.org/3"
 	defaultTimeout   = 30 * time.Second
 	defaultUserAgent = "Radarr-Go/1.0"
-	maxRetries       = 3
-	retryDelay       = 1 * time.Second
 )
 
 // Client provides access to TMDB API
 type Client struct {
 	httpClient *http.Client
-	apiKey     string
 	baseURL    string
 	userAgent  string
+	limiter    *rateLimiter
 	logger     *logger.Logger
+
+	// keyMu guards the API key, which can be replaced at runtime, and what is known about it
+	keyMu     sync.RWMutex
+	apiKey    string
+	keyStatus KeyStatus
+	// candidateKey was validated without being in use; its status applies if it is set next
+	candidateKey    string
+	candidateStatus KeyStatus
 }
 
-// NewClient creates a new TMDB API client
+// NewClient creates a new TMDB API client. All requests made through the client, whichever
+// operation or task makes them, share its configured rate limit.
 func NewClient(cfg *config.Config, logger *logger.Logger) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
-		apiKey:    cfg.TMDB.APIKey,
-		baseURL:   baseURL,
-		userAgent: defaultUserAgent,
-		logger:    logger,
+		httpClient: httpclient.New(defaultTimeout),
+		baseURL:    baseURL,
+		userAgent:  defaultUserAgent,
+		limiter:    newRateLimiter(cfg.TMDB.RequestsPerSecond, cfg.TMDB.Burst),
+		logger:     logger,
+		apiKey:     cfg.TMDB.APIKey,
+		keyStatus:  KeyStatus{Configured: cfg.TMDB.APIKey != ""},
 	}
 }
 
@@ -71,6 +81,75 @@ type Movie struct {
 	BelongsToCollection *Collection         `json:"belongs_to_collection"`
 	Budget              int64               `json:"budget"`
 	Revenue             int64               `json:"revenue"`
+	// ReleaseDates lists the certification and releases of the movie in each country
+	ReleaseDates ReleaseDates `json:"release_dates"`
+	// WatchProviders lists where the movie can be watched in each country
+	WatchProviders WatchProviders `json:"watch/providers"`
+}
+
+// Release types TMDB distinguishes
+const (
+	ReleaseTypePremiere          = 1
+	ReleaseTypeTheatricalLimited = 2
+	ReleaseTypeTheatrical        = 3
+	ReleaseTypeDigital           = 4
+	ReleaseTypePhysical          = 5
+	ReleaseTypeTV                = 6
+)
+
+// ReleaseDates holds the releases of a movie grouped by country
+type ReleaseDates struct {
+	Results []CountryReleases `json:"results"`
+}
+
+// CountryReleases holds the releases of a movie in one country
+type CountryReleases struct {
+	ISO31661     string        `json:"iso_3166_1"`
+	ReleaseDates []ReleaseDate `json:"release_dates"`
+}
+
+// ReleaseDate is one release of a movie with the certification it was given
+type ReleaseDate struct {
+	Certification string `json:"certification"`
+	Note          string `json:"note"`
+	ReleaseDate   string `json:"release_date"`
+	Type          int    `json:"type"`
+}
+
+// WatchProviders holds where a movie can be watched, keyed by ISO 3166-1 country
+type WatchProviders struct {
+	Results map[string]RegionWatchProviders `json:"results"`
+}
+
+// RegionWatchProviders holds where a movie can be watched in one country, by how it is offered
+type RegionWatchProviders struct {
+	Link     string          `json:"link"`
+	Flatrate []WatchProvider `json:"flatrate"`
+	Free     []WatchProvider `json:"free"`
+	Ads      []WatchProvider `json:"ads"`
+	Rent     []WatchProvider `json:"rent"`
+	Buy      []WatchProvider `json:"buy"`
+}
+
+// WatchProvider is a streaming service, store or channel offering movies
+type WatchProvider struct {
+	ProviderID      int    `json:"provider_id"`
+	ProviderName    string `json:"provider_name"`
+	LogoPath        string `json:"logo_path"`
+	DisplayPriority int    `json:"display_priority"`
+}
+
+// DiscoverOptions filters the movies returned by Discover
+type DiscoverOptions struct {
+	Page int
+	// WatchRegion is the ISO 3166-1 country the watch provider filters apply to
+	WatchRegion string
+	// WithWatchProviders keeps movies offered by any of the providers
+	WithWatchProviders []int
+	// WithoutWatchProviders drops movies offered by any of the providers
+	WithoutWatchProviders []int
+	// MonetizationTypes limits the provider filters to offers of these kinds, e.g. "flatrate"
+	MonetizationTypes []string
 }
 
 // Genre represents a movie genre
@@ -163,15 +242,12 @@ type CrewMember struct {
 	Gender      int    `json:"gender"`
 }
 
-// GetMovie retrieves a movie by TMDB ID
+// GetMovie retrieves a movie by TMDB ID along with its release dates and watch providers in every
+// country
 func (c *Client) GetMovie(id int) (*Movie, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("TMDB API key not configured")
-	}
-
 	endpoint := fmt.Sprintf("/movie/%d", id)
 	params := url.Values{
-		"api_key": {c.apiKey},
+		"append_to_response": {"release_dates,watch/providers"},
 	}
 
 	var movie Movie
@@ -185,14 +261,9 @@ func (c *Client) GetMovie(id int) (*Movie, error) {
 
 // SearchMovies searches for movies by query
 func (c *Client) SearchMovies(query string, page int) (*SearchResponse, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("TMDB API key not configured")
-	}
-
 	endpoint := "/search/movie"
 	params := url.Values{
-		"api_key": {c.apiKey},
-		"query":   {query},
+		"query": {query},
 	}
 
 	if page > 0 {
@@ -210,14 +281,8 @@ func (c *Client) SearchMovies(query string, page int) (*SearchResponse, error) {
 
 // GetCredits retrieves movie credits by TMDB ID
 func (c *Client) GetCredits(id int) (*Credits, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("TMDB API key not configured")
-	}
-
 	endpoint := fmt.Sprintf("/movie/%d/credits", id)
-	params := url.Values{
-		"api_key": {c.apiKey},
-	}
+	params := url.Values{}
 
 	var credits Credits
 	err := c.makeRequest(endpoint, params, &credits)
@@ -228,17 +293,81 @@ func (c *Client) GetCredits(id int) (*Credits, error) {
 	return &credits, nil
 }
 
-// GetPopular retrieves popular movies
-func (c *Client) GetPopular(page int) (*SearchResponse, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("TMDB API key not configured")
+// GetWatchProviders retrieves where a movie can be watched in each country
+func (c *Client) GetWatchProviders(id int) (*WatchProviders, error) {
+	endpoint := fmt.Sprintf("/movie/%d/watch/providers", id)
+
+	var providers WatchProviders
+	if err := c.makeRequest(endpoint, url.Values{}, &providers); err != nil {
+		return nil, fmt.Errorf("failed to get watch providers for movie %d: %w", id, err)
 	}
 
-	endpoint := "/movie/popular"
+	return &providers, nil
+}
+
+// GetAvailableWatchProviders retrieves the providers TMDB knows of for movies in a country
+func (c *Client) GetAvailableWatchProviders(region string) ([]WatchProvider, error) {
+	endpoint := "/watch/providers/movie"
+	params := url.Values{}
+	if region != "" {
+		params.Set("watch_region", region)
+	}
+
+	var response struct {
+		Results []WatchProvider `json:"results"`
+	}
+	if err := c.makeRequest(endpoint, params, &response); err != nil {
+		return nil, fmt.Errorf("failed to get watch providers: %w", err)
+	}
+
+	return response.Results, nil
+}
+
+// Discover retrieves movies matching the given options, most popular first
+func (c *Client) Discover(options DiscoverOptions) (*SearchResponse, error) {
+	endpoint := "/discover/movie"
 	params := url.Values{
-		"api_key": {c.apiKey},
+		"sort_by": {"popularity.desc"},
 	}
 
+	if options.Page > 0 {
+		params.Set("page", strconv.Itoa(options.Page))
+	}
+	if options.WatchRegion != "" {
+		params.Set("watch_region", options.WatchRegion)
+	}
+	if len(options.WithWatchProviders) > 0 {
+		params.Set("with_watch_providers", joinIDs(options.WithWatchProviders, "|"))
+	}
+	if len(options.WithoutWatchProviders) > 0 {
+		params.Set("without_watch_providers", joinIDs(options.WithoutWatchProviders, "|"))
+	}
+	if len(options.MonetizationTypes) > 0 {
+		params.Set("with_watch_monetization_types", strings.Join(options.MonetizationTypes, "|"))
+	}
+
+	var response SearchResponse
+	if err := c.makeRequest(endpoint, params, &response); err != nil {
+		return nil, fmt.Errorf("failed to discover movies: %w", err)
+	}
+
+	return &response, nil
+}
+
+// joinIDs formats IDs as a TMDB list, where "|" means any of them and "," all of them
+func joinIDs(ids []int, separator string) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.Itoa(id)
+	}
+	return strings.Join(values, separator)
+}
+
+// GetPopular retrieves popular movies
+func (c *Client) GetPopular(page int) (*SearchResponse, error) {
+	endpoint := "/movie/popular"
+	params := url.Values{}
+
 	if page > 0 {
 		params.Set("page", strconv.Itoa(page))
 	}
@@ -254,18 +383,12 @@ func (c *Client) GetPopular(page int) (*SearchResponse, error) {
 
 // GetTrending retrieves trending movies
 func (c *Client) GetTrending(timeWindow string, page int) (*SearchResponse, error) {
-	if c.apiKey == "" {
-		return nil, fmt.Errorf("TMDB API key not configured")
-	}
-
 	if timeWindow == "" {
 		timeWindow = "week"
 	}
 
 	endpoint := fmt.Sprintf("/trending/movie/%s", timeWindow)
-	params := url.Values{
-		"api_key": {c.apiKey},
-	}
+	params := url.Values{}
 
 	if page > 0 {
 		params.Set("page", strconv.Itoa(page))
@@ -280,60 +403,74 @@ func (c *Client) GetTrending(timeWindow string, page int) (*SearchResponse, erro
 	return &response, nil
 }
 
-// makeRequest makes an HTTP request to the TMDB API
+// makeRequest makes an HTTP request to the TMDB API with the configured key once the rate limiter
+// allows it. Without a key, or with a key TMDB has rejected, it fails without calling TMDB. Retries
+// with backoff, including on rate limiting, are handled by the shared HTTP client.
 func (c *Client) makeRequest(endpoint string, params url.Values, result interface{}) error {
-	reqURL := c.baseURL + endpoint + "?" + params.Encode()
+	key, status := c.currentKey()
+	switch {
+	case key == "":
+		return ErrNoAPIKey
+	case status.Checked && !status.Valid:
+		return ErrInvalidAPIKey
+	}
 
-	var lastErr error
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(retryDelay * time.Duration(attempt))
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
 
-		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-		defer cancel()
-		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
-			continue
-		}
+	err := c.get(ctx, endpoint, params, key, result)
+	c.recordKeyResult(key, err)
+	return err
+}
 
-		req.Header.Set("User-Agent", c.userAgent)
-		req.Header.Set("Accept", "application/json")
+// get requests an endpoint with the given key and decodes the response into result
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values, key string, result interface{}) error {
+	query := url.Values{}
+	for name, values := range params {
+		query[name] = values
+	}
+	query.Set("api_key", key)
+	reqURL := c.baseURL + endpoint + "?" + query.Encode()
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
-			c.logger.Warn("TMDB API request failed, retrying", "attempt", attempt+1, "error", err)
-			continue
-		}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for TMDB rate limit: %w", err)
+	}
 
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				c.logger.Warn("Failed to close response body", "error", err)
-			}
-		}()
-
-		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("rate limited by TMDB API")
-			c.logger.Warn("TMDB API rate limited, retrying", "attempt", attempt+1)
-			time.Sleep(retryDelay * 2)
-			continue
-		}
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("API request failed with status %d", resp.StatusCode)
-			c.logger.Error("TMDB API request failed", "status", resp.StatusCode, "url", reqURL)
-			continue
-		}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
 
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			lastErr = fmt.Errorf("failed to decode response: %w", err)
-			continue
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Warn("TMDB API request failed", "error", err)
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.logger.Warn("Failed to close response body", "error", err)
 		}
+	}()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("rate limited by TMDB API")
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrInvalidAPIKey
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Error("TMDB API request failed", "status", resp.StatusCode, "endpoint", endpoint)
+		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
 
-		return nil
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return lastErr
+	return nil
 }
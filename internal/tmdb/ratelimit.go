@@ -0,0 +1,75 @@
+package tmdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket pacing the requests of every operation sharing a client. Tokens
+// refill at rate per second up to burst; a request waits until a token is free.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newRateLimiter creates a limiter allowing rate requests per second with bursts of up to burst,
+// starting full. A rate of zero or less disables limiting, and a burst below one allows one.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	limiter := &rateLimiter{rate: rate, burst: float64(max(burst, 1)), now: time.Now}
+	limiter.tokens = limiter.burst
+	limiter.last = limiter.now()
+	return limiter
+}
+
+// Wait blocks until a request may be made or ctx is done. A nil limiter never waits.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, returning how long to wait until it is available. The bucket may go
+// negative so concurrent waiters queue behind each other rather than all waking at once.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token of a request that gave up waiting
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+1)
+}
@@ -0,0 +1,40 @@
+package tmdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(10, 2)
+	limiter.now = func() time.Time { return now }
+	limiter.last = now
+
+	// The burst goes out at once, then requests queue a tenth of a second apart
+	assert.Zero(t, limiter.reserve())
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve())
+	assert.Equal(t, 200*time.Millisecond, limiter.reserve())
+
+	// Waiting refills the bucket, but never past the burst
+	now = now.Add(time.Hour)
+	assert.Zero(t, limiter.reserve())
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve())
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0, 10))
+	assert.NoError(t, (*rateLimiter)(nil).Wait(context.Background()))
+
+	limiter := newRateLimiter(0.001, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,3 @@
+-- Migration 013 Down: Remove library maintenance scheduled task
+
+DELETE FROM scheduled_tasks WHERE name = 'Library Maintenance';
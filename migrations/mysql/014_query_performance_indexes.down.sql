@@ -0,0 +1,5 @@
+-- Migration 014 Down: Remove listing performance indexes
+
+DROP INDEX idx_wanted_movies_status_priority_created ON wanted_movies;
+DROP INDEX idx_movies_added ON movies;
+DROP INDEX idx_movies_monitored_has_file ON movies;
@@ -0,0 +1,10 @@
+-- Migration 014: Composite indexes for the movie, calendar, and wanted listings
+
+-- Monitored/has-file filters used by movie listings and wanted refresh
+CREATE INDEX idx_movies_monitored_has_file ON movies(monitored, has_file);
+
+-- Calendar date range lookups
+CREATE INDEX idx_movies_added ON movies(added);
+
+-- Default wanted ordering within a status
+CREATE INDEX idx_wanted_movies_status_priority_created ON wanted_movies(status, priority DESC, created_at DESC);
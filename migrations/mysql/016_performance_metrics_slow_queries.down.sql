@@ -0,0 +1,3 @@
+-- Migration 016 Down: Remove slow query count
+
+ALTER TABLE performance_metrics DROP COLUMN slow_query_count;
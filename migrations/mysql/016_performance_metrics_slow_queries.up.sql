@@ -0,0 +1,3 @@
+-- Migration 016: Count of slow database queries per performance sample
+
+ALTER TABLE performance_metrics ADD COLUMN slow_query_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+-- Migration 017 Down: Remove runtime, connection pool, and API latency percentile metrics
+
+ALTER TABLE performance_metrics
+    DROP COLUMN api_latency_p50_ms,
+    DROP COLUMN api_latency_p95_ms,
+    DROP COLUMN api_latency_p99_ms,
+    DROP COLUMN api_request_count,
+    DROP COLUMN db_open_connections,
+    DROP COLUMN db_wait_count,
+    DROP COLUMN goroutines,
+    DROP COLUMN gc_count,
+    DROP COLUMN gc_pause_ms;
@@ -0,0 +1,12 @@
+-- Migration 017: Runtime, connection pool, and API latency percentile metrics
+
+ALTER TABLE performance_metrics
+    ADD COLUMN api_latency_p50_ms FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN api_latency_p95_ms FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN api_latency_p99_ms FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN api_request_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN db_open_connections INT NOT NULL DEFAULT 0,
+    ADD COLUMN db_wait_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN goroutines INT NOT NULL DEFAULT 0,
+    ADD COLUMN gc_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN gc_pause_ms FLOAT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Migration 018 Down: Remove alternate titles
+
+ALTER TABLE movies DROP COLUMN alternate_titles;
@@ -0,0 +1,3 @@
+-- Migration 018: Alternate titles for fuzzy movie title search, which is ranked in memory on MySQL
+
+ALTER TABLE movies ADD COLUMN alternate_titles TEXT;
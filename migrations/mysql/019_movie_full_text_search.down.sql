@@ -0,0 +1,7 @@
+-- Migration 019 Down: Remove full-text search over movie text
+
+DROP INDEX idx_movies_full_text ON movies;
+
+ALTER TABLE movies
+    DROP COLUMN cast_members,
+    DROP COLUMN crew_members;
@@ -0,0 +1,8 @@
+-- Migration 019: Cast and crew names and a FULLTEXT index over movie text
+
+ALTER TABLE movies
+    ADD COLUMN cast_members TEXT,
+    ADD COLUMN crew_members TEXT;
+
+CREATE FULLTEXT INDEX idx_movies_full_text
+    ON movies (title, original_title, alternate_titles, overview, cast_members, crew_members);
@@ -0,0 +1,3 @@
+-- Migration 020 Down: Remove saved custom filters
+
+DROP TABLE IF EXISTS custom_filters;
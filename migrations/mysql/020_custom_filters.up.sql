@@ -0,0 +1,13 @@
+-- Migration 020: Saved custom filters
+-- Named predicate lists applied server-side with GET /api/v3/movie?filterId=
+
+CREATE TABLE IF NOT EXISTS custom_filters (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    type VARCHAR(50) NOT NULL DEFAULT 'movieIndex',
+    label VARCHAR(255) NOT NULL,
+    filters TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_custom_filters_type ON custom_filters(type);
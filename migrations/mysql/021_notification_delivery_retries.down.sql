@@ -0,0 +1,8 @@
+-- Migration 021 Down: Remove notification delivery retry tracking
+
+DROP INDEX idx_notification_history_status ON notification_history;
+ALTER TABLE notification_history
+    DROP COLUMN payload,
+    DROP COLUMN last_attempt_at,
+    DROP COLUMN attempts,
+    DROP COLUMN status;
@@ -0,0 +1,13 @@
+-- Migration 021: Notification delivery retries
+-- Tracks the attempts of each delivery and keeps the message of failed deliveries for manual retry
+
+ALTER TABLE notification_history
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'delivered',
+    ADD COLUMN attempts INT NOT NULL DEFAULT 1,
+    ADD COLUMN last_attempt_at TIMESTAMP NULL,
+    ADD COLUMN payload TEXT;
+
+-- Earlier failures were recorded per attempt without their message; they are listed but cannot be retried
+UPDATE notification_history SET status = 'failed' WHERE successful = FALSE;
+
+CREATE INDEX idx_notification_history_status ON notification_history(status);
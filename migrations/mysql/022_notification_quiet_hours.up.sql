@@ -0,0 +1,9 @@
+-- Migration 022: Notification quiet hours and digests
+-- Queued deliveries wait in the notification history until they are due
+
+ALTER TABLE notification_history ADD COLUMN deliver_after TIMESTAMP NULL;
+
+CREATE INDEX idx_notification_history_deliver_after ON notification_history(deliver_after);
+
+INSERT IGNORE INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Notification Queue', 'FlushNotifications', 60000, 'normal', true, DATE_ADD(NOW(), INTERVAL 1 MINUTE)); -- Every minute
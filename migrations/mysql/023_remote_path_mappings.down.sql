@@ -0,0 +1,3 @@
+-- Migration 023 Down: Remove remote path mappings
+
+DROP TABLE IF EXISTS remote_path_mappings;
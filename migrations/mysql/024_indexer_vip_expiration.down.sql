@@ -0,0 +1,4 @@
+-- Migration 024 Down: Remove indexer VIP expiration
+-- Nothing to remove, as the up migration changes nothing on MySQL.
+
+SELECT 1;
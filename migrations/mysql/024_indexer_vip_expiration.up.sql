@@ -0,0 +1,4 @@
+-- Migration 024: Indexer VIP expiration
+-- The MySQL schema has no indexers table yet, so there is nothing to add the column to.
+
+SELECT 1;
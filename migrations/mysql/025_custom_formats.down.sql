@@ -0,0 +1,9 @@
+-- Migration 025 Down: Remove custom formats
+
+ALTER TABLE quality_profiles
+    DROP COLUMN upgrade_allowed,
+    DROP COLUMN format_items,
+    DROP COLUMN cutoff_format_score,
+    DROP COLUMN min_format_score;
+
+DROP TABLE IF EXISTS custom_formats;
@@ -0,0 +1,21 @@
+-- Migration 025: Custom formats
+-- Custom format storage, with the TRaSH-Guides id of formats imported from the guides, and the
+-- quality profile custom format scores already present in the PostgreSQL schema
+
+CREATE TABLE IF NOT EXISTS custom_formats (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    include_custom_format_when_renaming BOOLEAN DEFAULT FALSE,
+    specifications TEXT NOT NULL,
+    trash_id VARCHAR(64) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_custom_formats_trash_id ON custom_formats(trash_id);
+
+ALTER TABLE quality_profiles
+    ADD COLUMN min_format_score INT DEFAULT 0,
+    ADD COLUMN cutoff_format_score INT DEFAULT 0,
+    ADD COLUMN format_items TEXT NULL,
+    ADD COLUMN upgrade_allowed BOOLEAN DEFAULT TRUE;
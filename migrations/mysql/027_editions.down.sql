@@ -0,0 +1,4 @@
+-- Migration 027 Down: Remove editions
+-- Nothing to remove, as the up migration changes nothing on MySQL.
+
+SELECT 1;
@@ -0,0 +1,5 @@
+-- Migration 027: Editions
+-- The MySQL schema has no releases or media_management_config tables yet, so there are no
+-- columns to add.
+
+SELECT 1;
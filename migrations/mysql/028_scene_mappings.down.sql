@@ -0,0 +1,5 @@
+-- Migration 028 Down: Remove scene mappings
+
+DELETE FROM scheduled_tasks WHERE name = 'Scene Mappings';
+
+DROP TABLE IF EXISTS scene_mappings;
@@ -0,0 +1,19 @@
+-- Migration 028: Scene mappings
+-- Release titles that don't match the TMDB title, managed through the API or refreshed from a remote list
+
+CREATE TABLE IF NOT EXISTS scene_mappings (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    title VARCHAR(500) NOT NULL,
+    clean_title VARCHAR(500) NOT NULL,
+    tmdb_id INT NOT NULL,
+    year INT DEFAULT 0,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_scene_mappings_clean_title ON scene_mappings(clean_title);
+CREATE INDEX idx_scene_mappings_tmdb_id ON scene_mappings(tmdb_id);
+
+INSERT IGNORE INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Scene Mappings', 'RefreshSceneMappings', 43200000, 'low', true, DATE_ADD(NOW(), INTERVAL 5 MINUTE)); -- Every 12 hours
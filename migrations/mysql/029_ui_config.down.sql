@@ -0,0 +1,3 @@
+-- Migration 029 Down: Remove UI configuration
+
+DROP TABLE IF EXISTS ui_config;
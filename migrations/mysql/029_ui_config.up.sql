@@ -0,0 +1,18 @@
+-- Migration 029: UI configuration
+-- Calendar, date and time display settings and the UI language loaded by the frontend
+
+CREATE TABLE IF NOT EXISTS ui_config (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    first_day_of_week INT DEFAULT 0,
+    calendar_week_column_header VARCHAR(50) DEFAULT 'ddd M/D',
+    short_date_format VARCHAR(50) DEFAULT 'MMM D YYYY',
+    long_date_format VARCHAR(50) DEFAULT 'dddd, MMMM D YYYY',
+    time_format VARCHAR(50) DEFAULT 'h(:mm)a',
+    show_relative_dates BOOLEAN DEFAULT TRUE,
+    enable_color_impaired_mode BOOLEAN DEFAULT FALSE,
+    ui_language INT DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+INSERT IGNORE INTO ui_config (id) VALUES (1);
@@ -0,0 +1,8 @@
+-- Migration 031 Down: Remove movie versions
+
+DELETE FROM wanted_movies WHERE version_id <> 0;
+DROP INDEX uq_wanted_movies_movie_version ON wanted_movies;
+ALTER TABLE wanted_movies ADD CONSTRAINT uq_wanted_movies_movie_id UNIQUE (movie_id);
+ALTER TABLE wanted_movies DROP COLUMN version_id;
+
+DROP TABLE IF EXISTS movie_versions;
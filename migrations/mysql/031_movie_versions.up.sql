@@ -0,0 +1,25 @@
+-- Migration 031: Movie versions
+-- Keep additional quality versions of a movie, each with its own quality profile and folder
+
+CREATE TABLE IF NOT EXISTS movie_versions (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    movie_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    quality_profile_id INT NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    monitored BOOLEAN DEFAULT TRUE,
+    has_file BOOLEAN DEFAULT FALSE,
+    movie_file_id INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_movie_versions_movie_id FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_movie_versions_movie_id ON movie_versions(movie_id);
+
+-- Wanted rows belong to a version, 0 being the movie's primary file
+ALTER TABLE wanted_movies ADD COLUMN version_id INT NOT NULL DEFAULT 0;
+
+ALTER TABLE wanted_movies DROP INDEX uq_wanted_movies_movie_id;
+CREATE UNIQUE INDEX uq_wanted_movies_movie_version ON wanted_movies(movie_id, version_id);
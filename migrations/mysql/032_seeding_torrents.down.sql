@@ -0,0 +1,5 @@
+-- Migration 032 Down: Remove seeding torrents
+
+DELETE FROM scheduled_tasks WHERE name = 'Seeding Cleanup';
+
+DROP TABLE IF EXISTS seeding_torrents;
@@ -0,0 +1,26 @@
+-- Migration 032: Seeding torrents
+-- Track imported torrents while they seed, and remove them once their seed goals are met
+
+CREATE TABLE IF NOT EXISTS seeding_torrents (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    download_client_id INT NOT NULL,
+    indexer_id INT NULL,
+    movie_id INT DEFAULT 0,
+    download_id VARCHAR(255) NOT NULL,
+    title VARCHAR(500),
+    status VARCHAR(20) NOT NULL DEFAULT 'seeding',
+    ratio DOUBLE DEFAULT 0,
+    seeding_time BIGINT DEFAULT 0,
+    imported_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_checked_at TIMESTAMP NULL,
+    removed_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_seeding_torrents_client_download ON seeding_torrents(download_client_id, download_id);
+CREATE INDEX idx_seeding_torrents_status ON seeding_torrents(status);
+CREATE INDEX idx_seeding_torrents_movie_id ON seeding_torrents(movie_id);
+
+INSERT IGNORE INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Seeding Cleanup', 'CleanupSeededTorrents', 1800000, 'low', true, DATE_ADD(NOW(), INTERVAL 10 MINUTE)); -- Every 30 minutes
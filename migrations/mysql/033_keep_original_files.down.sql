@@ -0,0 +1,4 @@
+-- Migration 033 Down: Remove keep original files
+-- Nothing to remove, as the up migration changes nothing on MySQL.
+
+SELECT 1;
@@ -0,0 +1,5 @@
+-- Migration 033: Keep original files
+-- The MySQL schema has no media_management_config table yet, so there is nothing to add the
+-- column to.
+
+SELECT 1;
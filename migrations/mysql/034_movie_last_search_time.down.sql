@@ -0,0 +1,4 @@
+-- Migration 034 Down: Remove movie last search time
+
+DROP INDEX idx_movies_last_search_time ON movies;
+ALTER TABLE movies DROP COLUMN last_search_time;
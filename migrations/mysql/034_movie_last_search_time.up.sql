@@ -0,0 +1,15 @@
+-- Migration 034: Movie last search time
+-- When releases were last searched for each movie, seeded from the wanted movies searches
+
+ALTER TABLE movies ADD COLUMN last_search_time TIMESTAMP NULL;
+
+CREATE INDEX idx_movies_last_search_time ON movies(last_search_time);
+
+UPDATE movies
+JOIN (
+    SELECT movie_id, MAX(last_search_time) AS last_search_time
+    FROM wanted_movies
+    WHERE last_search_time IS NOT NULL
+    GROUP BY movie_id
+) searched ON movies.id = searched.movie_id
+SET movies.last_search_time = searched.last_search_time;
@@ -0,0 +1,3 @@
+-- Migration 035 Down: Remove wanted search tasks
+
+DELETE FROM scheduled_tasks WHERE name IN ('Missing Movies Search', 'Cutoff Unmet Movies Search');
@@ -0,0 +1,6 @@
+-- Migration 035: Wanted search tasks
+-- Scheduled searches of all missing and cutoff unmet movies, disabled by default
+
+INSERT IGNORE INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Missing Movies Search', 'MissingMoviesSearch', 21600000, 'low', false, DATE_ADD(NOW(), INTERVAL 6 HOUR)), -- Every 6 hours (disabled by default)
+    ('Cutoff Unmet Movies Search', 'CutoffUnmetMoviesSearch', 86400000, 'low', false, DATE_ADD(NOW(), INTERVAL 1 DAY)); -- Daily (disabled by default)
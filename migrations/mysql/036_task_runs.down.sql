@@ -0,0 +1,3 @@
+-- Migration 036 Down: Remove task runs
+
+DROP TABLE IF EXISTS task_runs;
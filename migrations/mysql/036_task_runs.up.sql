@@ -0,0 +1,19 @@
+-- Migration 036: Task runs
+-- Finished task executions with their outcome and reported counts, kept apart from the task queue
+
+CREATE TABLE IF NOT EXISTS task_runs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    task_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    command_name VARCHAR(255) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    metrics TEXT,
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_runs_command_started ON task_runs(command_name, started_at);
+CREATE INDEX idx_task_runs_started_at ON task_runs(started_at);
@@ -0,0 +1,3 @@
+-- Migration 037 Down: Remove movie release dates by country
+
+ALTER TABLE movies DROP COLUMN release_dates;
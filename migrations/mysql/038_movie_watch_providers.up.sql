@@ -0,0 +1,4 @@
+-- Migration 038: Movie watch providers
+-- Where each movie can be streamed, rented or bought in the configured watch region
+
+ALTER TABLE movies ADD COLUMN watch_providers TEXT;
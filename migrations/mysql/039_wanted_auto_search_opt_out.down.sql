@@ -0,0 +1,4 @@
+-- Migration 039 Down: Remove wanted movie automatic search opt-outs
+
+ALTER TABLE wanted_movies DROP COLUMN excluded_from_auto_search;
+ALTER TABLE wanted_movies DROP COLUMN snoozed_until;
@@ -0,0 +1,5 @@
+-- Migration 039: Wanted movie automatic search opt-outs
+-- Snooze a wanted movie until a date or exclude it from automatic searches for good
+
+ALTER TABLE wanted_movies ADD COLUMN snoozed_until TIMESTAMP NULL;
+ALTER TABLE wanted_movies ADD COLUMN excluded_from_auto_search BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,15 @@
+-- Migration 040: Movie tombstones
+-- Remember movies deleted without an exclusion so import list syncs don't add them straight back
+
+CREATE TABLE IF NOT EXISTS movie_tombstones (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    tmdb_id INT NOT NULL,
+    movie_title VARCHAR(500) NOT NULL,
+    movie_year INT DEFAULT 0,
+    imdb_id VARCHAR(20),
+    deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_movie_tombstones_tmdb_id ON movie_tombstones(tmdb_id);
+CREATE INDEX idx_movie_tombstones_expires_at ON movie_tombstones(expires_at);
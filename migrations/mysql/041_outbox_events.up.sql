@@ -0,0 +1,14 @@
+-- Migration 041: Outbox events
+-- Notification events written with the grab or import they report and delivered at least once
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    event_type VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_events_next_attempt_at ON outbox_events(next_attempt_at);
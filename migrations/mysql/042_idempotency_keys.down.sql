@@ -0,0 +1,3 @@
+-- Migration 042 Down: Remove idempotency keys
+
+DROP TABLE IF EXISTS idempotency_keys;
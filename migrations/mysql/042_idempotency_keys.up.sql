@@ -0,0 +1,18 @@
+-- Migration 042: Idempotency keys
+-- Response snapshots for Idempotency-Key requests, so retried requests are not applied twice
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    idempotency_key VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INT NOT NULL DEFAULT 0,
+    response MEDIUMTEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_idempotency_keys_idempotency_key ON idempotency_keys(idempotency_key);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,3 @@
+-- Migration 043 Down: Remove file fingerprints
+
+DROP TABLE IF EXISTS file_fingerprints;
@@ -0,0 +1,16 @@
+-- Migration 043: File fingerprints
+-- Duration and frame hashes of movie files that may be duplicates, for telling copies apart
+
+CREATE TABLE IF NOT EXISTS file_fingerprints (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    movie_file_id INT NOT NULL,
+    movie_id INT NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    duration DOUBLE NOT NULL DEFAULT 0,
+    frame_hashes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_file_fingerprints_movie_file_id ON file_fingerprints(movie_file_id);
+CREATE INDEX idx_file_fingerprints_movie_id ON file_fingerprints(movie_id);
@@ -0,0 +1,4 @@
+-- Migration 044 Down: Remove file organization priority
+
+DROP INDEX idx_file_organizations_status_priority ON file_organizations;
+ALTER TABLE file_organizations DROP COLUMN priority;
@@ -0,0 +1,5 @@
+-- Migration 044: File organization priority
+-- Files queued while imports are paused are organized highest priority first once imports resume
+
+ALTER TABLE file_organizations ADD COLUMN priority INT NOT NULL DEFAULT 0;
+CREATE INDEX idx_file_organizations_status_priority ON file_organizations(status, priority);
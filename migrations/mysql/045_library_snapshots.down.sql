@@ -0,0 +1,5 @@
+-- Migration 045 Down: Remove library snapshots
+
+DELETE FROM scheduled_tasks WHERE name = 'Library Statistics';
+
+DROP TABLE IF EXISTS library_snapshots;
@@ -0,0 +1,21 @@
+-- Migration 045: Library snapshots
+-- Daily library size, movie counts and quality distribution for charting growth over time
+
+CREATE TABLE IF NOT EXISTS library_snapshots (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    date DATE NOT NULL,
+    movie_count INT NOT NULL DEFAULT 0,
+    monitored_count INT NOT NULL DEFAULT 0,
+    missing_count INT NOT NULL DEFAULT 0,
+    cutoff_unmet_count INT NOT NULL DEFAULT 0,
+    file_count INT NOT NULL DEFAULT 0,
+    size_on_disk BIGINT NOT NULL DEFAULT 0,
+    quality_distribution TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_library_snapshots_date ON library_snapshots(date);
+
+INSERT IGNORE INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Library Statistics', 'RecordLibraryStats', 86400000, 'low', true, DATE_ADD(NOW(), INTERVAL 10 MINUTE)); -- Daily
@@ -0,0 +1,4 @@
+-- Migration 046 Down: Remove release imported at
+-- Nothing to remove, as the up migration changes nothing on MySQL.
+
+SELECT 1;
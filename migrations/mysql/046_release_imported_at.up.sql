@@ -0,0 +1,5 @@
+-- Migration 046: Release imported at
+-- The MySQL schema has no releases table yet, so there is no column to add, nor releases for
+-- the completed download folder scan to import.
+
+SELECT 1;
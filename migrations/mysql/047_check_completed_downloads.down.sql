@@ -0,0 +1,3 @@
+-- Migration 047 Down: Remove the check completed downloads task
+
+DELETE FROM scheduled_tasks WHERE name = 'Check Completed Downloads';
@@ -0,0 +1,3 @@
+-- Migration 048 Down: Drop the root folders table created for MySQL
+
+DROP TABLE IF EXISTS root_folders;
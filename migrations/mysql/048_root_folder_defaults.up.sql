@@ -0,0 +1,22 @@
+-- Migration 048: Root folder defaults
+-- The MySQL schema never had the root folders table, so it's created here with the defaults given
+-- to movies added under a root folder without their own: quality profile, minimum availability
+-- and tags
+
+CREATE TABLE IF NOT EXISTS root_folders (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    path VARCHAR(500) NOT NULL UNIQUE,
+    accessible BOOLEAN DEFAULT TRUE,
+    free_space BIGINT DEFAULT 0,
+    total_space BIGINT DEFAULT 0,
+    unmapped_folders TEXT,
+    default_tags TEXT,
+    default_quality_profile_id INT DEFAULT 0,
+    default_monitor_option VARCHAR(50) DEFAULT 'movieOnly',
+    default_search_for_missing_movie BOOLEAN DEFAULT FALSE,
+    default_minimum_availability VARCHAR(20),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_root_folders_accessible ON root_folders(accessible);
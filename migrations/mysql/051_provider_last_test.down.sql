@@ -0,0 +1,4 @@
+-- Migration 051 Down: Remove provider test results
+-- Nothing to remove, as the up migration changes nothing on MySQL.
+
+SELECT 1;
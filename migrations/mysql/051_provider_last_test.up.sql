@@ -0,0 +1,5 @@
+-- Migration 051: Provider test results
+-- The MySQL schema has no indexer, download client or import list tables yet, so there is
+-- nothing to add the column to.
+
+SELECT 1;
@@ -0,0 +1,8 @@
+-- Migration 012 Down: Remove import list approval workflow columns
+
+DROP INDEX IF EXISTS idx_import_list_movies_list_tmdb;
+DROP INDEX IF EXISTS idx_import_list_movies_approval_status;
+
+ALTER TABLE import_list_movies DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE import_list_movies DROP COLUMN IF EXISTS approval_status;
+ALTER TABLE import_lists DROP COLUMN IF EXISTS requires_approval;
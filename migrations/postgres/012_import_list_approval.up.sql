@@ -0,0 +1,20 @@
+-- Migration 012: Manual approval workflow for import list items
+-- Lists can require approval before discovered movies are added to the library
+
+ALTER TABLE import_lists ADD COLUMN IF NOT EXISTS requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE import_list_movies ADD COLUMN IF NOT EXISTS approval_status VARCHAR(20) NOT NULL DEFAULT 'pending';
+ALTER TABLE import_list_movies ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- Earlier syncs stored a row per run; keep the most recent discovery of each movie per list
+DELETE FROM import_list_movies a
+    USING import_list_movies b
+    WHERE a.import_list_id = b.import_list_id
+      AND a.tmdb_id = b.tmdb_id
+      AND a.id < b.id;
+
+CREATE INDEX IF NOT EXISTS idx_import_list_movies_approval_status ON import_list_movies(approval_status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_import_list_movies_list_tmdb ON import_list_movies(import_list_id, tmdb_id);
+
+COMMENT ON COLUMN import_lists.requires_approval IS 'Discovered movies wait for manual approval before being added';
+COMMENT ON COLUMN import_list_movies.approval_status IS 'Review state: pending, approved, or rejected';
@@ -0,0 +1,6 @@
+-- Migration 013: Weekly library maintenance scan
+-- Reports inconsistencies between the library database and the files on disk
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Library Maintenance', 'LibraryMaintenance', 604800000, 'low', true, NOW() + INTERVAL '7 days') -- Weekly
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,7 @@
+-- Migration 014 Down: Remove listing performance indexes
+
+DROP INDEX IF EXISTS idx_wanted_movies_status_priority_created;
+DROP INDEX IF EXISTS idx_movies_movie_file_id;
+DROP INDEX IF EXISTS idx_movies_added;
+DROP INDEX IF EXISTS idx_movies_digital_release;
+DROP INDEX IF EXISTS idx_movies_monitored_has_file;
@@ -0,0 +1,14 @@
+-- Migration 014: Composite indexes for the movie, calendar, and wanted listings
+
+-- Monitored/has-file filters used by movie listings and wanted refresh
+CREATE INDEX IF NOT EXISTS idx_movies_monitored_has_file ON movies(monitored, has_file);
+
+-- Calendar date range lookups (in_cinemas and physical_release are indexed in 001)
+CREATE INDEX IF NOT EXISTS idx_movies_digital_release ON movies(digital_release);
+CREATE INDEX IF NOT EXISTS idx_movies_added ON movies(added);
+
+-- Movie file join when listing movies
+CREATE INDEX IF NOT EXISTS idx_movies_movie_file_id ON movies(movie_file_id);
+
+-- Default wanted ordering within a status
+CREATE INDEX IF NOT EXISTS idx_wanted_movies_status_priority_created ON wanted_movies(status, priority DESC, created_at DESC);
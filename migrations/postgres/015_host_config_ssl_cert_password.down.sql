@@ -0,0 +1,3 @@
+-- Migration 015 Down: Remove PKCS#12 certificate password
+
+ALTER TABLE host_config DROP COLUMN IF EXISTS ssl_cert_password;
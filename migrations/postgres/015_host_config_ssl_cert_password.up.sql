@@ -0,0 +1,3 @@
+-- Migration 015: Password for PKCS#12 (.pfx) SSL certificates
+
+ALTER TABLE host_config ADD COLUMN IF NOT EXISTS ssl_cert_password VARCHAR(500) DEFAULT '';
@@ -0,0 +1,12 @@
+-- Migration 017 Down: Remove runtime, connection pool, and API latency percentile metrics
+
+ALTER TABLE performance_metrics
+    DROP COLUMN IF EXISTS api_latency_p50_ms,
+    DROP COLUMN IF EXISTS api_latency_p95_ms,
+    DROP COLUMN IF EXISTS api_latency_p99_ms,
+    DROP COLUMN IF EXISTS api_request_count,
+    DROP COLUMN IF EXISTS db_open_connections,
+    DROP COLUMN IF EXISTS db_wait_count,
+    DROP COLUMN IF EXISTS goroutines,
+    DROP COLUMN IF EXISTS gc_count,
+    DROP COLUMN IF EXISTS gc_pause_ms;
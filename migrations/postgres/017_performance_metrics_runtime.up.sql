@@ -0,0 +1,12 @@
+-- Migration 017: Runtime, connection pool, and API latency percentile metrics
+
+ALTER TABLE performance_metrics
+    ADD COLUMN IF NOT EXISTS api_latency_p50_ms FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS api_latency_p95_ms FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS api_latency_p99_ms FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS api_request_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS db_open_connections INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS db_wait_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS goroutines INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS gc_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS gc_pause_ms FLOAT NOT NULL DEFAULT 0;
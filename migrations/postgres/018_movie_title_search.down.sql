@@ -0,0 +1,7 @@
+-- Migration 018 Down: Remove fuzzy movie title search support
+
+DROP INDEX IF EXISTS idx_movies_title_trgm;
+DROP INDEX IF EXISTS idx_movies_original_title_trgm;
+
+-- The pg_trgm extension is left installed since other database objects may use it
+ALTER TABLE movies DROP COLUMN IF EXISTS alternate_titles;
@@ -0,0 +1,24 @@
+-- Migration 018: Alternate titles and trigram indexes for fuzzy movie title search
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS alternate_titles TEXT DEFAULT '[]';
+
+-- pg_trgm needs CREATE privilege on the database; without it search ranks titles in memory
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+EXCEPTION
+    WHEN insufficient_privilege OR feature_not_supported OR undefined_file THEN
+        RAISE NOTICE 'pg_trgm unavailable, movie search falls back to in-memory ranking';
+END
+$$;
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+        CREATE INDEX IF NOT EXISTS idx_movies_title_trgm
+            ON movies USING GIN (lower(title) gin_trgm_ops);
+        CREATE INDEX IF NOT EXISTS idx_movies_original_title_trgm
+            ON movies USING GIN (lower(original_title) gin_trgm_ops);
+    END IF;
+END
+$$;
@@ -0,0 +1,6 @@
+-- Migration 019 Down: Remove full-text search over movie text
+
+DROP INDEX IF EXISTS idx_movies_search_vector;
+ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE movies DROP COLUMN IF EXISTS crew_members;
+ALTER TABLE movies DROP COLUMN IF EXISTS cast_members;
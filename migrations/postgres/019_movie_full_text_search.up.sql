@@ -0,0 +1,14 @@
+-- Migration 019: Cast and crew names and a full-text search vector over movie text
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS cast_members TEXT DEFAULT '[]';
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS crew_members TEXT DEFAULT '[]';
+
+-- Titles rank above the overview, which ranks above credits
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english',
+        coalesce(title, '') || ' ' || coalesce(original_title, '') || ' ' || coalesce(alternate_titles, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(overview, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(cast_members, '') || ' ' || coalesce(crew_members, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING GIN (search_vector);
@@ -0,0 +1,4 @@
+-- Migration 020 Down: Remove saved custom filters
+
+DROP INDEX IF EXISTS idx_custom_filters_type;
+DROP TABLE IF EXISTS custom_filters;
@@ -0,0 +1,13 @@
+-- Migration 020: Saved custom filters
+-- Named predicate lists applied server-side with GET /api/v3/movie?filterId=
+
+CREATE TABLE IF NOT EXISTS custom_filters (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL DEFAULT 'movieIndex',
+    label VARCHAR(255) NOT NULL,
+    filters TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_filters_type ON custom_filters(type);
@@ -0,0 +1,7 @@
+-- Migration 021 Down: Remove notification delivery retry tracking
+
+DROP INDEX IF EXISTS idx_notification_history_status;
+ALTER TABLE notification_history DROP COLUMN IF EXISTS payload;
+ALTER TABLE notification_history DROP COLUMN IF EXISTS last_attempt_at;
+ALTER TABLE notification_history DROP COLUMN IF EXISTS attempts;
+ALTER TABLE notification_history DROP COLUMN IF EXISTS status;
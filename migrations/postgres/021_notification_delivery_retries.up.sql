@@ -0,0 +1,12 @@
+-- Migration 021: Notification delivery retries
+-- Tracks the attempts of each delivery and keeps the message of failed deliveries for manual retry
+
+ALTER TABLE notification_history ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'delivered';
+ALTER TABLE notification_history ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE notification_history ADD COLUMN IF NOT EXISTS last_attempt_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notification_history ADD COLUMN IF NOT EXISTS payload TEXT;
+
+-- Earlier failures were recorded per attempt without their message; they are listed but cannot be retried
+UPDATE notification_history SET status = 'failed' WHERE successful = FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_notification_history_status ON notification_history(status);
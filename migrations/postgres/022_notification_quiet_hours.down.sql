@@ -0,0 +1,6 @@
+-- Migration 022 Down: Remove notification quiet hours and digests
+
+DELETE FROM scheduled_tasks WHERE name = 'Notification Queue';
+
+DROP INDEX IF EXISTS idx_notification_history_deliver_after;
+ALTER TABLE notification_history DROP COLUMN IF EXISTS deliver_after;
@@ -0,0 +1,10 @@
+-- Migration 022: Notification quiet hours and digests
+-- Queued deliveries wait in the notification history until they are due
+
+ALTER TABLE notification_history ADD COLUMN IF NOT EXISTS deliver_after TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_notification_history_deliver_after ON notification_history(deliver_after);
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Notification Queue', 'FlushNotifications', 60000, 'normal', true, NOW() + INTERVAL '1 minute') -- Every minute
+ON CONFLICT (name) DO NOTHING;
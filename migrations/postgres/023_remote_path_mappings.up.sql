@@ -0,0 +1,13 @@
+-- Migration 023: Remote path mappings
+-- Translate the paths download clients report into paths Radarr can reach
+
+CREATE TABLE IF NOT EXISTS remote_path_mappings (
+    id SERIAL PRIMARY KEY,
+    host VARCHAR(255) NOT NULL,
+    remote_path VARCHAR(500) NOT NULL,
+    local_path VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_remote_path_mappings_host ON remote_path_mappings(host);
@@ -0,0 +1,3 @@
+-- Migration 024 Down: Remove indexer VIP expiration
+
+ALTER TABLE indexers DROP COLUMN IF EXISTS vip_expiration;
@@ -0,0 +1,4 @@
+-- Migration 024: Indexer VIP expiration
+-- Optional date an indexer's VIP status or API key expires, reported by the indexer VIP health check
+
+ALTER TABLE indexers ADD COLUMN IF NOT EXISTS vip_expiration TIMESTAMP WITH TIME ZONE;
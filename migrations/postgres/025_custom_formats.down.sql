@@ -0,0 +1,4 @@
+-- Migration 025 Down: Remove custom formats
+
+DROP INDEX IF EXISTS idx_custom_formats_trash_id;
+DROP TABLE IF EXISTS custom_formats;
@@ -0,0 +1,14 @@
+-- Migration 025: Custom formats
+-- Custom format storage, with the TRaSH-Guides id of formats imported from the guides
+
+CREATE TABLE IF NOT EXISTS custom_formats (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    include_custom_format_when_renaming BOOLEAN DEFAULT FALSE,
+    specifications TEXT NOT NULL DEFAULT '[]',
+    trash_id VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_formats_trash_id ON custom_formats(trash_id);
@@ -0,0 +1,5 @@
+-- Migration 026 Down: Clear preferred sizes still at the default
+
+UPDATE quality_definitions
+SET preferred_size = NULL
+WHERE max_size > 0 AND preferred_size = LEAST(95, max_size);
@@ -0,0 +1,6 @@
+-- Migration 026: Quality definition preferred size
+-- Give seeded quality definitions the default preferred size, capped at their maximum
+
+UPDATE quality_definitions
+SET preferred_size = LEAST(95, max_size)
+WHERE preferred_size IS NULL AND max_size > 0;
@@ -0,0 +1,4 @@
+-- Migration 027 Down: Remove editions
+
+ALTER TABLE media_management_config DROP COLUMN IF EXISTS allow_edition_change_on_upgrade;
+ALTER TABLE releases DROP COLUMN IF EXISTS edition;
@@ -0,0 +1,7 @@
+-- Migration 027: Editions
+-- Store the edition parsed from release names, and whether upgrades may change a file's edition
+
+ALTER TABLE releases ADD COLUMN IF NOT EXISTS edition VARCHAR(255);
+
+ALTER TABLE media_management_config
+    ADD COLUMN IF NOT EXISTS allow_edition_change_on_upgrade BOOLEAN DEFAULT FALSE;
@@ -0,0 +1,7 @@
+-- Migration 028 Down: Remove scene mappings
+
+DELETE FROM scheduled_tasks WHERE name = 'Scene Mappings';
+
+DROP INDEX IF EXISTS idx_scene_mappings_tmdb_id;
+DROP INDEX IF EXISTS idx_scene_mappings_clean_title;
+DROP TABLE IF EXISTS scene_mappings;
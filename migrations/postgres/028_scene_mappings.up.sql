@@ -0,0 +1,20 @@
+-- Migration 028: Scene mappings
+-- Release titles that don't match the TMDB title, managed through the API or refreshed from a remote list
+
+CREATE TABLE IF NOT EXISTS scene_mappings (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    clean_title VARCHAR(500) NOT NULL,
+    tmdb_id INTEGER NOT NULL,
+    year INTEGER DEFAULT 0,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scene_mappings_clean_title ON scene_mappings(clean_title);
+CREATE INDEX IF NOT EXISTS idx_scene_mappings_tmdb_id ON scene_mappings(tmdb_id);
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Scene Mappings', 'RefreshSceneMappings', 43200000, 'low', true, NOW() + INTERVAL '5 minutes') -- Every 12 hours
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Migration 030 Down: Remove instance name
+
+ALTER TABLE host_config DROP COLUMN IF EXISTS instance_name;
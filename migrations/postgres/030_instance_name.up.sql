@@ -0,0 +1,4 @@
+-- Migration 030: Instance name
+-- Tells notifications, calendars and status reports from several instances apart
+
+ALTER TABLE host_config ADD COLUMN IF NOT EXISTS instance_name VARCHAR(100) DEFAULT 'Radarr';
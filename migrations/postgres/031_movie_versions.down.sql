@@ -0,0 +1,11 @@
+-- Migration 031 Down: Remove movie versions
+
+DELETE FROM wanted_movies WHERE version_id <> 0;
+DROP INDEX IF EXISTS uq_wanted_movies_movie_version;
+ALTER TABLE wanted_movies ADD CONSTRAINT uq_wanted_movies_movie_id UNIQUE (movie_id);
+ALTER TABLE wanted_movies DROP COLUMN IF EXISTS version_id;
+
+DELETE FROM movie_files WHERE version_id <> 0;
+ALTER TABLE movie_files DROP COLUMN IF EXISTS version_id;
+
+DROP TABLE IF EXISTS movie_versions;
@@ -0,0 +1,24 @@
+-- Migration 031: Movie versions
+-- Keep additional quality versions of a movie, each with its own quality profile and folder
+
+CREATE TABLE IF NOT EXISTS movie_versions (
+    id SERIAL PRIMARY KEY,
+    movie_id INTEGER NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    quality_profile_id INTEGER NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    monitored BOOLEAN DEFAULT TRUE,
+    has_file BOOLEAN DEFAULT FALSE,
+    movie_file_id INTEGER NULL REFERENCES movie_files(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_versions_movie_id ON movie_versions(movie_id);
+
+-- Files and wanted rows belong to a version, 0 being the movie's primary file
+ALTER TABLE movie_files ADD COLUMN IF NOT EXISTS version_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE wanted_movies ADD COLUMN IF NOT EXISTS version_id INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE wanted_movies DROP CONSTRAINT IF EXISTS uq_wanted_movies_movie_id;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_wanted_movies_movie_version ON wanted_movies(movie_id, version_id);
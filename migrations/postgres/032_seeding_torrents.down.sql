@@ -0,0 +1,8 @@
+-- Migration 032 Down: Remove seeding torrents
+
+DELETE FROM scheduled_tasks WHERE name = 'Seeding Cleanup';
+
+DROP INDEX IF EXISTS idx_seeding_torrents_movie_id;
+DROP INDEX IF EXISTS idx_seeding_torrents_status;
+DROP INDEX IF EXISTS uq_seeding_torrents_client_download;
+DROP TABLE IF EXISTS seeding_torrents;
@@ -0,0 +1,27 @@
+-- Migration 032: Seeding torrents
+-- Track imported torrents while they seed, and remove them once their seed goals are met
+
+CREATE TABLE IF NOT EXISTS seeding_torrents (
+    id SERIAL PRIMARY KEY,
+    download_client_id INTEGER NOT NULL,
+    indexer_id INTEGER NULL,
+    movie_id INTEGER DEFAULT 0,
+    download_id VARCHAR(255) NOT NULL,
+    title VARCHAR(500),
+    status VARCHAR(20) NOT NULL DEFAULT 'seeding',
+    ratio DOUBLE PRECISION DEFAULT 0,
+    seeding_time BIGINT DEFAULT 0,
+    imported_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_checked_at TIMESTAMP WITH TIME ZONE NULL,
+    removed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_seeding_torrents_client_download ON seeding_torrents(download_client_id, download_id);
+CREATE INDEX IF NOT EXISTS idx_seeding_torrents_status ON seeding_torrents(status);
+CREATE INDEX IF NOT EXISTS idx_seeding_torrents_movie_id ON seeding_torrents(movie_id);
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Seeding Cleanup', 'CleanupSeededTorrents', 1800000, 'low', true, NOW() + INTERVAL '10 minutes') -- Every 30 minutes
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Migration 033 Down: Remove keep original files
+
+ALTER TABLE media_management_config DROP COLUMN IF EXISTS keep_original_files;
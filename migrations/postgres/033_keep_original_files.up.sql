@@ -0,0 +1,4 @@
+-- Migration 033: Keep original files
+-- Leave imported files in the download folder so they keep seeding and can be cross-seeded
+
+ALTER TABLE media_management_config ADD COLUMN IF NOT EXISTS keep_original_files BOOLEAN DEFAULT FALSE;
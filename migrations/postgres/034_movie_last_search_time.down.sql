@@ -0,0 +1,4 @@
+-- Migration 034 Down: Remove movie last search time
+
+DROP INDEX IF EXISTS idx_movies_last_search_time;
+ALTER TABLE movies DROP COLUMN IF EXISTS last_search_time;
@@ -0,0 +1,15 @@
+-- Migration 034: Movie last search time
+-- When releases were last searched for each movie, seeded from the wanted movies searches
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS last_search_time TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_movies_last_search_time ON movies(last_search_time);
+
+UPDATE movies SET last_search_time = searched.last_search_time
+FROM (
+    SELECT movie_id, MAX(last_search_time) AS last_search_time
+    FROM wanted_movies
+    WHERE last_search_time IS NOT NULL
+    GROUP BY movie_id
+) searched
+WHERE movies.id = searched.movie_id;
@@ -0,0 +1,7 @@
+-- Migration 035: Wanted search tasks
+-- Scheduled searches of all missing and cutoff unmet movies, disabled by default
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Missing Movies Search', 'MissingMoviesSearch', 21600000, 'low', false, NOW() + INTERVAL '6 hours'), -- Every 6 hours (disabled by default)
+    ('Cutoff Unmet Movies Search', 'CutoffUnmetMoviesSearch', 86400000, 'low', false, NOW() + INTERVAL '1 day') -- Daily (disabled by default)
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Migration 036 Down: Remove task runs
+
+DROP INDEX IF EXISTS idx_task_runs_started_at;
+DROP INDEX IF EXISTS idx_task_runs_command_started;
+DROP TABLE IF EXISTS task_runs;
@@ -0,0 +1,19 @@
+-- Migration 036: Task runs
+-- Finished task executions with their outcome and reported counts, kept apart from the task queue
+
+CREATE TABLE IF NOT EXISTS task_runs (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    command_name VARCHAR(255) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    metrics TEXT,
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_runs_command_started ON task_runs(command_name, started_at);
+CREATE INDEX IF NOT EXISTS idx_task_runs_started_at ON task_runs(started_at);
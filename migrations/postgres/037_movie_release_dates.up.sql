@@ -0,0 +1,4 @@
+-- Migration 037: Movie release dates by country
+-- Certification and cinema, digital and physical release dates of each movie in every country
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS release_dates TEXT DEFAULT '[]';
@@ -0,0 +1,3 @@
+-- Migration 038 Down: Remove movie watch providers
+
+ALTER TABLE movies DROP COLUMN IF EXISTS watch_providers;
@@ -0,0 +1,3 @@
+-- Migration 040 Down: Remove movie tombstones
+
+DROP TABLE IF EXISTS movie_tombstones;
@@ -0,0 +1,15 @@
+-- Migration 040: Movie tombstones
+-- Remember movies deleted without an exclusion so import list syncs don't add them straight back
+
+CREATE TABLE IF NOT EXISTS movie_tombstones (
+    id SERIAL PRIMARY KEY,
+    tmdb_id INTEGER NOT NULL,
+    movie_title VARCHAR(500) NOT NULL,
+    movie_year INTEGER DEFAULT 0,
+    imdb_id VARCHAR(20),
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_movie_tombstones_tmdb_id ON movie_tombstones(tmdb_id);
+CREATE INDEX IF NOT EXISTS idx_movie_tombstones_expires_at ON movie_tombstones(expires_at);
@@ -0,0 +1,3 @@
+-- Migration 041 Down: Remove outbox events
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,16 @@
+-- Migration 043: File fingerprints
+-- Duration and frame hashes of movie files that may be duplicates, for telling copies apart
+
+CREATE TABLE IF NOT EXISTS file_fingerprints (
+    id SERIAL PRIMARY KEY,
+    movie_file_id INTEGER NOT NULL,
+    movie_id INTEGER NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    duration DOUBLE PRECISION NOT NULL DEFAULT 0,
+    frame_hashes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_file_fingerprints_movie_file_id ON file_fingerprints(movie_file_id);
+CREATE INDEX IF NOT EXISTS idx_file_fingerprints_movie_id ON file_fingerprints(movie_id);
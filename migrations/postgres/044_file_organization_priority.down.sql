@@ -0,0 +1,4 @@
+-- Migration 044 Down: Remove file organization priority
+
+DROP INDEX IF EXISTS idx_file_organizations_status_priority;
+ALTER TABLE file_organizations DROP COLUMN IF EXISTS priority;
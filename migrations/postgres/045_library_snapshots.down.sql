@@ -0,0 +1,6 @@
+-- Migration 045 Down: Remove library snapshots
+
+DELETE FROM scheduled_tasks WHERE name = 'Library Statistics';
+
+DROP INDEX IF EXISTS idx_library_snapshots_date;
+DROP TABLE IF EXISTS library_snapshots;
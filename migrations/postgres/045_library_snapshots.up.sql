@@ -0,0 +1,22 @@
+-- Migration 045: Library snapshots
+-- Daily library size, movie counts and quality distribution for charting growth over time
+
+CREATE TABLE IF NOT EXISTS library_snapshots (
+    id SERIAL PRIMARY KEY,
+    date DATE NOT NULL,
+    movie_count INTEGER NOT NULL DEFAULT 0,
+    monitored_count INTEGER NOT NULL DEFAULT 0,
+    missing_count INTEGER NOT NULL DEFAULT 0,
+    cutoff_unmet_count INTEGER NOT NULL DEFAULT 0,
+    file_count INTEGER NOT NULL DEFAULT 0,
+    size_on_disk BIGINT NOT NULL DEFAULT 0,
+    quality_distribution TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_library_snapshots_date ON library_snapshots(date);
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Library Statistics', 'RecordLibraryStats', 86400000, 'low', true, NOW() + INTERVAL '10 minutes') -- Daily
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Migration 046 Down: Remove release imported at
+
+DELETE FROM scheduled_tasks WHERE name = 'Completed Download Folders';
+
+ALTER TABLE releases DROP COLUMN IF EXISTS imported_at;
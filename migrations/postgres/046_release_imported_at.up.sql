@@ -0,0 +1,9 @@
+-- Migration 046: Release imported at
+-- When a grab was imported from its download client's completed download folder, so a folder
+-- entry is only imported once
+
+ALTER TABLE releases ADD COLUMN IF NOT EXISTS imported_at TIMESTAMP WITH TIME ZONE;
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Completed Download Folders', 'ScanCompletedDownloadFolders', 60000, 'normal', true, NOW() + INTERVAL '1 minute') -- Every minute
+ON CONFLICT (name) DO NOTHING;
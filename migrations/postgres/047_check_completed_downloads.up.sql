@@ -0,0 +1,6 @@
+-- Migration 047: Check completed downloads
+-- Completed downloads are checked regularly so the queue explains why they can't be imported
+
+INSERT INTO scheduled_tasks (name, command_name, interval_ms, priority, enabled, next_run) VALUES
+    ('Check Completed Downloads', 'CheckCompletedDownloads', 300000, 'low', true, NOW() + INTERVAL '5 minutes') -- Every 5 minutes
+ON CONFLICT (name) DO NOTHING;
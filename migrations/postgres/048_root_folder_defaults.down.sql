@@ -0,0 +1,3 @@
+-- Migration 048 Down: Remove the root folder default minimum availability
+
+ALTER TABLE root_folders DROP COLUMN IF EXISTS default_minimum_availability;
@@ -0,0 +1,5 @@
+-- Migration 048: Root folder defaults
+-- The minimum availability given to movies added under a root folder without one, alongside its
+-- default quality profile and tags
+
+ALTER TABLE root_folders ADD COLUMN IF NOT EXISTS default_minimum_availability VARCHAR(20);
@@ -0,0 +1,5 @@
+-- Migration 049 Down: Remove download client bandwidth metrics
+
+ALTER TABLE performance_metrics
+    DROP COLUMN IF EXISTS download_speed,
+    DROP COLUMN IF EXISTS upload_speed;
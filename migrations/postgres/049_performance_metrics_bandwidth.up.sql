@@ -0,0 +1,5 @@
+-- Migration 049: Download client bandwidth metrics
+
+ALTER TABLE performance_metrics
+    ADD COLUMN IF NOT EXISTS download_speed BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS upload_speed BIGINT NOT NULL DEFAULT 0;
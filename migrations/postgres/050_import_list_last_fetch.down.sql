@@ -0,0 +1,3 @@
+-- Migration 050 Down: Remove import list fetch caching
+
+ALTER TABLE import_lists DROP COLUMN IF EXISTS last_fetch;
@@ -0,0 +1,7 @@
+-- Migration 050: Import list fetch caching
+-- The last fetch of a remote list and its ETag and Last-Modified validators, so syncs of an
+-- unchanged list can be skipped
+
+ALTER TABLE import_lists ADD COLUMN IF NOT EXISTS last_fetch TEXT;
+
+COMMENT ON COLUMN import_lists.last_fetch IS 'Last fetch of the remote list with its HTTP caching validators (JSON)';
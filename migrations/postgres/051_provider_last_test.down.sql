@@ -0,0 +1,5 @@
+-- Migration 051 Down: Remove provider test results
+
+ALTER TABLE import_lists DROP COLUMN IF EXISTS last_test;
+ALTER TABLE download_clients DROP COLUMN IF EXISTS last_test;
+ALTER TABLE indexers DROP COLUMN IF EXISTS last_test;
@@ -0,0 +1,11 @@
+-- Migration 051: Provider test results
+-- The outcome of the last connection test of each indexer, download client and import list, with
+-- the categorized failures of the stages that failed
+
+ALTER TABLE indexers ADD COLUMN IF NOT EXISTS last_test TEXT;
+ALTER TABLE download_clients ADD COLUMN IF NOT EXISTS last_test TEXT;
+ALTER TABLE import_lists ADD COLUMN IF NOT EXISTS last_test TEXT;
+
+COMMENT ON COLUMN indexers.last_test IS 'Outcome of the last connection test (JSON)';
+COMMENT ON COLUMN download_clients.last_test IS 'Outcome of the last connection test (JSON)';
+COMMENT ON COLUMN import_lists.last_test IS 'Outcome of the last connection test (JSON)';
@@ -0,0 +1,3 @@
+-- Migration 052 Down: Restore the previous name for ignored downloads
+
+UPDATE history SET event_type = 'ignoredDownload' WHERE event_type = 'downloadIgnored';
@@ -0,0 +1,4 @@
+-- Migration 052: Radarr's name for ignored downloads
+-- History recorded ignored downloads as ignoredDownload; Radarr's API calls the event downloadIgnored
+
+UPDATE history SET event_type = 'downloadIgnored' WHERE event_type = 'ignoredDownload';
@@ -0,0 +1,16 @@
+// Package web embeds the frontend build in web/static, which the API server hosts
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed static
+var static embed.FS
+
+// UI returns the frontend build, with index.html at its root
+func UI() fs.FS {
+	ui, _ := fs.Sub(static, "static") //nolint:errcheck // fails only for invalid directory names
+	return ui
+}